@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Output formats of the -o flag.
+var formats = []string{"table", "json", "csv"}
+
+// errUsage is returned for a command line that cannot be run; the usage of
+// the command has already been printed.
+var errUsage = errors.New("usage")
+
+// usageError is a command line that cannot be run for the reason it gives.
+// It is errUsage.
+type usageError struct{ reason string }
+
+func usagef(format string, args ...interface{}) error {
+	return usageError{reason: fmt.Sprintf(format, args...)}
+}
+
+func (e usageError) Error() string { return e.reason }
+
+func (e usageError) Is(target error) bool { return target == errUsage }
+
+// options are the global flags.
+type options struct {
+	api, dsn, actor string
+	// format is the output format, one of formats.
+	format string
+}
+
+// app is the state shared by the commands.
+type app struct {
+	out    io.Writer
+	errOut io.Writer
+	opts   *options
+	// connect returns the client of the API, called on first use.
+	connect func() (*client, error)
+	api     *client
+}
+
+// client returns the client of the API, connecting it on first use.
+func (a *app) client() (*client, error) {
+	if a.api == nil {
+		c, err := a.connect()
+		if err != nil {
+			return nil, err
+		}
+		a.api = c
+	}
+	return a.api, nil
+}
+
+// command is a command of apigoctl: either a group of subcommands, or an
+// action run with the arguments left once its flags are parsed.
+type command struct {
+	name    string
+	args    string
+	summary string
+	sub     []*command
+	// flags defines the flags of the command on fs and returns its action.
+	flags func(fs *flag.FlagSet) action
+}
+
+type action func(ctx context.Context, a *app, args []string) error
+
+// find returns the subcommand of c called name, or nil.
+func (c *command) find(name string) *command {
+	for _, s := range c.sub {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// flagSet returns the flags of c, writing its usage to w, and its action.
+func (c *command) flagSet(path string, w io.Writer) (*flag.FlagSet, action) {
+	fs := flag.NewFlagSet(path, flag.ContinueOnError)
+	fs.SetOutput(w)
+	var act action
+	if c.flags != nil {
+		act = c.flags(fs)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(w, "Usage: %s", path)
+		if hasFlags(fs) {
+			fmt.Fprint(w, " [flags]")
+		}
+		if c.args != "" {
+			fmt.Fprint(w, " "+c.args)
+		}
+		fmt.Fprintf(w, "\n\n%s\n", c.summary)
+		if len(c.sub) > 0 {
+			fmt.Fprint(w, "\nCommands:\n")
+			for _, s := range c.sub {
+				if s.name != completeCommand {
+					fmt.Fprintf(w, "  %-16s %s\n", s.name, s.summary)
+				}
+			}
+		}
+		if hasFlags(fs) {
+			fmt.Fprint(w, "\nFlags:\n")
+			fs.PrintDefaults()
+		}
+	}
+	return fs, act
+}
+
+func hasFlags(fs *flag.FlagSet) bool {
+	has := false
+	fs.VisitAll(func(*flag.Flag) { has = true })
+	return has
+}
+
+// execute runs the command of args below c, called path.
+func (a *app) execute(ctx context.Context, c *command, path string, args []string) error {
+	fs, act := c.flagSet(path, a.errOut)
+	var err error
+	if len(c.sub) > 0 {
+		// The flags of a group come before its subcommand.
+		err = fs.Parse(args)
+		args = fs.Args()
+	} else {
+		args, err = parseInterspersed(fs, args)
+	}
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+
+	if len(c.sub) > 0 {
+		if len(args) == 0 {
+			fs.Usage()
+			return errUsage
+		}
+		s := c.find(args[0])
+		if s == nil {
+			fmt.Fprintf(a.errOut, "%s: unknown command %q\n\n", path, args[0])
+			fs.Usage()
+			return errUsage
+		}
+		return a.execute(ctx, s, path+" "+s.name, args[1:])
+	}
+	if err := act(ctx, a, args); err != nil {
+		var reason usageError
+		if errors.As(err, &reason) {
+			fmt.Fprintf(a.errOut, "%s: %s\n\n", path, reason.reason)
+		}
+		if errors.Is(err, errUsage) {
+			fs.Usage()
+		}
+		return err
+	}
+	return nil
+}
+
+// localBase is the URL of the API served in the process with -dsn.
+const localBase = "http://apigoctl.local"
+
+// parseInterspersed parses the flags of args on fs, which may come before,
+// between or after the other arguments until "--", and returns the others.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, rest = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// run runs apigoctl with args and returns its exit status: 0 on success, 2
+// for a command line that cannot be run and 1 for any other error.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	opts := &options{}
+	root := rootCommand(opts)
+	a := &app{out: stdout, errOut: stderr, opts: opts}
+
+	var db *sql.DB
+	a.connect = func() (*client, error) {
+		if opts.dsn == "" {
+			return newClient(opts.api, http.DefaultClient, opts.actor), nil
+		}
+		var err error
+		if db, err = sql.Open("mysql", opts.dsn); err != nil {
+			return nil, err
+		}
+		if err := db.PingContext(ctx); err != nil {
+			return nil, err
+		}
+		retention, err := retentionEnv()
+		if err != nil {
+			return nil, err
+		}
+		return newClient(localBase, &http.Client{Transport: handlerTransport{localHandler(db, retention)}}, opts.actor), nil
+	}
+
+	err := a.execute(ctx, root, root.name, args)
+	if db != nil {
+		db.Close()
+	}
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	}
+	fmt.Fprintf(stderr, "%s: %v\n", root.name, err)
+	return 1
+}
+
+// envOr returns the environment variable name, or def when it is not set.
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v2 "github.com/davidop97/apiGo/cmd/server/handler/v2"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/web"
+)
+
+// client calls the /api/v2 routes of the API at base.
+type client struct {
+	base  string
+	http  *http.Client
+	actor string
+}
+
+func newClient(base string, hc *http.Client, actor string) *client {
+	return &client{base: strings.TrimRight(base, "/") + "/api/v2", http: hc, actor: actor}
+}
+
+// apiError is an error response of the API.
+type apiError struct {
+	status int
+	web.ErrorResponse
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (%d %s)", e.Message, e.status, e.Code)
+}
+
+// importError is the response to an import whose rows are invalid; nothing
+// was saved.
+type importError v2.ImportErrorResponse
+
+func (e *importError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Message)
+	for _, row := range e.Rows {
+		fmt.Fprintf(&b, "\n  line %d: %s", row.Line, strings.Join(row.Errors, "; "))
+	}
+	return b.String()
+}
+
+// do sends a request to path below the API and decodes the data of its
+// envelope into data, unless it is nil.
+func (c *client) do(ctx context.Context, method, path string, body io.Reader, contentType string, data interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(web.HeaderActor, c.actor)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= 400 {
+		var rowsErr importError
+		if json.Unmarshal(raw, &rowsErr) == nil && len(rowsErr.Rows) > 0 {
+			return &rowsErr
+		}
+		apiErr := &apiError{status: res.StatusCode}
+		if json.Unmarshal(raw, &apiErr.ErrorResponse) != nil || apiErr.Message == "" {
+			apiErr.Code, apiErr.Message = "unexpected_response", http.StatusText(res.StatusCode)
+		}
+		return apiErr
+	}
+	if data == nil {
+		return nil
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("decoding the response of %s %s: %w", method, path, err)
+	}
+	return json.Unmarshal(envelope.Data, data)
+}
+
+// sendJSON sends v as the JSON body of a request.
+func (c *client) sendJSON(ctx context.Context, method, path string, v, data interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, method, path, bytes.NewReader(b), "application/json", data)
+}
+
+func (c *client) products(ctx context.Context, includeDeleted bool) ([]v2.ProductResponse, error) {
+	path := "/products"
+	if includeDeleted {
+		path += "?include_deleted=true"
+	}
+	var products []v2.ProductResponse
+	err := c.do(ctx, http.MethodGet, path, nil, "", &products)
+	return products, err
+}
+
+func (c *client) product(ctx context.Context, id int) (v2.ProductResponse, error) {
+	var p v2.ProductResponse
+	err := c.do(ctx, http.MethodGet, "/products/"+strconv.Itoa(id), nil, "", &p)
+	return p, err
+}
+
+func (c *client) createProduct(ctx context.Context, b body) (v2.ProductResponse, error) {
+	var p v2.ProductResponse
+	err := c.sendJSON(ctx, http.MethodPost, "/products", b, &p)
+	return p, err
+}
+
+func (c *client) updateProduct(ctx context.Context, id int, patch body) (v2.ProductResponse, error) {
+	var p v2.ProductResponse
+	err := c.sendJSON(ctx, http.MethodPatch, "/products/"+strconv.Itoa(id), patch, &p)
+	return p, err
+}
+
+func (c *client) deleteProduct(ctx context.Context, id int) error {
+	return c.do(ctx, http.MethodDelete, "/products/"+strconv.Itoa(id), nil, "", nil)
+}
+
+// sectionReports returns the product count of the section id, or of every
+// section when id is 0.
+func (c *client) sectionReports(ctx context.Context, id int) ([]section.ProdCountResponse, error) {
+	var reports []section.ProdCountResponse
+	if id == 0 {
+		err := c.do(ctx, http.MethodGet, "/sections/product-reports", nil, "", &reports)
+		return reports, err
+	}
+	var report section.ProdCountResponse
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/sections/%d/product-report", id), nil, "", &report)
+	return append(reports, report), err
+}
+
+// sellerReports returns the seller count of the locality id, or of every
+// locality when id is 0.
+func (c *client) sellerReports(ctx context.Context, id int) ([]domain.ReportSellers, error) {
+	var reports []domain.ReportSellers
+	if id == 0 {
+		err := c.do(ctx, http.MethodGet, "/localities/seller-reports", nil, "", &reports)
+		return reports, err
+	}
+	var report domain.ReportSellers
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/localities/%d/seller-report", id), nil, "", &report)
+	return append(reports, report), err
+}
+
+func (c *client) batches(ctx context.Context) ([]domain.ProductBatch, error) {
+	var batches []domain.ProductBatch
+	err := c.do(ctx, http.MethodGet, "/product-batches", nil, "", &batches)
+	return batches, err
+}
+
+func (c *client) purge(ctx context.Context) (v2.PurgeSummary, error) {
+	var summary v2.PurgeSummary
+	err := c.do(ctx, http.MethodPost, "/admin/purge", nil, "", &summary)
+	return summary, err
+}
+
+// importProducts uploads file to the product import, whose format is told by
+// the extension of its name.
+func (c *client) importProducts(ctx context.Context, file namedReader, mode string, dryRun bool) (v2.ImportSummary, error) {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	part, err := form.CreateFormFile("file", filepath.Base(file.Name()))
+	if err != nil {
+		return v2.ImportSummary{}, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return v2.ImportSummary{}, err
+	}
+	if err := form.Close(); err != nil {
+		return v2.ImportSummary{}, err
+	}
+
+	query := url.Values{"mode": {mode}, "dry_run": {strconv.FormatBool(dryRun)}}
+	var summary v2.ImportSummary
+	err = c.do(ctx, http.MethodPost, "/products/import?"+query.Encode(), &buf, form.FormDataContentType(), &summary)
+	return summary, err
+}
+
+// namedReader is a file to upload.
+type namedReader interface {
+	io.Reader
+	Name() string
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v2 "github.com/davidop97/apiGo/cmd/server/handler/v2"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+)
+
+// rootCommand returns the commands of apigoctl, whose global flags are
+// parsed into opts.
+func rootCommand(opts *options) *command {
+	return &command{
+		name:    "apigoctl",
+		args:    "<command>",
+		summary: "apigoctl manages the entities of the API and runs its reports.",
+		flags: func(fs *flag.FlagSet) action {
+			fs.StringVar(&opts.api, "api", envOr("APIGO_API", "http://localhost:8080"), "URL of the API server (APIGO_API)")
+			fs.StringVar(&opts.dsn, "dsn", os.Getenv("APIGO_DSN"), "MySQL DSN to serve the API from instead of calling a server (APIGO_DSN)")
+			fs.StringVar(&opts.format, "o", "table", "output format: "+strings.Join(formats, ", "))
+			fs.StringVar(&opts.actor, "actor", envOr("APIGO_ACTOR", "apigoctl"), "actor the changes are audited as (APIGO_ACTOR)")
+			return nil
+		},
+		sub: []*command{
+			{
+				name:    "products",
+				args:    "<command>",
+				summary: "Manage the products.",
+				sub: []*command{
+					{name: "list", summary: "List the products.", flags: productsList},
+					{name: "get", args: "<id>", summary: "Show a product.", flags: productsGet},
+					{name: "create", summary: "Create a product from the field flags.", flags: productsCreate},
+					{name: "update", args: "<id>", summary: "Change the fields of a product set with flags.", flags: productsUpdate},
+					{name: "delete", args: "<id>", summary: "Delete a product.", flags: productsDelete},
+				},
+			},
+			{
+				name:    "sections",
+				args:    "<command>",
+				summary: "Report on the sections.",
+				sub: []*command{
+					{name: "report", summary: "Count the products of the sections.", flags: sectionsReport},
+				},
+			},
+			{
+				name:    "localities",
+				args:    "<command>",
+				summary: "Report on the localities.",
+				sub: []*command{
+					{name: "report-sellers", summary: "Count the sellers of the localities.", flags: localitiesReportSellers},
+				},
+			},
+			{
+				name:    "batches",
+				args:    "<command>",
+				summary: "Report on the product batches.",
+				sub: []*command{
+					{name: "expiring", summary: "List the batches with stock due within some days, or already due.", flags: batchesExpiring},
+				},
+			},
+			{
+				name:    "import",
+				args:    "<entity>",
+				summary: "Import a CSV or NDJSON file in one transaction.",
+				sub: []*command{
+					{name: "products", args: "<file>", summary: "Import products; the CSV header names the product fields.", flags: importProducts},
+				},
+			},
+			{
+				name:    "export",
+				args:    "<entity>",
+				summary: "Export to a file import reads back.",
+				sub: []*command{
+					{name: "products", summary: "Export the products.", flags: exportProducts},
+				},
+			},
+			{name: "purge", summary: "Remove for good the entities deleted for longer than SOFT_DELETE_RETENTION; needs -dsn.", flags: purge},
+			{
+				name:    "completion",
+				args:    "<shell>",
+				summary: "Print the completion script of a shell, e.g. source <(apigoctl completion bash).",
+				sub: []*command{
+					{name: "bash", summary: "Print the bash completion script.", flags: completionScript(bashCompletion)},
+					{name: "zsh", summary: "Print the zsh completion script.", flags: completionScript(zshCompletion)},
+					{name: "fish", summary: "Print the fish completion script.", flags: completionScript(fishCompletion)},
+				},
+			},
+			{name: completeCommand, args: "<words>", summary: "Print the completions of the words of a command line.", flags: complete},
+		},
+	}
+}
+
+// productFields are the fields of the product requests, set with the flags
+// named after them.
+var productFields = []field{
+	{name: "product_code", kind: kindString, usage: "unique `code` of the product"},
+	{name: "description", kind: kindString, usage: "`description` of the product"},
+	{name: "expiration_rate", kind: kindFloat, usage: "expiration rate, a `number` up to 100"},
+	{name: "freezing_rate", kind: kindFloat, usage: "freezing rate, a `number` up to 100"},
+	{name: "height", kind: kindFloat, usage: "height, a positive `number`"},
+	{name: "length", kind: kindFloat, usage: "length, a positive `number`"},
+	{name: "width", kind: kindFloat, usage: "width, a positive `number`"},
+	{name: "net_weight", kind: kindFloat, usage: "net weight, a positive `number`"},
+	{name: "recommended_freezing_temperature", kind: kindFloat, usage: "recommended freezing temperature, a `number` up to 100"},
+	{name: "product_type_id", kind: kindInt, usage: "`id` of the product type"},
+	{name: "seller_id", kind: kindInt, usage: "`id` of the seller"},
+}
+
+func productsList(fs *flag.FlagSet) action {
+	deleted := fs.Bool("include-deleted", false, "also list the deleted products")
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		products, err := c.products(ctx, *deleted)
+		if err != nil {
+			return err
+		}
+		return a.print(products)
+	}
+}
+
+func productsGet(fs *flag.FlagSet) action {
+	return func(ctx context.Context, a *app, args []string) error {
+		id, err := idArg(args)
+		if err != nil {
+			return err
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		p, err := c.product(ctx, id)
+		if err != nil {
+			return err
+		}
+		return a.print(p)
+	}
+}
+
+func productsCreate(fs *flag.FlagSet) action {
+	body := defineFields(fs, productFields)
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		p, err := c.createProduct(ctx, body)
+		if err != nil {
+			return err
+		}
+		return a.print(p)
+	}
+}
+
+func productsUpdate(fs *flag.FlagSet) action {
+	patch := defineFields(fs, productFields)
+	return func(ctx context.Context, a *app, args []string) error {
+		id, err := idArg(args)
+		if err != nil {
+			return err
+		}
+		if len(patch) == 0 {
+			return usagef("no field to update")
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		p, err := c.updateProduct(ctx, id, patch)
+		if err != nil {
+			return err
+		}
+		return a.print(p)
+	}
+}
+
+func productsDelete(fs *flag.FlagSet) action {
+	return func(ctx context.Context, a *app, args []string) error {
+		id, err := idArg(args)
+		if err != nil {
+			return err
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		return c.deleteProduct(ctx, id)
+	}
+}
+
+func sectionsReport(fs *flag.FlagSet) action {
+	id := fs.Int("id", 0, "report on this section only")
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		reports, err := c.sectionReports(ctx, *id)
+		if err != nil {
+			return err
+		}
+		return a.print(reports)
+	}
+}
+
+func localitiesReportSellers(fs *flag.FlagSet) action {
+	id := fs.Int("id", 0, "report on this locality only")
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		reports, err := c.sellerReports(ctx, *id)
+		if err != nil {
+			return err
+		}
+		return a.print(reports)
+	}
+}
+
+// today is the date the expiring batches are due from, replaced in tests.
+var today = func() time.Time { return time.Now() }
+
+func batchesExpiring(fs *flag.FlagSet) action {
+	days := fs.Int("days", 7, "list the batches due within this many days")
+	section := fs.Int("section", 0, "list the batches of this section only")
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		if *days < 0 {
+			return usagef("-days must not be negative")
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		batches, err := c.batches(ctx)
+		if err != nil {
+			return err
+		}
+		return a.print(expiring(batches, today().AddDate(0, 0, *days), *section))
+	}
+}
+
+// expiring returns the batches of batches with stock left that are due by
+// until, in the section when not zero, the soonest due first.
+func expiring(batches []domain.ProductBatch, until time.Time, section int) []domain.ProductBatch {
+	limit := until.Format(time.DateOnly)
+	found := []domain.ProductBatch{}
+	for _, b := range batches {
+		// The due dates may be read with a time, which is not compared.
+		due := b.DueDate[:min(len(b.DueDate), len(time.DateOnly))]
+		if b.CurrentQuantity > 0 && due <= limit && (section == 0 || b.SectionID == section) {
+			found = append(found, b)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].DueDate < found[j].DueDate
+	})
+	return found
+}
+
+func importProducts(fs *flag.FlagSet) action {
+	mode := fs.String("mode", string(bulk.ModeInsert), "insert rejects the products already stored, upsert updates them")
+	dryRun := fs.Bool("dry-run", false, "check every row without saving any")
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		summary, err := c.importProducts(ctx, file, *mode, *dryRun)
+		if err != nil {
+			return err
+		}
+		return a.print(summary)
+	}
+}
+
+func exportProducts(fs *flag.FlagSet) action {
+	format := fs.String("format", "csv", "file format: csv or ndjson")
+	deleted := fs.Bool("include-deleted", false, "also export the deleted products")
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		if *format != "csv" && *format != "ndjson" {
+			return usagef("-format must be csv or ndjson")
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		products, err := c.products(ctx, *deleted)
+		if err != nil {
+			return err
+		}
+
+		// The rows are the requests that create the products again.
+		rows := make([]v2.ProductRequest, 0, len(products))
+		for _, p := range products {
+			rows = append(rows, v2.ProductRequest{
+				Description:                    p.Description,
+				ExpirationRate:                 p.ExpirationRate,
+				FreezingRate:                   p.FreezingRate,
+				Height:                         p.Height,
+				Length:                         p.Length,
+				NetWeight:                      p.NetWeight,
+				ProductCode:                    p.ProductCode,
+				RecommendedFreezingTemperature: p.RecommendedFreezingTemperature,
+				Width:                          p.Width,
+				ProductTypeID:                  p.ProductTypeID,
+				SellerID:                       p.SellerID,
+				DimensionUnit:                  string(p.DimensionUnit),
+				WeightUnit:                     string(p.WeightUnit),
+			})
+		}
+		if *format == "csv" {
+			return writeCSV(a.out, rows)
+		}
+		enc := json.NewEncoder(a.out)
+		for _, r := range rows {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// purged is a row of the purge output.
+type purged struct {
+	Entity string `json:"entity"`
+	Purged int    `json:"purged"`
+}
+
+// purge removes for good the entities deleted for a while. The server does
+// not serve the purge, which cannot be undone, until its requests are
+// authenticated, so it is only run with -dsn.
+func purge(fs *flag.FlagSet) action {
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) > 0 {
+			return errUsage
+		}
+		if a.opts.dsn == "" {
+			return usagef("purge needs -dsn: the server does not serve it")
+		}
+		c, err := a.client()
+		if err != nil {
+			return err
+		}
+		summary, err := c.purge(ctx)
+		if err != nil {
+			return err
+		}
+		rows := make([]purged, 0, len(summary.Purged))
+		for entity, n := range summary.Purged {
+			rows = append(rows, purged{Entity: entity, Purged: n})
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Entity < rows[j].Entity })
+		return a.print(rows)
+	}
+}
+
+// idArg returns the ID that is the only argument of args.
+func idArg(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errUsage
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return 0, usagef("invalid id %q", args[0])
+	}
+	return id, nil
+}
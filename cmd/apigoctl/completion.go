@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// completeCommand is the hidden command the completion scripts run to
+// complete a command line.
+const completeCommand = "__complete"
+
+// The completion scripts ask apigoctl for the candidates of the word under
+// the cursor, and fall back to file names when there are none.
+const (
+	bashCompletion = `_apigoctl() {
+	local IFS=$'\n'
+	COMPREPLY=($(apigoctl __complete -- "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _apigoctl apigoctl
+`
+	zshCompletion = `#compdef apigoctl
+_apigoctl() {
+	local -a candidates
+	candidates=("${(@f)$(apigoctl __complete -- "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	if [[ -n ${candidates[1]} ]]; then
+		compadd -- "${candidates[@]}"
+	else
+		_files
+	fi
+}
+compdef _apigoctl apigoctl
+`
+	fishCompletion = `complete -c apigoctl -a '(apigoctl __complete -- (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)'
+`
+)
+
+// flagValues are the values offered for the flags taking one of a few.
+var flagValues = map[string][]string{
+	"o":      formats,
+	"format": {"csv", "ndjson"},
+	"mode":   {"insert", "upsert"},
+}
+
+func completionScript(script string) func(fs *flag.FlagSet) action {
+	return func(fs *flag.FlagSet) action {
+		return func(ctx context.Context, a *app, args []string) error {
+			if len(args) > 0 {
+				return errUsage
+			}
+			_, err := io.WriteString(a.out, script)
+			return err
+		}
+	}
+}
+
+func complete(fs *flag.FlagSet) action {
+	return func(ctx context.Context, a *app, args []string) error {
+		for _, candidate := range completions(rootCommand(&options{}), args) {
+			fmt.Fprintln(a.out, candidate)
+		}
+		return nil
+	}
+}
+
+// completions returns the candidates of the last of words, the words of a
+// command line after apigoctl: the subcommands, the flags of the command
+// when the word starts with a dash, or the values of the flag before it.
+func completions(root *command, words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+
+	c := root
+	fs, _ := c.flagSet(c.name, io.Discard)
+	var valueOf string
+	for i := 0; i < len(words)-1; i++ {
+		w := words[i]
+		if strings.HasPrefix(w, "-") {
+			// A flag followed by its value takes the next word.
+			if f := fs.Lookup(strings.TrimLeft(w, "-")); f != nil && !isBoolFlag(f) {
+				if i == len(words)-2 {
+					valueOf = f.Name
+				}
+				i++
+			}
+			continue
+		}
+		if s := c.find(w); s != nil {
+			c = s
+			fs, _ = c.flagSet(c.name, io.Discard)
+		}
+	}
+
+	var candidates []string
+	switch {
+	case valueOf != "":
+		candidates = flagValues[valueOf]
+	case strings.HasPrefix(current, "-"):
+		fs.VisitAll(func(f *flag.Flag) {
+			candidates = append(candidates, "-"+f.Name)
+		})
+	default:
+		for _, s := range c.sub {
+			if s.name != completeCommand {
+				candidates = append(candidates, s.name)
+			}
+		}
+	}
+
+	var matching []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, current) {
+			matching = append(matching, candidate)
+		}
+	}
+	sort.Strings(matching)
+	return matching
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kinds of the values of the request fields.
+const (
+	kindString = iota
+	kindInt
+	kindFloat
+)
+
+// field is a field of a request body set with a flag. The flag is named
+// after the JSON field with dashes, e.g. -product-code for product_code.
+type field struct {
+	name  string
+	kind  int
+	usage string
+}
+
+// body is a request body made of the fields set with flags. The fields not
+// set are left out, so an update only changes the ones given.
+type body map[string]interface{}
+
+// defineFields defines the flags of fields on fs, which set them in the
+// returned body.
+func defineFields(fs *flag.FlagSet, fields []field) body {
+	b := body{}
+	for _, f := range fields {
+		fs.Var(fieldValue{body: b, field: f}, strings.ReplaceAll(f.name, "_", "-"), f.usage)
+	}
+	return b
+}
+
+// fieldValue is the flag.Value setting a field of a body.
+type fieldValue struct {
+	body  body
+	field field
+}
+
+func (v fieldValue) String() string {
+	if v.body == nil {
+		return ""
+	}
+	if value, ok := v.body[v.field.name]; ok {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+func (v fieldValue) Set(s string) error {
+	switch v.field.kind {
+	case kindInt:
+		if _, err := strconv.Atoi(s); err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		v.body[v.field.name] = json.Number(s)
+	case kindFloat:
+		if _, err := strconv.ParseFloat(s, 32); err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+		v.body[v.field.name] = json.Number(s)
+	default:
+		v.body[v.field.name] = s
+	}
+	return nil
+}
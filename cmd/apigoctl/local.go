@@ -0,0 +1,98 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	v2 "github.com/davidop97/apiGo/cmd/server/handler/v2"
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/employee"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// localHandler serves the /api/v2 routes apigoctl calls with the services
+// of db, as the server does. The changes are audited like the server's, with
+// AUDIT_HASH_CHAIN=true chaining their records, but the caches of running
+// servers are not invalidated: they keep the entries changed for up to
+// their CACHE_TTL. Only apigoctl serves /admin/purge, which removes the
+// entities deleted for longer than retention.
+func localHandler(db *sql.DB, retention time.Duration) http.Handler {
+	gin.SetMode(gin.ReleaseMode)
+	eng := gin.New()
+	eng.ContextWithFallback = true
+	eng.Use(web.RequestMeta())
+	rg := eng.Group("/api/v2")
+
+	log := audit.NewService(audit.NewRepository(db), audit.Options{Chain: os.Getenv("AUDIT_HASH_CHAIN") == "true"})
+
+	productService := product.NewAuditedService(product.NewService(product.NewRepository(db)), log)
+	products := v2.NewProduct(productService)
+	rg.GET("/products", products.GetAll())
+	rg.GET("/products/:id", products.Get())
+	rg.POST("/products", products.Create())
+	rg.POST("/products/import", products.Import())
+	rg.PATCH("/products/:id", products.Update())
+	rg.DELETE("/products/:id", products.Delete())
+
+	sections := v2.NewSection(section.NewAuditedService(section.NewService(section.NewRepository(db)), log))
+	rg.GET("/sections/product-reports", sections.ProductReports())
+	rg.GET("/sections/:id/product-report", sections.ProductReport())
+
+	localities := v2.NewLocality(locality.NewAuditedService(locality.NewService(locality.NewRepository(db)), log))
+	rg.GET("/localities/seller-reports", localities.SellerReports())
+	rg.GET("/localities/:id/seller-report", localities.SellerReport())
+
+	batches := v2.NewBatch(batch.NewAuditedService(batch.NewService(batch.NewRepository(db)), log))
+	rg.GET("/product-batches", batches.GetAll())
+
+	// Products go first: purging one removes its records.
+	admin := v2.NewAdmin(retention,
+		v2.Purger{Entity: "product", Purge: productService.Purge},
+		v2.Purger{Entity: "seller", Purge: seller.NewAuditedService(seller.NewService(seller.NewRepository(db)), log).Purge},
+		v2.Purger{Entity: "buyer", Purge: buyer.NewAuditedService(buyer.NewService(buyer.NewRepository(db)), log).Purge},
+		v2.Purger{Entity: "employee", Purge: employee.NewAuditedService(employee.NewService(employee.NewRepository(db)), log).PurgeEmployees},
+		v2.Purger{Entity: "warehouse", Purge: warehouse.NewAuditedService(warehouse.NewService(warehouse.NewRepository(db)), log).Purge},
+	)
+	rg.POST("/admin/purge", admin.Purge())
+
+	return eng
+}
+
+// retentionEnv returns the Go duration in SOFT_DELETE_RETENTION, or the
+// default retention when it is not set.
+func retentionEnv() (time.Duration, error) {
+	raw := os.Getenv("SOFT_DELETE_RETENTION")
+	if raw == "" {
+		return softdelete.DefaultRetention, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("SOFT_DELETE_RETENTION: %w", err)
+	}
+	return d, nil
+}
+
+// handlerTransport is an http.RoundTripper serving the requests in the
+// process with a handler.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
@@ -0,0 +1,36 @@
+// Command apigoctl manages the entities of the API and runs its reports from
+// a terminal.
+//
+// It calls the /api/v2 routes of the server at -api (APIGO_API,
+// http://localhost:8080 by default). With -dsn (APIGO_DSN) it serves those
+// routes itself from the MySQL database, through the same services, so it
+// works without a running server.
+//
+// Usage:
+//
+//	apigoctl [-api url | -dsn dsn] [-o table|json|csv] [-actor name] <command> [flags] [args]
+//
+// The commands are:
+//
+//	products list|get|create|update|delete
+//	sections report
+//	localities report-sellers
+//	batches expiring
+//	import products <file>
+//	export products
+//	purge
+//	completion bash|zsh|fish
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
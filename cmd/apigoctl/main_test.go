@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v2 "github.com/davidop97/apiGo/cmd/server/handler/v2"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/producttype/producttypetest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp runs apigoctl commands against the API served in the process
+// from an embedded database.
+type testApp struct {
+	db  *sql.DB
+	out bytes.Buffer
+	app *app
+}
+
+func newTestApp(t *testing.T) *testApp {
+	db := mysqltest.Open(t)
+	producttypetest.AddProductTypes(t, producttype.NewRepository(db), 7)
+	ta := &testApp{db: db}
+	ta.app = &app{
+		out:    &ta.out,
+		errOut: &bytes.Buffer{},
+		opts:   &options{},
+		connect: func() (*client, error) {
+			return newClient(localBase, &http.Client{Transport: handlerTransport{localHandler(db, softdelete.DefaultRetention)}}, "tester"), nil
+		},
+	}
+	return ta
+}
+
+// run runs the command line args and returns its output.
+func (ta *testApp) run(args ...string) (string, error) {
+	ta.out.Reset()
+	err := ta.app.execute(context.Background(), rootCommand(ta.app.opts), "apigoctl", args)
+	return ta.out.String(), err
+}
+
+func (ta *testApp) mustRun(t *testing.T, args ...string) string {
+	out, err := ta.run(args...)
+	require.NoError(t, err, args)
+	return out
+}
+
+// createArgs are the flags of a product for products create.
+func createArgs(code, description string) []string {
+	return []string{"-o", "json", "products", "create", "-product-code", code, "-description", description,
+		"-expiration-rate", "1", "-freezing-rate", "2", "-height", "3", "-length", "4", "-width", "5", "-net-weight", "6.5",
+		"-recommended-freezing-temperature", "-4", "-product-type-id", "7"}
+}
+
+func TestProducts(t *testing.T) {
+	ta := newTestApp(t)
+
+	t.Run("it should create, update, list and delete a product", func(t *testing.T) {
+		// Act
+		created := ta.mustRun(t, createArgs("YOG-001", "Yogurt")...)
+		updated := ta.mustRun(t, "-o", "json", "products", "update", "1", "-description", "Greek yogurt")
+		listed := ta.mustRun(t, "-o", "csv", "products", "list")
+		shown := ta.mustRun(t, "products", "get", "1")
+		ta.mustRun(t, "products", "delete", "1")
+		_, err := ta.run("products", "get", "1")
+
+		// Assert
+		assert.JSONEq(t, `{"id":1,"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":3,"length":4,
+			"net_weight":6.5,"product_code":"YOG-001","recommended_freezing_temperature":-4,"width":5,"product_type_id":7,
+			"seller_id":0,"dimension_unit":"cm","weight_unit":"kg","volume":0.00006,"volume_unit":"m3"}`, created)
+		var p v2.ProductResponse
+		require.NoError(t, json.Unmarshal([]byte(updated), &p))
+		assert.Equal(t, "Greek yogurt", p.Description)
+		assert.Equal(t, float32(6.5), p.NetWeight)
+		assert.Equal(t, "id,description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,dimension_unit,weight_unit,volume,volume_unit,deleted_at\n"+
+			"1,Greek yogurt,1,2,3,4,6.5,YOG-001,-4,5,7,0,cm,kg,0.00006,m3,\n", listed)
+		assert.Contains(t, shown, "ID  DESCRIPTION   EXPIRATION_RATE")
+		assert.Contains(t, shown, "1   Greek yogurt  1")
+		assert.EqualError(t, err, "product not found (404 not_found)")
+	})
+
+	t.Run("it should report the errors of the API", func(t *testing.T) {
+		// Arrange
+		ta.mustRun(t, createArgs("CHS-001", "Cheddar")...)
+
+		// Act
+		_, duplicated := ta.run(createArgs("CHS-001", "Cheddar")...)
+		_, invalid := ta.run("products", "create", "-product-code", "CHS-002")
+
+		// Assert
+		assert.EqualError(t, duplicated, "product_code already exists (409 conflict)")
+		var apiErr *apiError
+		require.ErrorAs(t, invalid, &apiErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, apiErr.status)
+	})
+
+	t.Run("it should refuse a command line it cannot run", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"products", "get"},
+			{"products", "get", "one"},
+			{"products", "update", "1"},
+			{"products", "sell"},
+			{"products", "create", "-height", "tall"},
+			{"-o", "yaml", "products", "list"},
+		} {
+			// Act
+			_, err := ta.run(args...)
+
+			// Assert
+			assert.ErrorIs(t, err, errUsage, args)
+		}
+	})
+}
+
+func TestPurge(t *testing.T) {
+	ta := newTestApp(t)
+	ta.mustRun(t, createArgs("YOG-001", "Yogurt")...)
+	ta.mustRun(t, "products", "delete", "1")
+
+	t.Run("it should refuse to purge through a server", func(t *testing.T) {
+		// Act
+		_, err := ta.run("purge")
+
+		// Assert
+		assert.ErrorIs(t, err, errUsage)
+	})
+
+	t.Run("it should purge the entities deleted for longer than the retention", func(t *testing.T) {
+		// Arrange
+		ta.app.api = nil
+		ta.app.connect = func() (*client, error) {
+			// A negative retention purges the entities deleted until now.
+			return newClient(localBase, &http.Client{Transport: handlerTransport{localHandler(ta.db, -time.Hour)}}, "tester"), nil
+		}
+
+		// Act
+		out := ta.mustRun(t, "-dsn", "test", "-o", "csv", "purge")
+		_, err := ta.run("products", "get", "1")
+
+		// Assert
+		assert.Equal(t, "entity,purged\nbuyer,0\nemployee,0\nproduct,1\nseller,0\nwarehouse,0\n", out)
+		assert.EqualError(t, err, "product not found (404 not_found)")
+	})
+}
+
+func TestImportExport(t *testing.T) {
+	ta := newTestApp(t)
+	ta.mustRun(t, createArgs("YOG-001", "Yogurt")...)
+	dir := t.TempDir()
+
+	t.Run("it should export the products a file import reads back", func(t *testing.T) {
+		// Arrange
+		exported := ta.mustRun(t, "export", "products")
+		file := filepath.Join(dir, "products.csv")
+		require.NoError(t, os.WriteFile(file, bytes.Replace([]byte(exported), []byte("Yogurt"), []byte("Greek yogurt"), 1), 0o600))
+
+		// Act
+		dryRun := ta.mustRun(t, "-o", "json", "import", "products", "-mode", "upsert", "-dry-run", file)
+		upserted := ta.mustRun(t, "-o", "json", "import", "products", "-mode", "upsert", file)
+
+		// Assert
+		assert.Equal(t, "description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,dimension_unit,weight_unit\n"+
+			"Yogurt,1,2,3,4,6.5,YOG-001,-4,5,7,0,cm,kg\n", exported)
+		assert.JSONEq(t, `{"rows":1,"inserted":0,"updated":1,"dry_run":true,"committed":false}`, dryRun)
+		assert.JSONEq(t, `{"rows":1,"inserted":0,"updated":1,"dry_run":false,"committed":true}`, upserted)
+		assert.Contains(t, ta.mustRun(t, "products", "get", "1"), "Greek yogurt")
+	})
+
+	t.Run("it should export the products as NDJSON", func(t *testing.T) {
+		// Act
+		exported := ta.mustRun(t, "export", "products", "-format", "ndjson")
+
+		// Assert
+		assert.JSONEq(t, `{"description":"Greek yogurt","expiration_rate":1,"freezing_rate":2,"height":3,"length":4,"net_weight":6.5,
+			"product_code":"YOG-001","recommended_freezing_temperature":-4,"width":5,"product_type_id":7,"seller_id":0,"dimension_unit":"cm","weight_unit":"kg"}`, exported)
+	})
+
+	t.Run("it should list the invalid rows of an import", func(t *testing.T) {
+		// Arrange
+		file := filepath.Join(dir, "invalid.ndjson")
+		require.NoError(t, os.WriteFile(file, []byte(`{"product_code":"YOG-001"}`+"\n"), 0o600))
+
+		// Act
+		_, err := ta.run("import", "products", file)
+
+		// Assert
+		var rowsErr *importError
+		require.ErrorAs(t, err, &rowsErr)
+		assert.Equal(t, 1, rowsErr.Rows[0].Line)
+		assert.Contains(t, err.Error(), "1 of 1 rows are invalid\n  line 1: ")
+	})
+}
+
+func TestReports(t *testing.T) {
+	ta := newTestApp(t)
+	ta.mustRun(t, createArgs("YOG-001", "Yogurt")...)
+	_, err := ta.db.Exec("INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (10, 2, 0, 5, 1, 50, 1, 7)")
+	require.NoError(t, err)
+	_, err = ta.db.Exec(`INSERT INTO productBatches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id) VALUES
+		(1, 10, 2, '2026-10-20', 10, '2026-10-01', 8, 0, 1, 1),
+		(2, 0, 2, '2026-10-19', 10, '2026-10-01', 8, 0, 1, 1),
+		(3, 10, 2, '2026-12-01', 10, '2026-10-01', 8, 0, 1, 1),
+		(4, 5, 2, '2026-10-15', 5, '2026-09-01', 8, 0, 1, 1)`)
+	require.NoError(t, err)
+	_, err = ta.db.Exec("INSERT INTO locality (postal_code, locality_name, province_name, country_name) VALUES (1000, 'Palermo', 'Buenos Aires', 'Argentina')")
+	require.NoError(t, err)
+
+	t.Run("it should count the products of the sections", func(t *testing.T) {
+		// Act
+		all := ta.mustRun(t, "-o", "csv", "sections", "report")
+		one := ta.mustRun(t, "-o", "json", "sections", "report", "-id", "1")
+		_, missing := ta.run("sections", "report", "-id", "9")
+
+		// Assert
+		assert.Equal(t, "id,section_number,product_count\n1,10,25\n", all)
+		assert.JSONEq(t, `[{"id":1,"section_number":10,"product_count":25}]`, one)
+		assert.EqualError(t, missing, "section not found (404 not_found)")
+	})
+
+	t.Run("it should count the sellers of the localities", func(t *testing.T) {
+		// Act
+		all := ta.mustRun(t, "-o", "csv", "localities", "report-sellers")
+
+		// Assert
+		assert.Equal(t, "locality_id,locality_name,postal_code,sellers_count\n1,Palermo,1000,0\n", all)
+	})
+
+	t.Run("it should list the batches with stock due within some days", func(t *testing.T) {
+		// Arrange
+		today = func() time.Time { return time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC) }
+		t.Cleanup(func() { today = time.Now })
+
+		// Act
+		soon := ta.mustRun(t, "-o", "csv", "batches", "expiring", "-days", "3")
+
+		// Assert
+		assert.Equal(t, "id,batch_number,current_quantity,current_temperature,due_date,initial_quantity,manufacturing_date,manufacturing_hour,minimum_temperature,product_id,section_id,cold_chain_override\n"+
+			"4,4,5,2,2026-10-15,5,2026-09-01,8,0,1,1,\n"+
+			"1,1,10,2,2026-10-20,10,2026-10-01,8,0,1,1,\n", soon)
+	})
+}
+
+func TestExpiring(t *testing.T) {
+	batches := []domain.ProductBatch{
+		{ID: 1, DueDate: "2026-10-20 00:00:00", CurrentQuantity: 1, SectionID: 1},
+		{ID: 2, DueDate: "2026-10-18", CurrentQuantity: 1, SectionID: 2},
+		{ID: 3, DueDate: "2026-10-21", CurrentQuantity: 1, SectionID: 1},
+	}
+	until := time.Date(2026, time.October, 20, 23, 0, 0, 0, time.UTC)
+
+	t.Run("it should compare the due dates without their time", func(t *testing.T) {
+		// Act
+		found := expiring(batches, until, 0)
+
+		// Assert
+		require.Len(t, found, 2)
+		assert.Equal(t, []int{2, 1}, []int{found[0].ID, found[1].ID})
+	})
+
+	t.Run("it should only list the batches of the section", func(t *testing.T) {
+		// Act
+		found := expiring(batches, until, 1)
+
+		// Assert
+		require.Len(t, found, 1)
+		assert.Equal(t, 1, found[0].ID)
+	})
+}
+
+func TestCompletions(t *testing.T) {
+	cases := map[string]struct {
+		words    []string
+		expected []string
+	}{
+		"commands":             {words: []string{""}, expected: []string{"batches", "completion", "export", "import", "localities", "products", "purge", "sections"}},
+		"subcommands":          {words: []string{"products", "u"}, expected: []string{"update"}},
+		"after global flags":   {words: []string{"-o", "json", "-actor", "ops", "sections", ""}, expected: []string{"report"}},
+		"flags":                {words: []string{"batches", "expiring", "-"}, expected: []string{"-days", "-section"}},
+		"flag values":          {words: []string{"-o", ""}, expected: []string{"csv", "json", "table"}},
+		"after a boolean flag": {words: []string{"import", "products", "-dry-run", "-mode", "u"}, expected: []string{"upsert"}},
+		"files":                {words: []string{"import", "products", "pro"}, expected: nil},
+	}
+	for name, tc := range cases {
+		t.Run("it should complete the "+name, func(t *testing.T) {
+			// Act
+			obtained := completions(rootCommand(&options{}), tc.words)
+
+			// Assert
+			assert.Equal(t, tc.expected, obtained)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := httptest.NewServer(localHandler(mysqltest.Open(t), softdelete.DefaultRetention))
+	t.Cleanup(srv.Close)
+
+	t.Run("it should call the API server and exit with 1 on errors", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		listed := run(context.Background(), []string{"-api", srv.URL, "-o", "json", "products", "list"}, &stdout, &stderr)
+		missing := run(context.Background(), []string{"-api", srv.URL, "products", "get", "3"}, &stdout, &stderr)
+
+		// Assert
+		assert.Equal(t, 0, listed)
+		assert.Equal(t, 1, missing)
+		assert.Equal(t, "[]\n", stdout.String())
+		assert.Equal(t, "apigoctl: product not found (404 not_found)\n", stderr.String())
+	})
+
+	t.Run("it should print the usage and exit with 2 for an unknown command", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run(context.Background(), []string{"-api", srv.URL, "warehouses"}, &stdout, &stderr)
+
+		// Assert
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), `apigoctl: unknown command "warehouses"`)
+		assert.Contains(t, stderr.String(), "Usage: apigoctl [flags] <command>")
+	})
+
+	t.Run("it should print a completion script", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run(context.Background(), []string{"completion", "bash"}, &stdout, &stderr)
+
+		// Assert
+		assert.Equal(t, 0, code)
+		assert.Equal(t, bashCompletion, stdout.String())
+	})
+}
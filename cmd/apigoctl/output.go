@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/davidop97/apiGo/pkg/table"
+)
+
+// print writes v, a slice of rows or a single one, in the output format: a
+// table aligned in columns, indented JSON or CSV. The columns are the JSON
+// fields of the rows, as in the CSV exports of the API.
+func (a *app) print(v interface{}) error {
+	switch a.opts.format {
+	case "table":
+		return writeTable(a.out, v)
+	case "json":
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "csv":
+		return writeCSV(a.out, v)
+	}
+	return usagef("-o must be one of %s", strings.Join(formats, ", "))
+}
+
+// writeTable writes the rows of v in columns under an upper case header.
+func writeTable(w io.Writer, v interface{}) error {
+	t := table.New(v)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := t.Header()
+	for i, name := range header {
+		header[i] = strings.ToUpper(name)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for i := 0; i < t.Len(); i++ {
+		row := t.Row(i)
+		cells := make([]string, len(row))
+		for j, value := range row {
+			cells[j] = table.Cell(value)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// writeCSV writes the rows of v as CSV, a line per row after the header.
+func writeCSV(w io.Writer, v interface{}) error {
+	t := table.New(v)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Header()); err != nil {
+		return err
+	}
+	for i := 0; i < t.Len(); i++ {
+		row := t.Row(i)
+		record := make([]string, len(row))
+		for j, value := range row {
+			record[j] = table.Cell(value)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
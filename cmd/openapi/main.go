@@ -0,0 +1,57 @@
+// Command openapi converts the Swagger 2.0 document generated by swag into an
+// OpenAPI 3 document.
+//
+// Usage:
+//
+//	go run ./cmd/openapi -in docs/swagger.json -out docs/openapi.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+)
+
+func main() {
+	in := flag.String("in", "docs/swagger.json", "Swagger 2.0 document to convert")
+	out := flag.String("out", "docs/openapi.json", "destination of the OpenAPI 3 document")
+	flag.Parse()
+
+	if err := convert(*in, *out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// convert reads the Swagger 2.0 document at in, converts it and writes the
+// OpenAPI 3 document to out once it validates.
+func convert(in, out string) error {
+	raw, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+
+	var doc2 openapi2.T
+	if err := json.Unmarshal(raw, &doc2); err != nil {
+		return err
+	}
+
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return err
+	}
+	if err := doc3.Validate(context.Background()); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(doc3, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, append(b, '\n'), 0o644)
+}
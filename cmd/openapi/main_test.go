@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	// The committed OpenAPI 3 documents must be the conversion of the committed
+	// Swagger documents, otherwise `make docs` was not run after an annotation change.
+	documents := []struct {
+		swagger string
+		openapi string
+	}{
+		{swagger: "../../docs/swagger.json", openapi: "../../docs/openapi.json"},
+		{swagger: "../../docs/v2/v2_swagger.json", openapi: "../../docs/v2/openapi.json"},
+	}
+
+	for _, doc := range documents {
+		t.Run(doc.openapi+" is up to date with "+doc.swagger, func(t *testing.T) {
+			// Arrange
+			out := filepath.Join(t.TempDir(), "openapi.json")
+
+			// Act
+			err := convert(doc.swagger, out)
+
+			// Assert
+			require.NoError(t, err)
+			expected, err := os.ReadFile(doc.openapi)
+			require.NoError(t, err)
+			obtained, err := os.ReadFile(out)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(obtained))
+		})
+	}
+}
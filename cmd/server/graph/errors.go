@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/carries"
+	"github.com/davidop97/apiGo/internal/employee"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/go-playground/validator/v10"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Codes set in the extensions of the errors returned by the resolvers.
+const (
+	CodeBadUserInput = "BAD_USER_INPUT"
+	CodeNotFound     = "NOT_FOUND"
+	CodeConflict     = "CONFLICT"
+	CodeInternal     = "INTERNAL_SERVER_ERROR"
+)
+
+// Error is an error with a code clients can switch on. The code is returned in
+// the extensions of the GraphQL error.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions implements the extension point graphql-go reads error codes from.
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+// serviceErrors maps the errors of the services to the error of the response.
+var serviceErrors = []struct {
+	err error
+	Error
+}{
+	{seller.ErrNotFound, Error{CodeNotFound, "seller not found"}},
+	{seller.ErrSellerAlreadyExists, Error{CodeConflict, "cid already exists"}},
+	{locality.ErrLocalityNotFound, Error{CodeNotFound, "locality not found"}},
+	{locality.ErrLocalityAlreadyExists, Error{CodeConflict, "locality already exists"}},
+	{carries.ErrDuplicateCarry, Error{CodeConflict, "cid already exists"}},
+	{carries.ErrLocalityCarriesNotFound, Error{CodeNotFound, "locality not found"}},
+	{carries.ErrIncorrectData, Error{CodeBadUserInput, "incorrect data"}},
+	{product.ErrNotFound, Error{CodeNotFound, "product not found"}},
+	{product.ErrProductCodeExists, Error{CodeConflict, "productCode already exists"}},
+	{product.ErrProductTypeNotFound, Error{CodeBadUserInput, "product type does not exist"}},
+	{product.ErrRecordPredatesLatest, Error{CodeConflict, "lastUpdateDate predates the latest record of the product"}},
+	{product.ErrInvalidCurrency, Error{CodeBadUserInput, "currency must be an ISO 4217 code"}},
+	{money.ErrOutOfRange, Error{CodeBadUserInput, "price out of range"}},
+	{batch.ErrDuplicateBatchNumber, Error{CodeConflict, "batchNumber already exists"}},
+	{batch.ErrProductNotFound, Error{CodeBadUserInput, "product does not exist"}},
+	{batch.ErrSectionNotFound, Error{CodeBadUserInput, "section does not exist"}},
+	{batch.ErrProductTypeMismatch, Error{CodeBadUserInput, "product is not of the product type of the section"}},
+	{batch.ErrColdChain, Error{CodeBadUserInput, "the section breaks the cold chain of the batch"}},
+	{batch.ErrSectionFull, Error{CodeConflict, "currentQuantity exceeds the maximum capacity of the section"}},
+	{section.ErrNotFound, Error{CodeNotFound, "section not found"}},
+	{section.ErrDuplicateSectNumber, Error{CodeConflict, "sectionNumber already exists"}},
+	{section.ErrProductTypeNotFound, Error{CodeBadUserInput, "product type does not exist"}},
+	{section.ErrWarehouseNotFound, Error{CodeBadUserInput, "warehouse does not exist"}},
+	{warehouse.ErrNotFound, Error{CodeNotFound, "warehouse not found"}},
+	{warehouse.ErrDuplicateWarehouse, Error{CodeConflict, "warehouseCode already exists"}},
+	{warehouse.ErrIncorrectData, Error{CodeBadUserInput, "incorrect data"}},
+	{employee.ErrNotFound, Error{CodeNotFound, "employee not found"}},
+	{employee.ErrEmployeeAlreadyExists, Error{CodeConflict, "cardNumberId already exists"}},
+	{inboudorder.ErrEmployeeNotFound, Error{CodeNotFound, "employee not found"}},
+	{inboudorder.ErrInboundOrderAlreadyExists, Error{CodeConflict, "orderNumber already exists"}},
+	{inboudorder.ErrEmployeeDoesNotExists, Error{CodeBadUserInput, "employee does not exist"}},
+	{inboudorder.ErrWarehouseDoesNotExists, Error{CodeBadUserInput, "warehouse does not exist"}},
+	{buyer.ErrNotFound, Error{CodeNotFound, "buyer not found"}},
+	{buyer.ErrAlreadyExists, Error{CodeConflict, "cardNumberId already exists"}},
+	{purchase_order.ErrPurchaseOrderAlreadyExists, Error{CodeConflict, "orderNumber already exists"}},
+	{purchase_order.ErrBuyerIDNotExists, Error{CodeBadUserInput, "buyer does not exist"}},
+	{purchase_order.ErrProductsRecordIDNotExits, Error{CodeBadUserInput, "product record does not exist"}},
+}
+
+// toError returns the response error of an error of the services. Errors the
+// services do not declare are reported without their message.
+func toError(err error) error {
+	for _, se := range serviceErrors {
+		if errors.Is(err, se.err) {
+			e := se.Error
+			return &e
+		}
+	}
+	return &Error{CodeInternal, "internal server error"}
+}
+
+// notFound returns the error of a missing entity of the given kind.
+func notFound(kind string) error {
+	return &Error{CodeNotFound, kind + " not found"}
+}
+
+// parseID converts an ID argument to the integer id of the services.
+func parseID(id graphql.ID) (int, error) {
+	n, err := strconv.Atoi(string(id))
+	if err != nil || n < 1 {
+		return 0, &Error{CodeBadUserInput, "id must be a positive integer"}
+	}
+	return n, nil
+}
+
+// optionalID is parseID for the optional filter of the reports, where a
+// missing id stands for every entity.
+func optionalID(id *graphql.ID) (int, error) {
+	if id == nil {
+		return 0, nil
+	}
+	return parseID(*id)
+}
+
+func toID(id int) graphql.ID {
+	return graphql.ID(strconv.Itoa(id))
+}
+
+var validate = validator.New()
+
+// validateInput checks the validate tags of an input and describes the
+// failures using the GraphQL names of the fields.
+func validateInput(input interface{}) error {
+	err := validate.Struct(input)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+
+	messages := make([]string, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		name := fieldName(fe.StructField())
+		switch fe.Tag() {
+		case "gt":
+			messages = append(messages, fmt.Sprintf("%s must be greater than %s", name, fe.Param()))
+		case "gte":
+			messages = append(messages, fmt.Sprintf("%s must be greater than or equal to %s", name, fe.Param()))
+		case "lte":
+			messages = append(messages, fmt.Sprintf("%s must be less than or equal to %s", name, fe.Param()))
+		case "max":
+			messages = append(messages, fmt.Sprintf("%s must be at most %s characters long", name, fe.Param()))
+		case "required":
+			messages = append(messages, fmt.Sprintf("%s must not be empty", name))
+		case "datetime":
+			messages = append(messages, fmt.Sprintf("%s must match the format YYYY-MM-DD", name))
+		default:
+			messages = append(messages, fmt.Sprintf("%s is invalid", name))
+		}
+	}
+	return &Error{CodeBadUserInput, strings.Join(messages, "; ")}
+}
+
+// fieldName returns the GraphQL name of an input struct field, e.g. "cid" for
+// CID and "productTypeId" for ProductTypeID.
+func fieldName(goName string) string {
+	if strings.ToUpper(goName) == goName {
+		return strings.ToLower(goName)
+	}
+	name := []rune(strings.TrimSuffix(goName, "ID"))
+	name[0] = unicode.ToLower(name[0])
+	if strings.HasSuffix(goName, "ID") {
+		return string(name) + "Id"
+	}
+	return string(name)
+}
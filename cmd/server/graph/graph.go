@@ -0,0 +1,94 @@
+// Package graph serves the domain types over GraphQL at /graphql. Queries
+// read through the same services as the REST handlers, mutations write
+// through them, and the relations between types are resolved with per-request
+// loaders so that a list of N parents costs one query per relation.
+package graph
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/carries"
+	"github.com/davidop97/apiGo/internal/employee"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var Schema string
+
+const (
+	// maxDepth bounds the nesting of a query, relations included.
+	maxDepth = 10
+	// maxParallelism is the number of fields resolved at once. A relation is
+	// loaded in batches of at most this many parents.
+	maxParallelism = 100
+)
+
+// Services are the services the resolvers read and write through.
+type Services struct {
+	Seller        seller.Service
+	Locality      locality.Service
+	Carry         carries.Service
+	Product       product.Service
+	Batch         batch.Service
+	Section       section.Service
+	Warehouse     warehouse.Service
+	Employee      employee.Service
+	InboundOrder  inboudorder.Service
+	Buyer         buyer.Service
+	PurchaseOrder purchase_order.Service
+}
+
+// request is the body of a GraphQL request.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler executes GraphQL requests against the schema.
+type Handler struct {
+	schema   *graphql.Schema
+	services Services
+}
+
+// NewHandler returns a new instance of Handler. It panics if the schema does
+// not match the resolvers.
+func NewHandler(s Services) *Handler {
+	return &Handler{
+		schema: graphql.MustParseSchema(Schema, &Resolver{s: s},
+			graphql.MaxDepth(maxDepth),
+			graphql.MaxParallelism(maxParallelism),
+		),
+		services: s,
+	}
+}
+
+// Serve executes the query of the body. The response is 200 with the errors
+// in the body, as GraphQL clients expect, unless the body is not JSON.
+func (h *Handler) Serve() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req request
+		if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+			c.JSON(http.StatusBadRequest, &graphql.Response{
+				Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("invalid JSON body")},
+			})
+			return
+		}
+
+		ctx := withLoaders(c.Request.Context(), newLoaders(h.services))
+		c.JSON(http.StatusOK, h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+	}
+}
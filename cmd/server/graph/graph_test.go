@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/carries"
+	"github.com/davidop97/apiGo/internal/employee"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mocks holds a mock of every service the resolvers use.
+type mocks struct {
+	seller        *seller.ServiceMock
+	locality      *locality.ServiceMock
+	carry         *carries.ServiceMock
+	product       *product.ServiceMock
+	batch         *batch.ServiceMock
+	section       *section.ServiceMock
+	warehouse     *warehouse.ServiceMock
+	employee      *employee.ServiceMock
+	inboundOrder  *inboudorder.ServiceMock
+	buyer         *buyer.BuyerServiceMock
+	purchaseOrder *purchase_order.ServiceMock
+}
+
+func newMocks() *mocks {
+	return &mocks{
+		seller:        seller.NewMockService(),
+		locality:      locality.NewMockService(),
+		carry:         &carries.ServiceMock{},
+		product:       &product.ServiceMock{},
+		batch:         &batch.ServiceMock{},
+		section:       &section.ServiceMock{},
+		warehouse:     &warehouse.ServiceMock{},
+		employee:      &employee.ServiceMock{},
+		inboundOrder:  &inboudorder.ServiceMock{},
+		buyer:         buyer.NewBuyerService(),
+		purchaseOrder: &purchase_order.ServiceMock{},
+	}
+}
+
+func (m *mocks) services() Services {
+	return Services{
+		Seller:        m.seller,
+		Locality:      m.locality,
+		Carry:         m.carry,
+		Product:       m.product,
+		Batch:         m.batch,
+		Section:       m.section,
+		Warehouse:     m.warehouse,
+		Employee:      m.employee,
+		InboundOrder:  m.inboundOrder,
+		Buyer:         m.buyer,
+		PurchaseOrder: m.purchaseOrder,
+	}
+}
+
+// post sends a GraphQL request with the given query and variables to a
+// /graphql route served by the mocks.
+func (m *mocks) post(t *testing.T, query string, variables map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.POST("/graphql", NewHandler(m.services()).Serve())
+	request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	return response
+}
+
+func TestHandler_Serve(t *testing.T) {
+	t.Run("it should return 400 when the body is not JSON", func(t *testing.T) {
+		// Arrange
+		r := gin.New()
+		r.POST("/graphql", NewHandler(newMocks().services()).Serve())
+		request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader("{"))
+		response := httptest.NewRecorder()
+
+		// Act
+		r.ServeHTTP(response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"errors":[{"message":"invalid JSON body"}]}`, response.Body.String())
+	})
+
+	t.Run("it should report a query that does not match the schema", func(t *testing.T) {
+		response := newMocks().post(t, `{ sellers { unknown } }`, nil)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `Cannot query field \"unknown\" on type \"Seller\"`)
+	})
+}
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/dataloader"
+)
+
+// loaders batch the relations resolved during one request. To-one relations
+// load pointers, which are nil when the referenced row does not exist.
+type loaders struct {
+	locality       *dataloader.Loader[int, *domain.Locality]
+	seller         *dataloader.Loader[int, *domain.Seller]
+	section        *dataloader.Loader[int, *domain.Section]
+	warehouse      *dataloader.Loader[int, *domain.Warehouse]
+	productBatches *dataloader.Loader[int, []domain.ProductBatch]
+	employees      *dataloader.Loader[int, []domain.Employee]
+	purchaseOrders *dataloader.Loader[int, []domain.PurchaseOrder]
+}
+
+func newLoaders(s Services) *loaders {
+	return &loaders{
+		locality: dataloader.New(dataloader.DefaultWait, byID(s.Locality.GetLocalitiesByIDs, func(l domain.Locality) int {
+			return l.ID
+		})),
+		seller: dataloader.New(dataloader.DefaultWait, byID(s.Seller.GetSellersByIDs, func(s domain.Seller) int {
+			return s.ID
+		})),
+		section: dataloader.New(dataloader.DefaultWait, byID(s.Section.GetByIDs, func(s domain.Section) int {
+			return s.ID
+		})),
+		warehouse: dataloader.New(dataloader.DefaultWait, byID(s.Warehouse.GetByIDs, func(w domain.Warehouse) int {
+			return w.ID
+		})),
+		productBatches: dataloader.New(dataloader.DefaultWait, groupedBy(s.Batch.GetByProductIDs, func(b domain.ProductBatch) int {
+			return b.ProductID
+		})),
+		employees: dataloader.New(dataloader.DefaultWait, groupedBy(s.Employee.GetEmployeesByWarehouseIDs, func(e domain.Employee) int {
+			return e.WarehouseID
+		})),
+		purchaseOrders: dataloader.New(dataloader.DefaultWait, groupedBy(s.PurchaseOrder.GetByBuyerIDs, func(po domain.PurchaseOrder) int {
+			return po.BuyerID
+		})),
+	}
+}
+
+// byID adapts a service method that returns the rows with the given ids to a
+// to-one loader.
+func byID[T any](get func(context.Context, []int) ([]T, error), id func(T) int) dataloader.FetchFunc[int, *T] {
+	return func(ctx context.Context, ids []int) (map[int]*T, error) {
+		rows, err := get(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[int]*T, len(rows))
+		for i := range rows {
+			values[id(rows[i])] = &rows[i]
+		}
+		return values, nil
+	}
+}
+
+// groupedBy adapts a service method that returns the rows referencing the
+// given ids to a to-many loader.
+func groupedBy[T any](get func(context.Context, []int) ([]T, error), parentID func(T) int) dataloader.FetchFunc[int, []T] {
+	return func(ctx context.Context, ids []int) (map[int][]T, error) {
+		rows, err := get(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[int][]T, len(ids))
+		for _, row := range rows {
+			values[parentID(row)] = append(values[parentID(row)], row)
+		}
+		return values, nil
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
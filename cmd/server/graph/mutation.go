@@ -0,0 +1,781 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// The inputs carry the same rules as the bodies of the /api/v2 requests. The
+// patches are applied over the input of the stored entity, which is then
+// validated as a whole.
+
+type LocalityInput struct {
+	PostalCode   int32  `validate:"gt=0"`
+	LocalityName string `validate:"required"`
+	ProvinceName string `validate:"required"`
+	CountryName  string `validate:"required"`
+}
+
+type SellerInput struct {
+	CID         int32  `validate:"gt=0"`
+	CompanyName string `validate:"required"`
+	Address     string `validate:"required"`
+	Telephone   string `validate:"required"`
+	LocalityID  int32  `validate:"gt=0"`
+}
+
+type SellerPatch struct {
+	CID         *int32
+	CompanyName *string
+	Address     *string
+	Telephone   *string
+	LocalityID  *int32
+}
+
+type CarryInput struct {
+	CID         string `validate:"required"`
+	CompanyName string `validate:"required"`
+	Address     string `validate:"required"`
+	Telephone   string `validate:"required"`
+	LocalityID  int32  `validate:"gt=0"`
+}
+
+type ProductInput struct {
+	Description                    string  `validate:"required"`
+	ExpirationRate                 float64 `validate:"gt=0,lte=100"`
+	FreezingRate                   float64 `validate:"gt=0,lte=100"`
+	Height                         float64 `validate:"gt=0"`
+	Length                         float64 `validate:"gt=0"`
+	NetWeight                      float64 `validate:"gt=0"`
+	ProductCode                    string  `validate:"required,max=100"`
+	RecommendedFreezingTemperature float64 `validate:"lte=100"`
+	Width                          float64 `validate:"gt=0"`
+	ProductTypeID                  int32   `validate:"gt=0"`
+	SellerID                       int32   `validate:"gte=0"`
+}
+
+type ProductPatch struct {
+	Description                    *string
+	ExpirationRate                 *float64
+	FreezingRate                   *float64
+	Height                         *float64
+	Length                         *float64
+	NetWeight                      *float64
+	ProductCode                    *string
+	RecommendedFreezingTemperature *float64
+	Width                          *float64
+	ProductTypeID                  *int32
+	SellerID                       *int32
+}
+
+type ProductRecordInput struct {
+	ProductID      int32   `validate:"gt=0"`
+	LastUpdateDate string  `validate:"datetime=2006-01-02"`
+	PurchasePrice  float64 `validate:"gt=0"`
+	SalePrice      float64 `validate:"gt=0"`
+	Currency       *string
+}
+
+type ProductBatchInput struct {
+	BatchNumber        int32 `validate:"gt=0"`
+	CurrentQuantity    int32 `validate:"gte=0"`
+	CurrentTemperature int32
+	DueDate            string `validate:"datetime=2006-01-02"`
+	InitialQuantity    int32  `validate:"gte=0"`
+	ManufacturingDate  string `validate:"datetime=2006-01-02"`
+	ManufacturingHour  int32  `validate:"gte=0,lte=23"`
+	MinimumTemperature int32
+	ProductID          int32 `validate:"gt=0"`
+	SectionID          int32 `validate:"gt=0"`
+}
+
+type SectionInput struct {
+	SectionNumber      int32 `validate:"gt=0"`
+	CurrentTemperature int32
+	MinimumTemperature int32
+	CurrentCapacity    int32 `validate:"gte=0"`
+	MinimumCapacity    int32 `validate:"gte=0"`
+	MaximumCapacity    int32 `validate:"gte=0"`
+	WarehouseID        int32 `validate:"gt=0"`
+	ProductTypeID      int32 `validate:"gt=0"`
+}
+
+type SectionPatch struct {
+	SectionNumber      *int32
+	CurrentTemperature *int32
+	MinimumTemperature *int32
+	CurrentCapacity    *int32
+	MinimumCapacity    *int32
+	MaximumCapacity    *int32
+	WarehouseID        *int32
+	ProductTypeID      *int32
+}
+
+type WarehouseInput struct {
+	Address            string `validate:"required"`
+	Telephone          string `validate:"required"`
+	WarehouseCode      string `validate:"required"`
+	MinimumCapacity    int32  `validate:"gte=0"`
+	MinimumTemperature int32
+}
+
+type WarehousePatch struct {
+	Address            *string
+	Telephone          *string
+	WarehouseCode      *string
+	MinimumCapacity    *int32
+	MinimumTemperature *int32
+}
+
+type EmployeeInput struct {
+	CardNumberID string `validate:"required"`
+	FirstName    string `validate:"required"`
+	LastName     string `validate:"required"`
+	WarehouseID  int32  `validate:"gt=0"`
+}
+
+type EmployeePatch struct {
+	FirstName   *string
+	LastName    *string
+	WarehouseID *int32
+}
+
+type InboundOrderInput struct {
+	OrderDate      string `validate:"datetime=2006-01-02"`
+	OrderNumber    string `validate:"required"`
+	EmployeeID     int32  `validate:"gt=0"`
+	ProductBatchID int32  `validate:"gt=0"`
+	WarehouseID    int32  `validate:"gt=0"`
+}
+
+type BuyerInput struct {
+	CardNumberID string `validate:"required"`
+	FirstName    string `validate:"required"`
+	LastName     string `validate:"required"`
+}
+
+type BuyerPatch struct {
+	FirstName *string
+	LastName  *string
+}
+
+type PurchaseOrderInput struct {
+	OrderNumber     string `validate:"required"`
+	OrderDate       string `validate:"datetime=2006-01-02"`
+	TrackingCode    string `validate:"required"`
+	BuyerID         int32  `validate:"gt=0"`
+	ProductRecordID int32  `validate:"gt=0"`
+	OrderStatusID   int32  `validate:"gt=0"`
+}
+
+// set overwrites dst with the value of a patch field when it is present.
+func set[T any](dst *T, value *T) {
+	if value != nil {
+		*dst = *value
+	}
+}
+
+func (r *Resolver) CreateLocality(ctx context.Context, args struct{ Input LocalityInput }) (*localityResolver, error) {
+	in := args.Input
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+	l := domain.Locality{
+		PostalCode:   int(in.PostalCode),
+		LocalityName: in.LocalityName,
+		ProvinceName: in.ProvinceName,
+		CountryName:  in.CountryName,
+	}
+	id, err := r.s.Locality.Save(ctx, l)
+	if err != nil {
+		return nil, toError(err)
+	}
+	l.ID = id
+	return &localityResolver{l}, nil
+}
+
+func (r *Resolver) CreateSeller(ctx context.Context, args struct{ Input SellerInput }) (*sellerResolver, error) {
+	if err := r.validateSeller(ctx, args.Input); err != nil {
+		return nil, err
+	}
+	s := args.Input.toSeller()
+	id, err := r.s.Seller.Save(ctx, s)
+	if err != nil {
+		return nil, toError(err)
+	}
+	s.ID = id
+	return &sellerResolver{s}, nil
+}
+
+func (r *Resolver) UpdateSeller(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input SellerPatch
+}) (*sellerResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.s.Seller.GetSellerByID(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	in := SellerInput{
+		CID:         int32(current.CID),
+		CompanyName: current.CompanyName,
+		Address:     current.Address,
+		Telephone:   current.Telephone,
+		LocalityID:  int32(current.IDLocality),
+	}
+	set(&in.CID, args.Input.CID)
+	set(&in.CompanyName, args.Input.CompanyName)
+	set(&in.Address, args.Input.Address)
+	set(&in.Telephone, args.Input.Telephone)
+	set(&in.LocalityID, args.Input.LocalityID)
+	if err := r.validateSeller(ctx, in); err != nil {
+		return nil, err
+	}
+
+	s := in.toSeller()
+	s.ID = id
+	if err := r.s.Seller.Update(ctx, s, id); err != nil {
+		return nil, toError(err)
+	}
+	return &sellerResolver{s}, nil
+}
+
+func (r *Resolver) DeleteSeller(ctx context.Context, args idArgs) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.s.Seller.Delete(ctx, id); err != nil {
+		return false, toError(err)
+	}
+	return true, nil
+}
+
+// validateSeller checks the input and that the locality of the seller exists.
+func (r *Resolver) validateSeller(ctx context.Context, in SellerInput) error {
+	if err := validateInput(in); err != nil {
+		return err
+	}
+	if !r.s.Seller.GetLocalityIdFromSeller(ctx, int(in.LocalityID)) {
+		return &Error{CodeBadUserInput, "locality does not exist"}
+	}
+	return nil
+}
+
+func (in SellerInput) toSeller() domain.Seller {
+	return domain.Seller{
+		CID:         int(in.CID),
+		CompanyName: in.CompanyName,
+		Address:     in.Address,
+		Telephone:   in.Telephone,
+		IDLocality:  int(in.LocalityID),
+	}
+}
+
+func (r *Resolver) CreateCarry(ctx context.Context, args struct{ Input CarryInput }) (*carryResolver, error) {
+	in := args.Input
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+	c := domain.Carries{
+		CID:         in.CID,
+		CompanyName: in.CompanyName,
+		Address:     in.Address,
+		Telephone:   in.Telephone,
+		LocalityID:  int(in.LocalityID),
+	}
+	id, err := r.s.Carry.Save(ctx, c)
+	if err != nil {
+		return nil, toError(err)
+	}
+	c.ID = id
+	return &carryResolver{c}, nil
+}
+
+func (r *Resolver) CreateProduct(ctx context.Context, args struct{ Input ProductInput }) (*productResolver, error) {
+	if err := validateInput(args.Input); err != nil {
+		return nil, err
+	}
+	p := args.Input.toProduct()
+	id, err := r.s.Product.Save(ctx, p)
+	if err != nil {
+		return nil, toError(err)
+	}
+	p.ID = id
+	return &productResolver{p}, nil
+}
+
+func (r *Resolver) UpdateProduct(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input ProductPatch
+}) (*productResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.s.Product.Get(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	in := ProductInput{
+		Description:                    current.Description,
+		ExpirationRate:                 float64(current.ExpirationRate),
+		FreezingRate:                   float64(current.FreezingRate),
+		Height:                         float64(current.Height),
+		Length:                         float64(current.Length),
+		NetWeight:                      float64(current.Netweight),
+		ProductCode:                    current.ProductCode,
+		RecommendedFreezingTemperature: float64(current.RecomFreezTemp),
+		Width:                          float64(current.Width),
+		ProductTypeID:                  int32(current.ProductTypeID),
+		SellerID:                       int32(current.SellerID),
+	}
+	patch := args.Input
+	set(&in.Description, patch.Description)
+	set(&in.ExpirationRate, patch.ExpirationRate)
+	set(&in.FreezingRate, patch.FreezingRate)
+	set(&in.Height, patch.Height)
+	set(&in.Length, patch.Length)
+	set(&in.NetWeight, patch.NetWeight)
+	set(&in.ProductCode, patch.ProductCode)
+	set(&in.RecommendedFreezingTemperature, patch.RecommendedFreezingTemperature)
+	set(&in.Width, patch.Width)
+	set(&in.ProductTypeID, patch.ProductTypeID)
+	set(&in.SellerID, patch.SellerID)
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+
+	p := in.toProduct()
+	p.ID = id
+	if err := r.s.Product.Update(ctx, p); err != nil {
+		return nil, toError(err)
+	}
+	return &productResolver{p}, nil
+}
+
+func (r *Resolver) DeleteProduct(ctx context.Context, args idArgs) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.s.Product.Delete(ctx, id); err != nil {
+		return false, toError(err)
+	}
+	return true, nil
+}
+
+func (in ProductInput) toProduct() domain.Product {
+	return domain.Product{
+		Description:    in.Description,
+		ExpirationRate: float32(in.ExpirationRate),
+		FreezingRate:   float32(in.FreezingRate),
+		Height:         float32(in.Height),
+		Length:         float32(in.Length),
+		Netweight:      float32(in.NetWeight),
+		ProductCode:    in.ProductCode,
+		RecomFreezTemp: float32(in.RecommendedFreezingTemperature),
+		Width:          float32(in.Width),
+		ProductTypeID:  int(in.ProductTypeID),
+		SellerID:       int(in.SellerID),
+	}
+}
+
+func (r *Resolver) CreateProductRecord(ctx context.Context, args struct{ Input ProductRecordInput }) (*productRecordResolver, error) {
+	in := args.Input
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+	currency := money.DefaultCurrency
+	if in.Currency != nil {
+		var err error
+		if currency, err = money.ParseCurrency(*in.Currency); err != nil {
+			return nil, toError(product.ErrInvalidCurrency)
+		}
+	}
+	purchase, err := money.FromFloat(in.PurchasePrice)
+	if err != nil {
+		return nil, toError(err)
+	}
+	sale, err := money.FromFloat(in.SalePrice)
+	if err != nil {
+		return nil, toError(err)
+	}
+	id, err := r.s.Product.CreateProductRecord(ctx, domain.ProductRecordCreate{
+		LastUpdate:    in.LastUpdateDate,
+		PurchasePrice: purchase,
+		SalePrice:     sale,
+		Currency:      currency,
+		ProductID:     int(in.ProductID),
+	})
+	if err != nil {
+		return nil, toError(err)
+	}
+	return &productRecordResolver{domain.ProductRecord{
+		ID:            id,
+		LastUpdate:    in.LastUpdateDate,
+		PurchasePrice: purchase,
+		SalePrice:     sale,
+		Currency:      currency,
+		ProductID:     int(in.ProductID),
+	}}, nil
+}
+
+func (r *Resolver) CreateProductBatch(ctx context.Context, args struct{ Input ProductBatchInput }) (*productBatchResolver, error) {
+	in := args.Input
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+	b := domain.ProductBatch{
+		BatchNumber:        int(in.BatchNumber),
+		CurrentQuantity:    int(in.CurrentQuantity),
+		CurrentTemperature: int(in.CurrentTemperature),
+		DueDate:            in.DueDate,
+		InitialQuantity:    int(in.InitialQuantity),
+		ManufacturingDate:  in.ManufacturingDate,
+		ManufacturingHour:  int(in.ManufacturingHour),
+		MinimumTemperature: int(in.MinimumTemperature),
+		ProductID:          int(in.ProductID),
+		SectionID:          int(in.SectionID),
+	}
+	saved, err := r.s.Batch.Save(ctx, b)
+	if err != nil {
+		return nil, toError(err)
+	}
+	b.ID = saved.ID
+	return &productBatchResolver{b}, nil
+}
+
+func (r *Resolver) CreateSection(ctx context.Context, args struct{ Input SectionInput }) (*sectionResolver, error) {
+	if err := validateInput(args.Input); err != nil {
+		return nil, err
+	}
+	s := args.Input.toSection()
+	id, err := r.s.Section.Save(ctx, s)
+	if err != nil {
+		return nil, toError(err)
+	}
+	s.ID = id
+	return &sectionResolver{s}, nil
+}
+
+func (r *Resolver) UpdateSection(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input SectionPatch
+}) (*sectionResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.s.Section.Get(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	in := SectionInput{
+		SectionNumber:      int32(current.SectionNumber),
+		CurrentTemperature: int32(current.CurrentTemperature),
+		MinimumTemperature: int32(current.MinimumTemperature),
+		CurrentCapacity:    int32(current.CurrentCapacity),
+		MinimumCapacity:    int32(current.MinimumCapacity),
+		MaximumCapacity:    int32(current.MaximumCapacity),
+		WarehouseID:        int32(current.WarehouseID),
+		ProductTypeID:      int32(current.ProductTypeID),
+	}
+	patch := args.Input
+	set(&in.SectionNumber, patch.SectionNumber)
+	set(&in.CurrentTemperature, patch.CurrentTemperature)
+	set(&in.MinimumTemperature, patch.MinimumTemperature)
+	set(&in.CurrentCapacity, patch.CurrentCapacity)
+	set(&in.MinimumCapacity, patch.MinimumCapacity)
+	set(&in.MaximumCapacity, patch.MaximumCapacity)
+	set(&in.WarehouseID, patch.WarehouseID)
+	set(&in.ProductTypeID, patch.ProductTypeID)
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+
+	s := in.toSection()
+	s.ID = id
+	if err := r.s.Section.Update(ctx, s); err != nil {
+		return nil, toError(err)
+	}
+	return &sectionResolver{s}, nil
+}
+
+func (r *Resolver) DeleteSection(ctx context.Context, args idArgs) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.s.Section.Delete(ctx, id); err != nil {
+		return false, toError(err)
+	}
+	return true, nil
+}
+
+func (in SectionInput) toSection() domain.Section {
+	return domain.Section{
+		SectionNumber:      int(in.SectionNumber),
+		CurrentTemperature: int(in.CurrentTemperature),
+		MinimumTemperature: int(in.MinimumTemperature),
+		CurrentCapacity:    int(in.CurrentCapacity),
+		MinimumCapacity:    int(in.MinimumCapacity),
+		MaximumCapacity:    int(in.MaximumCapacity),
+		WarehouseID:        int(in.WarehouseID),
+		ProductTypeID:      int(in.ProductTypeID),
+	}
+}
+
+func (r *Resolver) CreateWarehouse(ctx context.Context, args struct{ Input WarehouseInput }) (*warehouseResolver, error) {
+	if err := validateInput(args.Input); err != nil {
+		return nil, err
+	}
+	w := args.Input.toWarehouse()
+	id, err := r.s.Warehouse.Save(ctx, w)
+	if err != nil {
+		return nil, toError(err)
+	}
+	w.ID = id
+	return &warehouseResolver{w}, nil
+}
+
+func (r *Resolver) UpdateWarehouse(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input WarehousePatch
+}) (*warehouseResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.s.Warehouse.Get(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	in := WarehouseInput{
+		Address:            current.Address,
+		Telephone:          current.Telephone,
+		WarehouseCode:      current.WarehouseCode,
+		MinimumCapacity:    int32(current.MinimumCapacity),
+		MinimumTemperature: int32(current.MinimumTemperature),
+	}
+	set(&in.Address, args.Input.Address)
+	set(&in.Telephone, args.Input.Telephone)
+	set(&in.WarehouseCode, args.Input.WarehouseCode)
+	set(&in.MinimumCapacity, args.Input.MinimumCapacity)
+	set(&in.MinimumTemperature, args.Input.MinimumTemperature)
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+
+	w := in.toWarehouse()
+	w.ID = id
+	if err := r.s.Warehouse.Update(ctx, w); err != nil {
+		return nil, toError(err)
+	}
+	return &warehouseResolver{w}, nil
+}
+
+func (r *Resolver) DeleteWarehouse(ctx context.Context, args idArgs) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.s.Warehouse.Delete(ctx, id); err != nil {
+		return false, toError(err)
+	}
+	return true, nil
+}
+
+func (in WarehouseInput) toWarehouse() domain.Warehouse {
+	return domain.Warehouse{
+		Address:            in.Address,
+		Telephone:          in.Telephone,
+		WarehouseCode:      in.WarehouseCode,
+		MinimumCapacity:    int(in.MinimumCapacity),
+		MinimumTemperature: int(in.MinimumTemperature),
+	}
+}
+
+func (r *Resolver) CreateEmployee(ctx context.Context, args struct{ Input EmployeeInput }) (*employeeResolver, error) {
+	if err := validateInput(args.Input); err != nil {
+		return nil, err
+	}
+	e := args.Input.toEmployee()
+	id, err := r.s.Employee.SaveEmployee(ctx, e)
+	if err != nil {
+		return nil, toError(err)
+	}
+	e.ID = id
+	return &employeeResolver{e}, nil
+}
+
+func (r *Resolver) UpdateEmployee(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input EmployeePatch
+}) (*employeeResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.s.Employee.GetEmployeeByID(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	in := EmployeeInput{
+		CardNumberID: current.CardNumberID,
+		FirstName:    current.FirstName,
+		LastName:     current.LastName,
+		WarehouseID:  int32(current.WarehouseID),
+	}
+	set(&in.FirstName, args.Input.FirstName)
+	set(&in.LastName, args.Input.LastName)
+	set(&in.WarehouseID, args.Input.WarehouseID)
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+
+	e := in.toEmployee()
+	e.ID = id
+	if err := r.s.Employee.UpdateEmployee(ctx, e); err != nil {
+		return nil, toError(err)
+	}
+	return &employeeResolver{e}, nil
+}
+
+func (r *Resolver) DeleteEmployee(ctx context.Context, args idArgs) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.s.Employee.DeleteEmployee(ctx, id); err != nil {
+		return false, toError(err)
+	}
+	return true, nil
+}
+
+func (in EmployeeInput) toEmployee() domain.Employee {
+	return domain.Employee{
+		CardNumberID: in.CardNumberID,
+		FirstName:    in.FirstName,
+		LastName:     in.LastName,
+		WarehouseID:  int(in.WarehouseID),
+	}
+}
+
+func (r *Resolver) CreateInboundOrder(ctx context.Context, args struct{ Input InboundOrderInput }) (*inboundOrderResolver, error) {
+	in := args.Input
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+	order := domain.InboudOrder{
+		OrderDate:      in.OrderDate,
+		OrderNumber:    in.OrderNumber,
+		EmployeeID:     int(in.EmployeeID),
+		ProductBatchID: int(in.ProductBatchID),
+		WarehouseID:    int(in.WarehouseID),
+	}
+	id, err := r.s.InboundOrder.CreateInboundOrder(ctx, order)
+	if err != nil {
+		return nil, toError(err)
+	}
+	order.ID = id
+	return &inboundOrderResolver{order}, nil
+}
+
+func (r *Resolver) CreateBuyer(ctx context.Context, args struct{ Input BuyerInput }) (*buyerResolver, error) {
+	in := args.Input
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+	b := domain.Buyer{
+		CardNumberID: in.CardNumberID,
+		FirstName:    in.FirstName,
+		LastName:     in.LastName,
+	}
+	id, err := r.s.Buyer.Save(ctx, b)
+	if err != nil {
+		return nil, toError(err)
+	}
+	b.ID = id
+	return &buyerResolver{b}, nil
+}
+
+func (r *Resolver) UpdateBuyer(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input BuyerPatch
+}) (*buyerResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.s.Buyer.Get(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	in := BuyerInput{
+		CardNumberID: current.CardNumberID,
+		FirstName:    current.FirstName,
+		LastName:     current.LastName,
+	}
+	set(&in.FirstName, args.Input.FirstName)
+	set(&in.LastName, args.Input.LastName)
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+
+	// the service only changes the names of current
+	toUpdate := domain.Buyer{
+		ID:        id,
+		FirstName: in.FirstName,
+		LastName:  in.LastName,
+	}
+	if err := r.s.Buyer.Update(ctx, id, toUpdate, &current); err != nil {
+		return nil, toError(err)
+	}
+	return &buyerResolver{current}, nil
+}
+
+func (r *Resolver) DeleteBuyer(ctx context.Context, args idArgs) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.s.Buyer.Delete(ctx, id); err != nil {
+		return false, toError(err)
+	}
+	return true, nil
+}
+
+func (r *Resolver) CreatePurchaseOrder(ctx context.Context, args struct{ Input PurchaseOrderInput }) (*purchaseOrderResolver, error) {
+	in := args.Input
+	if err := validateInput(in); err != nil {
+		return nil, err
+	}
+	po := domain.PurchaseOrder{
+		OrderNumber:     in.OrderNumber,
+		OrderDate:       in.OrderDate,
+		TrackingCode:    in.TrackingCode,
+		BuyerID:         int(in.BuyerID),
+		ProductRecordID: int(in.ProductRecordID),
+		OrderStatusID:   int(in.OrderStatusID),
+	}
+	id, err := r.s.PurchaseOrder.Save(ctx, po)
+	if err != nil {
+		return nil, toError(err)
+	}
+	po.ID = id
+	return &purchaseOrderResolver{po}, nil
+}
@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolver_Mutations(t *testing.T) {
+	t.Run("it should create a seller through the service", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		s := domain.Seller{CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "555", IDLocality: 3}
+		m.seller.On("GetLocalityIdFromSeller", mock.Anything, 3).Return(true)
+		m.seller.On("Save", mock.Anything, s).Return(4, nil)
+
+		// Act
+		response := m.post(t, `mutation($input: SellerInput!) { createSeller(input: $input) { id cid localityId } }`,
+			map[string]interface{}{"input": map[string]interface{}{
+				"cid": 10, "companyName": "Acme", "address": "Street 1", "telephone": "555", "localityId": 3,
+			}})
+
+		// Assert
+		assert.JSONEq(t, `{"data":{"createSeller":{"id":"4","cid":10,"localityId":3}}}`, response.Body.String())
+		m.seller.AssertExpectations(t)
+	})
+
+	t.Run("it should reject a seller whose locality does not exist", func(t *testing.T) {
+		m := newMocks()
+		m.seller.On("GetLocalityIdFromSeller", mock.Anything, 3).Return(false)
+
+		response := m.post(t, `mutation { createSeller(input: {cid: 10, companyName: "Acme", address: "A", telephone: "5", localityId: 3}) { id } }`, nil)
+
+		assert.JSONEq(t, `{"errors":[{"message":"locality does not exist","path":["createSeller"],"extensions":{"code":"BAD_USER_INPUT"}}],
+			"data":null}`, response.Body.String())
+		m.seller.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should describe every invalid field of an input", func(t *testing.T) {
+		response := newMocks().post(t, `mutation { createProductBatch(input: {
+			batchNumber: 0, currentQuantity: 1, currentTemperature: 1, dueDate: "01/01/2024", initialQuantity: 1,
+			manufacturingDate: "2023-01-01", manufacturingHour: 25, minimumTemperature: 1, productId: 1, sectionId: 1}) { id } }`, nil)
+
+		assert.JSONEq(t, `{"errors":[{"message":"batchNumber must be greater than 0; dueDate must match the format YYYY-MM-DD; manufacturingHour must be less than or equal to 23",
+			"path":["createProductBatch"],"extensions":{"code":"BAD_USER_INPUT"}}],"data":null}`, response.Body.String())
+	})
+
+	t.Run("it should only change the fields present in a patch", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		current := domain.Section{ID: 2, SectionNumber: 5, CurrentTemperature: 1, MinimumTemperature: -3, CurrentCapacity: 10,
+			MinimumCapacity: 1, MaximumCapacity: 50, WarehouseID: 1, ProductTypeID: 1}
+		updated := current
+		updated.CurrentCapacity = 0
+		m.section.On("Get", mock.Anything, 2).Return(current, nil)
+		m.section.On("Update", mock.Anything, updated).Return(nil)
+
+		// Act
+		response := m.post(t, `mutation { updateSection(id: 2, input: {currentCapacity: 0}) { id sectionNumber currentCapacity } }`, nil)
+
+		// Assert
+		assert.JSONEq(t, `{"data":{"updateSection":{"id":"2","sectionNumber":5,"currentCapacity":0}}}`, response.Body.String())
+		m.section.AssertExpectations(t)
+	})
+
+	t.Run("it should return CONFLICT when the section number is taken", func(t *testing.T) {
+		m := newMocks()
+		m.section.On("Get", mock.Anything, 2).Return(domain.Section{ID: 2, SectionNumber: 5, WarehouseID: 1, ProductTypeID: 1}, nil)
+		m.section.On("Update", mock.Anything, mock.Anything).Return(section.ErrDuplicateSectNumber)
+
+		response := m.post(t, `mutation { updateSection(id: 2, input: {sectionNumber: 6}) { id } }`, nil)
+
+		assert.JSONEq(t, `{"errors":[{"message":"sectionNumber already exists","path":["updateSection"],"extensions":{"code":"CONFLICT"}}],
+			"data":null}`, response.Body.String())
+	})
+
+	t.Run("it should update the names of a buyer", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		current := domain.Buyer{ID: 1, CardNumberID: "C1", FirstName: "Ada", LastName: "Lovelace"}
+		m.buyer.On("Get", mock.Anything, 1).Return(current, nil)
+		m.buyer.On("Update", mock.Anything, 1, domain.Buyer{ID: 1, FirstName: "Grace", LastName: "Lovelace"}, mock.Anything).
+			Return(nil)
+
+		// Act
+		response := m.post(t, `mutation { updateBuyer(id: 1, input: {firstName: "Grace"}) { firstName lastName } }`, nil)
+
+		// Assert
+		assert.JSONEq(t, `{"data":{"updateBuyer":{"firstName":"Grace","lastName":"Lovelace"}}}`, response.Body.String())
+		m.buyer.AssertExpectations(t)
+	})
+
+	t.Run("it should delete a buyer", func(t *testing.T) {
+		m := newMocks()
+		m.buyer.On("Delete", mock.Anything, 1).Return(nil)
+
+		response := m.post(t, `mutation { deleteBuyer(id: 1) }`, nil)
+
+		assert.JSONEq(t, `{"data":{"deleteBuyer":true}}`, response.Body.String())
+	})
+
+	t.Run("it should return NOT_FOUND when deleting a missing buyer", func(t *testing.T) {
+		m := newMocks()
+		m.buyer.On("Delete", mock.Anything, 1).Return(buyer.ErrNotFound)
+
+		response := m.post(t, `mutation { deleteBuyer(id: 1) }`, nil)
+
+		assert.JSONEq(t, `{"errors":[{"message":"buyer not found","path":["deleteBuyer"],"extensions":{"code":"NOT_FOUND"}}],
+			"data":null}`, response.Body.String())
+	})
+
+	t.Run("it should create a purchase order through the service", func(t *testing.T) {
+		m := newMocks()
+		po := domain.PurchaseOrder{OrderNumber: "PO-1", OrderDate: "2024-01-01", TrackingCode: "T1", BuyerID: 1, ProductRecordID: 2, OrderStatusID: 1}
+		m.purchaseOrder.On("Save", mock.Anything, po).Return(7, nil)
+
+		response := m.post(t, `mutation { createPurchaseOrder(input: {orderNumber: "PO-1", orderDate: "2024-01-01", trackingCode: "T1",
+			buyerId: 1, productRecordId: 2, orderStatusId: 1}) { id orderNumber } }`, nil)
+
+		assert.JSONEq(t, `{"data":{"createPurchaseOrder":{"id":"7","orderNumber":"PO-1"}}}`, response.Body.String())
+	})
+}
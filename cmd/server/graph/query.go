@@ -0,0 +1,292 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Resolver is the root resolver of the queries and the mutations.
+type Resolver struct {
+	s Services
+}
+
+type idArgs struct {
+	ID graphql.ID
+}
+
+func (r *Resolver) Localities(ctx context.Context) ([]*localityResolver, error) {
+	localities, err := r.s.Locality.GetAll(ctx)
+	if err != nil && !errors.Is(err, locality.ErrNoRows) {
+		return nil, toError(err)
+	}
+	return resolve(localities, func(l domain.Locality) *localityResolver { return &localityResolver{l} }), nil
+}
+
+func (r *Resolver) Locality(ctx context.Context, args idArgs) (*localityResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	l, err := r.s.Locality.GetLocalityByID(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return &localityResolver{l}, nil
+}
+
+func (r *Resolver) Sellers(ctx context.Context) ([]*sellerResolver, error) {
+	sellers, err := r.s.Seller.GetAllSellers(ctx)
+	if err != nil && !errors.Is(err, seller.ErrNotFound) {
+		return nil, toError(err)
+	}
+	return resolve(sellers, func(s domain.Seller) *sellerResolver { return &sellerResolver{s} }), nil
+}
+
+func (r *Resolver) Seller(ctx context.Context, args idArgs) (*sellerResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := r.s.Seller.GetSellerByID(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return &sellerResolver{s}, nil
+}
+
+func (r *Resolver) Carries(ctx context.Context) ([]*carryResolver, error) {
+	list, err := r.s.Carry.GetAll(ctx)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(list, func(c domain.Carries) *carryResolver { return &carryResolver{c} }), nil
+}
+
+func (r *Resolver) Products(ctx context.Context) ([]*productResolver, error) {
+	products, err := r.s.Product.GetAll(ctx)
+	if err != nil && !errors.Is(err, product.ErrNotFound) {
+		return nil, toError(err)
+	}
+	return resolve(products, func(p domain.Product) *productResolver { return &productResolver{p} }), nil
+}
+
+func (r *Resolver) Product(ctx context.Context, args idArgs) (*productResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.s.Product.Get(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return &productResolver{p}, nil
+}
+
+func (r *Resolver) ProductBatches(ctx context.Context) ([]*productBatchResolver, error) {
+	batches, err := r.s.Batch.GetAll(ctx)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(batches, func(b domain.ProductBatch) *productBatchResolver { return &productBatchResolver{b} }), nil
+}
+
+func (r *Resolver) Sections(ctx context.Context) ([]*sectionResolver, error) {
+	sections, err := r.s.Section.GetAll(ctx)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(sections, func(s domain.Section) *sectionResolver { return &sectionResolver{s} }), nil
+}
+
+func (r *Resolver) Section(ctx context.Context, args idArgs) (*sectionResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := r.s.Section.Get(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return &sectionResolver{s}, nil
+}
+
+func (r *Resolver) Warehouses(ctx context.Context) ([]*warehouseResolver, error) {
+	warehouses, err := r.s.Warehouse.GetAll(ctx)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(warehouses, func(w domain.Warehouse) *warehouseResolver { return &warehouseResolver{w} }), nil
+}
+
+func (r *Resolver) Warehouse(ctx context.Context, args idArgs) (*warehouseResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	w, err := r.s.Warehouse.Get(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return &warehouseResolver{w}, nil
+}
+
+func (r *Resolver) Employees(ctx context.Context) ([]*employeeResolver, error) {
+	employees, err := r.s.Employee.GetAllEmployees(ctx)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(employees, func(e domain.Employee) *employeeResolver { return &employeeResolver{e} }), nil
+}
+
+func (r *Resolver) Employee(ctx context.Context, args idArgs) (*employeeResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	e, err := r.s.Employee.GetEmployeeByID(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return &employeeResolver{e}, nil
+}
+
+func (r *Resolver) Buyers(ctx context.Context) ([]*buyerResolver, error) {
+	buyers, err := r.s.Buyer.GetAll(ctx)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(buyers, func(b domain.Buyer) *buyerResolver { return &buyerResolver{b} }), nil
+}
+
+func (r *Resolver) Buyer(ctx context.Context, args idArgs) (*buyerResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.s.Buyer.Get(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return &buyerResolver{b}, nil
+}
+
+func (r *Resolver) SellerReports(ctx context.Context, args struct{ LocalityID *graphql.ID }) ([]*sellerReportResolver, error) {
+	id, err := optionalID(args.LocalityID)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := r.s.Locality.GetReportSellers(ctx, id)
+	if err != nil && !errors.Is(err, locality.ErrNoRows) {
+		return nil, toError(err)
+	}
+	// The report has a row for every matching locality, even without sellers.
+	if id != 0 && len(reports) == 0 {
+		return nil, notFound("locality")
+	}
+	return resolve(reports, func(rs domain.ReportSellers) *sellerReportResolver { return &sellerReportResolver{rs} }), nil
+}
+
+func (r *Resolver) CarryReports(ctx context.Context, args struct{ LocalityID *graphql.ID }) ([]*carryReportResolver, error) {
+	id, err := optionalID(args.LocalityID)
+	if err != nil {
+		return nil, err
+	}
+	var reports []domain.LocalityCarries
+	if id == 0 {
+		reports, err = r.s.Carry.GetAllCarriesByLocality(ctx)
+	} else {
+		var report domain.LocalityCarries
+		report, err = r.s.Carry.GetAllCarriesByLocalityID(ctx, id)
+		reports = []domain.LocalityCarries{report}
+	}
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(reports, func(lc domain.LocalityCarries) *carryReportResolver { return &carryReportResolver{lc} }), nil
+}
+
+func (r *Resolver) ProductRecordReports(ctx context.Context, args struct{ ProductID *graphql.ID }) ([]*productRecordReportResolver, error) {
+	id, err := optionalID(args.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := r.s.Product.GetProductRecord(ctx, id)
+	if err != nil && (id != 0 || !errors.Is(err, product.ErrNotFound)) {
+		return nil, toError(err)
+	}
+	if id != 0 && len(reports) == 0 {
+		return nil, notFound("product")
+	}
+	return resolve(reports, func(pr domain.ProductRecordGet) *productRecordReportResolver { return &productRecordReportResolver{pr} }), nil
+}
+
+func (r *Resolver) SectionProductReports(ctx context.Context, args struct{ SectionID *graphql.ID }) ([]*sectionProductReportResolver, error) {
+	id, err := optionalID(args.SectionID)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := r.s.Section.ProductCount(ctx, id)
+	if err != nil && (id != 0 || !errors.Is(err, section.ErrNotFound)) {
+		return nil, toError(err)
+	}
+	if id != 0 && len(reports) == 0 {
+		return nil, notFound("section")
+	}
+	return resolve(reports, func(pc section.ProdCountResponse) *sectionProductReportResolver {
+		return &sectionProductReportResolver{pc.ID, pc.SectionNumber, pc.ProductCount}
+	}), nil
+}
+
+func (r *Resolver) InboundOrderReports(ctx context.Context, args struct{ EmployeeID *graphql.ID }) ([]*inboundOrderReportResolver, error) {
+	id, err := optionalID(args.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	if id != 0 {
+		report, err := r.s.InboundOrder.GenerateReport(ctx, id)
+		if err != nil {
+			return nil, toError(err)
+		}
+		if report.Employee == nil {
+			return nil, notFound("employee")
+		}
+		return []*inboundOrderReportResolver{{*report.Employee, report.InboudOrdersCount}}, nil
+	}
+
+	reports, err := r.s.InboundOrder.GetAllReports(ctx)
+	if err != nil {
+		return nil, toError(err)
+	}
+	list := make([]*inboundOrderReportResolver, 0, len(reports))
+	for _, report := range reports {
+		if report.Employee != nil {
+			list = append(list, &inboundOrderReportResolver{*report.Employee, report.InboudOrdersCount})
+		}
+	}
+	return list, nil
+}
+
+func (r *Resolver) PurchaseOrderReports(ctx context.Context, args struct{ BuyerID *graphql.ID }) ([]*purchaseOrderReportResolver, error) {
+	id, err := optionalID(args.BuyerID)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := r.s.PurchaseOrder.PurchaseOrdersByBuyer(ctx, id)
+	if err != nil && !errors.Is(err, purchase_order.ErrBuyerIDNotExists) {
+		return nil, toError(err)
+	}
+	if id != 0 && len(reports) == 0 {
+		return nil, notFound("buyer")
+	}
+	return resolve(reports, func(po domain.PurchaseOrdersByBuyer) *purchaseOrderReportResolver {
+		return &purchaseOrderReportResolver{po}
+	}), nil
+}
@@ -0,0 +1,195 @@
+package graph
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolver_Queries(t *testing.T) {
+	t.Run("it should return a seller with its locality", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		m.seller.On("GetSellerByID", mock.Anything, 1).Return(domain.Seller{ID: 1, CID: 10, CompanyName: "Acme", IDLocality: 3}, nil)
+		m.locality.On("GetLocalitiesByIDs", mock.Anything, []int{3}).Return([]domain.Locality{{ID: 3, LocalityName: "Palermo"}}, nil)
+
+		// Act
+		response := m.post(t, `query($id: ID!) { seller(id: $id) { id cid companyName locality { id localityName } } }`,
+			map[string]interface{}{"id": "1"})
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"seller":{"id":"1","cid":10,"companyName":"Acme","locality":{"id":"3","localityName":"Palermo"}}}}`,
+			response.Body.String())
+		m.seller.AssertExpectations(t)
+		m.locality.AssertExpectations(t)
+	})
+
+	t.Run("it should load a relation of every item of a list in a single call", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		m.product.On("GetAll", mock.Anything).Return([]domain.Product{
+			{ID: 1, SellerID: 7}, {ID: 2, SellerID: 8}, {ID: 3, SellerID: 7},
+		}, nil)
+		m.seller.On("GetSellersByIDs", mock.Anything, mock.MatchedBy(func(ids []int) bool {
+			return assert.ElementsMatch(t, []int{7, 8}, ids)
+		})).Return([]domain.Seller{{ID: 7, CompanyName: "Acme"}, {ID: 8, CompanyName: "Globex"}}, nil).Once()
+		m.batch.On("GetByProductIDs", mock.Anything, mock.MatchedBy(func(ids []int) bool {
+			return assert.ElementsMatch(t, []int{1, 2, 3}, ids)
+		})).Return([]domain.ProductBatch{{ID: 10, ProductID: 1}, {ID: 11, ProductID: 1}, {ID: 12, ProductID: 3}}, nil).Once()
+
+		// Act
+		response := m.post(t, `{ products { id seller { companyName } batches { id } } }`, nil)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"products":[
+			{"id":"1","seller":{"companyName":"Acme"},"batches":[{"id":"10"},{"id":"11"}]},
+			{"id":"2","seller":{"companyName":"Globex"},"batches":[]},
+			{"id":"3","seller":{"companyName":"Acme"},"batches":[{"id":"12"}]}]}}`, response.Body.String())
+		m.seller.AssertNumberOfCalls(t, "GetSellersByIDs", 1)
+		m.batch.AssertNumberOfCalls(t, "GetByProductIDs", 1)
+	})
+
+	t.Run("it should resolve nested relations down to the employees of a warehouse", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		m.batch.On("GetAll", mock.Anything).Return([]domain.ProductBatch{{ID: 1, SectionID: 4}, {ID: 2, SectionID: 4}}, nil)
+		m.section.On("GetByIDs", mock.Anything, []int{4}).Return([]domain.Section{{ID: 4, WarehouseID: 5}}, nil).Once()
+		m.warehouse.On("GetByIDs", mock.Anything, []int{5}).Return([]domain.Warehouse{{ID: 5, WarehouseCode: "W5"}}, nil).Once()
+		m.employee.On("GetEmployeesByWarehouseIDs", mock.Anything, []int{5}).Return([]domain.Employee{{ID: 9, WarehouseID: 5, FirstName: "Ada"}}, nil).Once()
+
+		// Act
+		response := m.post(t, `{ productBatches { id section { warehouse { warehouseCode employees { firstName } } } } }`, nil)
+
+		// Assert
+		assert.JSONEq(t, `{"data":{"productBatches":[
+			{"id":"1","section":{"warehouse":{"warehouseCode":"W5","employees":[{"firstName":"Ada"}]}}},
+			{"id":"2","section":{"warehouse":{"warehouseCode":"W5","employees":[{"firstName":"Ada"}]}}}]}}`, response.Body.String())
+		m.section.AssertExpectations(t)
+		m.warehouse.AssertExpectations(t)
+		m.employee.AssertExpectations(t)
+	})
+
+	t.Run("it should return the purchase orders of the buyers", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		m.buyer.On("GetAll", mock.Anything).Return([]domain.Buyer{{ID: 1}, {ID: 2}}, nil)
+		m.purchaseOrder.On("GetByBuyerIDs", mock.Anything, mock.Anything).Return([]domain.PurchaseOrder{{ID: 3, BuyerID: 2, OrderNumber: "PO-3"}}, nil).Once()
+
+		// Act
+		response := m.post(t, `{ buyers { id purchaseOrders { orderNumber } } }`, nil)
+
+		// Assert
+		assert.JSONEq(t, `{"data":{"buyers":[{"id":"1","purchaseOrders":[]},{"id":"2","purchaseOrders":[{"orderNumber":"PO-3"}]}]}}`,
+			response.Body.String())
+	})
+
+	t.Run("it should return null for a relation whose row does not exist", func(t *testing.T) {
+		m := newMocks()
+		m.seller.On("GetSellerByID", mock.Anything, 1).Return(domain.Seller{ID: 1, IDLocality: 3}, nil)
+		m.locality.On("GetLocalitiesByIDs", mock.Anything, []int{3}).Return([]domain.Locality(nil), nil)
+
+		response := m.post(t, `{ seller(id: 1) { id locality { id } } }`, nil)
+
+		assert.JSONEq(t, `{"data":{"seller":{"id":"1","locality":null}}}`, response.Body.String())
+	})
+
+	t.Run("it should return an empty list when the service reports no sellers", func(t *testing.T) {
+		m := newMocks()
+		m.seller.On("GetAllSellers", mock.Anything).Return([]domain.Seller(nil), seller.ErrNotFound)
+
+		response := m.post(t, `{ sellers { id } }`, nil)
+
+		assert.JSONEq(t, `{"data":{"sellers":[]}}`, response.Body.String())
+	})
+
+	t.Run("it should compute the volume of a product in cubic metres", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("Get", mock.Anything, 1).Return(domain.Product{ID: 1, Height: 10, Length: 20, Width: 30}, nil)
+
+		response := m.post(t, `{ product(id: 1) { id volume } }`, nil)
+
+		assert.JSONEq(t, `{"data":{"product":{"id":"1","volume":0.006}}}`, response.Body.String())
+	})
+
+	t.Run("it should return a NOT_FOUND error when the product does not exist", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("Get", mock.Anything, 9).Return(domain.Product{}, product.ErrNotFound)
+
+		response := m.post(t, `{ product(id: 9) { id } }`, nil)
+
+		assert.JSONEq(t, `{"errors":[{"message":"product not found","path":["product"],"extensions":{"code":"NOT_FOUND"}}],
+			"data":{"product":null}}`, response.Body.String())
+	})
+
+	t.Run("it should return a BAD_USER_INPUT error when the id is not a positive integer", func(t *testing.T) {
+		response := newMocks().post(t, `{ section(id: "abc") { id } }`, nil)
+
+		assert.JSONEq(t, `{"errors":[{"message":"id must be a positive integer","path":["section"],"extensions":{"code":"BAD_USER_INPUT"}}],
+			"data":{"section":null}}`, response.Body.String())
+	})
+
+	t.Run("it should hide the message of unexpected errors", func(t *testing.T) {
+		m := newMocks()
+		m.warehouse.On("GetAll", mock.Anything).Return([]domain.Warehouse(nil), errors.New("connection refused"))
+
+		response := m.post(t, `{ warehouses { id } }`, nil)
+
+		assert.JSONEq(t, `{"errors":[{"message":"internal server error","path":["warehouses"],"extensions":{"code":"INTERNAL_SERVER_ERROR"}}],
+			"data":null}`, response.Body.String())
+	})
+}
+
+func TestResolver_Reports(t *testing.T) {
+	t.Run("it should report the sellers of every locality", func(t *testing.T) {
+		m := newMocks()
+		m.locality.On("GetReportSellers", mock.Anything, 0).Return([]domain.ReportSellers{
+			{Locality_id: 1, Locality_name: "Palermo", Postal_code: 1414, Sellers_count: 2},
+		}, nil)
+
+		response := m.post(t, `{ sellerReports { localityId localityName postalCode sellersCount } }`, nil)
+
+		assert.JSONEq(t, `{"data":{"sellerReports":[{"localityId":1,"localityName":"Palermo","postalCode":1414,"sellersCount":2}]}}`,
+			response.Body.String())
+	})
+
+	t.Run("it should report the carries of a locality", func(t *testing.T) {
+		m := newMocks()
+		m.carry.On("GetAllCarriesByLocalityID", mock.Anything, 2).Return(domain.LocalityCarries{LocalityID: "2", LocalityName: "Belgrano", CarriesCount: 4}, nil)
+
+		response := m.post(t, `{ carryReports(localityId: 2) { localityId localityName carriesCount } }`, nil)
+
+		assert.JSONEq(t, `{"data":{"carryReports":[{"localityId":2,"localityName":"Belgrano","carriesCount":4}]}}`, response.Body.String())
+	})
+
+	t.Run("it should return NOT_FOUND when the reported buyer does not exist", func(t *testing.T) {
+		m := newMocks()
+		m.purchaseOrder.On("PurchaseOrdersByBuyer", mock.Anything, 5).Return([]domain.PurchaseOrdersByBuyer(nil), nil)
+
+		response := m.post(t, `{ purchaseOrderReports(buyerId: 5) { purchaseOrdersCount } }`, nil)
+
+		assert.JSONEq(t, `{"errors":[{"message":"buyer not found","path":["purchaseOrderReports"],"extensions":{"code":"NOT_FOUND"}}],
+			"data":null}`, response.Body.String())
+	})
+
+	t.Run("it should report the inbound orders of an employee", func(t *testing.T) {
+		m := newMocks()
+		m.inboundOrder.On("GenerateReport", mock.Anything, 3).Return(inboudorder.Report{
+			Employee:          &domain.Employee{ID: 3, FirstName: "Ada"},
+			InboudOrdersCount: 6,
+		}, nil)
+
+		response := m.post(t, `{ inboundOrderReports(employeeId: 3) { employee { id firstName } inboundOrdersCount } }`, nil)
+
+		assert.JSONEq(t, `{"data":{"inboundOrderReports":[{"employee":{"id":"3","firstName":"Ada"},"inboundOrdersCount":6}]}}`,
+			response.Body.String())
+	})
+}
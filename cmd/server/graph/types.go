@@ -0,0 +1,265 @@
+package graph
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/units"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type localityResolver struct{ l domain.Locality }
+
+func (r *localityResolver) ID() graphql.ID       { return toID(r.l.ID) }
+func (r *localityResolver) PostalCode() int32    { return int32(r.l.PostalCode) }
+func (r *localityResolver) LocalityName() string { return r.l.LocalityName }
+func (r *localityResolver) ProvinceName() string { return r.l.ProvinceName }
+func (r *localityResolver) CountryName() string  { return r.l.CountryName }
+
+type sellerResolver struct{ s domain.Seller }
+
+func (r *sellerResolver) ID() graphql.ID      { return toID(r.s.ID) }
+func (r *sellerResolver) CID() int32          { return int32(r.s.CID) }
+func (r *sellerResolver) CompanyName() string { return r.s.CompanyName }
+func (r *sellerResolver) Address() string     { return r.s.Address }
+func (r *sellerResolver) Telephone() string   { return r.s.Telephone }
+func (r *sellerResolver) LocalityID() int32   { return int32(r.s.IDLocality) }
+
+func (r *sellerResolver) Locality(ctx context.Context) (*localityResolver, error) {
+	l, err := loadersFrom(ctx).locality.Load(ctx, r.s.IDLocality)
+	if err != nil {
+		return nil, toError(err)
+	}
+	if l == nil {
+		return nil, nil
+	}
+	return &localityResolver{*l}, nil
+}
+
+type carryResolver struct{ c domain.Carries }
+
+func (r *carryResolver) ID() graphql.ID      { return toID(r.c.ID) }
+func (r *carryResolver) CID() string         { return r.c.CID }
+func (r *carryResolver) CompanyName() string { return r.c.CompanyName }
+func (r *carryResolver) Address() string     { return r.c.Address }
+func (r *carryResolver) Telephone() string   { return r.c.Telephone }
+func (r *carryResolver) LocalityID() int32   { return int32(r.c.LocalityID) }
+
+type productResolver struct{ p domain.Product }
+
+func (r *productResolver) ID() graphql.ID          { return toID(r.p.ID) }
+func (r *productResolver) Description() string     { return r.p.Description }
+func (r *productResolver) ExpirationRate() float64 { return float64(r.p.ExpirationRate) }
+func (r *productResolver) FreezingRate() float64   { return float64(r.p.FreezingRate) }
+func (r *productResolver) Height() float64         { return float64(r.p.Height) }
+func (r *productResolver) Length() float64         { return float64(r.p.Length) }
+func (r *productResolver) NetWeight() float64      { return float64(r.p.Netweight) }
+func (r *productResolver) ProductCode() string     { return r.p.ProductCode }
+func (r *productResolver) RecommendedFreezingTemperature() float64 {
+	return float64(r.p.RecomFreezTemp)
+}
+func (r *productResolver) Width() float64       { return float64(r.p.Width) }
+func (r *productResolver) Volume() float64      { return units.Round(r.p.Volume(), 6) }
+func (r *productResolver) ProductTypeID() int32 { return int32(r.p.ProductTypeID) }
+func (r *productResolver) SellerID() int32      { return int32(r.p.SellerID) }
+
+func (r *productResolver) Seller(ctx context.Context) (*sellerResolver, error) {
+	s, err := loadersFrom(ctx).seller.Load(ctx, r.p.SellerID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	if s == nil {
+		return nil, nil
+	}
+	return &sellerResolver{*s}, nil
+}
+
+func (r *productResolver) Batches(ctx context.Context) ([]*productBatchResolver, error) {
+	batches, err := loadersFrom(ctx).productBatches.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(batches, func(b domain.ProductBatch) *productBatchResolver { return &productBatchResolver{b} }), nil
+}
+
+type productRecordResolver struct{ p domain.ProductRecord }
+
+func (r *productRecordResolver) ID() graphql.ID         { return toID(r.p.ID) }
+func (r *productRecordResolver) LastUpdateDate() string { return r.p.LastUpdate }
+func (r *productRecordResolver) PurchasePrice() float64 { return r.p.PurchasePrice.Float64() }
+func (r *productRecordResolver) SalePrice() float64     { return r.p.SalePrice.Float64() }
+func (r *productRecordResolver) Currency() string       { return string(r.p.Currency) }
+func (r *productRecordResolver) ProductID() int32       { return int32(r.p.ProductID) }
+
+type productBatchResolver struct{ b domain.ProductBatch }
+
+func (r *productBatchResolver) ID() graphql.ID            { return toID(r.b.ID) }
+func (r *productBatchResolver) BatchNumber() int32        { return int32(r.b.BatchNumber) }
+func (r *productBatchResolver) CurrentQuantity() int32    { return int32(r.b.CurrentQuantity) }
+func (r *productBatchResolver) CurrentTemperature() int32 { return int32(r.b.CurrentTemperature) }
+func (r *productBatchResolver) DueDate() string           { return r.b.DueDate }
+func (r *productBatchResolver) InitialQuantity() int32    { return int32(r.b.InitialQuantity) }
+func (r *productBatchResolver) ManufacturingDate() string { return r.b.ManufacturingDate }
+func (r *productBatchResolver) ManufacturingHour() int32  { return int32(r.b.ManufacturingHour) }
+func (r *productBatchResolver) MinimumTemperature() int32 { return int32(r.b.MinimumTemperature) }
+func (r *productBatchResolver) ProductID() int32          { return int32(r.b.ProductID) }
+func (r *productBatchResolver) SectionID() int32          { return int32(r.b.SectionID) }
+
+func (r *productBatchResolver) Section(ctx context.Context) (*sectionResolver, error) {
+	s, err := loadersFrom(ctx).section.Load(ctx, r.b.SectionID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	if s == nil {
+		return nil, nil
+	}
+	return &sectionResolver{*s}, nil
+}
+
+type sectionResolver struct{ s domain.Section }
+
+func (r *sectionResolver) ID() graphql.ID            { return toID(r.s.ID) }
+func (r *sectionResolver) SectionNumber() int32      { return int32(r.s.SectionNumber) }
+func (r *sectionResolver) CurrentTemperature() int32 { return int32(r.s.CurrentTemperature) }
+func (r *sectionResolver) MinimumTemperature() int32 { return int32(r.s.MinimumTemperature) }
+func (r *sectionResolver) CurrentCapacity() int32    { return int32(r.s.CurrentCapacity) }
+func (r *sectionResolver) MinimumCapacity() int32    { return int32(r.s.MinimumCapacity) }
+func (r *sectionResolver) MaximumCapacity() int32    { return int32(r.s.MaximumCapacity) }
+func (r *sectionResolver) ProductTypeID() int32      { return int32(r.s.ProductTypeID) }
+func (r *sectionResolver) WarehouseID() int32        { return int32(r.s.WarehouseID) }
+
+func (r *sectionResolver) Warehouse(ctx context.Context) (*warehouseResolver, error) {
+	w, err := loadersFrom(ctx).warehouse.Load(ctx, r.s.WarehouseID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	if w == nil {
+		return nil, nil
+	}
+	return &warehouseResolver{*w}, nil
+}
+
+type warehouseResolver struct{ w domain.Warehouse }
+
+func (r *warehouseResolver) ID() graphql.ID            { return toID(r.w.ID) }
+func (r *warehouseResolver) Address() string           { return r.w.Address }
+func (r *warehouseResolver) Telephone() string         { return r.w.Telephone }
+func (r *warehouseResolver) WarehouseCode() string     { return r.w.WarehouseCode }
+func (r *warehouseResolver) MinimumCapacity() int32    { return int32(r.w.MinimumCapacity) }
+func (r *warehouseResolver) MinimumTemperature() int32 { return int32(r.w.MinimumTemperature) }
+
+func (r *warehouseResolver) Employees(ctx context.Context) ([]*employeeResolver, error) {
+	employees, err := loadersFrom(ctx).employees.Load(ctx, r.w.ID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(employees, func(e domain.Employee) *employeeResolver { return &employeeResolver{e} }), nil
+}
+
+type employeeResolver struct{ e domain.Employee }
+
+func (r *employeeResolver) ID() graphql.ID       { return toID(r.e.ID) }
+func (r *employeeResolver) CardNumberID() string { return r.e.CardNumberID }
+func (r *employeeResolver) FirstName() string    { return r.e.FirstName }
+func (r *employeeResolver) LastName() string     { return r.e.LastName }
+func (r *employeeResolver) WarehouseID() int32   { return int32(r.e.WarehouseID) }
+
+type inboundOrderResolver struct{ i domain.InboudOrder }
+
+func (r *inboundOrderResolver) ID() graphql.ID        { return toID(r.i.ID) }
+func (r *inboundOrderResolver) OrderDate() string     { return r.i.OrderDate }
+func (r *inboundOrderResolver) OrderNumber() string   { return r.i.OrderNumber }
+func (r *inboundOrderResolver) EmployeeID() int32     { return int32(r.i.EmployeeID) }
+func (r *inboundOrderResolver) ProductBatchID() int32 { return int32(r.i.ProductBatchID) }
+func (r *inboundOrderResolver) WarehouseID() int32    { return int32(r.i.WarehouseID) }
+
+type buyerResolver struct{ b domain.Buyer }
+
+func (r *buyerResolver) ID() graphql.ID       { return toID(r.b.ID) }
+func (r *buyerResolver) CardNumberID() string { return r.b.CardNumberID }
+func (r *buyerResolver) FirstName() string    { return r.b.FirstName }
+func (r *buyerResolver) LastName() string     { return r.b.LastName }
+
+func (r *buyerResolver) PurchaseOrders(ctx context.Context) ([]*purchaseOrderResolver, error) {
+	orders, err := loadersFrom(ctx).purchaseOrders.Load(ctx, r.b.ID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return resolve(orders, func(po domain.PurchaseOrder) *purchaseOrderResolver { return &purchaseOrderResolver{po} }), nil
+}
+
+type purchaseOrderResolver struct{ po domain.PurchaseOrder }
+
+func (r *purchaseOrderResolver) ID() graphql.ID         { return toID(r.po.ID) }
+func (r *purchaseOrderResolver) OrderNumber() string    { return r.po.OrderNumber }
+func (r *purchaseOrderResolver) OrderDate() string      { return r.po.OrderDate }
+func (r *purchaseOrderResolver) TrackingCode() string   { return r.po.TrackingCode }
+func (r *purchaseOrderResolver) BuyerID() int32         { return int32(r.po.BuyerID) }
+func (r *purchaseOrderResolver) ProductRecordID() int32 { return int32(r.po.ProductRecordID) }
+func (r *purchaseOrderResolver) OrderStatusID() int32   { return int32(r.po.OrderStatusID) }
+
+type sellerReportResolver struct{ r domain.ReportSellers }
+
+func (r *sellerReportResolver) LocalityID() int32    { return int32(r.r.Locality_id) }
+func (r *sellerReportResolver) LocalityName() string { return r.r.Locality_name }
+func (r *sellerReportResolver) PostalCode() int32    { return int32(r.r.Postal_code) }
+func (r *sellerReportResolver) SellersCount() int32  { return int32(r.r.Sellers_count) }
+
+type carryReportResolver struct{ r domain.LocalityCarries }
+
+// LocalityID converts the id the carries repository reads as a string.
+func (r *carryReportResolver) LocalityID() int32 {
+	id, _ := strconv.Atoi(r.r.LocalityID)
+	return int32(id)
+}
+func (r *carryReportResolver) LocalityName() string { return r.r.LocalityName }
+func (r *carryReportResolver) CarriesCount() int32  { return int32(r.r.CarriesCount) }
+
+type productRecordReportResolver struct{ r domain.ProductRecordGet }
+
+func (r *productRecordReportResolver) ProductID() int32    { return int32(r.r.ProductID) }
+func (r *productRecordReportResolver) Description() string { return r.r.Description }
+func (r *productRecordReportResolver) RecordCount() int32  { return int32(r.r.RecordCount) }
+
+type sectionProductReportResolver struct {
+	id, sectionNumber, productCount int
+}
+
+func (r *sectionProductReportResolver) SectionID() int32     { return int32(r.id) }
+func (r *sectionProductReportResolver) SectionNumber() int32 { return int32(r.sectionNumber) }
+func (r *sectionProductReportResolver) ProductCount() int32  { return int32(r.productCount) }
+
+type inboundOrderReportResolver struct {
+	employee domain.Employee
+	count    int
+}
+
+func (r *inboundOrderReportResolver) Employee() *employeeResolver {
+	return &employeeResolver{r.employee}
+}
+func (r *inboundOrderReportResolver) InboundOrdersCount() int32 { return int32(r.count) }
+
+type purchaseOrderReportResolver struct{ r domain.PurchaseOrdersByBuyer }
+
+func (r *purchaseOrderReportResolver) Buyer() *buyerResolver {
+	return &buyerResolver{domain.Buyer{
+		ID:           r.r.ID,
+		CardNumberID: r.r.CardNumberID,
+		FirstName:    r.r.FirstName,
+		LastName:     r.r.LastName,
+	}}
+}
+func (r *purchaseOrderReportResolver) PurchaseOrdersCount() int32 {
+	return int32(r.r.PurchaseOrdersCount)
+}
+
+// resolve wraps every item of a list in its resolver. The result is never
+// nil, so empty lists are not reported as null.
+func resolve[T any, R any](items []T, wrap func(T) R) []R {
+	resolvers := make([]R, 0, len(items))
+	for _, item := range items {
+		resolvers = append(resolvers, wrap(item))
+	}
+	return resolvers
+}
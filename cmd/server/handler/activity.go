@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/activity"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrInvalidWarehouseID = "warehouse_id must be 1 or greater"
+	ErrInvalidEventType   = "type must be one of inbound_order.received, product_batch.created or section.capacity_changed"
+	ErrInvalidLastEventID = "Last-Event-ID must be an event id"
+)
+
+// activityHeartbeat is how often an idle stream is sent a comment, so the
+// proxies between the server and the client do not close it.
+var activityHeartbeat = 15 * time.Second
+
+// Activity contains the handlers of the live feed of warehouse activity.
+type Activity struct {
+	feed activity.Feed
+}
+
+// NewActivity returns a new instance of Activity.
+func NewActivity(feed activity.Feed) *Activity {
+	return &Activity{feed: feed}
+}
+
+// Stream godoc
+// @Summary Stream the warehouse activity.
+// @Description Streams the inbound orders received, the product batches created and the changes of capacity of the sections as Server-Sent Events, as they happen.
+// @Description Every event is sent with its id, its type as the event name and the JSON of the event as data.
+// @Description A client reconnecting with the Last-Event-ID header first receives the events it missed that the server still keeps.
+// @Description The ids are <epoch>-<sequence>, the epoch changing when the server restarts: a client whose last event is of
+// @Description another epoch receives every event the server keeps.
+// @Tags events
+// @Produce json,text/event-stream
+// @Param warehouse_id query int false "Only the events of this warehouse"
+// @Param type query []string false "Only the events of these types" collectionFormat(multi) Enums(inbound_order.received, product_batch.created, section.capacity_changed)
+// @Param Last-Event-ID header string false "Id of the last event received, e.g. 1697025600000-42"
+// @Success 200 {string} string "Server-Sent Events, the data of each an activity.Event"
+// @Failure 400 {object} web.ErrorResponse
+// @Router /events/stream [get]
+func (a *Activity) Stream() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var filter activity.Filter
+		if id := c.Query("warehouse_id"); id != "" {
+			var err error
+			if filter.WarehouseID, err = strconv.Atoi(id); err != nil || filter.WarehouseID < 1 {
+				web.Error(c, http.StatusBadRequest, ErrInvalidWarehouseID)
+				return
+			}
+		}
+		for _, t := range c.QueryArray("type") {
+			if !isActivityType(t) {
+				web.Error(c, http.StatusBadRequest, ErrInvalidEventType)
+				return
+			}
+			filter.Types = append(filter.Types, t)
+		}
+		var lastID activity.EventID
+		if id := c.GetHeader("Last-Event-ID"); id != "" {
+			var err error
+			if lastID, err = activity.ParseEventID(id); err != nil {
+				web.Error(c, http.StatusBadRequest, ErrInvalidLastEventID)
+				return
+			}
+		}
+
+		missed, sub := a.feed.Subscribe(filter, lastID)
+		defer sub.Close()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		for _, e := range missed {
+			if err := writeEvent(c, e); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+
+		heartbeat := time.NewTicker(activityHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case e, ok := <-sub.Events():
+				if !ok {
+					// The client fell behind: it resumes from its last event.
+					return
+				}
+				if err := writeEvent(c, e); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeEvent writes e as a Server-Sent Event.
+func writeEvent(c *gin.Context, e activity.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+func isActivityType(t string) bool {
+	for _, known := range activity.Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/activity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newActivityRouter(feed activity.Feed) *gin.Engine {
+	r := gin.New()
+	r.GET("/api/v1/events/stream", NewActivity(feed).Stream())
+	return r
+}
+
+// streamOnce serves req with a canceled context, so the stream ends once the
+// missed events are written.
+func streamOnce(t *testing.T, r http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	ctx, cancel := context.WithCancel(req.Context())
+	cancel()
+	response := httptest.NewRecorder()
+	serveHTTP(t, r, response, req.WithContext(ctx))
+	return response
+}
+
+// eventIDs returns the ids of the events of an SSE stream.
+func eventIDs(stream string) []string {
+	var ids []string
+	for _, line := range strings.Split(stream, "\n") {
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestActivity_Stream(t *testing.T) {
+	t.Run("it should replay the events after Last-Event-ID selected by the filters", func(t *testing.T) {
+		// Arrange
+		feed := activity.NewFeed(10)
+		first := feed.Publish(activity.InboundOrderReceived, 1, map[string]int{"id": 1})
+		feed.Publish(activity.BatchCreated, 1, map[string]int{"id": 2})
+		feed.Publish(activity.InboundOrderReceived, 2, map[string]int{"id": 3})
+		fourth := feed.Publish(activity.InboundOrderReceived, 1, map[string]int{"id": 4})
+		fifth := feed.Publish(activity.SectionCapacityChanged, 1, map[string]int{"section_id": 5})
+		r := newActivityRouter(feed)
+		request := httptest.NewRequest(http.MethodGet,
+			"/api/v1/events/stream?warehouse_id=1&type=inbound_order.received&type=section.capacity_changed", nil)
+		request.Header.Set("Last-Event-ID", first.ID.String())
+
+		// Act
+		response := streamOnce(t, r, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "text/event-stream", response.Header().Get("Content-Type"))
+		assert.Equal(t, []string{fourth.ID.String(), fifth.ID.String()}, eventIDs(response.Body.String()))
+		assert.Contains(t, response.Body.String(), "id: "+fourth.ID.String()+"\nevent: inbound_order.received\ndata: {\"id\":\""+fourth.ID.String()+"\",\"type\":\"inbound_order.received\",\"warehouse_id\":1,")
+	})
+
+	t.Run("it should replay every event kept when Last-Event-ID is of another epoch", func(t *testing.T) {
+		// Arrange
+		feed := activity.NewFeed(10)
+		first := feed.Publish(activity.BatchCreated, 1, nil)
+		second := feed.Publish(activity.BatchCreated, 1, nil)
+		r := newActivityRouter(feed)
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/events/stream", nil)
+		// The last event received before the server restarted
+		request.Header.Set("Last-Event-ID", "1-1")
+
+		// Act
+		response := streamOnce(t, r, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, []string{first.ID.String(), second.ID.String()}, eventIDs(response.Body.String()))
+	})
+
+	t.Run("it should stream the events as they are published", func(t *testing.T) {
+		// Arrange
+		feed := activity.NewFeed(10)
+		first := feed.Publish(activity.BatchCreated, 2, nil)
+		server := httptest.NewServer(newActivityRouter(feed))
+		defer server.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/events/stream?warehouse_id=2", nil)
+		require.NoError(t, err)
+		request.Header.Set("Last-Event-ID", first.ID.String())
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		// Act
+		feed.Publish(activity.BatchCreated, 1, nil)
+		published := feed.Publish(activity.BatchCreated, 2, map[string]int{"id": 7})
+
+		// Assert
+		lines := bufio.NewScanner(response.Body)
+		require.True(t, lines.Scan())
+		assert.Equal(t, "id: "+published.ID.String(), lines.Text())
+		require.True(t, lines.Scan())
+		assert.Equal(t, "event: product_batch.created", lines.Text())
+	})
+
+	t.Run("it should return 400 for an unknown event type", func(t *testing.T) {
+		// Arrange
+		r := newActivityRouter(activity.NewFeed(10))
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/events/stream?type=seller.created", nil)
+
+		// Act
+		response := streamOnce(t, r, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"`+ErrInvalidEventType+`"}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 for an invalid Last-Event-ID", func(t *testing.T) {
+		// Arrange
+		r := newActivityRouter(activity.NewFeed(10))
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/events/stream", nil)
+		request.Header.Set("Last-Event-ID", "abc")
+
+		// Act
+		response := streamOnce(t, r, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
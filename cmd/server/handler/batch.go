@@ -10,6 +10,7 @@ import (
 
 	"github.com/davidop97/apiGo/internal/batch"
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
@@ -56,18 +57,30 @@ func NewProductBatch(b batch.Service) *ProductBatch {
 // GetAll godoc
 // @Summary Retrieves all product batches.
 // @Description Gets a list of all product batches.
+// @Description With format=ndjson, or Accept: application/x-ndjson, the batches are streamed a JSON object per line as they are read.
 // @Tags productBatches
-// @Produce json
-// @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Produce json,application/x-ndjson,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "json (default), ndjson, csv or xlsx, overrides the Accept header" Enums(json, ndjson, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.ProductBatch}
+// @Failure 500 {object} web.MessageResponse
 // @Router /productBatches [get]
 func (b *ProductBatch) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if ok, err := web.Stream(c, b.batchService.Each); ok {
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+			}
+			return
+		}
+
 		l, err := b.batchService.GetAll(c)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
 			return
 		}
+		if web.Table(c, l) {
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{"data": l})
 	}
 }
@@ -78,11 +91,11 @@ func (b *ProductBatch) GetAll() gin.HandlerFunc {
 // @Tags productBatches
 // @Accept json
 // @Produce json
-// @Param sectionData body object true "Product batch data to create" format(json)
-// @Success 201 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 422 {object} map[string]interface{}
-// @Failure 409 {object} map[string]interface{}
+// @Param batchData body BatchRequest true "Product batch data to create"
+// @Success 201 {object} web.DataResponse{data=domain.ProductBatch}
+// @Failure 400 {object} web.MessageResponse
+// @Failure 409 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Router /productBatches [post]
 func (b *ProductBatch) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -139,21 +152,28 @@ func (b *ProductBatch) Create() gin.HandlerFunc {
 		// Process
 		// - create new product batch in the database
 		productBatch := requestToBatch(req)
-		id, err := b.batchService.Save(c, productBatch)
+		saved, err := b.batchService.Save(c, productBatch)
+		var coldChainErr *batch.ColdChainError
 		if err != nil {
 			switch {
+			case errors.As(err, &coldChainErr):
+				c.JSON(http.StatusConflict, gin.H{"message": "can't create batch, provided section breaks the cold chain of the batch", "violations": coldChainErr.Violations})
 			case errors.Is(err, batch.ErrDuplicateBatchNumber):
 				c.JSON(http.StatusConflict, gin.H{"message": "batch number must be unique, provided already exists"})
 			case errors.Is(err, batch.ErrProductNotFound):
 				c.JSON(http.StatusConflict, gin.H{"message": "can't create batch, provided product id was not found"})
 			case errors.Is(err, batch.ErrSectionNotFound):
 				c.JSON(http.StatusConflict, gin.H{"message": "can't create batch, provided section id was not found"})
+			case errors.Is(err, batch.ErrSectionFull):
+				c.JSON(http.StatusConflict, gin.H{"message": "can't create batch, provided current quantity exceeds the maximum capacity of the section"})
+			case errors.Is(err, batch.ErrProductTypeMismatch):
+				c.JSON(http.StatusConflict, gin.H{"message": "can't create batch, provided product is not of the product type of the section"})
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
 			}
 			return
 		}
-		productBatch.ID = id
+		productBatch.ID = saved.ID
 		c.JSON(http.StatusCreated, gin.H{"data": productBatch})
 	}
 }
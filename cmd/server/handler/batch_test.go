@@ -65,7 +65,7 @@ func TestHandler_ReadBatch(t *testing.T) {
 
 		// Act
 		// - Serve http request and record it
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if obtained status code matches expected one
@@ -102,7 +102,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 		expectedBody := `{"data":{"id":1,"batch_number":1,"current_quantity":1,"current_temperature":1,"due_date":"2023-11-10","initial_quantity":1,"manufacturing_date":"2023-11-10","manufacturing_hour":1,"minimum_temperature":1,"product_id":1,"section_id":1}}`
 		// - Set up service mock to expect a Save call with newBatch and return predefined ID
 		service := &batch.ServiceMock{}
-		service.On("Save", mock.Anything, newBatch).Return(id, nil)
+		service.On("Save", mock.Anything, newBatch).Return(batch.Saved{ID: id}, nil)
 		// - Initialize handler with the mocked service
 		handler := NewProductBatch(service)
 		// - Create a new Gin router and register the handler for the test route
@@ -117,7 +117,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP request and capture the response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the response status code matches the expected status code
@@ -151,7 +151,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP request to the handler and record the response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the status code is as expected for a bad request
@@ -186,7 +186,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP request to the handler and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the response status code matches the expected status code for a request with JSON syntax error.
@@ -220,7 +220,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP request to the handler and record the response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the status code matches the expected status code for a data type mismatch error
@@ -255,7 +255,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Dispatch the HTTP request to the handler and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the response status code is as expected for a request violating business logic.
@@ -292,7 +292,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 		// Act
 		// - Serve the HTTP request through the router to trigger the handler function,
 		//   capturing its response for validation.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the status code matches the expected code for validation failure.
@@ -330,7 +330,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Dispatch the HTTP request through the router to the handler, capturing the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Ensure the response status code is as expected for a validation error.
@@ -368,7 +368,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP request through the router to the handler, recording the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the response status code is as expected for a request violating the validation rule.
@@ -406,7 +406,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Dispatch the HTTP request through the router to the handler, capturing the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the response status code matches the expected code for a validation error.
@@ -444,7 +444,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP request through the router to the handler, capturing the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Ensure the response status code is as expected for a request violating date format expectations.
@@ -482,7 +482,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Process the HTTP request through the router, invoking the handler and recording its response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the response status code aligns with the expected code for a date format validation error.
@@ -520,7 +520,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 		expectedBody := `{"message": "batch number must be unique, provided already exists"}`
 		// - Initialize the service mock to return the predefined error when the Save method is called with a duplicate batch number.
 		service := &batch.ServiceMock{}
-		service.On("Save", mock.Anything, newBatch).Return(0, err) // Service returns 0, indicating no new ID was generated.
+		service.On("Save", mock.Anything, newBatch).Return(batch.Saved{}, err)
 		// - Setup the handler with the mocked service to process the POST request.
 		handler := NewProductBatch(service)
 		// - Create a Gin router and register the handler for the endpoint being tested.
@@ -535,7 +535,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Dispatch the HTTP request through the router to the handler, capturing the response for validation.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the response status code matches the expected code for a duplicate batch number conflict.
@@ -573,7 +573,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 		expectedBody := `{"message": "can't create batch, provided product id was not found"}`
 		// - Initialize the service mock to return the predefined error when the Save method is called with a non-existent product_id.
 		service := &batch.ServiceMock{}
-		service.On("Save", mock.Anything, newBatch).Return(0, err) // Indicates no new ID generated due to error.
+		service.On("Save", mock.Anything, newBatch).Return(batch.Saved{}, err)
 		// - Setup the handler with the mocked service to process the POST request.
 		handler := NewProductBatch(service)
 		// - Create a Gin router and register the handler for the endpoint being tested.
@@ -588,7 +588,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Dispatch the HTTP request through the router to the handler, capturing the response for validation.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the response status code matches the expected code for an attempt to create a batch for a non-existent product.
@@ -626,7 +626,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 		expectedBody := `{"message": "can't create batch, provided section id was not found"}`
 		// - Initialize the service mock to return the predefined error when the Save method is called with a non-existent section_id.
 		service := &batch.ServiceMock{}
-		service.On("Save", mock.Anything, newBatch).Return(0, err) // Indicates no new ID generated due to error.
+		service.On("Save", mock.Anything, newBatch).Return(batch.Saved{}, err)
 		// - Setup the handler with the mocked service to process the POST request.
 		handler := NewProductBatch(service)
 		// - Create a Gin router and register the handler for the endpoint being tested.
@@ -641,7 +641,7 @@ func TestHandler_CreateBatch(t *testing.T) {
 
 		// Act
 		// - Dispatch the HTTP request through the router to the handler, capturing the response for validation.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the response status code matches the expected code for an attempt to create a batch for a non-existent section.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/davidop97/apiGo/internal/buyer"
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
@@ -39,10 +40,10 @@ type RequestBodyBuyerCreate struct {
 // @Tags buyers
 // @Produce json
 // @Param id path int true "Buyer id"
-// @Failure 400 {object} map[string]any
-// @Failure 404 {object} map[string]any
-// @Failure 500 {object} map[string]any
-// @Success 200 {object} map[string]any
+// @Failure 400 {object} web.ErrorMessageResponse
+// @Failure 404 {object} web.ErrorMessageResponse
+// @Failure 500 {object} web.ErrorMessageResponse
+// @Success 200 {object} web.DataResponse{data=domain.Buyer}
 // @Router /buyers/{id} [get]
 func (b *Buyer) Get() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -78,9 +79,10 @@ func (b *Buyer) Get() gin.HandlerFunc {
 // @Description get all buyers
 // @Tags domain.Buyer
 // @Tags buyers
-// @Produce json
-// @Failure 500 {object} map[string]any
-// @Success 200 {object} map[string]any
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Failure 500 {object} web.ErrorMessageResponse
+// @Success 200 {object} web.DataResponse{data=[]domain.Buyer}
 // @Router /buyers [get]
 func (b *Buyer) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -88,7 +90,10 @@ func (b *Buyer) GetAll() gin.HandlerFunc {
 		buyers, err := b.buyerService.GetAll(c)
 		// check for errors
 		if err != nil {
-			c.String(http.StatusInternalServerError, "Internal server error", nil)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			return
+		}
+		if web.Table(c, buyers) {
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{
@@ -104,12 +109,12 @@ func (b *Buyer) GetAll() gin.HandlerFunc {
 // @Tags buyers
 // @Accept json
 // @Produce json
-// @Param body body domain.Buyer true "Buyer body"
-// @Failure 400 {object} map[string]any
-// @Failure 422 {object} map[string]any
-// @Failure 409 {object} map[string]any
-// @Failure 500 {object} map[string]any
-// @Success 201 {object} map[string]any
+// @Param body body RequestBodyBuyerCreate true "Buyer body"
+// @Failure 400 {object} web.ErrorMessageResponse
+// @Failure 422 {object} web.ErrorMessageResponse
+// @Failure 409 {object} web.ErrorMessageResponse
+// @Failure 500 {object} web.ErrorMessageResponse
+// @Success 201 {object} web.DataResponse{data=domain.Buyer}
 // @Router /buyers [post]
 func (b *Buyer) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -164,10 +169,11 @@ func (b *Buyer) Create() gin.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param request body Request true "Buyer update request"
-// @Failure 400 {object} map[string]any
-// @Failure 404 {object} map[string]any
-// @Failure 500 {object} map[string]any
-// @Success 200 {object} map[string]any
+// @Failure 400 {object} web.ErrorMessageResponse
+// @Failure 404 {object} web.ErrorMessageResponse
+// @Failure 409 {object} web.ErrorMessageResponse
+// @Failure 500 {object} web.ErrorMessageResponse
+// @Success 200 {object} web.DataResponse{data=domain.Buyer}
 // @Router /buyers/{id} [patch]
 func (b *Buyer) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -224,11 +230,12 @@ func (b *Buyer) Update() gin.HandlerFunc {
 // @Description delete a buyer
 // @Tags domain.Buyer
 // @Tags buyers
+// @Produce json
 // @Param id path int true "Delete buyer ID"
-// @Failure 400 {object} map[string]any
-// @Failure 404 {object} map[string]any
-// @Failure 500 {object} map[string]any
-// @Success 204 {object} map[string]any
+// @Failure 400 {object} web.ErrorMessageResponse
+// @Failure 404 {object} web.ErrorMessageResponse
+// @Failure 500 {object} web.ErrorMessageResponse
+// @Success 204
 // @Router /buyers/{id} [delete]
 func (b *Buyer) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
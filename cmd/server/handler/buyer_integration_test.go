@@ -68,7 +68,7 @@ func TestIntegrationHandler_ReadBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -120,7 +120,7 @@ func TestIntegrationHandler_DeleteBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -69,7 +69,7 @@ func TestHandler_ReadBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -116,7 +116,7 @@ func TestHandler_ReadBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -158,7 +158,7 @@ func TestHandler_ReadBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -188,18 +188,18 @@ func TestHandler_ReadBuyer(t *testing.T) {
 		// prepare server to call endpoint GetAll
 		// create gin router
 		r := gin.New()
-		route := "/api/v1/buyers"
+		route := "/api/v1/buyers/:id"
 
 		// Add handler to the router
 		r.GET(route, handler.Get())
 
 		// create the request
-		request, _ := http.NewRequest("GET", "/api/v1/buyers", nil)
+		request, _ := http.NewRequest("GET", "/api/v1/buyers/abc", nil)
 		// create the response recorder
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -241,7 +241,7 @@ func TestHandler_ReadBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -282,7 +282,7 @@ func TestHandler_ReadBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -330,7 +330,7 @@ func TestService_DeleteBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -371,7 +371,7 @@ func TestService_DeleteBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -400,18 +400,18 @@ func TestService_DeleteBuyer(t *testing.T) {
 		// prepare server to call endpoint GetAll
 		// create gin router
 		r := gin.New()
-		route := "/api/v1/buyers"
+		route := "/api/v1/buyers/:id"
 
 		// Add handler to the router
 		r.DELETE(route, handler.Delete())
 
 		// create the request
-		request, _ := http.NewRequest("DELETE", "/api/v1/buyers", nil)
+		request, _ := http.NewRequest("DELETE", "/api/v1/buyers/abc", nil)
 		// create the response recorder
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -451,7 +451,7 @@ func TestService_DeleteBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -507,7 +507,7 @@ func TestService_CreateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -553,7 +553,7 @@ func TestService_CreateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -601,7 +601,7 @@ func TestService_CreateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -642,7 +642,7 @@ func TestService_CreateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -691,7 +691,7 @@ func TestService_CreateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -752,7 +752,7 @@ func TestService_UpdateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -806,7 +806,7 @@ func TestService_UpdateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -839,19 +839,19 @@ func TestService_UpdateBuyer(t *testing.T) {
 		// prepare server to call endpoint Update
 		// create gin router
 		r := gin.New()
-		route := "/api/v1/buyers"
+		route := "/api/v1/buyers/:id"
 
 		// Add handler to the router
 		r.PATCH(route, handler.Update())
 
 		// create the request
 		reqBodyBytes := bytes.NewBuffer([]byte(string(buyerJSONToUpdate)))
-		request, _ := http.NewRequest("PATCH", "/api/v1/buyers", reqBodyBytes)
+		request, _ := http.NewRequest("PATCH", "/api/v1/buyers/abc", reqBodyBytes)
 		// create the response recorder
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -899,7 +899,7 @@ func TestService_UpdateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -954,7 +954,7 @@ func TestService_UpdateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -1009,7 +1009,7 @@ func TestService_UpdateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -1059,7 +1059,7 @@ func TestService_UpdateBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
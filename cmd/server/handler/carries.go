@@ -8,9 +8,20 @@ import (
 
 	"github.com/davidop97/apiGo/internal/carries"
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
+// CarryRequest is the body request for a carry. The handler reads every field
+// as a string and converts locality_id before saving.
+type CarryRequest struct {
+	CID         string `json:"cid"`
+	CompanyName string `json:"company_name"`
+	Address     string `json:"address"`
+	Telephone   string `json:"telephone"`
+	LocalityID  string `json:"locality_id"`
+}
+
 type Carry struct {
 	carriesService carries.Service
 }
@@ -24,9 +35,10 @@ func NewCarry(c carries.Service) *Carry {
 // ShowGetAll godoc
 // @Summary Get all carries, returns empty list if there are no carries.
 // @Tags carries
-// @Produce json
-// @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.Carries}
+// @Failure 500 {object} web.MessageResponse
 // @Router /carries [get]
 func (c *Carry) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -36,6 +48,9 @@ func (c *Carry) GetAll() gin.HandlerFunc {
 			return
 		}
 
+		if web.Table(ctx, carriesList) {
+			return
+		}
 		ctx.JSON(http.StatusOK, map[string]interface{}{
 			"data": carriesList,
 		})
@@ -45,10 +60,14 @@ func (c *Carry) GetAll() gin.HandlerFunc {
 // ShowSave godoc
 // @Summary Save a carry, returns error if the carry already exists or if the data is incorrect.
 // @Tags carries
+// @Accept json
 // @Produce json
-// @Success 201 {object} map[string]interface{}
-// @Failure 409 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Param body body CarryRequest true "Carry to be created"
+// @Success 201 {object} web.DataResponse{data=domain.Carries}
+// @Failure 404 {object} web.MessageResponse
+// @Failure 409 {object} web.MessageResponse
+// @Failure 422 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Router /carries [post]
 func (c *Carry) Save() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -110,10 +129,15 @@ func (c *Carry) Save() gin.HandlerFunc {
 // ShowGetAllByLocality godoc
 // @Summary Get all carries by locality, returns empty list if there are no carries.
 // @Tags carries
-// @Produce json
-// @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /carries/locality [get]
+// @Description data holds a single domain.LocalityCarries when id is given and a list of them otherwise.
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id query int false "Locality ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
+// @Router /localities/reportCarries [get]
 func (c *Carry) GetCarriesByLocality() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		if ctx.Query("id") == "" {
@@ -123,6 +147,9 @@ func (c *Carry) GetCarriesByLocality() gin.HandlerFunc {
 				return
 			}
 
+			if web.Table(ctx, carriesList) {
+				return
+			}
 			ctx.JSON(http.StatusOK, map[string]interface{}{
 				"data": carriesList,
 			})
@@ -145,6 +172,9 @@ func (c *Carry) GetCarriesByLocality() gin.HandlerFunc {
 			return
 		}
 
+		if web.Table(ctx, localityCarries) {
+			return
+		}
 		ctx.JSON(http.StatusOK, map[string]interface{}{
 			"data": localityCarries,
 		})
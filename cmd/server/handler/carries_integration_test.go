@@ -43,7 +43,7 @@ func TestHandlerIntegration_CarriesGetAllCarriesByLocalityID(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		// Assert.
 		assert.NoError(t, err)
@@ -69,7 +69,7 @@ func TestHandlerIntegration_CarriesGetAllCarriesByLocalityID(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		// Assert.
 		assert.NoError(t, err)
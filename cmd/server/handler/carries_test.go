@@ -55,7 +55,7 @@ func TestHandler_CarriesRead(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -104,7 +104,7 @@ func TestHandler_CarriesCreate(t *testing.T) {
 		t.Log(string(carryJson))
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		t.Log(carryJson)
 
@@ -138,7 +138,7 @@ func TestHandler_CarriesCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -168,7 +168,7 @@ func TestHandler_CarriesCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -199,7 +199,7 @@ func TestHandler_CarriesCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -231,7 +231,7 @@ func TestHandler_CarriesCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -263,7 +263,7 @@ func TestHandler_CarriesCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -294,7 +294,7 @@ func TestHandler_CarriesCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -337,7 +337,7 @@ func TestHandler_CarriesGetAllCarriesByLocality(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -374,7 +374,7 @@ func TestHandler_CarriesGetAllCarriesByLocalityID(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		// Assert.
 		assert.NoError(t, err)
@@ -397,7 +397,7 @@ func TestHandler_CarriesGetAllCarriesByLocalityID(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		// Assert.
 		assert.NoError(t, err)
@@ -421,7 +421,7 @@ func TestHandler_CarriesGetAllCarriesByLocalityID(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		// Act.
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		// Assert.
 		assert.NoError(t, err)
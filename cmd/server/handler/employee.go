@@ -10,6 +10,7 @@ import (
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/davidop97/apiGo/internal/employee"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
@@ -44,8 +45,10 @@ type EmployeeRequest struct {
 // @Summary Get a employee by id or an error if that id not exists.
 // @Tags domain.Employee
 // @Produce json
-// @Success 200
-// @Failure 404
+// @Success 200 {object} web.DataResponse{data=domain.Employee}
+// @Failure 400 {object} web.ErrorMessageResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Param id path int true "id from the employee"
 // @Router /employees/{id} [get]
 func (e *Employee) Get() gin.HandlerFunc {
@@ -54,6 +57,7 @@ func (e *Employee) Get() gin.HandlerFunc {
 		id, err := strconv.Atoi(idParam)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+			return
 		}
 		currentEmployee, err := e.employeeService.GetEmployeeByID(c, id)
 		if err != nil {
@@ -72,15 +76,19 @@ func (e *Employee) Get() gin.HandlerFunc {
 
 // @Summary Get all the employees available or an error if the list is empty.
 // @Tags domain.Employee
-// @Produce json
-// @Success 200
-// @Failure 404
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.Employee}
+// @Failure 500 {object} web.MessageResponse
 // @Router /employees [get]
 func (e *Employee) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		employees, err := e.employeeService.GetAllEmployees(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal error"})
+			return
+		}
+		if web.Table(c, employees) {
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"data": employees})
@@ -91,10 +99,12 @@ func (e *Employee) GetAll() gin.HandlerFunc {
 // @Tags domain.Employee
 // @Produce json
 // @Accept json
-// @Success 201
-// @Failure 422
-// @Failure 409
-// @Param body body Employee true "Struct of Employee domain"
+// @Success 201 {object} web.DataResponse{data=domain.Employee}
+// @Failure 400 {object} web.MessageResponse
+// @Failure 409 {object} web.MessageResponse
+// @Failure 422 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
+// @Param body body EmployeeRequest true "Struct of Employee domain"
 // @Router /employees [post]
 func (e *Employee) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -143,6 +153,7 @@ func (e *Employee) Create() gin.HandlerFunc {
 				return
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "internal error"})
+				return
 			}
 		}
 
@@ -156,9 +167,13 @@ func (e *Employee) Create() gin.HandlerFunc {
 // @Tags domain.Employee
 // @Produce json
 // @Accept json
-// @Success 200
-// @Failure 404
+// @Success 200 {object} web.DataResponse{data=domain.Employee}
+// @Failure 400 {object} web.MessageResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 409 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Param id path int true "id from the employee"
+// @Param body body EmployeeRequest true "Employee fields to update"
 // @Router /employees/{id} [patch]
 func (e *Employee) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -241,8 +256,11 @@ func (e *Employee) Update() gin.HandlerFunc {
 
 // @Summary Delete a employee using its id or return an error if that employee not exist.
 // @Tags domain.Employee
+// @Produce json
 // @Success 204
-// @Failure 404
+// @Failure 400 {object} web.MessageResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Param id path int true "id from the employee"
 // @Router /employees/{id} [delete]
 func (e *Employee) Delete() gin.HandlerFunc {
@@ -51,7 +51,7 @@ func TestIntegration_ReadEmployee(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// When
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if the obtained status code matches expected
@@ -73,7 +73,7 @@ func TestIntegration_ReadEmployee(t *testing.T) {
 		err := errors.New("database connection error")
 
 		// - Declaring the expected response body the handler should return.
-		expectedBody := `{}`
+		expectedBody := `{"message":"internal error"}`
 
 		// - Creating a mock of the repository layer.
 		repository := &employee.RepositoryMock{}
@@ -97,7 +97,7 @@ func TestIntegration_ReadEmployee(t *testing.T) {
 
 		// Act
 		// - Serving the HTTP GET request and recording the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Checking if the obtained status code matches the expected code.
@@ -147,7 +147,7 @@ func TestIntegration_CreateEmployee(t *testing.T) {
 
 		// Act
 		// - serve http POST request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if obtained status code matches expected
@@ -184,7 +184,7 @@ func TestIntegration_CreateEmployee(t *testing.T) {
 
 		// Act
 		// - Serve HTTP POST request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if obtained status code matches expected
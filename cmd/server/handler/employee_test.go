@@ -52,7 +52,7 @@ func TestHandler_Read(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -88,7 +88,7 @@ func TestHandler_Read(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -118,7 +118,7 @@ func TestHandler_Read(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -156,7 +156,7 @@ func TestHandler_Create(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -192,7 +192,7 @@ func TestHandler_Create(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -229,7 +229,7 @@ func TestHandler_Create(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -277,7 +277,7 @@ func TestHandler_Update(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -324,7 +324,7 @@ func TestHandler_Update(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -357,7 +357,7 @@ func TestHandler_Update(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -385,7 +385,7 @@ func TestHandler_Update(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -415,7 +415,7 @@ func TestHandler_Delete(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -445,7 +445,7 @@ func TestHandler_Delete(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -473,7 +473,7 @@ func TestHandler_Delete(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
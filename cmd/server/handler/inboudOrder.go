@@ -11,6 +11,7 @@ import (
 
 	"github.com/davidop97/apiGo/internal/domain"
 	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
@@ -46,11 +47,11 @@ type InboudOrderRequest struct {
 
 // @Summary Get all reports with inboudOrders
 // @Tags inboundOrders
-// @Produce json
-// @Failure 400 {object} map[string]any
-// @Failure 404 {object} map[string]any
-// @Success 500 {object} map[string]any
-// @Success 200 {object} map[string]any
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.MessageResponse
+// @Success 200 {object} web.DataResponse{data=[]inboudorder.Report}
 // @Router /employees/reportInboundOrder [get]
 func (i *InboudOrder) GetAllReports() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -62,6 +63,10 @@ func (i *InboudOrder) GetAllReports() gin.HandlerFunc {
 			return
 		}
 
+		if web.Table(c, reports) {
+			return
+		}
+
 		// Construir la respuesta JSON
 		response := gin.H{"data": reports}
 
@@ -74,12 +79,13 @@ func (i *InboudOrder) GetAllReports() gin.HandlerFunc {
 // Summary Report inbound By employee
 // @Description get inbound orders
 // @Tags inboundOrders
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param id query int false "Inbound Orders By Employee id"
-// @Failure 400 {object} map[string]any
-// @Failure 404 {object} map[string]any
-// @Success 500 {object} map[string]any
-// @Success 200 {object} map[string]any
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Failure 400 {object} web.ErrorMessageResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
+// @Success 200 {object} web.DataResponse{data=inboudorder.Report}
 // @Router /employees/reportInboundOrders [get]
 func (i *InboudOrder) GenerateReport() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -107,6 +113,9 @@ func (i *InboudOrder) GenerateReport() gin.HandlerFunc {
 			}
 		}
 
+		if web.Table(c, report) {
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{"data": report})
 
 	}
@@ -116,11 +125,11 @@ func (i *InboudOrder) GenerateReport() gin.HandlerFunc {
 // @Tags inboundOrders
 // @Produce json
 // @Param body body InboudOrderRequest true "Inbound orders body"
-// @Failure 400 {object} map[string]any
-// @Failure 422 {object} map[string]any
-// @Failure 409 {object} map[string]any
-// @Failure 500 {object} map[string]any
-// @Success 201 {object} map[string]any
+// @Failure 400 {object} web.MessageResponse
+// @Failure 422 {object} web.MessageResponse
+// @Failure 409 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
+// @Success 201 {object} web.DataResponse{data=domain.InboudOrder}
 // @Router /inboundOrders [post]
 func (i *InboudOrder) CreateInboundOrder() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -182,6 +191,7 @@ func (i *InboudOrder) CreateInboundOrder() gin.HandlerFunc {
 				return
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "internal error, impossible to creat a new inbound order"})
+				return
 			}
 		}
 
@@ -59,7 +59,7 @@ func TestHandler_GetAllReports(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -87,7 +87,7 @@ func TestHandler_GetAllReports(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -129,7 +129,7 @@ func TestHandler_GenerateReport(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -158,7 +158,7 @@ func TestHandler_GenerateReport(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -188,7 +188,7 @@ func TestHandler_GenerateReport(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -218,7 +218,7 @@ func TestHandler_GenerateReport(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -248,7 +248,7 @@ func TestHandler_GenerateReport(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
@@ -290,7 +290,7 @@ func TestHandler_CreateInboundOrder(t *testing.T) {
 		request, _ := http.NewRequest("POST", route, body)
 		response := httptest.NewRecorder()
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
 		assert.Equal(t, expectedHeaders, response.Header())
@@ -323,7 +323,7 @@ func TestHandler_CreateInboundOrder(t *testing.T) {
 		request, _ := http.NewRequest("POST", route, body)
 		response := httptest.NewRecorder()
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
 		assert.Equal(t, expectedHeaders, response.Header())
@@ -357,7 +357,7 @@ func TestHandler_CreateInboundOrder(t *testing.T) {
 		request, _ := http.NewRequest("POST", route, body)
 		response := httptest.NewRecorder()
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
 		assert.Equal(t, expectedHeaders, response.Header())
@@ -394,7 +394,7 @@ func TestHandler_CreateInboundOrder(t *testing.T) {
 		request, _ := http.NewRequest("POST", route, body)
 		response := httptest.NewRecorder()
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
 		assert.Equal(t, expectedHeaders, response.Header())
@@ -431,7 +431,7 @@ func TestHandler_CreateInboundOrder(t *testing.T) {
 		request, _ := http.NewRequest("POST", route, body)
 		response := httptest.NewRecorder()
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
 		assert.Equal(t, expectedHeaders, response.Header())
@@ -468,7 +468,7 @@ func TestHandler_CreateInboundOrder(t *testing.T) {
 		request, _ := http.NewRequest("POST", route, body)
 		response := httptest.NewRecorder()
 		//When
-		engine.ServeHTTP(response, request)
+		serveHTTP(t, engine, response, request)
 		//Then
 		assert.Equal(t, expectedStatusCode, response.Code)
 		assert.Equal(t, expectedHeaders, response.Header())
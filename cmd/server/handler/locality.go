@@ -37,12 +37,12 @@ func NewLocality(l locality.Service) *Locality {
 // @Description Get a locality by id or an error if that id not exists or an internal error occurs.
 // @Tags domain.Locality
 // @Produce json
-// @Success 200 {object} domain.Locality "Locality requested"
-// @Failure 400 {object} string "invalid id"
-// @Failure 404 {object} string "Locality not found"
-// @Failure 500 {object} string "Server Internal error"
+// @Success 200 {object} web.DataResponse{data=domain.Locality} "Locality requested"
+// @Failure 400 {object} web.ErrorResponse "invalid id"
+// @Failure 404 {object} web.ErrorResponse "Locality not found"
+// @Failure 500 {object} web.ErrorResponse "Server Internal error"
 // @Param id path int true "id from the locality"
-// @Router /locality/{id} [get]
+// @Router /localities/{id} [get]
 func (l *Locality) GetLocalityById() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		idParam := c.Param("id")
@@ -80,10 +80,11 @@ func (l *Locality) GetLocalityById() gin.HandlerFunc {
 // @Summary Get all the localities available.
 // @Description Get all the localities available or an error if the list is empty or an internal error occurs.
 // @Tags domain.Locality
-// @Produce json
-// @Success 200 {array} domain.Locality "List of all localities"
-// @Failure 404 {object} string "Localities not found"
-// @Failure 500 {object} string "Server Internal error"
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.Locality} "List of all localities"
+// @Failure 404 {object} web.ErrorResponse "Localities not found"
+// @Failure 500 {object} web.ErrorResponse "Server Internal error"
 // @Router /localities [get]
 func (l *Locality) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -99,6 +100,9 @@ func (l *Locality) GetAll() gin.HandlerFunc {
 			return
 		}
 		//If no errors occurs, return a 200 status code and the list of localities.
+		if web.Table(c, allLocalities) {
+			return
+		}
 		web.Success(c, http.StatusOK, allLocalities)
 	}
 }
@@ -107,11 +111,11 @@ func (l *Locality) GetAll() gin.HandlerFunc {
 // @Description Create a new locality or an error if that locality cannot be created or an internal error occurs.
 // @Tags domain.Locality
 // @Produce json
-// @Success 201 {object} domain.Locality "New Locality created"
-// @Failure 409 {object} string "locality already exists"
-// @Failure 422 {object} string "invalid JSON"
-// @Failure 422 {object} string "invalid or missing field"
-// @Failure 500 {object} string "Server Internal error"
+// @Success 201 {object} web.DataResponse{data=domain.Locality} "New Locality created"
+// @Failure 409 {object} web.ErrorResponse "locality already exists"
+// @Failure 422 {object} web.ErrorResponse "invalid JSON"
+// @Failure 422 {object} web.ErrorResponse "invalid or missing field"
+// @Failure 500 {object} web.ErrorResponse "Server Internal error"
 // @Param Locality body domain.Locality true "Struct of Locality domain"
 // @Router /localities [post]
 func (l *Locality) Create() gin.HandlerFunc {
@@ -210,14 +214,15 @@ func (l *Locality) Create() gin.HandlerFunc {
 // @Summary Get a report of sellers by locality.
 // @Description Get a report of sellers by locality using its id. Or show all the sellers of all the localities if the id is not provided.
 // @Tags domain.Locality
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param id query int false "locality id"
-// @Success 200 {object} domain.ReportSellers "Report of sellers by locality"
-// @Failure 400 {object} string "invalid id"
-// @Failure 400 {object} string "error getting the report for the requested ID. Id must be greater than 0"
-// @Failure 404 {object} string "locality not found"
-// @Failure 404 {object} string "sellers not found for the requested ID"
-// @Failure 500 {object} string "server internal error"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.ReportSellers} "Report of sellers by locality"
+// @Failure 400 {object} web.ErrorResponse "invalid id"
+// @Failure 400 {object} web.ErrorResponse "error getting the report for the requested ID. Id must be greater than 0"
+// @Failure 404 {object} web.ErrorResponse "locality not found"
+// @Failure 404 {object} web.ErrorResponse "sellers not found for the requested ID"
+// @Failure 500 {object} web.ErrorResponse "server internal error"
 // @Router /localities/reportSellers [get]
 func (s *Locality) GetReportSellers() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -264,6 +269,9 @@ func (s *Locality) GetReportSellers() gin.HandlerFunc {
 			return
 		}
 		//If no errors occurs, return a 200 status code and the requested report.
+		if web.Table(c, report) {
+			return
+		}
 		web.Success(c, http.StatusOK, report)
 	}
 }
@@ -59,7 +59,7 @@ func TestGetAllLocalityIntegration(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (200 OK in this case).
@@ -108,7 +108,7 @@ func TestGetAllLocalityIntegration(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
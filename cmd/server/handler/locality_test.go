@@ -71,7 +71,7 @@ func TestHandler_GetLocalityByID(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (200 OK in this case).
@@ -118,7 +118,7 @@ func TestHandler_GetLocalityByID(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (404 Not Found in this case).
@@ -167,7 +167,7 @@ func TestHandler_GetLocalityByID(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
@@ -211,7 +211,7 @@ func TestHandler_GetLocalityByID(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (400 Bad Request in this case).
@@ -255,7 +255,7 @@ func TestHandler_GetLocalityByID(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (400 Bad Request in this case).
@@ -336,7 +336,7 @@ func TestHandler_GetAll(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (200 OK in this case).
@@ -381,7 +381,7 @@ func TestHandler_GetAll(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (404 Not Found in this case).
@@ -428,7 +428,7 @@ func TestHandler_GetAll(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
@@ -504,7 +504,7 @@ func TestHandler_CreateLocality(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (201 Created in this case).
@@ -569,7 +569,7 @@ func TestHandler_CreateLocality(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
@@ -634,7 +634,7 @@ func TestHandler_CreateLocality(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (409 Conflict in this case).
@@ -688,7 +688,7 @@ func TestHandler_CreateLocality(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (422 Unprocessable Entity in this case).
@@ -742,7 +742,7 @@ func TestHandler_CreateLocality(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (422 Unprocessable Entity in this case).
@@ -795,7 +795,7 @@ func TestHandler_CreateLocality(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (422 Unprocessable Entity in this case).
@@ -891,11 +891,11 @@ func TestHandler_GetReportSellers(t *testing.T) {
 
 		//Create the router and set the route.
 		router := gin.New()
-		route := "/api/v1/localities/reportSellers/:id"
+		route := "/api/v1/localities/reportSellers"
 		router.GET(route, localityHandler.GetReportSellers())
 
 		//Create the request
-		request, _ := http.NewRequest(http.MethodGet, "/api/v1/localities/reportSellers/1", nil)
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/localities/reportSellers", nil)
 		query := request.URL.Query()
 		query.Add("id", strconv.Itoa(1))
 		request.URL.RawQuery = query.Encode()
@@ -904,7 +904,7 @@ func TestHandler_GetReportSellers(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (200 OK in this case).
@@ -944,11 +944,11 @@ func TestHandler_GetReportSellers(t *testing.T) {
 
 		//Create the router and set the route.
 		router := gin.New()
-		route := "/api/v1/localities/reportSellers/:id"
+		route := "/api/v1/localities/reportSellers"
 		router.GET(route, localityHandler.GetReportSellers())
 
 		//Create the request
-		request, _ := http.NewRequest(http.MethodGet, "/api/v1/localities/reportSellers/1", nil)
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/localities/reportSellers", nil)
 		query := request.URL.Query()
 		query.Add("id", strconv.Itoa(1))
 		request.URL.RawQuery = query.Encode()
@@ -957,7 +957,7 @@ func TestHandler_GetReportSellers(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
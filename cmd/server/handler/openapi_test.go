@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidop97/apiGo/docs"
+	"github.com/davidop97/apiGo/pkg/openapitest"
+)
+
+// spec is the /api/v1 document every handler test is checked against.
+var spec = openapitest.New(docs.OpenAPI)
+
+// serveHTTP serves req through h and checks the exchange against
+// docs/openapi.json.
+func serveHTTP(t *testing.T, h http.Handler, w *httptest.ResponseRecorder, req *http.Request) {
+	t.Helper()
+	spec.ServeHTTP(t, h, w, req)
+}
@@ -1,25 +1,116 @@
 package handler
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/pkg/money"
 	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
 var (
-	ErrInvalidIDD      = "invalid id"
-	ErrProductNotFound = "product not found"
-	ErrInternalServer  = "internal server error"
-	ErrInvalidDate     = "invalid date format"
-	ProductDeleted     = "product deleted"
+	ErrInvalidIDD        = "invalid id"
+	ErrProductNotFound   = "product not found"
+	ErrInternalServer    = "internal server error"
+	ErrInvalidDate       = "invalid date format"
+	ProductDeleted       = "product deleted"
+	ErrSearchQuery       = "q must contain a word"
+	ErrSearchSellerID    = "seller_id must be 1 or greater"
+	ErrSearchProductType = "product_type_id must be 1 or greater"
+	ErrSearchLimit       = fmt.Sprintf("limit must be an integer between 1 and %d", product.MaxSearchLimit)
 )
 
+// The prices of the v1 routes are JSON numbers, as they were before the
+// prices became money.Amount; the types below convert them at the boundary.
+// Only /api/v2 writes them as decimal strings.
+
+// ProductRecordRequest is the body of the product record creation.
+type ProductRecordRequest struct {
+	LastUpdate    string  `json:"last_update_date"`
+	PurchasePrice float64 `json:"purchase_price" example:"100"`
+	SalePrice     float64 `json:"sale_price" example:"110"`
+	// Currency is the ISO 4217 code of the prices, USD by default.
+	Currency  string `json:"currency,omitempty" example:"USD"`
+	ProductID int    `json:"product_id"`
+}
+
+// ProductRecordResponse is a product record.
+type ProductRecordResponse struct {
+	ID            int            `json:"id"`
+	LastUpdate    string         `json:"last_update_date"`
+	PurchasePrice float64        `json:"purchase_price" example:"100"`
+	SalePrice     float64        `json:"sale_price" example:"110"`
+	Currency      money.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	ProductID     int            `json:"product_id"`
+}
+
+// ProductRecordHistoryResponse is a record of the prices of a product with
+// its margin, markup and the changes since the previous record, null on the
+// first record and when the previous one is in another currency.
+type ProductRecordHistoryResponse struct {
+	ProductRecordResponse
+	Margin              float64  `json:"margin"`
+	Markup              float64  `json:"markup"`
+	PurchasePriceChange *float64 `json:"purchase_price_change" extensions:"x-nullable"`
+	SalePriceChange     *float64 `json:"sale_price_change" extensions:"x-nullable"`
+}
+
+// ProductRecordBulkRequest is the body of the bulk product record creation:
+// the products matching every selector field given get a record with the
+// sale price of the rule (absolute, percentage or margin) and value.
+type ProductRecordBulkRequest struct {
+	SellerID      int     `json:"seller_id"`
+	ProductTypeID int     `json:"product_type_id"`
+	ProductIDs    []int   `json:"product_ids"`
+	Rule          string  `json:"rule" enums:"absolute,percentage,margin"`
+	Value         float64 `json:"value" example:"10"`
+	LastUpdate    string  `json:"last_update_date"`
+}
+
+// ProductRecordChange is the new record of a product created in bulk.
+type ProductRecordChange struct {
+	ProductID     int            `json:"product_id"`
+	RecordID      int            `json:"record_id,omitempty"`
+	PurchasePrice float64        `json:"purchase_price" example:"100"`
+	OldSalePrice  float64        `json:"old_sale_price" example:"120"`
+	NewSalePrice  float64        `json:"new_sale_price" example:"132"`
+	Currency      money.Currency `json:"currency" swaggertype:"string" example:"USD"`
+}
+
+// recordResponse returns the v1 form of a product record.
+func recordResponse(r domain.ProductRecord) ProductRecordResponse {
+	return ProductRecordResponse{
+		ID:            r.ID,
+		LastUpdate:    r.LastUpdate,
+		PurchasePrice: r.PurchasePrice.Float64(),
+		SalePrice:     r.SalePrice.Float64(),
+		Currency:      r.Currency,
+		ProductID:     r.ProductID,
+	}
+}
+
+// historyResponse returns the v1 form of a record of the price history.
+func historyResponse(h domain.ProductRecordHistory) ProductRecordHistoryResponse {
+	res := ProductRecordHistoryResponse{ProductRecordResponse: recordResponse(h.ProductRecord), Margin: h.Margin, Markup: h.Markup}
+	if h.PurchasePriceChange != nil {
+		change := h.PurchasePriceChange.Float64()
+		res.PurchasePriceChange = &change
+	}
+	if h.SalePriceChange != nil {
+		change := h.SalePriceChange.Float64()
+		res.SalePriceChange = &change
+	}
+	return res
+}
+
 // Product struct represents a product handler.
 type Product struct {
 	// productService product.Service
@@ -40,7 +131,7 @@ func NewProduct(service product.Service) *Product {
 // @Tags ping
 // @Tags domain.Product
 // @Produce json
-// @Success 200
+// @Success 200 {string} string "pong"
 // @Router /ping [get]
 func (p *Product) Ping() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -51,9 +142,10 @@ func (p *Product) Ping() gin.HandlerFunc {
 // GetAll handles the endpoint to retrieve all products.
 // @Summary Retrieves a list of all products.
 // @Tags products
-// @Produce json
-// @Success 200 {object} domain.Product "List of all products"
-// @Failure 500 {object} string "Internal Server Error"
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.Product} "List of all products"
+// @Failure 500 {string} string "Internal Server Error"
 // @Router /products [get]
 func (p *Product) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -63,20 +155,81 @@ func (p *Product) GetAll() gin.HandlerFunc {
 			return
 		}
 
+		if web.Table(c, products) {
+			return
+		}
 		web.Success(c, http.StatusOK, products)
 	}
 }
 
+// Search handles the endpoint to search products.
+// @Summary Searches products.
+// @Description Searches the words of q in the description and product_code of the products, the most relevant first.
+// @Description A word matches the words starting with it and, from four letters on, the ones differing by a typo (two from eight letters on).
+// @Description Every word of q must match.
+// @Tags products
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Param q query string true "Words to search"
+// @Param seller_id query int false "Only the products of this seller"
+// @Param product_type_id query int false "Only the products of this product type"
+// @Param limit query int false "Number of products returned, 20 by default" minimum(1) maximum(100)
+// @Success 200 {object} web.DataResponse{data=[]product.SearchResult} "Products found, with their score"
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/search [get]
+func (p *Product) Search() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		q := product.SearchQuery{Text: c.Query("q")}
+		for _, param := range []struct {
+			name    string
+			dst     *int
+			max     int
+			message string
+		}{
+			{"seller_id", &q.SellerID, 0, ErrSearchSellerID},
+			{"product_type_id", &q.ProductTypeID, 0, ErrSearchProductType},
+			{"limit", &q.Limit, product.MaxSearchLimit, ErrSearchLimit},
+		} {
+			raw, ok := c.GetQuery(param.name)
+			if !ok {
+				continue
+			}
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 || (param.max > 0 && n > param.max) {
+				web.Error(c, http.StatusBadRequest, param.message)
+				return
+			}
+			*param.dst = n
+		}
+
+		results, err := p.service.Search(c, q)
+		if err != nil {
+			if errors.Is(err, product.ErrEmptyQuery) {
+				web.Error(c, http.StatusBadRequest, ErrSearchQuery)
+				return
+			}
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+
+		if web.Table(c, results) {
+			return
+		}
+		web.Success(c, http.StatusOK, results)
+	}
+}
+
 // Get handles the endpoint to retrieve a specific product by ID.
 // @Summary Retrieves a product by ID.
 // @Tags products
 // @Produce json
 // @Tags domain.Product
 // @Param id path int true "Product ID"
-// @Success 200 {object} domain.Product "Product data"
-// @Failure 400 {object} string "Invalid ID"
-// @Failure 404 {object} string "Product Not Found"
-// @Failure 500 {object} string "Internal Server Error"
+// @Success 200 {object} web.DataResponse{data=domain.Product} "Product data"
+// @Failure 400 {string} string "Invalid ID"
+// @Failure 404 {string} string "Product Not Found"
+// @Failure 500 {string} string "Internal Server Error"
 // @Router /products/{id} [get]
 func (p *Product) Get() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -108,10 +261,10 @@ func (p *Product) Get() gin.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param product body domain.Product true "Product to be created"
-// @Success 201 {object} domain.Product "Created product data"
-// @Failure 400 {object} string "Invalid JSON"
-// @Failure 422 {object} string "Invalid JSON"
-// @Failure 500 {object} string "Internal Server Error"
+// @Success 201 {object} web.DataResponse{data=domain.Product} "Created product data"
+// @Failure 409 {string} string "Product code already exists"
+// @Failure 422 {string} string "Invalid JSON or product type not found"
+// @Failure 500 {string} string "Internal Server Error"
 // @Router /products [post]
 func (p *Product) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -137,6 +290,9 @@ func (p *Product) Create() gin.HandlerFunc {
 			case errors.Is(err, product.ErrProductCodeExists):
 				web.Response(c, http.StatusConflict, err.Error())
 				return
+			case errors.Is(err, product.ErrProductTypeNotFound):
+				web.Response(c, http.StatusUnprocessableEntity, err.Error())
+				return
 			default:
 				web.Response(c, http.StatusInternalServerError, ErrInternalServer)
 				return
@@ -213,12 +369,13 @@ func checkStruct(product *domain.Product) bool {
 // @Produce json
 // @Param id path int true "Product ID"
 // @Param product body domain.Product true "Updated product object"
-// @Success 200 {object} domain.Product "Updated product data"
-// @Failure 400 {object} string "Invalid ID"
-// @Failure 404 {object} string "Product Not Found"
-// @Failure 422 {object} string "Invalid JSON"
-// @Failure 500 {object} string "Internal Server Error"
-// @Router /products/{id} [put]
+// @Success 200 {object} web.DataResponse{data=domain.Product} "Updated product data"
+// @Failure 400 {string} string "Invalid ID"
+// @Failure 404 {string} string "Product Not Found"
+// @Failure 409 {string} string "Product code already exists"
+// @Failure 422 {string} string "Invalid JSON or product type not found"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /products/{id} [patch]
 func (p *Product) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, err := strconv.Atoi(c.Param("id"))
@@ -267,6 +424,9 @@ func (p *Product) Update() gin.HandlerFunc {
 			case errors.Is(err, product.ErrProductCodeExists):
 				web.Response(c, http.StatusConflict, err.Error())
 				return
+			case errors.Is(err, product.ErrProductTypeNotFound):
+				web.Response(c, http.StatusUnprocessableEntity, err.Error())
+				return
 			default:
 				web.Response(c, http.StatusInternalServerError, ErrInternalServer)
 				return
@@ -317,22 +477,41 @@ func (p *Product) Delete() gin.HandlerFunc {
 // @Tags productrecords
 // @Accept json
 // @Produce json
-// @Param product body domain.ProductRecordCreate true "Product Record to be created"
-// @Success 201 {object} domain.ProductRecord
+// @Param product body ProductRecordRequest true "Product Record to be created"
+// @Success 201 {object} web.DataResponse{data=ProductRecordResponse}
+// @Failure 409 {string} string "Product Not Found or record predating the latest one"
 // @Failure 422 {string} string "Invalid JSON"
 // @Failure 500 {string} string "Internal Server Error"
-// @Router /productrecord [post]
+// @Router /productRecords [post]
 func (p *Product) CreateProductRecord() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req domain.ProductRecordCreate
+		var body ProductRecordRequest
 
 		// Check if JSON is valid
-		err := c.ShouldBindJSON(&req)
+		err := c.ShouldBindJSON(&body)
 		if err != nil {
 			c.JSON(http.StatusUnprocessableEntity, ErrInvalidJSON)
 			return
 		}
 
+		purchase, err := money.FromFloat(body.PurchasePrice)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, ErrInvalidJSON)
+			return
+		}
+		sale, err := money.FromFloat(body.SalePrice)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, ErrInvalidJSON)
+			return
+		}
+		req := domain.ProductRecordCreate{
+			LastUpdate:    body.LastUpdate,
+			PurchasePrice: purchase,
+			SalePrice:     sale,
+			Currency:      money.Currency(body.Currency),
+			ProductID:     body.ProductID,
+		}
+
 		// Check if all fields are valid
 		if req.LastUpdate == "" || req.PurchasePrice <= 0 || req.SalePrice <= 0 || req.ProductID <= 0 {
 			c.JSON(http.StatusUnprocessableEntity, ErrInvalidJSON)
@@ -348,10 +527,20 @@ func (p *Product) CreateProductRecord() gin.HandlerFunc {
 			}
 		}
 
+		// The prices are in USD unless told otherwise
+		if req.Currency == "" {
+			req.Currency = money.DefaultCurrency
+		}
+		req.Currency, err = money.ParseCurrency(string(req.Currency))
+		if err != nil {
+			web.Response(c, http.StatusUnprocessableEntity, product.ErrInvalidCurrency.Error())
+			return
+		}
+
 		products, err := p.service.CreateProductRecord(c, req)
 		if err != nil {
 			switch {
-			case errors.Is(err, product.ErrNotFound):
+			case errors.Is(err, product.ErrNotFound), errors.Is(err, product.ErrRecordPredatesLatest):
 				web.Response(c, http.StatusConflict, err.Error())
 				return
 			default:
@@ -366,22 +555,105 @@ func (p *Product) CreateProductRecord() gin.HandlerFunc {
 			LastUpdate:    req.LastUpdate,
 			PurchasePrice: req.PurchasePrice,
 			SalePrice:     req.SalePrice,
+			Currency:      req.Currency,
 			ProductID:     req.ProductID,
 		}
 
-		web.Success(c, http.StatusCreated, update)
+		web.Success(c, http.StatusCreated, recordResponse(update))
+	}
+}
+
+// CreateProductRecordsBulk handles the endpoint to create the records of many products at once.
+// @Summary Creates a record with new prices for every selected product, in one transaction.
+// @Description The purchase price is the one of the latest record of each product. If a product cannot be repriced, nothing is saved.
+// @Tags productrecords
+// @Accept json
+// @Produce json
+// @Param dry_run query bool false "Preview the new prices without saving them"
+// @Param records body ProductRecordBulkRequest true "Selector and rule"
+// @Success 200 {object} web.DataResponse{data=[]ProductRecordChange}
+// @Failure 400 {string} string "Invalid dry_run"
+// @Failure 422 {string} string "Invalid JSON, selector or rule, or products that cannot be repriced"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /productRecords/bulk [post]
+func (p *Product) CreateProductRecordsBulk() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+		if err != nil {
+			web.Response(c, http.StatusBadRequest, "invalid dry_run")
+			return
+		}
+
+		var req ProductRecordBulkRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.Response(c, http.StatusUnprocessableEntity, ErrInvalidJSON)
+			return
+		}
+		if _, err := time.Parse("2006-01-02", req.LastUpdate); err != nil {
+			web.Response(c, http.StatusUnprocessableEntity, ErrInvalidDate)
+			return
+		}
+		value, err := money.FromFloat(req.Value)
+		if err != nil {
+			web.Response(c, http.StatusUnprocessableEntity, ErrInvalidJSON)
+			return
+		}
+
+		changes, err := p.service.UpdatePrices(c, product.PriceUpdate{
+			Selector:   product.PriceSelector{SellerID: req.SellerID, ProductTypeID: req.ProductTypeID, ProductIDs: req.ProductIDs},
+			Rule:       product.PriceRule{Type: req.Rule, Value: value},
+			LastUpdate: req.LastUpdate,
+			DryRun:     dryRun,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, product.ErrEmptySelector), errors.Is(err, product.ErrInvalidPriceRule), errors.Is(err, product.ErrNoProductsSelected):
+				web.Response(c, http.StatusUnprocessableEntity, err.Error())
+				return
+			default:
+				web.Response(c, http.StatusInternalServerError, ErrInternalServer)
+				return
+			}
+		}
+
+		// Every rejected product is reported
+		var rejected []string
+		records := make([]ProductRecordChange, 0, len(changes))
+		for _, change := range changes {
+			if change.Err != nil {
+				rejected = append(rejected, fmt.Sprintf("product %d: %s", change.ProductID, change.Err))
+				continue
+			}
+			records = append(records, ProductRecordChange{
+				ProductID:     change.ProductID,
+				RecordID:      change.RecordID,
+				PurchasePrice: change.PurchasePrice.Float64(),
+				OldSalePrice:  change.OldSalePrice.Float64(),
+				NewSalePrice:  change.NewSalePrice.Float64(),
+				Currency:      change.Currency,
+			})
+		}
+		if len(rejected) > 0 {
+			web.Response(c, http.StatusUnprocessableEntity, strings.Join(rejected, "; "))
+			return
+		}
+
+		web.Success(c, http.StatusOK, records)
 	}
 }
 
 // GetProductRecord handles the endpoint to retrieve product records by product ID.
 // @Summary Retrieves product records by product ID or all product records if idProduct is 0.
+// @Description With format=ndjson, or Accept: application/x-ndjson, the counts are streamed a JSON object per line as they are read.
 // @Tags productrecords
-// @Produce json
+// @Produce json,application/x-ndjson,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param id query int false "Product ID"
-// @Success 200 {array} domain.ProductRecordGet
+// @Param format query string false "json (default), ndjson, csv or xlsx, overrides the Accept header" Enums(json, ndjson, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.ProductRecordGet}
+// @Failure 400 {string} string "Invalid ID"
 // @Failure 404 {string} string "Product Not Found"
 // @Failure 500 {string} string "Internal Server Error"
-// @Router /productrecord [get]
+// @Router /products/reportRecords [get]
 func (p *Product) GetProductRecord() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var id int // id = 0
@@ -397,7 +669,14 @@ func (p *Product) GetProductRecord() gin.HandlerFunc {
 			}
 		}
 
-		products, err := p.service.GetProductRecord(c, id)
+		// A streamed response is written as the records are read
+		streamed, err := web.Stream(c, func(ctx context.Context, fn func(domain.ProductRecordGet) error) error {
+			return p.service.EachProductRecord(ctx, id, fn)
+		})
+		var products []domain.ProductRecordGet
+		if !streamed {
+			products, err = p.service.GetProductRecord(c, id)
+		}
 		if err != nil {
 			switch {
 			case errors.Is(err, product.ErrNotFound):
@@ -409,6 +688,69 @@ func (p *Product) GetProductRecord() gin.HandlerFunc {
 			}
 		}
 
-		web.Success(c, http.StatusOK, products)
+		if !streamed && !web.Table(c, products) {
+			web.Success(c, http.StatusOK, products)
+		}
+	}
+}
+
+// GetRecords handles the endpoint to retrieve the price history of a product.
+// @Summary Retrieves the records of a product, the oldest first, with their margin, markup and price changes.
+// @Tags productrecords
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Param id path int true "Product ID"
+// @Param from query string false "Only the records dated on or after this date (yyyy-mm-dd)"
+// @Param to query string false "Only the records dated on or before this date (yyyy-mm-dd)"
+// @Param currency query string false "ISO 4217 code to convert the prices to"
+// @Success 200 {object} web.DataResponse{data=[]ProductRecordHistoryResponse}
+// @Failure 400 {string} string "Invalid ID or date"
+// @Failure 404 {string} string "Product Not Found"
+// @Failure 422 {string} string "Invalid currency or currency without a rate"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /products/{id}/records [get]
+func (p *Product) GetRecords() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Response(c, http.StatusBadRequest, ErrInvalidID)
+			return
+		}
+
+		// Both dates are optional, in format yyyy-mm-dd
+		filter := domain.ProductRecordFilter{From: c.Query("from"), To: c.Query("to"), Currency: money.Currency(c.Query("currency"))}
+		for _, date := range []string{filter.From, filter.To} {
+			if date == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				web.Response(c, http.StatusBadRequest, ErrInvalidDate)
+				return
+			}
+		}
+
+		history, err := p.service.ProductRecords(c, id, filter)
+		if err != nil {
+			switch {
+			case errors.Is(err, product.ErrNotFound):
+				web.Response(c, http.StatusNotFound, ErrProductNotFound)
+				return
+			case errors.Is(err, product.ErrInvalidCurrency), errors.Is(err, product.ErrCurrencyRateNotFound), errors.Is(err, money.ErrOutOfRange):
+				web.Response(c, http.StatusUnprocessableEntity, err.Error())
+				return
+			default:
+				web.Response(c, http.StatusInternalServerError, ErrInternalServer)
+				return
+			}
+		}
+
+		records := make([]ProductRecordHistoryResponse, 0, len(history))
+		for _, h := range history {
+			records = append(records, historyResponse(h))
+		}
+		if web.Table(c, records) {
+			return
+		}
+		web.Success(c, http.StatusOK, records)
 	}
 }
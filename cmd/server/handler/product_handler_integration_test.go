@@ -8,6 +8,7 @@ import (
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/pkg/money"
 	"github.com/gin-gonic/gin"
 	"github.com/goccy/go-json"
 	"github.com/stretchr/testify/mock"
@@ -50,7 +51,7 @@ func TestIntegrationCreateProduct(t *testing.T) {
 		// act
 		request, _ := http.NewRequest(http.MethodPost, "/api/v1/products", reader)
 		response := httptest.NewRecorder()
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		// assert
 		if response.Code != http.StatusCreated {
@@ -90,7 +91,7 @@ func TestIntegrationCreateProduct(t *testing.T) {
 		// act
 		request, _ := http.NewRequest(http.MethodPost, "/api/v1/products", reader)
 		response := httptest.NewRecorder()
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		// assert
 		if response.Code != http.StatusConflict {
@@ -103,12 +104,13 @@ func TestIntegrationCreateProduct(t *testing.T) {
 		// arrange
 		expectedProductRecord := domain.ProductRecordCreate{
 			LastUpdate:    "2021-04-04",
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     44,
 		}
 		// Convert product to json
-		jsonProductRecord, _ := json.Marshal(expectedProductRecord)
+		jsonProductRecord, _ := json.Marshal(recordRequest(expectedProductRecord))
 
 		// Create a new reader with the JSON
 		reader := bytes.NewReader(jsonProductRecord)
@@ -118,14 +120,16 @@ func TestIntegrationCreateProduct(t *testing.T) {
 		mockRepo := &product.RepositoryMock{}
 		service := product.NewService(mockRepo)
 		productHandler := NewProduct(service)
-		mockRepo.On("Get", mock.Anything, expectedProductRecord.ProductID).Return(domain.Product{}, nil)
+		mockRepo.On("InTx", mock.Anything).Return(nil)
+		mockRepo.On("GetForUpdate", mock.Anything, expectedProductRecord.ProductID).Return(domain.Product{}, nil)
+		mockRepo.On("LatestRecordDate", mock.Anything, expectedProductRecord.ProductID).Return("", nil)
 		mockRepo.On("CreateProductRecord", mock.Anything, expectedProductRecord).Return(1, nil)
 		router.POST("/api/v1/productRecords", productHandler.CreateProductRecord())
 
 		// act
 		request, _ := http.NewRequest(http.MethodPost, "/api/v1/productRecords", reader)
 		response := httptest.NewRecorder()
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		// assert
 		if response.Code != http.StatusCreated {
@@ -136,12 +140,13 @@ func TestIntegrationCreateProduct(t *testing.T) {
 		// arrange
 		expectedProductRecord := domain.ProductRecordCreate{
 			LastUpdate:    "2021-04-04",
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     44,
 		}
 		// Convert product to json
-		jsonProductRecord, _ := json.Marshal(expectedProductRecord)
+		jsonProductRecord, _ := json.Marshal(recordRequest(expectedProductRecord))
 
 		// Create a new reader with the JSON
 		reader := bytes.NewReader(jsonProductRecord)
@@ -151,14 +156,15 @@ func TestIntegrationCreateProduct(t *testing.T) {
 		mockRepo := &product.RepositoryMock{}
 		service := product.NewService(mockRepo)
 		productHandler := NewProduct(service)
-		mockRepo.On("Get", mock.Anything, expectedProductRecord.ProductID).Return(domain.Product{}, product.ErrNotFound)
+		mockRepo.On("InTx", mock.Anything).Return(nil)
+		mockRepo.On("GetForUpdate", mock.Anything, expectedProductRecord.ProductID).Return(domain.Product{}, product.ErrNotFound)
 		//mockRepo.On("CreateProductRecord", mock.Anything, expectedProductRecord).Return(1, nil)
 		router.POST("/api/v1/productRecords", productHandler.CreateProductRecord())
 
 		// act
 		request, _ := http.NewRequest(http.MethodPost, "/api/v1/productRecords", reader)
 		response := httptest.NewRecorder()
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		// assert
 		if response.Code != http.StatusConflict {
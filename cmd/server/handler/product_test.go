@@ -7,13 +7,18 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
 )
 
 // User story: READ
@@ -36,7 +41,7 @@ func Test_Handler_Ping(t *testing.T) {
 		gin.SetMode(gin.TestMode) // Config gin to test mode
 		router := gin.New()
 		router.GET(route, handler.Ping())
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code) // Check status code 200
@@ -98,7 +103,7 @@ func Test_Handler_Get(t *testing.T) {
 		gin.SetMode(gin.TestMode) // Config gin to test mode
 		router := gin.New()
 		router.GET(route, handler.GetAll())
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusOK, w.Code)                                    // Check status code 200
@@ -106,6 +111,37 @@ func Test_Handler_Get(t *testing.T) {
 		handlerMock.AssertExpectations(t)                                         // Check if mock was called
 	})
 
+	t.Run("when the format is xlsx, it should export the products as a workbook", func(t *testing.T) {
+		//Arrange
+		route := "/api/v1/products"
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("GetAll", mock.Anything).Return([]domain.Product{
+			{ID: 1, Description: "Fresh Milk", ProductCode: "123456", ProductTypeID: 1, SellerID: 1},
+		}, nil)
+		handler := NewProduct(handlerMock)
+		req := httptest.NewRequest(http.MethodGet, route+"?format=xlsx", nil)
+		w := httptest.NewRecorder()
+
+		//Act
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET(route, handler.GetAll())
+		serveHTTP(t, router, w, req)
+
+		//Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, web.MIMEXLSX, w.Header().Get("Content-Type"))
+		f, err := excelize.OpenReader(w.Body)
+		require.NoError(t, err)
+		defer f.Close()
+		rows, err := f.GetRows("Sheet1")
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "id", rows[0][0])
+		assert.Equal(t, []string{"1", "Fresh Milk"}, rows[1][:2])
+		handlerMock.AssertExpectations(t)
+	})
+
 	// find_by_id_non_existent
 	t.Run("when the id does not exist, it should return a code 404", func(t *testing.T) {
 		//Arrange
@@ -124,7 +160,7 @@ func Test_Handler_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusNotFound, w.Code) // Check status code 404
@@ -150,7 +186,7 @@ func Test_Handler_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusOK, w.Code) // Check status code 200
@@ -175,7 +211,7 @@ func Test_Handler_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code) // Check status code 500
@@ -200,7 +236,7 @@ func Test_Handler_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code) // Check status code 500
@@ -224,7 +260,7 @@ func Test_Handler_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusBadRequest, w.Code) // Check status code 400
@@ -275,7 +311,7 @@ func TestProduct_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusCreated, w.Code) // Check status code 201
@@ -316,7 +352,7 @@ func TestProduct_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 400
@@ -360,12 +396,47 @@ func TestProduct_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusConflict, w.Code) // Check status code 409
 		handlerMock.AssertExpectations(t)
 	})
+	// create_unknown_product_type
+	t.Run("when the product type does not exist, it should return a code 422", func(t *testing.T) {
+		//Arrange
+		newProduct := domain.Product{
+			Description:    "Fresh Milk",
+			ExpirationRate: 0.1,
+			FreezingRate:   0.05,
+			Height:         25,
+			Length:         10,
+			Netweight:      1,
+			ProductCode:    "123456",
+			RecomFreezTemp: -4,
+			Width:          10,
+			ProductTypeID:  99, // Missing product type
+			SellerID:       1,
+		}
+		route := "/api/v1/products"
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("Save", mock.Anything, newProduct).Return(0, product.ErrProductTypeNotFound)
+		handler := NewProduct(handlerMock) // Instance of handler
+
+		jsonProduct, _ := json.Marshal(newProduct)
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST(route, handler.Create())
+		req := httptest.NewRequest(http.MethodPost, route, bytes.NewReader(jsonProduct))
+		w := httptest.NewRecorder() // Instance of response
+
+		//Act
+		serveHTTP(t, router, w, req) // Execute request
+
+		//Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
+		handlerMock.AssertExpectations(t)
+	})
 	// Err invalid json
 	t.Run("when the json is invalid, it should return StatusUnprocessableEntity", func(t *testing.T) {
 		//Arrange
@@ -386,7 +457,7 @@ func TestProduct_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
@@ -429,7 +500,7 @@ func TestProduct_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code) // Check status code 500
@@ -473,13 +544,13 @@ func TestProduct_Update(t *testing.T) {
 		//Config gin to test mode
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		router.PUT(route, handler.Update())
+		router.PATCH(route, handler.Update())
 		//Request route with id
-		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/products/%d", expectedProduct.ID), reader)
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/products/%d", expectedProduct.ID), reader)
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusOK, w.Code) // Check status code 200
@@ -517,13 +588,13 @@ func TestProduct_Update(t *testing.T) {
 		//Config gin to test mode
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		router.PUT(route, handler.Update())
+		router.PATCH(route, handler.Update())
 		//Request route with id
-		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/products/%d", nonexistentProduct.ID), reader)
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/products/%d", nonexistentProduct.ID), reader)
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusNotFound, w.Code) // Check status code 404
@@ -539,13 +610,13 @@ func TestProduct_Update(t *testing.T) {
 		//Config gin to test mode
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		router.PUT(route, handler.Update())
+		router.PATCH(route, handler.Update())
 		//Request route with id
-		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/products/%s", "abc"), nil)
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/products/%s", "abc"), nil)
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusBadRequest, w.Code) // Check status code 400
@@ -563,13 +634,13 @@ func TestProduct_Update(t *testing.T) {
 		//Config gin to test mode
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		router.PUT(route, handler.Update())
+		router.PATCH(route, handler.Update())
 		//Request route with id
-		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/products/%d", id), nil)
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/products/%d", id), nil)
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code) // Check status code 500
@@ -586,13 +657,13 @@ func TestProduct_Update(t *testing.T) {
 		//Config gin to test mode
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		router.PUT(route, handler.Update())
+		router.PATCH(route, handler.Update())
 		//Request route with id
-		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/products/%d", 1), bytes.NewReader([]byte("invalid json")))
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/products/%d", 1), bytes.NewReader([]byte("invalid json")))
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
@@ -629,13 +700,13 @@ func TestProduct_Update(t *testing.T) {
 		//Config gin to test mode
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		router.PUT(route, handler.Update())
+		router.PATCH(route, handler.Update())
 		//Request route with id
-		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/products/%d", 1), reader)
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/products/%d", 1), reader)
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusConflict, w.Code) // Check status code 409
@@ -674,13 +745,13 @@ func TestProduct_Update(t *testing.T) {
 		//Config gin to test mode
 		gin.SetMode(gin.TestMode)
 		router := gin.New()
-		router.PUT(route, handler.Update())
+		router.PATCH(route, handler.Update())
 		//Request route with id
-		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/products/%d", id), reader)
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/products/%d", id), reader)
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code) // Check status code 500
@@ -710,7 +781,7 @@ func TestProduct_Delete(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusNoContent, w.Code) // Check status code 200
@@ -735,7 +806,7 @@ func TestProduct_Delete(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusNotFound, w.Code) // Check status code 404
@@ -759,7 +830,7 @@ func TestProduct_Delete(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusBadRequest, w.Code) // Check status code 400
@@ -784,7 +855,7 @@ func TestProduct_Delete(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code) // Check status code 500
@@ -793,13 +864,25 @@ func TestProduct_Delete(t *testing.T) {
 
 }
 
+// recordRequest returns the v1 body creating r, with the prices as numbers.
+func recordRequest(r domain.ProductRecordCreate) ProductRecordRequest {
+	return ProductRecordRequest{
+		LastUpdate:    r.LastUpdate,
+		PurchasePrice: r.PurchasePrice.Float64(),
+		SalePrice:     r.SalePrice.Float64(),
+		Currency:      string(r.Currency),
+		ProductID:     r.ProductID,
+	}
+}
+
 func TestProductRecord_Create(t *testing.T) {
 	t.Run("when the petition is correct, it should return a code 200 with the object", func(t *testing.T) {
 		//Arrange
 		expectedProductRecord := domain.ProductRecordCreate{
 			LastUpdate:    "2021-04-04",
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     44,
 		}
 		expectedProductID := 1
@@ -809,7 +892,7 @@ func TestProductRecord_Create(t *testing.T) {
 		handler := NewProduct(handlerMock) // Instance of handler
 
 		// Convert product to json
-		jsonProduct, _ := json.Marshal(expectedProductRecord)
+		jsonProduct, _ := json.Marshal(recordRequest(expectedProductRecord))
 
 		// Create a new reader with the JSON
 		reader := bytes.NewReader(jsonProduct)
@@ -823,10 +906,12 @@ func TestProductRecord_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusCreated, w.Code) // Check status code 201
+		// The prices stay JSON numbers on v1
+		assert.JSONEq(t, `{"data":{"id":1,"last_update_date":"2021-04-04","purchase_price":10,"sale_price":15,"currency":"USD","product_id":44}}`, w.Body.String())
 		handlerMock.AssertExpectations(t)
 	})
 
@@ -834,12 +919,13 @@ func TestProductRecord_Create(t *testing.T) {
 		//Arrange
 		expectedProductRecord := domain.ProductRecordCreate{
 			LastUpdate:    "2021-04-04",
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     100,
 		}
 		//expectedProduct to json
-		jsonProduct, _ := json.Marshal(expectedProductRecord)
+		jsonProduct, _ := json.Marshal(recordRequest(expectedProductRecord))
 		route := "/api/v1/productRecords"
 		handlerMock := &product.ServiceMock{}
 		handlerMock.On("CreateProductRecord", mock.Anything, expectedProductRecord).Return(0, product.ErrNotFound)
@@ -854,7 +940,7 @@ func TestProductRecord_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusConflict, w.Code) // Check status code 409
@@ -881,7 +967,7 @@ func TestProductRecord_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
@@ -900,7 +986,7 @@ func TestProductRecord_Create(t *testing.T) {
 		handler := NewProduct(handlerMock) // Instance of handler
 
 		// Convert product to json
-		jsonProduct, _ := json.Marshal(invalidProductRecord)
+		jsonProduct, _ := json.Marshal(recordRequest(invalidProductRecord))
 
 		// Create a new reader with the JSON
 		reader := bytes.NewReader(jsonProduct)
@@ -914,10 +1000,33 @@ func TestProductRecord_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
+
+		//Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
+	})
+
+	t.Run("when a price is out of range, it should return a code 422", func(t *testing.T) {
+		//Arrange
+		route := "/api/v1/productRecords"
+		handlerMock := &product.ServiceMock{}
+		handler := NewProduct(handlerMock) // Instance of handler
+		body := `{"last_update_date":"2021-01-01","purchase_price":1e300,"sale_price":15,"product_id":1}`
+
+		// Config gin to test mode
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST(route, handler.CreateProductRecord())
+		// Request route
+		req := httptest.NewRequest(http.MethodPost, route, strings.NewReader(body))
+		w := httptest.NewRecorder() // Instance of response
+
+		//Act
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
+		handlerMock.AssertNotCalled(t, "CreateProductRecord", mock.Anything, mock.Anything)
 	})
 
 	// create_fail_due_to_invalid_date
@@ -925,8 +1034,9 @@ func TestProductRecord_Create(t *testing.T) {
 		//Arrange
 		invalidProductRecord := domain.ProductRecordCreate{
 			LastUpdate:    "invalid date", // Invalid date
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     44,
 		}
 		route := "/api/v1/productRecords"
@@ -934,7 +1044,7 @@ func TestProductRecord_Create(t *testing.T) {
 		handler := NewProduct(handlerMock) // Instance of handler
 
 		// Convert product to json
-		jsonProduct, _ := json.Marshal(invalidProductRecord)
+		jsonProduct, _ := json.Marshal(recordRequest(invalidProductRecord))
 
 		// Create a new reader with the JSON
 		reader := bytes.NewReader(jsonProduct)
@@ -948,7 +1058,7 @@ func TestProductRecord_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
@@ -959,8 +1069,9 @@ func TestProductRecord_Create(t *testing.T) {
 		//Arrange
 		productRecord := domain.ProductRecordCreate{
 			LastUpdate:    "2021-04-04",
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     44,
 		}
 		route := "/api/v1/productRecords"
@@ -969,7 +1080,7 @@ func TestProductRecord_Create(t *testing.T) {
 		handler := NewProduct(handlerMock) // Instance of handler
 
 		// Convert product to json
-		jsonProduct, _ := json.Marshal(productRecord)
+		jsonProduct, _ := json.Marshal(recordRequest(productRecord))
 
 		// Create a new reader with the JSON
 		reader := bytes.NewReader(jsonProduct)
@@ -983,7 +1094,7 @@ func TestProductRecord_Create(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code) // Check status code 500
@@ -1015,13 +1126,38 @@ func TestProductRecord_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusOK, w.Code) // Check status code 200
 		handlerMock.AssertExpectations(t)      // Check if mock was called
 	})
 
+	t.Run("when the format is csv, it should export the record counts as CSV", func(t *testing.T) {
+		//Arrange
+		route := "/api/v1/products/reportRecords"
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("GetProductRecord", mock.Anything, 0).Return([]domain.ProductRecordGet{
+			{ProductID: 44, Description: "Test", RecordCount: 1},
+			{ProductID: 45, Description: "Other", RecordCount: 3},
+		}, nil)
+		handler := NewProduct(handlerMock)
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET(route, handler.GetProductRecord())
+		req := httptest.NewRequest(http.MethodGet, route+"?format=csv", nil)
+		w := httptest.NewRecorder()
+
+		//Act
+		serveHTTP(t, router, w, req)
+
+		//Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Regexp(t, `^attachment; filename="products-reportRecords-\d{8}T\d{6}Z\.csv"$`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "product_id,description,record_count\n44,Test,1\n45,Other,3\n", w.Body.String())
+		handlerMock.AssertExpectations(t)
+	})
+
 	t.Run("when the id is string, it should return StatusBadRequest and invalidID error", func(t *testing.T) {
 		//Arrange
 		expectedProductID := "string"
@@ -1038,7 +1174,7 @@ func TestProductRecord_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusBadRequest, w.Code) // Check status code 400
@@ -1070,7 +1206,7 @@ func TestProductRecord_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusNotFound, w.Code) // Check status code 404
@@ -1101,7 +1237,7 @@ func TestProductRecord_Get(t *testing.T) {
 		w := httptest.NewRecorder() // Instance of response
 
 		//Act
-		router.ServeHTTP(w, req) // Execute request
+		serveHTTP(t, router, w, req) // Execute request
 
 		//Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code) // Check status code 500
@@ -1109,6 +1245,157 @@ func TestProductRecord_Get(t *testing.T) {
 	})
 }
 
+func TestProductRecord_GetRecords(t *testing.T) {
+	t.Run("when the petition is correct, it should return a code 200 with the history", func(t *testing.T) {
+		//Arrange
+		expectedHistory := []domain.ProductRecordHistory{
+			{
+				ProductRecord: domain.ProductRecord{ID: 1, LastUpdate: "2021-04-04", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(20), Currency: "USD", ProductID: 44},
+				Margin:        0.5,
+				Markup:        1,
+			},
+		}
+		route := "/api/v1/products/:id/records"
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("ProductRecords", mock.Anything, 44, domain.ProductRecordFilter{From: "2021-01-01"}).Return(expectedHistory, nil)
+		handler := NewProduct(handlerMock) // Instance of handler
+
+		//Config gin to test mode
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET(route, handler.GetRecords())
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/44/records?from=2021-01-01", nil)
+		w := httptest.NewRecorder() // Instance of response
+
+		//Act
+		serveHTTP(t, router, w, req) // Execute request
+
+		//Assert
+		assert.Equal(t, http.StatusOK, w.Code) // Check status code 200
+		assert.JSONEq(t, `{"data":[{"id":1,"last_update_date":"2021-04-04","purchase_price":10,"sale_price":20,"currency":"USD","product_id":44,
+			"margin":0.5,"markup":1,"purchase_price_change":null,"sale_price_change":null}]}`, w.Body.String())
+		handlerMock.AssertExpectations(t) // Check if mock was called
+	})
+
+	t.Run("when a date is invalid, it should return StatusBadRequest", func(t *testing.T) {
+		//Arrange
+		route := "/api/v1/products/:id/records"
+		handlerMock := &product.ServiceMock{}
+		handler := NewProduct(handlerMock) // Instance of handler
+
+		//Config gin to test mode
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET(route, handler.GetRecords())
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/44/records?to=04-04-2021", nil)
+		w := httptest.NewRecorder() // Instance of response
+
+		//Act
+		serveHTTP(t, router, w, req) // Execute request
+
+		//Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code) // Check status code 400
+		handlerMock.AssertExpectations(t)              // Check if mock was called
+	})
+
+	t.Run("when the product does not exist, it should return a code 404", func(t *testing.T) {
+		//Arrange
+		route := "/api/v1/products/:id/records"
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("ProductRecords", mock.Anything, 44, domain.ProductRecordFilter{}).Return([]domain.ProductRecordHistory(nil), product.ErrNotFound)
+		handler := NewProduct(handlerMock) // Instance of handler
+
+		//Config gin to test mode
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET(route, handler.GetRecords())
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/44/records", nil)
+		w := httptest.NewRecorder() // Instance of response
+
+		//Act
+		serveHTTP(t, router, w, req) // Execute request
+
+		//Assert
+		assert.Equal(t, http.StatusNotFound, w.Code) // Check status code 404
+		handlerMock.AssertExpectations(t)            // Check if mock was called
+	})
+
+	t.Run("when the currency has no rate, it should return a code 422", func(t *testing.T) {
+		//Arrange
+		route := "/api/v1/products/:id/records"
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("ProductRecords", mock.Anything, 44, domain.ProductRecordFilter{Currency: "GBP"}).Return([]domain.ProductRecordHistory(nil), product.ErrCurrencyRateNotFound)
+		handler := NewProduct(handlerMock) // Instance of handler
+
+		//Config gin to test mode
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET(route, handler.GetRecords())
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/44/records?currency=GBP", nil)
+		w := httptest.NewRecorder() // Instance of response
+
+		//Act
+		serveHTTP(t, router, w, req) // Execute request
+
+		//Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
+		handlerMock.AssertExpectations(t)                       // Check if mock was called
+	})
+}
+
+func TestProductRecord_CreateBulk(t *testing.T) {
+	t.Run("when the petition is correct, it should return a code 200 with the new records", func(t *testing.T) {
+		//Arrange
+		route := "/api/v1/productRecords/bulk"
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("UpdatePrices", mock.Anything, product.PriceUpdate{
+			Selector:   product.PriceSelector{ProductIDs: []int{44}},
+			Rule:       product.PriceRule{Type: product.PriceRuleAbsolute, Value: money.FromInt(2)},
+			LastUpdate: "2021-04-04",
+		}).Return([]product.PriceChange{{ProductID: 44, RecordID: 3, PurchasePrice: money.FromInt(10), OldSalePrice: money.FromInt(15), NewSalePrice: money.FromInt(17), Currency: "USD"}}, nil)
+		handler := NewProduct(handlerMock) // Instance of handler
+
+		//Config gin to test mode
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST(route, handler.CreateProductRecordsBulk())
+		req := httptest.NewRequest(http.MethodPost, route,
+			strings.NewReader(`{"product_ids":[44],"rule":"absolute","value":2,"last_update_date":"2021-04-04"}`))
+		w := httptest.NewRecorder() // Instance of response
+
+		//Act
+		serveHTTP(t, router, w, req) // Execute request
+
+		//Assert
+		assert.Equal(t, http.StatusOK, w.Code) // Check status code 200
+		assert.JSONEq(t, `{"data":[{"product_id":44,"record_id":3,"purchase_price":10,"old_sale_price":15,"new_sale_price":17,"currency":"USD"}]}`, w.Body.String())
+		handlerMock.AssertExpectations(t) // Check if mock was called
+	})
+
+	t.Run("when a product cannot be repriced, it should return a code 422", func(t *testing.T) {
+		//Arrange
+		route := "/api/v1/productRecords/bulk"
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("UpdatePrices", mock.Anything, mock.Anything).Return([]product.PriceChange{{ProductID: 44, Err: product.ErrNoRecordToReprice}}, nil)
+		handler := NewProduct(handlerMock) // Instance of handler
+
+		//Config gin to test mode
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST(route, handler.CreateProductRecordsBulk())
+		req := httptest.NewRequest(http.MethodPost, route+"?dry_run=true",
+			strings.NewReader(`{"seller_id":1,"rule":"percentage","value":8,"last_update_date":"2021-04-04"}`))
+		w := httptest.NewRecorder() // Instance of response
+
+		//Act
+		serveHTTP(t, router, w, req) // Execute request
+
+		//Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code) // Check status code 422
+		assert.JSONEq(t, `"product 44: product has no record to reprice"`, w.Body.String())
+	})
+}
+
 func expectedResponseBody(products []domain.Product) string {
 	// Create map with data key
 	reponseBody := map[string]interface{}{"data": products}
@@ -1119,3 +1406,44 @@ func expectedResponseBody(products []domain.Product) string {
 	}
 	return string(jsonProducts)
 }
+
+func TestProduct_Search(t *testing.T) {
+	t.Run("it should return the products found with their score", func(t *testing.T) {
+		//Arrange
+		found := domain.Product{ID: 1, Description: "Greek yogurt", ProductCode: "YOG-001", ProductTypeID: 1, SellerID: 2}
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("Search", mock.Anything, product.SearchQuery{Text: "yogurt", SellerID: 2}).
+			Return([]product.SearchResult{{Product: found, Score: 0.693}}, nil)
+		router := gin.New()
+		router.GET("/api/v1/products/search", NewProduct(handlerMock).Search())
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/search?q=yogurt&seller_id=2", nil)
+		w := httptest.NewRecorder()
+
+		//Act
+		serveHTTP(t, router, w, req)
+
+		//Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"description":"Greek yogurt","expiration_rate":0,"freezing_rate":0,"height":0,"length":0,
+			"netweight":0,"product_code":"YOG-001","recommended_freezing_temperature":0,"width":0,"product_type_id":1,"seller_id":2,
+			"score":0.693}]}`, w.Body.String())
+		handlerMock.AssertExpectations(t)
+	})
+
+	t.Run("it should return 400 when q has no words", func(t *testing.T) {
+		//Arrange
+		handlerMock := &product.ServiceMock{}
+		handlerMock.On("Search", mock.Anything, product.SearchQuery{}).Return([]product.SearchResult(nil), product.ErrEmptyQuery)
+		router := gin.New()
+		router.GET("/api/v1/products/search", NewProduct(handlerMock).Search())
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/search", nil)
+		w := httptest.NewRecorder()
+
+		//Act
+		serveHTTP(t, router, w, req)
+
+		//Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"`+ErrSearchQuery+`"}`, w.Body.String())
+	})
+}
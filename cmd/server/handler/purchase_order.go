@@ -8,6 +8,7 @@ import (
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
@@ -35,11 +36,11 @@ type RequestBodyPurchaseCreate struct {
 // @Tags purchase_orders
 // @Produce json
 // @Param body body RequestBodyPurchaseCreate true "Purchase orders body"
-// @Failure 400 {object} map[string]any
-// @Failure 422 {object} map[string]any
-// @Failure 409 {object} map[string]any
-// @Failure 500 {object} map[string]any
-// @Success 201 {object} map[string]any
+// @Failure 400 {object} web.ErrorMessageResponse
+// @Failure 422 {object} web.ErrorMessageResponse
+// @Failure 409 {object} web.ErrorMessageResponse
+// @Failure 500 {object} web.ErrorMessageResponse
+// @Success 201 {object} web.DataResponse{data=domain.PurchaseOrder}
 // @Router /purchaseOrders [post]
 func (po *PurchaseOrder) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -103,13 +104,15 @@ func (po *PurchaseOrder) Create() gin.HandlerFunc {
 // Summary Report Purchase Orders By Buyer
 // @Description get a buyer
 // @Tags purchase_orders
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param id query int false "Purchase Orders By Buyer id"
-// @Failure 400 {object} map[string]any
-// @Failure 404 {object} map[string]any
-// @Success 204 {object} map[string]any
-// @Success 200 {object} map[string]any
-// @Router /buyers/reportPurchaseOrders/ [get]
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Failure 400 {object} web.ErrorMessageResponse
+// @Failure 404 {object} web.ErrorMessageResponse
+// @Failure 500 {object} web.ErrorMessageResponse
+// @Success 204
+// @Success 200 {object} web.DataResponse{data=[]domain.PurchaseOrdersByBuyer}
+// @Router /buyers/reportPurchaseOrders [get]
 func (po *PurchaseOrder) ReportPurchaseOrdersByBuyer() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// request
@@ -143,6 +146,9 @@ func (po *PurchaseOrder) ReportPurchaseOrdersByBuyer() gin.HandlerFunc {
 		}
 
 		// return response
+		if web.Table(c, reports) {
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{"data": reports})
 	}
 
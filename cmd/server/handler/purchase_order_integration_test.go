@@ -3,7 +3,6 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -63,7 +62,9 @@ func TestIntegrationHandler_CreatePurchaseOrder(t *testing.T) {
 		repoMock.On("ExistsPurchaseOrder", mock.Anything, 1).Return(false)
 		repoMock.On("ExistsBuyer", mock.Anything, buyerID).Return(true)
 		repoMock.On("ExistsProductsRecord", mock.Anything, productRecordID).Return(true)
+		repoMock.On("InTx", mock.Anything).Return(nil)
 		repoMock.On("Save", mock.Anything, poToSave).Return(expectedPurchaseOrderID, nil)
+		repoMock.On("SaveEvent", mock.Anything, mock.Anything).Return(nil)
 
 		// create service with mock of the service
 		service := purchase_order.NewService(repoMock)
@@ -87,7 +88,7 @@ func TestIntegrationHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -143,13 +144,13 @@ func TestIntegrationHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		// prepare server to call endpoint GetAll
 		// create gin router
 		r := gin.New()
-		route := "/api/v1/buyers/reportPurchaseOrders/:id"
+		route := "/api/v1/buyers/reportPurchaseOrders"
 
 		// Add handler to the router
 		r.GET(route, handler.ReportPurchaseOrdersByBuyer())
 
 		// create the request
-		url := fmt.Sprintf("/api/v1/buyers/reportPurchaseOrders/%d", buyerID)
+		url := "/api/v1/buyers/reportPurchaseOrders"
 		request, _ := http.NewRequest("GET", url, nil)
 
 		// Add the "id" query parameter
@@ -161,7 +162,7 @@ func TestIntegrationHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
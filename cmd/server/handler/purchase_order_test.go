@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -65,7 +64,7 @@ func TestHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -105,7 +104,7 @@ func TestHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -145,7 +144,7 @@ func TestHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -194,7 +193,7 @@ func TestHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -245,7 +244,7 @@ func TestHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -296,7 +295,7 @@ func TestHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -347,7 +346,7 @@ func TestHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -398,7 +397,7 @@ func TestHandler_CreatePurchaseOrder(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -448,13 +447,13 @@ func TestHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		// prepare server to call endpoint GetAll
 		// create gin router
 		r := gin.New()
-		route := "/api/v1/buyers/reportPurchaseOrders/:id"
+		route := "/api/v1/buyers/reportPurchaseOrders"
 
 		// Add handler to the router
 		r.GET(route, handler.ReportPurchaseOrdersByBuyer())
 
 		// create the request
-		url := fmt.Sprintf("/api/v1/buyers/reportPurchaseOrders/%d", buyerID)
+		url := "/api/v1/buyers/reportPurchaseOrders"
 		request, _ := http.NewRequest("GET", url, nil)
 
 		// Add the "id" query parameter
@@ -466,7 +465,7 @@ func TestHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -495,13 +494,13 @@ func TestHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		// prepare server to call endpoint GetAll
 		// create gin router
 		r := gin.New()
-		route := "/api/v1/buyers/reportPurchaseOrders/:id"
+		route := "/api/v1/buyers/reportPurchaseOrders"
 
 		// Add handler to the router
 		r.GET(route, handler.ReportPurchaseOrdersByBuyer())
 
 		// create the request
-		url := fmt.Sprintf("/api/v1/buyers/reportPurchaseOrders/%d", buyerID)
+		url := "/api/v1/buyers/reportPurchaseOrders"
 		request, _ := http.NewRequest("GET", url, nil)
 
 		// Add the "id" query parameter
@@ -513,7 +512,7 @@ func TestHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -542,13 +541,13 @@ func TestHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		// prepare server to call endpoint GetAll
 		// create gin router
 		r := gin.New()
-		route := "/api/v1/buyers/reportPurchaseOrders/:id"
+		route := "/api/v1/buyers/reportPurchaseOrders"
 
 		// Add handler to the router
 		r.GET(route, handler.ReportPurchaseOrdersByBuyer())
 
 		// create the request
-		url := fmt.Sprintf("/api/v1/buyers/reportPurchaseOrders/%d", buyerID)
+		url := "/api/v1/buyers/reportPurchaseOrders"
 		request, _ := http.NewRequest("GET", url, nil)
 
 		// Add the "id" query parameter
@@ -560,7 +559,7 @@ func TestHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
@@ -590,13 +589,13 @@ func TestHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		// prepare server to call endpoint GetAll
 		// create gin router
 		r := gin.New()
-		route := "/api/v1/buyers/reportPurchaseOrders/:id"
+		route := "/api/v1/buyers/reportPurchaseOrders"
 
 		// Add handler to the router
 		r.GET(route, handler.ReportPurchaseOrdersByBuyer())
 
 		// create the request
-		url := fmt.Sprintf("/api/v1/buyers/reportPurchaseOrders/%d", buyerID)
+		url := "/api/v1/buyers/reportPurchaseOrders"
 		request, _ := http.NewRequest("GET", url, nil)
 
 		// Add the "id" query parameter
@@ -608,7 +607,7 @@ func TestHandler_ReportPurchaseOrdersByBuyer(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// assert
 		// assert results
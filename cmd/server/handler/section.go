@@ -10,6 +10,7 @@ import (
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
@@ -54,9 +55,10 @@ func NewSection(s section.Service) *Section {
 // @Summary Retrieves all sections.
 // @Description Gets a list of all the sections.
 // @Tags sections
-// @Produce json
-// @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.Section}
+// @Failure 500 {object} web.MessageResponse
 // @Router /sections [get]
 func (s *Section) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -65,6 +67,9 @@ func (s *Section) GetAll() gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal error"})
 			return
 		}
+		if web.Table(c, l) {
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{"data": l})
 	}
 }
@@ -75,9 +80,10 @@ func (s *Section) GetAll() gin.HandlerFunc {
 // @Tags sections
 // @Produce json
 // @Param id path int true "ID of the section item"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Success 200 {object} web.DataResponse{data=domain.Section}
+// @Failure 400 {object} web.MessageResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Router /sections/{id} [get]
 func (s *Section) Get() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -107,11 +113,12 @@ func (s *Section) Get() gin.HandlerFunc {
 // @Tags sections
 // @Accept json
 // @Produce json
-// @Param sectionData body object true "Section data to create" format(json)
-// @Success 201 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 422 {object} map[string]interface{}
-// @Failure 409 {object} map[string]interface{}
+// @Param sectionData body SectionRequest true "Section data to create"
+// @Success 201 {object} web.DataResponse{data=domain.Section}
+// @Failure 400 {object} web.MessageResponse
+// @Failure 409 {object} web.MessageResponse
+// @Failure 422 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Router /sections [post]
 func (s *Section) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -170,6 +177,10 @@ func (s *Section) Create() gin.HandlerFunc {
 			switch {
 			case errors.Is(err, section.ErrDuplicateSectNumber):
 				c.JSON(http.StatusConflict, gin.H{"message": "duplicate section number"})
+			case errors.Is(err, section.ErrProductTypeNotFound):
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "product type not found"})
+			case errors.Is(err, section.ErrWarehouseNotFound):
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "warehouse not found"})
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "internal error"})
 			}
@@ -189,12 +200,13 @@ func (s *Section) Create() gin.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param id path int true "ID of the section to update"
-// @Param sectionData body object true "Updated section data" format(json)
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 409 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Param sectionData body SectionRequest true "Updated section data"
+// @Success 200 {object} web.DataResponse{data=domain.Section}
+// @Failure 400 {object} web.MessageResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 409 {object} web.MessageResponse
+// @Failure 422 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Router /sections/{id} [patch]
 func (s *Section) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -243,6 +255,10 @@ func (s *Section) Update() gin.HandlerFunc {
 			switch {
 			case errors.Is(err, section.ErrDuplicateSectNumber):
 				c.JSON(http.StatusConflict, gin.H{"message": "duplicate section number"})
+			case errors.Is(err, section.ErrProductTypeNotFound):
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "product type not found"})
+			case errors.Is(err, section.ErrWarehouseNotFound):
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "warehouse not found"})
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "internal error"})
 			}
@@ -261,9 +277,9 @@ func (s *Section) Update() gin.HandlerFunc {
 // @Produce json
 // @Param id path int true "ID of the section to delete"
 // @Success 204 "No content"
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} web.MessageResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Router /sections/{id} [delete]
 func (s *Section) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -297,11 +313,13 @@ func (s *Section) Delete() gin.HandlerFunc {
 // @Summary Gets number of products stored in a section.
 // @Description Gets the total ammount of products stored in a given section. If section is not specified, gets ammount of products for each section.
 // @Tags sections
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param id query int false "ID of the specific section"
-// @Success 200 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]section.ProdCountResponse}
+// @Failure 400 {object} web.MessageResponse
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Router /sections/reportProducts [get]
 func (s *Section) ProductCount() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -335,6 +353,9 @@ func (s *Section) ProductCount() gin.HandlerFunc {
 		}
 
 		// Response
+		if web.Table(c, l) {
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{"data": l})
 	}
 }
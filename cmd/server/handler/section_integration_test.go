@@ -67,7 +67,7 @@ func TestIntegration_ReadSection(t *testing.T) {
 
 		// Act
 		// - serve request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if the obtained status code matches expected
@@ -114,7 +114,7 @@ func TestIntegration_ReadSection(t *testing.T) {
 
 		// Act
 		// - Serving the HTTP GET request and recording the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Checking if the obtained status code matches the expected code.
@@ -169,7 +169,7 @@ func TestIntegration_CreateSection(t *testing.T) {
 
 		// Act
 		// - serve http POST request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if obtained status code matches expected
@@ -207,7 +207,7 @@ func TestIntegration_CreateSection(t *testing.T) {
 
 		// Act
 		// - Serve HTTP POST request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if obtained status code matches expected
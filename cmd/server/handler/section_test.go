@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandler_ReadSection(t *testing.T) {
@@ -68,7 +69,7 @@ func TestHandler_ReadSection(t *testing.T) {
 
 		// Act
 		// - serve request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if the obtained status code matches expected
@@ -79,6 +80,37 @@ func TestHandler_ReadSection(t *testing.T) {
 		service.AssertExpectations(t)
 	})
 
+	// Test case: Export all sections
+	// This test verifies if the handler writes the list of sections as CSV when asked with the Accept header
+	t.Run("it should export all sections as CSV when the client accepts CSV", func(t *testing.T) {
+		// Arrange
+		service := &section.ServiceMock{}
+		service.On("GetAll", mock.Anything).Return([]domain.Section{
+			{ID: 1, SectionNumber: 1, WarehouseID: 1, ProductTypeID: 1},
+			{ID: 2, SectionNumber: 2, WarehouseID: 1, ProductTypeID: 2},
+		}, nil)
+		handler := NewSection(service)
+		r := gin.New()
+		route := "/api/v1/sections"
+		r.GET(route, handler.GetAll())
+		request, _ := http.NewRequest("GET", route, nil)
+		request.Header.Set("Accept", "text/csv")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		// - a header line and a line per section
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", response.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSuffix(response.Body.String(), "\n"), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "id,section_number,"), lines[0])
+		assert.True(t, strings.HasPrefix(lines[2], "2,2,"), lines[2])
+		service.AssertExpectations(t)
+	})
+
 	// Test case: Get a section
 	// This testcase verifies if the handler returns the section corresponding to the id provided by the user
 	t.Run("it should return a section corresponding to the given id", func(t *testing.T) {
@@ -116,7 +148,7 @@ func TestHandler_ReadSection(t *testing.T) {
 
 		// Act
 		// - serve http GET request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if obtained status code matches expected
@@ -155,7 +187,7 @@ func TestHandler_ReadSection(t *testing.T) {
 
 		// Act
 		// - serve http request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if obtained status code matches expected
@@ -192,7 +224,7 @@ func TestHandler_ReadSection(t *testing.T) {
 
 		// Act
 		// - serve http GET request and record repsonse
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if obtained status code matches expected
@@ -240,7 +272,7 @@ func TestHandler_ReadSection(t *testing.T) {
 
 		// Act
 		// - Serving the HTTP GET request and recording the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Checking if the obtained status code matches the expected code.
@@ -292,7 +324,7 @@ func TestHandler_ReadSection(t *testing.T) {
 
 		// Act
 		// - Serving the HTTP GET request and recording the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Checking if the obtained status code matches the expected code.
@@ -346,7 +378,7 @@ func TestHandler_CreateSection(t *testing.T) {
 
 		// Act
 		// - serve http POST request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - check if obtained status code matches expected
@@ -396,7 +428,7 @@ func TestHandler_CreateSection(t *testing.T) {
 
 		// Act
 		// - Serve HTTP POST request and record response
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if obtained status code matches expected
@@ -436,7 +468,7 @@ func TestHandler_CreateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP POST request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected code for unprocessable entity.
@@ -478,7 +510,7 @@ func TestHandler_CreateSection(t *testing.T) {
 
 		// Act
 		// - Serve HTTP POST request and record response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if obtained status code matches the expected bad request code.
@@ -521,7 +553,7 @@ func TestHandler_CreateSection(t *testing.T) {
 
 		// Act
 		// - Serve HTTP POST request and record response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if obtained status code matches the expected bad request code.
@@ -563,7 +595,7 @@ func TestHandler_CreateSection(t *testing.T) {
 
 		// Act
 		// - Serve HTTP POST request and record response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if obtained status code matches the expected bad request code.
@@ -605,7 +637,7 @@ func TestHandler_CreateSection(t *testing.T) {
 
 		// Act
 		// - Serve HTTP POST request and record response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if obtained status code matches the expected bad request code.
@@ -663,7 +695,7 @@ func TestHandler_CreateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP POST request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected internal server error code.
@@ -707,7 +739,7 @@ func TestHandler_DeleteSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP DELETE request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected success code.
@@ -753,7 +785,7 @@ func TestHandler_DeleteSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP DELETE request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected not found code.
@@ -795,7 +827,7 @@ func TestHandler_DeleteSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP DELETE request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected bad request code.
@@ -841,7 +873,7 @@ func TestHandler_DeleteSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP DELETE request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected internal server error code.
@@ -915,7 +947,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected success code.
@@ -966,7 +998,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected not found code.
@@ -1041,7 +1073,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected conflict code.
@@ -1089,7 +1121,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
 		//   The handler is expected to validate the ID format before processing the request.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected bad request code.
@@ -1146,7 +1178,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected bad request code.
@@ -1206,7 +1238,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected bad request code.
@@ -1266,7 +1298,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected bad request code.
@@ -1326,7 +1358,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected bad request code.
@@ -1401,7 +1433,7 @@ func TestHandler_UpdateSection(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP PATCH request and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Check if the obtained status code matches the expected internal server error code.
@@ -1464,7 +1496,7 @@ func TestHandler_SectionProductCount(t *testing.T) {
 
 		// Act
 		// - Execute the HTTP request against the mock server and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the HTTP status code in the response matches the expected status code.
@@ -1475,6 +1507,33 @@ func TestHandler_SectionProductCount(t *testing.T) {
 		service.AssertExpectations(t)
 	})
 
+	t.Run("it should export the number of products per section as CSV when the format is csv", func(t *testing.T) {
+		// Arrange
+		// - Mock the service to return the product count of two sections.
+		service := &section.ServiceMock{}
+		service.On("ProductCount", mock.Anything, 0).Return([]section.ProdCountResponse{
+			{ID: 1, SectionNumber: 1, ProductCount: 1},
+			{ID: 2, SectionNumber: 2, ProductCount: 5},
+		}, nil)
+		handler := NewSection(service)
+		r := gin.New()
+		route := "/api/v1/sections/reportProducts"
+		r.GET(route, handler.ProductCount())
+
+		request, _ := http.NewRequest("GET", route+"?format=csv", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		// - The report is an attachment named after the report, with a row per section in the order of the json fields.
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Regexp(t, `^attachment; filename="sections-reportProducts-\d{8}T\d{6}Z\.csv"$`, response.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,section_number,product_count\n1,1,1\n2,2,5\n", response.Body.String())
+		service.AssertExpectations(t)
+	})
+
 	t.Run("it should return the number of products for the section corresponding to the given id", func(t *testing.T) {
 		// Arrange
 		// - Define the expected product count response for a specific section. This simulates the expected service output for the given section ID.
@@ -1512,7 +1571,7 @@ func TestHandler_SectionProductCount(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP request to the handler and record the response.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the HTTP status code matches the expected status, indicating the request was processed successfully.
@@ -1550,7 +1609,7 @@ func TestHandler_SectionProductCount(t *testing.T) {
 
 		// Act
 		// - Serve the HTTP request to the handler and record the response, simulating the endpoint's behavior when the specified section ID does not exist.
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// - Verify that the HTTP status code matches the expected status, indicating the proper handling of a non-existing section request.
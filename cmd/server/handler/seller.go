@@ -41,10 +41,11 @@ func NewSeller(s seller.Service) *Seller {
 // @Summary Get all the sellers available.
 // @Description Get all the sellers available or an error if the list is empty or an internal error occurs.
 // @Tags domain.Seller
-// @Produce json
-// @Success 200 {array} domain.Seller "List of all sellers"
-// @Failure 404 {object} string "Sellers not found"
-// @Failure 500 {object} string "Server Internal error"
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.Seller} "List of all sellers"
+// @Failure 404 {object} web.ErrorResponse "Sellers not found"
+// @Failure 500 {object} web.ErrorResponse "Server Internal error"
 // @Router /seller [get]
 func (s *Seller) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -60,6 +61,9 @@ func (s *Seller) GetAll() gin.HandlerFunc {
 			return
 		}
 		//If no errors occurs, return a 200 status code and the list of sellers.
+		if web.Table(c, allSeller) {
+			return
+		}
 		web.Success(c, http.StatusOK, allSeller)
 	}
 }
@@ -68,10 +72,10 @@ func (s *Seller) GetAll() gin.HandlerFunc {
 // @Description Get a seller by id or an error if that id not exists or an internal error occurs.
 // @Tags domain.Seller
 // @Produce json
-// @Success 200 {object} domain.Seller "Seller requested"
-// @Failure 400 {object} string "invalid id"
-// @Failure 404 {object} string "Seller not found"
-// @Failure 500 {object} string "Server Internal error"
+// @Success 200 {object} web.DataResponse{data=domain.Seller} "Seller requested"
+// @Failure 400 {object} web.ErrorResponse "invalid id"
+// @Failure 404 {object} web.ErrorResponse "Seller not found"
+// @Failure 500 {object} web.ErrorResponse "Server Internal error"
 // @Param id path int true "id from the seller"
 // @Router /seller/{id} [get]
 func (s *Seller) Get() gin.HandlerFunc {
@@ -106,13 +110,14 @@ func (s *Seller) Get() gin.HandlerFunc {
 // @Tags domain.Seller
 // @Produce json
 // @Accept json
-// @Success 201 {object} domain.Seller "New created seller"
-// @Failure 409 {object} string "seller already exists"
-// @Failure 422 {object} string "invalid JSON"
-// @Failure 422 {object} string "invalid seller"
-// @Failure 422 {object} string "id locality not exists"
-// @Failure 422 {object} string "invalid or missing locality. Locality_id must be 1 or greater"
-// @Failure 500 {object} string "Server Internal error"
+// @Success 201 {object} web.DataResponse{data=domain.Seller} "New created seller"
+// @Failure 400 {object} web.ErrorResponse "invalid JSON"
+// @Failure 409 {object} web.ErrorResponse "seller already exists"
+// @Failure 422 {object} web.ErrorResponse "invalid JSON"
+// @Failure 422 {object} web.ErrorResponse "invalid seller"
+// @Failure 422 {object} web.ErrorResponse "id locality not exists"
+// @Failure 422 {object} web.ErrorResponse "invalid or missing locality. Locality_id must be 1 or greater"
+// @Failure 500 {object} web.ErrorResponse "Server Internal error"
 // @Param Seller body domain.Seller true "Struct of Seller domain"
 // @Router /seller [post]
 func (s *Seller) Create() gin.HandlerFunc {
@@ -239,13 +244,14 @@ func (s *Seller) Create() gin.HandlerFunc {
 // @Tags domain.Seller
 // @Produce json
 // @Accept json
-// @Success 200 {object} domain.Seller "Seller updated"
-// @Failure 400 {object} string "invalid id"
-// @Failure 404 {object} string "Seller not found"
-// @Failure 500 {object} string "Server Internal error"
+// @Success 200 {object} web.DataResponse{data=domain.Seller} "Seller updated"
+// @Failure 400 {object} web.ErrorResponse "invalid id"
+// @Failure 404 {object} web.ErrorResponse "Seller not found"
+// @Failure 422 {object} web.ErrorResponse "id locality not exists"
+// @Failure 500 {object} web.ErrorResponse "Server Internal error"
 // @Param id path int true "id from the seller"
 // @Param Seller body domain.Seller true "Struct of Seller domain"
-// @Router /seller/{id}  [patch]
+// @Router /seller/{id} [patch]
 func (s *Seller) Update() gin.HandlerFunc {
 
 	return func(c *gin.Context) {
@@ -324,11 +330,12 @@ func (s *Seller) Update() gin.HandlerFunc {
 // @Description Delete a seller using its id or return an error if that
 // seller not exist.
 // @Tags domain.Seller
-// @Success 204 {object} string "Seller deleted"
-// @Failure 400 {object} string "invalid id"
-// @Failure 400 {object} string "id must be 1 or greater"
-// @Failure 404 {object} string "Seller not found"
-// @Failure 500 {object} string "Server Internal error"
+// @Produce json
+// @Success 204 "Seller deleted"
+// @Failure 400 {object} web.ErrorResponse "invalid id"
+// @Failure 400 {object} web.ErrorResponse "id must be 1 or greater"
+// @Failure 404 {object} web.ErrorResponse "Seller not found"
+// @Failure 500 {object} web.ErrorResponse "Server Internal error"
 // @Param id path int true "id from the seller"
 // @Router /seller/{id} [delete]
 func (s *Seller) Delete() gin.HandlerFunc {
@@ -73,7 +73,7 @@ func TestGetAllSellerIntegration(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		//Check if the status code is the correct one (200 OK in this case).
@@ -122,7 +122,7 @@ func TestGetAllSellerIntegration(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		//Check if the status code is the correct one (500 Internal Server Error in this case).
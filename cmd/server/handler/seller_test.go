@@ -102,7 +102,7 @@ func TestHandler_READ(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Unmarshal the response into the actualSellers
 		err := json.Unmarshal([]byte(response.Body.Bytes()), &actualSellers)
@@ -151,7 +151,7 @@ func TestHandler_READ(t *testing.T) {
 		router.GET("/seller", sellerHandler.GetAll())
 
 		// Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "Sellers not found"}
@@ -212,7 +212,7 @@ func TestHandler_READ(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "internal server error"}
@@ -295,7 +295,7 @@ func TestHandler_READ(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		//Unmarshal the response into the actualSeller
 		err := json.Unmarshal([]byte(response.Body.Bytes()), &actualSeller) //The []byte is not necessary.
@@ -357,7 +357,7 @@ func TestHandler_READ(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "seller not found"}
@@ -422,7 +422,7 @@ func TestHandler_READ(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "internal server error"}
@@ -464,7 +464,7 @@ func TestHandler_READ(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "invalid id"}
@@ -520,7 +520,7 @@ func TestHandler_DELETE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// Check if the status code is the correct one (204 No Content in this case).
@@ -565,7 +565,7 @@ func TestHandler_DELETE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		// Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
@@ -619,7 +619,7 @@ func TestHandler_DELETE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "seller not found"}
@@ -668,7 +668,7 @@ func TestHandler_DELETE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "invalid id"}
@@ -717,7 +717,7 @@ func TestHandler_DELETE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		// Act
-		r.ServeHTTP(response, request)
+		serveHTTP(t, r, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "id must be 1 or greater"}
@@ -777,7 +777,7 @@ func TestHandler_CREATE(t *testing.T) {
 		router.POST("/seller", sellerHandler.Create())
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (201 Created in this case).
@@ -827,7 +827,7 @@ func TestHandler_CREATE(t *testing.T) {
 		router.POST("/seller", sellerHandler.Create())
 
 		// Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "seller already exists"}
@@ -887,7 +887,7 @@ func TestHandler_CREATE(t *testing.T) {
 		router.POST("/seller", sellerHandler.Create())
 
 		// Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "Invalid or missing 'company_name'"}
@@ -943,7 +943,7 @@ func TestHandler_CREATE(t *testing.T) {
 		router.POST("/seller", sellerHandler.Create())
 
 		// Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "invalid json"}
@@ -1006,7 +1006,7 @@ func TestHandler_CREATE(t *testing.T) {
 		router.POST("/seller", sellerHandler.Create())
 
 		// Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "invalid or missing cid. CID must be 1 or greater"}
@@ -1069,7 +1069,7 @@ func TestHandler_CREATE(t *testing.T) {
 		router.POST("/seller", sellerHandler.Create())
 
 		// Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Unmarshal the response into the actualErrorResponse
 		//because the response is a JSON with the format: {"message": "nvalid or missing locality. Locality_id must be 1 or greater"}
@@ -1131,7 +1131,7 @@ func TestHandler_CREATE(t *testing.T) {
 		router.POST("/seller", sellerHandler.Create())
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (422 Unprocessable Entity in this case).
@@ -1193,7 +1193,7 @@ func TestHandler_CREATE(t *testing.T) {
 		router.POST("/seller", sellerHandler.Create())
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
@@ -1292,7 +1292,7 @@ func TestHandler_UPDATE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (200 OK in this case).
@@ -1351,7 +1351,7 @@ func TestHandler_UPDATE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
@@ -1414,7 +1414,7 @@ func TestHandler_UPDATE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (404 Not Found in this case).
@@ -1472,7 +1472,7 @@ func TestHandler_UPDATE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (400 Bad Request in this case).
@@ -1545,7 +1545,7 @@ func TestHandler_UPDATE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (400 Bad Request in this case).
@@ -1620,7 +1620,7 @@ func TestHandler_UPDATE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (422 Unprocessable Entity in this case).
@@ -1708,7 +1708,7 @@ func TestHandler_UPDATE(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		//Act
-		router.ServeHTTP(response, request)
+		serveHTTP(t, router, response, request)
 
 		//Assert
 		// Check if the status code is the correct one (500 Internal Server Error in this case).
@@ -0,0 +1,67 @@
+package v2
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// Purger removes for good the entities of one type deleted before a time and
+// returns how many it removed.
+type Purger struct {
+	Entity string
+	Purge  func(ctx context.Context, before time.Time) (int, error)
+}
+
+// PurgeSummary is the result of a purge.
+type PurgeSummary struct {
+	// Before is the time the purged entities were deleted before.
+	Before time.Time `json:"before" validate:"required"`
+	// Purged counts the purged entities by type.
+	Purged map[string]int `json:"purged" validate:"required"`
+}
+
+// Admin contains the /admin handlers.
+type Admin struct {
+	retention time.Duration
+	purgers   []Purger
+	now       func() time.Time
+}
+
+// NewAdmin returns a new instance of Admin purging, in order, with purgers
+// the entities deleted for longer than retention.
+func NewAdmin(retention time.Duration, purgers ...Purger) *Admin {
+	return &Admin{retention: retention, purgers: purgers, now: time.Now}
+}
+
+// Purge godoc
+// @Summary Purge the deleted entities
+// @Description Removes for good the sellers, products, buyers, employees and warehouses
+// @Description deleted for longer than the retention period. Entities still referenced,
+// @Description such as a product with batches, are kept. The server does not serve this
+// @Description route until its requests are authenticated; apigoctl purge serves it with -dsn.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} web.Envelope{data=PurgeSummary}
+// @Failure 500 {object} web.ErrorResponse
+// @Router /admin/purge [post]
+func (a *Admin) Purge() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		summary := PurgeSummary{
+			Before: a.now().Add(-a.retention).UTC(),
+			Purged: make(map[string]int, len(a.purgers)),
+		}
+		for _, p := range a.purgers {
+			n, err := p.Purge(c, summary.Before)
+			if err != nil {
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+				return
+			}
+			summary.Purged[p.Entity] = n
+		}
+		web.Resource(c, http.StatusOK, summary, link("/admin/purge"))
+	}
+}
@@ -0,0 +1,69 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAdminRouter(h *Admin) *gin.Engine {
+	r := gin.New()
+	r.Use(web.RequestMeta())
+	r.POST("/api/v2/admin/purge", h.Purge())
+	return r
+}
+
+func TestAdmin_Purge(t *testing.T) {
+	now := time.Date(2026, time.October, 31, 12, 0, 0, 0, time.UTC)
+	newAdmin := func(purgers ...Purger) *Admin {
+		h := NewAdmin(30*24*time.Hour, purgers...)
+		h.now = func() time.Time { return now }
+		return h
+	}
+	purged := func(entity string, n int, got *time.Time) Purger {
+		return Purger{Entity: entity, Purge: func(_ context.Context, before time.Time) (int, error) {
+			*got = before
+			return n, nil
+		}}
+	}
+
+	t.Run("it should purge the entities deleted before the retention period", func(t *testing.T) {
+		// Arrange
+		var productsBefore, sellersBefore time.Time
+		r := newAdminRouter(newAdmin(purged("product", 2, &productsBefore), purged("seller", 0, &sellersBefore)))
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/admin/purge", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"before":"2026-10-01T12:00:00Z","purged":{"product":2,"seller":0}},
+			"meta":{},"links":{"self":"/api/v2/admin/purge"}}`, response.Body.String())
+		assert.Equal(t, now.AddDate(0, 0, -30), productsBefore)
+		assert.Equal(t, productsBefore, sellersBefore)
+	})
+
+	t.Run("it should return 500 when a purge fails", func(t *testing.T) {
+		// Arrange
+		r := newAdminRouter(newAdmin(Purger{Entity: "product", Purge: func(context.Context, time.Time) (int, error) {
+			return 0, errors.New("connection refused")
+		}}))
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/admin/purge", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+	})
+}
@@ -0,0 +1,130 @@
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// Page sizes of the audit log.
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+var (
+	ErrAuditIDWithoutEntity = "id requires entity"
+	ErrInvalidLimit         = fmt.Sprintf("limit must be an integer between 1 and %d", maxAuditLimit)
+	ErrInvalidBefore        = "before must be a positive integer"
+)
+
+// Audit contains the /audit handlers.
+type Audit struct {
+	auditService audit.Service
+}
+
+// NewAudit returns a new instance of Audit.
+func NewAudit(s audit.Service) *Audit {
+	return &Audit{auditService: s}
+}
+
+// List godoc
+// @Summary List the audit log
+// @Description Lists the mutations of the entities, newest first. A page holds
+// @Description up to limit records and links to the next page, if any.
+// @Tags audit
+// @Produce json
+// @Param entity query string false "Entity type, e.g. section"
+// @Param id query int false "Entity ID, requires entity"
+// @Param limit query int false "Page size" default(50) minimum(1) maximum(500)
+// @Param before query int false "Return the records older than this record ID"
+// @Success 200 {object} web.Envelope{data=[]domain.AuditRecord}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /audit [get]
+func (a *Audit) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f, ok := auditFilter(c)
+		if !ok {
+			return
+		}
+
+		// One record more than the page tells whether there is a next page.
+		limit := f.Limit
+		f.Limit++
+		records, err := a.auditService.List(c, f)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+
+		var next string
+		if len(records) > limit {
+			records = records[:limit]
+			q := c.Request.URL.Query()
+			q.Set("before", strconv.Itoa(records[limit-1].ID))
+			next = c.Request.URL.Path + "?" + q.Encode()
+		}
+		web.Page(c, records, next)
+	}
+}
+
+// Verify godoc
+// @Summary Verify the audit log
+// @Description Walks the hash chain of the audit log and reports the first
+// @Description record that was edited or follows a deleted record.
+// @Tags audit
+// @Produce json
+// @Success 200 {object} web.Envelope{data=audit.Verification}
+// @Failure 500 {object} web.ErrorResponse
+// @Router /audit/verify [get]
+func (a *Audit) Verify() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		v, err := a.auditService.Verify(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Resource(c, http.StatusOK, v, link("/audit/verify"))
+	}
+}
+
+// auditFilter reads the entity, id, limit and before query parameters. It
+// writes a 400 response and returns false when one is invalid.
+func auditFilter(c *gin.Context) (audit.Filter, bool) {
+	f := audit.Filter{Entity: c.Query("entity"), Limit: defaultAuditLimit}
+
+	if raw, ok := c.GetQuery("id"); ok {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 1 {
+			web.Error(c, http.StatusBadRequest, ErrInvalidID)
+			return audit.Filter{}, false
+		}
+		if f.Entity == "" {
+			web.Error(c, http.StatusBadRequest, ErrAuditIDWithoutEntity)
+			return audit.Filter{}, false
+		}
+		f.EntityID = id
+	}
+	if raw, ok := c.GetQuery("limit"); ok {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			web.Error(c, http.StatusBadRequest, ErrInvalidLimit)
+			return audit.Filter{}, false
+		}
+		f.Limit = limit
+	}
+	if raw, ok := c.GetQuery("before"); ok {
+		before, err := strconv.Atoi(raw)
+		if err != nil || before < 1 {
+			web.Error(c, http.StatusBadRequest, ErrInvalidBefore)
+			return audit.Filter{}, false
+		}
+		f.BeforeID = before
+	}
+	return f, true
+}
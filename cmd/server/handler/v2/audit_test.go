@@ -0,0 +1,133 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAuditRouter(service audit.Service) *gin.Engine {
+	h := NewAudit(service)
+	r := gin.New()
+	r.GET("/api/v2/audit", h.List())
+	r.GET("/api/v2/audit/verify", h.Verify())
+	return r
+}
+
+func TestAudit_List(t *testing.T) {
+	recordedAt := time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+	records := []domain.AuditRecord{
+		{ID: 9, RecordedAt: recordedAt, Actor: "jdoe", RequestID: "4bf9", Entity: "section", EntityID: 12, Operation: audit.OpDelete,
+			Before: json.RawMessage(`{"id":12}`), Changes: map[string]domain.AuditChange{"id": {Before: float64(12)}}},
+		{ID: 4, RecordedAt: recordedAt, Actor: "jdoe", RequestID: "3af2", Entity: "section", EntityID: 12, Operation: audit.OpCreate,
+			After: json.RawMessage(`{"id":12}`), Changes: map[string]domain.AuditChange{"id": {After: float64(12)}}},
+	}
+
+	t.Run("it should list the records of an entity with a link to the next page", func(t *testing.T) {
+		// Arrange
+		service := &audit.ServiceMock{}
+		service.On("List", mock.Anything, audit.Filter{Entity: "section", EntityID: 12, Limit: 2}).Return(records, nil)
+		r := newAuditRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/audit?entity=section&id=12&limit=1", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":9,"recorded_at":"2026-10-18T15:04:05Z","actor":"jdoe","request_id":"4bf9",
+			"entity":"section","entity_id":12,"operation":"delete","before":{"id":12},"after":null,
+			"changes":{"id":{"before":12,"after":null}}}],
+			"meta":{"count":1},
+			"links":{"self":"/api/v2/audit?entity=section&id=12&limit=1","next":"/api/v2/audit?before=9&entity=section&id=12&limit=1"}}`,
+			response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should not link to a next page after the last one", func(t *testing.T) {
+		// Arrange
+		service := &audit.ServiceMock{}
+		service.On("List", mock.Anything, audit.Filter{BeforeID: 9, Limit: 51}).Return(records[1:], nil)
+		r := newAuditRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/audit?before=9", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body struct {
+			Links map[string]string `json:"links"`
+		}
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, map[string]string{"self": "/api/v2/audit?before=9"}, body.Links)
+	})
+
+	t.Run("it should return 400 for an invalid query", func(t *testing.T) {
+		cases := map[string]string{
+			"/api/v2/audit?id=12":                    ErrAuditIDWithoutEntity,
+			"/api/v2/audit?entity=section&id=twelve": ErrInvalidID,
+			"/api/v2/audit?limit=501":                ErrInvalidLimit,
+			"/api/v2/audit?before=0":                 ErrInvalidBefore,
+		}
+		for target, message := range cases {
+			// Arrange
+			r := newAuditRouter(&audit.ServiceMock{})
+			request := httptest.NewRequest(http.MethodGet, target, nil)
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, response.Code, target)
+			assert.JSONEq(t, `{"code":"bad_request","message":"`+message+`"}`, response.Body.String(), target)
+		}
+	})
+
+	t.Run("it should return 500 when the log cannot be read", func(t *testing.T) {
+		// Arrange
+		service := &audit.ServiceMock{}
+		service.On("List", mock.Anything, mock.Anything).Return([]domain.AuditRecord(nil), errors.New("connection refused"))
+		r := newAuditRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/audit", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestAudit_Verify(t *testing.T) {
+	t.Run("it should report where the hash chain breaks", func(t *testing.T) {
+		// Arrange
+		brokenAt := 7
+		service := &audit.ServiceMock{}
+		service.On("Verify", mock.Anything).Return(audit.Verification{Checked: 3, BrokenAt: &brokenAt}, nil)
+		r := newAuditRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/audit/verify", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"checked":3,"valid":false,"broken_at":7},"meta":{},"links":{"self":"/api/v2/audit/verify"}}`,
+			response.Body.String())
+	})
+}
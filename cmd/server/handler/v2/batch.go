@@ -0,0 +1,262 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrDuplicateBatchNumber = "batch_number already exists"
+	ErrBatchProductNotFound = "product_id does not exist"
+	ErrBatchSectionNotFound = "section_id does not exist"
+	ErrBatchProductType     = "the product is not of the product type of the section"
+	ErrBatchColdChain       = "the section breaks the cold chain of the batch"
+	ErrBatchOverride        = "the cold chain check cannot be overridden until requests are authenticated"
+	ErrBatchOverrideReason  = "cold_chain_override needs a reason"
+	ErrBatchSectionFull     = "current_quantity exceeds the maximum capacity of the section"
+	ErrBatchNotFound        = "product batch not found"
+	ErrBatchNotEnoughStock  = "quantity exceeds the current_quantity of the batch"
+	ErrBatchSectionEmpty    = "quantity exceeds the current capacity of the section"
+)
+
+// BatchRequest is the body of the product batch creation request.
+// Quantities, temperatures and the hour are pointers so that zero is a valid
+// value while the field stays required.
+type BatchRequest struct {
+	BatchNumber        int    `json:"batch_number" binding:"required,gt=0"`
+	CurrentQuantity    *int   `json:"current_quantity" binding:"required,gte=0"`
+	CurrentTemperature *int   `json:"current_temperature" binding:"required"`
+	DueDate            string `json:"due_date" binding:"required,datetime=2006-01-02"`
+	InitialQuantity    *int   `json:"initial_quantity" binding:"required,gte=0"`
+	ManufacturingDate  string `json:"manufacturing_date" binding:"required,datetime=2006-01-02"`
+	ManufacturingHour  *int   `json:"manufacturing_hour" binding:"required,gte=0,lte=23"`
+	MinimumTemperature *int   `json:"minimum_temperature" binding:"required"`
+	ProductID          int    `json:"product_id" binding:"required,gt=0"`
+	SectionID          int    `json:"section_id" binding:"required,gt=0"`
+	// ColdChainOverride asks to store the batch in a section that breaks its
+	// cold chain, which is refused until requests are authenticated.
+	ColdChainOverride *ColdChainOverrideRequest `json:"cold_chain_override"`
+}
+
+// ConsumeRequest is the body of the product batch consumption request.
+type ConsumeRequest struct {
+	Quantity int `json:"quantity" binding:"required,gt=0" example:"20"`
+}
+
+// ColdChainOverrideRequest tells why a batch is stored in spite of the
+// violations of its cold chain.
+type ColdChainOverrideRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// ColdChainErrorResponse is the body of a rejected batch. Violations lists
+// the temperatures its section breaks, when they are the reason.
+type ColdChainErrorResponse struct {
+	Code       string                      `json:"code" validate:"required"`
+	Message    string                      `json:"message" validate:"required"`
+	Violations []domain.ColdChainViolation `json:"violations,omitempty"`
+}
+
+// Batch contains the /product-batches handlers.
+type Batch struct {
+	batchService batch.Service
+}
+
+// NewBatch returns a new instance of Batch.
+func NewBatch(s batch.Service) *Batch {
+	return &Batch{batchService: s}
+}
+
+// GetAll godoc
+// @Summary List product batches
+// @Description With format=ndjson, or Accept: application/x-ndjson, the batches are streamed a JSON object per line as they are read.
+// @Description A stream that fails after its first line ends with an error line.
+// @Tags product-batches
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx, ndjson)
+// @Success 200 {object} web.Envelope{data=[]domain.ProductBatch}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /product-batches [get]
+func (b *Batch) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ok, err := web.Stream(c, b.batchService.Each); ok {
+			if err != nil {
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+
+		batches, err := b.batchService.GetAll(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, batches)
+	}
+}
+
+// ByProduct godoc
+// @Summary List the batches of a product
+// @Tags products
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Product ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.ProductBatch}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id}/product-batches [get]
+func (b *Batch) ByProduct(products product.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+		if _, err := products.Get(c, id); !parentFound(c, err, product.ErrNotFound, ErrProductNotFound) {
+			return
+		}
+
+		batches, err := b.batchService.GetByProductIDs(c, []int{id})
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, batches)
+	}
+}
+
+// Create godoc
+// @Summary Create a product batch
+// @Description A batch whose section is colder than its minimum temperature, may get colder, or is warmer than the
+// @Description recommended freezing temperature of its product is rejected with the violations of its cold chain.
+// @Description A cold_chain_override of the check is refused with 403 until requests are authenticated, as the X-Actor
+// @Description header does not prove who sent them; one without a reason is rejected with 400. The batches overridden
+// @Description before are still listed with their cold_chain_override.
+// @Description The current quantity of the batch is added to the current capacity of its section, which it cannot take
+// @Description over its maximum capacity.
+// @Tags product-batches
+// @Accept json
+// @Produce json
+// @Param body body BatchRequest true "Product batch to create"
+// @Success 201 {object} web.Envelope{data=domain.ProductBatch}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} ColdChainErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /product-batches [post]
+func (b *Batch) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req BatchRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		pb := req.toProductBatch()
+		saved, err := b.batchService.Save(c, pb)
+		var coldChainErr *batch.ColdChainError
+		if err != nil {
+			switch {
+			case errors.As(err, &coldChainErr):
+				web.Response(c, http.StatusUnprocessableEntity, ColdChainErrorResponse{
+					Code:       "unprocessable_entity",
+					Message:    ErrBatchColdChain,
+					Violations: coldChainErr.Violations,
+				})
+			case errors.Is(err, batch.ErrOverrideReason):
+				web.Error(c, http.StatusBadRequest, ErrBatchOverrideReason)
+			case errors.Is(err, batch.ErrOverrideNotAllowed):
+				web.Error(c, http.StatusForbidden, ErrBatchOverride)
+			case errors.Is(err, batch.ErrDuplicateBatchNumber):
+				web.Error(c, http.StatusConflict, ErrDuplicateBatchNumber)
+			case errors.Is(err, batch.ErrSectionFull):
+				web.Error(c, http.StatusConflict, ErrBatchSectionFull)
+			case errors.Is(err, batch.ErrProductNotFound):
+				web.Error(c, http.StatusUnprocessableEntity, ErrBatchProductNotFound)
+			case errors.Is(err, batch.ErrSectionNotFound):
+				web.Error(c, http.StatusUnprocessableEntity, ErrBatchSectionNotFound)
+			case errors.Is(err, batch.ErrProductTypeMismatch):
+				web.Error(c, http.StatusUnprocessableEntity, ErrBatchProductType)
+			default:
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+
+		// No override is stored
+		pb.ID, pb.ColdChainOverride = saved.ID, nil
+		created(c, pb, link("/product-batches/%d", saved.ID))
+	}
+}
+
+// Consume godoc
+// @Summary Consume the stock of a product batch
+// @Description Takes quantity from the current quantity of a batch and from the current capacity of its section, in
+// @Description one transaction. A consumption taking the section below its minimum capacity writes a
+// @Description section.capacity_low event to the outbox.
+// @Tags product-batches
+// @Accept json
+// @Produce json
+// @Param id path int true "Product batch ID"
+// @Param body body ConsumeRequest true "Quantity to consume"
+// @Success 200 {object} web.Envelope{data=domain.ProductBatch}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /product-batches/{id}/consume [post]
+func (b *Batch) Consume() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+		var req ConsumeRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		consumed, err := b.batchService.Consume(c, id, req.Quantity)
+		if err != nil {
+			switch {
+			case errors.Is(err, batch.ErrNotFound):
+				web.Error(c, http.StatusNotFound, ErrBatchNotFound)
+			case errors.Is(err, batch.ErrNotEnoughStock):
+				web.Error(c, http.StatusConflict, ErrBatchNotEnoughStock)
+			case errors.Is(err, batch.ErrSectionFull):
+				web.Error(c, http.StatusConflict, ErrBatchSectionEmpty)
+			default:
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+		web.Resource(c, http.StatusOK, consumed.Batch, link("/product-batches/%d", id))
+	}
+}
+
+func (r BatchRequest) toProductBatch() domain.ProductBatch {
+	b := domain.ProductBatch{
+		BatchNumber:        r.BatchNumber,
+		CurrentQuantity:    *r.CurrentQuantity,
+		CurrentTemperature: *r.CurrentTemperature,
+		DueDate:            r.DueDate,
+		InitialQuantity:    *r.InitialQuantity,
+		ManufacturingDate:  r.ManufacturingDate,
+		ManufacturingHour:  *r.ManufacturingHour,
+		MinimumTemperature: *r.MinimumTemperature,
+		ProductID:          r.ProductID,
+		SectionID:          r.SectionID,
+	}
+	if r.ColdChainOverride != nil {
+		b.ColdChainOverride = &domain.ColdChainOverride{Reason: r.ColdChainOverride.Reason}
+	}
+	return b
+}
@@ -0,0 +1,356 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBatchRouter(service batch.Service) *gin.Engine {
+	h := NewBatch(service)
+	r := gin.New()
+	r.GET("/api/v2/product-batches", h.GetAll())
+	r.POST("/api/v2/product-batches", h.Create())
+	r.POST("/api/v2/product-batches/:id/consume", h.Consume())
+	return r
+}
+
+func TestBatch_GetAll(t *testing.T) {
+	t.Run("it should stream the batches a line each when the Accept header asks for NDJSON", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Each", mock.Anything).Return([]domain.ProductBatch{
+			{ID: 1, BatchNumber: 11, DueDate: "2026-12-01", ManufacturingDate: "2026-10-01", ProductID: 1, SectionID: 2},
+			{ID: 2, BatchNumber: 12, DueDate: "2026-12-02", ManufacturingDate: "2026-10-02", ProductID: 1, SectionID: 2},
+		}, nil)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/product-batches", nil)
+		request.Header.Set("Accept", "application/x-ndjson")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/x-ndjson", response.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSuffix(response.Body.String(), "\n"), "\n")
+		assert.Len(t, lines, 2)
+		assert.JSONEq(t, `{"id":2,"batch_number":12,"current_quantity":0,"current_temperature":0,"due_date":"2026-12-02","initial_quantity":0,
+			"manufacturing_date":"2026-10-02","manufacturing_hour":0,"minimum_temperature":0,"product_id":1,"section_id":2}`, lines[1])
+		service.AssertNotCalled(t, "GetAll", mock.Anything)
+	})
+
+	t.Run("it should return 500 when the stream fails before its first batch", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Each", mock.Anything).Return([]domain.ProductBatch{}, errors.New("connection refused"))
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/product-batches?format=ndjson", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.JSONEq(t, `{"code":"internal_server_error","message":"internal server error"}`, response.Body.String())
+	})
+}
+
+func TestBatch_Create(t *testing.T) {
+	body := `{"batch_number":11,"current_quantity":5,"current_temperature":-2,"due_date":"2026-12-01","initial_quantity":5,
+		"manufacturing_date":"2026-10-01","manufacturing_hour":0,"minimum_temperature":-5,"product_id":1,"section_id":2}`
+
+	t.Run("it should create the batch", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, domain.ProductBatch{
+			BatchNumber: 11, CurrentQuantity: 5, CurrentTemperature: -2, DueDate: "2026-12-01", InitialQuantity: 5,
+			ManufacturingDate: "2026-10-01", MinimumTemperature: -5, ProductID: 1, SectionID: 2,
+		}).Return(batch.Saved{ID: 8}, nil)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "/api/v2/product-batches/8", response.Header().Get("Location"))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 when the section does not exist", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(batch.Saved{}, batch.ErrSectionNotFound)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"section_id does not exist"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when the product is not of the type of the section", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(batch.Saved{}, batch.ErrProductTypeMismatch)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"the product is not of the product type of the section"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 with the violations of the cold chain", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(batch.Saved{}, &batch.ColdChainError{Violations: []domain.ColdChainViolation{{
+			Rule:        batch.RuleSectionAboveRecommended,
+			Message:     "the section is warmer than the recommended freezing temperature of the product",
+			Temperature: 5,
+			Limit:       -4,
+		}}})
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"the section breaks the cold chain of the batch",
+			"violations":[{"rule":"section_above_recommended_temperature",
+			"message":"the section is warmer than the recommended freezing temperature of the product","temperature":5,"limit":-4}]}`,
+			response.Body.String())
+	})
+
+	t.Run("it should pass the cold chain override to the service", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, mock.MatchedBy(func(b domain.ProductBatch) bool {
+			return b.ColdChainOverride != nil && b.ColdChainOverride.Reason == "the freezer is fixed tonight"
+		})).Return(batch.Saved{ID: 8}, nil)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches",
+			strings.NewReader(strings.Replace(body, `"section_id":2`, `"section_id":2,"cold_chain_override":{"reason":"the freezer is fixed tonight"}`, 1)))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 403 for an override of the cold chain check", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(batch.Saved{}, batch.ErrOverrideNotAllowed)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches",
+			strings.NewReader(strings.Replace(body, `"section_id":2`, `"section_id":2,"cold_chain_override":{"reason":"urgent"}`, 1)))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.JSONEq(t, `{"code":"forbidden","message":"the cold chain check cannot be overridden until requests are authenticated"}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 when the override has no reason", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(batch.Saved{}, batch.ErrOverrideReason)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches",
+			strings.NewReader(strings.Replace(body, `"section_id":2`, `"section_id":2,"cold_chain_override":{"reason":""}`, 1)))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"cold_chain_override needs a reason"}`, response.Body.String())
+	})
+
+	t.Run("it should return 409 when the batch number is taken", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(batch.Saved{}, batch.ErrDuplicateBatchNumber)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"batch_number already exists"}`, response.Body.String())
+	})
+
+	t.Run("it should return 409 when the section is full", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(batch.Saved{}, batch.ErrSectionFull)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"current_quantity exceeds the maximum capacity of the section"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when the hour is out of range", func(t *testing.T) {
+		// Arrange
+		r := newBatchRouter(&batch.ServiceMock{})
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches",
+			strings.NewReader(strings.Replace(body, `"manufacturing_hour":0`, `"manufacturing_hour":24`, 1)))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"manufacturing_hour must be less than or equal to 23"}`, response.Body.String())
+	})
+}
+
+func TestBatch_Consume(t *testing.T) {
+	t.Run("it should return the batch consumed", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("Consume", mock.Anything, 7, 20).Return(batch.Consumed{Batch: domain.ProductBatch{
+			ID: 7, BatchNumber: 111, CurrentQuantity: 10, DueDate: "2024-01-01", InitialQuantity: 30, ManufacturingDate: "2023-01-01", ProductID: 1, SectionID: 2,
+		}}, nil)
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches/7/consume", strings.NewReader(`{"quantity":20}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"current_quantity":10`)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should map the errors of the consumption", func(t *testing.T) {
+		for _, tc := range []struct {
+			err     error
+			status  int
+			message string
+		}{
+			{batch.ErrNotFound, http.StatusNotFound, ErrBatchNotFound},
+			{batch.ErrNotEnoughStock, http.StatusConflict, ErrBatchNotEnoughStock},
+			{batch.ErrSectionFull, http.StatusConflict, ErrBatchSectionEmpty},
+		} {
+			// Arrange
+			service := &batch.ServiceMock{}
+			service.On("Consume", mock.Anything, 7, 20).Return(batch.Consumed{}, tc.err)
+			r := newBatchRouter(service)
+			request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches/7/consume", strings.NewReader(`{"quantity":20}`))
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, tc.status, response.Code, tc.message)
+			assert.Contains(t, response.Body.String(), tc.message)
+		}
+	})
+
+	t.Run("it should return 422 for a quantity that is not positive", func(t *testing.T) {
+		service := &batch.ServiceMock{}
+		r := newBatchRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-batches/7/consume", strings.NewReader(`{"quantity":0}`))
+		response := httptest.NewRecorder()
+
+		serveHTTP(t, r, response, request)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		service.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestBatch_ByProduct(t *testing.T) {
+	newRouter := func(service batch.Service, products product.Service) *gin.Engine {
+		r := gin.New()
+		r.GET("/api/v2/products/:id/product-batches", NewBatch(service).ByProduct(products))
+		return r
+	}
+
+	t.Run("it should list the batches of the product", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		service.On("GetByProductIDs", mock.Anything, []int{1}).Return([]domain.ProductBatch{
+			{ID: 2, BatchNumber: 12, DueDate: "2026-12-02", ManufacturingDate: "2026-10-02", ProductID: 1, SectionID: 2},
+		}, nil)
+		products := &product.ServiceMock{}
+		products.On("Get", mock.Anything, 1).Return(domain.Product{ID: 1}, nil)
+		r := newRouter(service, products)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1/product-batches", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":2,"batch_number":12,"current_quantity":0,"current_temperature":0,"due_date":"2026-12-02",
+			"initial_quantity":0,"manufacturing_date":"2026-10-02","manufacturing_hour":0,"minimum_temperature":0,"product_id":1,"section_id":2}],
+			"meta":{"count":1},"links":{"self":"/api/v2/products/1/product-batches"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the product does not exist", func(t *testing.T) {
+		// Arrange
+		service := &batch.ServiceMock{}
+		products := &product.ServiceMock{}
+		products.On("Get", mock.Anything, 9).Return(domain.Product{}, product.ErrNotFound)
+		r := newRouter(service, products)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/9/product-batches", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"product not found"}`, response.Body.String())
+		service.AssertNotCalled(t, "GetByProductIDs", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,290 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrBuyerNotFound      = "buyer not found"
+	ErrBuyerAlreadyExists = "card_number_id already exists"
+)
+
+// BuyerRequest is the body of the buyer creation and update requests.
+// The card number of a buyer cannot be changed once it is created.
+type BuyerRequest struct {
+	CardNumberID string `json:"card_number_id" binding:"required"`
+	FirstName    string `json:"first_name" binding:"required"`
+	LastName     string `json:"last_name" binding:"required"`
+}
+
+// BuyerPatch documents the body of the buyer update request: every field of
+// BuyerRequest is optional and the missing ones keep their stored value.
+type BuyerPatch struct {
+	CardNumberID string `json:"card_number_id,omitempty"`
+	FirstName    string `json:"first_name,omitempty"`
+	LastName     string `json:"last_name,omitempty"`
+}
+
+// Buyer contains the /buyers handlers.
+type Buyer struct {
+	buyerService buyer.Service
+}
+
+// NewBuyer returns a new instance of Buyer.
+func NewBuyer(s buyer.Service) *Buyer {
+	return &Buyer{buyerService: s}
+}
+
+// GetAll godoc
+// @Summary List buyers
+// @Tags buyers
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Param include_deleted query bool false "Include the deleted buyers"
+// @Success 200 {object} web.Envelope{data=[]domain.Buyer}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers [get]
+func (b *Buyer) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		buyers, err := b.buyerService.GetAll(ctx)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, buyers)
+	}
+}
+
+// Get godoc
+// @Summary Get a buyer
+// @Tags buyers
+// @Produce json
+// @Param id path int true "Buyer ID"
+// @Param include_deleted query bool false "Include the buyer if it is deleted"
+// @Success 200 {object} web.Envelope{data=domain.Buyer}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers/{id} [get]
+func (b *Buyer) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		by, err := b.buyerService.Get(ctx, id)
+		if err != nil {
+			b.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, by, link("/buyers/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Create a buyer
+// @Tags buyers
+// @Accept json
+// @Produce json
+// @Param body body BuyerRequest true "Buyer to create"
+// @Success 201 {object} web.Envelope{data=domain.Buyer}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers [post]
+func (b *Buyer) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req BuyerRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		by := req.toBuyer()
+		id, err := b.buyerService.Save(c, by)
+		if err != nil {
+			b.writeError(c, err)
+			return
+		}
+
+		by.ID = id
+		created(c, by, link("/buyers/%d", id))
+	}
+}
+
+// Update godoc
+// @Summary Update a buyer
+// @Description Only the fields present in the body are changed. card_number_id cannot be changed.
+// @Tags buyers
+// @Accept json
+// @Produce json
+// @Param id path int true "Buyer ID"
+// @Param body body BuyerPatch true "Fields to update"
+// @Success 200 {object} web.Envelope{data=domain.Buyer}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers/{id} [patch]
+func (b *Buyer) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		current, err := b.buyerService.Get(c, id)
+		if err != nil {
+			b.writeError(c, err)
+			return
+		}
+
+		req := BuyerRequest{
+			CardNumberID: current.CardNumberID,
+			FirstName:    current.FirstName,
+			LastName:     current.LastName,
+		}
+		if !bind(c, &req) {
+			return
+		}
+		if req.CardNumberID != current.CardNumberID {
+			web.Error(c, http.StatusUnprocessableEntity, fmt.Sprintf(ErrCannotBeChanged, "card_number_id"))
+			return
+		}
+
+		// the service only changes the names of current
+		toUpdate := domain.Buyer{
+			ID:        id,
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+		}
+		if err := b.buyerService.Update(c, id, toUpdate, &current); err != nil {
+			b.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, current, link("/buyers/%d", id))
+	}
+}
+
+// Delete godoc
+// @Summary Delete a buyer
+// @Tags buyers
+// @Produce json
+// @Param id path int true "Buyer ID"
+// @Success 204
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers/{id} [delete]
+func (b *Buyer) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		if err := b.buyerService.Delete(c, id); err != nil {
+			b.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Restore godoc
+// @Summary Restore a deleted buyer
+// @Description Undoes the deletion of a buyer. A buyer that is not deleted is returned as it is.
+// @Tags buyers
+// @Produce json
+// @Param id path int true "Buyer ID"
+// @Success 200 {object} web.Envelope{data=domain.Buyer}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers/{id}/restore [post]
+func (b *Buyer) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		by, err := b.buyerService.Restore(c, id)
+		if err != nil {
+			b.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, by, link("/buyers/%d", id))
+	}
+}
+
+// Import godoc
+// @Summary Import buyers
+// @Description Saves every buyer of a CSV or NDJSON file in one transaction. The CSV header names the fields of BuyerRequest.
+// @Description A card_number_id already stored fails its row, or updates the names of the stored buyer when mode is upsert.
+// @Description When a row is invalid nothing is saved and the errors of every row are listed with their line number.
+// @Tags buyers
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV (text/csv) or NDJSON (application/x-ndjson) file"
+// @Param mode query string false "insert (default) or upsert" Enums(insert, upsert)
+// @Param dry_run query bool false "Check every row without saving any"
+// @Success 200 {object} web.Envelope{data=ImportSummary}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 415 {object} web.ErrorResponse
+// @Failure 422 {object} ImportErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers/import [post]
+func (b *Buyer) Import() gin.HandlerFunc {
+	im := importer[BuyerRequest, domain.Buyer]{
+		toDomain: BuyerRequest.toBuyer,
+		run:      b.buyerService.Import,
+		rowMessage: func(err error) string {
+			if errors.Is(err, buyer.ErrAlreadyExists) {
+				return ErrBuyerAlreadyExists
+			}
+			return err.Error()
+		},
+	}
+	return im.handle
+}
+
+// writeError maps the errors of the buyer service to a response.
+func (b *Buyer) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, buyer.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrBuyerNotFound)
+	case errors.Is(err, buyer.ErrAlreadyExists):
+		web.Error(c, http.StatusConflict, ErrBuyerAlreadyExists)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
+
+func (r BuyerRequest) toBuyer() domain.Buyer {
+	return domain.Buyer{
+		CardNumberID: r.CardNumberID,
+		FirstName:    r.FirstName,
+		LastName:     r.LastName,
+	}
+}
@@ -0,0 +1,102 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBuyerRouter(service buyer.Service) *gin.Engine {
+	h := NewBuyer(service)
+	r := gin.New()
+	r.GET("/api/v2/buyers", h.GetAll())
+	r.GET("/api/v2/buyers/:id", h.Get())
+	r.POST("/api/v2/buyers", h.Create())
+	r.POST("/api/v2/buyers/import", h.Import())
+	r.PATCH("/api/v2/buyers/:id", h.Update())
+	r.DELETE("/api/v2/buyers/:id", h.Delete())
+	return r
+}
+
+func TestBuyer_Create(t *testing.T) {
+	t.Run("it should return 409 when the card number is taken", func(t *testing.T) {
+		// Arrange
+		service := buyer.NewBuyerService()
+		service.On("Save", mock.Anything, domain.Buyer{CardNumberID: "B1", FirstName: "Ana", LastName: "Ruiz"}).Return(0, buyer.ErrAlreadyExists)
+		r := newBuyerRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/buyers",
+			strings.NewReader(`{"card_number_id":"B1","first_name":"Ana","last_name":"Ruiz"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"card_number_id already exists"}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+}
+
+func TestBuyer_Update(t *testing.T) {
+	stored := domain.Buyer{ID: 2, CardNumberID: "B1", FirstName: "Ana", LastName: "Ruiz"}
+
+	t.Run("it should pass the new names to the service", func(t *testing.T) {
+		// Arrange
+		service := buyer.NewBuyerService()
+		service.On("Get", mock.Anything, 2).Return(stored, nil)
+		service.On("Update", mock.Anything, 2, domain.Buyer{ID: 2, FirstName: "Eva", LastName: "Ruiz"}, mock.Anything).Return(nil)
+		r := newBuyerRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/buyers/2", strings.NewReader(`{"first_name":"Eva"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 when the card number changes", func(t *testing.T) {
+		// Arrange
+		service := buyer.NewBuyerService()
+		service.On("Get", mock.Anything, 2).Return(stored, nil)
+		r := newBuyerRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/buyers/2", strings.NewReader(`{"card_number_id":"B2"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"card_number_id cannot be changed"}`, response.Body.String())
+		service.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestBuyer_Get(t *testing.T) {
+	t.Run("it should return 404 when the buyer does not exist", func(t *testing.T) {
+		// Arrange
+		service := buyer.NewBuyerService()
+		service.On("Get", mock.Anything, 2).Return(domain.Buyer{}, buyer.ErrNotFound)
+		r := newBuyerRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/buyers/2", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"buyer not found"}`, response.Body.String())
+	})
+}
@@ -0,0 +1,189 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/davidop97/apiGo/internal/carries"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrCarryAlreadyExists = "carry already exists"
+	ErrIncorrectCarry     = "incorrect carry data"
+)
+
+// CarryRequest is the body of the carry creation request.
+type CarryRequest struct {
+	CID         string `json:"cid" binding:"required"`
+	CompanyName string `json:"company_name" binding:"required"`
+	Address     string `json:"address" binding:"required"`
+	Telephone   string `json:"telephone" binding:"required"`
+	LocalityID  int    `json:"locality_id" binding:"required,gt=0"`
+}
+
+// LocalityCarries is the carry count of a locality. Unlike
+// domain.LocalityCarries its locality_id is a number.
+type LocalityCarries struct {
+	LocalityID   int    `json:"locality_id"`
+	LocalityName string `json:"locality_name"`
+	CarriesCount int    `json:"carries_count"`
+}
+
+// Carry contains the /carries handlers and the carry reports of /localities.
+type Carry struct {
+	carriesService carries.Service
+}
+
+// NewCarry returns a new instance of Carry.
+func NewCarry(s carries.Service) *Carry {
+	return &Carry{carriesService: s}
+}
+
+// GetAll godoc
+// @Summary List carries
+// @Tags carries
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.Carries}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /carries [get]
+func (h *Carry) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		list, err := h.carriesService.GetAll(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, list)
+	}
+}
+
+// Create godoc
+// @Summary Create a carry
+// @Tags carries
+// @Accept json
+// @Produce json
+// @Param body body CarryRequest true "Carry to create"
+// @Success 201 {object} web.Envelope{data=domain.Carries}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /carries [post]
+func (h *Carry) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req CarryRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		carry := domain.Carries{
+			CID:         req.CID,
+			CompanyName: req.CompanyName,
+			Address:     req.Address,
+			Telephone:   req.Telephone,
+			LocalityID:  req.LocalityID,
+		}
+		id, err := h.carriesService.Save(c, carry)
+		if err != nil {
+			switch {
+			case errors.Is(err, carries.ErrDuplicateCarry):
+				web.Error(c, http.StatusConflict, ErrCarryAlreadyExists)
+			case errors.Is(err, carries.ErrLocalityCarriesNotFound):
+				web.Error(c, http.StatusUnprocessableEntity, ErrLocalityNotExists)
+			case errors.Is(err, carries.ErrIncorrectData):
+				web.Error(c, http.StatusUnprocessableEntity, ErrIncorrectCarry)
+			default:
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+
+		carry.ID = id
+		created(c, carry, link("/carries/%d", id))
+	}
+}
+
+// LocalityReports godoc
+// @Summary Count the carries of every locality
+// @Tags localities
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]LocalityCarries}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /localities/carry-reports [get]
+func (h *Carry) LocalityReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		list, err := h.carriesService.GetAllCarriesByLocality(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+
+		reports := make([]LocalityCarries, 0, len(list))
+		for _, lc := range list {
+			report, err := toLocalityCarries(lc)
+			if err != nil {
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+				return
+			}
+			reports = append(reports, report)
+		}
+		web.Collection(c, reports)
+	}
+}
+
+// LocalityReport godoc
+// @Summary Count the carries of a locality
+// @Tags localities
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Locality ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=LocalityCarries}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /localities/{id}/carry-report [get]
+func (h *Carry) LocalityReport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		lc, err := h.carriesService.GetAllCarriesByLocalityID(c, id)
+		if err != nil {
+			if errors.Is(err, carries.ErrLocalityCarriesNotFound) {
+				web.Error(c, http.StatusNotFound, ErrLocalityNotFound)
+				return
+			}
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+
+		report, err := toLocalityCarries(lc)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Report(c, report, link("/localities/%d/carry-report", id))
+	}
+}
+
+func toLocalityCarries(lc domain.LocalityCarries) (LocalityCarries, error) {
+	id, err := strconv.Atoi(lc.LocalityID)
+	if err != nil {
+		return LocalityCarries{}, err
+	}
+	return LocalityCarries{
+		LocalityID:   id,
+		LocalityName: lc.LocalityName,
+		CarriesCount: lc.CarriesCount,
+	}, nil
+}
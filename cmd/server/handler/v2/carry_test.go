@@ -0,0 +1,99 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/carries"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCarryRouter(service carries.Service) *gin.Engine {
+	h := NewCarry(service)
+	r := gin.New()
+	r.GET("/api/v2/carries", h.GetAll())
+	r.POST("/api/v2/carries", h.Create())
+	r.GET("/api/v2/localities/carry-reports", h.LocalityReports())
+	r.GET("/api/v2/localities/:id/carry-report", h.LocalityReport())
+	return r
+}
+
+func TestCarry_Create(t *testing.T) {
+	body := `{"cid":"C1","company_name":"Fast","address":"Street 1","telephone":"555","locality_id":6}`
+
+	t.Run("it should take locality_id as a number", func(t *testing.T) {
+		// Arrange
+		service := &carries.ServiceMock{}
+		service.On("Save", mock.Anything, domain.Carries{CID: "C1", CompanyName: "Fast", Address: "Street 1", Telephone: "555", LocalityID: 6}).Return(2, nil)
+		r := newCarryRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/carries", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.JSONEq(t, `{"data":{"id":2,"cid":"C1","company_name":"Fast","address":"Street 1","telephone":"555","locality_id":6},
+			"meta":{},"links":{"self":"/api/v2/carries/2"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 when the locality does not exist", func(t *testing.T) {
+		// Arrange
+		service := &carries.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(0, carries.ErrLocalityCarriesNotFound)
+		r := newCarryRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/carries", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"locality_id does not exist"}`, response.Body.String())
+	})
+}
+
+func TestCarry_LocalityReports(t *testing.T) {
+	t.Run("it should list the carry count of every locality", func(t *testing.T) {
+		// Arrange
+		service := &carries.ServiceMock{}
+		service.On("GetAllCarriesByLocality", mock.Anything).Return([]domain.LocalityCarries{
+			{LocalityID: "6", LocalityName: "Centro", CarriesCount: 3},
+		}, nil)
+		r := newCarryRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/localities/carry-reports", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"locality_id":6,"locality_name":"Centro","carries_count":3}],
+			"meta":{"count":1},"links":{"self":"/api/v2/localities/carry-reports"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the locality does not exist", func(t *testing.T) {
+		// Arrange
+		service := &carries.ServiceMock{}
+		service.On("GetAllCarriesByLocalityID", mock.Anything, 6).Return(domain.LocalityCarries{}, carries.ErrLocalityCarriesNotFound)
+		r := newCarryRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/localities/6/carry-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"locality not found"}`, response.Body.String())
+	})
+}
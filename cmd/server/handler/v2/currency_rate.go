@@ -0,0 +1,139 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/currencyrate"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrCurrencyRateInvalid = "rate must be a decimal greater than 0"
+	ErrCurrencyRateBase    = "the rate of USD, the base currency, is always 1"
+)
+
+// CurrencyRateRequest is the body of the currency rate update request.
+type CurrencyRateRequest struct {
+	// Rate is the value of one unit of the currency in USD.
+	Rate money.Rate `json:"rate" binding:"required" swaggertype:"string" example:"1.08"`
+}
+
+// CurrencyRate contains the /currency-rates handlers.
+type CurrencyRate struct {
+	currencyRateService currencyrate.Service
+}
+
+// NewCurrencyRate returns a new instance of CurrencyRate.
+func NewCurrencyRate(s currencyrate.Service) *CurrencyRate {
+	return &CurrencyRate{currencyRateService: s}
+}
+
+// GetAll godoc
+// @Summary List currency rates
+// @Description The rates are the value of one unit of each currency in USD, the currency of the prices recorded without one.
+// @Tags currency-rates
+// @Produce json
+// @Success 200 {object} web.Envelope{data=[]domain.CurrencyRate}
+// @Failure 500 {object} web.ErrorResponse
+// @Router /currency-rates [get]
+func (cr *CurrencyRate) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rates, err := cr.currencyRateService.GetAll(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, rates)
+	}
+}
+
+// Get godoc
+// @Summary Get the rate of a currency
+// @Tags currency-rates
+// @Produce json
+// @Param currency path string true "ISO 4217 code"
+// @Success 200 {object} web.Envelope{data=domain.CurrencyRate}
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /currency-rates/{currency} [get]
+func (cr *CurrencyRate) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rate, err := cr.currencyRateService.Get(c, money.Currency(c.Param("currency")))
+		if err != nil {
+			cr.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, rate, link("/currency-rates/%s", rate.Currency))
+	}
+}
+
+// Set godoc
+// @Summary Set the rate of a currency
+// @Description Creates the rate of the currency or replaces its current one. The reports converted afterwards use the new rate.
+// @Tags currency-rates
+// @Accept json
+// @Produce json
+// @Param currency path string true "ISO 4217 code"
+// @Param body body CurrencyRateRequest true "Rate of the currency"
+// @Success 200 {object} web.Envelope{data=domain.CurrencyRate}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /currency-rates/{currency} [put]
+func (cr *CurrencyRate) Set() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req CurrencyRateRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		rate, err := cr.currencyRateService.Set(c, domain.CurrencyRate{Currency: money.Currency(c.Param("currency")), Rate: req.Rate})
+		if err != nil {
+			cr.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, rate, link("/currency-rates/%s", rate.Currency))
+	}
+}
+
+// Delete godoc
+// @Summary Delete the rate of a currency
+// @Description The prices in the currency cannot be converted until it has a rate again.
+// @Tags currency-rates
+// @Produce json
+// @Param currency path string true "ISO 4217 code"
+// @Success 204
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /currency-rates/{currency} [delete]
+func (cr *CurrencyRate) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := cr.currencyRateService.Delete(c, money.Currency(c.Param("currency"))); err != nil {
+			cr.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// writeError maps the errors of the currency rate service to a response.
+func (cr *CurrencyRate) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, currencyrate.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrCurrencyRateNotFound)
+	case errors.Is(err, currencyrate.ErrInvalidCurrency):
+		web.Error(c, http.StatusUnprocessableEntity, ErrInvalidCurrency)
+	case errors.Is(err, currencyrate.ErrInvalidRate):
+		web.Error(c, http.StatusUnprocessableEntity, ErrCurrencyRateInvalid)
+	case errors.Is(err, currencyrate.ErrBaseCurrency):
+		web.Error(c, http.StatusUnprocessableEntity, ErrCurrencyRateBase)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
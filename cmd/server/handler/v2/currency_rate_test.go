@@ -0,0 +1,121 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/currencyrate"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCurrencyRateRouter(service currencyrate.Service) *gin.Engine {
+	h := NewCurrencyRate(service)
+	r := gin.New()
+	r.GET("/api/v2/currency-rates", h.GetAll())
+	r.GET("/api/v2/currency-rates/:currency", h.Get())
+	r.PUT("/api/v2/currency-rates/:currency", h.Set())
+	r.DELETE("/api/v2/currency-rates/:currency", h.Delete())
+	return r
+}
+
+func TestCurrencyRate_GetAll(t *testing.T) {
+	t.Run("it should wrap the rates in an envelope", func(t *testing.T) {
+		// Arrange
+		service := &currencyrate.ServiceMock{}
+		service.On("GetAll", mock.Anything).Return([]domain.CurrencyRate{{ID: 1, Currency: "EUR", Rate: 108000000}}, nil)
+		r := newCurrencyRateRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/currency-rates", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"currency":"EUR","rate":"1.08"}],"meta":{"count":1},"links":{"self":"/api/v2/currency-rates"}}`, response.Body.String())
+	})
+}
+
+func TestCurrencyRate_Get(t *testing.T) {
+	t.Run("it should return 404 when the currency has no rate", func(t *testing.T) {
+		// Arrange
+		service := &currencyrate.ServiceMock{}
+		service.On("Get", mock.Anything, money.Currency("GBP")).Return(domain.CurrencyRate{}, currencyrate.ErrNotFound)
+		r := newCurrencyRateRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/currency-rates/GBP", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"there is no rate for the currency"}`, response.Body.String())
+	})
+}
+
+func TestCurrencyRate_Set(t *testing.T) {
+	t.Run("it should set the rate of the currency in the path", func(t *testing.T) {
+		// Arrange
+		service := &currencyrate.ServiceMock{}
+		service.On("Set", mock.Anything, domain.CurrencyRate{Currency: "eur", Rate: 109500000}).
+			Return(domain.CurrencyRate{ID: 1, Currency: "EUR", Rate: 109500000}, nil)
+		r := newCurrencyRateRouter(service)
+		request := httptest.NewRequest(http.MethodPut, "/api/v2/currency-rates/eur", strings.NewReader(`{"rate":"1.095"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"currency":"EUR","rate":"1.095"},"meta":{},"links":{"self":"/api/v2/currency-rates/EUR"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 for the base currency or a rate that is not positive", func(t *testing.T) {
+		for err, message := range map[error]string{
+			currencyrate.ErrBaseCurrency:    "the rate of USD, the base currency, is always 1",
+			currencyrate.ErrInvalidRate:     "rate must be a decimal greater than 0",
+			currencyrate.ErrInvalidCurrency: "currency must be an ISO 4217 code",
+		} {
+			// Arrange
+			service := &currencyrate.ServiceMock{}
+			service.On("Set", mock.Anything, mock.Anything).Return(domain.CurrencyRate{}, err)
+			r := newCurrencyRateRouter(service)
+			request := httptest.NewRequest(http.MethodPut, "/api/v2/currency-rates/USD", strings.NewReader(`{"rate":"1"}`))
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code, message)
+			assert.JSONEq(t, `{"code":"unprocessable_entity","message":"`+message+`"}`, response.Body.String())
+		}
+	})
+}
+
+func TestCurrencyRate_Delete(t *testing.T) {
+	t.Run("it should delete the rate of the currency", func(t *testing.T) {
+		// Arrange
+		service := &currencyrate.ServiceMock{}
+		service.On("Delete", mock.Anything, money.Currency("EUR")).Return(nil)
+		r := newCurrencyRateRouter(service)
+		request := httptest.NewRequest(http.MethodDelete, "/api/v2/currency-rates/EUR", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		service.AssertExpectations(t)
+	})
+}
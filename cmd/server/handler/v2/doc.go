@@ -0,0 +1,15 @@
+// Package v2 holds the handlers of the /api/v2 routes.
+//
+// Every route uses a plural, kebab-case resource path; reports hang from the
+// resource they describe (e.g. /localities/{id}/seller-report). Successful
+// responses are wrapped in a web.Envelope and failures are web.ErrorResponse
+// bodies. Request bodies are validated with the binding tags of the request
+// structs: malformed JSON answers 400 and invalid fields answer 422.
+//
+// @title API GO
+// @version 2.0
+// @description This API manage many products of any company.
+// @host localhost:8080
+// @BasePath /api/v2
+// @schemes http
+package v2
@@ -0,0 +1,289 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/employee"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrEmployeeNotFound      = "employee not found"
+	ErrEmployeeAlreadyExists = "card_number_id already exists"
+)
+
+// EmployeeRequest is the body of the employee creation and update requests.
+type EmployeeRequest struct {
+	CardNumberID string `json:"card_number_id" binding:"required"`
+	FirstName    string `json:"first_name" binding:"required"`
+	LastName     string `json:"last_name" binding:"required"`
+	WarehouseID  int    `json:"warehouse_id" binding:"required,gt=0"`
+}
+
+// EmployeePatch documents the body of the employee update request: every field of
+// EmployeeRequest is optional and the missing ones keep their stored value.
+type EmployeePatch struct {
+	CardNumberID string `json:"card_number_id,omitempty"`
+	FirstName    string `json:"first_name,omitempty"`
+	LastName     string `json:"last_name,omitempty"`
+	WarehouseID  int    `json:"warehouse_id,omitempty"`
+}
+
+// Employee contains the /employees handlers.
+type Employee struct {
+	employeeService employee.Service
+}
+
+// NewEmployee returns a new instance of Employee.
+func NewEmployee(s employee.Service) *Employee {
+	return &Employee{employeeService: s}
+}
+
+// GetAll godoc
+// @Summary List employees
+// @Tags employees
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Param include_deleted query bool false "Include the deleted employees"
+// @Success 200 {object} web.Envelope{data=[]domain.Employee}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /employees [get]
+func (e *Employee) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		employees, err := e.employeeService.GetAllEmployees(ctx)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, employees)
+	}
+}
+
+// ByWarehouse godoc
+// @Summary List the employees of a warehouse
+// @Tags warehouses
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Warehouse ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.Employee}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses/{id}/employees [get]
+func (e *Employee) ByWarehouse(warehouses warehouse.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+		if _, err := warehouses.Get(c, id); !parentFound(c, err, warehouse.ErrNotFound, ErrWarehouseNotFound) {
+			return
+		}
+
+		employees, err := e.employeeService.GetEmployeesByWarehouseIDs(c, []int{id})
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, employees)
+	}
+}
+
+// Get godoc
+// @Summary Get an employee
+// @Tags employees
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param include_deleted query bool false "Include the employee if it is deleted"
+// @Success 200 {object} web.Envelope{data=domain.Employee}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /employees/{id} [get]
+func (e *Employee) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		emp, err := e.employeeService.GetEmployeeByID(ctx, id)
+		if err != nil {
+			e.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, emp, link("/employees/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Create an employee
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param body body EmployeeRequest true "Employee to create"
+// @Success 201 {object} web.Envelope{data=domain.Employee}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /employees [post]
+func (e *Employee) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req EmployeeRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		emp := req.toEmployee()
+		id, err := e.employeeService.SaveEmployee(c, emp)
+		if err != nil {
+			e.writeError(c, err)
+			return
+		}
+
+		emp.ID = id
+		created(c, emp, link("/employees/%d", id))
+	}
+}
+
+// Update godoc
+// @Summary Update an employee
+// @Description Only the fields present in the body are changed.
+// @Tags employees
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param body body EmployeePatch true "Fields to update"
+// @Success 200 {object} web.Envelope{data=domain.Employee}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /employees/{id} [patch]
+func (e *Employee) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		current, err := e.employeeService.GetEmployeeByID(c, id)
+		if err != nil {
+			e.writeError(c, err)
+			return
+		}
+
+		req := employeeToRequest(current)
+		if !bind(c, &req) {
+			return
+		}
+
+		emp := req.toEmployee()
+		emp.ID = id
+		if err := e.employeeService.UpdateEmployee(c, emp); err != nil {
+			e.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, emp, link("/employees/%d", id))
+	}
+}
+
+// Delete godoc
+// @Summary Delete an employee
+// @Tags employees
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 204
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /employees/{id} [delete]
+func (e *Employee) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		if err := e.employeeService.DeleteEmployee(c, id); err != nil {
+			e.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Restore godoc
+// @Summary Restore a deleted employee
+// @Description Undoes the deletion of an employee. An employee that is not deleted is returned as it is.
+// @Tags employees
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {object} web.Envelope{data=domain.Employee}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /employees/{id}/restore [post]
+func (e *Employee) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		emp, err := e.employeeService.RestoreEmployee(c, id)
+		if err != nil {
+			e.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, emp, link("/employees/%d", id))
+	}
+}
+
+// writeError maps the errors of the employee service to a response.
+func (e *Employee) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, employee.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrEmployeeNotFound)
+	case errors.Is(err, employee.ErrEmployeeAlreadyExists):
+		web.Error(c, http.StatusConflict, ErrEmployeeAlreadyExists)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
+
+func (r EmployeeRequest) toEmployee() domain.Employee {
+	return domain.Employee{
+		CardNumberID: r.CardNumberID,
+		FirstName:    r.FirstName,
+		LastName:     r.LastName,
+		WarehouseID:  r.WarehouseID,
+	}
+}
+
+func employeeToRequest(e domain.Employee) EmployeeRequest {
+	return EmployeeRequest{
+		CardNumberID: e.CardNumberID,
+		FirstName:    e.FirstName,
+		LastName:     e.LastName,
+		WarehouseID:  e.WarehouseID,
+	}
+}
@@ -0,0 +1,151 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/employee"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newEmployeeRouter(service employee.Service) *gin.Engine {
+	h := NewEmployee(service)
+	r := gin.New()
+	r.GET("/api/v2/employees", h.GetAll())
+	r.GET("/api/v2/employees/:id", h.Get())
+	r.POST("/api/v2/employees", h.Create())
+	r.PATCH("/api/v2/employees/:id", h.Update())
+	r.DELETE("/api/v2/employees/:id", h.Delete())
+	return r
+}
+
+func TestEmployee_Create(t *testing.T) {
+	t.Run("it should create the employee", func(t *testing.T) {
+		// Arrange
+		service := &employee.ServiceMock{}
+		service.On("SaveEmployee", mock.Anything, domain.Employee{CardNumberID: "E1", FirstName: "Ana", LastName: "Ruiz", WarehouseID: 2}).Return(5, nil)
+		r := newEmployeeRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/employees",
+			strings.NewReader(`{"card_number_id":"E1","first_name":"Ana","last_name":"Ruiz","warehouse_id":2}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.JSONEq(t, `{"data":{"id":5,"card_number_id":"E1","first_name":"Ana","last_name":"Ruiz","warehouse_id":2},
+			"meta":{},"links":{"self":"/api/v2/employees/5"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 409 when the card number is taken", func(t *testing.T) {
+		// Arrange
+		service := &employee.ServiceMock{}
+		service.On("SaveEmployee", mock.Anything, mock.Anything).Return(0, employee.ErrEmployeeAlreadyExists)
+		r := newEmployeeRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/employees",
+			strings.NewReader(`{"card_number_id":"E1","first_name":"Ana","last_name":"Ruiz","warehouse_id":2}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"card_number_id already exists"}`, response.Body.String())
+	})
+}
+
+func TestEmployee_Update(t *testing.T) {
+	t.Run("it should only change the fields present in the body", func(t *testing.T) {
+		// Arrange
+		stored := domain.Employee{ID: 5, CardNumberID: "E1", FirstName: "Ana", LastName: "Ruiz", WarehouseID: 2}
+		updated := stored
+		updated.WarehouseID = 3
+		service := &employee.ServiceMock{}
+		service.On("GetEmployeeByID", mock.Anything, 5).Return(stored, nil)
+		service.On("UpdateEmployee", mock.Anything, updated).Return(nil)
+		r := newEmployeeRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/employees/5", strings.NewReader(`{"warehouse_id":3}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		service.AssertExpectations(t)
+	})
+}
+
+func TestEmployee_Delete(t *testing.T) {
+	t.Run("it should return 404 when the employee does not exist", func(t *testing.T) {
+		// Arrange
+		service := &employee.ServiceMock{}
+		service.On("DeleteEmployee", mock.Anything, 5).Return(employee.ErrNotFound)
+		r := newEmployeeRouter(service)
+		request := httptest.NewRequest(http.MethodDelete, "/api/v2/employees/5", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"employee not found"}`, response.Body.String())
+	})
+}
+
+func TestEmployee_ByWarehouse(t *testing.T) {
+	newRouter := func(service employee.Service, warehouses warehouse.Service) *gin.Engine {
+		r := gin.New()
+		r.GET("/api/v2/warehouses/:id/employees", NewEmployee(service).ByWarehouse(warehouses))
+		return r
+	}
+
+	t.Run("it should list the employees of the warehouse", func(t *testing.T) {
+		// Arrange
+		service := &employee.ServiceMock{}
+		service.On("GetEmployeesByWarehouseIDs", mock.Anything, []int{2}).Return([]domain.Employee{
+			{ID: 5, CardNumberID: "E1", FirstName: "Ana", LastName: "Ruiz", WarehouseID: 2},
+		}, nil)
+		warehouses := &warehouse.ServiceMock{}
+		warehouses.On("Get", mock.Anything, 2).Return(domain.Warehouse{ID: 2}, nil)
+		r := newRouter(service, warehouses)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/2/employees", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":5,"card_number_id":"E1","first_name":"Ana","last_name":"Ruiz","warehouse_id":2}],
+			"meta":{"count":1},"links":{"self":"/api/v2/warehouses/2/employees"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the warehouse does not exist", func(t *testing.T) {
+		// Arrange
+		service := &employee.ServiceMock{}
+		warehouses := &warehouse.ServiceMock{}
+		warehouses.On("Get", mock.Anything, 9).Return(domain.Warehouse{}, warehouse.ErrNotFound)
+		r := newRouter(service, warehouses)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/9/employees", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"warehouse not found"}`, response.Body.String())
+		service.AssertNotCalled(t, "GetEmployeesByWarehouseIDs", mock.Anything, mock.Anything)
+	})
+}
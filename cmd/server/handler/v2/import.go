@@ -0,0 +1,166 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var (
+	ErrImportFileRequired = "file is required"
+	ErrInvalidDryRun      = "dry_run must be true or false"
+	ErrInvalidRows        = "%d of %d rows are invalid"
+)
+
+// ImportSummary is the result of an import whose rows were all valid.
+type ImportSummary struct {
+	Rows     int  `json:"rows"`
+	Inserted int  `json:"inserted"`
+	Updated  int  `json:"updated"`
+	DryRun   bool `json:"dry_run"`
+	// Committed is false for a dry run, whose writes are discarded.
+	Committed bool `json:"committed"`
+}
+
+// RowError lists what is wrong with the row at a line of an import file.
+type RowError struct {
+	Line   int      `json:"line" validate:"required"`
+	Errors []string `json:"errors" validate:"required"`
+}
+
+// ImportErrorResponse is the body of an import rejected because of invalid
+// rows. Nothing was saved.
+type ImportErrorResponse struct {
+	Code    string     `json:"code" validate:"required"`
+	Message string     `json:"message" validate:"required"`
+	Rows    []RowError `json:"rows" validate:"required"`
+}
+
+// importer runs the import of the file uploaded to c. Every row is decoded
+// into a request R, validated with its binding tags and converted with
+// toDomain; run saves the rows, and rowMessage describes why run rejected one.
+type importer[R any, T any] struct {
+	toDomain   func(R) T
+	run        func(ctx context.Context, items []T, opts bulk.Options) ([]bulk.Outcome, error)
+	rowMessage func(error) string
+}
+
+func (im importer[R, T]) handle(c *gin.Context) {
+	opts, ok := importOptions(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, ErrImportFileRequired)
+		return
+	}
+	format, err := bulk.FormatOf(header.Header.Get("Content-Type"), header.Filename)
+	if err != nil {
+		web.Error(c, http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+		return
+	}
+	defer file.Close()
+
+	rows, err := bulk.Decode[R](file, format)
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var rowErrors []RowError
+	items := make([]T, 0, len(rows))
+	for _, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = binding.Validator.ValidateStruct(&row.Value)
+		}
+		switch {
+		case row.Err != nil:
+			rowErrors = append(rowErrors, RowError{Line: row.Line, Errors: errorMessages(row.Err)})
+		case err != nil:
+			rowErrors = append(rowErrors, RowError{Line: row.Line, Errors: validationMessages(row.Value, err)})
+		default:
+			items = append(items, im.toDomain(row.Value))
+		}
+	}
+	if len(rowErrors) > 0 {
+		invalidRows(c, rowErrors, len(rows))
+		return
+	}
+
+	outcomes, err := im.run(c, items, opts)
+	if err != nil {
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+		return
+	}
+
+	summary := ImportSummary{Rows: len(rows), DryRun: opts.DryRun}
+	for i, o := range outcomes {
+		switch {
+		case o.Err != nil:
+			rowErrors = append(rowErrors, RowError{Line: rows[i].Line, Errors: []string{im.rowMessage(o.Err)}})
+		case o.Action == bulk.ActionInserted:
+			summary.Inserted++
+		case o.Action == bulk.ActionUpdated:
+			summary.Updated++
+		}
+	}
+	if len(rowErrors) > 0 {
+		invalidRows(c, rowErrors, len(rows))
+		return
+	}
+
+	summary.Committed = !opts.DryRun
+	web.Resource(c, http.StatusOK, summary, c.Request.URL.Path)
+}
+
+// importOptions reads the mode and dry_run query parameters. It writes a 400
+// response and returns false when one is invalid.
+func importOptions(c *gin.Context) (bulk.Options, bool) {
+	mode, err := bulk.ParseMode(c.Query("mode"))
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, bulk.ErrInvalidMode.Error())
+		return bulk.Options{}, false
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, ErrInvalidDryRun)
+		return bulk.Options{}, false
+	}
+	return bulk.Options{Mode: mode, DryRun: dryRun}, true
+}
+
+// invalidRows writes the 422 response of an import with invalid rows.
+func invalidRows(c *gin.Context, rowErrors []RowError, total int) {
+	web.Response(c, http.StatusUnprocessableEntity, ImportErrorResponse{
+		Code:    "unprocessable_entity",
+		Message: fmt.Sprintf(ErrInvalidRows, len(rowErrors), total),
+		Rows:    rowErrors,
+	})
+}
+
+// errorMessages splits an error made with errors.Join into its messages.
+func errorMessages(err error) []string {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []string{err.Error()}
+	}
+	var messages []string
+	for _, e := range joined.Unwrap() {
+		messages = append(messages, errorMessages(e)...)
+	}
+	return messages
+}
@@ -0,0 +1,236 @@
+package v2
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// uploadRequest returns a request uploading content as the file field of a
+// multipart form.
+func uploadRequest(t *testing.T, target, filename, contentType, content string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	part, err := form.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	request := httptest.NewRequest(http.MethodPost, target, &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	return request
+}
+
+const productsCSV = `description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id
+Yogurt,1,2,3,4,5,YG-1,-4,6,7,8
+Milk,1,2,3,4,5,MK-1,-4,6,7,0
+`
+
+func TestProduct_Import(t *testing.T) {
+	yogurt := storedProduct
+	yogurt.ID = 0
+	milk := yogurt
+	milk.Description, milk.ProductCode, milk.SellerID = "Milk", "MK-1", 0
+
+	t.Run("it should import every row of a CSV file", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Import", mock.Anything, []domain.Product{yogurt, milk}, bulk.Options{Mode: bulk.ModeInsert}).
+			Return([]bulk.Outcome{bulk.Inserted(1), bulk.Inserted(2)}, nil)
+		r := newProductRouter(service)
+		request := uploadRequest(t, "/api/v2/products/import", "products.csv", "text/csv", productsCSV)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"rows":2,"inserted":2,"updated":0,"dry_run":false,"committed":true},
+			"meta":{},"links":{"self":"/api/v2/products/import"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should pass the mode and dry_run of the query", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Import", mock.Anything, []domain.Product{yogurt}, bulk.Options{Mode: bulk.ModeUpsert, DryRun: true}).
+			Return([]bulk.Outcome{bulk.Updated(1)}, nil)
+		r := newProductRouter(service)
+		ndjson := `{"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":3,"length":4,"net_weight":5,` +
+			`"product_code":"YG-1","recommended_freezing_temperature":-4,"width":6,"product_type_id":7,"seller_id":8}` + "\n"
+		request := uploadRequest(t, "/api/v2/products/import?mode=upsert&dry_run=true", "products.ndjson", "", ndjson)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"rows":1,"inserted":0,"updated":1,"dry_run":true,"committed":false},
+			"meta":{},"links":{"self":"/api/v2/products/import"}}`, response.Body.String())
+	})
+
+	t.Run("it should report the invalid rows by line without saving any", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		r := newProductRouter(service)
+		file := productsCSV + "Cheese,1,2,3,4,5,,-4,6,7,8\n" + "Butter,one,2,3,4,5,BT-1,-4,6,7,8\n"
+		request := uploadRequest(t, "/api/v2/products/import", "products.csv", "text/csv", file)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"2 of 4 rows are invalid","rows":[
+			{"line":4,"errors":["product_code is required"]},
+			{"line":5,"errors":["expiration_rate must be a number"]}]}`, response.Body.String())
+		service.AssertNotCalled(t, "Import")
+	})
+
+	t.Run("it should report the rows the service rejected", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Import", mock.Anything, mock.Anything, mock.Anything).
+			Return([]bulk.Outcome{bulk.Inserted(1), bulk.Failed(product.ErrProductCodeExists)}, nil)
+		r := newProductRouter(service)
+		request := uploadRequest(t, "/api/v2/products/import", "products.csv", "text/csv", productsCSV)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"1 of 2 rows are invalid","rows":[
+			{"line":3,"errors":["product_code already exists"]}]}`, response.Body.String())
+	})
+
+	t.Run("it should reject files it cannot read", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			request  *http.Request
+			status   int
+			expected string
+		}{
+			{
+				name:     "missing file",
+				request:  httptest.NewRequest(http.MethodPost, "/api/v2/products/import", nil),
+				status:   http.StatusBadRequest,
+				expected: `{"code":"bad_request","message":"file is required"}`,
+			},
+			{
+				name:     "unknown mode",
+				request:  uploadRequest(t, "/api/v2/products/import?mode=replace", "products.csv", "text/csv", productsCSV),
+				status:   http.StatusBadRequest,
+				expected: `{"code":"bad_request","message":"mode must be insert or upsert"}`,
+			},
+			{
+				name:     "unsupported format",
+				request:  uploadRequest(t, "/api/v2/products/import", "products.xlsx", "application/octet-stream", "PK"),
+				status:   http.StatusUnsupportedMediaType,
+				expected: `{"code":"unsupported_media_type","message":"file must be CSV (text/csv) or NDJSON (application/x-ndjson)"}`,
+			},
+			{
+				name:     "unknown column",
+				request:  uploadRequest(t, "/api/v2/products/import", "products.csv", "text/csv", "product_code,netweight\nYG-1,5\n"),
+				status:   http.StatusBadRequest,
+				expected: `{"code":"bad_request","message":"unknown column \"netweight\""}`,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				response := httptest.NewRecorder()
+
+				serveHTTP(t, newProductRouter(&product.ServiceMock{}), response, tt.request)
+
+				assert.Equal(t, tt.status, response.Code)
+				assert.JSONEq(t, tt.expected, response.Body.String())
+			})
+		}
+	})
+}
+
+func TestSeller_Import(t *testing.T) {
+	t.Run("it should report a seller whose locality does not exist", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("Import", mock.Anything, []domain.Seller{{CID: 1, CompanyName: "Meli", Address: "Fake Street 123", Telephone: "555-0100", IDLocality: 9}}, bulk.Options{Mode: bulk.ModeInsert}).
+			Return([]bulk.Outcome{bulk.Failed(seller.ErrLocalityNotExists)}, nil)
+		r := newSellerRouter(service)
+		file := "cid,company_name,address,telephone,locality_id\n1,Meli,Fake Street 123,555-0100,9\n"
+		request := uploadRequest(t, "/api/v2/sellers/import", "sellers.csv", "text/csv", file)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"1 of 1 rows are invalid","rows":[
+			{"line":2,"errors":["locality_id does not exist"]}]}`, response.Body.String())
+	})
+}
+
+func TestLocality_Import(t *testing.T) {
+	t.Run("it should import every row of an NDJSON file", func(t *testing.T) {
+		// Arrange
+		service := locality.NewMockService()
+		service.On("Import", mock.Anything, []domain.Locality{{PostalCode: 1425, LocalityName: "Palermo", ProvinceName: "Buenos Aires", CountryName: "Argentina"}}, bulk.Options{Mode: bulk.ModeUpsert}).
+			Return([]bulk.Outcome{bulk.Updated(3)}, nil)
+		r := newLocalityRouter(service)
+		file := `{"postal_code":1425,"locality_name":"Palermo","province_name":"Buenos Aires","country_name":"Argentina"}`
+		request := uploadRequest(t, "/api/v2/localities/import?mode=upsert", "localities.ndjson", "application/x-ndjson", file)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"rows":1,"inserted":0,"updated":1,"dry_run":false,"committed":true},
+			"meta":{},"links":{"self":"/api/v2/localities/import"}}`, response.Body.String())
+	})
+}
+
+func TestBuyer_Import(t *testing.T) {
+	t.Run("it should report a card_number_id already stored", func(t *testing.T) {
+		// Arrange
+		service := buyer.NewBuyerService()
+		service.On("Import", mock.Anything, []domain.Buyer{{CardNumberID: "402323", FirstName: "John", LastName: "Doe"}}, bulk.Options{Mode: bulk.ModeInsert}).
+			Return([]bulk.Outcome{bulk.Failed(buyer.ErrAlreadyExists)}, nil)
+		r := newBuyerRouter(service)
+		request := uploadRequest(t, "/api/v2/buyers/import", "buyers.csv", "text/csv", "card_number_id,first_name,last_name\n402323,John,Doe\n")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"1 of 1 rows are invalid","rows":[
+			{"line":2,"errors":["card_number_id already exists"]}]}`, response.Body.String())
+	})
+}
@@ -0,0 +1,154 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrInboundOrderAlreadyExists = "order_number already exists"
+	ErrInboundEmployeeNotExists  = "employee_id does not exist"
+	ErrInboundWarehouseNotExists = "warehouse_id does not exist"
+)
+
+// InboundOrderRequest is the body of the inbound order creation request.
+type InboundOrderRequest struct {
+	OrderDate      string `json:"order_date" binding:"required,datetime=2006-01-02"`
+	OrderNumber    string `json:"order_number" binding:"required"`
+	EmployeeID     int    `json:"employee_id" binding:"required,gt=0"`
+	ProductBatchID int    `json:"product_batch_id" binding:"required,gt=0"`
+	WarehouseID    int    `json:"warehouse_id" binding:"required,gt=0"`
+}
+
+// InboundOrderReport is the number of inbound orders received by an
+// employee.
+type InboundOrderReport struct {
+	domain.Employee
+	InboundOrdersCount int `json:"inbound_orders_count"`
+}
+
+// InboundOrder contains the /inbound-orders handlers and the inbound order
+// reports of the employees.
+type InboundOrder struct {
+	inboundOrderService inboudorder.Service
+}
+
+// NewInboundOrder returns a new instance of InboundOrder.
+func NewInboundOrder(s inboudorder.Service) *InboundOrder {
+	return &InboundOrder{inboundOrderService: s}
+}
+
+// Create godoc
+// @Summary Create an inbound order
+// @Tags inbound-orders
+// @Accept json
+// @Produce json
+// @Param body body InboundOrderRequest true "Inbound order to create"
+// @Success 201 {object} web.Envelope{data=domain.InboudOrder}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /inbound-orders [post]
+func (i *InboundOrder) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req InboundOrderRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		order := domain.InboudOrder{
+			OrderDate:      req.OrderDate,
+			OrderNumber:    req.OrderNumber,
+			EmployeeID:     req.EmployeeID,
+			ProductBatchID: req.ProductBatchID,
+			WarehouseID:    req.WarehouseID,
+		}
+		id, err := i.inboundOrderService.CreateInboundOrder(c, order)
+		if err != nil {
+			switch {
+			case errors.Is(err, inboudorder.ErrInboundOrderAlreadyExists):
+				web.Error(c, http.StatusConflict, ErrInboundOrderAlreadyExists)
+			case errors.Is(err, inboudorder.ErrEmployeeDoesNotExists):
+				web.Error(c, http.StatusUnprocessableEntity, ErrInboundEmployeeNotExists)
+			case errors.Is(err, inboudorder.ErrWarehouseDoesNotExists):
+				web.Error(c, http.StatusUnprocessableEntity, ErrInboundWarehouseNotExists)
+			default:
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+
+		order.ID = id
+		created(c, order, link("/inbound-orders/%d", id))
+	}
+}
+
+// Reports godoc
+// @Summary Count the inbound orders of every employee
+// @Tags employees
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]InboundOrderReport}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /employees/inbound-order-reports [get]
+func (i *InboundOrder) Reports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reports, err := i.inboundOrderService.GetAllReports(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+
+		list := make([]InboundOrderReport, 0, len(reports))
+		for _, r := range reports {
+			list = append(list, toInboundOrderReport(r))
+		}
+		web.Collection(c, list)
+	}
+}
+
+// Report godoc
+// @Summary Count the inbound orders of an employee
+// @Tags employees
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Employee ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=InboundOrderReport}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /employees/{id}/inbound-order-report [get]
+func (i *InboundOrder) Report() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		report, err := i.inboundOrderService.GenerateReport(c, id)
+		if err != nil {
+			if errors.Is(err, inboudorder.ErrEmployeeNotFound) {
+				web.Error(c, http.StatusNotFound, ErrEmployeeNotFound)
+				return
+			}
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Report(c, toInboundOrderReport(report), link("/employees/%d/inbound-order-report", id))
+	}
+}
+
+func toInboundOrderReport(r inboudorder.Report) InboundOrderReport {
+	report := InboundOrderReport{InboundOrdersCount: r.InboudOrdersCount}
+	if r.Employee != nil {
+		report.Employee = *r.Employee
+	}
+	return report
+}
@@ -0,0 +1,100 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newInboundOrderRouter(service inboudorder.Service) *gin.Engine {
+	h := NewInboundOrder(service)
+	r := gin.New()
+	r.POST("/api/v2/inbound-orders", h.Create())
+	r.GET("/api/v2/employees/inbound-order-reports", h.Reports())
+	r.GET("/api/v2/employees/:id/inbound-order-report", h.Report())
+	return r
+}
+
+func TestInboundOrder_Create(t *testing.T) {
+	body := `{"order_date":"2026-10-18","order_number":"IO-1","employee_id":1,"product_batch_id":2,"warehouse_id":3}`
+
+	t.Run("it should keep the order date of the body", func(t *testing.T) {
+		// Arrange
+		service := &inboudorder.ServiceMock{}
+		service.On("CreateInboundOrder", mock.Anything, domain.InboudOrder{
+			OrderDate: "2026-10-18", OrderNumber: "IO-1", EmployeeID: 1, ProductBatchID: 2, WarehouseID: 3,
+		}).Return(9, nil)
+		r := newInboundOrderRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/inbound-orders", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "/api/v2/inbound-orders/9", response.Header().Get("Location"))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 when the employee does not exist", func(t *testing.T) {
+		// Arrange
+		service := &inboudorder.ServiceMock{}
+		service.On("CreateInboundOrder", mock.Anything, mock.Anything).Return(0, inboudorder.ErrEmployeeDoesNotExists)
+		r := newInboundOrderRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/inbound-orders", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"employee_id does not exist"}`, response.Body.String())
+	})
+}
+
+func TestInboundOrder_Report(t *testing.T) {
+	t.Run("it should flatten the employee into the report", func(t *testing.T) {
+		// Arrange
+		service := &inboudorder.ServiceMock{}
+		service.On("GenerateReport", mock.Anything, 1).Return(inboudorder.Report{
+			Employee:          &domain.Employee{ID: 1, CardNumberID: "E1", FirstName: "Ana", LastName: "Ruiz", WarehouseID: 3},
+			InboudOrdersCount: 4,
+		}, nil)
+		r := newInboundOrderRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/employees/1/inbound-order-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"card_number_id":"E1","first_name":"Ana","last_name":"Ruiz","warehouse_id":3,"inbound_orders_count":4},
+			"meta":{},"links":{"self":"/api/v2/employees/1/inbound-order-report"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the employee does not exist", func(t *testing.T) {
+		// Arrange
+		service := &inboudorder.ServiceMock{}
+		service.On("GenerateReport", mock.Anything, 1).Return(inboudorder.Report{}, inboudorder.ErrEmployeeNotFound)
+		r := newInboundOrderRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/employees/1/inbound-order-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"employee not found"}`, response.Body.String())
+	})
+}
@@ -0,0 +1,215 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrLocalityNotFound      = "locality not found"
+	ErrLocalityAlreadyExists = "locality already exists"
+)
+
+// LocalityRequest is the body of the locality creation request.
+type LocalityRequest struct {
+	PostalCode   int    `json:"postal_code" binding:"required,gt=0"`
+	LocalityName string `json:"locality_name" binding:"required"`
+	ProvinceName string `json:"province_name" binding:"required"`
+	CountryName  string `json:"country_name" binding:"required"`
+}
+
+// Locality contains the /localities handlers.
+type Locality struct {
+	localityService locality.Service
+}
+
+// NewLocality returns a new instance of Locality.
+func NewLocality(l locality.Service) *Locality {
+	return &Locality{localityService: l}
+}
+
+// GetAll godoc
+// @Summary List localities
+// @Tags localities
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.Locality}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /localities [get]
+func (l *Locality) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		localities, err := l.localityService.GetAll(c)
+		if err != nil && !errors.Is(err, locality.ErrNoRows) {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, localities)
+	}
+}
+
+// Get godoc
+// @Summary Get a locality
+// @Tags localities
+// @Produce json
+// @Param id path int true "Locality ID"
+// @Success 200 {object} web.Envelope{data=domain.Locality}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /localities/{id} [get]
+func (l *Locality) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		loc, err := l.localityService.GetLocalityByID(c, id)
+		if err != nil {
+			l.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, loc, link("/localities/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Create a locality
+// @Tags localities
+// @Accept json
+// @Produce json
+// @Param body body LocalityRequest true "Locality to create"
+// @Success 201 {object} web.Envelope{data=domain.Locality}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /localities [post]
+func (l *Locality) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req LocalityRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		loc := req.toLocality()
+		id, err := l.localityService.Save(c, loc)
+		if err != nil {
+			l.writeError(c, err)
+			return
+		}
+
+		loc.ID = id
+		created(c, loc, link("/localities/%d", id))
+	}
+}
+
+// SellerReports godoc
+// @Summary Count the sellers of every locality
+// @Tags localities
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.ReportSellers}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /localities/seller-reports [get]
+func (l *Locality) SellerReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reports, err := l.localityService.GetReportSellers(c, 0)
+		if err != nil && !errors.Is(err, locality.ErrNoRows) {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, reports)
+	}
+}
+
+// SellerReport godoc
+// @Summary Count the sellers of a locality
+// @Tags localities
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Locality ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=domain.ReportSellers}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /localities/{id}/seller-report [get]
+func (l *Locality) SellerReport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		reports, err := l.localityService.GetReportSellers(c, id)
+		if err != nil && !errors.Is(err, locality.ErrNoRows) {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		// The report has a row for every matching locality, even without sellers.
+		if len(reports) == 0 {
+			web.Error(c, http.StatusNotFound, ErrLocalityNotFound)
+			return
+		}
+		web.Report(c, reports[0], link("/localities/%d/seller-report", id))
+	}
+}
+
+// Import godoc
+// @Summary Import localities
+// @Description Saves every locality of a CSV or NDJSON file in one transaction. The CSV header names the fields of LocalityRequest.
+// @Description A postal_code already stored fails its row, or updates the stored locality when mode is upsert.
+// @Description When a row is invalid nothing is saved and the errors of every row are listed with their line number.
+// @Tags localities
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV (text/csv) or NDJSON (application/x-ndjson) file"
+// @Param mode query string false "insert (default) or upsert" Enums(insert, upsert)
+// @Param dry_run query bool false "Check every row without saving any"
+// @Success 200 {object} web.Envelope{data=ImportSummary}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 415 {object} web.ErrorResponse
+// @Failure 422 {object} ImportErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /localities/import [post]
+func (l *Locality) Import() gin.HandlerFunc {
+	im := importer[LocalityRequest, domain.Locality]{
+		toDomain: LocalityRequest.toLocality,
+		run:      l.localityService.Import,
+		rowMessage: func(err error) string {
+			if errors.Is(err, locality.ErrLocalityAlreadyExists) {
+				return ErrLocalityAlreadyExists
+			}
+			return err.Error()
+		},
+	}
+	return im.handle
+}
+
+// writeError maps the errors of the locality service to a response.
+func (l *Locality) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, locality.ErrLocalityNotFound):
+		web.Error(c, http.StatusNotFound, ErrLocalityNotFound)
+	case errors.Is(err, locality.ErrLocalityAlreadyExists):
+		web.Error(c, http.StatusConflict, ErrLocalityAlreadyExists)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
+
+func (r LocalityRequest) toLocality() domain.Locality {
+	return domain.Locality{
+		PostalCode:   r.PostalCode,
+		LocalityName: r.LocalityName,
+		ProvinceName: r.ProvinceName,
+		CountryName:  r.CountryName,
+	}
+}
@@ -0,0 +1,163 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func newLocalityRouter(service locality.Service) *gin.Engine {
+	h := NewLocality(service)
+	r := gin.New()
+	r.GET("/api/v2/localities", h.GetAll())
+	r.GET("/api/v2/localities/:id", h.Get())
+	r.POST("/api/v2/localities", h.Create())
+	r.POST("/api/v2/localities/import", h.Import())
+	r.GET("/api/v2/localities/seller-reports", h.SellerReports())
+	r.GET("/api/v2/localities/:id/seller-report", h.SellerReport())
+	return r
+}
+
+func TestLocality_Create(t *testing.T) {
+	t.Run("it should create the locality", func(t *testing.T) {
+		// Arrange
+		service := locality.NewMockService()
+		service.On("Save", mock.Anything, domain.Locality{PostalCode: 1000, LocalityName: "Centro", ProvinceName: "Norte", CountryName: "AR"}).Return(6, nil)
+		r := newLocalityRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/localities",
+			strings.NewReader(`{"postal_code":1000,"locality_name":"Centro","province_name":"Norte","country_name":"AR"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.JSONEq(t, `{"data":{"id":6,"postal_code":1000,"locality_name":"Centro","province_name":"Norte","country_name":"AR"},
+			"meta":{},"links":{"self":"/api/v2/localities/6"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 when the postal code is not positive", func(t *testing.T) {
+		// Arrange
+		r := newLocalityRouter(locality.NewMockService())
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/localities",
+			strings.NewReader(`{"postal_code":0,"locality_name":"Centro","province_name":"Norte","country_name":"AR"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"postal_code is required"}`, response.Body.String())
+	})
+}
+
+func TestLocality_SellerReports(t *testing.T) {
+	t.Run("it should export the reports as CSV when the Accept header asks for it", func(t *testing.T) {
+		// Arrange
+		service := locality.NewMockService()
+		service.On("GetReportSellers", mock.Anything, 0).Return([]domain.ReportSellers{
+			{Locality_id: 6, Locality_name: "Centro", Postal_code: 1000, Sellers_count: 2},
+			{Locality_id: 7, Locality_name: "Sur", Postal_code: 2000, Sellers_count: 0},
+		}, nil)
+		r := newLocalityRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/localities/seller-reports", nil)
+		request.Header.Set("Accept", "text/csv")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Regexp(t, `^attachment; filename="localities-seller-reports-\d{8}T\d{6}Z\.csv"$`, response.Header().Get("Content-Disposition"))
+		assert.Equal(t, "locality_id,locality_name,postal_code,sellers_count\n6,Centro,1000,2\n7,Sur,2000,0\n", response.Body.String())
+	})
+
+	t.Run("it should return 400 when the format is unknown", func(t *testing.T) {
+		// Arrange
+		service := locality.NewMockService()
+		service.On("GetReportSellers", mock.Anything, 0).Return([]domain.ReportSellers{}, nil)
+		r := newLocalityRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/localities/seller-reports?format=pdf", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"format must be json, csv or xlsx"}`, response.Body.String())
+	})
+}
+
+func TestLocality_SellerReport(t *testing.T) {
+	t.Run("it should return the seller count of a locality", func(t *testing.T) {
+		// Arrange
+		service := locality.NewMockService()
+		service.On("GetReportSellers", mock.Anything, 6).Return([]domain.ReportSellers{
+			{Locality_id: 6, Locality_name: "Centro", Postal_code: 1000, Sellers_count: 2},
+		}, nil)
+		r := newLocalityRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/localities/6/seller-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"locality_id":6,"locality_name":"Centro","postal_code":1000,"sellers_count":2},
+			"meta":{},"links":{"self":"/api/v2/localities/6/seller-report"}}`, response.Body.String())
+	})
+
+	t.Run("it should export the report as a one row XLSX workbook", func(t *testing.T) {
+		// Arrange
+		service := locality.NewMockService()
+		service.On("GetReportSellers", mock.Anything, 6).Return([]domain.ReportSellers{
+			{Locality_id: 6, Locality_name: "Centro", Postal_code: 1000, Sellers_count: 2},
+		}, nil)
+		r := newLocalityRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/localities/6/seller-report?format=xlsx", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		require.Equal(t, http.StatusOK, response.Code)
+		f, err := excelize.OpenReader(response.Body)
+		require.NoError(t, err)
+		defer f.Close()
+		rows, err := f.GetRows("Sheet1")
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"locality_id", "locality_name", "postal_code", "sellers_count"}, {"6", "Centro", "1000", "2"}}, rows)
+	})
+
+	t.Run("it should return 404 when the locality does not exist", func(t *testing.T) {
+		// Arrange
+		service := locality.NewMockService()
+		service.On("GetReportSellers", mock.Anything, 6).Return([]domain.ReportSellers{}, nil)
+		r := newLocalityRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/localities/6/seller-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"locality not found"}`, response.Body.String())
+	})
+}
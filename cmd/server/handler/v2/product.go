@@ -0,0 +1,1006 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/davidop97/apiGo/pkg/units"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrProductNotFound   = "product not found"
+	ErrProductCodeExists = "product_code already exists"
+	ErrSearchQuery       = "q must contain a word"
+	ErrSearchSellerID    = "seller_id must be 1 or greater"
+	ErrSearchProductType = "product_type_id must be 1 or greater"
+	ErrSearchLimit       = fmt.Sprintf("limit must be an integer between 1 and %d", product.MaxSearchLimit)
+
+	ErrProductRecordDate     = "from, to and as_of must be dates formatted as 2006-01-02"
+	ErrProductRecordRange    = "from must not be after to"
+	ErrProductRecordPredates = "last_update_date predates the latest record of the product"
+	ErrProductRecordNotFound = "the product has no record on or before the date"
+	ErrPriceUpdateSelector   = "select the products by seller_id, product_type_id or product_ids"
+	ErrPriceUpdateMargin     = "the value of a margin rule must be between 0 and 100"
+	ErrPriceUpdateNoProducts = "no product matches the selector"
+	ErrPriceUpdateRejected   = "%d of %d products cannot be repriced"
+	ErrInvalidCurrency       = "currency must be an ISO 4217 code"
+	ErrCurrencyRateNotFound  = "there is no rate for the currency"
+	ErrPriceOutOfRange       = "the price is out of range"
+	ErrInvalidUnits          = "units must be metric or imperial"
+	ErrProductConverted      = "%s must be greater than 0 once converted to %s"
+)
+
+// ProductResponse is the representation of a product. It differs from
+// domain.Product in the net_weight field name, and in its dimensions and
+// weight, written in the units of the system the request asked for.
+type ProductResponse struct {
+	ID                             int     `json:"id"`
+	Description                    string  `json:"description"`
+	ExpirationRate                 float32 `json:"expiration_rate"`
+	FreezingRate                   float32 `json:"freezing_rate"`
+	Height                         float32 `json:"height"`
+	Length                         float32 `json:"length"`
+	NetWeight                      float32 `json:"net_weight"`
+	ProductCode                    string  `json:"product_code"`
+	RecommendedFreezingTemperature float32 `json:"recommended_freezing_temperature"`
+	Width                          float32 `json:"width"`
+	ProductTypeID                  int     `json:"product_type_id"`
+	SellerID                       int     `json:"seller_id"`
+	// DimensionUnit is the unit of height, length and width.
+	DimensionUnit units.LengthUnit `json:"dimension_unit" swaggertype:"string" enums:"cm,in"`
+	// WeightUnit is the unit of net_weight.
+	WeightUnit units.MassUnit `json:"weight_unit" swaggertype:"string" enums:"kg,lb"`
+	// Volume is height * length * width, in VolumeUnit.
+	Volume     float64          `json:"volume"`
+	VolumeUnit units.VolumeUnit `json:"volume_unit" swaggertype:"string" enums:"m3,ft3"`
+	// DeletedAt is set while the product is soft deleted.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ProductSearchResult is a product found by a search, with its relevance.
+type ProductSearchResult struct {
+	ProductResponse
+	// Score is the relevance of the product to the query: the higher, the
+	// more relevant.
+	Score float64 `json:"score"`
+}
+
+// ProductRequest is the body of the product creation and update requests.
+// Height, length and width are in dimension_unit and net_weight in
+// weight_unit, centimetres and kilograms by default.
+type ProductRequest struct {
+	Description                    string  `json:"description" binding:"required"`
+	ExpirationRate                 float32 `json:"expiration_rate" binding:"required,gt=0,lte=100"`
+	FreezingRate                   float32 `json:"freezing_rate" binding:"required,gt=0,lte=100"`
+	Height                         float32 `json:"height" binding:"required,gt=0"`
+	Length                         float32 `json:"length" binding:"required,gt=0"`
+	NetWeight                      float32 `json:"net_weight" binding:"required,gt=0"`
+	ProductCode                    string  `json:"product_code" binding:"required,max=100"`
+	RecommendedFreezingTemperature float32 `json:"recommended_freezing_temperature" binding:"required,lte=100"`
+	Width                          float32 `json:"width" binding:"required,gt=0"`
+	ProductTypeID                  int     `json:"product_type_id" binding:"required,gt=0"`
+	SellerID                       int     `json:"seller_id" binding:"gte=0"`
+	DimensionUnit                  string  `json:"dimension_unit,omitempty" binding:"omitempty,oneof=cm m in" enums:"cm,m,in"`
+	WeightUnit                     string  `json:"weight_unit,omitempty" binding:"omitempty,oneof=g kg lb" enums:"g,kg,lb"`
+}
+
+// ProductPatch documents the body of the product update request: every field of
+// ProductRequest is optional and the missing ones keep their stored value.
+type ProductPatch struct {
+	Description                    string  `json:"description,omitempty"`
+	ExpirationRate                 float32 `json:"expiration_rate,omitempty"`
+	FreezingRate                   float32 `json:"freezing_rate,omitempty"`
+	Height                         float32 `json:"height,omitempty"`
+	Length                         float32 `json:"length,omitempty"`
+	NetWeight                      float32 `json:"net_weight,omitempty"`
+	ProductCode                    string  `json:"product_code,omitempty"`
+	RecommendedFreezingTemperature float32 `json:"recommended_freezing_temperature,omitempty"`
+	Width                          float32 `json:"width,omitempty"`
+	ProductTypeID                  int     `json:"product_type_id,omitempty"`
+	SellerID                       int     `json:"seller_id,omitempty"`
+	// DimensionUnit and WeightUnit are the units of the fields of the body
+	// only: the stored values are kept whatever their unit.
+	DimensionUnit string `json:"dimension_unit,omitempty" enums:"cm,m,in"`
+	WeightUnit    string `json:"weight_unit,omitempty" enums:"g,kg,lb"`
+}
+
+// ProductRecordRequest is the body of the product record creation request.
+// The product is the one of the path.
+type ProductRecordRequest struct {
+	LastUpdateDate string       `json:"last_update_date" binding:"required,datetime=2006-01-02"`
+	PurchasePrice  money.Amount `json:"purchase_price" binding:"required,gt=0" swaggertype:"string" example:"100.00"`
+	SalePrice      money.Amount `json:"sale_price" binding:"required,gt=0" swaggertype:"string" example:"120.00"`
+	// Currency is the ISO 4217 code of the prices, USD by default.
+	Currency string `json:"currency" example:"USD"`
+}
+
+// PriceUpdateRequest is the body of the bulk price update request. The
+// products matching every selector field given get a new record dated
+// last_update_date, with the purchase price of their latest record and the
+// sale price of the rule: absolute sets the sale price to value, percentage
+// raises it by value percent and margin sets it over the purchase price so
+// that the margin is value percent.
+type PriceUpdateRequest struct {
+	SellerID       int          `json:"seller_id" binding:"gte=0"`
+	ProductTypeID  int          `json:"product_type_id" binding:"gte=0"`
+	ProductIDs     []int        `json:"product_ids" binding:"dive,gt=0"`
+	Rule           string       `json:"rule" binding:"required,oneof=absolute percentage margin" enums:"absolute,percentage,margin"`
+	Value          money.Amount `json:"value" swaggertype:"string" example:"10"`
+	LastUpdateDate string       `json:"last_update_date" binding:"required,datetime=2006-01-02"`
+}
+
+// PriceChangeResponse is the new record of a product. record_id is missing
+// from a dry run.
+type PriceChangeResponse struct {
+	ProductID     int            `json:"product_id"`
+	RecordID      int            `json:"record_id,omitempty"`
+	PurchasePrice money.Amount   `json:"purchase_price" swaggertype:"string" example:"100.00"`
+	OldSalePrice  money.Amount   `json:"old_sale_price" swaggertype:"string" example:"120.00"`
+	NewSalePrice  money.Amount   `json:"new_sale_price" swaggertype:"string" example:"132.00"`
+	Currency      money.Currency `json:"currency" swaggertype:"string" example:"USD"`
+}
+
+// ProductError tells why a product was rejected.
+type ProductError struct {
+	ProductID int    `json:"product_id" validate:"required"`
+	Error     string `json:"error" validate:"required"`
+}
+
+// PriceUpdateErrorResponse is the body of a rejected price update. Products
+// lists the products that cannot be repriced, when they are the reason. No
+// record was saved.
+type PriceUpdateErrorResponse struct {
+	Code     string         `json:"code" validate:"required"`
+	Message  string         `json:"message" validate:"required"`
+	Products []ProductError `json:"products,omitempty"`
+}
+
+// Product contains the /products handlers.
+type Product struct {
+	productService product.Service
+}
+
+// NewProduct returns a new instance of Product.
+func NewProduct(s product.Service) *Product {
+	return &Product{productService: s}
+}
+
+// GetAll godoc
+// @Summary List products
+// @Tags products
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Param include_deleted query bool false "Include the deleted products"
+// @Param units query string false "System of units of the dimensions, weights and volumes, metric by default" Enums(metric, imperial)
+// @Success 200 {object} web.Envelope{data=[]ProductResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products [get]
+func (p *Product) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+		system, ok := queryUnits(c)
+		if !ok {
+			return
+		}
+
+		products, err := p.productService.GetAll(ctx)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+
+		list := make([]ProductResponse, 0, len(products))
+		for _, prod := range products {
+			list = append(list, toProductResponse(prod, system))
+		}
+		web.Collection(c, list)
+	}
+}
+
+// BySeller godoc
+// @Summary List the products of a seller
+// @Tags sellers
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Seller ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Param units query string false "System of units of the dimensions, weights and volumes, metric by default" Enums(metric, imperial)
+// @Success 200 {object} web.Envelope{data=[]ProductResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sellers/{id}/products [get]
+func (p *Product) BySeller(sellers seller.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+		system, ok := queryUnits(c)
+		if !ok {
+			return
+		}
+		if _, err := sellers.GetSellerByID(c, id); !parentFound(c, err, seller.ErrNotFound, ErrSellerNotFound) {
+			return
+		}
+
+		products, err := p.productService.GetBySeller(c, id)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+
+		list := make([]ProductResponse, 0, len(products))
+		for _, prod := range products {
+			list = append(list, toProductResponse(prod, system))
+		}
+		web.Collection(c, list)
+	}
+}
+
+// Search godoc
+// @Summary Search products
+// @Description Searches the words of q in the description and product_code of the products, the most relevant first.
+// @Description A word matches the words starting with it and, from four letters on, the ones differing by a typo (two from eight letters on).
+// @Description Every word of q must match.
+// @Tags products
+// @Produce json
+// @Param q query string true "Words to search"
+// @Param seller_id query int false "Only the products of this seller"
+// @Param product_type_id query int false "Only the products of this product type"
+// @Param limit query int false "Number of products returned, 20 by default" minimum(1) maximum(100)
+// @Param units query string false "System of units of the dimensions, weights and volumes, metric by default" Enums(metric, imperial)
+// @Success 200 {object} web.Envelope{data=[]ProductSearchResult}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/search [get]
+func (p *Product) Search() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		q, ok := searchQuery(c)
+		if !ok {
+			return
+		}
+		system, ok := queryUnits(c)
+		if !ok {
+			return
+		}
+
+		results, err := p.productService.Search(c, q)
+		if err != nil {
+			if errors.Is(err, product.ErrEmptyQuery) {
+				web.Error(c, http.StatusBadRequest, ErrSearchQuery)
+				return
+			}
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+
+		list := make([]ProductSearchResult, 0, len(results))
+		for _, r := range results {
+			list = append(list, ProductSearchResult{ProductResponse: toProductResponse(r.Product, system), Score: r.Score})
+		}
+		web.Collection(c, list)
+	}
+}
+
+// searchQuery reads the q, seller_id, product_type_id and limit query
+// parameters. It writes a 400 response and returns false when one is invalid.
+func searchQuery(c *gin.Context) (product.SearchQuery, bool) {
+	q := product.SearchQuery{Text: c.Query("q")}
+	params := []struct {
+		name    string
+		dst     *int
+		max     int
+		message string
+	}{
+		{"seller_id", &q.SellerID, 0, ErrSearchSellerID},
+		{"product_type_id", &q.ProductTypeID, 0, ErrSearchProductType},
+		{"limit", &q.Limit, product.MaxSearchLimit, ErrSearchLimit},
+	}
+	for _, param := range params {
+		raw, ok := c.GetQuery(param.name)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || (param.max > 0 && n > param.max) {
+			web.Error(c, http.StatusBadRequest, param.message)
+			return product.SearchQuery{}, false
+		}
+		*param.dst = n
+	}
+	return q, true
+}
+
+// Get godoc
+// @Summary Get a product
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param include_deleted query bool false "Include the product if it is deleted"
+// @Param units query string false "System of units of the dimensions, weights and volumes, metric by default" Enums(metric, imperial)
+// @Success 200 {object} web.Envelope{data=ProductResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id} [get]
+func (p *Product) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+		system, ok := queryUnits(c)
+		if !ok {
+			return
+		}
+
+		prod, err := p.productService.Get(ctx, id)
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, toProductResponse(prod, system), link("/products/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Create a product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param body body ProductRequest true "Product to create"
+// @Param units query string false "System of units of the response, metric by default" Enums(metric, imperial)
+// @Success 201 {object} web.Envelope{data=ProductResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products [post]
+func (p *Product) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		system, ok := queryUnits(c)
+		if !ok {
+			return
+		}
+		var req ProductRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		prod := req.toProduct()
+		if field, unit, ok := storable(prod); !ok {
+			web.Error(c, http.StatusUnprocessableEntity, ErrProductConverted, field, unit)
+			return
+		}
+		id, err := p.productService.Save(c, prod)
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+
+		prod.ID = id
+		created(c, toProductResponse(prod, system), link("/products/%d", id))
+	}
+}
+
+// Update godoc
+// @Summary Update a product
+// @Description Only the fields present in the body are changed.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param body body ProductPatch true "Fields to update"
+// @Param units query string false "System of units of the response, metric by default" Enums(metric, imperial)
+// @Success 200 {object} web.Envelope{data=ProductResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id} [patch]
+func (p *Product) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		system, ok := queryUnits(c)
+		if !ok {
+			return
+		}
+
+		current, err := p.productService.Get(c, id)
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+
+		req, err := patchRequest(c, current)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		if !bind(c, &req) {
+			return
+		}
+
+		prod := req.toProduct()
+		if field, unit, ok := storable(prod); !ok {
+			web.Error(c, http.StatusUnprocessableEntity, ErrProductConverted, field, unit)
+			return
+		}
+		prod.ID = id
+		if err := p.productService.Update(c, prod); err != nil {
+			p.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, toProductResponse(prod, system), link("/products/%d", id))
+	}
+}
+
+// Delete godoc
+// @Summary Delete a product
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 204
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id} [delete]
+func (p *Product) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		if err := p.productService.Delete(c, id); err != nil {
+			p.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Restore godoc
+// @Summary Restore a deleted product
+// @Description Undoes the deletion of a product. A product that is not deleted is returned as it is.
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param units query string false "System of units of the response, metric by default" Enums(metric, imperial)
+// @Success 200 {object} web.Envelope{data=ProductResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id}/restore [post]
+func (p *Product) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		system, ok := queryUnits(c)
+		if !ok {
+			return
+		}
+
+		prod, err := p.productService.Restore(c, id)
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, toProductResponse(prod, system), link("/products/%d", id))
+	}
+}
+
+// CreateRecord godoc
+// @Summary Record the prices of a product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param body body ProductRecordRequest true "Record to create"
+// @Success 201 {object} web.Envelope{data=domain.ProductRecord}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id}/records [post]
+func (p *Product) CreateRecord() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		var req ProductRecordRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		currency := money.DefaultCurrency
+		if req.Currency != "" {
+			var err error
+			if currency, err = money.ParseCurrency(req.Currency); err != nil {
+				web.Error(c, http.StatusUnprocessableEntity, ErrInvalidCurrency)
+				return
+			}
+		}
+
+		recordID, err := p.productService.CreateProductRecord(c, domain.ProductRecordCreate{
+			LastUpdate:    req.LastUpdateDate,
+			PurchasePrice: req.PurchasePrice,
+			SalePrice:     req.SalePrice,
+			Currency:      currency,
+			ProductID:     id,
+		})
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+
+		record := domain.ProductRecord{
+			ID:            recordID,
+			LastUpdate:    req.LastUpdateDate,
+			PurchasePrice: req.PurchasePrice,
+			SalePrice:     req.SalePrice,
+			Currency:      currency,
+			ProductID:     id,
+		}
+		created(c, record, link("/products/%d/records", id))
+	}
+}
+
+// Records godoc
+// @Summary List the price history of a product
+// @Description The records are sorted by last_update_date, the oldest first, with their margin ((sale - purchase) / sale), markup ((sale - purchase) / purchase) and the changes of their prices since the previous record, null on the first one.
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param from query string false "Only the records dated on or after this date" format(date)
+// @Param to query string false "Only the records dated on or before this date" format(date)
+// @Param currency query string false "ISO 4217 code to convert the prices to, at the current currency rates"
+// @Success 200 {object} web.Envelope{data=[]domain.ProductRecordHistory}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id}/records [get]
+func (p *Product) Records() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+		from, ok := queryDate(c, "from", "")
+		if !ok {
+			return
+		}
+		to, ok := queryDate(c, "to", "")
+		if !ok {
+			return
+		}
+		if from != "" && to != "" && from > to {
+			web.Error(c, http.StatusBadRequest, ErrProductRecordRange)
+			return
+		}
+
+		history, err := p.productService.ProductRecords(c, id, domain.ProductRecordFilter{From: from, To: to, Currency: money.Currency(c.Query("currency"))})
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+		web.Collection(c, history)
+	}
+}
+
+// Price godoc
+// @Summary Get the prices of a product on a date
+// @Description Returns the record in force on as_of, the latest one dated on or before it, with its margin, markup and price changes.
+// @Tags products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param as_of query string false "Date of the prices, today by default" format(date)
+// @Param currency query string false "ISO 4217 code to convert the prices to, at the current currency rates"
+// @Success 200 {object} web.Envelope{data=domain.ProductRecordHistory}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id}/price [get]
+func (p *Product) Price() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+		asOf, ok := queryDate(c, "as_of", time.Now().UTC().Format(time.DateOnly))
+		if !ok {
+			return
+		}
+
+		record, err := p.productService.PriceAsOf(c, id, asOf, money.Currency(c.Query("currency")))
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, record, link("/products/%d/price?as_of=%s", id, asOf))
+	}
+}
+
+// queryDate reads the date of the query parameter name, or def when it is
+// missing. It writes a 400 response and returns false when it is not a date.
+func queryDate(c *gin.Context, name, def string) (string, bool) {
+	raw, ok := c.GetQuery(name)
+	if !ok {
+		return def, true
+	}
+	if _, err := time.Parse(time.DateOnly, raw); err != nil {
+		web.Error(c, http.StatusBadRequest, ErrProductRecordDate)
+		return "", false
+	}
+	return raw, true
+}
+
+// UpdatePrices godoc
+// @Summary Record new prices for many products
+// @Description Creates a record for every product matching the selector fields, in one transaction. If a product cannot be repriced nothing is saved and the response lists why.
+// @Description With dry_run=true the new prices are returned without saving them.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param dry_run query bool false "Preview the new prices without saving them"
+// @Param body body PriceUpdateRequest true "Selector and rule"
+// @Success 200 {object} web.Envelope{data=[]PriceChangeResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 422 {object} PriceUpdateErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/records/bulk [post]
+func (p *Product) UpdatePrices() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidDryRun)
+			return
+		}
+		var req PriceUpdateRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		changes, err := p.productService.UpdatePrices(c, product.PriceUpdate{
+			Selector:   product.PriceSelector{SellerID: req.SellerID, ProductTypeID: req.ProductTypeID, ProductIDs: req.ProductIDs},
+			Rule:       product.PriceRule{Type: req.Rule, Value: req.Value},
+			LastUpdate: req.LastUpdateDate,
+			DryRun:     dryRun,
+		})
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+
+		var rejected []ProductError
+		list := make([]PriceChangeResponse, 0, len(changes))
+		for _, change := range changes {
+			if change.Err != nil {
+				rejected = append(rejected, ProductError{ProductID: change.ProductID, Error: priceUpdateMessage(change.Err)})
+				continue
+			}
+			list = append(list, PriceChangeResponse{
+				ProductID:     change.ProductID,
+				RecordID:      change.RecordID,
+				PurchasePrice: change.PurchasePrice,
+				OldSalePrice:  change.OldSalePrice,
+				NewSalePrice:  change.NewSalePrice,
+				Currency:      change.Currency,
+			})
+		}
+		if len(rejected) > 0 {
+			web.Response(c, http.StatusUnprocessableEntity, PriceUpdateErrorResponse{
+				Code:     "unprocessable_entity",
+				Message:  fmt.Sprintf(ErrPriceUpdateRejected, len(rejected), len(changes)),
+				Products: rejected,
+			})
+			return
+		}
+		web.Collection(c, list)
+	}
+}
+
+// priceUpdateMessage describes why a product of a price update was rejected.
+func priceUpdateMessage(err error) string {
+	switch {
+	case errors.Is(err, product.ErrRecordPredatesLatest):
+		return ErrProductRecordPredates
+	case errors.Is(err, money.ErrOutOfRange):
+		return ErrPriceOutOfRange
+	}
+	return err.Error()
+}
+
+// RecordReports godoc
+// @Summary Count the records of every product
+// @Description With format=ndjson, or Accept: application/x-ndjson, the counts are streamed a JSON object per line as they are read.
+// @Description A stream that fails after its first line ends with an error line.
+// @Tags products
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx, ndjson)
+// @Success 200 {object} web.Envelope{data=[]domain.ProductRecordGet}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/record-reports [get]
+func (p *Product) RecordReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, err := web.Stream(c, func(ctx context.Context, fn func(domain.ProductRecordGet) error) error {
+			return p.productService.EachProductRecord(ctx, 0, fn)
+		})
+		if ok {
+			if err != nil {
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+
+		reports, err := p.productService.GetProductRecord(c, 0)
+		if err != nil && !errors.Is(err, product.ErrNotFound) {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, reports)
+	}
+}
+
+// RecordReport godoc
+// @Summary Count the records of a product
+// @Tags products
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Product ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=domain.ProductRecordGet}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/{id}/record-report [get]
+func (p *Product) RecordReport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		reports, err := p.productService.GetProductRecord(c, id)
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+		if len(reports) == 0 {
+			web.Error(c, http.StatusNotFound, ErrProductNotFound)
+			return
+		}
+		web.Report(c, reports[0], link("/products/%d/record-report", id))
+	}
+}
+
+// Import godoc
+// @Summary Import products
+// @Description Saves every product of a CSV or NDJSON file in one transaction. The CSV header names the fields of ProductRequest.
+// @Description A product_code already stored fails its row, or updates the stored product when mode is upsert.
+// @Description When a row is invalid nothing is saved and the errors of every row are listed with their line number.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV (text/csv) or NDJSON (application/x-ndjson) file"
+// @Param mode query string false "insert (default) or upsert" Enums(insert, upsert)
+// @Param dry_run query bool false "Check every row without saving any"
+// @Success 200 {object} web.Envelope{data=ImportSummary}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 415 {object} web.ErrorResponse
+// @Failure 422 {object} ImportErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /products/import [post]
+func (p *Product) Import() gin.HandlerFunc {
+	im := importer[ProductRequest, domain.Product]{
+		toDomain: ProductRequest.toProduct,
+		run:      p.productService.Import,
+		rowMessage: func(err error) string {
+			switch {
+			case errors.Is(err, product.ErrProductCodeExists):
+				return ErrProductCodeExists
+			case errors.Is(err, product.ErrProductTypeNotFound):
+				return ErrProductTypeNotExists
+			}
+			return err.Error()
+		},
+	}
+	return im.handle
+}
+
+// writeError maps the errors of the product service to a response.
+func (p *Product) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, product.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrProductNotFound)
+	case errors.Is(err, product.ErrProductCodeExists):
+		web.Error(c, http.StatusConflict, ErrProductCodeExists)
+	case errors.Is(err, product.ErrProductTypeNotFound):
+		web.Error(c, http.StatusUnprocessableEntity, ErrProductTypeNotExists)
+	case errors.Is(err, product.ErrRecordPredatesLatest):
+		web.Error(c, http.StatusConflict, ErrProductRecordPredates)
+	case errors.Is(err, product.ErrRecordNotFound):
+		web.Error(c, http.StatusNotFound, ErrProductRecordNotFound)
+	case errors.Is(err, product.ErrEmptySelector):
+		web.Error(c, http.StatusUnprocessableEntity, ErrPriceUpdateSelector)
+	case errors.Is(err, product.ErrInvalidPriceRule):
+		web.Error(c, http.StatusUnprocessableEntity, ErrPriceUpdateMargin)
+	case errors.Is(err, product.ErrNoProductsSelected):
+		web.Error(c, http.StatusUnprocessableEntity, ErrPriceUpdateNoProducts)
+	case errors.Is(err, product.ErrInvalidCurrency):
+		web.Error(c, http.StatusUnprocessableEntity, ErrInvalidCurrency)
+	case errors.Is(err, product.ErrCurrencyRateNotFound):
+		web.Error(c, http.StatusUnprocessableEntity, ErrCurrencyRateNotFound)
+	case errors.Is(err, money.ErrOutOfRange):
+		web.Error(c, http.StatusUnprocessableEntity, ErrPriceOutOfRange)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
+
+// toProduct returns the product of the request, with its dimensions and
+// weight converted to centimetres and kilograms.
+func (r ProductRequest) toProduct() domain.Product {
+	length, mass := r.units()
+	return domain.Product{
+		Description:    r.Description,
+		ExpirationRate: r.ExpirationRate,
+		FreezingRate:   r.FreezingRate,
+		Height:         toStored(length.ToCentimetres, r.Height),
+		Length:         toStored(length.ToCentimetres, r.Length),
+		Netweight:      toStored(mass.ToKilograms, r.NetWeight),
+		ProductCode:    r.ProductCode,
+		RecomFreezTemp: r.RecommendedFreezingTemperature,
+		Width:          toStored(length.ToCentimetres, r.Width),
+		ProductTypeID:  r.ProductTypeID,
+		SellerID:       r.SellerID,
+	}
+}
+
+// storable returns the field of p, and the unit it is stored in, that its
+// conversion took to 0 or less, or to infinity, as the tiniest values in
+// other units round to 0 and the largest overflow.
+func storable(p domain.Product) (field, unit string, ok bool) {
+	for _, f := range []struct {
+		field, unit string
+		value       float32
+	}{
+		{"height", string(units.Centimetre), p.Height},
+		{"length", string(units.Centimetre), p.Length},
+		{"width", string(units.Centimetre), p.Width},
+		{"net_weight", string(units.Kilogram), p.Netweight},
+	} {
+		if !(f.value > 0) || math.IsInf(float64(f.value), 1) {
+			return f.field, f.unit, false
+		}
+	}
+	return "", "", true
+}
+
+// units returns the units of the request, the default ones for the units
+// missing or unknown, which the binding tags reject.
+func (r ProductRequest) units() (units.LengthUnit, units.MassUnit) {
+	length, err := units.ParseLengthUnit(r.DimensionUnit)
+	if err != nil {
+		length = units.Centimetre
+	}
+	mass, err := units.ParseMassUnit(r.WeightUnit)
+	if err != nil {
+		mass = units.Kilogram
+	}
+	return length, mass
+}
+
+// productToRequest returns the request that saves p again, with its
+// dimensions and weight in the units of the request.
+func productToRequest(p domain.Product, r ProductRequest) ProductRequest {
+	length, mass := r.units()
+	return ProductRequest{
+		Description:                    p.Description,
+		ExpirationRate:                 p.ExpirationRate,
+		FreezingRate:                   p.FreezingRate,
+		Height:                         toStored(length.FromCentimetres, p.Height),
+		Length:                         toStored(length.FromCentimetres, p.Length),
+		NetWeight:                      toStored(mass.FromKilograms, p.Netweight),
+		ProductCode:                    p.ProductCode,
+		RecommendedFreezingTemperature: p.RecomFreezTemp,
+		Width:                          toStored(length.FromCentimetres, p.Width),
+		ProductTypeID:                  p.ProductTypeID,
+		SellerID:                       p.SellerID,
+		DimensionUnit:                  r.DimensionUnit,
+		WeightUnit:                     r.WeightUnit,
+	}
+}
+
+// patchRequest returns the request the body of the product update request is
+// bound over: the stored product in the units of the body, so that the fields
+// the body leaves out keep their value. A malformed body is left to bind.
+func patchRequest(c *gin.Context, current domain.Product) (ProductRequest, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return ProductRequest{}, err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var bodyUnits ProductRequest
+	_ = json.Unmarshal(body, &bodyUnits)
+	return productToRequest(current, ProductRequest{DimensionUnit: bodyUnits.DimensionUnit, WeightUnit: bodyUnits.WeightUnit}), nil
+}
+
+// toStored converts v, rounded to 4 decimals so that a value converted back
+// and forth keeps its digits.
+func toStored(convert func(float64) float64, v float32) float32 {
+	return float32(units.Round(convert(float64(v)), 4))
+}
+
+// toProductResponse returns the representation of p in the units of system.
+func toProductResponse(p domain.Product, system units.System) ProductResponse {
+	length, mass, volume := system.Length(), system.Mass(), system.Volume()
+	return ProductResponse{
+		ID:                             p.ID,
+		Description:                    p.Description,
+		ExpirationRate:                 p.ExpirationRate,
+		FreezingRate:                   p.FreezingRate,
+		Height:                         toStored(length.FromCentimetres, p.Height),
+		Length:                         toStored(length.FromCentimetres, p.Length),
+		NetWeight:                      toStored(mass.FromKilograms, p.Netweight),
+		ProductCode:                    p.ProductCode,
+		RecommendedFreezingTemperature: p.RecomFreezTemp,
+		Width:                          toStored(length.FromCentimetres, p.Width),
+		ProductTypeID:                  p.ProductTypeID,
+		SellerID:                       p.SellerID,
+		DimensionUnit:                  length,
+		WeightUnit:                     mass,
+		Volume:                         units.Round(volume.FromCubicMetres(p.Volume()), 6),
+		VolumeUnit:                     volume,
+		DeletedAt:                      p.DeletedAt,
+	}
+}
+
+// queryUnits reads the units query parameter. It writes a 400 response and
+// returns false when it is not a system of units.
+func queryUnits(c *gin.Context) (units.System, bool) {
+	system, err := units.ParseSystem(c.Query("units"))
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, ErrInvalidUnits)
+		return "", false
+	}
+	return system, true
+}
@@ -0,0 +1,704 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newProductRouter(service product.Service) *gin.Engine {
+	h := NewProduct(service)
+	r := gin.New()
+	r.GET("/api/v2/products", h.GetAll())
+	r.GET("/api/v2/products/search", h.Search())
+	r.GET("/api/v2/products/:id", h.Get())
+	r.POST("/api/v2/products", h.Create())
+	r.POST("/api/v2/products/import", h.Import())
+	r.PATCH("/api/v2/products/:id", h.Update())
+	r.DELETE("/api/v2/products/:id", h.Delete())
+	r.POST("/api/v2/products/records/bulk", h.UpdatePrices())
+	r.POST("/api/v2/products/:id/records", h.CreateRecord())
+	r.GET("/api/v2/products/:id/records", h.Records())
+	r.GET("/api/v2/products/:id/price", h.Price())
+	r.GET("/api/v2/products/record-reports", h.RecordReports())
+	r.GET("/api/v2/products/:id/record-report", h.RecordReport())
+	return r
+}
+
+var storedProduct = domain.Product{
+	ID:             1,
+	Description:    "Yogurt",
+	ExpirationRate: 1,
+	FreezingRate:   2,
+	Height:         3,
+	Length:         4,
+	Netweight:      5,
+	ProductCode:    "YG-1",
+	RecomFreezTemp: -4,
+	Width:          6,
+	ProductTypeID:  7,
+	SellerID:       8,
+}
+
+func TestProduct_Get(t *testing.T) {
+	t.Run("it should use snake_case field names", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Get", mock.Anything, 1).Return(storedProduct, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":3,"length":4,
+			"net_weight":5,"product_code":"YG-1","recommended_freezing_temperature":-4,"width":6,"product_type_id":7,"seller_id":8,
+			"dimension_unit":"cm","weight_unit":"kg","volume":0.000072,"volume_unit":"m3"},
+			"meta":{},"links":{"self":"/api/v2/products/1"}}`, response.Body.String())
+	})
+
+	t.Run("it should write the dimensions and weight in imperial units", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Get", mock.Anything, 1).Return(storedProduct, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1?units=imperial", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":1.1811,"length":1.5748,
+			"net_weight":11.0231,"product_code":"YG-1","recommended_freezing_temperature":-4,"width":2.3622,"product_type_id":7,"seller_id":8,
+			"dimension_unit":"in","weight_unit":"lb","volume":0.002543,"volume_unit":"ft3"},
+			"meta":{},"links":{"self":"/api/v2/products/1"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 for an unknown system of units", func(t *testing.T) {
+		// Arrange
+		r := newProductRouter(&product.ServiceMock{})
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1?units=nautical", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"units must be metric or imperial"}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the product does not exist", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Get", mock.Anything, 9).Return(domain.Product{}, product.ErrNotFound)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/9", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"product not found"}`, response.Body.String())
+	})
+}
+
+func TestProduct_Search(t *testing.T) {
+	t.Run("it should return the products found with their score", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Search", mock.Anything, product.SearchQuery{Text: "yog", SellerID: 8, ProductTypeID: 7, Limit: 5}).
+			Return([]product.SearchResult{{Product: storedProduct, Score: 1.75}}, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/search?q=yog&seller_id=8&product_type_id=7&limit=5", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":3,"length":4,
+			"net_weight":5,"product_code":"YG-1","recommended_freezing_temperature":-4,"width":6,"product_type_id":7,"seller_id":8,
+			"dimension_unit":"cm","weight_unit":"kg","volume":0.000072,"volume_unit":"m3","score":1.75}],"meta":{"count":1},
+			"links":{"self":"/api/v2/products/search?q=yog&seller_id=8&product_type_id=7&limit=5"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 for a query without words", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Search", mock.Anything, product.SearchQuery{Text: "-"}).Return([]product.SearchResult(nil), product.ErrEmptyQuery)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/search?q=-", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"`+ErrSearchQuery+`"}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 for an invalid filter", func(t *testing.T) {
+		for query, message := range map[string]string{
+			"seller_id=0":          ErrSearchSellerID,
+			"product_type_id=type": ErrSearchProductType,
+			"limit=101":            ErrSearchLimit,
+		} {
+			// Arrange
+			r := newProductRouter(&product.ServiceMock{})
+			request := httptest.NewRequest(http.MethodGet, "/api/v2/products/search?q=yog&"+query, nil)
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, response.Code, query)
+			assert.JSONEq(t, `{"code":"bad_request","message":"`+message+`"}`, response.Body.String(), query)
+		}
+	})
+}
+
+func TestProduct_Create(t *testing.T) {
+	t.Run("it should return 409 when the product code is taken", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		toSave := storedProduct
+		toSave.ID = 0
+		service.On("Save", mock.Anything, toSave).Return(0, product.ErrProductCodeExists)
+		r := newProductRouter(service)
+		body := `{"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":3,"length":4,"net_weight":5,
+			"product_code":"YG-1","recommended_freezing_temperature":-4,"width":6,"product_type_id":7,"seller_id":8}`
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"product_code already exists"}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 when the product type does not exist", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(0, product.ErrProductTypeNotFound)
+		r := newProductRouter(service)
+		body := `{"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":3,"length":4,"net_weight":5,
+			"product_code":"YG-1","recommended_freezing_temperature":-4,"width":6,"product_type_id":99,"seller_id":8}`
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"product_type_id does not exist"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when a rate is out of range", func(t *testing.T) {
+		// Arrange
+		r := newProductRouter(&product.ServiceMock{})
+		body := `{"description":"Yogurt","expiration_rate":101,"freezing_rate":2,"height":3,"length":4,"net_weight":5,
+			"product_code":"YG-1","recommended_freezing_temperature":-4,"width":6,"product_type_id":7}`
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"expiration_rate must be less than or equal to 100"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when a dimension is 0 once converted", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		r := newProductRouter(service)
+		body := `{"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":0.0000001,"length":4,"net_weight":5,
+			"product_code":"YG-1","recommended_freezing_temperature":-4,"width":6,"product_type_id":7,"dimension_unit":"m"}`
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"height must be greater than 0 once converted to cm"}`, response.Body.String())
+		service.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProduct_Update(t *testing.T) {
+	t.Run("it should only change the fields present in the body", func(t *testing.T) {
+		// Arrange
+		updated := storedProduct
+		updated.Netweight = 9
+		service := &product.ServiceMock{}
+		service.On("Get", mock.Anything, 1).Return(storedProduct, nil)
+		service.On("Update", mock.Anything, updated).Return(nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/products/1", strings.NewReader(`{"net_weight":9}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should convert the fields of the body from its units", func(t *testing.T) {
+		// Arrange
+		updated := storedProduct
+		updated.Height = 2.54
+		updated.Netweight = 0.9072
+		service := &product.ServiceMock{}
+		service.On("Get", mock.Anything, 1).Return(storedProduct, nil)
+		service.On("Update", mock.Anything, updated).Return(nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/products/1",
+			strings.NewReader(`{"height":1,"dimension_unit":"in","net_weight":2,"weight_unit":"lb"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 for an unknown unit", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Get", mock.Anything, 1).Return(storedProduct, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/products/1", strings.NewReader(`{"height":1,"dimension_unit":"ft"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		service.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should return 422 when the weight is 0 once converted", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("Get", mock.Anything, 1).Return(storedProduct, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/products/1", strings.NewReader(`{"net_weight":0.00001,"weight_unit":"g"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"net_weight must be greater than 0 once converted to kg"}`, response.Body.String())
+		service.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProduct_CreateRecord(t *testing.T) {
+	t.Run("it should record the prices of the product in the path", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("CreateProductRecord", mock.Anything, domain.ProductRecordCreate{
+			LastUpdate: "2026-10-01", PurchasePrice: amount("10.5"), SalePrice: money.FromInt(15), Currency: "EUR", ProductID: 1,
+		}).Return(3, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products/1/records",
+			strings.NewReader(`{"last_update_date":"2026-10-01","purchase_price":"10.5","sale_price":"15","currency":"eur"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.JSONEq(t, `{"data":{"id":3,"last_update_date":"2026-10-01","purchase_price":"10.50","sale_price":"15.00","currency":"EUR","product_id":1},
+			"meta":{},"links":{"self":"/api/v2/products/1/records"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 when the date is not a calendar date", func(t *testing.T) {
+		// Arrange
+		r := newProductRouter(&product.ServiceMock{})
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products/1/records",
+			strings.NewReader(`{"last_update_date":"01/10/2026","purchase_price":"10","sale_price":"15"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"last_update_date must match the format YYYY-MM-DD"}`, response.Body.String())
+	})
+
+	t.Run("it should return 409 when the record predates the latest one", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("CreateProductRecord", mock.Anything, mock.Anything).Return(0, product.ErrRecordPredatesLatest)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products/1/records",
+			strings.NewReader(`{"last_update_date":"2026-09-01","purchase_price":"10","sale_price":"15"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"last_update_date predates the latest record of the product"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when the currency is not an ISO 4217 code", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products/1/records",
+			strings.NewReader(`{"last_update_date":"2026-10-01","purchase_price":"10","sale_price":"15","currency":"EURO"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"currency must be an ISO 4217 code"}`, response.Body.String())
+		service.AssertNotCalled(t, "CreateProductRecord", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProduct_UpdatePrices(t *testing.T) {
+	body := `{"seller_id":14,"rule":"percentage","value":"8","last_update_date":"2026-11-01"}`
+	update := product.PriceUpdate{
+		Selector:   product.PriceSelector{SellerID: 14},
+		Rule:       product.PriceRule{Type: product.PriceRulePercentage, Value: money.FromInt(8)},
+		LastUpdate: "2026-11-01",
+	}
+
+	t.Run("it should return the new records of the products", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("UpdatePrices", mock.Anything, update).Return([]product.PriceChange{
+			{ProductID: 1, RecordID: 21, PurchasePrice: money.FromInt(10), OldSalePrice: money.FromInt(15), NewSalePrice: amount("16.2"), Currency: "USD"},
+		}, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products/records/bulk", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"product_id":1,"record_id":21,"purchase_price":"10.00","old_sale_price":"15.00","new_sale_price":"16.20","currency":"USD"}],
+			"meta":{"count":1},"links":{"self":"/api/v2/products/records/bulk"}}`, response.Body.String())
+	})
+
+	t.Run("it should preview the new prices of a dry run", func(t *testing.T) {
+		// Arrange
+		dryRun := update
+		dryRun.DryRun = true
+		service := &product.ServiceMock{}
+		service.On("UpdatePrices", mock.Anything, dryRun).Return([]product.PriceChange{
+			{ProductID: 1, PurchasePrice: money.FromInt(10), OldSalePrice: money.FromInt(15), NewSalePrice: amount("16.2"), Currency: "USD"},
+		}, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products/records/bulk?dry_run=true", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"product_id":1,"purchase_price":"10.00","old_sale_price":"15.00","new_sale_price":"16.20","currency":"USD"}],
+			"meta":{"count":1},"links":{"self":"/api/v2/products/records/bulk?dry_run=true"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 with the products that cannot be repriced", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("UpdatePrices", mock.Anything, update).Return([]product.PriceChange{
+			{ProductID: 1, PurchasePrice: money.FromInt(10), OldSalePrice: money.FromInt(15), NewSalePrice: amount("16.2"), Currency: "USD"},
+			{ProductID: 2, Err: product.ErrNoRecordToReprice},
+			{ProductID: 3, Err: product.ErrRecordPredatesLatest},
+		}, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/products/records/bulk", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"2 of 3 products cannot be repriced","products":[
+			{"product_id":2,"error":"product has no record to reprice"},
+			{"product_id":3,"error":"last_update_date predates the latest record of the product"}]}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 for an invalid selector or rule", func(t *testing.T) {
+		for body, message := range map[string]string{
+			`{"rule":"percentage","value":"8","last_update_date":"2026-11-01"}`:            "select the products by seller_id, product_type_id or product_ids",
+			`{"seller_id":14,"rule":"double","value":"8","last_update_date":"2026-11-01"}`: "rule is invalid",
+		} {
+			// Arrange
+			service := &product.ServiceMock{}
+			service.On("UpdatePrices", mock.Anything, mock.Anything).Return([]product.PriceChange(nil), product.ErrEmptySelector)
+			r := newProductRouter(service)
+			request := httptest.NewRequest(http.MethodPost, "/api/v2/products/records/bulk", strings.NewReader(body))
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code, body)
+			assert.JSONEq(t, `{"code":"unprocessable_entity","message":"`+message+`"}`, response.Body.String(), body)
+		}
+	})
+}
+
+var recordHistory = domain.ProductRecordHistory{
+	ProductRecord: domain.ProductRecord{ID: 3, LastUpdate: "2026-10-01", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(16), Currency: "USD", ProductID: 1},
+	Margin:        0.375,
+	Markup:        0.6,
+}
+
+func TestProduct_Records(t *testing.T) {
+	t.Run("it should list the records of the product within the dates", func(t *testing.T) {
+		// Arrange
+		change := money.FromInt(-2)
+		record := recordHistory
+		record.PurchasePriceChange, record.SalePriceChange = &change, &change
+		service := &product.ServiceMock{}
+		service.On("ProductRecords", mock.Anything, 1, domain.ProductRecordFilter{From: "2026-09-01", To: "2026-10-31"}).
+			Return([]domain.ProductRecordHistory{record}, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1/records?from=2026-09-01&to=2026-10-31", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":3,"last_update_date":"2026-10-01","purchase_price":"10.00","sale_price":"16.00","currency":"USD","product_id":1,
+			"margin":0.375,"markup":0.6,"purchase_price_change":"-2.00","sale_price_change":"-2.00"}],
+			"meta":{"count":1},"links":{"self":"/api/v2/products/1/records?from=2026-09-01&to=2026-10-31"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 for invalid dates", func(t *testing.T) {
+		for query, message := range map[string]string{
+			"from=2026-13-01":               "from, to and as_of must be dates formatted as 2006-01-02",
+			"from=2026-10-02&to=2026-10-01": "from must not be after to",
+		} {
+			// Arrange
+			r := newProductRouter(&product.ServiceMock{})
+			request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1/records?"+query, nil)
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, response.Code, query)
+			assert.JSONEq(t, `{"code":"bad_request","message":"`+message+`"}`, response.Body.String(), query)
+		}
+	})
+
+	t.Run("it should return 404 when the product does not exist", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("ProductRecords", mock.Anything, 9, domain.ProductRecordFilter{}).Return([]domain.ProductRecordHistory(nil), product.ErrNotFound)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/9/records", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestProduct_Price(t *testing.T) {
+	t.Run("it should return the record in force on the date", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("PriceAsOf", mock.Anything, 1, "2026-10-15", money.Currency("")).Return(recordHistory, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1/price?as_of=2026-10-15", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":3,"last_update_date":"2026-10-01","purchase_price":"10.00","sale_price":"16.00","currency":"USD","product_id":1,
+			"margin":0.375,"markup":0.6,"purchase_price_change":null,"sale_price_change":null},
+			"meta":{},"links":{"self":"/api/v2/products/1/price?as_of=2026-10-15"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the product has no record on the date", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("PriceAsOf", mock.Anything, 1, mock.Anything, mock.Anything).Return(domain.ProductRecordHistory{}, product.ErrRecordNotFound)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1/price", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"the product has no record on or before the date"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when the currency has no rate", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("PriceAsOf", mock.Anything, 1, mock.Anything, money.Currency("GBP")).Return(domain.ProductRecordHistory{}, product.ErrCurrencyRateNotFound)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1/price?currency=GBP", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"there is no rate for the currency"}`, response.Body.String())
+	})
+}
+
+func TestProduct_RecordReport(t *testing.T) {
+	t.Run("it should list the reports of every product", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("GetProductRecord", mock.Anything, 0).Return([]domain.ProductRecordGet{
+			{ProductID: 1, Description: "Yogurt", RecordCount: 2},
+		}, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/record-reports", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"product_id":1,"description":"Yogurt","record_count":2}],
+			"meta":{"count":1},"links":{"self":"/api/v2/products/record-reports"}}`, response.Body.String())
+	})
+
+	t.Run("it should return the report of one product", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("GetProductRecord", mock.Anything, 1).Return([]domain.ProductRecordGet{
+			{ProductID: 1, Description: "Yogurt", RecordCount: 2},
+		}, nil)
+		r := newProductRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/products/1/record-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"product_id":1,"description":"Yogurt","record_count":2},
+			"meta":{},"links":{"self":"/api/v2/products/1/record-report"}}`, response.Body.String())
+	})
+}
+
+// amount parses s, an amount the tests know to be valid.
+func amount(s string) money.Amount {
+	a, err := money.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func TestProduct_BySeller(t *testing.T) {
+	newRouter := func(service product.Service, sellers seller.Service) *gin.Engine {
+		r := gin.New()
+		r.GET("/api/v2/sellers/:id/products", NewProduct(service).BySeller(sellers))
+		return r
+	}
+
+	t.Run("it should list the products of the seller", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		service.On("GetBySeller", mock.Anything, 8).Return([]domain.Product{storedProduct}, nil)
+		sellers := &seller.ServiceMock{}
+		sellers.On("GetSellerByID", mock.Anything, 8).Return(domain.Seller{ID: 8}, nil)
+		r := newRouter(service, sellers)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sellers/8/products", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"description":"Yogurt","expiration_rate":1,"freezing_rate":2,"height":3,"length":4,
+			"net_weight":5,"product_code":"YG-1","recommended_freezing_temperature":-4,"width":6,"product_type_id":7,"seller_id":8,
+			"dimension_unit":"cm","weight_unit":"kg","volume":0.000072,"volume_unit":"m3"}],
+			"meta":{"count":1},"links":{"self":"/api/v2/sellers/8/products"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the seller does not exist", func(t *testing.T) {
+		// Arrange
+		service := &product.ServiceMock{}
+		sellers := &seller.ServiceMock{}
+		sellers.On("GetSellerByID", mock.Anything, 9).Return(domain.Seller{}, seller.ErrNotFound)
+		r := newRouter(service, sellers)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sellers/9/products", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"seller not found"}`, response.Body.String())
+		service.AssertNotCalled(t, "GetBySeller", mock.Anything, mock.Anything)
+	})
+}
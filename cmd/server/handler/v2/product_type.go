@@ -0,0 +1,204 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrProductTypeNotFound      = "product type not found"
+	ErrProductTypeAlreadyExists = "description already exists"
+	ErrProductTypeIncorrectData = "description must not be blank"
+	ErrProductTypeInUse         = "product type is used by products or sections"
+	// ErrProductTypeNotExists answers the products and the sections whose
+	// product_type_id does not exist.
+	ErrProductTypeNotExists = "product_type_id does not exist"
+)
+
+// ProductTypeRequest is the body of the product type creation and update
+// requests.
+type ProductTypeRequest struct {
+	Description string `json:"description" binding:"required"`
+}
+
+// ProductTypePatch documents the body of the product type update request:
+// the missing description keeps its stored value.
+type ProductTypePatch struct {
+	Description string `json:"description,omitempty"`
+}
+
+// ProductType contains the /product-types handlers.
+type ProductType struct {
+	productTypeService producttype.Service
+}
+
+// NewProductType returns a new instance of ProductType.
+func NewProductType(s producttype.Service) *ProductType {
+	return &ProductType{productTypeService: s}
+}
+
+// GetAll godoc
+// @Summary List product types
+// @Tags product-types
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.ProductType}
+// @Failure 500 {object} web.ErrorResponse
+// @Router /product-types [get]
+func (p *ProductType) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		types, err := p.productTypeService.GetAll(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, types)
+	}
+}
+
+// Get godoc
+// @Summary Get a product type
+// @Tags product-types
+// @Produce json
+// @Param id path int true "Product type ID"
+// @Success 200 {object} web.Envelope{data=domain.ProductType}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /product-types/{id} [get]
+func (p *ProductType) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		pt, err := p.productTypeService.Get(c, id)
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, pt, link("/product-types/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Create a product type
+// @Tags product-types
+// @Accept json
+// @Produce json
+// @Param body body ProductTypeRequest true "Product type to create"
+// @Success 201 {object} web.Envelope{data=domain.ProductType}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /product-types [post]
+func (p *ProductType) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ProductTypeRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		pt := domain.ProductType{Description: req.Description}
+		id, err := p.productTypeService.Save(c, pt)
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+
+		pt.ID = id
+		created(c, pt, link("/product-types/%d", id))
+	}
+}
+
+// Update godoc
+// @Summary Update a product type
+// @Description Only the fields present in the body are changed.
+// @Tags product-types
+// @Accept json
+// @Produce json
+// @Param id path int true "Product type ID"
+// @Param body body ProductTypePatch true "Fields to update"
+// @Success 200 {object} web.Envelope{data=domain.ProductType}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /product-types/{id} [patch]
+func (p *ProductType) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		current, err := p.productTypeService.Get(c, id)
+		if err != nil {
+			p.writeError(c, err)
+			return
+		}
+
+		req := ProductTypeRequest{Description: current.Description}
+		if !bind(c, &req) {
+			return
+		}
+
+		pt := domain.ProductType{ID: id, Description: req.Description}
+		if err := p.productTypeService.Update(c, pt); err != nil {
+			p.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, pt, link("/product-types/%d", id))
+	}
+}
+
+// Delete godoc
+// @Summary Delete a product type
+// @Description A product type that products or sections have cannot be deleted.
+// @Tags product-types
+// @Produce json
+// @Param id path int true "Product type ID"
+// @Success 204
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /product-types/{id} [delete]
+func (p *ProductType) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		if err := p.productTypeService.Delete(c, id); err != nil {
+			p.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// writeError maps the errors of the product type service to a response.
+func (p *ProductType) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, producttype.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrProductTypeNotFound)
+	case errors.Is(err, producttype.ErrDuplicateDescription):
+		web.Error(c, http.StatusConflict, ErrProductTypeAlreadyExists)
+	case errors.Is(err, producttype.ErrInUse):
+		web.Error(c, http.StatusConflict, ErrProductTypeInUse)
+	case errors.Is(err, producttype.ErrIncorrectData):
+		web.Error(c, http.StatusUnprocessableEntity, ErrProductTypeIncorrectData)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
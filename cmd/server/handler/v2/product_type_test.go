@@ -0,0 +1,165 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newProductTypeRouter(service producttype.Service) *gin.Engine {
+	h := NewProductType(service)
+	r := gin.New()
+	r.GET("/api/v2/product-types", h.GetAll())
+	r.GET("/api/v2/product-types/:id", h.Get())
+	r.POST("/api/v2/product-types", h.Create())
+	r.PATCH("/api/v2/product-types/:id", h.Update())
+	r.DELETE("/api/v2/product-types/:id", h.Delete())
+	return r
+}
+
+func TestProductType_GetAll(t *testing.T) {
+	t.Run("it should wrap the product types in an envelope", func(t *testing.T) {
+		// Arrange
+		service := &producttype.ServiceMock{}
+		service.On("GetAll", mock.Anything).Return([]domain.ProductType{{ID: 1, Description: "Dairy"}}, nil)
+		r := newProductTypeRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/product-types", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"description":"Dairy"}],"meta":{"count":1},"links":{"self":"/api/v2/product-types"}}`, response.Body.String())
+	})
+}
+
+func TestProductType_Get(t *testing.T) {
+	t.Run("it should return 404 when the product type does not exist", func(t *testing.T) {
+		// Arrange
+		service := &producttype.ServiceMock{}
+		service.On("Get", mock.Anything, 9).Return(domain.ProductType{}, producttype.ErrNotFound)
+		r := newProductTypeRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/product-types/9", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"product type not found"}`, response.Body.String())
+	})
+}
+
+func TestProductType_Create(t *testing.T) {
+	t.Run("it should create the product type", func(t *testing.T) {
+		// Arrange
+		service := &producttype.ServiceMock{}
+		service.On("Save", mock.Anything, domain.ProductType{Description: "Dairy"}).Return(4, nil)
+		r := newProductTypeRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-types", strings.NewReader(`{"description":"Dairy"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "/api/v2/product-types/4", response.Header().Get("Location"))
+		assert.JSONEq(t, `{"data":{"id":4,"description":"Dairy"},"meta":{},"links":{"self":"/api/v2/product-types/4"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 409 when the description is taken", func(t *testing.T) {
+		// Arrange
+		service := &producttype.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(0, producttype.ErrDuplicateDescription)
+		r := newProductTypeRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-types", strings.NewReader(`{"description":"Dairy"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"description already exists"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when the description is missing", func(t *testing.T) {
+		// Arrange
+		service := &producttype.ServiceMock{}
+		r := newProductTypeRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/product-types", strings.NewReader(`{}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"description is required"}`, response.Body.String())
+		service.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductType_Update(t *testing.T) {
+	t.Run("it should change the description", func(t *testing.T) {
+		// Arrange
+		service := &producttype.ServiceMock{}
+		service.On("Get", mock.Anything, 1).Return(domain.ProductType{ID: 1, Description: "Dairy"}, nil)
+		service.On("Update", mock.Anything, domain.ProductType{ID: 1, Description: "Dairy and Eggs"}).Return(nil)
+		r := newProductTypeRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/product-types/1", strings.NewReader(`{"description":"Dairy and Eggs"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"description":"Dairy and Eggs"},"meta":{},"links":{"self":"/api/v2/product-types/1"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+}
+
+func TestProductType_Delete(t *testing.T) {
+	t.Run("it should return 409 when products or sections have the type", func(t *testing.T) {
+		// Arrange
+		service := &producttype.ServiceMock{}
+		service.On("Delete", mock.Anything, 1).Return(producttype.ErrInUse)
+		r := newProductTypeRouter(service)
+		request := httptest.NewRequest(http.MethodDelete, "/api/v2/product-types/1", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"product type is used by products or sections"}`, response.Body.String())
+	})
+
+	t.Run("it should delete the product type", func(t *testing.T) {
+		// Arrange
+		service := &producttype.ServiceMock{}
+		service.On("Delete", mock.Anything, 1).Return(nil)
+		r := newProductTypeRouter(service)
+		request := httptest.NewRequest(http.MethodDelete, "/api/v2/product-types/1", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+}
@@ -0,0 +1,167 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrPurchaseOrderAlreadyExists = "purchase order already exists"
+	ErrPurchaseBuyerNotExists     = "buyer_id does not exist"
+	ErrPurchaseRecordNotExists    = "product_record_id does not exist"
+)
+
+// PurchaseOrderRequest is the body of the purchase order creation request.
+type PurchaseOrderRequest struct {
+	OrderNumber     string `json:"order_number" binding:"required"`
+	OrderDate       string `json:"order_date" binding:"required,datetime=2006-01-02"`
+	TrackingCode    string `json:"tracking_code" binding:"required"`
+	BuyerID         int    `json:"buyer_id" binding:"required,gt=0"`
+	ProductRecordID int    `json:"product_record_id" binding:"required,gt=0"`
+	OrderStatusID   int    `json:"order_status_id" binding:"required,gt=0"`
+}
+
+// PurchaseOrder contains the /purchase-orders handlers and the purchase
+// order reports of the buyers.
+type PurchaseOrder struct {
+	purchaseOrderService purchase_order.Service
+}
+
+// NewPurchaseOrder returns a new instance of PurchaseOrder.
+func NewPurchaseOrder(s purchase_order.Service) *PurchaseOrder {
+	return &PurchaseOrder{purchaseOrderService: s}
+}
+
+// Create godoc
+// @Summary Create a purchase order
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Param body body PurchaseOrderRequest true "Purchase order to create"
+// @Success 201 {object} web.Envelope{data=domain.PurchaseOrder}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /purchase-orders [post]
+func (p *PurchaseOrder) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req PurchaseOrderRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		order := domain.PurchaseOrder{
+			OrderNumber:     req.OrderNumber,
+			OrderDate:       req.OrderDate,
+			TrackingCode:    req.TrackingCode,
+			BuyerID:         req.BuyerID,
+			ProductRecordID: req.ProductRecordID,
+			OrderStatusID:   req.OrderStatusID,
+		}
+		id, err := p.purchaseOrderService.Save(c, order)
+		if err != nil {
+			switch {
+			case errors.Is(err, purchase_order.ErrPurchaseOrderAlreadyExists):
+				web.Error(c, http.StatusConflict, ErrPurchaseOrderAlreadyExists)
+			case errors.Is(err, purchase_order.ErrBuyerIDNotExists):
+				web.Error(c, http.StatusUnprocessableEntity, ErrPurchaseBuyerNotExists)
+			case errors.Is(err, purchase_order.ErrProductsRecordIDNotExits):
+				web.Error(c, http.StatusUnprocessableEntity, ErrPurchaseRecordNotExists)
+			default:
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+
+		order.ID = id
+		created(c, order, link("/purchase-orders/%d", id))
+	}
+}
+
+// ByBuyer godoc
+// @Summary List the purchase orders of a buyer
+// @Tags buyers
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Buyer ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.PurchaseOrder}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers/{id}/purchase-orders [get]
+func (p *PurchaseOrder) ByBuyer(buyers buyer.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+		if _, err := buyers.Get(c, id); !parentFound(c, err, buyer.ErrNotFound, ErrBuyerNotFound) {
+			return
+		}
+
+		orders, err := p.purchaseOrderService.GetByBuyerIDs(c, []int{id})
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, orders)
+	}
+}
+
+// Reports godoc
+// @Summary Count the purchase orders of every buyer
+// @Tags buyers
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.PurchaseOrdersByBuyer}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers/purchase-order-reports [get]
+func (p *PurchaseOrder) Reports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reports, err := p.purchaseOrderService.PurchaseOrdersByBuyer(c, 0)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, reports)
+	}
+}
+
+// Report godoc
+// @Summary Count the purchase orders of a buyer
+// @Tags buyers
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Buyer ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=domain.PurchaseOrdersByBuyer}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /buyers/{id}/purchase-order-report [get]
+func (p *PurchaseOrder) Report() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		reports, err := p.purchaseOrderService.PurchaseOrdersByBuyer(c, id)
+		if err != nil && !errors.Is(err, purchase_order.ErrBuyerIDNotExists) {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		if len(reports) == 0 {
+			web.Error(c, http.StatusNotFound, ErrBuyerNotFound)
+			return
+		}
+		web.Report(c, reports[0], link("/buyers/%d/purchase-order-report", id))
+	}
+}
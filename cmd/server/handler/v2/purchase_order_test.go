@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPurchaseOrderRouter(service purchase_order.Service) *gin.Engine {
+	h := NewPurchaseOrder(service)
+	r := gin.New()
+	r.POST("/api/v2/purchase-orders", h.Create())
+	r.GET("/api/v2/buyers/purchase-order-reports", h.Reports())
+	r.GET("/api/v2/buyers/:id/purchase-order-report", h.Report())
+	return r
+}
+
+func TestPurchaseOrder_Create(t *testing.T) {
+	body := `{"order_number":"PO-1","order_date":"2026-10-18","tracking_code":"T1","buyer_id":1,"product_record_id":2,"order_status_id":1}`
+
+	t.Run("it should return 422 when the product record does not exist", func(t *testing.T) {
+		// Arrange
+		service := &purchase_order.ServiceMock{}
+		service.On("Save", mock.Anything, domain.PurchaseOrder{
+			OrderNumber: "PO-1", OrderDate: "2026-10-18", TrackingCode: "T1", BuyerID: 1, ProductRecordID: 2, OrderStatusID: 1,
+		}).Return(0, purchase_order.ErrProductsRecordIDNotExits)
+		r := newPurchaseOrderRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/purchase-orders", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"product_record_id does not exist"}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+}
+
+func TestPurchaseOrder_Reports(t *testing.T) {
+	t.Run("it should return an empty collection when there are no buyers", func(t *testing.T) {
+		// Arrange
+		service := &purchase_order.ServiceMock{}
+		service.On("PurchaseOrdersByBuyer", mock.Anything, 0).Return([]domain.PurchaseOrdersByBuyer(nil), nil)
+		r := newPurchaseOrderRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/buyers/purchase-order-reports", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[],"meta":{"count":0},"links":{"self":"/api/v2/buyers/purchase-order-reports"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the buyer does not exist", func(t *testing.T) {
+		// Arrange
+		service := &purchase_order.ServiceMock{}
+		service.On("PurchaseOrdersByBuyer", mock.Anything, 4).Return([]domain.PurchaseOrdersByBuyer(nil), purchase_order.ErrBuyerIDNotExists)
+		r := newPurchaseOrderRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/buyers/4/purchase-order-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"buyer not found"}`, response.Body.String())
+	})
+}
+
+func TestPurchaseOrder_ByBuyer(t *testing.T) {
+	newRouter := func(service purchase_order.Service, buyers buyer.Service) *gin.Engine {
+		r := gin.New()
+		r.GET("/api/v2/buyers/:id/purchase-orders", NewPurchaseOrder(service).ByBuyer(buyers))
+		return r
+	}
+
+	t.Run("it should list the purchase orders of the buyer", func(t *testing.T) {
+		// Arrange
+		service := &purchase_order.ServiceMock{}
+		service.On("GetByBuyerIDs", mock.Anything, []int{1}).Return([]domain.PurchaseOrder{
+			{ID: 3, OrderNumber: "PO-1", OrderDate: "2026-10-18", TrackingCode: "T1", BuyerID: 1, ProductRecordID: 2, OrderStatusID: 1},
+		}, nil)
+		buyers := &buyer.BuyerServiceMock{}
+		buyers.On("Get", mock.Anything, 1).Return(domain.Buyer{ID: 1}, nil)
+		r := newRouter(service, buyers)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/buyers/1/purchase-orders", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":3,"order_number":"PO-1","order_date":"2026-10-18","tracking_code":"T1","buyer_id":1,
+			"product_record_id":2,"order_status_id":1}],"meta":{"count":1},"links":{"self":"/api/v2/buyers/1/purchase-orders"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the buyer does not exist", func(t *testing.T) {
+		// Arrange
+		service := &purchase_order.ServiceMock{}
+		buyers := &buyer.BuyerServiceMock{}
+		buyers.On("Get", mock.Anything, 9).Return(domain.Buyer{}, buyer.ErrNotFound)
+		r := newRouter(service, buyers)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/buyers/9/purchase-orders", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"buyer not found"}`, response.Body.String())
+		service.AssertNotCalled(t, "GetByBuyerIDs", mock.Anything, mock.Anything)
+	})
+}
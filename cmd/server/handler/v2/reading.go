@@ -0,0 +1,295 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/telemetry"
+	"github.com/davidop97/apiGo/pkg/lineproto"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+// maxReadings is the most readings a single request may send.
+const maxReadings = 5000
+
+var (
+	ErrReadingsMissing     = "temperature or readings is required"
+	ErrReadingsAmbiguous   = "send either a single reading or readings, not both"
+	ErrReadingsTooMany     = fmt.Sprintf("at most %d readings may be sent at once", maxReadings)
+	ErrReadingsTemperature = "temperature must be a finite number"
+	ErrReadingsRange       = fmt.Sprintf("temperature must be between %.2f and %.2f", -telemetry.MaxTemperature, telemetry.MaxTemperature)
+	ErrReadingsFuture      = fmt.Sprintf("recorded_at must be at most %s after now", telemetry.MaxClockSkew)
+	ErrReadingsMeasurement = fmt.Sprintf("the measurement of point %%d must be %s", temperatureMeasurement)
+	ErrReadingsNoValue     = "point %d has no temperature or value field"
+	ErrReadingsSensor      = "the sensor of point %d must be at most 64 characters long"
+	ErrReadingsMediaType   = "the readings must be sent as application/json or text/plain line protocol"
+	ErrInvalidPrecision    = "precision must be ns, us, ms or s"
+	ErrInvalidFrom         = "from must be an RFC 3339 time"
+	ErrInvalidTo           = "to must be an RFC 3339 time"
+	ErrInvalidReadingRange = "from must be before to"
+	ErrInvalidBucket       = fmt.Sprintf("bucket must be a duration of whole seconds, e.g. 5m, splitting the range in at most %d buckets", telemetry.MaxBuckets)
+)
+
+// temperatureMeasurement is the only measurement of the line protocol the
+// readings are read from.
+const temperatureMeasurement = "temperature"
+
+// precisions are the units of the timestamps of the line protocol.
+var precisions = map[string]time.Duration{
+	"":   time.Nanosecond,
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+}
+
+// ReadingRequest is a reading of a sensor of a section. recorded_at is the
+// time the reading is received when missing.
+type ReadingRequest struct {
+	Sensor      string     `json:"sensor,omitempty" binding:"max=64"`
+	Temperature *float64   `json:"temperature" binding:"required" example:"-18.5"`
+	RecordedAt  *time.Time `json:"recorded_at,omitempty"`
+}
+
+// ReadingsRequest is the body of the readings ingestion request: a single
+// reading, or a batch of them in readings.
+type ReadingsRequest struct {
+	Sensor      string           `json:"sensor,omitempty" binding:"max=64"`
+	Temperature *float64         `json:"temperature,omitempty" example:"-18.5"`
+	RecordedAt  *time.Time       `json:"recorded_at,omitempty"`
+	Readings    []ReadingRequest `json:"readings,omitempty" binding:"dive"`
+}
+
+// Telemetry contains the /sections/{id}/readings handlers.
+type Telemetry struct {
+	telemetryService telemetry.Service
+}
+
+// NewTelemetry returns a new instance of Telemetry.
+func NewTelemetry(s telemetry.Service) *Telemetry {
+	return &Telemetry{telemetryService: s}
+}
+
+// Ingest godoc
+// @Summary Ingest the temperature readings of a section
+// @Description Stores the readings of the sensors of a section: a JSON reading, a JSON batch in readings, or the
+// @Description InfluxDB line protocol as text/plain, one temperature point per line, other measurements being
+// @Description rejected with 400, with a temperature or value field, an optional sensor tag and an optional
+// @Description timestamp in precision units, e.g.
+// @Description `temperature,sensor=north value=-18.5 1697025600000000000`.
+// @Description The latest reading sets the current temperature of the section, rounded. A reading below the minimum
+// @Description temperature of the section starts an excursion, announced by a section.temperature_excursion_started
+// @Description event, which ends, with a section.temperature_excursion_ended event, once a reading is a degree above
+// @Description the minimum. Readings older than the latest one stored are kept for the history only. A temperature
+// @Description beyond ±9999.99, or a reading recorded more than 5 minutes after now, is rejected with 400, and every
+// @Description change of the current temperature is recorded in the audit log.
+// @Tags sections
+// @Accept json,plain
+// @Produce json
+// @Param id path int true "Section ID"
+// @Param precision query string false "Unit of the timestamps of the line protocol" Enums(ns, us, ms, s) default(ns)
+// @Param body body ReadingsRequest true "Reading or readings to ingest"
+// @Success 201 {object} web.Envelope{data=telemetry.Ingested}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 415 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections/{id}/readings [post]
+func (h *Telemetry) Ingest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		var readings []domain.SectionReading
+		// A body without a media type is JSON, as for the other endpoints
+		switch c.ContentType() {
+		case "application/json", "":
+			readings, ok = jsonReadings(c)
+		case "text/plain":
+			readings, ok = lineReadings(c)
+		default:
+			web.Error(c, http.StatusUnsupportedMediaType, ErrReadingsMediaType)
+			return
+		}
+		if !ok {
+			return
+		}
+		if len(readings) > maxReadings {
+			web.Error(c, http.StatusUnprocessableEntity, ErrReadingsTooMany)
+			return
+		}
+
+		ingested, err := h.telemetryService.Ingest(c, id, readings)
+		if err != nil {
+			switch {
+			case errors.Is(err, telemetry.ErrSectionNotFound):
+				web.Error(c, http.StatusNotFound, ErrSectionNotFound)
+			case errors.Is(err, telemetry.ErrEmpty):
+				web.Error(c, http.StatusUnprocessableEntity, ErrReadingsMissing)
+			case errors.Is(err, telemetry.ErrInvalidTemperature):
+				web.Error(c, http.StatusUnprocessableEntity, ErrReadingsTemperature)
+			case errors.Is(err, telemetry.ErrTemperatureOutOfRange):
+				web.Error(c, http.StatusBadRequest, ErrReadingsRange)
+			case errors.Is(err, telemetry.ErrFutureReading):
+				web.Error(c, http.StatusBadRequest, ErrReadingsFuture)
+			default:
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+		created(c, ingested, link("/sections/%d/readings", id))
+	}
+}
+
+// jsonReadings reads a JSON reading or batch of readings. It writes an error
+// response and returns false when the body is not one.
+func jsonReadings(c *gin.Context) ([]domain.SectionReading, bool) {
+	var req ReadingsRequest
+	if !bind(c, &req) {
+		return nil, false
+	}
+	single := req.Temperature != nil
+	switch {
+	case single && len(req.Readings) > 0:
+		web.Error(c, http.StatusUnprocessableEntity, ErrReadingsAmbiguous)
+		return nil, false
+	case single:
+		return []domain.SectionReading{ReadingRequest{Sensor: req.Sensor, Temperature: req.Temperature, RecordedAt: req.RecordedAt}.toReading()}, true
+	case len(req.Readings) == 0:
+		web.Error(c, http.StatusUnprocessableEntity, ErrReadingsMissing)
+		return nil, false
+	}
+
+	readings := make([]domain.SectionReading, 0, len(req.Readings))
+	for _, r := range req.Readings {
+		readings = append(readings, r.toReading())
+	}
+	return readings, true
+}
+
+// lineReadings reads readings in the line protocol. It writes an error
+// response and returns false when the body is not a set of temperature
+// points with a value.
+func lineReadings(c *gin.Context) ([]domain.SectionReading, bool) {
+	precision, ok := precisions[c.Query("precision")]
+	if !ok {
+		web.Error(c, http.StatusBadRequest, ErrInvalidPrecision)
+		return nil, false
+	}
+	points, err := lineproto.Parse(c.Request.Body, precision)
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	readings := make([]domain.SectionReading, 0, len(points))
+	for i, p := range points {
+		if p.Measurement != temperatureMeasurement {
+			web.Error(c, http.StatusBadRequest, ErrReadingsMeasurement, i+1)
+			return nil, false
+		}
+		temperature, ok := p.Fields["temperature"]
+		if !ok {
+			temperature, ok = p.Fields["value"]
+		}
+		if !ok {
+			web.Error(c, http.StatusBadRequest, ErrReadingsNoValue, i+1)
+			return nil, false
+		}
+		if len(p.Tags["sensor"]) > 64 {
+			web.Error(c, http.StatusBadRequest, ErrReadingsSensor, i+1)
+			return nil, false
+		}
+		readings = append(readings, domain.SectionReading{Sensor: p.Tags["sensor"], Temperature: temperature, RecordedAt: p.Time})
+	}
+	return readings, true
+}
+
+func (r ReadingRequest) toReading() domain.SectionReading {
+	reading := domain.SectionReading{Sensor: r.Sensor, Temperature: *r.Temperature}
+	if r.RecordedAt != nil {
+		reading.RecordedAt = *r.RecordedAt
+	}
+	return reading
+}
+
+// Readings godoc
+// @Summary Summarise the temperature readings of a section
+// @Description Returns the minimum, average and maximum temperature and the number of the readings of a section
+// @Description recorded from from until to, in buckets aligned to the Unix epoch. The buckets without readings are
+// @Description left out.
+// @Tags sections
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Section ID"
+// @Param from query string false "Start of the range, RFC 3339, a day before to by default" format(date-time)
+// @Param to query string false "End of the range, excluded, RFC 3339, now by default" format(date-time)
+// @Param bucket query string false "Length of the buckets, a duration of whole seconds" default(5m)
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.ReadingBucket}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections/{id}/readings [get]
+func (h *Telemetry) Readings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+		from, ok := queryTime(c, "from", ErrInvalidFrom)
+		if !ok {
+			return
+		}
+		to, ok := queryTime(c, "to", ErrInvalidTo)
+		if !ok {
+			return
+		}
+		var bucket time.Duration
+		if raw := c.Query("bucket"); raw != "" {
+			var err error
+			if bucket, err = time.ParseDuration(raw); err != nil || bucket <= 0 {
+				web.Error(c, http.StatusBadRequest, ErrInvalidBucket)
+				return
+			}
+		}
+
+		buckets, err := h.telemetryService.Readings(c, id, from, to, bucket)
+		if err != nil {
+			switch {
+			case errors.Is(err, telemetry.ErrSectionNotFound):
+				web.Error(c, http.StatusNotFound, ErrSectionNotFound)
+			case errors.Is(err, telemetry.ErrInvalidRange):
+				web.Error(c, http.StatusBadRequest, ErrInvalidReadingRange)
+			case errors.Is(err, telemetry.ErrInvalidBucket):
+				web.Error(c, http.StatusBadRequest, ErrInvalidBucket)
+			default:
+				web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			}
+			return
+		}
+		web.Collection(c, buckets)
+	}
+}
+
+// queryTime reads the RFC 3339 time of a query parameter, zero when missing.
+// It writes a 400 response with message and returns false when it is not a
+// time.
+func queryTime(c *gin.Context, name, message string) (time.Time, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return time.Time{}, true
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, message)
+		return time.Time{}, false
+	}
+	return t, true
+}
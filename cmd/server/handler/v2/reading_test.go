@@ -0,0 +1,232 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/telemetry"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTelemetryRouter(service telemetry.Service) *gin.Engine {
+	h := NewTelemetry(service)
+	r := gin.New()
+	r.POST("/api/v2/sections/:id/readings", h.Ingest())
+	r.GET("/api/v2/sections/:id/readings", h.Readings())
+	return r
+}
+
+func TestTelemetry_Ingest(t *testing.T) {
+	noon := time.Date(2023, 10, 11, 12, 0, 0, 0, time.UTC)
+
+	t.Run("it should ingest a single JSON reading", func(t *testing.T) {
+		// Arrange
+		service := &telemetry.ServiceMock{}
+		service.On("Ingest", mock.Anything, 3, []domain.SectionReading{{Sensor: "north", Temperature: -18.5, RecordedAt: noon}}).
+			Return(telemetry.Ingested{Accepted: 1, CurrentTemperature: -19}, nil)
+		r := newTelemetryRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sections/3/readings", strings.NewReader(`{"sensor":"north","temperature":-18.5,"recorded_at":"2023-10-11T12:00:00Z"}`))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.JSONEq(t, `{"data":{"accepted":1,"current_temperature":-19},"meta":{},"links":{"self":"/api/v2/sections/3/readings"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should ingest a JSON batch and return the excursion the section is in", func(t *testing.T) {
+		// Arrange
+		service := &telemetry.ServiceMock{}
+		excursion := &domain.TemperatureExcursion{ID: 8, SectionID: 3, MinimumTemperature: -20, LowestTemperature: -22, StartedAt: noon}
+		service.On("Ingest", mock.Anything, 3, []domain.SectionReading{{Temperature: -22, RecordedAt: noon}, {Temperature: -21}}).
+			Return(telemetry.Ingested{Accepted: 2, CurrentTemperature: -21, Excursion: excursion}, nil)
+		r := newTelemetryRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sections/3/readings", strings.NewReader(`{"readings":[{"temperature":-22,"recorded_at":"2023-10-11T12:00:00Z"},{"temperature":-21}]}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.JSONEq(t, `{"data":{"accepted":2,"current_temperature":-21,"excursion":{"id":8,"section_id":3,"minimum_temperature":-20,"lowest_temperature":-22,"started_at":"2023-10-11T12:00:00Z"}},"meta":{},"links":{"self":"/api/v2/sections/3/readings"}}`, response.Body.String())
+	})
+
+	t.Run("it should ingest the line protocol in the precision given", func(t *testing.T) {
+		// Arrange
+		service := &telemetry.ServiceMock{}
+		service.On("Ingest", mock.Anything, 3, []domain.SectionReading{
+			{Sensor: "north", Temperature: -18.5, RecordedAt: noon},
+			{Temperature: -18},
+		}).Return(telemetry.Ingested{Accepted: 2, CurrentTemperature: -18}, nil)
+		r := newTelemetryRouter(service)
+		body := "temperature,sensor=north value=-18.5 1697025600\ntemperature temperature=-18\n"
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sections/3/readings?precision=s", strings.NewReader(body))
+		request.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should reject a body that is not readings", func(t *testing.T) {
+		for _, tc := range []struct {
+			name        string
+			contentType string
+			body        string
+			status      int
+			message     string
+		}{
+			{"no reading", "application/json", `{}`, http.StatusUnprocessableEntity, ErrReadingsMissing},
+			{"both", "application/json", `{"temperature":1,"readings":[{"temperature":2}]}`, http.StatusUnprocessableEntity, ErrReadingsAmbiguous},
+			{"reading without temperature", "application/json", `{"readings":[{"sensor":"north"}]}`, http.StatusUnprocessableEntity, "temperature is required"},
+			{"invalid line", "text/plain", "temperature value=cold", http.StatusBadRequest, `lineproto: invalid line 1: field \"value\" is not a number`},
+			{"point without temperature", "text/plain", "temperature humidity=40", http.StatusBadRequest, "point 1 has no temperature or value field"},
+			{"other measurement", "text/plain", "temperature value=-18\nhumidity,sensor=north value=80", http.StatusBadRequest, "the measurement of point 2 must be temperature"},
+			{"unsupported media type", "application/xml", `<reading/>`, http.StatusUnsupportedMediaType, ErrReadingsMediaType},
+		} {
+			// Arrange
+			service := &telemetry.ServiceMock{}
+			r := newTelemetryRouter(service)
+			request := httptest.NewRequest(http.MethodPost, "/api/v2/sections/3/readings", strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", tc.contentType)
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, tc.status, response.Code, tc.name)
+			assert.Contains(t, response.Body.String(), tc.message, tc.name)
+			service.AssertNotCalled(t, "Ingest", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("it should return 404 for a section that does not exist", func(t *testing.T) {
+		// Arrange
+		service := &telemetry.ServiceMock{}
+		service.On("Ingest", mock.Anything, 9, mock.Anything).Return(telemetry.Ingested{}, telemetry.ErrSectionNotFound)
+		r := newTelemetryRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sections/9/readings", strings.NewReader(`{"temperature":-18}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"section not found"}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 for a temperature the readings cannot store", func(t *testing.T) {
+		// Arrange
+		service := &telemetry.ServiceMock{}
+		service.On("Ingest", mock.Anything, 3, mock.Anything).Return(telemetry.Ingested{}, telemetry.ErrTemperatureOutOfRange)
+		r := newTelemetryRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sections/3/readings", strings.NewReader(`{"temperature":10000}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"temperature must be between -9999.99 and 9999.99"}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 for a reading recorded in the future", func(t *testing.T) {
+		for contentType, body := range map[string]string{
+			"application/json": `{"temperature":-18,"recorded_at":"2123-10-11T12:00:00Z"}`,
+			"text/plain":       "temperature value=-18 4852958400000000000",
+		} {
+			// Arrange
+			service := &telemetry.ServiceMock{}
+			service.On("Ingest", mock.Anything, 3, mock.Anything).Return(telemetry.Ingested{}, telemetry.ErrFutureReading)
+			r := newTelemetryRouter(service)
+			request := httptest.NewRequest(http.MethodPost, "/api/v2/sections/3/readings", strings.NewReader(body))
+			request.Header.Set("Content-Type", contentType)
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, response.Code, contentType)
+			assert.JSONEq(t, `{"code":"bad_request","message":"recorded_at must be at most 5m0s after now"}`, response.Body.String(), contentType)
+		}
+	})
+}
+
+func TestTelemetry_Readings(t *testing.T) {
+	noon := time.Date(2023, 10, 11, 12, 0, 0, 0, time.UTC)
+
+	t.Run("it should summarise the readings of the range by bucket", func(t *testing.T) {
+		// Arrange
+		service := &telemetry.ServiceMock{}
+		service.On("Readings", mock.Anything, 3, noon, noon.Add(time.Hour), 15*time.Minute).
+			Return([]domain.ReadingBucket{{Start: noon, Min: -20, Avg: -19.5, Max: -19, Count: 4}}, nil)
+		r := newTelemetryRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sections/3/readings?from=2023-10-11T12:00:00Z&to=2023-10-11T13:00:00Z&bucket=15m", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"start":"2023-10-11T12:00:00Z","min":-20,"avg":-19.5,"max":-19,"count":4}],"meta":{"count":1},"links":{"self":"/api/v2/sections/3/readings?from=2023-10-11T12:00:00Z&to=2023-10-11T13:00:00Z&bucket=15m"}}`, response.Body.String())
+	})
+
+	t.Run("it should leave the range and the bucket not given to the defaults", func(t *testing.T) {
+		// Arrange
+		service := &telemetry.ServiceMock{}
+		service.On("Readings", mock.Anything, 3, time.Time{}, time.Time{}, time.Duration(0)).Return([]domain.ReadingBucket(nil), nil)
+		r := newTelemetryRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sections/3/readings", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 400 for an invalid range or bucket", func(t *testing.T) {
+		for query, message := range map[string]string{
+			"from=yesterday": ErrInvalidFrom,
+			"to=2023-10-11":  ErrInvalidTo,
+			"bucket=often":   ErrInvalidBucket,
+			"from=2023-10-11T13:00:00Z&to=2023-10-11T12:00:00Z": ErrInvalidReadingRange,
+		} {
+			// Arrange
+			service := &telemetry.ServiceMock{}
+			service.On("Readings", mock.Anything, 3, mock.Anything, mock.Anything, mock.Anything).Return([]domain.ReadingBucket(nil), telemetry.ErrInvalidRange)
+			r := newTelemetryRouter(service)
+			request := httptest.NewRequest(http.MethodGet, "/api/v2/sections/3/readings?"+query, nil)
+			response := httptest.NewRecorder()
+
+			// Act
+			serveHTTP(t, r, response, request)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, response.Code, query)
+			assert.Contains(t, response.Body.String(), message, query)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// basePath is the prefix every route of this package is served under.
+const basePath = "/api/v2"
+
+var (
+	ErrInvalidID             = "id must be a positive integer"
+	ErrInvalidIncludeDeleted = "include_deleted must be a boolean"
+	ErrInvalidJSON           = "invalid JSON body"
+	ErrInternalServer        = "internal server error"
+	ErrCannotBeChanged       = "%s cannot be changed"
+)
+
+// link returns the absolute path of a resource below basePath.
+func link(format string, args ...interface{}) string {
+	return basePath + fmt.Sprintf(format, args...)
+}
+
+// created writes data as a newly created resource located at self.
+func created(c *gin.Context, data interface{}, self string) {
+	c.Header("Location", self)
+	web.Resource(c, http.StatusCreated, data, self)
+}
+
+// pathID reads the id path parameter. It writes a 400 response and returns
+// false when the parameter is not a positive integer.
+func pathID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		web.Error(c, http.StatusBadRequest, ErrInvalidID)
+		return 0, false
+	}
+	return id, true
+}
+
+// parentFound reports whether the parent resource of a nested collection was
+// read without error. It writes a 404 response with notFound when err is
+// errNotFound, and a 500 response for any other error.
+func parentFound(c *gin.Context, err, errNotFound error, notFound string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, errNotFound):
+		web.Error(c, http.StatusNotFound, notFound)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+	return false
+}
+
+// includeDeleted reads the include_deleted query parameter and returns the
+// context the reads of the request run with: one including the soft deleted
+// rows when the parameter is true. It writes a 400 response and returns false
+// when the parameter is not a boolean.
+func includeDeleted(c *gin.Context) (context.Context, bool) {
+	raw, ok := c.GetQuery("include_deleted")
+	if !ok {
+		return c, true
+	}
+	included, err := strconv.ParseBool(raw)
+	if err != nil {
+		web.Error(c, http.StatusBadRequest, ErrInvalidIncludeDeleted)
+		return nil, false
+	}
+	if !included {
+		return c, true
+	}
+	return softdelete.WithDeleted(c), true
+}
+
+// bind decodes the JSON body over req and validates the result with its
+// binding tags. Fields missing from the body keep the value req already had,
+// so the same request struct serves creations (zero value) and partial
+// updates (filled from the stored entity). It writes a 400 response for
+// malformed JSON, a 422 response for invalid fields, and returns false in
+// both cases.
+func bind(c *gin.Context, req interface{}) bool {
+	if err := json.NewDecoder(c.Request.Body).Decode(req); err != nil {
+		web.Error(c, http.StatusBadRequest, ErrInvalidJSON)
+		return false
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		web.Error(c, http.StatusUnprocessableEntity, validationMessage(req, err))
+		return false
+	}
+	return true
+}
+
+// validationMessage describes the validation errors of req using the JSON
+// names of the failing fields.
+func validationMessage(req interface{}, err error) string {
+	return strings.Join(validationMessages(req, err), "; ")
+}
+
+// validationMessages describes each validation error of req using the JSON
+// name of the failing field.
+func validationMessages(req interface{}, err error) []string {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return []string{err.Error()}
+	}
+
+	t := reflect.TypeOf(req)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	messages := make([]string, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		name := fe.Field()
+		if f, ok := t.FieldByName(fe.StructField()); ok {
+			if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag != "" {
+				name = tag
+			}
+		}
+
+		switch fe.Tag() {
+		case "required":
+			messages = append(messages, fmt.Sprintf("%s is required", name))
+		case "gt":
+			messages = append(messages, fmt.Sprintf("%s must be greater than %s", name, fe.Param()))
+		case "gte":
+			messages = append(messages, fmt.Sprintf("%s must be greater than or equal to %s", name, fe.Param()))
+		case "lte":
+			messages = append(messages, fmt.Sprintf("%s must be less than or equal to %s", name, fe.Param()))
+		case "max":
+			messages = append(messages, fmt.Sprintf("%s must be at most %s characters long", name, fe.Param()))
+		case "datetime":
+			messages = append(messages, fmt.Sprintf("%s must match the format YYYY-MM-DD", name))
+		default:
+			messages = append(messages, fmt.Sprintf("%s is invalid", name))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,292 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrSectionNotFound           = "section not found"
+	ErrDuplicateSectionNumber    = "section_number already exists"
+	ErrSectionWarehouseNotExists = "warehouse_id does not exist"
+)
+
+// SectionRequest is the body of the section creation and update requests.
+// Temperatures and capacities are pointers so that zero is a valid value
+// while the field stays required.
+type SectionRequest struct {
+	SectionNumber      int  `json:"section_number" binding:"required,gt=0"`
+	CurrentTemperature *int `json:"current_temperature" binding:"required"`
+	MinimumTemperature *int `json:"minimum_temperature" binding:"required"`
+	CurrentCapacity    *int `json:"current_capacity" binding:"required,gte=0"`
+	MinimumCapacity    *int `json:"minimum_capacity" binding:"required,gte=0"`
+	MaximumCapacity    *int `json:"maximum_capacity" binding:"required,gte=0"`
+	WarehouseID        int  `json:"warehouse_id" binding:"required,gt=0"`
+	ProductTypeID      int  `json:"product_type_id" binding:"required,gt=0"`
+}
+
+// SectionPatch documents the body of the section update request: every field of
+// SectionRequest is optional and the missing ones keep their stored value.
+type SectionPatch struct {
+	SectionNumber      int `json:"section_number,omitempty"`
+	CurrentTemperature int `json:"current_temperature,omitempty"`
+	MinimumTemperature int `json:"minimum_temperature,omitempty"`
+	CurrentCapacity    int `json:"current_capacity,omitempty"`
+	MinimumCapacity    int `json:"minimum_capacity,omitempty"`
+	MaximumCapacity    int `json:"maximum_capacity,omitempty"`
+	WarehouseID        int `json:"warehouse_id,omitempty"`
+	ProductTypeID      int `json:"product_type_id,omitempty"`
+}
+
+// Section contains the /sections handlers.
+type Section struct {
+	sectionService section.Service
+}
+
+// NewSection returns a new instance of Section.
+func NewSection(s section.Service) *Section {
+	return &Section{sectionService: s}
+}
+
+// GetAll godoc
+// @Summary List sections
+// @Tags sections
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.Section}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections [get]
+func (s *Section) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sections, err := s.sectionService.GetAll(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, sections)
+	}
+}
+
+// Get godoc
+// @Summary Get a section
+// @Tags sections
+// @Produce json
+// @Param id path int true "Section ID"
+// @Success 200 {object} web.Envelope{data=domain.Section}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections/{id} [get]
+func (s *Section) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		sect, err := s.sectionService.Get(c, id)
+		if err != nil {
+			s.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, sect, link("/sections/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Create a section
+// @Tags sections
+// @Accept json
+// @Produce json
+// @Param body body SectionRequest true "Section to create"
+// @Success 201 {object} web.Envelope{data=domain.Section}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections [post]
+func (s *Section) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SectionRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		sect := req.toSection()
+		id, err := s.sectionService.Save(c, sect)
+		if err != nil {
+			s.writeError(c, err)
+			return
+		}
+
+		sect.ID = id
+		created(c, sect, link("/sections/%d", id))
+	}
+}
+
+// Update godoc
+// @Summary Update a section
+// @Description Only the fields present in the body are changed.
+// @Tags sections
+// @Accept json
+// @Produce json
+// @Param id path int true "Section ID"
+// @Param body body SectionPatch true "Fields to update"
+// @Success 200 {object} web.Envelope{data=domain.Section}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections/{id} [patch]
+func (s *Section) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		current, err := s.sectionService.Get(c, id)
+		if err != nil {
+			s.writeError(c, err)
+			return
+		}
+
+		req := sectionToRequest(current)
+		if !bind(c, &req) {
+			return
+		}
+
+		sect := req.toSection()
+		sect.ID = id
+		if err := s.sectionService.Update(c, sect); err != nil {
+			s.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, sect, link("/sections/%d", id))
+	}
+}
+
+// Delete godoc
+// @Summary Delete a section
+// @Tags sections
+// @Produce json
+// @Param id path int true "Section ID"
+// @Success 204
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections/{id} [delete]
+func (s *Section) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		if err := s.sectionService.Delete(c, id); err != nil {
+			s.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ProductReports godoc
+// @Summary Count the products of every section
+// @Tags sections
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]section.ProdCountResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections/product-reports [get]
+func (s *Section) ProductReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reports, err := s.sectionService.ProductCount(c, 0)
+		if err != nil && !errors.Is(err, section.ErrNotFound) {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, reports)
+	}
+}
+
+// ProductReport godoc
+// @Summary Count the products of a section
+// @Tags sections
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Section ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=section.ProdCountResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sections/{id}/product-report [get]
+func (s *Section) ProductReport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		reports, err := s.sectionService.ProductCount(c, id)
+		if err != nil {
+			s.writeError(c, err)
+			return
+		}
+		if len(reports) == 0 {
+			web.Error(c, http.StatusNotFound, ErrSectionNotFound)
+			return
+		}
+		web.Report(c, reports[0], link("/sections/%d/product-report", id))
+	}
+}
+
+// writeError maps the errors of the section service to a response.
+func (s *Section) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, section.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrSectionNotFound)
+	case errors.Is(err, section.ErrDuplicateSectNumber):
+		web.Error(c, http.StatusConflict, ErrDuplicateSectionNumber)
+	case errors.Is(err, section.ErrProductTypeNotFound):
+		web.Error(c, http.StatusUnprocessableEntity, ErrProductTypeNotExists)
+	case errors.Is(err, section.ErrWarehouseNotFound):
+		web.Error(c, http.StatusUnprocessableEntity, ErrSectionWarehouseNotExists)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
+
+func (r SectionRequest) toSection() domain.Section {
+	return domain.Section{
+		SectionNumber:      r.SectionNumber,
+		CurrentTemperature: *r.CurrentTemperature,
+		MinimumTemperature: *r.MinimumTemperature,
+		CurrentCapacity:    *r.CurrentCapacity,
+		MinimumCapacity:    *r.MinimumCapacity,
+		MaximumCapacity:    *r.MaximumCapacity,
+		WarehouseID:        r.WarehouseID,
+		ProductTypeID:      r.ProductTypeID,
+	}
+}
+
+func sectionToRequest(s domain.Section) SectionRequest {
+	return SectionRequest{
+		SectionNumber:      s.SectionNumber,
+		CurrentTemperature: &s.CurrentTemperature,
+		MinimumTemperature: &s.MinimumTemperature,
+		CurrentCapacity:    &s.CurrentCapacity,
+		MinimumCapacity:    &s.MinimumCapacity,
+		MaximumCapacity:    &s.MaximumCapacity,
+		WarehouseID:        s.WarehouseID,
+		ProductTypeID:      s.ProductTypeID,
+	}
+}
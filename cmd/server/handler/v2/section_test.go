@@ -0,0 +1,156 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSectionRouter(service section.Service) *gin.Engine {
+	h := NewSection(service)
+	r := gin.New()
+	r.GET("/api/v2/sections", h.GetAll())
+	r.GET("/api/v2/sections/:id", h.Get())
+	r.POST("/api/v2/sections", h.Create())
+	r.PATCH("/api/v2/sections/:id", h.Update())
+	r.DELETE("/api/v2/sections/:id", h.Delete())
+	r.GET("/api/v2/sections/product-reports", h.ProductReports())
+	r.GET("/api/v2/sections/:id/product-report", h.ProductReport())
+	return r
+}
+
+func TestSection_Create(t *testing.T) {
+	t.Run("it should accept zero temperatures and capacities", func(t *testing.T) {
+		// Arrange
+		toSave := domain.Section{SectionNumber: 5, MaximumCapacity: 10, WarehouseID: 1, ProductTypeID: 2}
+		service := &section.ServiceMock{}
+		service.On("Save", mock.Anything, toSave).Return(3, nil)
+		r := newSectionRouter(service)
+		body := `{"section_number":5,"current_temperature":0,"minimum_temperature":0,"current_capacity":0,
+			"minimum_capacity":0,"maximum_capacity":10,"warehouse_id":1,"product_type_id":2}`
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sections", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "/api/v2/sections/3", response.Header().Get("Location"))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 when a temperature is missing", func(t *testing.T) {
+		// Arrange
+		r := newSectionRouter(&section.ServiceMock{})
+		body := `{"section_number":5,"current_temperature":0,"current_capacity":0,
+			"minimum_capacity":0,"maximum_capacity":10,"warehouse_id":1,"product_type_id":2}`
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sections", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"minimum_temperature is required"}`, response.Body.String())
+	})
+}
+
+func TestSection_Update(t *testing.T) {
+	t.Run("it should return 422 when the product type does not exist", func(t *testing.T) {
+		// Arrange
+		stored := domain.Section{ID: 3, SectionNumber: 5, MaximumCapacity: 10, WarehouseID: 1, ProductTypeID: 2}
+		service := &section.ServiceMock{}
+		service.On("Get", mock.Anything, 3).Return(stored, nil)
+		service.On("Update", mock.Anything, mock.Anything).Return(section.ErrProductTypeNotFound)
+		r := newSectionRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/sections/3", strings.NewReader(`{"product_type_id":99}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"product_type_id does not exist"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when the warehouse does not exist", func(t *testing.T) {
+		// Arrange
+		stored := domain.Section{ID: 3, SectionNumber: 5, MaximumCapacity: 10, WarehouseID: 1, ProductTypeID: 2}
+		service := &section.ServiceMock{}
+		service.On("Get", mock.Anything, 3).Return(stored, nil)
+		service.On("Update", mock.Anything, mock.Anything).Return(section.ErrWarehouseNotFound)
+		r := newSectionRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/sections/3", strings.NewReader(`{"warehouse_id":99}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"warehouse_id does not exist"}`, response.Body.String())
+	})
+
+	t.Run("it should return 409 when the new section number is taken", func(t *testing.T) {
+		// Arrange
+		stored := domain.Section{ID: 3, SectionNumber: 5, MaximumCapacity: 10, WarehouseID: 1, ProductTypeID: 2}
+		service := &section.ServiceMock{}
+		service.On("Get", mock.Anything, 3).Return(stored, nil)
+		service.On("Update", mock.Anything, mock.Anything).Return(section.ErrDuplicateSectNumber)
+		r := newSectionRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/sections/3", strings.NewReader(`{"section_number":6}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"section_number already exists"}`, response.Body.String())
+	})
+}
+
+func TestSection_ProductReport(t *testing.T) {
+	t.Run("it should return the product count of a section", func(t *testing.T) {
+		// Arrange
+		service := &section.ServiceMock{}
+		service.On("ProductCount", mock.Anything, 3).Return([]section.ProdCountResponse{{ID: 3, SectionNumber: 5, ProductCount: 40}}, nil)
+		r := newSectionRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sections/3/product-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":3,"section_number":5,"product_count":40},"meta":{},
+			"links":{"self":"/api/v2/sections/3/product-report"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the section does not exist", func(t *testing.T) {
+		// Arrange
+		service := &section.ServiceMock{}
+		service.On("ProductCount", mock.Anything, 9).Return([]section.ProdCountResponse(nil), section.ErrNotFound)
+		r := newSectionRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sections/9/product-report", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"section not found"}`, response.Body.String())
+	})
+}
@@ -0,0 +1,307 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrSellerNotFound      = "seller not found"
+	ErrSellerAlreadyExists = "seller already exists"
+	ErrLocalityNotExists   = "locality_id does not exist"
+)
+
+// SellerRequest is the body of the seller creation and update requests.
+type SellerRequest struct {
+	CID         int    `json:"cid" binding:"required,gt=0"`
+	CompanyName string `json:"company_name" binding:"required"`
+	Address     string `json:"address" binding:"required"`
+	Telephone   string `json:"telephone" binding:"required"`
+	LocalityID  int    `json:"locality_id" binding:"required,gt=0"`
+}
+
+// SellerPatch documents the body of the seller update request: every field of
+// SellerRequest is optional and the missing ones keep their stored value.
+type SellerPatch struct {
+	CID         int    `json:"cid,omitempty"`
+	CompanyName string `json:"company_name,omitempty"`
+	Address     string `json:"address,omitempty"`
+	Telephone   string `json:"telephone,omitempty"`
+	LocalityID  int    `json:"locality_id,omitempty"`
+}
+
+// Seller contains the /sellers handlers.
+type Seller struct {
+	sellerService seller.Service
+}
+
+// NewSeller returns a new instance of Seller.
+func NewSeller(s seller.Service) *Seller {
+	return &Seller{sellerService: s}
+}
+
+// GetAll godoc
+// @Summary List sellers
+// @Tags sellers
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Param include_deleted query bool false "Include the deleted sellers"
+// @Success 200 {object} web.Envelope{data=[]domain.Seller}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sellers [get]
+func (s *Seller) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		sellers, err := s.sellerService.GetAllSellers(ctx)
+		if err != nil && !errors.Is(err, seller.ErrNotFound) {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, sellers)
+	}
+}
+
+// Get godoc
+// @Summary Get a seller
+// @Tags sellers
+// @Produce json
+// @Param id path int true "Seller ID"
+// @Param include_deleted query bool false "Include the seller if it is deleted"
+// @Success 200 {object} web.Envelope{data=domain.Seller}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sellers/{id} [get]
+func (s *Seller) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		sell, err := s.sellerService.GetSellerByID(ctx, id)
+		if err != nil {
+			s.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, sell, link("/sellers/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Create a seller
+// @Tags sellers
+// @Accept json
+// @Produce json
+// @Param body body SellerRequest true "Seller to create"
+// @Success 201 {object} web.Envelope{data=domain.Seller}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sellers [post]
+func (s *Seller) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SellerRequest
+		if !bind(c, &req) || !s.localityExists(c, req.LocalityID) {
+			return
+		}
+
+		sell := req.toSeller()
+		id, err := s.sellerService.Save(c, sell)
+		if err != nil {
+			s.writeError(c, err)
+			return
+		}
+
+		sell.ID = id
+		created(c, sell, link("/sellers/%d", id))
+	}
+}
+
+// Update godoc
+// @Summary Update a seller
+// @Description Only the fields present in the body are changed.
+// @Tags sellers
+// @Accept json
+// @Produce json
+// @Param id path int true "Seller ID"
+// @Param body body SellerPatch true "Fields to update"
+// @Success 200 {object} web.Envelope{data=domain.Seller}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sellers/{id} [patch]
+func (s *Seller) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		current, err := s.sellerService.GetSellerByID(c, id)
+		if err != nil {
+			s.writeError(c, err)
+			return
+		}
+
+		req := sellerToRequest(current)
+		if !bind(c, &req) || !s.localityExists(c, req.LocalityID) {
+			return
+		}
+
+		sell := req.toSeller()
+		sell.ID = id
+		if err := s.sellerService.Update(c, sell, id); err != nil {
+			s.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, sell, link("/sellers/%d", id))
+	}
+}
+
+// Delete godoc
+// @Summary Delete a seller
+// @Tags sellers
+// @Produce json
+// @Param id path int true "Seller ID"
+// @Success 204
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sellers/{id} [delete]
+func (s *Seller) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		if err := s.sellerService.Delete(c, id); err != nil {
+			s.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Restore godoc
+// @Summary Restore a deleted seller
+// @Description Undoes the deletion of a seller. A seller that is not deleted is returned as it is.
+// @Tags sellers
+// @Produce json
+// @Param id path int true "Seller ID"
+// @Success 200 {object} web.Envelope{data=domain.Seller}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sellers/{id}/restore [post]
+func (s *Seller) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		sell, err := s.sellerService.Restore(c, id)
+		if err != nil {
+			s.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, sell, link("/sellers/%d", id))
+	}
+}
+
+// Import godoc
+// @Summary Import sellers
+// @Description Saves every seller of a CSV or NDJSON file in one transaction. The CSV header names the fields of SellerRequest.
+// @Description A cid already stored fails its row, or updates the stored seller when mode is upsert.
+// @Description When a row is invalid nothing is saved and the errors of every row are listed with their line number.
+// @Tags sellers
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV (text/csv) or NDJSON (application/x-ndjson) file"
+// @Param mode query string false "insert (default) or upsert" Enums(insert, upsert)
+// @Param dry_run query bool false "Check every row without saving any"
+// @Success 200 {object} web.Envelope{data=ImportSummary}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 415 {object} web.ErrorResponse
+// @Failure 422 {object} ImportErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /sellers/import [post]
+func (s *Seller) Import() gin.HandlerFunc {
+	im := importer[SellerRequest, domain.Seller]{
+		toDomain: SellerRequest.toSeller,
+		run:      s.sellerService.Import,
+		rowMessage: func(err error) string {
+			switch {
+			case errors.Is(err, seller.ErrSellerAlreadyExists):
+				return ErrSellerAlreadyExists
+			case errors.Is(err, seller.ErrLocalityNotExists):
+				return ErrLocalityNotExists
+			}
+			return err.Error()
+		},
+	}
+	return im.handle
+}
+
+// localityExists writes a 422 response and returns false when the locality
+// referenced by a seller does not exist.
+func (s *Seller) localityExists(c *gin.Context, localityID int) bool {
+	if !s.sellerService.GetLocalityIdFromSeller(c, localityID) {
+		web.Error(c, http.StatusUnprocessableEntity, ErrLocalityNotExists)
+		return false
+	}
+	return true
+}
+
+// writeError maps the errors of the seller service to a response.
+func (s *Seller) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, seller.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrSellerNotFound)
+	case errors.Is(err, seller.ErrSellerAlreadyExists):
+		web.Error(c, http.StatusConflict, ErrSellerAlreadyExists)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
+
+func (r SellerRequest) toSeller() domain.Seller {
+	return domain.Seller{
+		CID:         r.CID,
+		CompanyName: r.CompanyName,
+		Address:     r.Address,
+		Telephone:   r.Telephone,
+		IDLocality:  r.LocalityID,
+	}
+}
+
+func sellerToRequest(s domain.Seller) SellerRequest {
+	return SellerRequest{
+		CID:         s.CID,
+		CompanyName: s.CompanyName,
+		Address:     s.Address,
+		Telephone:   s.Telephone,
+		LocalityID:  s.IDLocality,
+	}
+}
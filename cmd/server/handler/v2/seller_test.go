@@ -0,0 +1,241 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSellerRouter(service seller.Service) *gin.Engine {
+	h := NewSeller(service)
+	r := gin.New()
+	r.GET("/api/v2/sellers", h.GetAll())
+	r.GET("/api/v2/sellers/:id", h.Get())
+	r.POST("/api/v2/sellers", h.Create())
+	r.POST("/api/v2/sellers/import", h.Import())
+	r.PATCH("/api/v2/sellers/:id", h.Update())
+	r.DELETE("/api/v2/sellers/:id", h.Delete())
+	return r
+}
+
+func TestSeller_GetAll(t *testing.T) {
+	t.Run("it should wrap the sellers in an envelope", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("GetAllSellers", mock.Anything).Return([]domain.Seller{
+			{ID: 1, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "555", IDLocality: 3},
+		}, nil)
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sellers", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"cid":10,"company_name":"Acme","address":"Street 1","telephone":"555","locality_id":3}],
+			"meta":{"count":1},"links":{"self":"/api/v2/sellers"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return an empty collection when there are no sellers", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("GetAllSellers", mock.Anything).Return([]domain.Seller(nil), seller.ErrNotFound)
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sellers", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[],"meta":{"count":0},"links":{"self":"/api/v2/sellers"}}`, response.Body.String())
+	})
+}
+
+func TestSeller_Get(t *testing.T) {
+	t.Run("it should return 400 when the id is not a positive integer", func(t *testing.T) {
+		// Arrange
+		r := newSellerRouter(seller.NewMockService())
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sellers/abc", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"id must be a positive integer"}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the seller does not exist", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("GetSellerByID", mock.Anything, 7).Return(domain.Seller{}, seller.ErrNotFound)
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/sellers/7", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"seller not found"}`, response.Body.String())
+	})
+}
+
+func TestSeller_Create(t *testing.T) {
+	body := `{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"555","locality_id":3}`
+
+	t.Run("it should create the seller and point to it", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("GetLocalityIdFromSeller", mock.Anything, 3).Return(true)
+		service.On("Save", mock.Anything, domain.Seller{CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "555", IDLocality: 3}).Return(4, nil)
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sellers", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "/api/v2/sellers/4", response.Header().Get("Location"))
+		assert.JSONEq(t, `{"data":{"id":4,"cid":10,"company_name":"Acme","address":"Street 1","telephone":"555","locality_id":3},
+			"meta":{},"links":{"self":"/api/v2/sellers/4"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 400 when the body is not JSON", func(t *testing.T) {
+		// Arrange
+		r := newSellerRouter(seller.NewMockService())
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sellers", strings.NewReader(`{"cid":`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"invalid JSON body"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 naming every invalid field", func(t *testing.T) {
+		// Arrange
+		r := newSellerRouter(seller.NewMockService())
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sellers", strings.NewReader(`{"cid":-1,"address":"Street 1","telephone":"555","locality_id":3}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"cid must be greater than 0; company_name is required"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when the locality does not exist", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("GetLocalityIdFromSeller", mock.Anything, 3).Return(false)
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sellers", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"locality_id does not exist"}`, response.Body.String())
+	})
+
+	t.Run("it should return 409 when the cid is taken", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("GetLocalityIdFromSeller", mock.Anything, 3).Return(true)
+		service.On("Save", mock.Anything, mock.Anything).Return(0, seller.ErrSellerAlreadyExists)
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/sellers", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"seller already exists"}`, response.Body.String())
+	})
+}
+
+func TestSeller_Update(t *testing.T) {
+	t.Run("it should only change the fields present in the body", func(t *testing.T) {
+		// Arrange
+		stored := domain.Seller{ID: 2, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "555", IDLocality: 3}
+		updated := stored
+		updated.Telephone = "777"
+		service := seller.NewMockService()
+		service.On("GetSellerByID", mock.Anything, 2).Return(stored, nil)
+		service.On("GetLocalityIdFromSeller", mock.Anything, 3).Return(true)
+		service.On("Update", mock.Anything, updated).Return(nil)
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/sellers/2", strings.NewReader(`{"telephone":"777"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":2,"cid":10,"company_name":"Acme","address":"Street 1","telephone":"777","locality_id":3},
+			"meta":{},"links":{"self":"/api/v2/sellers/2"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+}
+
+func TestSeller_Delete(t *testing.T) {
+	t.Run("it should answer 204 without a body", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("Delete", mock.Anything, 2).Return(nil)
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodDelete, "/api/v2/sellers/2", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.Empty(t, response.Body.String())
+	})
+
+	t.Run("it should return 500 on unexpected errors", func(t *testing.T) {
+		// Arrange
+		service := seller.NewMockService()
+		service.On("Delete", mock.Anything, 2).Return(errors.New("connection lost"))
+		r := newSellerRouter(service)
+		request := httptest.NewRequest(http.MethodDelete, "/api/v2/sellers/2", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.JSONEq(t, `{"code":"internal_server_error","message":"internal server error"}`, response.Body.String())
+	})
+}
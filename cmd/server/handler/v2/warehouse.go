@@ -0,0 +1,324 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrWarehouseNotFound      = "warehouse not found"
+	ErrWarehouseAlreadyExists = "warehouse_code already exists"
+	ErrWarehouseIncorrectData = "incorrect warehouse data"
+)
+
+// WarehouseRequest is the body of the warehouse creation and update requests.
+// The minimums are pointers so that zero is a valid value while the field
+// stays required.
+type WarehouseRequest struct {
+	Address            string `json:"address" binding:"required"`
+	Telephone          string `json:"telephone" binding:"required"`
+	WarehouseCode      string `json:"warehouse_code" binding:"required"`
+	MinimumCapacity    *int   `json:"minimum_capacity" binding:"required,gte=0"`
+	MinimumTemperature *int   `json:"minimum_temperature" binding:"required"`
+}
+
+// WarehousePatch documents the body of the warehouse update request: every field of
+// WarehouseRequest is optional and the missing ones keep their stored value.
+type WarehousePatch struct {
+	Address            string `json:"address,omitempty"`
+	Telephone          string `json:"telephone,omitempty"`
+	WarehouseCode      string `json:"warehouse_code,omitempty"`
+	MinimumCapacity    int    `json:"minimum_capacity,omitempty"`
+	MinimumTemperature int    `json:"minimum_temperature,omitempty"`
+}
+
+// Warehouse contains the /warehouses handlers.
+type Warehouse struct {
+	warehouseService warehouse.Service
+}
+
+// NewWarehouse returns a new instance of Warehouse.
+func NewWarehouse(s warehouse.Service) *Warehouse {
+	return &Warehouse{warehouseService: s}
+}
+
+// GetAll godoc
+// @Summary List warehouses
+// @Tags warehouses
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Param include_deleted query bool false "Include the deleted warehouses"
+// @Success 200 {object} web.Envelope{data=[]domain.Warehouse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses [get]
+func (w *Warehouse) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		warehouses, err := w.warehouseService.GetAll(ctx)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, warehouses)
+	}
+}
+
+// Get godoc
+// @Summary Get a warehouse
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Param include_deleted query bool false "Include the warehouse if it is deleted"
+// @Success 200 {object} web.Envelope{data=domain.Warehouse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses/{id} [get]
+func (w *Warehouse) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		ctx, ok := includeDeleted(c)
+		if !ok {
+			return
+		}
+
+		wh, err := w.warehouseService.Get(ctx, id)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, wh, link("/warehouses/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Create a warehouse
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param body body WarehouseRequest true "Warehouse to create"
+// @Success 201 {object} web.Envelope{data=domain.Warehouse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses [post]
+func (w *Warehouse) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req WarehouseRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		wh := req.toWarehouse()
+		id, err := w.warehouseService.Save(c, wh)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+
+		wh.ID = id
+		created(c, wh, link("/warehouses/%d", id))
+	}
+}
+
+// Update godoc
+// @Summary Update a warehouse
+// @Description Only the fields present in the body are changed.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Param body body WarehousePatch true "Fields to update"
+// @Success 200 {object} web.Envelope{data=domain.Warehouse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses/{id} [patch]
+func (w *Warehouse) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		current, err := w.warehouseService.Get(c, id)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+
+		req := warehouseToRequest(current)
+		if !bind(c, &req) {
+			return
+		}
+
+		wh := req.toWarehouse()
+		wh.ID = id
+		if err := w.warehouseService.Update(c, wh); err != nil {
+			w.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, wh, link("/warehouses/%d", id))
+	}
+}
+
+// Delete godoc
+// @Summary Delete a warehouse
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Success 204
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses/{id} [delete]
+func (w *Warehouse) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		if err := w.warehouseService.Delete(c, id); err != nil {
+			w.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Restore godoc
+// @Summary Restore a deleted warehouse
+// @Description Undoes the deletion of a warehouse. A warehouse that is not deleted is returned as it is.
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} web.Envelope{data=domain.Warehouse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses/{id}/restore [post]
+func (w *Warehouse) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		wh, err := w.warehouseService.Restore(c, id)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, wh, link("/warehouses/%d", id))
+	}
+}
+
+// Sections godoc
+// @Summary List the sections of a warehouse
+// @Tags warehouses
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Warehouse ID"
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.Envelope{data=[]domain.Section}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses/{id}/sections [get]
+func (w *Warehouse) Sections() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		sections, err := w.warehouseService.Sections(c, id)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+		web.Collection(c, sections)
+	}
+}
+
+// Summary godoc
+// @Summary Summarise a warehouse
+// @Description Aggregates the sections of a warehouse: the sums of their current, minimum and maximum capacities,
+// @Description the current capacity in percent of the maximum one, the range of their current temperatures, the
+// @Description number of batches they store and of employees of the warehouse, and the sections storing more than
+// @Description their maximum capacity or less than their minimum one.
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} web.Envelope{data=domain.WarehouseSummary}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /warehouses/{id}/summary [get]
+func (w *Warehouse) Summary() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		summary, err := w.warehouseService.Summary(c, id)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, summary, link("/warehouses/%d/summary", id))
+	}
+}
+
+// writeError maps the errors of the warehouse service to a response.
+func (w *Warehouse) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, warehouse.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrWarehouseNotFound)
+	case errors.Is(err, warehouse.ErrDuplicateWarehouse):
+		web.Error(c, http.StatusConflict, ErrWarehouseAlreadyExists)
+	case errors.Is(err, warehouse.ErrIncorrectData):
+		web.Error(c, http.StatusUnprocessableEntity, ErrWarehouseIncorrectData)
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
+
+func (r WarehouseRequest) toWarehouse() domain.Warehouse {
+	return domain.Warehouse{
+		Address:            r.Address,
+		Telephone:          r.Telephone,
+		WarehouseCode:      r.WarehouseCode,
+		MinimumCapacity:    *r.MinimumCapacity,
+		MinimumTemperature: *r.MinimumTemperature,
+	}
+}
+
+func warehouseToRequest(w domain.Warehouse) WarehouseRequest {
+	return WarehouseRequest{
+		Address:            w.Address,
+		Telephone:          w.Telephone,
+		WarehouseCode:      w.WarehouseCode,
+		MinimumCapacity:    &w.MinimumCapacity,
+		MinimumTemperature: &w.MinimumTemperature,
+	}
+}
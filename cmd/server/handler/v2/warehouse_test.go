@@ -0,0 +1,266 @@
+package v2
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newWarehouseRouter(service warehouse.Service) *gin.Engine {
+	h := NewWarehouse(service)
+	r := gin.New()
+	r.GET("/api/v2/warehouses", h.GetAll())
+	r.GET("/api/v2/warehouses/:id", h.Get())
+	r.POST("/api/v2/warehouses", h.Create())
+	r.PATCH("/api/v2/warehouses/:id", h.Update())
+	r.DELETE("/api/v2/warehouses/:id", h.Delete())
+	r.POST("/api/v2/warehouses/:id/restore", h.Restore())
+	r.GET("/api/v2/warehouses/:id/sections", h.Sections())
+	r.GET("/api/v2/warehouses/:id/summary", h.Summary())
+	return r
+}
+
+func TestWarehouse_GetAll(t *testing.T) {
+	t.Run("it should wrap the warehouses in an envelope", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("GetAll", mock.Anything).Return([]domain.Warehouse{
+			{ID: 1, Address: "Street 1", Telephone: "555", WarehouseCode: "W1", MinimumCapacity: 10, MinimumTemperature: -5},
+		}, nil)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"address":"Street 1","telephone":"555","warehouse_code":"W1","minimum_capacity":10,"minimum_temperature":-5}],
+			"meta":{"count":1},"links":{"self":"/api/v2/warehouses"}}`, response.Body.String())
+	})
+}
+
+func TestWarehouse_Get(t *testing.T) {
+	t.Run("it should read a deleted warehouse when deleted warehouses are included", func(t *testing.T) {
+		// Arrange
+		deletedAt := time.Date(2026, time.October, 1, 8, 30, 0, 0, time.UTC)
+		service := &warehouse.ServiceMock{}
+		service.On("Get", mock.MatchedBy(softdelete.Included), 1).Return(domain.Warehouse{
+			ID: 1, Address: "Street 1", Telephone: "555", WarehouseCode: "W1", MinimumCapacity: 10, MinimumTemperature: -5, DeletedAt: &deletedAt,
+		}, nil)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/1?include_deleted=true", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"address":"Street 1","telephone":"555","warehouse_code":"W1","minimum_capacity":10,"minimum_temperature":-5,
+			"deleted_at":"2026-10-01T08:30:00Z"},"meta":{},"links":{"self":"/api/v2/warehouses/1"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 when include_deleted is not a boolean", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/1?include_deleted=maybe", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"include_deleted must be a boolean"}`, response.Body.String())
+		service.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWarehouse_Restore(t *testing.T) {
+	t.Run("it should return the restored warehouse", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("Restore", mock.Anything, 1).Return(domain.Warehouse{
+			ID: 1, Address: "Street 1", Telephone: "555", WarehouseCode: "W1", MinimumCapacity: 10, MinimumTemperature: -5,
+		}, nil)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/warehouses/1/restore", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"address":"Street 1","telephone":"555","warehouse_code":"W1","minimum_capacity":10,"minimum_temperature":-5},
+			"meta":{},"links":{"self":"/api/v2/warehouses/1"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 409 when another warehouse took the code", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("Restore", mock.Anything, 1).Return(domain.Warehouse{}, warehouse.ErrDuplicateWarehouse)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/warehouses/1/restore", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"warehouse_code already exists"}`, response.Body.String())
+	})
+}
+
+func TestWarehouse_Sections(t *testing.T) {
+	t.Run("it should list the sections of the warehouse", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("Sections", mock.Anything, 2).Return([]domain.Section{
+			{ID: 5, SectionNumber: 1, CurrentTemperature: -18, MinimumTemperature: -20, CurrentCapacity: 10, MinimumCapacity: 5, MaximumCapacity: 100, WarehouseID: 2, ProductTypeID: 1},
+		}, nil)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/2/sections", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":5,"section_number":1,"current_temperature":-18,"minimum_temperature":-20,"current_capacity":10,
+			"minimum_capacity":5,"maximum_capacity":100,"warehouse_id":2,"product_type_id":1}],
+			"meta":{"count":1},"links":{"self":"/api/v2/warehouses/2/sections"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the warehouse does not exist", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("Sections", mock.Anything, 9).Return([]domain.Section(nil), warehouse.ErrNotFound)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/9/sections", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"warehouse not found"}`, response.Body.String())
+	})
+
+	t.Run("it should return 500 when the warehouse cannot be read", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("Sections", mock.Anything, 9).Return([]domain.Section(nil), sql.ErrConnDone)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/9/sections", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.JSONEq(t, `{"code":"internal_server_error","message":"internal server error"}`, response.Body.String())
+	})
+}
+
+func TestWarehouse_Summary(t *testing.T) {
+	t.Run("it should return the summary of the warehouse", func(t *testing.T) {
+		// Arrange
+		over := domain.Section{ID: 6, SectionNumber: 2, CurrentTemperature: 4, CurrentCapacity: 130, MinimumCapacity: 20, MaximumCapacity: 120, WarehouseID: 2, ProductTypeID: 1}
+		service := &warehouse.ServiceMock{}
+		service.On("Summary", mock.Anything, 2).Return(domain.WarehouseSummary{
+			WarehouseID:      2,
+			SectionCount:     2,
+			Capacity:         domain.WarehouseCapacity{Current: 180, Minimum: 30, Maximum: 220},
+			Utilisation:      81.82,
+			TemperatureRange: &domain.TemperatureRange{Min: -18, Max: 4},
+			BatchCount:       4,
+			EmployeeCount:    3,
+			OverCapacity:     []domain.Section{over},
+			UnderCapacity:    []domain.Section{},
+		}, nil)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/2/summary", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"warehouse_id":2,"section_count":2,"capacity":{"current":180,"minimum":30,"maximum":220},
+			"utilisation_percentage":81.82,"temperature_range":{"min":-18,"max":4},"batch_count":4,"employee_count":3,
+			"over_capacity":[{"id":6,"section_number":2,"current_temperature":4,"minimum_temperature":0,"current_capacity":130,
+			"minimum_capacity":20,"maximum_capacity":120,"warehouse_id":2,"product_type_id":1}],"under_capacity":[]},
+			"meta":{},"links":{"self":"/api/v2/warehouses/2/summary"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 404 when the warehouse does not exist", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("Summary", mock.Anything, 9).Return(domain.WarehouseSummary{}, warehouse.ErrNotFound)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/warehouses/9/summary", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestWarehouse_Create(t *testing.T) {
+	t.Run("it should return 409 when the warehouse code is taken", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("Save", mock.Anything, mock.Anything).Return(0, warehouse.ErrDuplicateWarehouse)
+		r := newWarehouseRouter(service)
+		body := `{"address":"Street 1","telephone":"555","warehouse_code":"W1","minimum_capacity":0,"minimum_temperature":0}`
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/warehouses", strings.NewReader(body))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"warehouse_code already exists"}`, response.Body.String())
+	})
+}
+
+func TestWarehouse_Update(t *testing.T) {
+	t.Run("it should return 404 when the warehouse does not exist", func(t *testing.T) {
+		// Arrange
+		service := &warehouse.ServiceMock{}
+		service.On("Get", mock.Anything, 4).Return(domain.Warehouse{}, warehouse.ErrNotFound)
+		r := newWarehouseRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/warehouses/4", strings.NewReader(`{"telephone":"777"}`))
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"warehouse not found"}`, response.Body.String())
+	})
+}
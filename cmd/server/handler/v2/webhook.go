@@ -0,0 +1,276 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/webhook"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrWebhookNotFound         = "webhook subscription not found"
+	ErrWebhookDeliveryNotFound = "webhook delivery not found"
+	ErrInvalidDeliveryStatus   = "status must be one of pending, delivered or dead"
+)
+
+// WebhookRequest is the body of the webhook subscription creation and update
+// requests.
+type WebhookRequest struct {
+	URL string `json:"url" binding:"required"`
+	// Secret signs the deliveries, see the X-Webhook-Signature header. It is
+	// never returned.
+	Secret     string   `json:"secret" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required" enums:"purchase_order.created,inbound_order.received,product_batch.created,section.capacity_low,section.temperature_excursion_started,section.temperature_excursion_ended"`
+}
+
+// WebhookPatch documents the body of the webhook subscription update request:
+// every field of WebhookRequest is optional and the missing ones keep their
+// stored value.
+type WebhookPatch struct {
+	URL        string   `json:"url,omitempty"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types,omitempty" enums:"purchase_order.created,inbound_order.received,product_batch.created,section.capacity_low,section.temperature_excursion_started,section.temperature_excursion_ended"`
+}
+
+// Webhook contains the /webhooks handlers.
+type Webhook struct {
+	webhookService webhook.Service
+}
+
+// NewWebhook returns a new instance of Webhook.
+func NewWebhook(s webhook.Service) *Webhook {
+	return &Webhook{webhookService: s}
+}
+
+// GetAll godoc
+// @Summary List webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} web.Envelope{data=[]domain.WebhookSubscription}
+// @Failure 500 {object} web.ErrorResponse
+// @Router /webhooks [get]
+func (w *Webhook) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subs, err := w.webhookService.ListSubscriptions(c)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, subs)
+	}
+}
+
+// Get godoc
+// @Summary Get a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} web.Envelope{data=domain.WebhookSubscription}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /webhooks/{id} [get]
+func (w *Webhook) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		sub, err := w.webhookService.GetSubscription(c, id)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, sub, link("/webhooks/%d", id))
+	}
+}
+
+// Create godoc
+// @Summary Subscribe to events
+// @Description The events of the given types are posted to url as JSON, signed with secret.
+// @Description A delivery that is not answered with a 2xx status is retried with an exponential backoff.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param body body WebhookRequest true "Subscription to create"
+// @Success 201 {object} web.Envelope{data=domain.WebhookSubscription}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /webhooks [post]
+func (w *Webhook) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req WebhookRequest
+		if !bind(c, &req) {
+			return
+		}
+
+		sub, err := w.webhookService.CreateSubscription(c, req.toSubscription())
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+		created(c, sub, link("/webhooks/%d", sub.ID))
+	}
+}
+
+// Update godoc
+// @Summary Update a webhook subscription
+// @Description Only the fields present in the body are changed.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param body body WebhookPatch true "Fields to update"
+// @Success 200 {object} web.Envelope{data=domain.WebhookSubscription}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 422 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /webhooks/{id} [patch]
+func (w *Webhook) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		current, err := w.webhookService.GetSubscription(c, id)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+
+		req := subscriptionToRequest(current)
+		if !bind(c, &req) {
+			return
+		}
+
+		sub := req.toSubscription()
+		sub.ID, sub.CreatedAt = id, current.CreatedAt
+		sub, err = w.webhookService.UpdateSubscription(c, sub)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, sub, link("/webhooks/%d", id))
+	}
+}
+
+// Delete godoc
+// @Summary Delete a webhook subscription
+// @Description Deletes the subscription and its deliveries.
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 204
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (w *Webhook) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		if err := w.webhookService.DeleteSubscription(c, id); err != nil {
+			w.writeError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Deliveries godoc
+// @Summary List webhook deliveries
+// @Description Lists the deliveries, newest first. The dead deliveries, which ran out of attempts, are the dead-letter list.
+// @Tags webhooks
+// @Produce json
+// @Param status query string false "Status of the deliveries" Enums(pending, delivered, dead)
+// @Success 200 {object} web.Envelope{data=[]domain.WebhookDelivery}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /webhooks/deliveries [get]
+func (w *Webhook) Deliveries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := c.Query("status")
+		switch status {
+		case "", webhook.StatusPending, webhook.StatusDelivered, webhook.StatusDead:
+		default:
+			web.Error(c, http.StatusBadRequest, ErrInvalidDeliveryStatus)
+			return
+		}
+
+		deliveries, err := w.webhookService.ListDeliveries(c, status)
+		if err != nil {
+			web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+			return
+		}
+		web.Collection(c, deliveries)
+	}
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery
+// @Description Attempts the delivery at once, whatever its status, and returns its outcome.
+// @Description A failed redelivery is retried like a new delivery.
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} web.Envelope{data=domain.WebhookDelivery}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /webhooks/deliveries/{id}/redeliver [post]
+func (w *Webhook) Redeliver() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := pathID(c)
+		if !ok {
+			return
+		}
+
+		d, err := w.webhookService.Redeliver(c, id)
+		if err != nil {
+			w.writeError(c, err)
+			return
+		}
+		web.Resource(c, http.StatusOK, d, link("/webhooks/deliveries/%d", id))
+	}
+}
+
+// writeError maps the errors of the webhook service to a response.
+func (w *Webhook) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		web.Error(c, http.StatusNotFound, ErrWebhookNotFound)
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		web.Error(c, http.StatusNotFound, ErrWebhookDeliveryNotFound)
+	case errors.Is(err, webhook.ErrInvalidURL), errors.Is(err, webhook.ErrMissingSecret),
+		errors.Is(err, webhook.ErrNoEventTypes), errors.Is(err, webhook.ErrUnknownEventType):
+		web.Error(c, http.StatusUnprocessableEntity, err.Error())
+	default:
+		web.Error(c, http.StatusInternalServerError, ErrInternalServer)
+	}
+}
+
+func (r WebhookRequest) toSubscription() domain.WebhookSubscription {
+	return domain.WebhookSubscription{
+		URL:        r.URL,
+		Secret:     r.Secret,
+		EventTypes: r.EventTypes,
+	}
+}
+
+func subscriptionToRequest(s domain.WebhookSubscription) WebhookRequest {
+	return WebhookRequest{
+		URL:        s.URL,
+		Secret:     s.Secret,
+		EventTypes: s.EventTypes,
+	}
+}
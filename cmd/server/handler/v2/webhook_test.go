@@ -0,0 +1,232 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/webhook"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newWebhookRouter(service webhook.Service) *gin.Engine {
+	h := NewWebhook(service)
+	r := gin.New()
+	r.GET("/api/v2/webhooks", h.GetAll())
+	r.POST("/api/v2/webhooks", h.Create())
+	r.GET("/api/v2/webhooks/deliveries", h.Deliveries())
+	r.POST("/api/v2/webhooks/deliveries/:id/redeliver", h.Redeliver())
+	r.GET("/api/v2/webhooks/:id", h.Get())
+	r.PATCH("/api/v2/webhooks/:id", h.Update())
+	r.DELETE("/api/v2/webhooks/:id", h.Delete())
+	return r
+}
+
+func TestWebhook_Create(t *testing.T) {
+	createdAt := time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+
+	t.Run("it should create a subscription without returning its secret", func(t *testing.T) {
+		// Arrange
+		sub := domain.WebhookSubscription{URL: "https://erp.example.com/hooks", Secret: "s3cr3t",
+			EventTypes: []string{webhook.EventPurchaseOrderCreated}}
+		saved := sub
+		saved.ID, saved.CreatedAt = 3, createdAt
+		service := &webhook.ServiceMock{}
+		service.On("CreateSubscription", mock.Anything, sub).Return(saved, nil)
+		r := newWebhookRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/webhooks",
+			strings.NewReader(`{"url":"https://erp.example.com/hooks","secret":"s3cr3t","event_types":["purchase_order.created"]}`))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "/api/v2/webhooks/3", response.Header().Get("Location"))
+		assert.JSONEq(t, `{"data":{"id":3,"url":"https://erp.example.com/hooks","event_types":["purchase_order.created"],
+			"created_at":"2026-10-18T15:04:05Z"},"meta":{},"links":{"self":"/api/v2/webhooks/3"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 422 for an invalid subscription", func(t *testing.T) {
+		// Arrange
+		service := &webhook.ServiceMock{}
+		service.On("CreateSubscription", mock.Anything, mock.Anything).
+			Return(domain.WebhookSubscription{}, fmt.Errorf("%w: seller.created", webhook.ErrUnknownEventType))
+		r := newWebhookRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/webhooks",
+			strings.NewReader(`{"url":"https://erp.example.com/hooks","secret":"s3cr3t","event_types":["seller.created"]}`))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"unknown event type: seller.created"}`, response.Body.String())
+	})
+
+	t.Run("it should return 422 when the secret is missing", func(t *testing.T) {
+		// Arrange
+		r := newWebhookRouter(&webhook.ServiceMock{})
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/webhooks",
+			strings.NewReader(`{"url":"https://erp.example.com/hooks","event_types":["purchase_order.created"]}`))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"secret is required"}`, response.Body.String())
+	})
+}
+
+func TestWebhook_Update(t *testing.T) {
+	createdAt := time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+	stored := domain.WebhookSubscription{ID: 3, URL: "https://erp.example.com/hooks", Secret: "s3cr3t",
+		EventTypes: []string{webhook.EventPurchaseOrderCreated}, CreatedAt: createdAt}
+
+	t.Run("it should change only the fields in the body", func(t *testing.T) {
+		// Arrange
+		changed := stored
+		changed.EventTypes = []string{webhook.EventBatchCreated}
+		service := &webhook.ServiceMock{}
+		service.On("GetSubscription", mock.Anything, 3).Return(stored, nil)
+		service.On("UpdateSubscription", mock.Anything, changed).Return(changed, nil)
+		r := newWebhookRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/webhooks/3", strings.NewReader(`{"event_types":["product_batch.created"]}`))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":{"id":3,"url":"https://erp.example.com/hooks","event_types":["product_batch.created"],
+			"created_at":"2026-10-18T15:04:05Z"},"meta":{},"links":{"self":"/api/v2/webhooks/3"}}`, response.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("it should return 404 when the subscription does not exist", func(t *testing.T) {
+		// Arrange
+		service := &webhook.ServiceMock{}
+		service.On("GetSubscription", mock.Anything, 4).Return(domain.WebhookSubscription{}, webhook.ErrNotFound)
+		r := newWebhookRouter(service)
+		request := httptest.NewRequest(http.MethodPatch, "/api/v2/webhooks/4", strings.NewReader(`{}`))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"webhook subscription not found"}`, response.Body.String())
+	})
+}
+
+func TestWebhook_Deliveries(t *testing.T) {
+	createdAt := time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+
+	t.Run("it should list the dead deliveries", func(t *testing.T) {
+		// Arrange
+		dead := []domain.WebhookDelivery{{ID: 5, SubscriptionID: 3, EventType: webhook.EventBatchCreated,
+			Payload: []byte(`{"type":"product_batch.created"}`), Status: webhook.StatusDead, Attempts: 8,
+			NextAttemptAt: createdAt, LastError: "unexpected status 500", CreatedAt: createdAt}}
+		service := &webhook.ServiceMock{}
+		service.On("ListDeliveries", mock.Anything, webhook.StatusDead).Return(dead, nil)
+		r := newWebhookRouter(service)
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/webhooks/deliveries?status=dead", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"data":[{"id":5,"subscription_id":3,"event_type":"product_batch.created",
+			"payload":{"type":"product_batch.created"},"status":"dead","attempts":8,
+			"next_attempt_at":"2026-10-18T15:04:05Z","last_error":"unexpected status 500","created_at":"2026-10-18T15:04:05Z"}],
+			"meta":{"count":1},"links":{"self":"/api/v2/webhooks/deliveries?status=dead"}}`, response.Body.String())
+	})
+
+	t.Run("it should return 400 for an unknown status", func(t *testing.T) {
+		// Arrange
+		r := newWebhookRouter(&webhook.ServiceMock{})
+		request := httptest.NewRequest(http.MethodGet, "/api/v2/webhooks/deliveries?status=failed", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"`+ErrInvalidDeliveryStatus+`"}`, response.Body.String())
+	})
+}
+
+func TestWebhook_Redeliver(t *testing.T) {
+	t.Run("it should return the redelivered delivery", func(t *testing.T) {
+		// Arrange
+		deliveredAt := time.Date(2026, time.October, 18, 16, 0, 0, 0, time.UTC)
+		delivered := domain.WebhookDelivery{ID: 5, SubscriptionID: 3, EventType: webhook.EventBatchCreated,
+			Payload: []byte(`{}`), Status: webhook.StatusDelivered, Attempts: 1, NextAttemptAt: deliveredAt,
+			CreatedAt: deliveredAt, DeliveredAt: &deliveredAt}
+		service := &webhook.ServiceMock{}
+		service.On("Redeliver", mock.Anything, 5).Return(delivered, nil)
+		r := newWebhookRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/webhooks/deliveries/5/redeliver", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"status":"delivered"`)
+	})
+
+	t.Run("it should return 404 when the delivery does not exist", func(t *testing.T) {
+		// Arrange
+		service := &webhook.ServiceMock{}
+		service.On("Redeliver", mock.Anything, 6).Return(domain.WebhookDelivery{}, webhook.ErrDeliveryNotFound)
+		r := newWebhookRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/webhooks/deliveries/6/redeliver", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"not_found","message":"webhook delivery not found"}`, response.Body.String())
+	})
+
+	t.Run("it should return 500 when the delivery cannot be read", func(t *testing.T) {
+		// Arrange
+		service := &webhook.ServiceMock{}
+		service.On("Redeliver", mock.Anything, 6).Return(domain.WebhookDelivery{}, errors.New("connection refused"))
+		r := newWebhookRouter(service)
+		request := httptest.NewRequest(http.MethodPost, "/api/v2/webhooks/deliveries/6/redeliver", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		serveHTTP(t, r, response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+	})
+}
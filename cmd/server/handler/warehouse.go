@@ -8,6 +8,7 @@ import (
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
@@ -25,9 +26,9 @@ func NewWarehouse(w warehouse.Service) *Warehouse {
 // @Summary Get the warehouses by ID, returns error if the warehouse doesn't exists.
 // @Tags warehouses
 // @Produce json
-// @Success 200 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Success 200 {object} web.DataResponse{data=domain.Warehouse}
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Param id path int true "Warehouse ID"
 // @Router /warehouses/{id} [get]
 func (w *Warehouse) Get() gin.HandlerFunc {
@@ -59,9 +60,10 @@ func (w *Warehouse) Get() gin.HandlerFunc {
 // ShowGetAll godoc
 // @Summary Get all the warehouses available.
 // @Tags warehouses
-// @Produce json
-// @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(json, csv, xlsx)
+// @Success 200 {object} web.DataResponse{data=[]domain.Warehouse}
+// @Failure 500 {object} web.MessageResponse
 // @Router /warehouses [get]
 func (w *Warehouse) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -71,6 +73,9 @@ func (w *Warehouse) GetAll() gin.HandlerFunc {
 			return
 		}
 
+		if web.Table(c, warehouses) {
+			return
+		}
 		c.JSON(http.StatusOK, map[string]interface{}{
 			"data": warehouses,
 		})
@@ -82,10 +87,11 @@ func (w *Warehouse) GetAll() gin.HandlerFunc {
 // @Tags warehouses
 // @Produce json
 // @Accept json
-// @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Failure 422 {object} map[string]interface{}
-// @Param body body Warehouse true "Warehouse struct"
+// @Success 201 {object} web.DataResponse{data=domain.Warehouse}
+// @Failure 409 {object} web.MessageResponse
+// @Failure 422 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
+// @Param body body domain.Warehouse true "Warehouse struct"
 // @Router /warehouses [post]
 func (w *Warehouse) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -140,10 +146,11 @@ func (w *Warehouse) Create() gin.HandlerFunc {
 // @Tags warehouses
 // @Produce json
 // @Accept json
-// @Success 200 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Success 200 {object} web.DataResponse{data=domain.Warehouse}
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Param id path int true "Warehouse ID"
+// @Param body body domain.Warehouse true "Warehouse fields to update"
 // @Router /warehouses/{id} [patch]
 func (w *Warehouse) Update() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -181,9 +188,10 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 // ShowUpdate godoc
 // @Summary Delete the warehouses by ID, returns error if the warehouse doesn't exists.
 // @Tags warehouses
-// @Success 204 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Produce json
+// @Success 204
+// @Failure 404 {object} web.MessageResponse
+// @Failure 500 {object} web.MessageResponse
 // @Param id path int true "Warehouse ID"
 // @Router /warehouses/{id} [delete]
 func (w *Warehouse) Delete() gin.HandlerFunc {
@@ -43,7 +43,7 @@ func TestHandlerIntegration_WarehouseRead(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -71,7 +71,7 @@ func TestHandlerIntegration_WarehouseRead(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
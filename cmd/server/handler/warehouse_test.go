@@ -61,7 +61,7 @@ func TestHandler_WarehouseRead(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -98,7 +98,7 @@ func TestHandler_WarehouseRead(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -126,7 +126,7 @@ func TestHandler_WarehouseRead(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -149,7 +149,7 @@ func TestHandler_WarehouseRead(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -181,7 +181,7 @@ func TestHandler_WarehouseDelete(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -209,7 +209,7 @@ func TestHandler_WarehouseDelete(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -232,7 +232,7 @@ func TestHandler_WarehouseDelete(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -274,7 +274,7 @@ func TestHandler_WarehouseCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -303,7 +303,7 @@ func TestHandler_WarehouseCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -340,7 +340,7 @@ func TestHandler_WarehouseCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -377,7 +377,7 @@ func TestHandler_WarehouseCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -422,14 +422,14 @@ func TestHandler_WarehouseUpdate(t *testing.T) {
 		service.On("Get", mock.Anything, 1).Return(oldWarehouse, nil)
 
 		handler := NewWarehouse(service)
-		server.PUT("/api/v1/warehouses/:id", handler.Update())
+		server.PATCH("/api/v1/warehouses/:id", handler.Update())
 
 		body, _ := json.Marshal(whouse)
-		req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/warehouses/%d", expectedWarehouse.ID), bytes.NewBuffer([]byte(body)))
+		req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/warehouses/%d", expectedWarehouse.ID), bytes.NewBuffer([]byte(body)))
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -453,14 +453,14 @@ func TestHandler_WarehouseUpdate(t *testing.T) {
 		service.On("Get", mock.Anything, warehouseID).Return(domain.Warehouse{}, warehouse.ErrNotFound)
 
 		handler := NewWarehouse(service)
-		server.PUT("/api/v1/warehouses/:id", handler.Update())
+		server.PATCH("/api/v1/warehouses/:id", handler.Update())
 
 		body, _ := json.Marshal(whouse)
-		req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/warehouses/%d", warehouseID), bytes.NewBuffer([]byte(body)))
+		req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/warehouses/%d", warehouseID), bytes.NewBuffer([]byte(body)))
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -478,12 +478,12 @@ func TestHandler_WarehouseUpdate(t *testing.T) {
 		service := &warehouse.ServiceMock{}
 
 		handler := NewWarehouse(service)
-		server.PUT("/api/v1/warehouses/:id", handler.Update())
-		req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/warehouses/"+warehouseID), nil)
+		server.PATCH("/api/v1/warehouses/:id", handler.Update())
+		req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/warehouses/"+warehouseID), nil)
 		res := httptest.NewRecorder()
 
 		//Act
-		server.ServeHTTP(res, req)
+		serveHTTP(t, server, res, req)
 
 		//Assert
 		assert.NoError(t, err)
@@ -2,6 +2,8 @@ package main
 
 import (
 	"database/sql"
+	"net"
+	"os"
 
 	"github.com/davidop97/apiGo/cmd/server/routes"
 	"github.com/gin-gonic/gin"
@@ -12,7 +14,9 @@ import (
 // @title API GO
 // @version 1.0
 // @description This API manage many products of any company.
-// @host localhost:8080/api/v1
+// @host localhost:8080
+// @BasePath /api/v1
+// @schemes http
 func main() {
 	// NO MODIFICAR
 	db, err := sql.Open("mysql", "mysql_apigo_user:MySql_ApiGo#97@/mysqlapigo")
@@ -25,6 +29,22 @@ func main() {
 	router := routes.NewRouter(eng, db)
 	router.MapRoutes()
 
+	// The gRPC API listens on its own port, GRPC_PORT or 9090 by default.
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		panic(err)
+	}
+	grpcServer := router.GRPCServer()
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+
 	if err := eng.Run(); err != nil {
 		panic(err)
 	}
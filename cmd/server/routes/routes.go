@@ -1,26 +1,43 @@
 package routes
 
 import (
+	"context"
 	"database/sql"
+	"os"
+	"time"
 
 	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
 
+	"github.com/davidop97/apiGo/cmd/server/graph"
 	"github.com/davidop97/apiGo/cmd/server/handler"
+	v2 "github.com/davidop97/apiGo/cmd/server/handler/v2"
+	"github.com/davidop97/apiGo/cmd/server/rpc"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/web"
 
+	"github.com/davidop97/apiGo/internal/activity"
+	"github.com/davidop97/apiGo/internal/audit"
 	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/currencyrate"
 
 	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/events"
 
 	"github.com/davidop97/apiGo/internal/buyer"
 	"github.com/davidop97/apiGo/internal/carries"
 	"github.com/davidop97/apiGo/internal/employee"
 	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/producttype"
 	"github.com/davidop97/apiGo/internal/purchase_order"
 	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/telemetry"
 
 	"github.com/davidop97/apiGo/internal/seller"
 	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/internal/webhook"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 
 	//import docs for swagger
 	swaggerFiles "github.com/swaggo/files"
@@ -28,16 +45,53 @@ import (
 
 	//import docs for swagger
 	_ "github.com/davidop97/apiGo/docs"
+	_ "github.com/davidop97/apiGo/docs/v2"
+)
+
+// The /api/v1 routes are deprecated in favour of /api/v2 and answer with the
+// Deprecation and Sunset headers until they are removed.
+var (
+	v1DeprecatedSince = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	v1Sunset          = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
 )
 
 type Router interface {
 	MapRoutes()
+	// GRPCServer returns the gRPC API backed by the services of the routes,
+	// so it must be called after MapRoutes.
+	GRPCServer() *grpc.Server
 }
 
 type router struct {
 	eng *gin.Engine
 	rg  *gin.RouterGroup
+	v2  *gin.RouterGroup
 	db  *sql.DB
+
+	// audit records the mutations of every service.
+	audit audit.Service
+
+	// events carries the events the relay reads from the outbox.
+	events events.EventBus
+
+	// webhooks delivers the events of the services to their subscriptions.
+	webhooks webhook.Service
+
+	// cache keeps the hot lookups of reference data for cacheTTL. It is nil
+	// when caching is off.
+	cache    cache.Cache
+	cacheTTL time.Duration
+
+	// currencyRates converts the prices of the product reports.
+	currencyRates currencyrate.Service
+
+	// activity is the live feed of warehouse activity the inbound order,
+	// batch and section services publish to.
+	activity activity.Feed
+
+	// services collects the services built for the REST routes so that
+	// /graphql and the gRPC API share them.
+	services graph.Services
 }
 
 func NewRouter(eng *gin.Engine, db *sql.DB) Router {
@@ -47,10 +101,18 @@ func NewRouter(eng *gin.Engine, db *sql.DB) Router {
 func (r *router) MapRoutes() {
 	r.setGroup()
 
+	r.buildCache()
+	r.buildAuditRoutes()
+	r.buildEventBus()
+	r.buildWebhookRoutes()
+	r.buildActivityRoutes()
 	r.buildSellerRoutes()
 	r.buildlocalityRoutes()
+	r.buildProductTypeRoutes()
+	r.buildCurrencyRateRoutes()
 	r.buildProductRoutes()
 	r.buildSectionRoutes()
+	r.buildTelemetryRoutes()
 	r.buildWarehouseRoutes()
 	r.buildEmployeeRoutes()
 	r.buildBuyerRoutes()
@@ -58,16 +120,161 @@ func (r *router) MapRoutes() {
 	r.buildInboudOrderRoutes()
 	r.buildBatchRoutes()
 	r.buildPORoutes()
+	r.buildGraphQLRoutes()
 }
 
 func (r *router) setGroup() {
-	r.rg = r.eng.Group("/api/v1")
+	// The services are given the *gin.Context, which only carries the values
+	// web.RequestMeta stores in the request context with the fallback.
+	r.eng.ContextWithFallback = true
+	r.eng.Use(web.RequestMeta())
+	r.rg = r.eng.Group("/api/v1", web.Deprecated(v1DeprecatedSince, v1Sunset, "/api/v2"))
+	r.v2 = r.eng.Group("/api/v2")
+	r.v2.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.InstanceName("v2")))
+}
+
+// buildCache builds the cache of the localities, product types, products,
+// sections and warehouses read by id, kept for CACHE_TTL (a Go duration, 5m by default).
+// CACHE=lru, the default, keeps them in the process; CACHE=redis in the Redis
+// server at REDIS_URL, shared by the API instances; CACHE=off reads them from
+// the database every time.
+func (r *router) buildCache() {
+	r.cacheTTL = durationEnv("CACHE_TTL", cache.DefaultTTL)
+	switch c := os.Getenv("CACHE"); c {
+	case "", "lru":
+		r.cache = cache.NewLRU(cache.DefaultSize)
+	case "redis":
+		var err error
+		if r.cache, err = cache.ConnectRedis(os.Getenv("REDIS_URL")); err != nil {
+			panic(err)
+		}
+	case "off":
+	default:
+		panic("unknown CACHE " + c)
+	}
+}
+
+// The repositories of the reference data read through the cache, if any.
+// They share it, so the writes of one invalidate the entries of all.
+
+func (r *router) localities() locality.Repository {
+	repo := locality.NewRepository(r.db)
+	if r.cache == nil {
+		return repo
+	}
+	return locality.NewCachedRepository(repo, r.cache, r.cacheTTL)
+}
+
+func (r *router) productTypes() producttype.Repository {
+	repo := producttype.NewRepository(r.db)
+	if r.cache == nil {
+		return repo
+	}
+	return producttype.NewCachedRepository(repo, r.cache, r.cacheTTL)
+}
+
+func (r *router) products() product.Repository {
+	repo := product.NewRepositoryWithLookups(r.db, r.productTypes())
+	if r.cache == nil {
+		return repo
+	}
+	return product.NewCachedRepository(repo, r.cache, r.cacheTTL)
+}
+
+func (r *router) sections() section.Repository {
+	repo := section.NewRepositoryWithLookups(r.db, r.productTypes(), r.warehouses())
+	if r.cache == nil {
+		return repo
+	}
+	return section.NewCachedRepository(repo, r.cache, r.cacheTTL)
+}
+
+func (r *router) warehouses() warehouse.Repository {
+	repo := warehouse.NewRepository(r.db)
+	if r.cache == nil {
+		return repo
+	}
+	return warehouse.NewCachedRepository(repo, r.cache, r.cacheTTL)
+}
+
+// buildAuditRoutes builds the audit log the other services record their
+// mutations in, so it must be called before them. AUDIT_HASH_CHAIN=true makes
+// the log tamper evident.
+func (r *router) buildAuditRoutes() {
+	repo := audit.NewRepository(r.db)
+	r.audit = audit.NewService(repo, audit.Options{Chain: os.Getenv("AUDIT_HASH_CHAIN") == "true"})
+
+	v2Handler := v2.NewAudit(r.audit)
+	r.v2.GET("/audit", v2Handler.List())
+	r.v2.GET("/audit/verify", v2Handler.Verify())
+}
+
+// buildEventBus builds the bus the events of the outbox are published to and
+// starts relaying them every OUTBOX_RELAY_INTERVAL (a Go duration, 1s by
+// default). EVENT_BUS=nats publishes them to the NATS server at NATS_URL,
+// where the handlers of the processes sharing NATS_QUEUE ("apigo" by default)
+// handle each event once; the default, memory, keeps them in the process.
+func (r *router) buildEventBus() {
+	interval := durationEnv("OUTBOX_RELAY_INTERVAL", time.Second)
+	switch bus := os.Getenv("EVENT_BUS"); bus {
+	case "", "memory":
+		r.events = events.NewMemoryBus()
+	case "nats":
+		queue := os.Getenv("NATS_QUEUE")
+		if queue == "" {
+			queue = "apigo"
+		}
+		var err error
+		if r.events, err = events.ConnectNATS(os.Getenv("NATS_URL"), events.NATSOptions{Queue: queue}); err != nil {
+			panic(err)
+		}
+	default:
+		panic("unknown EVENT_BUS " + bus)
+	}
+
+	relay := outbox.NewRelay(outbox.NewRepository(r.db), r.events, outbox.RelayOptions{})
+	// The relay runs for as long as the server does.
+	go relay.Run(context.Background(), interval)
+}
+
+// buildWebhookRoutes builds the webhooks, which receive the events of the
+// bus, so it must be called after buildEventBus. The deliveries are attempted
+// in the background every WEBHOOK_DELIVERY_INTERVAL (a Go duration, 10s by
+// default) and as soon as an event is received.
+func (r *router) buildWebhookRoutes() {
+	interval := durationEnv("WEBHOOK_DELIVERY_INTERVAL", 10*time.Second)
+	repo := webhook.NewRepository(r.db)
+	r.webhooks = webhook.NewService(repo, webhook.Options{})
+	if err := webhook.Forward(r.events, r.webhooks); err != nil {
+		panic(err)
+	}
+	// The deliveries run for as long as the server does.
+	go r.webhooks.Run(context.Background(), interval)
+
+	v2Handler := v2.NewWebhook(r.webhooks)
+	r.v2.GET("/webhooks", v2Handler.GetAll())
+	r.v2.POST("/webhooks", v2Handler.Create())
+	r.v2.GET("/webhooks/deliveries", v2Handler.Deliveries())
+	r.v2.POST("/webhooks/deliveries/:id/redeliver", v2Handler.Redeliver())
+	r.v2.GET("/webhooks/:id", v2Handler.Get())
+	r.v2.PATCH("/webhooks/:id", v2Handler.Update())
+	r.v2.DELETE("/webhooks/:id", v2Handler.Delete())
+}
+
+// buildActivityRoutes builds the live feed of warehouse activity, which the
+// services publish to, so it must be called before their routes.
+func (r *router) buildActivityRoutes() {
+	r.activity = activity.NewFeed(activity.DefaultSize)
+
+	handler := handler.NewActivity(r.activity)
+	r.rg.GET("/events/stream", handler.Stream())
 }
 
 func (r *router) buildSellerRoutes() {
 	// Example
-	repo := seller.NewRepository(r.db)
-	service := seller.NewService(repo)
+	repo := seller.NewRepositoryWithLookups(r.db, r.localities())
+	service := seller.NewAuditedService(seller.NewService(repo), r.audit)
+	r.services.Seller = service
 	handler := handler.NewSeller(service)
 	r.rg.GET("/seller", handler.GetAll())
 	r.rg.GET("/seller/:id", handler.Get())
@@ -75,24 +282,69 @@ func (r *router) buildSellerRoutes() {
 	r.rg.POST("/seller", handler.Create())
 	r.rg.PATCH("/seller/:id", handler.Update())
 
+	v2Handler := v2.NewSeller(service)
+	r.v2.GET("/sellers", v2Handler.GetAll())
+	r.v2.GET("/sellers/:id", v2Handler.Get())
+	r.v2.POST("/sellers", v2Handler.Create())
+	r.v2.POST("/sellers/import", v2Handler.Import())
+	r.v2.PATCH("/sellers/:id", v2Handler.Update())
+	r.v2.DELETE("/sellers/:id", v2Handler.Delete())
+	r.v2.POST("/sellers/:id/restore", v2Handler.Restore())
+
 }
 
 func (r *router) buildlocalityRoutes() {
-	repo := locality.NewRepository(r.db)
-	service := locality.NewService(repo)
+	repo := r.localities()
+	service := locality.NewAuditedService(locality.NewService(repo), r.audit)
+	r.services.Locality = service
 	handler := handler.NewLocality(service)
 	r.rg.GET("/localities/:id", handler.GetLocalityById())
 	r.rg.GET("/localities/", handler.GetAll())
 	r.rg.POST("/localities/", handler.Create())
 	r.rg.GET("/localities/reportSellers", handler.GetReportSellers())
+
+	v2Handler := v2.NewLocality(service)
+	r.v2.GET("/localities", v2Handler.GetAll())
+	r.v2.GET("/localities/:id", v2Handler.Get())
+	r.v2.POST("/localities", v2Handler.Create())
+	r.v2.POST("/localities/import", v2Handler.Import())
+	r.v2.GET("/localities/seller-reports", v2Handler.SellerReports())
+	r.v2.GET("/localities/:id/seller-report", v2Handler.SellerReport())
+}
+
+func (r *router) buildProductTypeRoutes() {
+	repo := r.productTypes()
+	service := producttype.NewAuditedService(producttype.NewService(repo), r.audit)
+
+	v2Handler := v2.NewProductType(service)
+	r.v2.GET("/product-types", v2Handler.GetAll())
+	r.v2.GET("/product-types/:id", v2Handler.Get())
+	r.v2.POST("/product-types", v2Handler.Create())
+	r.v2.PATCH("/product-types/:id", v2Handler.Update())
+	r.v2.DELETE("/product-types/:id", v2Handler.Delete())
+}
+
+// buildCurrencyRateRoutes builds the currency rates the product reports are
+// converted with, so it must be called before buildProductRoutes.
+func (r *router) buildCurrencyRateRoutes() {
+	repo := currencyrate.NewRepository(r.db)
+	r.currencyRates = currencyrate.NewAuditedService(currencyrate.NewService(repo), r.audit)
+
+	v2Handler := v2.NewCurrencyRate(r.currencyRates)
+	r.v2.GET("/currency-rates", v2Handler.GetAll())
+	r.v2.GET("/currency-rates/:currency", v2Handler.Get())
+	r.v2.PUT("/currency-rates/:currency", v2Handler.Set())
+	r.v2.DELETE("/currency-rates/:currency", v2Handler.Delete())
 }
 
 func (r *router) buildProductRoutes() {
-	repo := product.NewRepository(r.db)
-	service := product.NewService(repo)
+	repo := r.products()
+	service := product.NewAuditedService(product.NewServiceWithRates(repo, r.currencyRates), r.audit)
+	r.services.Product = service
 	handler := handler.NewProduct(service)
 	prodGroup := r.rg.Group("/products")
 	prodGroup.GET("/", handler.GetAll())
+	prodGroup.GET("/search", handler.Search())
 	prodGroup.GET("/:id", handler.Get())
 	prodGroup.POST("/", handler.Create())
 	prodGroup.PATCH("/:id", handler.Update())
@@ -101,14 +353,34 @@ func (r *router) buildProductRoutes() {
 
 	//routes for productRecords
 	r.rg.POST("/productRecords", handler.CreateProductRecord())
+	r.rg.POST("/productRecords/bulk", handler.CreateProductRecordsBulk())
 	prodGroup.GET("/reportRecords", handler.GetProductRecord())
+	prodGroup.GET("/:id/records", handler.GetRecords())
+
+	v2Handler := v2.NewProduct(service)
+	r.v2.GET("/products", v2Handler.GetAll())
+	r.v2.GET("/products/search", v2Handler.Search())
+	r.v2.GET("/products/:id", v2Handler.Get())
+	r.v2.POST("/products", v2Handler.Create())
+	r.v2.POST("/products/import", v2Handler.Import())
+	r.v2.PATCH("/products/:id", v2Handler.Update())
+	r.v2.DELETE("/products/:id", v2Handler.Delete())
+	r.v2.POST("/products/:id/restore", v2Handler.Restore())
+	r.v2.POST("/products/records/bulk", v2Handler.UpdatePrices())
+	r.v2.POST("/products/:id/records", v2Handler.CreateRecord())
+	r.v2.GET("/products/:id/records", v2Handler.Records())
+	r.v2.GET("/products/:id/price", v2Handler.Price())
+	r.v2.GET("/products/record-reports", v2Handler.RecordReports())
+	r.v2.GET("/products/:id/record-report", v2Handler.RecordReport())
+	r.v2.GET("/sellers/:id/products", v2Handler.BySeller(r.services.Seller))
 
 	r.rg.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
 
 func (r *router) buildSectionRoutes() {
-	repo := section.NewRepository(r.db)
-	service := section.NewService(repo)
+	repo := r.sections()
+	service := section.NewLiveService(section.NewAuditedService(section.NewService(repo), r.audit), r.activity)
+	r.services.Section = service
 	handler := handler.NewSection(service)
 	sectGroup := r.rg.Group("/sections")
 	sectGroup.GET("/", handler.GetAll())
@@ -117,11 +389,35 @@ func (r *router) buildSectionRoutes() {
 	sectGroup.DELETE("/:id", handler.Delete())
 	sectGroup.PATCH("/:id", handler.Update())
 	sectGroup.GET("/reportProducts", handler.ProductCount())
+
+	v2Handler := v2.NewSection(service)
+	r.v2.GET("/sections", v2Handler.GetAll())
+	r.v2.GET("/sections/:id", v2Handler.Get())
+	r.v2.POST("/sections", v2Handler.Create())
+	r.v2.PATCH("/sections/:id", v2Handler.Update())
+	r.v2.DELETE("/sections/:id", v2Handler.Delete())
+	r.v2.GET("/sections/product-reports", v2Handler.ProductReports())
+	r.v2.GET("/sections/:id/product-report", v2Handler.ProductReport())
+}
+
+// buildTelemetryRoutes builds the ingestion of the temperature readings of
+// the sections, which set their current temperature.
+func (r *router) buildTelemetryRoutes() {
+	repo := telemetry.NewRepository(r.db)
+	if r.cache != nil {
+		repo = telemetry.NewCachedRepository(repo, r.cache)
+	}
+	service := telemetry.NewAuditedService(telemetry.NewService(repo, r.sections()), r.audit)
+
+	v2Handler := v2.NewTelemetry(service)
+	r.v2.POST("/sections/:id/readings", v2Handler.Ingest())
+	r.v2.GET("/sections/:id/readings", v2Handler.Readings())
 }
 
 func (r *router) buildWarehouseRoutes() {
-	repo := warehouse.NewRepository(r.db)
-	service := warehouse.NewService(repo)
+	repo := r.warehouses()
+	service := warehouse.NewAuditedService(warehouse.NewService(repo), r.audit)
+	r.services.Warehouse = service
 	warehouseHandler := handler.NewWarehouse(service)
 	warehouseRouter := r.rg.Group("/warehouses")
 	warehouseRouter.GET("/", warehouseHandler.GetAll())
@@ -129,22 +425,43 @@ func (r *router) buildWarehouseRoutes() {
 	warehouseRouter.POST("/", warehouseHandler.Create())
 	warehouseRouter.PATCH("/:id", warehouseHandler.Update())
 	warehouseRouter.DELETE("/:id", warehouseHandler.Delete())
+
+	v2Handler := v2.NewWarehouse(service)
+	r.v2.GET("/warehouses", v2Handler.GetAll())
+	r.v2.GET("/warehouses/:id", v2Handler.Get())
+	r.v2.POST("/warehouses", v2Handler.Create())
+	r.v2.PATCH("/warehouses/:id", v2Handler.Update())
+	r.v2.DELETE("/warehouses/:id", v2Handler.Delete())
+	r.v2.POST("/warehouses/:id/restore", v2Handler.Restore())
+	r.v2.GET("/warehouses/:id/sections", v2Handler.Sections())
+	r.v2.GET("/warehouses/:id/summary", v2Handler.Summary())
 }
 
 func (r *router) buildEmployeeRoutes() {
 	repo := employee.NewRepository(r.db)
-	service := employee.NewService(repo)
+	service := employee.NewAuditedService(employee.NewService(repo), r.audit)
+	r.services.Employee = service
 	handler := handler.NewEmployee(service)
 	r.rg.GET("/employees", handler.GetAll())
 	r.rg.GET("/employees/:id", handler.Get())
 	r.rg.POST("/employees", handler.Create())
 	r.rg.PATCH("/employees/:id", handler.Update())
 	r.rg.DELETE("/employees/:id", handler.Delete())
+
+	v2Handler := v2.NewEmployee(service)
+	r.v2.GET("/employees", v2Handler.GetAll())
+	r.v2.GET("/employees/:id", v2Handler.Get())
+	r.v2.POST("/employees", v2Handler.Create())
+	r.v2.PATCH("/employees/:id", v2Handler.Update())
+	r.v2.DELETE("/employees/:id", v2Handler.Delete())
+	r.v2.POST("/employees/:id/restore", v2Handler.Restore())
+	r.v2.GET("/warehouses/:id/employees", v2Handler.ByWarehouse(r.services.Warehouse))
 }
 
 func (r *router) buildBuyerRoutes() {
 	repo := buyer.NewRepository(r.db)
-	service := buyer.NewService(repo)
+	service := buyer.NewAuditedService(buyer.NewService(repo), r.audit)
+	r.services.Buyer = service
 	handler := handler.NewBuyer(service)
 	//r.rg.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	r.rg.GET("/buyers", handler.GetAll())
@@ -152,39 +469,114 @@ func (r *router) buildBuyerRoutes() {
 	r.rg.DELETE("/buyers/:id", handler.Delete())
 	r.rg.POST("/buyers", handler.Create())
 	r.rg.PATCH("/buyers/:id", handler.Update())
+
+	v2Handler := v2.NewBuyer(service)
+	r.v2.GET("/buyers", v2Handler.GetAll())
+	r.v2.GET("/buyers/:id", v2Handler.Get())
+	r.v2.POST("/buyers", v2Handler.Create())
+	r.v2.POST("/buyers/import", v2Handler.Import())
+	r.v2.PATCH("/buyers/:id", v2Handler.Update())
+	r.v2.DELETE("/buyers/:id", v2Handler.Delete())
+	r.v2.POST("/buyers/:id/restore", v2Handler.Restore())
 }
 
 func (r *router) buildCarriesRoutes() {
 	repo := carries.NewRepository(r.db)
-	service := carries.NewService(repo)
+	service := carries.NewAuditedService(carries.NewService(repo), r.audit)
+	r.services.Carry = service
 	handler := handler.NewCarry(service)
 	//r.rg.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	r.rg.GET("/carries", handler.GetAll())
 	r.rg.POST("/carries", handler.Save())
 	r.rg.GET("/localities/reportCarries", handler.GetCarriesByLocality())
+
+	v2Handler := v2.NewCarry(service)
+	r.v2.GET("/carries", v2Handler.GetAll())
+	r.v2.POST("/carries", v2Handler.Create())
+	r.v2.GET("/localities/carry-reports", v2Handler.LocalityReports())
+	r.v2.GET("/localities/:id/carry-report", v2Handler.LocalityReport())
 }
 func (r *router) buildInboudOrderRoutes() {
 	repo := inboudorder.NewRepository(r.db)
-	service := inboudorder.NewService(repo)
+	service := inboudorder.NewLiveService(inboudorder.NewAuditedService(inboudorder.NewService(repo), r.audit), r.activity)
+	r.services.InboundOrder = service
 	handler := handler.NewInboudOrder(service)
 	r.rg.GET("/employees/reportInboundOrders", handler.GenerateReport())
 	r.rg.GET("/employees/reportInboundOrder", handler.GetAllReports())
 	r.rg.POST("/inboundOrders", handler.CreateInboundOrder())
+
+	v2Handler := v2.NewInboundOrder(service)
+	r.v2.POST("/inbound-orders", v2Handler.Create())
+	r.v2.GET("/employees/inbound-order-reports", v2Handler.Reports())
+	r.v2.GET("/employees/:id/inbound-order-report", v2Handler.Report())
 }
+
+// buildBatchRoutes must be called after buildSectionRoutes, whose service
+// finds the warehouse of the batches published to the live feed. The batches
+// whose section breaks their cold chain are rejected. Their stock is added to the capacity of
+// their section, whose cached copy is removed.
 func (r *router) buildBatchRoutes() {
-	repo := batch.NewRepository(r.db)
-	service := batch.NewService(repo)
+	products, sections := r.products(), r.sections()
+	repo := batch.NewRepositoryWithLookups(r.db, products, sections)
+	if r.cache != nil {
+		repo = batch.NewCachedRepository(repo, r.cache)
+	}
+	service := batch.NewServiceWithColdChain(repo, products, sections)
+	service = batch.NewLiveService(batch.NewAuditedService(service, r.audit), r.services.Section, r.activity)
+	r.services.Batch = service
 	handler := handler.NewProductBatch(service)
 	batchGroup := r.rg.Group("/productBatches")
 	batchGroup.GET("/", handler.GetAll())
 	batchGroup.POST("/", handler.Create())
+
+	v2Handler := v2.NewBatch(service)
+	r.v2.GET("/product-batches", v2Handler.GetAll())
+	r.v2.POST("/product-batches", v2Handler.Create())
+	r.v2.POST("/product-batches/:id/consume", v2Handler.Consume())
+	r.v2.GET("/products/:id/product-batches", v2Handler.ByProduct(r.services.Product))
 }
 
 // purchase order route
 func (r *router) buildPORoutes() {
 	repo := purchase_order.NewRepository(r.db)
-	service := purchase_order.NewService(repo)
+	service := purchase_order.NewAuditedService(purchase_order.NewService(repo), r.audit)
+	r.services.PurchaseOrder = service
 	handler := handler.NewPurchaseOrder(service)
 	r.rg.POST("/purchaseOrders", handler.Create())
 	r.rg.GET("/buyers/reportPurchaseOrders", handler.ReportPurchaseOrdersByBuyer())
+
+	v2Handler := v2.NewPurchaseOrder(service)
+	r.v2.POST("/purchase-orders", v2Handler.Create())
+	r.v2.GET("/buyers/purchase-order-reports", v2Handler.Reports())
+	r.v2.GET("/buyers/:id/purchase-order-report", v2Handler.Report())
+	r.v2.GET("/buyers/:id/purchase-orders", v2Handler.ByBuyer(r.services.Buyer))
+}
+
+// durationEnv returns the Go duration in the environment variable name, or
+// def when it is not set. It panics when the variable is not a duration.
+func durationEnv(name string, def time.Duration) time.Duration {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (r *router) buildGraphQLRoutes() {
+	handler := graph.NewHandler(r.services)
+	r.eng.POST("/graphql", handler.Serve())
+}
+
+func (r *router) GRPCServer() *grpc.Server {
+	return rpc.NewServer(rpc.Services{
+		Product:       r.services.Product,
+		Section:       r.services.Section,
+		Batch:         r.services.Batch,
+		InboundOrder:  r.services.InboundOrder,
+		PurchaseOrder: r.services.PurchaseOrder,
+	})
 }
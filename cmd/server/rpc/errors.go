@@ -0,0 +1,241 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/money"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// serviceErrors maps the errors of the services to the status of the response.
+var serviceErrors = []struct {
+	err     error
+	code    codes.Code
+	message string
+}{
+	{product.ErrNotFound, codes.NotFound, "product not found"},
+	{product.ErrProductCodeExists, codes.AlreadyExists, "product_code already exists"},
+	{product.ErrProductTypeNotFound, codes.FailedPrecondition, "product type does not exist"},
+	{product.ErrRecordPredatesLatest, codes.FailedPrecondition, "last_update_date predates the latest record of the product"},
+	{money.ErrOutOfRange, codes.InvalidArgument, "price out of range"},
+	{batch.ErrDuplicateBatchNumber, codes.AlreadyExists, "batch_number already exists"},
+	{batch.ErrProductNotFound, codes.FailedPrecondition, "product does not exist"},
+	{batch.ErrSectionNotFound, codes.FailedPrecondition, "section does not exist"},
+	{batch.ErrProductTypeMismatch, codes.FailedPrecondition, "product is not of the product type of the section"},
+	{batch.ErrColdChain, codes.FailedPrecondition, "the section breaks the cold chain of the batch"},
+	{batch.ErrSectionFull, codes.FailedPrecondition, "current_quantity exceeds the maximum capacity of the section"},
+	{section.ErrNotFound, codes.NotFound, "section not found"},
+	{section.ErrDuplicateSectNumber, codes.AlreadyExists, "section_number already exists"},
+	{section.ErrProductTypeNotFound, codes.FailedPrecondition, "product type does not exist"},
+	{section.ErrWarehouseNotFound, codes.FailedPrecondition, "warehouse does not exist"},
+	{inboudorder.ErrEmployeeNotFound, codes.NotFound, "employee not found"},
+	{inboudorder.ErrInboundOrderAlreadyExists, codes.AlreadyExists, "order_number already exists"},
+	{inboudorder.ErrEmployeeDoesNotExists, codes.FailedPrecondition, "employee does not exist"},
+	{inboudorder.ErrWarehouseDoesNotExists, codes.FailedPrecondition, "warehouse does not exist"},
+	{purchase_order.ErrPurchaseOrderAlreadyExists, codes.AlreadyExists, "order_number already exists"},
+	{purchase_order.ErrBuyerIDNotExists, codes.FailedPrecondition, "buyer does not exist"},
+	{purchase_order.ErrProductsRecordIDNotExits, codes.FailedPrecondition, "product record does not exist"},
+}
+
+// toStatus returns the status of an error of the services. Errors the services
+// do not declare are reported without their message.
+func toStatus(err error) error {
+	for _, se := range serviceErrors {
+		if errors.Is(err, se.err) {
+			return status.Error(se.code, se.message)
+		}
+	}
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// notFound returns the status of a missing entity of the given kind.
+func notFound(kind string) error {
+	return status.Error(codes.NotFound, kind+" not found")
+}
+
+// checkID converts the id of a request to the integer id of the services.
+func checkID(id int64) (int, error) {
+	if id < 1 {
+		return 0, status.Error(codes.InvalidArgument, "id must be a positive integer")
+	}
+	return int(id), nil
+}
+
+// checkOptionalID is checkID for the filter of the reports, where 0 stands
+// for every entity.
+func checkOptionalID(id int64) (int, error) {
+	if id == 0 {
+		return 0, nil
+	}
+	return checkID(id)
+}
+
+// merge copies the fields of mask from src to dst. An empty mask stands for
+// every field set in src.
+func merge(dst, src proto.Message, mask *fieldmaskpb.FieldMask) error {
+	if len(mask.GetPaths()) == 0 {
+		proto.Merge(dst, src)
+		return nil
+	}
+	if !mask.IsValid(src) {
+		return status.Error(codes.InvalidArgument, "update_mask contains unknown fields")
+	}
+	from, to := src.ProtoReflect(), dst.ProtoReflect()
+	for _, path := range mask.GetPaths() {
+		fd := from.Descriptor().Fields().ByName(protoreflect.Name(path))
+		to.Set(fd, from.Get(fd))
+	}
+	return nil
+}
+
+var validate = newValidator()
+
+// newValidator returns a validator with the rules of the messages clients
+// send. The generated structs cannot carry validate tags, so the rules mirror
+// the ones of the REST requests here.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(protoName)
+	v.RegisterStructValidationMapRules(map[string]string{
+		"Description":                    "required",
+		"ExpirationRate":                 "gt=0,lte=100",
+		"FreezingRate":                   "gt=0,lte=100",
+		"Height":                         "gt=0",
+		"Length":                         "gt=0",
+		"NetWeight":                      "gt=0",
+		"ProductCode":                    "required,max=100",
+		"RecommendedFreezingTemperature": "lte=100",
+		"Width":                          "gt=0",
+		"ProductTypeId":                  "gt=0",
+		"SellerId":                       "gte=0",
+	}, &apigov1.Product{})
+	v.RegisterStructValidationMapRules(map[string]string{
+		"ProductId":      "gt=0",
+		"LastUpdateDate": "datetime=2006-01-02",
+		"PurchasePrice":  "gt=0",
+		"SalePrice":      "gt=0",
+	}, &apigov1.ProductRecord{})
+	v.RegisterStructValidationMapRules(map[string]string{
+		"SectionNumber":   "gt=0",
+		"CurrentCapacity": "gte=0",
+		"MinimumCapacity": "gte=0",
+		"MaximumCapacity": "gte=0",
+		"WarehouseId":     "gt=0",
+		"ProductTypeId":   "gt=0",
+	}, &apigov1.Section{})
+	v.RegisterStructValidationMapRules(map[string]string{
+		"BatchNumber":       "gt=0",
+		"CurrentQuantity":   "gte=0",
+		"DueDate":           "datetime=2006-01-02",
+		"InitialQuantity":   "gte=0",
+		"ManufacturingDate": "datetime=2006-01-02",
+		"ManufacturingHour": "gte=0,lte=23",
+		"ProductId":         "gt=0",
+		"SectionId":         "gt=0",
+	}, &apigov1.ProductBatch{})
+	v.RegisterStructValidationMapRules(map[string]string{
+		"OrderDate":      "datetime=2006-01-02",
+		"OrderNumber":    "required",
+		"EmployeeId":     "gt=0",
+		"ProductBatchId": "gt=0",
+		"WarehouseId":    "gt=0",
+	}, &apigov1.InboundOrder{})
+	v.RegisterStructValidationMapRules(map[string]string{
+		"OrderNumber":     "required",
+		"OrderDate":       "datetime=2006-01-02",
+		"TrackingCode":    "required",
+		"BuyerId":         "gt=0",
+		"ProductRecordId": "gt=0",
+		"OrderStatusId":   "gt=0",
+	}, &apigov1.PurchaseOrder{})
+	return v
+}
+
+// protoName returns the name of a field in the proto file, e.g. net_weight
+// for NetWeight.
+func protoName(fld reflect.StructField) string {
+	for _, part := range strings.Split(fld.Tag.Get("protobuf"), ",") {
+		if name, ok := strings.CutPrefix(part, "name="); ok {
+			return name
+		}
+	}
+	return fld.Name
+}
+
+// validateMessage checks the rules of a message. Failures are returned as an
+// InvalidArgument status with a BadRequest detail listing every field.
+func validateMessage(m proto.Message) error {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return status.Error(codes.InvalidArgument, "missing "+describeMissing(m))
+	}
+	err := validate.Struct(m)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+
+	messages := make([]string, 0, len(fieldErrors))
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		message := describe(fe)
+		messages = append(messages, message)
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: fe.Field(), Description: message})
+	}
+	st, detailErr := status.New(codes.InvalidArgument, strings.Join(messages, "; ")).
+		WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, strings.Join(messages, "; "))
+	}
+	return st.Err()
+}
+
+// describeMissing names the message a request did not set, e.g. product_batch.
+func describeMissing(m proto.Message) string {
+	if m == nil {
+		return "message"
+	}
+	name := string(m.ProtoReflect().Descriptor().Name())
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+func describe(fe validator.FieldError) string {
+	name := fe.Field()
+	switch fe.Tag() {
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", name, fe.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", name, fe.Param())
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", name, fe.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s characters long", name, fe.Param())
+	case "required":
+		return fmt.Sprintf("%s must not be empty", name)
+	case "datetime":
+		return fmt.Sprintf("%s must match the format YYYY-MM-DD", name)
+	default:
+		return fmt.Sprintf("%s is invalid", name)
+	}
+}
@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+)
+
+type inboundOrderServer struct {
+	apigov1.UnimplementedInboundOrderServiceServer
+	s inboudorder.Service
+}
+
+func (is *inboundOrderServer) CreateInboundOrder(ctx context.Context, req *apigov1.CreateInboundOrderRequest) (*apigov1.InboundOrder, error) {
+	in := req.GetInboundOrder()
+	if err := validateMessage(in); err != nil {
+		return nil, err
+	}
+	order := domain.InboudOrder{
+		OrderDate:      in.GetOrderDate(),
+		OrderNumber:    in.GetOrderNumber(),
+		EmployeeID:     int(in.GetEmployeeId()),
+		ProductBatchID: int(in.GetProductBatchId()),
+		WarehouseID:    int(in.GetWarehouseId()),
+	}
+	id, err := is.s.CreateInboundOrder(ctx, order)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &apigov1.InboundOrder{
+		Id:             int64(id),
+		OrderDate:      order.OrderDate,
+		OrderNumber:    order.OrderNumber,
+		EmployeeId:     int64(order.EmployeeID),
+		ProductBatchId: int64(order.ProductBatchID),
+		WarehouseId:    int64(order.WarehouseID),
+	}, nil
+}
+
+func (is *inboundOrderServer) ListInboundOrderReports(req *apigov1.ListInboundOrderReportsRequest, stream apigov1.InboundOrderService_ListInboundOrderReportsServer) error {
+	id, err := checkOptionalID(req.GetEmployeeId())
+	if err != nil {
+		return err
+	}
+	if id != 0 {
+		report, err := is.s.GenerateReport(stream.Context(), id)
+		if err != nil {
+			return toStatus(err)
+		}
+		if report.Employee == nil {
+			return notFound("employee")
+		}
+		return stream.Send(toInboundOrderReportPB(report))
+	}
+
+	reports, err := is.s.GetAllReports(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, report := range reports {
+		if report.Employee == nil {
+			continue
+		}
+		if err := stream.Send(toInboundOrderReportPB(report)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toInboundOrderReportPB(r inboudorder.Report) *apigov1.InboundOrderReport {
+	return &apigov1.InboundOrderReport{
+		EmployeeId:         int64(r.ID),
+		CardNumberId:       r.CardNumberID,
+		FirstName:          r.FirstName,
+		LastName:           r.LastName,
+		WarehouseId:        int64(r.WarehouseID),
+		InboundOrdersCount: int32(r.InboudOrdersCount),
+	}
+}
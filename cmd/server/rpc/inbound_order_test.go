@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestInboundOrderServer(t *testing.T) {
+	ctx := context.Background()
+	employee := &domain.Employee{ID: 1, CardNumberID: "E1", FirstName: "Ada", LastName: "Lovelace", WarehouseID: 2}
+
+	t.Run("it should create an inbound order through the service", func(t *testing.T) {
+		m := newMocks()
+		m.inboundOrder.On("CreateInboundOrder", mock.Anything, domain.InboudOrder{
+			OrderDate: "2024-01-01", OrderNumber: "IO-1", EmployeeID: 1, ProductBatchID: 2, WarehouseID: 3,
+		}).Return(6, nil)
+
+		order, err := apigov1.NewInboundOrderServiceClient(m.dial(t)).CreateInboundOrder(ctx, &apigov1.CreateInboundOrderRequest{
+			InboundOrder: &apigov1.InboundOrder{OrderDate: "2024-01-01", OrderNumber: "IO-1", EmployeeId: 1, ProductBatchId: 2, WarehouseId: 3},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), order.GetId())
+	})
+
+	t.Run("it should return FailedPrecondition when the employee does not exist", func(t *testing.T) {
+		m := newMocks()
+		m.inboundOrder.On("CreateInboundOrder", mock.Anything, mock.Anything).Return(0, inboudorder.ErrEmployeeDoesNotExists)
+
+		_, err := apigov1.NewInboundOrderServiceClient(m.dial(t)).CreateInboundOrder(ctx, &apigov1.CreateInboundOrderRequest{
+			InboundOrder: &apigov1.InboundOrder{OrderDate: "2024-01-01", OrderNumber: "IO-1", EmployeeId: 1, ProductBatchId: 2, WarehouseId: 3},
+		})
+
+		assertStatus(t, err, codes.FailedPrecondition, "employee does not exist")
+	})
+
+	t.Run("it should stream the report of every employee", func(t *testing.T) {
+		m := newMocks()
+		m.inboundOrder.On("GetAllReports", mock.Anything).Return([]inboudorder.Report{{Employee: employee, InboudOrdersCount: 3}}, nil)
+
+		stream, err := apigov1.NewInboundOrderServiceClient(m.dial(t)).ListInboundOrderReports(ctx, &apigov1.ListInboundOrderReportsRequest{})
+		require.NoError(t, err)
+		reports, err := recvAll(stream.Recv)
+
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, "E1", reports[0].GetCardNumberId())
+		assert.Equal(t, int32(3), reports[0].GetInboundOrdersCount())
+	})
+
+	t.Run("it should return NotFound for the report of a missing employee", func(t *testing.T) {
+		m := newMocks()
+		m.inboundOrder.On("GenerateReport", mock.Anything, 9).Return(inboudorder.Report{}, inboudorder.ErrEmployeeNotFound)
+
+		stream, err := apigov1.NewInboundOrderServiceClient(m.dial(t)).ListInboundOrderReports(ctx, &apigov1.ListInboundOrderReportsRequest{EmployeeId: 9})
+		require.NoError(t, err)
+		_, err = recvAll(stream.Recv)
+
+		assertStatus(t, err, codes.NotFound, "employee not found")
+	})
+}
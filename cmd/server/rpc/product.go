@@ -0,0 +1,174 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/pkg/money"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type productServer struct {
+	apigov1.UnimplementedProductServiceServer
+	s product.Service
+}
+
+func (ps *productServer) GetProduct(ctx context.Context, req *apigov1.GetProductRequest) (*apigov1.Product, error) {
+	id, err := checkID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p, err := ps.s.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProductPB(p), nil
+}
+
+func (ps *productServer) ListProducts(_ *apigov1.ListProductsRequest, stream apigov1.ProductService_ListProductsServer) error {
+	products, err := ps.s.GetAll(stream.Context())
+	if err != nil && !errors.Is(err, product.ErrNotFound) {
+		return toStatus(err)
+	}
+	return sendAll(products, toProductPB, stream.Send)
+}
+
+func (ps *productServer) CreateProduct(ctx context.Context, req *apigov1.CreateProductRequest) (*apigov1.Product, error) {
+	in := req.GetProduct()
+	if err := validateMessage(in); err != nil {
+		return nil, err
+	}
+	p := fromProductPB(in)
+	p.ID = 0
+	id, err := ps.s.Save(ctx, p)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	p.ID = id
+	return toProductPB(p), nil
+}
+
+func (ps *productServer) UpdateProduct(ctx context.Context, req *apigov1.UpdateProductRequest) (*apigov1.Product, error) {
+	id, err := checkID(req.GetProduct().GetId())
+	if err != nil {
+		return nil, err
+	}
+	current, err := ps.s.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	updated := toProductPB(current)
+	if err := merge(updated, req.GetProduct(), req.GetUpdateMask()); err != nil {
+		return nil, err
+	}
+	updated.Id = int64(id)
+	if err := validateMessage(updated); err != nil {
+		return nil, err
+	}
+
+	if err := ps.s.Update(ctx, fromProductPB(updated)); err != nil {
+		return nil, toStatus(err)
+	}
+	return updated, nil
+}
+
+func (ps *productServer) DeleteProduct(ctx context.Context, req *apigov1.DeleteProductRequest) (*emptypb.Empty, error) {
+	id, err := checkID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := ps.s.Delete(ctx, id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (ps *productServer) CreateProductRecord(ctx context.Context, req *apigov1.CreateProductRecordRequest) (*apigov1.ProductRecord, error) {
+	in := req.GetProductRecord()
+	if err := validateMessage(in); err != nil {
+		return nil, err
+	}
+	purchase, err := money.FromFloat(float64(in.GetPurchasePrice()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	sale, err := money.FromFloat(float64(in.GetSalePrice()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	id, err := ps.s.CreateProductRecord(ctx, domain.ProductRecordCreate{
+		LastUpdate:    in.GetLastUpdateDate(),
+		PurchasePrice: purchase,
+		SalePrice:     sale,
+		ProductID:     int(in.GetProductId()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &apigov1.ProductRecord{
+		Id:             int64(id),
+		ProductId:      in.GetProductId(),
+		LastUpdateDate: in.GetLastUpdateDate(),
+		PurchasePrice:  in.GetPurchasePrice(),
+		SalePrice:      in.GetSalePrice(),
+	}, nil
+}
+
+func (ps *productServer) ListProductRecordReports(req *apigov1.ListProductRecordReportsRequest, stream apigov1.ProductService_ListProductRecordReportsServer) error {
+	id, err := checkOptionalID(req.GetProductId())
+	if err != nil {
+		return err
+	}
+	reports, err := ps.s.GetProductRecord(stream.Context(), id)
+	if err != nil && (id != 0 || !errors.Is(err, product.ErrNotFound)) {
+		return toStatus(err)
+	}
+	if id != 0 && len(reports) == 0 {
+		return notFound("product")
+	}
+	return sendAll(reports, func(r domain.ProductRecordGet) *apigov1.ProductRecordReport {
+		return &apigov1.ProductRecordReport{
+			ProductId:   int64(r.ProductID),
+			Description: r.Description,
+			RecordCount: int32(r.RecordCount),
+		}
+	}, stream.Send)
+}
+
+func toProductPB(p domain.Product) *apigov1.Product {
+	return &apigov1.Product{
+		Id:                             int64(p.ID),
+		Description:                    p.Description,
+		ExpirationRate:                 p.ExpirationRate,
+		FreezingRate:                   p.FreezingRate,
+		Height:                         p.Height,
+		Length:                         p.Length,
+		NetWeight:                      p.Netweight,
+		ProductCode:                    p.ProductCode,
+		RecommendedFreezingTemperature: p.RecomFreezTemp,
+		Width:                          p.Width,
+		ProductTypeId:                  int64(p.ProductTypeID),
+		SellerId:                       int64(p.SellerID),
+	}
+}
+
+func fromProductPB(p *apigov1.Product) domain.Product {
+	return domain.Product{
+		ID:             int(p.GetId()),
+		Description:    p.GetDescription(),
+		ExpirationRate: p.GetExpirationRate(),
+		FreezingRate:   p.GetFreezingRate(),
+		Height:         p.GetHeight(),
+		Length:         p.GetLength(),
+		Netweight:      p.GetNetWeight(),
+		ProductCode:    p.GetProductCode(),
+		RecomFreezTemp: p.GetRecommendedFreezingTemperature(),
+		Width:          p.GetWidth(),
+		ProductTypeID:  int(p.GetProductTypeId()),
+		SellerID:       int(p.GetSellerId()),
+	}
+}
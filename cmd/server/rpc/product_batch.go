@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/domain"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+)
+
+type productBatchServer struct {
+	apigov1.UnimplementedProductBatchServiceServer
+	s batch.Service
+}
+
+func (bs *productBatchServer) ListProductBatches(req *apigov1.ListProductBatchesRequest, stream apigov1.ProductBatchService_ListProductBatchesServer) error {
+	var (
+		batches []domain.ProductBatch
+		err     error
+	)
+	if len(req.GetProductIds()) == 0 {
+		batches, err = bs.s.GetAll(stream.Context())
+	} else {
+		ids := make([]int, 0, len(req.GetProductIds()))
+		for _, productID := range req.GetProductIds() {
+			id, err := checkID(productID)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		batches, err = bs.s.GetByProductIDs(stream.Context(), ids)
+	}
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendAll(batches, toProductBatchPB, stream.Send)
+}
+
+func (bs *productBatchServer) CreateProductBatch(ctx context.Context, req *apigov1.CreateProductBatchRequest) (*apigov1.ProductBatch, error) {
+	in := req.GetProductBatch()
+	if err := validateMessage(in); err != nil {
+		return nil, err
+	}
+	b := domain.ProductBatch{
+		BatchNumber:        int(in.GetBatchNumber()),
+		CurrentQuantity:    int(in.GetCurrentQuantity()),
+		CurrentTemperature: int(in.GetCurrentTemperature()),
+		DueDate:            in.GetDueDate(),
+		InitialQuantity:    int(in.GetInitialQuantity()),
+		ManufacturingDate:  in.GetManufacturingDate(),
+		ManufacturingHour:  int(in.GetManufacturingHour()),
+		MinimumTemperature: int(in.GetMinimumTemperature()),
+		ProductID:          int(in.GetProductId()),
+		SectionID:          int(in.GetSectionId()),
+	}
+	saved, err := bs.s.Save(ctx, b)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	b.ID = saved.ID
+	return toProductBatchPB(b), nil
+}
+
+func toProductBatchPB(b domain.ProductBatch) *apigov1.ProductBatch {
+	return &apigov1.ProductBatch{
+		Id:                 int64(b.ID),
+		BatchNumber:        int32(b.BatchNumber),
+		CurrentQuantity:    int32(b.CurrentQuantity),
+		CurrentTemperature: int32(b.CurrentTemperature),
+		DueDate:            b.DueDate,
+		InitialQuantity:    int32(b.InitialQuantity),
+		ManufacturingDate:  b.ManufacturingDate,
+		ManufacturingHour:  int32(b.ManufacturingHour),
+		MinimumTemperature: int32(b.MinimumTemperature),
+		ProductId:          int64(b.ProductID),
+		SectionId:          int64(b.SectionID),
+	}
+}
@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/domain"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestProductBatchServer(t *testing.T) {
+	ctx := context.Background()
+	b := domain.ProductBatch{ID: 1, BatchNumber: 100, CurrentQuantity: 5, DueDate: "2024-02-01", InitialQuantity: 5,
+		ManufacturingDate: "2024-01-01", ManufacturingHour: 8, ProductID: 3, SectionID: 4}
+
+	t.Run("it should stream the batches of the requested products", func(t *testing.T) {
+		m := newMocks()
+		m.batch.On("GetByProductIDs", mock.Anything, []int{3, 4}).Return([]domain.ProductBatch{b}, nil)
+
+		stream, err := apigov1.NewProductBatchServiceClient(m.dial(t)).ListProductBatches(ctx, &apigov1.ListProductBatchesRequest{ProductIds: []int64{3, 4}})
+		require.NoError(t, err)
+		batches, err := recvAll(stream.Recv)
+
+		require.NoError(t, err)
+		require.Len(t, batches, 1)
+		assert.Equal(t, int64(3), batches[0].GetProductId())
+		m.batch.AssertNotCalled(t, "GetAll", mock.Anything)
+	})
+
+	t.Run("it should stream every batch without a filter", func(t *testing.T) {
+		m := newMocks()
+		m.batch.On("GetAll", mock.Anything).Return([]domain.ProductBatch{b, b}, nil)
+
+		stream, err := apigov1.NewProductBatchServiceClient(m.dial(t)).ListProductBatches(ctx, &apigov1.ListProductBatchesRequest{})
+		require.NoError(t, err)
+		batches, err := recvAll(stream.Recv)
+
+		require.NoError(t, err)
+		assert.Len(t, batches, 2)
+	})
+
+	t.Run("it should create a batch through the service", func(t *testing.T) {
+		m := newMocks()
+		toSave := b
+		toSave.ID = 0
+		m.batch.On("Save", mock.Anything, toSave).Return(batch.Saved{ID: 8}, nil)
+
+		created, err := apigov1.NewProductBatchServiceClient(m.dial(t)).CreateProductBatch(ctx, &apigov1.CreateProductBatchRequest{ProductBatch: toProductBatchPB(b)})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(8), created.GetId())
+	})
+
+	t.Run("it should return FailedPrecondition when the section does not exist", func(t *testing.T) {
+		m := newMocks()
+		m.batch.On("Save", mock.Anything, mock.Anything).Return(batch.Saved{}, batch.ErrSectionNotFound)
+
+		_, err := apigov1.NewProductBatchServiceClient(m.dial(t)).CreateProductBatch(ctx, &apigov1.CreateProductBatchRequest{ProductBatch: toProductBatchPB(b)})
+
+		assertStatus(t, err, codes.FailedPrecondition, "section does not exist")
+	})
+
+	t.Run("it should validate the dates of a batch", func(t *testing.T) {
+		in := toProductBatchPB(b)
+		in.DueDate = "01/02/2024"
+
+		_, err := apigov1.NewProductBatchServiceClient(newMocks().dial(t)).CreateProductBatch(ctx, &apigov1.CreateProductBatchRequest{ProductBatch: in})
+
+		assertStatus(t, err, codes.InvalidArgument, "due_date must match the format YYYY-MM-DD")
+	})
+}
@@ -0,0 +1,243 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/pkg/money"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var stored = domain.Product{
+	ID: 1, Description: "Milk", ExpirationRate: 0.5, FreezingRate: 0.3, Height: 10, Length: 20, Netweight: 1,
+	ProductCode: "P-1", RecomFreezTemp: -5, Width: 5, ProductTypeID: 2, SellerID: 3,
+}
+
+func TestProductServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should get a product", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("Get", mock.Anything, 1).Return(stored, nil)
+
+		p, err := apigov1.NewProductServiceClient(m.dial(t)).GetProduct(ctx, &apigov1.GetProductRequest{Id: 1})
+
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(toProductPB(stored), p))
+	})
+
+	t.Run("it should return NotFound for a missing product", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("Get", mock.Anything, 9).Return(domain.Product{}, product.ErrNotFound)
+
+		_, err := apigov1.NewProductServiceClient(m.dial(t)).GetProduct(ctx, &apigov1.GetProductRequest{Id: 9})
+
+		assertStatus(t, err, codes.NotFound, "product not found")
+	})
+
+	t.Run("it should reject an id that is not positive", func(t *testing.T) {
+		_, err := apigov1.NewProductServiceClient(newMocks().dial(t)).GetProduct(ctx, &apigov1.GetProductRequest{})
+
+		assertStatus(t, err, codes.InvalidArgument, "id must be a positive integer")
+	})
+
+	t.Run("it should stream every product", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		second := stored
+		second.ID, second.ProductCode = 2, "P-2"
+		m.product.On("GetAll", mock.Anything).Return([]domain.Product{stored, second}, nil)
+
+		// Act
+		stream, err := apigov1.NewProductServiceClient(m.dial(t)).ListProducts(ctx, &apigov1.ListProductsRequest{})
+		require.NoError(t, err)
+		products, err := recvAll(stream.Recv)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, products, 2)
+		assert.Equal(t, "P-2", products[1].GetProductCode())
+	})
+
+	t.Run("it should end the stream without products when there are none", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("GetAll", mock.Anything).Return([]domain.Product(nil), product.ErrNotFound)
+
+		stream, err := apigov1.NewProductServiceClient(m.dial(t)).ListProducts(ctx, &apigov1.ListProductsRequest{})
+		require.NoError(t, err)
+		products, err := recvAll(stream.Recv)
+
+		require.NoError(t, err)
+		assert.Empty(t, products)
+	})
+
+	t.Run("it should create a product through the service", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		in := toProductPB(stored)
+		in.Id = 40
+		toSave := stored
+		toSave.ID = 0
+		m.product.On("Save", mock.Anything, toSave).Return(7, nil)
+
+		// Act
+		p, err := apigov1.NewProductServiceClient(m.dial(t)).CreateProduct(ctx, &apigov1.CreateProductRequest{Product: in})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), p.GetId())
+		m.product.AssertExpectations(t)
+	})
+
+	t.Run("it should describe every invalid field of a product", func(t *testing.T) {
+		// Arrange
+		in := toProductPB(stored)
+		in.Description, in.Height = "", -1
+
+		// Act
+		_, err := apigov1.NewProductServiceClient(newMocks().dial(t)).CreateProduct(ctx, &apigov1.CreateProductRequest{Product: in})
+
+		// Assert
+		assertStatus(t, err, codes.InvalidArgument, "description must not be empty; height must be greater than 0")
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+		badRequest := details[0].(*errdetails.BadRequest)
+		require.Len(t, badRequest.GetFieldViolations(), 2)
+		assert.Equal(t, "height", badRequest.GetFieldViolations()[1].GetField())
+	})
+
+	t.Run("it should reject a request without a product", func(t *testing.T) {
+		_, err := apigov1.NewProductServiceClient(newMocks().dial(t)).CreateProduct(ctx, &apigov1.CreateProductRequest{})
+
+		assertStatus(t, err, codes.InvalidArgument, "missing product")
+	})
+
+	t.Run("it should return AlreadyExists when the product code is taken", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("Save", mock.Anything, mock.Anything).Return(0, product.ErrProductCodeExists)
+
+		_, err := apigov1.NewProductServiceClient(m.dial(t)).CreateProduct(ctx, &apigov1.CreateProductRequest{Product: toProductPB(stored)})
+
+		assertStatus(t, err, codes.AlreadyExists, "product_code already exists")
+	})
+
+	t.Run("it should only update the fields of the mask", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		updated := stored
+		updated.Description = "Oat milk"
+		m.product.On("Get", mock.Anything, 1).Return(stored, nil)
+		m.product.On("Update", mock.Anything, updated).Return(nil)
+
+		// Act
+		p, err := apigov1.NewProductServiceClient(m.dial(t)).UpdateProduct(ctx, &apigov1.UpdateProductRequest{
+			Product:    &apigov1.Product{Id: 1, Description: "Oat milk", ProductCode: "ignored"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "P-1", p.GetProductCode())
+		m.product.AssertExpectations(t)
+	})
+
+	t.Run("it should update the fields set when there is no mask", func(t *testing.T) {
+		m := newMocks()
+		updated := stored
+		updated.Width = 8
+		m.product.On("Get", mock.Anything, 1).Return(stored, nil)
+		m.product.On("Update", mock.Anything, updated).Return(nil)
+
+		_, err := apigov1.NewProductServiceClient(m.dial(t)).UpdateProduct(ctx, &apigov1.UpdateProductRequest{
+			Product: &apigov1.Product{Id: 1, Width: 8},
+		})
+
+		require.NoError(t, err)
+		m.product.AssertExpectations(t)
+	})
+
+	t.Run("it should reject a mask with unknown fields", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("Get", mock.Anything, 1).Return(stored, nil)
+
+		_, err := apigov1.NewProductServiceClient(m.dial(t)).UpdateProduct(ctx, &apigov1.UpdateProductRequest{
+			Product:    &apigov1.Product{Id: 1},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"colour"}},
+		})
+
+		assertStatus(t, err, codes.InvalidArgument, "update_mask contains unknown fields")
+		m.product.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should delete a product", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("Delete", mock.Anything, 1).Return(nil)
+
+		_, err := apigov1.NewProductServiceClient(m.dial(t)).DeleteProduct(ctx, &apigov1.DeleteProductRequest{Id: 1})
+
+		require.NoError(t, err)
+		m.product.AssertExpectations(t)
+	})
+
+	t.Run("it should hide the errors the services do not declare", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("Delete", mock.Anything, 1).Return(errors.New("connection refused"))
+
+		_, err := apigov1.NewProductServiceClient(m.dial(t)).DeleteProduct(ctx, &apigov1.DeleteProductRequest{Id: 1})
+
+		assertStatus(t, err, codes.Internal, "internal server error")
+	})
+
+	t.Run("it should create a product record", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("CreateProductRecord", mock.Anything, domain.ProductRecordCreate{
+			LastUpdate: "2024-01-01", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(12), ProductID: 1,
+		}).Return(5, nil)
+
+		r, err := apigov1.NewProductServiceClient(m.dial(t)).CreateProductRecord(ctx, &apigov1.CreateProductRecordRequest{
+			ProductRecord: &apigov1.ProductRecord{ProductId: 1, LastUpdateDate: "2024-01-01", PurchasePrice: 10, SalePrice: 12},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), r.GetId())
+	})
+
+	t.Run("it should stream the record report of a product", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		m.product.On("GetProductRecord", mock.Anything, 1).
+			Return([]domain.ProductRecordGet{{ProductID: 1, Description: "Milk", RecordCount: 4}}, nil)
+
+		// Act
+		stream, err := apigov1.NewProductServiceClient(m.dial(t)).ListProductRecordReports(ctx, &apigov1.ListProductRecordReportsRequest{ProductId: 1})
+		require.NoError(t, err)
+		reports, err := recvAll(stream.Recv)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, int32(4), reports[0].GetRecordCount())
+	})
+
+	t.Run("it should return NotFound for the report of a missing product", func(t *testing.T) {
+		m := newMocks()
+		m.product.On("GetProductRecord", mock.Anything, 9).Return([]domain.ProductRecordGet(nil), product.ErrNotFound)
+
+		stream, err := apigov1.NewProductServiceClient(m.dial(t)).ListProductRecordReports(ctx, &apigov1.ListProductRecordReportsRequest{ProductId: 9})
+		require.NoError(t, err)
+		_, err = recvAll(stream.Recv)
+
+		assertStatus(t, err, codes.NotFound, "product not found")
+	})
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+)
+
+type purchaseOrderServer struct {
+	apigov1.UnimplementedPurchaseOrderServiceServer
+	s purchase_order.Service
+}
+
+func (ps *purchaseOrderServer) CreatePurchaseOrder(ctx context.Context, req *apigov1.CreatePurchaseOrderRequest) (*apigov1.PurchaseOrder, error) {
+	in := req.GetPurchaseOrder()
+	if err := validateMessage(in); err != nil {
+		return nil, err
+	}
+	po := domain.PurchaseOrder{
+		OrderNumber:     in.GetOrderNumber(),
+		OrderDate:       in.GetOrderDate(),
+		TrackingCode:    in.GetTrackingCode(),
+		BuyerID:         int(in.GetBuyerId()),
+		ProductRecordID: int(in.GetProductRecordId()),
+		OrderStatusID:   int(in.GetOrderStatusId()),
+	}
+	id, err := ps.s.Save(ctx, po)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &apigov1.PurchaseOrder{
+		Id:              int64(id),
+		OrderNumber:     po.OrderNumber,
+		OrderDate:       po.OrderDate,
+		TrackingCode:    po.TrackingCode,
+		BuyerId:         int64(po.BuyerID),
+		ProductRecordId: int64(po.ProductRecordID),
+		OrderStatusId:   int64(po.OrderStatusID),
+	}, nil
+}
+
+func (ps *purchaseOrderServer) ListPurchaseOrderReports(req *apigov1.ListPurchaseOrderReportsRequest, stream apigov1.PurchaseOrderService_ListPurchaseOrderReportsServer) error {
+	id, err := checkOptionalID(req.GetBuyerId())
+	if err != nil {
+		return err
+	}
+	reports, err := ps.s.PurchaseOrdersByBuyer(stream.Context(), id)
+	if err != nil && !errors.Is(err, purchase_order.ErrBuyerIDNotExists) {
+		return toStatus(err)
+	}
+	if id != 0 && len(reports) == 0 {
+		return notFound("buyer")
+	}
+	return sendAll(reports, func(r domain.PurchaseOrdersByBuyer) *apigov1.PurchaseOrderReport {
+		return &apigov1.PurchaseOrderReport{
+			BuyerId:             int64(r.ID),
+			CardNumberId:        r.CardNumberID,
+			FirstName:           r.FirstName,
+			LastName:            r.LastName,
+			PurchaseOrdersCount: int32(r.PurchaseOrdersCount),
+		}
+	}, stream.Send)
+}
@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPurchaseOrderServer(t *testing.T) {
+	ctx := context.Background()
+	in := &apigov1.PurchaseOrder{OrderNumber: "PO-1", OrderDate: "2024-01-01", TrackingCode: "T1", BuyerId: 1, ProductRecordId: 2, OrderStatusId: 1}
+
+	t.Run("it should create a purchase order through the service", func(t *testing.T) {
+		m := newMocks()
+		m.purchaseOrder.On("Save", mock.Anything, domain.PurchaseOrder{
+			OrderNumber: "PO-1", OrderDate: "2024-01-01", TrackingCode: "T1", BuyerID: 1, ProductRecordID: 2, OrderStatusID: 1,
+		}).Return(7, nil)
+
+		po, err := apigov1.NewPurchaseOrderServiceClient(m.dial(t)).CreatePurchaseOrder(ctx, &apigov1.CreatePurchaseOrderRequest{PurchaseOrder: in})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), po.GetId())
+	})
+
+	t.Run("it should return AlreadyExists for a repeated order number", func(t *testing.T) {
+		m := newMocks()
+		m.purchaseOrder.On("Save", mock.Anything, mock.Anything).Return(0, purchase_order.ErrPurchaseOrderAlreadyExists)
+
+		_, err := apigov1.NewPurchaseOrderServiceClient(m.dial(t)).CreatePurchaseOrder(ctx, &apigov1.CreatePurchaseOrderRequest{PurchaseOrder: in})
+
+		assertStatus(t, err, codes.AlreadyExists, "order_number already exists")
+	})
+
+	t.Run("it should stream the report of every buyer", func(t *testing.T) {
+		m := newMocks()
+		m.purchaseOrder.On("PurchaseOrdersByBuyer", mock.Anything, 0).Return([]domain.PurchaseOrdersByBuyer{
+			{ID: 1, CardNumberID: "C1", FirstName: "Ada", LastName: "Lovelace", PurchaseOrdersCount: 2},
+		}, nil)
+
+		stream, err := apigov1.NewPurchaseOrderServiceClient(m.dial(t)).ListPurchaseOrderReports(ctx, &apigov1.ListPurchaseOrderReportsRequest{})
+		require.NoError(t, err)
+		reports, err := recvAll(stream.Recv)
+
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, int32(2), reports[0].GetPurchaseOrdersCount())
+	})
+
+	t.Run("it should return NotFound for the report of a missing buyer", func(t *testing.T) {
+		m := newMocks()
+		m.purchaseOrder.On("PurchaseOrdersByBuyer", mock.Anything, 9).Return([]domain.PurchaseOrdersByBuyer(nil), purchase_order.ErrBuyerIDNotExists)
+
+		stream, err := apigov1.NewPurchaseOrderServiceClient(m.dial(t)).ListPurchaseOrderReports(ctx, &apigov1.ListPurchaseOrderReportsRequest{BuyerId: 9})
+		require.NoError(t, err)
+		_, err = recvAll(stream.Recv)
+
+		assertStatus(t, err, codes.NotFound, "buyer not found")
+	})
+
+	t.Run("it should reject a negative buyer id", func(t *testing.T) {
+		stream, err := apigov1.NewPurchaseOrderServiceClient(newMocks().dial(t)).ListPurchaseOrderReports(ctx, &apigov1.ListPurchaseOrderReportsRequest{BuyerId: -1})
+		require.NoError(t, err)
+		_, err = recvAll(stream.Recv)
+
+		assertStatus(t, err, codes.InvalidArgument, "id must be a positive integer")
+	})
+}
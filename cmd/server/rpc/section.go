@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type sectionServer struct {
+	apigov1.UnimplementedSectionServiceServer
+	s section.Service
+}
+
+func (ss *sectionServer) GetSection(ctx context.Context, req *apigov1.GetSectionRequest) (*apigov1.Section, error) {
+	id, err := checkID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	s, err := ss.s.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toSectionPB(s), nil
+}
+
+func (ss *sectionServer) ListSections(_ *apigov1.ListSectionsRequest, stream apigov1.SectionService_ListSectionsServer) error {
+	sections, err := ss.s.GetAll(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	return sendAll(sections, toSectionPB, stream.Send)
+}
+
+func (ss *sectionServer) CreateSection(ctx context.Context, req *apigov1.CreateSectionRequest) (*apigov1.Section, error) {
+	in := req.GetSection()
+	if err := validateMessage(in); err != nil {
+		return nil, err
+	}
+	s := fromSectionPB(in)
+	s.ID = 0
+	id, err := ss.s.Save(ctx, s)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	s.ID = id
+	return toSectionPB(s), nil
+}
+
+func (ss *sectionServer) UpdateSection(ctx context.Context, req *apigov1.UpdateSectionRequest) (*apigov1.Section, error) {
+	id, err := checkID(req.GetSection().GetId())
+	if err != nil {
+		return nil, err
+	}
+	current, err := ss.s.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	updated := toSectionPB(current)
+	if err := merge(updated, req.GetSection(), req.GetUpdateMask()); err != nil {
+		return nil, err
+	}
+	updated.Id = int64(id)
+	if err := validateMessage(updated); err != nil {
+		return nil, err
+	}
+
+	if err := ss.s.Update(ctx, fromSectionPB(updated)); err != nil {
+		return nil, toStatus(err)
+	}
+	return updated, nil
+}
+
+func (ss *sectionServer) DeleteSection(ctx context.Context, req *apigov1.DeleteSectionRequest) (*emptypb.Empty, error) {
+	id, err := checkID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := ss.s.Delete(ctx, id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (ss *sectionServer) ListSectionProductReports(req *apigov1.ListSectionProductReportsRequest, stream apigov1.SectionService_ListSectionProductReportsServer) error {
+	id, err := checkOptionalID(req.GetSectionId())
+	if err != nil {
+		return err
+	}
+	reports, err := ss.s.ProductCount(stream.Context(), id)
+	if err != nil && (id != 0 || !errors.Is(err, section.ErrNotFound)) {
+		return toStatus(err)
+	}
+	if id != 0 && len(reports) == 0 {
+		return notFound("section")
+	}
+	return sendAll(reports, func(r section.ProdCountResponse) *apigov1.SectionProductReport {
+		return &apigov1.SectionProductReport{
+			SectionId:     int64(r.ID),
+			SectionNumber: int32(r.SectionNumber),
+			ProductCount:  int32(r.ProductCount),
+		}
+	}, stream.Send)
+}
+
+func toSectionPB(s domain.Section) *apigov1.Section {
+	return &apigov1.Section{
+		Id:                 int64(s.ID),
+		SectionNumber:      int32(s.SectionNumber),
+		CurrentTemperature: int32(s.CurrentTemperature),
+		MinimumTemperature: int32(s.MinimumTemperature),
+		CurrentCapacity:    int32(s.CurrentCapacity),
+		MinimumCapacity:    int32(s.MinimumCapacity),
+		MaximumCapacity:    int32(s.MaximumCapacity),
+		WarehouseId:        int64(s.WarehouseID),
+		ProductTypeId:      int64(s.ProductTypeID),
+	}
+}
+
+func fromSectionPB(s *apigov1.Section) domain.Section {
+	return domain.Section{
+		ID:                 int(s.GetId()),
+		SectionNumber:      int(s.GetSectionNumber()),
+		CurrentTemperature: int(s.GetCurrentTemperature()),
+		MinimumTemperature: int(s.GetMinimumTemperature()),
+		CurrentCapacity:    int(s.GetCurrentCapacity()),
+		MinimumCapacity:    int(s.GetMinimumCapacity()),
+		MaximumCapacity:    int(s.GetMaximumCapacity()),
+		WarehouseID:        int(s.GetWarehouseId()),
+		ProductTypeID:      int(s.GetProductTypeId()),
+	}
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestSectionServer(t *testing.T) {
+	ctx := context.Background()
+	current := domain.Section{ID: 2, SectionNumber: 5, CurrentTemperature: 1, MinimumTemperature: -3, CurrentCapacity: 10,
+		MinimumCapacity: 1, MaximumCapacity: 50, WarehouseID: 1, ProductTypeID: 1}
+
+	t.Run("it should stream every section", func(t *testing.T) {
+		m := newMocks()
+		m.section.On("GetAll", mock.Anything).Return([]domain.Section{current}, nil)
+
+		stream, err := apigov1.NewSectionServiceClient(m.dial(t)).ListSections(ctx, &apigov1.ListSectionsRequest{})
+		require.NoError(t, err)
+		sections, err := recvAll(stream.Recv)
+
+		require.NoError(t, err)
+		require.Len(t, sections, 1)
+		assert.Equal(t, int32(5), sections[0].GetSectionNumber())
+	})
+
+	t.Run("it should set a field of the mask to its zero value", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		updated := current
+		updated.CurrentCapacity = 0
+		m.section.On("Get", mock.Anything, 2).Return(current, nil)
+		m.section.On("Update", mock.Anything, updated).Return(nil)
+
+		// Act
+		s, err := apigov1.NewSectionServiceClient(m.dial(t)).UpdateSection(ctx, &apigov1.UpdateSectionRequest{
+			Section:    &apigov1.Section{Id: 2},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"current_capacity"}},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), s.GetCurrentCapacity())
+		m.section.AssertExpectations(t)
+	})
+
+	t.Run("it should return AlreadyExists when the section number is taken", func(t *testing.T) {
+		m := newMocks()
+		m.section.On("Save", mock.Anything, mock.Anything).Return(0, section.ErrDuplicateSectNumber)
+
+		_, err := apigov1.NewSectionServiceClient(m.dial(t)).CreateSection(ctx, &apigov1.CreateSectionRequest{Section: toSectionPB(current)})
+
+		assertStatus(t, err, codes.AlreadyExists, "section_number already exists")
+	})
+
+	t.Run("it should stream the product report of every section", func(t *testing.T) {
+		// Arrange
+		m := newMocks()
+		m.section.On("ProductCount", mock.Anything, 0).Return([]section.ProdCountResponse{
+			{ID: 1, SectionNumber: 5, ProductCount: 3},
+			{ID: 2, SectionNumber: 6, ProductCount: 0},
+		}, nil)
+
+		// Act
+		stream, err := apigov1.NewSectionServiceClient(m.dial(t)).ListSectionProductReports(ctx, &apigov1.ListSectionProductReportsRequest{})
+		require.NoError(t, err)
+		reports, err := recvAll(stream.Recv)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Equal(t, int32(3), reports[0].GetProductCount())
+	})
+
+	t.Run("it should return NotFound for the report of a missing section", func(t *testing.T) {
+		m := newMocks()
+		m.section.On("ProductCount", mock.Anything, 9).Return([]section.ProdCountResponse{}, nil)
+
+		stream, err := apigov1.NewSectionServiceClient(m.dial(t)).ListSectionProductReports(ctx, &apigov1.ListSectionProductReportsRequest{SectionId: 9})
+		require.NoError(t, err)
+		_, err = recvAll(stream.Recv)
+
+		assertStatus(t, err, codes.NotFound, "section not found")
+	})
+}
@@ -0,0 +1,74 @@
+// Package rpc serves the gRPC API defined in proto/apigo/v1. Its servers
+// delegate to the same services as the REST handlers.
+package rpc
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/internal/section"
+	apigov1 "github.com/davidop97/apiGo/pkg/pb/apigo/v1"
+	"github.com/davidop97/apiGo/pkg/web"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+)
+
+// Services are the services the gRPC API delegates to.
+type Services struct {
+	Product       product.Service
+	Section       section.Service
+	Batch         batch.Service
+	InboundOrder  inboudorder.Service
+	PurchaseOrder purchase_order.Service
+}
+
+// NewServer returns a gRPC server with every service of the API registered,
+// along with the reflection service used by tools like grpcurl.
+func NewServer(s Services, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(requestMeta)}, opts...)
+	srv := grpc.NewServer(opts...)
+	apigov1.RegisterProductServiceServer(srv, &productServer{s: s.Product})
+	apigov1.RegisterSectionServiceServer(srv, &sectionServer{s: s.Section})
+	apigov1.RegisterProductBatchServiceServer(srv, &productBatchServer{s: s.Batch})
+	apigov1.RegisterInboundOrderServiceServer(srv, &inboundOrderServer{s: s.InboundOrder})
+	apigov1.RegisterPurchaseOrderServiceServer(srv, &purchaseOrderServer{s: s.PurchaseOrder})
+	reflection.Register(srv)
+	return srv
+}
+
+// requestMeta stores the request ID and the actor of a call in its context,
+// as web.RequestMeta does for the REST routes, from the x-request-id and
+// x-actor metadata.
+func requestMeta(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if id := first(md, web.HeaderRequestID); id != "" {
+		ctx = web.WithRequestID(ctx, id)
+	}
+	if actor := first(md, web.HeaderActor); actor != "" {
+		ctx = web.WithActor(ctx, actor)
+	}
+	return handler(ctx, req)
+}
+
+// first returns the first value of the metadata key, or "" if there is none.
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// sendAll converts every item and sends it on a stream, stopping at the first
+// error of the stream.
+func sendAll[T any, M any](items []T, convert func(T) M, send func(M) error) error {
+	for _, item := range items {
+		if err := send(convert(item)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
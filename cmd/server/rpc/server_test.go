@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// mocks holds a mock of every service the servers use.
+type mocks struct {
+	product       *product.ServiceMock
+	section       *section.ServiceMock
+	batch         *batch.ServiceMock
+	inboundOrder  *inboudorder.ServiceMock
+	purchaseOrder *purchase_order.ServiceMock
+}
+
+func newMocks() *mocks {
+	return &mocks{
+		product:       &product.ServiceMock{},
+		section:       &section.ServiceMock{},
+		batch:         &batch.ServiceMock{},
+		inboundOrder:  &inboudorder.ServiceMock{},
+		purchaseOrder: &purchase_order.ServiceMock{},
+	}
+}
+
+// dial starts a server backed by the mocks on an in-memory listener and
+// returns a connection to it.
+func (m *mocks) dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(Services{
+		Product:       m.product,
+		Section:       m.section,
+		Batch:         m.batch,
+		InboundOrder:  m.inboundOrder,
+		PurchaseOrder: m.purchaseOrder,
+	})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// recvAll reads a server stream until it ends and returns the messages and
+// the error that ended it, if any.
+func recvAll[M any](recv func() (M, error)) ([]M, error) {
+	var list []M
+	for {
+		m, err := recv()
+		if errors.Is(err, io.EOF) {
+			return list, nil
+		}
+		if err != nil {
+			return list, err
+		}
+		list = append(list, m)
+	}
+}
+
+// assertStatus checks the code and message of the status of an error.
+func assertStatus(t *testing.T, err error, code codes.Code, message string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a status: %v", err)
+	assert.Equal(t, code, st.Code())
+	assert.Equal(t, message, st.Message())
+}
+
+func TestNewServer(t *testing.T) {
+	t.Run("it should list every service through reflection", func(t *testing.T) {
+		// Arrange
+		conn := newMocks().dial(t)
+		stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		require.NoError(t, err)
+
+		// Act
+		err = stream.Send(&rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+		})
+		require.NoError(t, err)
+		response, err := stream.Recv()
+		require.NoError(t, err)
+
+		// Assert
+		var names []string
+		for _, s := range response.GetListServicesResponse().GetService() {
+			names = append(names, s.GetName())
+		}
+		sort.Strings(names)
+		assert.Equal(t, []string{
+			"apigo.v1.InboundOrderService",
+			"apigo.v1.ProductBatchService",
+			"apigo.v1.ProductService",
+			"apigo.v1.PurchaseOrderService",
+			"apigo.v1.SectionService",
+			"grpc.reflection.v1alpha.ServerReflection",
+		}, names)
+	})
+}
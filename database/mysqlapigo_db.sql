@@ -153,7 +153,148 @@ create table locality(
 -- Update table sellers: add column locality_id (FK)
 ALTER TABLE `sellers` ADD locality_id int not null  DEFAULT 0;
 
+-- Soft delete (added with restore): a row with deleted_at set is hidden from
+-- reads until it is restored, or purged once the retention period is over.
+ALTER TABLE `products` ADD `deleted_at` datetime(6) DEFAULT NULL;
+ALTER TABLE `sellers` ADD `deleted_at` datetime(6) DEFAULT NULL;
+ALTER TABLE `buyers` ADD `deleted_at` datetime(6) DEFAULT NULL;
+ALTER TABLE `warehouses` ADD `deleted_at` datetime(6) DEFAULT NULL;
+ALTER TABLE `employees` ADD `deleted_at` datetime(6) DEFAULT NULL;
 
+-- table `audit_log` (added for the audit log): a row per mutation of an entity.
+-- The JSON snapshots are stored as text so the hash chain can be checked
+-- against the exact bytes that were hashed. Rows are never updated or deleted.
+CREATE TABLE `audit_log` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `recorded_at` datetime(6) NOT NULL,
+    `actor` varchar(255) NOT NULL,
+    `request_id` varchar(64) NOT NULL,
+    `entity` varchar(64) NOT NULL,
+    `entity_id` int(11) NOT NULL,
+    `operation` varchar(16) NOT NULL,
+    `before_json` mediumtext,
+    `after_json` mediumtext,
+    `changes_json` mediumtext NOT NULL,
+    `prev_hash` char(64) NOT NULL DEFAULT '',
+    `hash` char(64) NOT NULL DEFAULT '',
+    PRIMARY KEY (`id`),
+    KEY `idx_audit_log_entity` (`entity`, `entity_id`)
+);
 
+CREATE TRIGGER `audit_log_no_update` BEFORE UPDATE ON `audit_log` FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append-only';
 
+CREATE TRIGGER `audit_log_no_delete` BEFORE DELETE ON `audit_log` FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append-only';
 
+-- table `webhook_subscriptions` (added for webhooks): event_types is a comma
+-- separated list of the event types posted to url.
+CREATE TABLE `webhook_subscriptions` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `url` varchar(2048) NOT NULL,
+    `secret` varchar(255) NOT NULL,
+    `event_types` varchar(1024) NOT NULL,
+    `created_at` datetime(6) NOT NULL,
+    PRIMARY KEY (`id`)
+);
+
+-- table `webhook_deliveries` (added for webhooks): an event to post to a
+-- subscription, retried until it is delivered or dead.
+CREATE TABLE `webhook_deliveries` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `subscription_id` int(11) NOT NULL,
+    `event_type` varchar(64) NOT NULL,
+    `payload` mediumtext NOT NULL,
+    `status` varchar(16) NOT NULL,
+    `attempts` int(11) NOT NULL DEFAULT 0,
+    `next_attempt_at` datetime(6) NOT NULL,
+    `last_error` text,
+    `created_at` datetime(6) NOT NULL,
+    `delivered_at` datetime(6) DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_webhook_deliveries_due` (`status`, `next_attempt_at`),
+    CONSTRAINT `fk_webhook_deliveries_subscription` FOREIGN KEY (`subscription_id`) REFERENCES `webhook_subscriptions` (`id`) ON DELETE CASCADE
+);
+
+-- table `outbox` (added for the event bus): the events of the changes, written
+-- in the same transaction as them and published by the relay. published_at
+-- stays NULL until the event is published. A failed event is retried from
+-- next_attempt_at, and dead_at is set once the relay gave up on it.
+CREATE TABLE `outbox` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `event_id` varchar(36) NOT NULL,
+    `event_type` varchar(64) NOT NULL,
+    `version` int(11) NOT NULL,
+    `aggregate_id` int(11) NOT NULL,
+    `payload` mediumtext NOT NULL,
+    `occurred_at` datetime(6) NOT NULL,
+    `published_at` datetime(6) DEFAULT NULL,
+    `attempts` int(11) NOT NULL DEFAULT 0,
+    `last_error` text,
+    `next_attempt_at` datetime(6) DEFAULT NULL,
+    `dead_at` datetime(6) DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `event_id` (`event_id`),
+    KEY `idx_outbox_pending` (`published_at`, `id`)
+);
+
+-- table `product_types` (added for product types): the types the products and
+-- the sections reference with id_product_type. A section only stores batches
+-- of the products of its type.
+CREATE TABLE `product_types` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `description` varchar(255) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `description` (`description`)
+);
+
+-- Money (added with currencies): the prices of the product records are exact
+-- decimals in the ISO 4217 currency of the record, USD when it has none.
+-- currency_rates values one unit of every other currency in USD.
+ALTER TABLE `productsRecord` MODIFY `purchase_price` decimal(19,4) NOT NULL;
+ALTER TABLE `productsRecord` MODIFY `sale_price` decimal(19,4) NOT NULL;
+ALTER TABLE `productsRecord` ADD `currency` char(3) NOT NULL DEFAULT 'USD';
+
+CREATE TABLE `currency_rates` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `currency` char(3) NOT NULL,
+    `rate` decimal(18,8) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `currency` (`currency`)
+);
+
+-- Units (added with units of measure): the dimensions of the products are in
+-- centimetres and their weight in kilograms, whatever unit the API got them in.
+ALTER TABLE `products` RENAME COLUMN `lenght` TO `length`;
+
+-- Cold chain (added with the cold chain check): the JSON of the override of a
+-- batch stored in a section that breaks its cold chain, with the reason, the
+-- actor and the violations overridden. NULL for the other batches.
+ALTER TABLE `productBatches` ADD `cold_chain_override` text DEFAULT NULL;
+
+-- Telemetry (added with the temperature sensors): the readings of the sensors
+-- of the sections, which keep current_temperature at the latest one, and the
+-- excursions of the sections below their minimum temperature. An excursion is
+-- open until its section warms up past the hysteresis, then gets its end.
+CREATE TABLE `section_readings` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `section_id` int(11) NOT NULL,
+    `sensor` varchar(64) NOT NULL DEFAULT '',
+    `temperature` decimal(6,2) NOT NULL,
+    `recorded_at` datetime(6) NOT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_section_readings_time` (`section_id`, `recorded_at`),
+    CONSTRAINT `fk_section_readings_section` FOREIGN KEY (`section_id`) REFERENCES `sections` (`id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `temperature_excursions` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `section_id` int(11) NOT NULL,
+    `minimum_temperature` int(11) NOT NULL,
+    `lowest_temperature` decimal(6,2) NOT NULL,
+    `started_at` datetime(6) NOT NULL,
+    `ended_at` datetime(6) DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_temperature_excursions_open` (`section_id`, `ended_at`),
+    CONSTRAINT `fk_temperature_excursions_section` FOREIGN KEY (`section_id`) REFERENCES `sections` (`id`) ON DELETE CASCADE
+);
@@ -1,8 +1,21 @@
 USE `mysqlapigo`;
 
 -- DML
+-- product types data
+INSERT INTO `product_types` (`id`, `description`) VALUES
+(1, 'Dairy'),
+(2, 'Frozen Vegetables'),
+(3, 'Bakery'),
+(4, 'Canned Goods'),
+(5, 'Beverages'),
+(6, 'Frozen Meals'),
+(7, 'Fresh Fruit'),
+(8, 'Eggs'),
+(9, 'Confectionery'),
+(10, 'Plant-Based Drinks');
+
 -- products data
-INSERT INTO `products` (`id`, `description`, `expiration_rate`, `freezing_rate`, `height`, `lenght`, `netweight`, `product_code`, `recommended_freezing_temperature`, `width`, `id_product_type`, `id_seller`) VALUES
+INSERT INTO `products` (`id`, `description`, `expiration_rate`, `freezing_rate`, `height`, `length`, `netweight`, `product_code`, `recommended_freezing_temperature`, `width`, `id_product_type`, `id_seller`) VALUES
 (1, 'Fresh Milk', 0.1, 0.05, 25.0, 10.0, 1.0, 'MILK1001', -4.0, 10.0, 1, 101),
 (2, 'Frozen Peas', 0.02, 0.2, 5.0, 15.0, 0.5, 'PEAS2002', -18.0, 8.0, 2, 102),
 (3, 'Whole Wheat Bread', 0.15, 0, 10.0, 20.0, 0.75, 'BRED3003', 0.0, 15.0, 3, 103),
@@ -150,4 +163,14 @@ VALUES
 ('2021-01-10', 100, 110, 1),
 ('2022-02-20', 200, 230, 2),
 ('2023-03-05', 300, 340, 3),
-('2024-01-11', 400, 500, 3);
\ No newline at end of file
+('2024-01-11', 400, 500, 3);
+
+-- currency rates data: the value of one unit in USD
+INSERT INTO currency_rates (currency, rate)
+VALUES
+('EUR', 1.08),
+('GBP', 1.27),
+('ARS', 0.001),
+('BRL', 0.18),
+('COP', 0.00025),
+('MXN', 0.055);
@@ -0,0 +1,13 @@
+// Package database holds the MySQL scripts used to build the mysqlapigo
+// database. The DDL is embedded so tests can build the same schema on an
+// embedded engine without reading files relative to the working directory.
+package database
+
+import (
+	_ "embed"
+)
+
+// Schema is the DDL script in mysqlapigo_db.sql.
+//
+//go:embed mysqlapigo_db.sql
+var Schema string
@@ -19,25 +19,53 @@ const docTemplate = `{
             "get": {
                 "description": "get all buyers",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "domain.Buyer",
                     "buyers"
                 ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Buyer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     }
                 }
@@ -61,7 +89,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Buyer"
+                            "$ref": "#/definitions/handler.RequestBodyBuyerCreate"
                         }
                     }
                 ],
@@ -69,46 +97,55 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Buyer"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     }
                 }
             }
         },
-        "/buyers/reportPurchaseOrders/": {
+        "/buyers/reportPurchaseOrders": {
             "get": {
                 "description": "get a buyer",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "purchase_orders"
@@ -119,35 +156,60 @@ const docTemplate = `{
                         "description": "Purchase Orders By Buyer id",
                         "name": "id",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.PurchaseOrdersByBuyer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     }
                 }
@@ -176,35 +238,46 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Buyer"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     }
                 }
             },
             "delete": {
                 "description": "delete a buyer",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "domain.Buyer",
                     "buyers"
@@ -220,31 +293,24 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     }
                 }
@@ -284,29 +350,43 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Buyer"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     }
                 }
@@ -315,30 +395,61 @@ const docTemplate = `{
         "/carries": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "carries"
                 ],
                 "summary": "Get all carries, returns empty list if there are no carries.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Carries"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
             },
             "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -346,75 +457,116 @@ const docTemplate = `{
                     "carries"
                 ],
                 "summary": "Save a carry, returns error if the carry already exists or if the data is incorrect.",
+                "parameters": [
+                    {
+                        "description": "Carry to be created",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CarryRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Carries"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
             }
         },
-        "/carries/locality": {
+        "/employees": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "carries"
+                    "domain.Employee"
+                ],
+                "summary": "Get all the employees available or an error if the list is empty.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all carries by locality, returns empty list if there are no carries.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Employee"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
-            }
-        },
-        "/employees": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "domain.Employee"
-                ],
-                "summary": "Get all the employees available or an error if the list is empty.",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    },
-                    "404": {
-                        "description": "Not Found"
-                    }
-                }
             },
             "post": {
                 "consumes": [
@@ -434,19 +586,52 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.Employee"
+                            "$ref": "#/definitions/handler.EmployeeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created"
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Employee"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
                     },
                     "409": {
-                        "description": "Conflict"
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
                     },
                     "422": {
-                        "description": "Unprocessable Entity"
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
                     }
                 }
             }
@@ -454,39 +639,59 @@ const docTemplate = `{
         "/employees/reportInboundOrder": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "inboundOrders"
                 ],
                 "summary": "Get all reports with inboudOrders",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/inboudorder.Report"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -496,7 +701,9 @@ const docTemplate = `{
             "get": {
                 "description": "get inbound orders",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "inboundOrders"
@@ -507,35 +714,54 @@ const docTemplate = `{
                         "description": "Inbound Orders By Employee id",
                         "name": "id",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/inboudorder.Report"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -561,14 +787,47 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Employee"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
                     }
                 }
             },
             "delete": {
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "domain.Employee"
                 ],
@@ -586,8 +845,23 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
                     }
                 }
             },
@@ -609,14 +883,115 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Employee fields to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.EmployeeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Employee"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/stream": {
+            "get": {
+                "description": "Streams the inbound orders received, the product batches created and the changes of capacity of the sections as Server-Sent Events, as they happen.\nEvery event is sent with its id, its type as the event name and the JSON of the event as data.\nA client reconnecting with the Last-Event-ID header first receives the events it missed that the server still keeps.\nThe ids are \u003cepoch\u003e-\u003csequence\u003e, the epoch changing when the server restarts: a client whose last event is of\nanother epoch receives every event the server keeps.",
+                "produces": [
+                    "application/json",
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream the warehouse activity.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only the events of this warehouse",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "inbound_order.received",
+                                "product_batch.created",
+                                "section.capacity_changed"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only the events of these types",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received, e.g. 1697025600000-42",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Server-Sent Events, the data of each an activity.Event",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -645,36 +1020,43 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.InboudOrder"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -684,32 +1066,59 @@ const docTemplate = `{
             "get": {
                 "description": "Get all the localities available or an error if the list is empty or an internal error occurs.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "domain.Locality"
                 ],
                 "summary": "Get all the localities available.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "List of all localities",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Locality"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Locality"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
                         "description": "Localities not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Server Internal error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
                 }
@@ -738,118 +1147,229 @@ const docTemplate = `{
                     "201": {
                         "description": "New Locality created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Locality"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Locality"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "409": {
                         "description": "locality already exists",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "422": {
                         "description": "invalid or missing field",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Server Internal error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/localities/reportSellers": {
+        "/localities/reportCarries": {
             "get": {
-                "description": "Get a report of sellers by locality using its id. Or show all the sellers of all the localities if the id is not provided.",
+                "description": "data holds a single domain.LocalityCarries when id is given and a list of them otherwise.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "domain.Locality"
+                    "carries"
                 ],
-                "summary": "Get a report of sellers by locality.",
+                "summary": "Get all carries by locality, returns empty list if there are no carries.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "locality id",
+                        "description": "Locality ID",
                         "name": "id",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Report of sellers by locality",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ReportSellers"
+                            "$ref": "#/definitions/web.DataResponse"
                         }
                     },
                     "400": {
-                        "description": "error getting the report for the requested ID. Id must be greater than 0",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "sellers not found for the requested ID",
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
-                        "description": "server internal error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
             }
         },
-        "/locality/{id}": {
+        "/localities/reportSellers": {
             "get": {
-                "description": "Get a locality by id or an error if that id not exists or an internal error occurs.",
+                "description": "Get a report of sellers by locality using its id. Or show all the sellers of all the localities if the id is not provided.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "domain.Locality"
                 ],
-                "summary": "Get a locality by id or an error if that id not exists.",
+                "summary": "Get a report of sellers by locality.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "id from the locality",
+                        "description": "locality id",
                         "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report of sellers by locality",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ReportSellers"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error getting the report for the requested ID. Id must be greater than 0",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "sellers not found for the requested ID",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "server internal error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/localities/{id}": {
+            "get": {
+                "description": "Get a locality by id or an error if that id not exists or an internal error occurs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domain.Locality"
+                ],
+                "summary": "Get a locality by id or an error if that id not exists.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id from the locality",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Locality requested",
                         "schema": {
-                            "$ref": "#/definitions/domain.Locality"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Locality"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "invalid id",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Locality not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Server Internal error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
                 }
@@ -868,34 +1388,67 @@ const docTemplate = `{
                 "summary": "Responds with a pong message.",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "pong",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
         "/productBatches": {
             "get": {
-                "description": "Gets a list of all product batches.",
+                "description": "Gets a list of all product batches.\nWith format=ndjson, or Accept: application/x-ndjson, the batches are streamed a JSON object per line as they are read.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/x-ndjson",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "productBatches"
                 ],
                 "summary": "Retrieves all product batches.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "ndjson",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "json (default), ndjson, csv or xlsx, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ProductBatch"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -914,13 +1467,12 @@ const docTemplate = `{
                 "summary": "Creates a new product batch.",
                 "parameters": [
                     {
-                        "format": "json",
                         "description": "Product batch data to create",
-                        "name": "sectionData",
+                        "name": "batchData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/handler.BatchRequest"
                         }
                     }
                 ],
@@ -928,63 +1480,92 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ProductBatch"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
             }
         },
-        "/productrecord": {
-            "get": {
+        "/productRecords": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "productrecords"
                 ],
-                "summary": "Retrieves product records by product ID or all product records if idProduct is 0.",
+                "summary": "Creates a new product record.",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "query"
+                        "description": "Product Record to be created",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProductRecordRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.ProductRecordGet"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.ProductRecordResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "404": {
-                        "description": "Product Not Found",
+                    "409": {
+                        "description": "Product Not Found or record predating the latest one",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid JSON",
                         "schema": {
                             "type": "string"
                         }
@@ -996,8 +1577,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/productRecords/bulk": {
             "post": {
+                "description": "The purchase price is the one of the latest record of each product. If a product cannot be repriced, nothing is saved.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1007,27 +1591,54 @@ const docTemplate = `{
                 "tags": [
                     "productrecords"
                 ],
-                "summary": "Creates a new product record.",
+                "summary": "Creates a record with new prices for every selected product, in one transaction.",
                 "parameters": [
                     {
-                        "description": "Product Record to be created",
-                        "name": "product",
+                        "type": "boolean",
+                        "description": "Preview the new prices without saving them",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Selector and rule",
+                        "name": "records",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ProductRecordCreate"
+                            "$ref": "#/definitions/handler.ProductRecordBulkRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.ProductRecordChange"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid dry_run",
                         "schema": {
-                            "$ref": "#/definitions/domain.ProductRecord"
+                            "type": "string"
                         }
                     },
                     "422": {
-                        "description": "Invalid JSON",
+                        "description": "Invalid JSON, selector or rule, or products that cannot be repriced",
                         "schema": {
                             "type": "string"
                         }
@@ -1044,17 +1655,47 @@ const docTemplate = `{
         "/products": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "products"
                 ],
                 "summary": "Retrieves a list of all products.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "List of all products",
                         "schema": {
-                            "$ref": "#/definitions/domain.Product"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Product"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
@@ -1091,17 +1732,29 @@ const docTemplate = `{
                     "201": {
                         "description": "Created product data",
                         "schema": {
-                            "$ref": "#/definitions/domain.Product"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Product"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid JSON",
+                    "409": {
+                        "description": "Product code already exists",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "422": {
-                        "description": "Invalid JSON",
+                        "description": "Invalid JSON or product type not found",
                         "schema": {
                             "type": "string"
                         }
@@ -1115,30 +1768,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
+        "/products/reportRecords": {
             "get": {
+                "description": "With format=ndjson, or Accept: application/x-ndjson, the counts are streamed a JSON object per line as they are read.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/x-ndjson",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "products",
-                    "domain.Product"
+                    "productrecords"
                 ],
-                "summary": "Retrieves a product by ID.",
+                "summary": "Retrieves product records by product ID or all product records if idProduct is 0.",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Product ID",
                         "name": "id",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "ndjson",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "json (default), ndjson, csv or xlsx, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product data",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Product"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ProductRecordGet"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1160,18 +1842,107 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/products/search": {
+            "get": {
+                "description": "Searches the words of q in the description and product_code of the products, the most relevant first.\nA word matches the words starting with it and, from four letters on, the ones differing by a typo (two from eight letters on).\nEvery word of q must match.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Searches products.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Words to search",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the products of this seller",
+                        "name": "seller_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the products of this product type",
+                        "name": "product_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Number of products returned, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "Products found, with their score",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/product.SearchResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "products",
+                    "domain.Product"
                 ],
-                "summary": "Updates an existing product by ID.",
+                "summary": "Retrieves a product by ID.",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1179,22 +1950,25 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated product object",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Product"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated product data",
+                        "description": "Product data",
                         "schema": {
-                            "$ref": "#/definitions/domain.Product"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Product"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1209,12 +1983,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "422": {
-                        "description": "Invalid JSON",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1263,62 +2031,247 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/purchaseOrders": {
-            "post": {
-                "description": "create a new purchase order",
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "purchase_orders"
+                    "products"
                 ],
+                "summary": "Updates an existing product by ID.",
                 "parameters": [
                     {
-                        "description": "Purchase orders body",
-                        "name": "body",
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated product object",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.RequestBodyPurchaseCreate"
+                            "$ref": "#/definitions/domain.Product"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Updated product data",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Product"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     },
                     "409": {
-                        "description": "Conflict",
+                        "description": "Product code already exists",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid JSON or product type not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/records": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "productrecords"
+                ],
+                "summary": "Retrieves the records of a product, the oldest first, with their margin, markup and price changes.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the records dated on or after this date (yyyy-mm-dd)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the records dated on or before this date (yyyy-mm-dd)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code to convert the prices to",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.ProductRecordHistoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or date",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid currency or currency without a rate",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchaseOrders": {
+            "post": {
+                "description": "create a new purchase order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "parameters": [
+                    {
+                        "description": "Purchase orders body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RequestBodyPurchaseCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.PurchaseOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.ErrorMessageResponse"
                         }
                     }
                 }
@@ -1328,25 +2281,53 @@ const docTemplate = `{
             "get": {
                 "description": "Gets a list of all the sections.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "sections"
                 ],
                 "summary": "Retrieves all sections.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Section"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -1365,13 +2346,12 @@ const docTemplate = `{
                 "summary": "Creates a new section.",
                 "parameters": [
                     {
-                        "format": "json",
                         "description": "Section data to create",
                         "name": "sectionData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/handler.SectionRequest"
                         }
                     }
                 ],
@@ -1379,29 +2359,43 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Section"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -1411,7 +2405,9 @@ const docTemplate = `{
             "get": {
                 "description": "Gets the total ammount of products stored in a given section. If section is not specified, gets ammount of products for each section.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "sections"
@@ -1423,28 +2419,57 @@ const docTemplate = `{
                         "description": "ID of the specific section",
                         "name": "id",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/section.ProdCountResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -1473,22 +2498,37 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Section"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -1518,22 +2558,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -1559,13 +2596,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "format": "json",
                         "description": "Updated section data",
                         "name": "sectionData",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/handler.SectionRequest"
                         }
                     }
                 ],
@@ -1573,36 +2609,49 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Section"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "409": {
                         "description": "Conflict",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -1612,32 +2661,59 @@ const docTemplate = `{
             "get": {
                 "description": "Get all the sellers available or an error if the list is empty or an internal error occurs.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "domain.Seller"
                 ],
                 "summary": "Get all the sellers available.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "List of all sellers",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Seller"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Seller"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
                         "description": "Sellers not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Server Internal error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
                 }
@@ -1669,25 +2745,43 @@ const docTemplate = `{
                     "201": {
                         "description": "New created seller",
                         "schema": {
-                            "$ref": "#/definitions/domain.Seller"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Seller"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid JSON",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "409": {
                         "description": "seller already exists",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "422": {
                         "description": "invalid or missing locality. Locality_id must be 1 or greater",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Server Internal error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
                 }
@@ -1716,31 +2810,46 @@ const docTemplate = `{
                     "200": {
                         "description": "Seller requested",
                         "schema": {
-                            "$ref": "#/definitions/domain.Seller"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Seller"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "invalid id",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Seller not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Server Internal error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
                 }
             },
             "delete": {
                 "description": "Delete a seller using its id or return an error if that",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "domain.Seller"
                 ],
@@ -1756,27 +2865,24 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Seller deleted",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "description": "Seller deleted"
                     },
                     "400": {
                         "description": "id must be 1 or greater",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Seller not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Server Internal error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
                 }
@@ -1815,25 +2921,43 @@ const docTemplate = `{
                     "200": {
                         "description": "Seller updated",
                         "schema": {
-                            "$ref": "#/definitions/domain.Seller"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Seller"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "invalid id",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Seller not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "id locality not exists",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Server Internal error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
                 }
@@ -1842,25 +2966,53 @@ const docTemplate = `{
         "/warehouses": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
                     "warehouses"
                 ],
                 "summary": "Get all the warehouses available.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format, overrides the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Warehouse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -1883,30 +3035,45 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.Warehouse"
+                            "$ref": "#/definitions/domain.Warehouse"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Warehouse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -1934,27 +3101,39 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Warehouse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
             },
             "delete": {
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "warehouses"
                 ],
@@ -1970,24 +3149,18 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "description": "No Content"
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -2010,28 +3183,46 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Warehouse fields to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Warehouse"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Warehouse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/web.MessageResponse"
                         }
                     }
                 }
@@ -2045,6 +3236,10 @@ const docTemplate = `{
                 "card_number_id": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "description": "DeletedAt is set while the buyer is soft deleted.",
+                    "type": "string"
+                },
                 "first_name": {
                     "type": "string"
                 },
@@ -2056,106 +3251,222 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.Locality": {
+        "domain.Carries": {
             "type": "object",
             "properties": {
-                "country_name": {
+                "address": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "cid": {
+                    "type": "string"
                 },
-                "locality_name": {
+                "company_name": {
                     "type": "string"
                 },
-                "postal_code": {
+                "id": {
                     "type": "integer"
                 },
-                "province_name": {
+                "locality_id": {
+                    "type": "integer"
+                },
+                "telephone": {
                     "type": "string"
                 }
             }
         },
-        "domain.Product": {
+        "domain.ColdChainOverride": {
             "type": "object",
             "properties": {
-                "description": {
+                "actor": {
                     "type": "string"
                 },
-                "expiration_rate": {
-                    "type": "number"
-                },
-                "freezing_rate": {
-                    "type": "number"
-                },
-                "height": {
-                    "type": "number"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "length": {
-                    "type": "number"
+                "reason": {
+                    "type": "string"
                 },
-                "netweight": {
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ColdChainViolation"
+                    }
+                }
+            }
+        },
+        "domain.ColdChainViolation": {
+            "type": "object",
+            "properties": {
+                "limit": {
                     "type": "number"
                 },
-                "product_code": {
+                "message": {
                     "type": "string"
                 },
-                "product_type_id": {
-                    "type": "integer"
-                },
-                "recommended_freezing_temperature": {
-                    "type": "number"
-                },
-                "seller_id": {
-                    "type": "integer"
+                "rule": {
+                    "type": "string"
                 },
-                "width": {
+                "temperature": {
+                    "description": "Temperature is the temperature of the section breaking Limit, the\ntemperature of the batch or its product.",
                     "type": "number"
                 }
             }
         },
-        "domain.ProductRecord": {
+        "domain.Employee": {
             "type": "object",
             "properties": {
+                "card_number_id": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is set while the employee is soft deleted.",
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "last_update_date": {
+                "last_name": {
                     "type": "string"
                 },
-                "product_id": {
-                    "description": "Product_code of the product (fk)",
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.InboudOrder": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
                     "type": "integer"
                 },
-                "purchase_price": {
-                    "type": "number"
+                "id": {
+                    "type": "integer"
                 },
-                "sale_price": {
-                    "type": "number"
+                "order_date": {
+                    "type": "string"
+                },
+                "order_number": {
+                    "type": "string"
+                },
+                "product_batch_id": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.ProductRecordCreate": {
+        "domain.Locality": {
             "type": "object",
             "properties": {
-                "last_update_date": {
+                "country_name": {
                     "type": "string"
                 },
-                "product_id": {
-                    "description": "Product_code of the product (fk)",
+                "id": {
                     "type": "integer"
                 },
-                "purchase_price": {
+                "locality_name": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "integer"
+                },
+                "province_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Product": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt is set while the product is soft deleted.",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expiration_rate": {
                     "type": "number"
                 },
-                "sale_price": {
+                "freezing_rate": {
+                    "type": "number"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "netweight": {
+                    "type": "number"
+                },
+                "product_code": {
+                    "type": "string"
+                },
+                "product_type_id": {
+                    "type": "integer"
+                },
+                "recommended_freezing_temperature": {
+                    "type": "number"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "width": {
                     "type": "number"
                 }
             }
         },
+        "domain.ProductBatch": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "type": "integer"
+                },
+                "cold_chain_override": {
+                    "description": "ColdChainOverride is set on the batches stored in a section that\nbreaks their cold chain.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ColdChainOverride"
+                        }
+                    ]
+                },
+                "current_quantity": {
+                    "type": "integer"
+                },
+                "current_temperature": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "initial_quantity": {
+                    "type": "integer"
+                },
+                "manufacturing_date": {
+                    "type": "string"
+                },
+                "manufacturing_hour": {
+                    "type": "integer"
+                },
+                "minimum_temperature": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "section_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.ProductRecordGet": {
             "type": "object",
             "properties": {
@@ -2172,6 +3483,52 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "buyer_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_date": {
+                    "type": "string"
+                },
+                "order_number": {
+                    "type": "string"
+                },
+                "order_status_id": {
+                    "type": "integer"
+                },
+                "product_record_id": {
+                    "type": "integer"
+                },
+                "tracking_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PurchaseOrdersByBuyer": {
+            "type": "object",
+            "properties": {
+                "card_number_id": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "purchase_orders_count": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.ReportSellers": {
             "type": "object",
             "properties": {
@@ -2189,6 +3546,38 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.Section": {
+            "type": "object",
+            "properties": {
+                "current_capacity": {
+                    "type": "integer"
+                },
+                "current_temperature": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "maximum_capacity": {
+                    "type": "integer"
+                },
+                "minimum_capacity": {
+                    "type": "integer"
+                },
+                "minimum_temperature": {
+                    "type": "integer"
+                },
+                "product_type_id": {
+                    "type": "integer"
+                },
+                "section_number": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.Seller": {
             "type": "object",
             "properties": {
@@ -2201,6 +3590,10 @@ const docTemplate = `{
                 "company_name": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "description": "DeletedAt is set while the seller is soft deleted.",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -2212,8 +3605,107 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.Employee": {
-            "type": "object"
+        "domain.Warehouse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is set while the warehouse is soft deleted.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "minimum_capacity": {
+                    "type": "integer"
+                },
+                "minimum_temperature": {
+                    "type": "integer"
+                },
+                "telephone": {
+                    "type": "string"
+                },
+                "warehouse_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "batch_number": {
+                    "type": "integer"
+                },
+                "current_quantity": {
+                    "type": "integer"
+                },
+                "current_temperature": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "initial_quantity": {
+                    "type": "integer"
+                },
+                "manufacturing_date": {
+                    "type": "string"
+                },
+                "manufacturing_hour": {
+                    "type": "integer"
+                },
+                "minimum_temperature": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "section_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CarryRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "cid": {
+                    "type": "string"
+                },
+                "company_name": {
+                    "type": "string"
+                },
+                "locality_id": {
+                    "type": "string"
+                },
+                "telephone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.EmployeeRequest": {
+            "type": "object",
+            "properties": {
+                "card_number_id": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
         },
         "handler.InboudOrderRequest": {
             "type": "object",
@@ -2238,6 +3730,155 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.ProductRecordBulkRequest": {
+            "type": "object",
+            "properties": {
+                "last_update_date": {
+                    "type": "string"
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "product_type_id": {
+                    "type": "integer"
+                },
+                "rule": {
+                    "type": "string",
+                    "enum": [
+                        "absolute",
+                        "percentage",
+                        "margin"
+                    ]
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "handler.ProductRecordChange": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "new_sale_price": {
+                    "type": "number",
+                    "example": 132
+                },
+                "old_sale_price": {
+                    "type": "number",
+                    "example": 120
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "record_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ProductRecordHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_update_date": {
+                    "type": "string"
+                },
+                "margin": {
+                    "type": "number"
+                },
+                "markup": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "purchase_price_change": {
+                    "type": "number",
+                    "x-nullable": true
+                },
+                "sale_price": {
+                    "type": "number",
+                    "example": 110
+                },
+                "sale_price_change": {
+                    "type": "number",
+                    "x-nullable": true
+                }
+            }
+        },
+        "handler.ProductRecordRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency is the ISO 4217 code of the prices, USD by default.",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "last_update_date": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "sale_price": {
+                    "type": "number",
+                    "example": 110
+                }
+            }
+        },
+        "handler.ProductRecordResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_update_date": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "sale_price": {
+                    "type": "number",
+                    "example": 110
+                }
+            }
+        },
         "handler.Request": {
             "type": "object",
             "properties": {
@@ -2252,6 +3893,20 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.RequestBodyBuyerCreate": {
+            "type": "object",
+            "properties": {
+                "card_number_id": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.RequestBodyPurchaseCreate": {
             "type": "object",
             "properties": {
@@ -2278,8 +3933,172 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.Warehouse": {
-            "type": "object"
+        "handler.SectionRequest": {
+            "type": "object",
+            "properties": {
+                "current_capacity": {
+                    "type": "integer"
+                },
+                "current_temperature": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "maximum_capacity": {
+                    "type": "integer"
+                },
+                "minimum_capacity": {
+                    "type": "integer"
+                },
+                "minimum_temperature": {
+                    "type": "integer"
+                },
+                "product_type_id": {
+                    "type": "integer"
+                },
+                "section_number": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "inboudorder.Report": {
+            "type": "object",
+            "properties": {
+                "card_number_id": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is set while the employee is soft deleted.",
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inboud_orders_count": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "product.SearchResult": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt is set while the product is soft deleted.",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expiration_rate": {
+                    "type": "number"
+                },
+                "freezing_rate": {
+                    "type": "number"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "netweight": {
+                    "type": "number"
+                },
+                "product_code": {
+                    "type": "string"
+                },
+                "product_type_id": {
+                    "type": "integer"
+                },
+                "recommended_freezing_temperature": {
+                    "type": "number"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "number"
+                }
+            }
+        },
+        "section.ProdCountResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "product_count": {
+                    "type": "integer"
+                },
+                "section_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.DataResponse": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {}
+            }
+        },
+        "web.ErrorMessageResponse": {
+            "type": "object",
+            "required": [
+                "error"
+            ],
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.ErrorResponse": {
+            "type": "object",
+            "required": [
+                "code",
+                "message"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.MessageResponse": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -2287,9 +4106,9 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8080/api/v1",
-	BasePath:         "",
-	Schemes:          []string{},
+	Host:             "localhost:8080",
+	BasePath:         "/api/v1",
+	Schemes:          []string{"http"},
 	Title:            "API GO",
 	Description:      "This API manage many products of any company.",
 	InfoInstanceName: "swagger",
@@ -0,0 +1,9 @@
+package docs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 version of swagger.json, produced by cmd/openapi.
+// Regenerate it together with the Swagger files with `make docs`.
+//
+//go:embed openapi.json
+var OpenAPI []byte
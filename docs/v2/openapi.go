@@ -0,0 +1,9 @@
+package v2
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 version of v2_swagger.json, produced by
+// cmd/openapi. Regenerate it together with the Swagger files with `make docs`.
+//
+//go:embed openapi.json
+var OpenAPI []byte
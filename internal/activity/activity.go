@@ -0,0 +1,234 @@
+// Package activity is the live feed of warehouse activity: the inbound orders
+// received, the product batches created and the changes of the capacity of
+// the sections, as they happen. The services publish to it through hooks and
+// the /events/stream route streams it to the clients as Server-Sent Events.
+//
+// The feed is kept in memory: each event gets the next sequential ID, after
+// the epoch of the feed, and the latest ones are kept in a bounded ring
+// buffer, so a client that reconnects with the ID of the last event it
+// received gets the ones it missed, as long as they are still in the buffer.
+// A client that received its last event from another feed, e.g. before the
+// server restarted, gets all of them.
+package activity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/outbox"
+)
+
+// The types of the events of the feed.
+const (
+	InboundOrderReceived   = outbox.InboundOrderReceived
+	BatchCreated           = outbox.ProductBatchCreated
+	SectionCapacityChanged = "section.capacity_changed"
+)
+
+// Types are the types of the events of the feed.
+var Types = []string{InboundOrderReceived, BatchCreated, SectionCapacityChanged}
+
+// DefaultSize is the number of events a feed keeps for the clients resuming
+// their stream.
+const DefaultSize = 1000
+
+// subscriberBuffer is the number of events a subscriber may lag behind before
+// it is dropped.
+const subscriberBuffer = 64
+
+// ErrInvalidEventID is returned by ParseEventID for a text that is not an
+// event ID.
+var ErrInvalidEventID = errors.New("invalid event id")
+
+// EventID identifies an event of the feed. Seq is sequential, starting at 1
+// when the feed is created, and Epoch is the time the feed was created, in
+// Unix milliseconds, so that the IDs of a feed created again, e.g. by a
+// restart of the server, do not match the ones of the previous feed.
+type EventID struct {
+	Epoch int64
+	Seq   uint64
+}
+
+// ParseEventID parses the text of an event ID, <epoch>-<seq>. A bare
+// sequence, the ID of a feed without an epoch, parses with a zero epoch.
+func ParseEventID(s string) (EventID, error) {
+	epoch, seq, found := strings.Cut(s, "-")
+	if !found {
+		epoch, seq = "0", s
+	}
+	var id EventID
+	var err error
+	if id.Epoch, err = strconv.ParseInt(epoch, 10, 64); err != nil || id.Epoch < 0 {
+		return EventID{}, ErrInvalidEventID
+	}
+	if id.Seq, err = strconv.ParseUint(seq, 10, 64); err != nil {
+		return EventID{}, ErrInvalidEventID
+	}
+	return id, nil
+}
+
+// String returns the text of id, <epoch>-<seq>.
+func (id EventID) String() string {
+	return fmt.Sprintf("%d-%d", id.Epoch, id.Seq)
+}
+
+// MarshalText writes id as its text, so that it is a string in JSON.
+func (id EventID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// Event is an event of the feed.
+type Event struct {
+	ID          EventID     `json:"id"`
+	Type        string      `json:"type"`
+	WarehouseID int         `json:"warehouse_id"`
+	OccurredAt  time.Time   `json:"occurred_at"`
+	Data        interface{} `json:"data"`
+}
+
+// Filter selects events of the feed. The zero value selects every event.
+type Filter struct {
+	// WarehouseID selects the events of a warehouse when not zero.
+	WarehouseID int
+	// Types selects the events of the given types when not empty.
+	Types []string
+}
+
+// Match reports whether f selects e.
+func (f Filter) Match(e Event) bool {
+	if f.WarehouseID != 0 && e.WarehouseID != f.WarehouseID {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// Publisher publishes events to the feed. It is the part of Feed the hooks of
+// the services are given.
+type Publisher interface {
+	// Publish adds an event of type eventType about the warehouse warehouseID
+	// to the feed and returns it with its ID.
+	Publish(eventType string, warehouseID int, data interface{}) Event
+}
+
+// Subscription receives the events of the feed selected by its filter.
+type Subscription interface {
+	// Events receives the events published after the subscription. It is
+	// closed when the subscription is closed, or when its receiver fell too
+	// far behind: the client is then expected to resume from the last event it
+	// received.
+	Events() <-chan Event
+	// Close ends the subscription.
+	Close()
+}
+
+// Feed is the live feed of warehouse activity.
+type Feed interface {
+	Publisher
+	// Subscribe returns the events selected by f still in the buffer after
+	// the event lastID, which are all of them when lastID is zero, of
+	// another epoch (the feed was created again since) or ahead of the feed,
+	// and a subscription receiving the ones published from then on.
+	Subscribe(f Filter, lastID EventID) ([]Event, Subscription)
+}
+
+// NewFeed returns an empty feed keeping the latest size events.
+func NewFeed(size int) Feed {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	now := time.Now
+	return &feed{ring: make([]Event, 0, size), epoch: now().UnixMilli(), subs: make(map[*subscription]struct{}), now: now}
+}
+
+type feed struct {
+	mu sync.Mutex
+	// ring holds the latest events, the oldest at start once it is full.
+	ring  []Event
+	start int
+	// epoch is the epoch of the IDs of the events, and last the sequence of
+	// the latest one.
+	epoch int64
+	last  uint64
+	subs  map[*subscription]struct{}
+	now   func() time.Time
+}
+
+func (f *feed) Publish(eventType string, warehouseID int, data interface{}) Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.last++
+	e := Event{ID: EventID{Epoch: f.epoch, Seq: f.last}, Type: eventType, WarehouseID: warehouseID, OccurredAt: f.now().UTC(), Data: data}
+	if len(f.ring) < cap(f.ring) {
+		f.ring = append(f.ring, e)
+	} else {
+		f.ring[f.start] = e
+		f.start = (f.start + 1) % len(f.ring)
+	}
+
+	for s := range f.subs {
+		if !s.filter.Match(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			// The subscriber lags behind: it resumes from the buffer.
+			delete(f.subs, s)
+			close(s.events)
+		}
+	}
+	return e
+}
+
+func (f *feed) Subscribe(filter Filter, lastID EventID) ([]Event, Subscription) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	after := lastID.Seq
+	if lastID.Epoch != f.epoch || after > f.last {
+		after = 0
+	}
+	var missed []Event
+	for i := range f.ring {
+		e := f.ring[(f.start+i)%len(f.ring)]
+		if e.ID.Seq > after && filter.Match(e) {
+			missed = append(missed, e)
+		}
+	}
+
+	s := &subscription{feed: f, filter: filter, events: make(chan Event, subscriberBuffer)}
+	f.subs[s] = struct{}{}
+	return missed, s
+}
+
+type subscription struct {
+	feed   *feed
+	filter Filter
+	events chan Event
+}
+
+func (s *subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	if _, ok := s.feed.subs[s]; ok {
+		delete(s.feed.subs, s)
+		close(s.events)
+	}
+}
@@ -0,0 +1,158 @@
+package activity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ids returns the sequences of the IDs of events.
+func ids(events []Event) []uint64 {
+	var ids []uint64
+	for _, e := range events {
+		ids = append(ids, e.ID.Seq)
+	}
+	return ids
+}
+
+func TestFeed_Subscribe(t *testing.T) {
+	t.Run("it should return the missed events selected by the filter", func(t *testing.T) {
+		// Arrange
+		feed := NewFeed(10)
+		feed.Publish(InboundOrderReceived, 1, nil)
+		feed.Publish(BatchCreated, 1, nil)
+		feed.Publish(InboundOrderReceived, 2, nil)
+		feed.Publish(SectionCapacityChanged, 1, nil)
+
+		// Act
+		missed, sub := feed.Subscribe(Filter{WarehouseID: 1, Types: []string{InboundOrderReceived, SectionCapacityChanged}}, EventID{})
+		defer sub.Close()
+
+		// Assert
+		assert.Equal(t, []uint64{1, 4}, ids(missed))
+	})
+
+	t.Run("it should resume after the last event received", func(t *testing.T) {
+		// Arrange
+		feed := NewFeed(10)
+		var published []Event
+		for i := 0; i < 5; i++ {
+			published = append(published, feed.Publish(BatchCreated, 1, nil))
+		}
+
+		// Act
+		missed, sub := feed.Subscribe(Filter{}, published[2].ID)
+		defer sub.Close()
+
+		// Assert
+		assert.Equal(t, []uint64{4, 5}, ids(missed))
+	})
+
+	t.Run("it should keep only the latest events", func(t *testing.T) {
+		// Arrange
+		feed := NewFeed(3)
+		var published []Event
+		for i := 0; i < 5; i++ {
+			published = append(published, feed.Publish(BatchCreated, 1, nil))
+		}
+
+		// Act
+		missed, sub := feed.Subscribe(Filter{}, published[0].ID)
+		defer sub.Close()
+
+		// Assert
+		assert.Equal(t, []uint64{3, 4, 5}, ids(missed))
+	})
+
+	t.Run("it should replay every event when the last one is ahead of the feed", func(t *testing.T) {
+		// Arrange
+		feed := NewFeed(10)
+		first := feed.Publish(BatchCreated, 1, nil)
+		feed.Publish(BatchCreated, 1, nil)
+
+		// Act
+		missed, sub := feed.Subscribe(Filter{}, EventID{Epoch: first.ID.Epoch, Seq: 90})
+		defer sub.Close()
+
+		// Assert
+		assert.Equal(t, []uint64{1, 2}, ids(missed))
+	})
+
+	t.Run("it should replay every event when the last one is of another epoch", func(t *testing.T) {
+		// Arrange
+		feed := NewFeed(10)
+		var published []Event
+		for i := 0; i < 3; i++ {
+			published = append(published, feed.Publish(BatchCreated, 1, nil))
+		}
+		// The last event a client received before the server restarted
+		previous := EventID{Epoch: published[0].ID.Epoch - 1, Seq: 2}
+
+		// Act
+		missed, sub := feed.Subscribe(Filter{}, previous)
+		defer sub.Close()
+
+		// Assert
+		assert.Equal(t, []uint64{1, 2, 3}, ids(missed))
+	})
+
+	t.Run("it should send the new events selected by the filter", func(t *testing.T) {
+		// Arrange
+		feed := NewFeed(10)
+		_, sub := feed.Subscribe(Filter{WarehouseID: 2}, EventID{})
+		defer sub.Close()
+
+		// Act
+		feed.Publish(BatchCreated, 1, nil)
+		published := feed.Publish(BatchCreated, 2, map[string]int{"id": 7})
+
+		// Assert
+		require.Len(t, sub.Events(), 1)
+		assert.Equal(t, published, <-sub.Events())
+	})
+
+	t.Run("it should drop a subscriber that fell behind", func(t *testing.T) {
+		// Arrange
+		feed := NewFeed(10)
+		_, sub := feed.Subscribe(Filter{}, EventID{})
+
+		// Act
+		for i := 0; i < subscriberBuffer+1; i++ {
+			feed.Publish(BatchCreated, 1, nil)
+		}
+
+		// Assert
+		received := 0
+		for range sub.Events() {
+			received++
+		}
+		assert.Equal(t, subscriberBuffer, received)
+		sub.Close()
+	})
+}
+
+func TestParseEventID(t *testing.T) {
+	t.Run("it should parse the epoch and the sequence of an id", func(t *testing.T) {
+		id, err := ParseEventID("1697025600000-42")
+
+		require.NoError(t, err)
+		assert.Equal(t, EventID{Epoch: 1697025600000, Seq: 42}, id)
+		assert.Equal(t, "1697025600000-42", id.String())
+	})
+
+	t.Run("it should parse a bare sequence with a zero epoch", func(t *testing.T) {
+		id, err := ParseEventID("42")
+
+		require.NoError(t, err)
+		assert.Equal(t, EventID{Seq: 42}, id)
+	})
+
+	t.Run("it should reject a text that is not an id", func(t *testing.T) {
+		for _, s := range []string{"", "abc", "-42", "1697025600000-", "1697025600000-x", "1-2-3"} {
+			_, err := ParseEventID(s)
+
+			assert.ErrorIs(t, err, ErrInvalidEventID, s)
+		}
+	})
+}
@@ -0,0 +1,221 @@
+// Package audittest provides a contract test suite for audit.Repository.
+// Every implementation of the interface should pass it.
+package audittest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewRecord returns a valid update record of the given entity.
+func NewRecord(entity string, id int) domain.AuditRecord {
+	return domain.AuditRecord{
+		RecordedAt: time.Date(2026, time.October, 18, 15, 4, 5, 123456000, time.UTC),
+		Actor:      "jdoe",
+		RequestID:  "4bf92f3577b34da6",
+		Entity:     entity,
+		EntityID:   id,
+		Operation:  audit.OpUpdate,
+		Before:     json.RawMessage(`{"maximum_capacity":10}`),
+		After:      json.RawMessage(`{"maximum_capacity":20}`),
+		Changes:    map[string]domain.AuditChange{"maximum_capacity": {Before: float64(10), After: float64(20)}},
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) audit.Repository) {
+	ctx := context.Background()
+
+	t.Run("it should append a record and read it back", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		rec := NewRecord("section", 12)
+		rec.PrevHash = "0a"
+		rec.Hash = "0b"
+
+		// Act
+		id, err := repo.Append(ctx, rec)
+		require.NoError(t, err)
+		obtained, err := repo.List(ctx, audit.Filter{})
+
+		// Assert
+		require.NoError(t, err)
+		rec.ID = id
+		assert.Equal(t, []domain.AuditRecord{rec}, obtained)
+	})
+
+	t.Run("it should keep a missing snapshot missing", func(t *testing.T) {
+		repo := newRepository(t)
+		rec := NewRecord("section", 12)
+		rec.Operation = audit.OpCreate
+		rec.Before = nil
+
+		_, err := repo.Append(ctx, rec)
+		require.NoError(t, err)
+		obtained, err := repo.List(ctx, audit.Filter{})
+
+		require.NoError(t, err)
+		require.Len(t, obtained, 1)
+		assert.Nil(t, obtained[0].Before)
+	})
+
+	t.Run("it should list the records of an entity newest first, a page at a time", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		var ids []int
+		for _, rec := range []domain.AuditRecord{NewRecord("section", 12), NewRecord("section", 13), NewRecord("section", 12), NewRecord("product", 12), NewRecord("section", 12)} {
+			id, err := repo.Append(ctx, rec)
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		// Act
+		first, err := repo.List(ctx, audit.Filter{Entity: "section", EntityID: 12, Limit: 2})
+		require.NoError(t, err)
+		second, err := repo.List(ctx, audit.Filter{Entity: "section", EntityID: 12, Limit: 2, BeforeID: first[1].ID})
+		require.NoError(t, err)
+		sections, err := repo.List(ctx, audit.Filter{Entity: "section"})
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, []int{ids[4], ids[2]}, recordIDs(first))
+		assert.Equal(t, []int{ids[0]}, recordIDs(second))
+		assert.Equal(t, []int{ids[4], ids[2], ids[1], ids[0]}, recordIDs(sections))
+	})
+
+	t.Run("it should return the hash of the newest chained record", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		empty, err := repo.LastHash(ctx)
+		require.NoError(t, err)
+		chained := NewRecord("section", 12)
+		chained.Hash = "0b"
+		_, err = repo.Append(ctx, chained)
+		require.NoError(t, err)
+		_, err = repo.Append(ctx, NewRecord("section", 12))
+		require.NoError(t, err)
+
+		// Act
+		obtained, err := repo.LastHash(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, empty)
+		assert.Equal(t, "0b", obtained)
+	})
+
+	t.Run("it should append the record chained to the newest chained one", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		chained := NewRecord("section", 12)
+		chained.Hash = "0b"
+		_, err := repo.Append(ctx, chained)
+		require.NoError(t, err)
+		var prev string
+
+		// Act
+		id, err := repo.AppendChained(ctx, func(prevHash string) (domain.AuditRecord, error) {
+			prev = prevHash
+			rec := NewRecord("section", 12)
+			rec.PrevHash = prevHash
+			rec.Hash = "0c"
+			return rec, nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "0b", prev)
+		obtained, err := repo.List(ctx, audit.Filter{})
+		require.NoError(t, err)
+		require.NotEmpty(t, obtained)
+		assert.Equal(t, id, obtained[0].ID)
+		assert.Equal(t, "0c", obtained[0].Hash)
+	})
+
+	t.Run("it should not append the record when chain fails", func(t *testing.T) {
+		repo := newRepository(t)
+		errChain := errors.New("chain failed")
+
+		_, err := repo.AppendChained(ctx, func(string) (domain.AuditRecord, error) {
+			return domain.AuditRecord{}, errChain
+		})
+
+		require.ErrorIs(t, err, errChain)
+		obtained, err := repo.List(ctx, audit.Filter{})
+		require.NoError(t, err)
+		assert.Empty(t, obtained)
+	})
+
+	t.Run("it should keep whole the chain of services recording at once", func(t *testing.T) {
+		// Arrange: two services sharing the log, as two API instances or an
+		// instance and apigoctl do
+		repo := newRepository(t)
+		services := []audit.Service{
+			audit.NewService(repo, audit.Options{Chain: true}),
+			audit.NewService(repo, audit.Options{Chain: true}),
+		}
+		const perService = 10
+
+		// Act
+		var wg sync.WaitGroup
+		errs := make(chan error, len(services)*perService)
+		for _, sv := range services {
+			for i := 0; i < perService; i++ {
+				wg.Add(1)
+				go func(sv audit.Service, id int) {
+					defer wg.Done()
+					errs <- sv.Record(ctx, audit.OpUpdate, "section", id, map[string]int{"v": id}, map[string]int{"v": id + 1})
+				}(sv, i+1)
+			}
+		}
+		wg.Wait()
+		close(errs)
+
+		// Assert
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		report, err := services[0].Verify(ctx)
+		require.NoError(t, err)
+		assert.True(t, report.Valid)
+		assert.Nil(t, report.BrokenAt)
+		assert.Equal(t, len(services)*perService, report.Checked)
+	})
+
+	t.Run("it should pass every record to fn oldest first", func(t *testing.T) {
+		repo := newRepository(t)
+		var ids []int
+		for _, id := range []int{1, 2, 3} {
+			appended, err := repo.Append(ctx, NewRecord("section", id))
+			require.NoError(t, err)
+			ids = append(ids, appended)
+		}
+
+		var obtained []domain.AuditRecord
+		err := repo.Each(ctx, func(rec domain.AuditRecord) error {
+			obtained = append(obtained, rec)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ids, recordIDs(obtained))
+	})
+}
+
+func recordIDs(records []domain.AuditRecord) []int {
+	ids := make([]int, 0, len(records))
+	for _, rec := range records {
+		ids = append(ids, rec.ID)
+	}
+	return ids
+}
@@ -0,0 +1,254 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+)
+
+// ErrChainBusy is returned when the lock of the hash chain is not obtained
+// within chainLockTimeout.
+var ErrChainBusy = errors.New("audit: hash chain locked by another writer")
+
+// chainLock is the name of the lock of the database serializing the appends
+// of chained records, and chainLockTimeout, in seconds, how long an append
+// waits for it.
+const (
+	chainLock        = "apigo_audit_chain"
+	chainLockTimeout = 10
+)
+
+// Filter selects records of the log. The zero value selects every record.
+type Filter struct {
+	// Entity and EntityID select the records of an entity type, and of one
+	// entity when EntityID is set.
+	Entity   string
+	EntityID int
+	// BeforeID selects the records older than the record with that id, to
+	// page through the log from the newest record.
+	BeforeID int
+	// Limit caps the number of records, when it is positive.
+	Limit int
+}
+
+// Repository stores the audit log. It is append-only: records are never
+// updated or deleted.
+type Repository interface {
+	// Append stores r and returns its id.
+	Append(ctx context.Context, r domain.AuditRecord) (int, error)
+	// List returns the records selected by f, newest first.
+	List(ctx context.Context, f Filter) ([]domain.AuditRecord, error)
+	// LastHash returns the hash of the newest chained record, or "" when no
+	// record is chained.
+	LastHash(ctx context.Context) (string, error)
+	// AppendChained stores the record chain builds from the hash of the
+	// newest chained record and returns its id. No other record is appended
+	// between reading the hash and storing the record, by this process or
+	// any other sharing the log, so that the chain cannot fork.
+	AppendChained(ctx context.Context, chain func(prevHash string) (domain.AuditRecord, error)) (int, error)
+	// Each calls fn with every record, oldest first, and stops at the first
+	// error fn returns.
+	Each(ctx context.Context, fn func(domain.AuditRecord) error) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// datetimeLayout is how recorded_at is written to, and read from, its
+// DATETIME(6) column.
+const datetimeLayout = "2006-01-02 15:04:05.999999"
+
+const selectRecords = "SELECT id, recorded_at, actor, request_id, entity, entity_id, operation, before_json, after_json, changes_json, prev_hash, hash FROM audit_log"
+
+// Append stores a record in the audit_log table.
+func (r *repository) Append(ctx context.Context, rec domain.AuditRecord) (int, error) {
+	return appendRecord(ctx, r.db, rec)
+}
+
+// AppendChained reads the hash of the newest chained record and stores the
+// next one in a transaction, holding the named lock chainLock of the
+// database meanwhile. The lock, unlike one of the process, is shared by every
+// API instance and apigoctl writing to the same log, and unlike a locking
+// read it also holds while the log is empty.
+func (r *repository) AppendChained(ctx context.Context, chain func(prevHash string) (domain.AuditRecord, error)) (id int, err error) {
+	// The lock belongs to a connection, which must stay the same until it
+	// is released
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", chainLock, chainLockTimeout).Scan(&locked); err != nil {
+		return 0, err
+	}
+	if locked.Int64 != 1 {
+		return 0, ErrChainBusy
+	}
+	defer func() {
+		var released sql.NullInt64
+		if relErr := conn.QueryRowContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", chainLock).Scan(&released); relErr != nil {
+			err = errors.Join(err, relErr)
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	prev, err := lastHash(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	rec, err := chain(prev)
+	if err != nil {
+		return 0, err
+	}
+	if id, err = appendRecord(ctx, tx, rec); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// appendRecord inserts a record into the audit_log table with db.
+func appendRecord(ctx context.Context, db dbtx.DB, rec domain.AuditRecord) (int, error) {
+	changes, err := json.Marshal(rec.Changes)
+	if err != nil {
+		return 0, err
+	}
+
+	query := "INSERT INTO audit_log (recorded_at, actor, request_id, entity, entity_id, operation, before_json, after_json, changes_json, prev_hash, hash) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	res, err := db.ExecContext(ctx, query, rec.RecordedAt.UTC().Format(datetimeLayout), rec.Actor, rec.RequestID,
+		rec.Entity, rec.EntityID, rec.Operation, nullJSON(rec.Before), nullJSON(rec.After), string(changes), rec.PrevHash, rec.Hash)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// List returns the records of the audit_log table selected by f, newest first.
+func (r *repository) List(ctx context.Context, f Filter) ([]domain.AuditRecord, error) {
+	query := selectRecords + " WHERE 1=1"
+	var args []interface{}
+	if f.Entity != "" {
+		query += " AND entity = ?"
+		args = append(args, f.Entity)
+	}
+	if f.EntityID > 0 {
+		query += " AND entity_id = ?"
+		args = append(args, f.EntityID)
+	}
+	if f.BeforeID > 0 {
+		query += " AND id < ?"
+		args = append(args, f.BeforeID)
+	}
+	query += " ORDER BY id DESC"
+	if f.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, f.Limit)
+	}
+
+	var records []domain.AuditRecord
+	err := r.each(ctx, query, args, func(rec domain.AuditRecord) error {
+		records = append(records, rec)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// LastHash returns the hash of the newest chained record of the audit_log table.
+func (r *repository) LastHash(ctx context.Context) (string, error) {
+	return lastHash(ctx, r.db)
+}
+
+func lastHash(ctx context.Context, db dbtx.DB) (string, error) {
+	var hash string
+	err := db.QueryRowContext(ctx, "SELECT hash FROM audit_log WHERE hash <> '' ORDER BY id DESC LIMIT 1").Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return hash, err
+}
+
+// Each scans the records of the audit_log table into fn, oldest first.
+func (r *repository) Each(ctx context.Context, fn func(domain.AuditRecord) error) error {
+	return r.each(ctx, selectRecords+" ORDER BY id", nil, fn)
+}
+
+// each runs a query selecting the columns of selectRecords and scans every
+// row into fn.
+func (r *repository) each(ctx context.Context, query string, args []interface{}, fn func(domain.AuditRecord) error) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec domain.AuditRecord
+		var recordedAt string
+		var before, after sql.NullString
+		var changes string
+		if err := rows.Scan(&rec.ID, &recordedAt, &rec.Actor, &rec.RequestID, &rec.Entity, &rec.EntityID, &rec.Operation,
+			&before, &after, &changes, &rec.PrevHash, &rec.Hash); err != nil {
+			return err
+		}
+		if rec.RecordedAt, err = parseDatetime(recordedAt); err != nil {
+			return err
+		}
+		if before.Valid {
+			rec.Before = json.RawMessage(before.String)
+		}
+		if after.Valid {
+			rec.After = json.RawMessage(after.String)
+		}
+		if err := json.Unmarshal([]byte(changes), &rec.Changes); err != nil {
+			return fmt.Errorf("audit: record %d: %w", rec.ID, err)
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// parseDatetime parses a DATETIME column, read as text or, with the
+// parseTime option of the driver, converted to RFC 3339 by database/sql.
+func parseDatetime(s string) (time.Time, error) {
+	if t, err := time.Parse(datetimeLayout, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// nullJSON stores a missing snapshot as NULL.
+func nullJSON(raw json.RawMessage) sql.NullString {
+	return sql.NullString{String: string(raw), Valid: raw != nil}
+}
@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) Append(ctx context.Context, rec domain.AuditRecord) (int, error) {
+	args := r.Called(ctx, rec)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) List(ctx context.Context, f Filter) ([]domain.AuditRecord, error) {
+	args := r.Called(ctx, f)
+	return args.Get(0).([]domain.AuditRecord), args.Error(1)
+}
+
+func (r *RepositoryMock) LastHash(ctx context.Context) (string, error) {
+	args := r.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (r *RepositoryMock) Each(ctx context.Context, fn func(domain.AuditRecord) error) error {
+	args := r.Called(ctx)
+	for _, rec := range args.Get(0).([]domain.AuditRecord) {
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// AppendChained chains the record to the hash LastHash returns and stores it
+// with Append, so that tests expect the calls as they would without the lock.
+func (r *RepositoryMock) AppendChained(ctx context.Context, chain func(prevHash string) (domain.AuditRecord, error)) (int, error) {
+	prev, err := r.LastHash(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rec, err := chain(prev)
+	if err != nil {
+		return 0, err
+	}
+	return r.Append(ctx, rec)
+}
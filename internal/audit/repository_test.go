@@ -0,0 +1,15 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/audit/audittest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	audittest.TestRepository(t, func(t *testing.T) audit.Repository {
+		return audit.NewRepository(mysqltest.Open(t))
+	})
+}
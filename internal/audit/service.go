@@ -0,0 +1,212 @@
+// Package audit keeps the append-only log of the mutations of every entity:
+// who created, updated or deleted it, in which request, and how it looked
+// before and after.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/web"
+)
+
+// errBroken stops Verify at the first record breaking the chain.
+var errBroken = errors.New("audit: hash chain broken")
+
+// Operations of the records.
+const (
+	OpCreate  = "create"
+	OpUpdate  = "update"
+	OpDelete  = "delete"
+	OpRestore = "restore"
+)
+
+// Recorder appends mutations to the log. It is the part of Service the
+// audited services depend on.
+type Recorder interface {
+	// Record appends the mutation op of the entity identified by entity and
+	// id. before is nil for a creation and after is nil for a deletion. The
+	// actor and request ID are taken from ctx, see web.RequestMeta.
+	Record(ctx context.Context, op, entity string, id int, before, after interface{}) error
+}
+
+type Service interface {
+	Recorder
+	// List returns the records selected by f, newest first.
+	List(ctx context.Context, f Filter) ([]domain.AuditRecord, error)
+	// Verify walks the hash chain of the log from its oldest record.
+	Verify(ctx context.Context) (Verification, error)
+}
+
+// Options configures the log.
+type Options struct {
+	// Chain links every record to the previous one with a SHA-256 hash, so
+	// that a record edited or deleted in the table breaks the chain. The
+	// records of a log are chained from the moment Chain is enabled.
+	Chain bool
+}
+
+// Verification is the result of walking the hash chain.
+type Verification struct {
+	// Checked is the number of chained records that were checked.
+	Checked int `json:"checked"`
+	// Valid is false when a record does not match its hash or does not link
+	// to the record before it.
+	Valid bool `json:"valid"`
+	// BrokenAt is the id of the first record breaking the chain.
+	BrokenAt *int `json:"broken_at,omitempty"`
+}
+
+type service struct {
+	repo  Repository
+	chain bool
+	now   func() time.Time
+}
+
+func NewService(repo Repository, opts Options) Service {
+	return &service{repo: repo, chain: opts.Chain, now: time.Now}
+}
+
+// Record builds the record of a mutation, with the difference between before
+// and after, and appends it to the log, chained to the last record when the
+// log is tamper evident.
+func (s *service) Record(ctx context.Context, op, entity string, id int, before, after interface{}) error {
+	rec := domain.AuditRecord{
+		RecordedAt: s.now().UTC().Truncate(time.Microsecond),
+		Actor:      web.ActorOf(ctx),
+		RequestID:  web.RequestIDOf(ctx),
+		Entity:     entity,
+		EntityID:   id,
+		Operation:  op,
+	}
+	var err error
+	if rec.Before, err = snapshot(before); err != nil {
+		return err
+	}
+	if rec.After, err = snapshot(after); err != nil {
+		return err
+	}
+	if rec.Changes, err = changes(rec.Before, rec.After); err != nil {
+		return err
+	}
+
+	if !s.chain {
+		_, err = s.repo.Append(ctx, rec)
+		return err
+	}
+	_, err = s.repo.AppendChained(ctx, func(prevHash string) (domain.AuditRecord, error) {
+		rec.PrevHash = prevHash
+		var err error
+		rec.Hash, err = hashOf(rec)
+		return rec, err
+	})
+	return err
+}
+
+// List returns the records selected by f.
+func (s *service) List(ctx context.Context, f Filter) ([]domain.AuditRecord, error) {
+	return s.repo.List(ctx, f)
+}
+
+// Verify checks that every chained record matches its hash and links to the
+// chained record before it. Records appended before the chain was enabled
+// have no hash and are skipped.
+func (s *service) Verify(ctx context.Context) (Verification, error) {
+	v := Verification{Valid: true}
+	prev := ""
+	err := s.repo.Each(ctx, func(rec domain.AuditRecord) error {
+		if rec.Hash == "" {
+			return nil
+		}
+		v.Checked++
+		hash, err := hashOf(rec)
+		if err != nil {
+			return err
+		}
+		if rec.PrevHash != prev || rec.Hash != hash {
+			v.Valid = false
+			v.BrokenAt = &rec.ID
+			return errBroken
+		}
+		prev = rec.Hash
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBroken) {
+		return Verification{}, err
+	}
+	return v, nil
+}
+
+// snapshot encodes an entity as JSON, or returns nil when there is none.
+func snapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// changes compares the fields of two JSON objects and returns those whose
+// value differs, by name. A missing snapshot has no fields.
+func changes(before, after json.RawMessage) (map[string]domain.AuditChange, error) {
+	var b, a map[string]interface{}
+	if before != nil {
+		if err := json.Unmarshal(before, &b); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if err := json.Unmarshal(after, &a); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := map[string]domain.AuditChange{}
+	for name, value := range b {
+		if !reflect.DeepEqual(value, a[name]) {
+			diff[name] = domain.AuditChange{Before: value, After: a[name]}
+		}
+	}
+	for name, value := range a {
+		if _, ok := b[name]; !ok {
+			diff[name] = domain.AuditChange{After: value}
+		}
+	}
+	return diff, nil
+}
+
+// hashOf returns the SHA-256 hash, in hex, of the hash of the previous record
+// and the fields of rec that are not derived from others. The fields are
+// encoded as a JSON array so that no two records share an encoding.
+func hashOf(rec domain.AuditRecord) (string, error) {
+	fields := []interface{}{
+		rec.PrevHash,
+		rec.RecordedAt.UTC().Format(time.RFC3339Nano),
+		rec.Actor,
+		rec.RequestID,
+		rec.Entity,
+		rec.EntityID,
+		rec.Operation,
+		rawOrNull(rec.Before),
+		rawOrNull(rec.After),
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// rawOrNull encodes a missing snapshot as null.
+func rawOrNull(raw json.RawMessage) json.RawMessage {
+	if raw == nil {
+		return json.RawMessage("null")
+	}
+	return raw
+}
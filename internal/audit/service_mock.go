@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func (s *ServiceMock) Record(ctx context.Context, op, entity string, id int, before, after interface{}) error {
+	args := s.Called(ctx, op, entity, id, before, after)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) List(ctx context.Context, f Filter) ([]domain.AuditRecord, error) {
+	args := s.Called(ctx, f)
+	return args.Get(0).([]domain.AuditRecord), args.Error(1)
+}
+
+func (s *ServiceMock) Verify(ctx context.Context) (Verification, error) {
+	args := s.Called(ctx)
+	return args.Get(0).(Verification), args.Error(1)
+}
@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type auditedSection struct {
+	ID              int    `json:"id"`
+	MaximumCapacity int    `json:"maximum_capacity"`
+	Name            string `json:"name,omitempty"`
+}
+
+var recordedAt = time.Date(2026, time.October, 18, 15, 4, 5, 123456789, time.UTC)
+
+func newTestService(repo Repository, opts Options) *service {
+	s := NewService(repo, opts).(*service)
+	s.now = func() time.Time { return recordedAt }
+	return s
+}
+
+func TestService_Record(t *testing.T) {
+	ctx := web.WithActor(web.WithRequestID(context.Background(), "4bf92f3577b34da6"), "jdoe")
+
+	t.Run("it should record who changed which fields of an entity", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("Append", ctx, domain.AuditRecord{
+			RecordedAt: recordedAt.Truncate(time.Microsecond),
+			Actor:      "jdoe",
+			RequestID:  "4bf92f3577b34da6",
+			Entity:     "section",
+			EntityID:   12,
+			Operation:  OpUpdate,
+			Before:     json.RawMessage(`{"id":12,"maximum_capacity":10}`),
+			After:      json.RawMessage(`{"id":12,"maximum_capacity":20,"name":"cold"}`),
+			Changes: map[string]domain.AuditChange{
+				"maximum_capacity": {Before: float64(10), After: float64(20)},
+				"name":             {After: "cold"},
+			},
+		}).Return(1, nil)
+		s := newTestService(repo, Options{})
+
+		// Act
+		err := s.Record(ctx, OpUpdate, "section", 12,
+			auditedSection{ID: 12, MaximumCapacity: 10}, auditedSection{ID: 12, MaximumCapacity: 20, Name: "cold"})
+
+		// Assert
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("it should record a creation without a before snapshot", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		var obtained domain.AuditRecord
+		repo.On("Append", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			obtained = args.Get(1).(domain.AuditRecord)
+		}).Return(1, nil)
+		s := newTestService(repo, Options{})
+
+		// Act
+		err := s.Record(context.Background(), OpCreate, "section", 12, nil, auditedSection{ID: 12})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, web.AnonymousActor, obtained.Actor)
+		assert.Nil(t, obtained.Before)
+		assert.Equal(t, map[string]domain.AuditChange{"id": {After: float64(12)}, "maximum_capacity": {After: float64(0)}}, obtained.Changes)
+	})
+
+	t.Run("it should chain the record to the last one when the log is tamper evident", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("LastHash", ctx).Return("0a", nil)
+		var obtained domain.AuditRecord
+		repo.On("Append", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			obtained = args.Get(1).(domain.AuditRecord)
+		}).Return(1, nil)
+		s := newTestService(repo, Options{Chain: true})
+
+		// Act
+		err := s.Record(ctx, OpDelete, "section", 12, auditedSection{ID: 12}, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "0a", obtained.PrevHash)
+		hash, err := hashOf(obtained)
+		require.NoError(t, err)
+		assert.Equal(t, hash, obtained.Hash)
+		assert.Len(t, obtained.Hash, 64)
+	})
+
+	t.Run("it should return the error of the repository", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		repo.On("LastHash", ctx).Return("", errors.New("connection refused"))
+		s := newTestService(repo, Options{Chain: true})
+
+		err := s.Record(ctx, OpDelete, "section", 12, auditedSection{ID: 12}, nil)
+
+		assert.EqualError(t, err, "connection refused")
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	// chain returns records chained as Record chains them, the first one
+	// unchained as if appended before the chain was enabled.
+	chain := func(t *testing.T, n int) []domain.AuditRecord {
+		records := []domain.AuditRecord{{ID: 1, RecordedAt: recordedAt, Entity: "section", EntityID: 1, Operation: OpCreate}}
+		prev := ""
+		for id := 2; id <= n; id++ {
+			rec := domain.AuditRecord{ID: id, RecordedAt: recordedAt, Entity: "section", EntityID: id, Operation: OpCreate,
+				After: json.RawMessage(`{"id":1}`), PrevHash: prev}
+			var err error
+			rec.Hash, err = hashOf(rec)
+			require.NoError(t, err)
+			records = append(records, rec)
+			prev = rec.Hash
+		}
+		return records
+	}
+
+	t.Run("it should check every chained record", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		repo.On("Each", ctx).Return(chain(t, 4), nil)
+		s := newTestService(repo, Options{Chain: true})
+
+		obtained, err := s.Verify(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, Verification{Checked: 3, Valid: true}, obtained)
+	})
+
+	t.Run("it should find an edited record", func(t *testing.T) {
+		// Arrange
+		records := chain(t, 4)
+		records[2].Actor = "mallory"
+		repo := &RepositoryMock{}
+		repo.On("Each", ctx).Return(records, nil)
+		s := newTestService(repo, Options{Chain: true})
+
+		// Act
+		obtained, err := s.Verify(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		brokenAt := 3
+		assert.Equal(t, Verification{Checked: 2, Valid: false, BrokenAt: &brokenAt}, obtained)
+	})
+
+	t.Run("it should find a deleted record", func(t *testing.T) {
+		records := chain(t, 4)
+		records = append(records[:2], records[3])
+		repo := &RepositoryMock{}
+		repo.On("Each", ctx).Return(records, nil)
+		s := newTestService(repo, Options{Chain: true})
+
+		obtained, err := s.Verify(ctx)
+
+		require.NoError(t, err)
+		assert.False(t, obtained.Valid)
+		require.NotNil(t, obtained.BrokenAt)
+		assert.Equal(t, 4, *obtained.BrokenAt)
+	})
+}
@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"reflect"
+
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+)
+
+// Created, Updated, Deleted and Restored record a mutation the caller already made. The
+// record is written even if ctx was canceled meanwhile, and a failure to write
+// it is logged rather than returned: the mutation cannot be undone, and the
+// request that made it succeeded.
+
+// Created records the creation of the entity id as after.
+func Created(ctx context.Context, r Recorder, entity string, id int, after interface{}) {
+	track(ctx, r, OpCreate, entity, id, nil, after)
+}
+
+// Updated records the update of the entity id from before to after. before
+// is nil when the entity was not read before the update.
+func Updated(ctx context.Context, r Recorder, entity string, id int, before, after interface{}) {
+	track(ctx, r, OpUpdate, entity, id, before, after)
+}
+
+// Deleted records the deletion of the entity id, which was before.
+func Deleted(ctx context.Context, r Recorder, entity string, id int, before interface{}) {
+	track(ctx, r, OpDelete, entity, id, before, nil)
+}
+
+// Restored records the restoration of the deleted entity id from before to
+// after.
+func Restored(ctx context.Context, r Recorder, entity string, id int, before, after interface{}) {
+	track(ctx, r, OpRestore, entity, id, before, after)
+}
+
+// Imported records the rows an import saved: items[i] was saved as told by
+// outcomes[i], and withID returns it with the id it was saved with. Nothing
+// is recorded for a dry run or an import with a rejected row, as none of
+// their rows were kept. The entities updated by an import are recorded
+// without their state before it.
+func Imported[T any](ctx context.Context, r Recorder, entity string, items []T, outcomes []bulk.Outcome, opts bulk.Options, withID func(T, int) T) {
+	if opts.DryRun || len(outcomes) != len(items) {
+		return
+	}
+	for _, o := range outcomes {
+		if o.Err != nil {
+			return
+		}
+	}
+	for i, o := range outcomes {
+		after := withID(items[i], o.ID)
+		if o.Action == bulk.ActionUpdated {
+			Updated(ctx, r, entity, o.ID, nil, after)
+		} else {
+			Created(ctx, r, entity, o.ID, after)
+		}
+	}
+}
+
+func track(ctx context.Context, r Recorder, op, entity string, id int, before, after interface{}) {
+	if err := r.Record(context.WithoutCancel(ctx), op, entity, id, before, after); err != nil {
+		log.Printf("audit: recording %s of %s %d: %v", op, entity, id, err)
+	}
+}
+
+// Update runs update, the update of the entity id to the value updated, and
+// records it as read by get before and after it. A failed read is recorded
+// as a missing snapshot before the update, and as updated after it.
+func Update[T any](ctx context.Context, r Recorder, entity string, id int, updated T, get func(context.Context, int) (T, error), update func() error) error {
+	var before interface{}
+	if b, err := get(ctx, id); err == nil {
+		before = b
+	}
+	if err := update(); err != nil {
+		return err
+	}
+	if a, err := get(ctx, id); err == nil {
+		updated = a
+	}
+	Updated(ctx, r, entity, id, before, updated)
+	return nil
+}
+
+// Delete runs del, the deletion of the entity id, and records it as read by
+// get before it.
+func Delete[T any](ctx context.Context, r Recorder, entity string, id int, get func(context.Context, int) (T, error), del func() error) error {
+	var before interface{}
+	if b, err := get(ctx, id); err == nil {
+		before = b
+	}
+	if err := del(); err != nil {
+		return err
+	}
+	Deleted(ctx, r, entity, id, before)
+	return nil
+}
+
+// Restore runs restore, the restoration of the deleted entity id, and records
+// it as read by get, deleted rows included, before it. Nothing is recorded
+// when the entity was not deleted, as restore left it as it was.
+func Restore[T any](ctx context.Context, r Recorder, entity string, id int, get func(context.Context, int) (T, error), restore func() (T, error)) (T, error) {
+	var before interface{}
+	if b, err := get(softdelete.WithDeleted(ctx), id); err == nil {
+		before = b
+	}
+	restored, err := restore()
+	if err != nil {
+		return restored, err
+	}
+	if !reflect.DeepEqual(before, restored) {
+		Restored(ctx, r, entity, id, before, restored)
+	}
+	return restored, nil
+}
@@ -0,0 +1,58 @@
+package batch
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+)
+
+// entity is the name of product batches in the audit log.
+const entity = "product_batch"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording in log its creations and
+// consumptions, and the updates of the capacity of the sections they make.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a product batch and records its creation and the update of its
+// section. The batch is recorded without the override of its cold chain
+// check, which the service never stores.
+func (s *auditedService) Save(ctx context.Context, b domain.ProductBatch) (Saved, error) {
+	saved, err := s.Service.Save(ctx, b)
+	if err != nil {
+		return Saved{}, err
+	}
+	b.ID, b.ColdChainOverride = saved.ID, nil
+	audit.Created(ctx, s.log, entity, saved.ID, b)
+	s.recordSections(ctx, saved.Sections)
+	return saved, nil
+}
+
+// Consume consumes the stock of a product batch and records the update of the
+// batch and of its section.
+func (s *auditedService) Consume(ctx context.Context, id, quantity int) (Consumed, error) {
+	consumed, err := s.Service.Consume(ctx, id, quantity)
+	if err != nil {
+		return Consumed{}, err
+	}
+	before := consumed.Batch
+	before.CurrentQuantity += quantity
+	audit.Updated(ctx, s.log, entity, id, before, consumed.Batch)
+	s.recordSections(ctx, consumed.Sections)
+	return consumed, nil
+}
+
+func (s *auditedService) recordSections(ctx context.Context, changes []SectionChange) {
+	for _, c := range changes {
+		section.RecordUpdate(ctx, s.log, c.Before, c.After)
+	}
+}
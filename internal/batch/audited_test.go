@@ -0,0 +1,69 @@
+package batch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditedService(t *testing.T) {
+	ctx := context.Background()
+	before := domain.Section{ID: 12, SectionNumber: 4, CurrentCapacity: 10, MinimumCapacity: 5, MaximumCapacity: 100, WarehouseID: 3}
+	after := before
+	after.CurrentCapacity = 40
+	changes := []SectionChange{{Before: before, After: after}}
+
+	t.Run("it should record the creation of a batch and the update of its section", func(t *testing.T) {
+		// Arrange
+		b := domain.ProductBatch{BatchNumber: 1, CurrentQuantity: 30, ProductID: 2, SectionID: 12}
+		saved := b
+		saved.ID = 7
+		inner, log := &ServiceMock{}, &audit.ServiceMock{}
+		inner.On("Save", mock.Anything, b).Return(Saved{ID: 7, Sections: changes}, nil)
+		log.On("Record", mock.Anything, audit.OpCreate, "product_batch", 7, nil, saved).Return(nil)
+		log.On("Record", mock.Anything, audit.OpUpdate, "section", 12, before, after).Return(nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		obtained, err := s.Save(ctx, b)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, obtained.ID)
+		log.AssertExpectations(t)
+	})
+
+	t.Run("it should record the consumption of a batch and the update of its section", func(t *testing.T) {
+		// Arrange
+		consumed := domain.ProductBatch{ID: 7, BatchNumber: 1, CurrentQuantity: 10, ProductID: 2, SectionID: 12}
+		full := consumed
+		full.CurrentQuantity = 40
+		inner, log := &ServiceMock{}, &audit.ServiceMock{}
+		inner.On("Consume", mock.Anything, 7, 30).Return(Consumed{Batch: consumed, Sections: changes}, nil)
+		log.On("Record", mock.Anything, audit.OpUpdate, "product_batch", 7, full, consumed).Return(nil)
+		log.On("Record", mock.Anything, audit.OpUpdate, "section", 12, before, after).Return(nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		_, err := s.Consume(ctx, 7, 30)
+
+		// Assert
+		assert.NoError(t, err)
+		log.AssertExpectations(t)
+	})
+
+	t.Run("it should record nothing when the consumption fails", func(t *testing.T) {
+		inner, log := &ServiceMock{}, &audit.ServiceMock{}
+		inner.On("Consume", mock.Anything, 7, 30).Return(Consumed{}, ErrNotEnoughStock)
+		s := NewAuditedService(inner, log)
+
+		_, err := s.Consume(ctx, 7, 30)
+
+		assert.ErrorIs(t, err, ErrNotEnoughStock)
+		log.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
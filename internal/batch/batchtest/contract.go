@@ -0,0 +1,221 @@
+// Package batchtest provides a contract test suite for batch.Repository.
+// Every implementation of the interface should pass it.
+package batchtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows the batch repository references but does not write.
+type Fixtures struct {
+	// AddProduct stores a product of the given type and returns its id.
+	AddProduct func(t *testing.T, productTypeID int) int
+	// AddSection stores a section of the given product type and returns its
+	// id.
+	AddSection func(t *testing.T, productTypeID int) int
+}
+
+// NewBatch returns a valid batch with the given number.
+func NewBatch(number, productID, sectionID int) domain.ProductBatch {
+	return domain.ProductBatch{
+		BatchNumber:        number,
+		CurrentQuantity:    10,
+		CurrentTemperature: 4,
+		DueDate:            "2024-01-01",
+		InitialQuantity:    20,
+		ManufacturingDate:  "2023-01-01",
+		ManufacturingHour:  10,
+		MinimumTemperature: -2,
+		ProductID:          productID,
+		SectionID:          sectionID,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, where the
+// product types 1 and 2 exist, each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (batch.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save a batch and list it", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		b := NewBatch(1, fixtures.AddProduct(t, 1), fixtures.AddSection(t, 1))
+
+		// Act
+		id, err := repo.Save(ctx, b)
+		require.NoError(t, err)
+		obtained, err := repo.GetAll(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		b.ID = id
+		assert.Equal(t, []domain.ProductBatch{b}, obtained)
+	})
+
+	t.Run("it should store the cold chain override of a batch", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		product := fixtures.AddProduct(t, 1)
+		b := NewBatch(1, product, fixtures.AddSection(t, 1))
+		b.ColdChainOverride = &domain.ColdChainOverride{
+			Reason:     "the freezer is fixed tonight",
+			Actor:      "admin",
+			Violations: []domain.ColdChainViolation{{Rule: batch.RuleSectionAboveRecommended, Message: "too warm", Temperature: 4, Limit: -4}},
+		}
+
+		// Act
+		id, err := repo.Save(ctx, b)
+		require.NoError(t, err)
+		obtained, err := repo.GetByProductIDs(ctx, []int{product})
+
+		// Assert
+		require.NoError(t, err)
+		b.ID = id
+		assert.Equal(t, []domain.ProductBatch{b}, obtained)
+	})
+
+	t.Run("it should pass every batch to fn and stop at its first error", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		product, section := fixtures.AddProduct(t, 1), fixtures.AddSection(t, 1)
+		for _, number := range []int{1, 2, 3} {
+			_, err := repo.Save(ctx, NewBatch(number, product, section))
+			require.NoError(t, err)
+		}
+		stop := errors.New("stop")
+
+		// Act
+		var all, some []int
+		err := repo.Each(ctx, func(b domain.ProductBatch) error {
+			all = append(all, b.BatchNumber)
+			return nil
+		})
+		require.NoError(t, err)
+		errStopped := repo.Each(ctx, func(b domain.ProductBatch) error {
+			some = append(some, b.BatchNumber)
+			return stop
+		})
+
+		// Assert
+		assert.ElementsMatch(t, []int{1, 2, 3}, all)
+		assert.Len(t, some, 1)
+		assert.True(t, errors.Is(errStopped, stop))
+	})
+
+	t.Run("it should report whether a batch number is taken", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		_, err := repo.Save(ctx, NewBatch(1, fixtures.AddProduct(t, 1), fixtures.AddSection(t, 1)))
+		require.NoError(t, err)
+
+		assert.True(t, repo.Exists(ctx, 1))
+		assert.False(t, repo.Exists(ctx, 2))
+	})
+
+	t.Run("it should consume the stock a batch holds", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		id, err := repo.Save(ctx, NewBatch(1, fixtures.AddProduct(t, 1), fixtures.AddSection(t, 1)))
+		require.NoError(t, err)
+
+		// Act
+		consumed, err := repo.Consume(ctx, id, 4)
+		_, errTooMuch := repo.Consume(ctx, id, 7)
+		_, errMissing := repo.Consume(ctx, id+100, 1)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, id, consumed.ID)
+		assert.Equal(t, 6, consumed.CurrentQuantity)
+		assert.True(t, errors.Is(errTooMuch, batch.ErrNotEnoughStock))
+		assert.True(t, errors.Is(errMissing, batch.ErrNotFound))
+		all, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 6, all[0].CurrentQuantity)
+	})
+
+	t.Run("it should reject a batch whose product does not exist", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+
+		_, err := repo.Save(ctx, NewBatch(1, 99, fixtures.AddSection(t, 1)))
+
+		assert.True(t, errors.Is(err, batch.ErrProductNotFound))
+	})
+
+	t.Run("it should reject a batch whose section does not exist", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+
+		_, err := repo.Save(ctx, NewBatch(1, fixtures.AddProduct(t, 1), 99))
+
+		assert.True(t, errors.Is(err, batch.ErrSectionNotFound))
+	})
+
+	t.Run("it should reject a batch whose product is not of the type of its section", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+
+		_, err := repo.Save(ctx, NewBatch(1, fixtures.AddProduct(t, 1), fixtures.AddSection(t, 2)))
+
+		assert.True(t, errors.Is(err, batch.ErrProductTypeMismatch))
+		assert.False(t, repo.Exists(ctx, 1))
+	})
+
+	t.Run("it should adjust the current capacity of a section within its bounds", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		id := fixtures.AddSection(t, 1)
+		first, err := repo.AdjustSectionCapacity(ctx, id, 0)
+		require.NoError(t, err)
+
+		// Act
+		added, err := repo.AdjustSectionCapacity(ctx, id, 5)
+		require.NoError(t, err)
+		_, errFull := repo.AdjustSectionCapacity(ctx, id, added.MaximumCapacity-added.CurrentCapacity+1)
+		_, errEmpty := repo.AdjustSectionCapacity(ctx, id, -added.CurrentCapacity-1)
+		taken, err := repo.AdjustSectionCapacity(ctx, id, -added.CurrentCapacity)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, id, added.ID)
+		assert.Equal(t, first.CurrentCapacity+5, added.CurrentCapacity)
+		assert.ErrorIs(t, errFull, batch.ErrSectionFull)
+		assert.ErrorIs(t, errEmpty, batch.ErrSectionFull)
+		assert.Equal(t, 0, taken.CurrentCapacity)
+	})
+
+	t.Run("it should not adjust the capacity of a section that does not exist", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.AdjustSectionCapacity(ctx, 99, 0)
+
+		assert.ErrorIs(t, err, batch.ErrSectionNotFound)
+	})
+
+	t.Run("it should return the batches of the given products", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		section := fixtures.AddSection(t, 1)
+		first, second, other := fixtures.AddProduct(t, 1), fixtures.AddProduct(t, 1), fixtures.AddProduct(t, 1)
+		for number, product := range []int{first, second, second, other} {
+			_, err := repo.Save(ctx, NewBatch(number+1, product, section))
+			require.NoError(t, err)
+		}
+
+		// Act
+		obtained, err := repo.GetByProductIDs(ctx, []int{first, second})
+
+		// Assert
+		require.NoError(t, err)
+		var products []int
+		for _, b := range obtained {
+			products = append(products, b.ProductID)
+		}
+		assert.ElementsMatch(t, []int{first, second, second}, products)
+	})
+}
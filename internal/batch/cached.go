@@ -0,0 +1,54 @@
+package batch
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/cache"
+)
+
+// cachedRepository removes from a cache the sections whose capacity the
+// batches of a Repository change.
+type cachedRepository struct {
+	Repository
+	cache cache.Cache
+}
+
+// NewCachedRepository returns r removing from c, where the cached section
+// repository keeps them, the sections whose capacity it changes.
+func NewCachedRepository(r Repository, c cache.Cache) Repository {
+	return &cachedRepository{Repository: r, cache: c}
+}
+
+func (r *cachedRepository) AdjustSectionCapacity(ctx context.Context, sectionID, quantity int) (domain.Section, error) {
+	defer cache.Invalidate(ctx, r.cache, section.CacheKey(sectionID))
+	return r.Repository.AdjustSectionCapacity(ctx, sectionID, quantity)
+}
+
+// InTx runs fn in a transaction of the repository and invalidates the
+// sections written once it ends.
+func (r *cachedRepository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	tx := &txRepository{}
+	defer func() { cache.Invalidate(ctx, r.cache, tx.written...) }()
+	return r.Repository.InTx(ctx, func(inner Repository) error {
+		tx.Repository = inner
+		return fn(tx)
+	})
+}
+
+// txRepository records the keys of the sections written in a transaction.
+type txRepository struct {
+	Repository
+	written []string
+}
+
+func (r *txRepository) AdjustSectionCapacity(ctx context.Context, sectionID, quantity int) (domain.Section, error) {
+	r.written = append(r.written, section.CacheKey(sectionID))
+	return r.Repository.AdjustSectionCapacity(ctx, sectionID, quantity)
+}
+
+// InTx runs fn in the transaction already open.
+func (r *txRepository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return fn(r)
+}
@@ -0,0 +1,41 @@
+package batch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should remove from the cache a section whose capacity changes in a transaction", func(t *testing.T) {
+		// Arrange
+		c := cache.NewLRU(10)
+		require.NoError(t, c.Set(ctx, section.CacheKey(3), []byte(`{"id":3}`), time.Minute))
+		require.NoError(t, c.Set(ctx, section.CacheKey(4), []byte(`{"id":4}`), time.Minute))
+		inner := &RepositoryMock{}
+		inner.On("InTx", ctx).Return(nil)
+		inner.On("AdjustSectionCapacity", ctx, 3, 10).Return(domain.Section{ID: 3, CurrentCapacity: 20}, nil)
+		r := NewCachedRepository(inner, c)
+
+		// Act
+		err := r.InTx(ctx, func(tx Repository) error {
+			_, err := tx.AdjustSectionCapacity(ctx, 3, 10)
+			return err
+		})
+
+		// Assert
+		require.NoError(t, err)
+		_, found, _ := c.Get(ctx, section.CacheKey(3))
+		assert.False(t, found)
+		_, found, _ = c.Get(ctx, section.CacheKey(4))
+		assert.True(t, found)
+	})
+}
@@ -0,0 +1,130 @@
+package batch
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/section"
+)
+
+// Errors of the cold chain check
+var (
+	// ErrColdChain is returned, in a *ColdChainError, for a batch whose
+	// section cannot keep it at its temperatures.
+	ErrColdChain = errors.New("the section breaks the cold chain of the batch")
+	// ErrOverrideNotAllowed is returned for an override of the cold chain
+	// check of a batch that breaks it. Overrides are refused until the
+	// requests are authenticated: the actor of a request is only the
+	// X-Actor header the client chose.
+	ErrOverrideNotAllowed = errors.New("the cold chain check cannot be overridden without authentication")
+	// ErrOverrideReason is returned for an override of the cold chain check
+	// without a reason.
+	ErrOverrideReason = errors.New("the cold chain override needs a reason")
+)
+
+// Rules of the cold chain check
+const (
+	// RuleSectionBelowBatchMinimum rejects a section colder than the
+	// minimum temperature of the batch.
+	RuleSectionBelowBatchMinimum = "section_below_batch_minimum"
+	// RuleSectionMinimumBelowBatchMinimum rejects a section whose minimum
+	// temperature is below the minimum temperature of the batch.
+	RuleSectionMinimumBelowBatchMinimum = "section_minimum_below_batch_minimum"
+	// RuleSectionAboveRecommended rejects a section warmer than the
+	// recommended freezing temperature of the product.
+	RuleSectionAboveRecommended = "section_above_recommended_temperature"
+)
+
+// ColdChainError lists the violations of the cold chain of a batch.
+type ColdChainError struct {
+	Violations []domain.ColdChainViolation
+}
+
+func (e *ColdChainError) Error() string {
+	rules := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		rules = append(rules, v.Rule)
+	}
+	return fmt.Sprintf("%s: %s", ErrColdChain, strings.Join(rules, ", "))
+}
+
+func (e *ColdChainError) Unwrap() error { return ErrColdChain }
+
+// ColdChainViolations returns the temperatures of section s at which batch b
+// of product p cannot be kept, none when s keeps its cold chain.
+func ColdChainViolations(b domain.ProductBatch, p domain.Product, s domain.Section) []domain.ColdChainViolation {
+	var violations []domain.ColdChainViolation
+	if s.CurrentTemperature < b.MinimumTemperature {
+		violations = append(violations, domain.ColdChainViolation{
+			Rule:        RuleSectionBelowBatchMinimum,
+			Message:     "the section is colder than the minimum temperature of the batch",
+			Temperature: float64(s.CurrentTemperature),
+			Limit:       float64(b.MinimumTemperature),
+		})
+	}
+	if s.MinimumTemperature < b.MinimumTemperature {
+		violations = append(violations, domain.ColdChainViolation{
+			Rule:        RuleSectionMinimumBelowBatchMinimum,
+			Message:     "the section may get colder than the minimum temperature of the batch",
+			Temperature: float64(s.MinimumTemperature),
+			Limit:       float64(b.MinimumTemperature),
+		})
+	}
+	if float32(s.CurrentTemperature) > p.RecomFreezTemp {
+		violations = append(violations, domain.ColdChainViolation{
+			Rule:        RuleSectionAboveRecommended,
+			Message:     "the section is warmer than the recommended freezing temperature of the product",
+			Temperature: float64(s.CurrentTemperature),
+			Limit:       float64(p.RecomFreezTemp),
+		})
+	}
+	return violations
+}
+
+// coldChain checks the cold chain of the batches saved.
+type coldChain struct {
+	products ProductGetter
+	sections SectionGetter
+}
+
+// check returns a *ColdChainError when the section of b breaks its cold
+// chain, or ErrOverrideNotAllowed when b carries an override of the check.
+// An override without a reason is ErrOverrideReason, and the override of a
+// batch without violations is dropped. A product or section that does not
+// exist is ErrProductNotFound or ErrSectionNotFound, and one that cannot be
+// read fails the check.
+func (cc *coldChain) check(ctx context.Context, b *domain.ProductBatch) error {
+	override := b.ColdChainOverride
+	b.ColdChainOverride = nil
+	if override != nil && strings.TrimSpace(override.Reason) == "" {
+		return ErrOverrideReason
+	}
+
+	p, err := cc.products.Get(ctx, b.ProductID)
+	switch {
+	case errors.Is(err, product.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return ErrProductNotFound
+	case err != nil:
+		return err
+	}
+	s, err := cc.sections.Get(ctx, b.SectionID)
+	switch {
+	case errors.Is(err, section.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return ErrSectionNotFound
+	case err != nil:
+		return err
+	}
+	violations := ColdChainViolations(*b, p, s)
+	if len(violations) == 0 {
+		return nil
+	}
+	if override != nil {
+		return ErrOverrideNotAllowed
+	}
+	return &ColdChainError{Violations: violations}
+}
@@ -0,0 +1,175 @@
+package batch
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColdChainViolations(t *testing.T) {
+	b := domain.ProductBatch{MinimumTemperature: -20}
+	p := domain.Product{RecomFreezTemp: -18}
+
+	t.Run("it should accept a section within the temperatures of the batch and its product", func(t *testing.T) {
+		assert.Empty(t, ColdChainViolations(b, p, domain.Section{CurrentTemperature: -18, MinimumTemperature: -20}))
+	})
+
+	t.Run("it should report every temperature the section breaks", func(t *testing.T) {
+		violations := ColdChainViolations(b, p, domain.Section{CurrentTemperature: -22, MinimumTemperature: -25})
+
+		require.Len(t, violations, 2)
+		assert.Equal(t, RuleSectionBelowBatchMinimum, violations[0].Rule)
+		assert.Equal(t, -22.0, violations[0].Temperature)
+		assert.Equal(t, -20.0, violations[0].Limit)
+		assert.Equal(t, RuleSectionMinimumBelowBatchMinimum, violations[1].Rule)
+		assert.Equal(t, -25.0, violations[1].Temperature)
+	})
+
+	t.Run("it should report a section warmer than the recommended temperature of the product", func(t *testing.T) {
+		violations := ColdChainViolations(b, p, domain.Section{CurrentTemperature: -5, MinimumTemperature: -20})
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, RuleSectionAboveRecommended, violations[0].Rule)
+		assert.Equal(t, -18.0, violations[0].Limit)
+	})
+}
+
+func TestService_SaveColdChain(t *testing.T) {
+	ctx := context.Background()
+	// The section is warmer than the product may be kept at.
+	b := domain.ProductBatch{BatchNumber: 1, MinimumTemperature: -20, ProductID: 2, SectionID: 3}
+	newService := func(repository *RepositoryMock) Service {
+		products, sections := &product.ServiceMock{}, &section.ServiceMock{}
+		products.On("Get", mock.Anything, 2).Return(domain.Product{ID: 2, RecomFreezTemp: -18}, nil)
+		sections.On("Get", mock.Anything, 3).Return(domain.Section{ID: 3, CurrentTemperature: -5, MinimumTemperature: -10}, nil)
+		return NewServiceWithColdChain(repository, products, sections)
+	}
+
+	t.Run("it should reject a batch whose section breaks its cold chain", func(t *testing.T) {
+		// Arrange
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, 1).Return(false)
+
+		// Act
+		saved, err := newService(repository).Save(ctx, b)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrColdChain)
+		var coldChainErr *ColdChainError
+		require.ErrorAs(t, err, &coldChainErr)
+		require.Len(t, coldChainErr.Violations, 1)
+		assert.Equal(t, RuleSectionAboveRecommended, coldChainErr.Violations[0].Rule)
+		assert.Equal(t, Saved{}, saved)
+		repository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should refuse every override until requests are authenticated", func(t *testing.T) {
+		// Arrange
+		ctx := web.WithActor(ctx, "admin")
+		overridden := b
+		overridden.ColdChainOverride = &domain.ColdChainOverride{Reason: "the freezer is fixed tonight"}
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, 1).Return(false)
+
+		// Act
+		_, err := newService(repository).Save(ctx, overridden)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrOverrideNotAllowed)
+		repository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should reject an override without a reason", func(t *testing.T) {
+		// Arrange
+		ctx := web.WithActor(ctx, "admin")
+		overridden := b
+		overridden.ColdChainOverride = &domain.ColdChainOverride{Reason: "  "}
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, 1).Return(false)
+
+		// Act
+		_, err := newService(repository).Save(ctx, overridden)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrOverrideReason)
+		assert.NotErrorIs(t, err, ErrColdChain)
+		repository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should drop the override of a batch that keeps its cold chain", func(t *testing.T) {
+		// Arrange
+		ctx := web.WithActor(ctx, "admin")
+		cold := b
+		cold.MinimumTemperature = -30
+		products, sections := &product.ServiceMock{}, &section.ServiceMock{}
+		products.On("Get", mock.Anything, 2).Return(domain.Product{ID: 2, RecomFreezTemp: 0}, nil)
+		sections.On("Get", mock.Anything, 3).Return(domain.Section{ID: 3, CurrentTemperature: -5, MinimumTemperature: -10}, nil)
+		overridden := cold
+		overridden.ColdChainOverride = &domain.ColdChainOverride{Reason: "just in case"}
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, 1).Return(false)
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Save", ctx, cold).Return(4, nil)
+		repository.On("SaveEvent", ctx, mock.Anything).Return(nil)
+		repository.On("AdjustSectionCapacity", ctx, 3, 0).Return(domain.Section{ID: 3}, nil)
+
+		// Act
+		_, err := NewServiceWithColdChain(repository, products, sections).Save(ctx, overridden)
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("it should reject a batch whose product or section does not exist", func(t *testing.T) {
+		// Arrange
+		products, sections := &product.ServiceMock{}, &section.ServiceMock{}
+		products.On("Get", mock.Anything, 2).Return(domain.Product{ID: 2}, nil)
+		products.On("Get", mock.Anything, 9).Return(domain.Product{}, sql.ErrNoRows)
+		sections.On("Get", mock.Anything, 3).Return(domain.Section{}, section.ErrNotFound)
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, 1).Return(false)
+		service := NewServiceWithColdChain(repository, products, sections)
+		noProduct := b
+		noProduct.ProductID = 9
+
+		// Act
+		_, errProduct := service.Save(ctx, noProduct)
+		_, errSection := service.Save(ctx, b)
+
+		// Assert
+		assert.ErrorIs(t, errProduct, ErrProductNotFound)
+		assert.ErrorIs(t, errSection, ErrSectionNotFound)
+		repository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should fail when the product or section cannot be read", func(t *testing.T) {
+		// Arrange
+		products, sections := &product.ServiceMock{}, &section.ServiceMock{}
+		products.On("Get", mock.Anything, 2).Return(domain.Product{ID: 2}, nil)
+		products.On("Get", mock.Anything, 9).Return(domain.Product{}, sql.ErrConnDone)
+		sections.On("Get", mock.Anything, 3).Return(domain.Section{}, context.DeadlineExceeded)
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, 1).Return(false)
+		service := NewServiceWithColdChain(repository, products, sections)
+		unreadable := b
+		unreadable.ProductID = 9
+
+		// Act
+		_, errProduct := service.Save(ctx, unreadable)
+		_, errSection := service.Save(ctx, b)
+
+		// Assert
+		assert.ErrorIs(t, errProduct, sql.ErrConnDone)
+		assert.ErrorIs(t, errSection, context.DeadlineExceeded)
+		repository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,67 @@
+package batch
+
+import (
+	"context"
+	"log"
+
+	"github.com/davidop97/apiGo/internal/activity"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+)
+
+// SectionGetter gets a section, e.g. through the section service or the
+// cached section repository.
+type SectionGetter interface {
+	Get(ctx context.Context, id int) (domain.Section, error)
+}
+
+// liveService publishes the batches a Service creates, and the changes of
+// capacity of their sections, to the live feed of warehouse activity.
+type liveService struct {
+	Service
+	sections SectionGetter
+	feed     activity.Publisher
+}
+
+// NewLiveService returns s publishing the batches it creates to feed, in the
+// warehouse of their section as read from sections, and the changes of
+// capacity of the sections its batches make.
+func NewLiveService(s Service, sections SectionGetter, feed activity.Publisher) Service {
+	return &liveService{Service: s, sections: sections, feed: feed}
+}
+
+// Save saves a product batch and publishes its creation. A batch whose
+// section cannot be read is not published, as its warehouse is unknown: the
+// batch was saved and the request succeeded all the same.
+func (s *liveService) Save(ctx context.Context, b domain.ProductBatch) (Saved, error) {
+	saved, err := s.Service.Save(ctx, b)
+	if err != nil {
+		return Saved{}, err
+	}
+	b.ID = saved.ID
+	s.publishSections(saved.Sections)
+	sect, err := s.sections.Get(context.WithoutCancel(ctx), b.SectionID)
+	if err != nil {
+		log.Printf("activity: reading section %d of product batch %d: %v", b.SectionID, saved.ID, err)
+		return saved, nil
+	}
+	s.feed.Publish(activity.BatchCreated, sect.WarehouseID, b)
+	return saved, nil
+}
+
+// Consume consumes the stock of a product batch and publishes the change of
+// the capacity of its section.
+func (s *liveService) Consume(ctx context.Context, id, quantity int) (Consumed, error) {
+	consumed, err := s.Service.Consume(ctx, id, quantity)
+	if err != nil {
+		return Consumed{}, err
+	}
+	s.publishSections(consumed.Sections)
+	return consumed, nil
+}
+
+func (s *liveService) publishSections(changes []SectionChange) {
+	for _, c := range changes {
+		section.PublishCapacity(s.feed, c.Before, c.After)
+	}
+}
@@ -0,0 +1,83 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/activity"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveService(t *testing.T) {
+	ctx := context.Background()
+	b := domain.ProductBatch{BatchNumber: 111, CurrentQuantity: 200, ProductID: 1, SectionID: 12}
+
+	t.Run("it should publish the creation of a batch in the warehouse of its section", func(t *testing.T) {
+		// Arrange
+		inner, sections, feed := &ServiceMock{}, &section.ServiceMock{}, activity.NewFeed(10)
+		inner.On("Save", mock.Anything, b).Return(Saved{ID: 7}, nil)
+		sections.On("Get", mock.Anything, 12).Return(domain.Section{ID: 12, WarehouseID: 3}, nil)
+		s := NewLiveService(inner, sections, feed)
+
+		// Act
+		obtained, err := s.Save(ctx, b)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, obtained.ID)
+		events, sub := feed.Subscribe(activity.Filter{}, activity.EventID{})
+		defer sub.Close()
+		require.Len(t, events, 1)
+		saved := b
+		saved.ID = 7
+		assert.Equal(t, activity.BatchCreated, events[0].Type)
+		assert.Equal(t, 3, events[0].WarehouseID)
+		assert.Equal(t, saved, events[0].Data)
+	})
+
+	t.Run("it should save a batch whose section cannot be read without publishing it", func(t *testing.T) {
+		// Arrange
+		inner, sections, feed := &ServiceMock{}, &section.ServiceMock{}, activity.NewFeed(10)
+		inner.On("Save", mock.Anything, b).Return(Saved{ID: 7}, nil)
+		sections.On("Get", mock.Anything, 12).Return(domain.Section{}, errors.New("connection refused"))
+		s := NewLiveService(inner, sections, feed)
+
+		// Act
+		obtained, err := s.Save(ctx, b)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, obtained.ID)
+		events, sub := feed.Subscribe(activity.Filter{}, activity.EventID{})
+		defer sub.Close()
+		assert.Empty(t, events)
+	})
+
+	t.Run("it should publish the changes of capacity of the sections once the consumption succeeded", func(t *testing.T) {
+		// Arrange
+		before := domain.Section{ID: 12, SectionNumber: 4, CurrentCapacity: 60, MinimumCapacity: 50, MaximumCapacity: 100, WarehouseID: 3}
+		after := before
+		after.CurrentCapacity = 40
+		inner, feed := &ServiceMock{}, activity.NewFeed(10)
+		inner.On("Consume", mock.Anything, 7, 20).
+			Return(Consumed{Batch: domain.ProductBatch{ID: 7, SectionID: 12}, Sections: []SectionChange{{Before: before, After: after}}}, nil)
+		s := NewLiveService(inner, &section.ServiceMock{}, feed)
+
+		// Act
+		_, err := s.Consume(ctx, 7, 20)
+
+		// Assert
+		assert.NoError(t, err)
+		events, sub := feed.Subscribe(activity.Filter{}, activity.EventID{})
+		defer sub.Close()
+		require.Len(t, events, 1)
+		assert.Equal(t, activity.SectionCapacityChanged, events[0].Type)
+		assert.Equal(t, 3, events[0].WarehouseID)
+		assert.Equal(t, section.CapacityChange{SectionID: 12, SectionNumber: 4, PreviousCapacity: 60, CurrentCapacity: 40, MinimumCapacity: 50, MaximumCapacity: 100}, events[0].Data)
+	})
+}
@@ -3,25 +3,71 @@ package batch
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/davidop97/apiGo/pkg/sqlin"
 )
 
 // Errors
 var (
 	ErrProductNotFound = errors.New("associated product not found")
 	ErrSectionNotFound = errors.New("associated section not found")
+	// ErrProductTypeMismatch is returned when the product of a batch is not of
+	// the product type of its section.
+	ErrProductTypeMismatch = errors.New("product type does not match the section")
+	// ErrSectionFull is returned when a batch would take its section over
+	// its maximum capacity, or below zero.
+	ErrSectionFull = errors.New("batch exceeds the capacity of the section")
+	// ErrNotFound is returned when a product batch does not exist.
+	ErrNotFound = errors.New("product batch not found")
+	// ErrNotEnoughStock is returned when more is consumed from a batch than
+	// it holds.
+	ErrNotEnoughStock = errors.New("batch holds less than the quantity consumed")
 )
 
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.ProductBatch, error)
+	// Each calls fn with every Product Batch, one row at a time, and stops at
+	// the first error fn returns.
+	Each(ctx context.Context, fn func(domain.ProductBatch) error) error
 	Save(ctx context.Context, b domain.ProductBatch) (int, error)
 	Exists(ctx context.Context, batchNumber int) bool
+	GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatch, error)
+	// Consume takes quantity from the current quantity of a batch and
+	// returns the batch changed. It returns ErrNotFound when the batch does
+	// not exist and ErrNotEnoughStock, changing nothing, when it holds less
+	// than quantity.
+	Consume(ctx context.Context, id, quantity int) (domain.ProductBatch, error)
+	// AdjustSectionCapacity adds quantity, which may be negative, to the
+	// current capacity of a section and returns the section changed. It
+	// returns ErrSectionFull, changing nothing, when the capacity would
+	// exceed the maximum capacity of the section or go below zero.
+	AdjustSectionCapacity(ctx context.Context, sectionID, quantity int) (domain.Section, error)
+	// SaveEvent writes e to the outbox, to be published once committed.
+	SaveEvent(ctx context.Context, e events.Event) error
+	// InTx calls fn with a repository whose queries run in one transaction,
+	// committed only if fn returns nil.
+	InTx(ctx context.Context, fn func(r Repository) error) error
+}
+
+// ProductGetter gets a product, e.g. through the cached product repository.
+type ProductGetter interface {
+	Get(ctx context.Context, id int) (domain.Product, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db dbtx.DB
+	// products and sections check the references of the batches saved. They
+	// are queried directly when nil.
+	products ProductGetter
+	sections SectionGetter
 }
 
 func NewRepository(db *sql.DB) Repository {
@@ -30,46 +76,75 @@ func NewRepository(db *sql.DB) Repository {
 	}
 }
 
+// NewRepositoryWithLookups returns a repository checking that the product
+// and the section of the batches it saves exist through products and
+// sections, which may be cached, instead of querying them.
+func NewRepositoryWithLookups(db *sql.DB, products ProductGetter, sections SectionGetter) Repository {
+	return &repository{
+		db:       db,
+		products: products,
+		sections: sections,
+	}
+}
+
 // GetAll returns all Product Batches stored in the database
 func (r *repository) GetAll(ctx context.Context) (batches []domain.ProductBatch, err error) {
-	query := "SELECT id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id FROM productBatches;"
-	rows, err := r.db.Query(query)
+	err = r.Each(ctx, func(b domain.ProductBatch) error {
+		batches = append(batches, b)
+		return nil
+	})
+	return
+}
+
+// Each scans the Product Batches stored in the database into fn as the rows
+// are read, so they are never held in memory together
+func (r *repository) Each(ctx context.Context, fn func(domain.ProductBatch) error) error {
+	query := "SELECT id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id, cold_chain_override FROM productBatches;"
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
-		return
+		return err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		b := domain.ProductBatch{}
-		_ = rows.Scan(&b.ID, &b.BatchNumber, &b.CurrentQuantity, &b.CurrentTemperature, &b.DueDate, &b.InitialQuantity, &b.ManufacturingDate, &b.ManufacturingHour, &b.MinimumTemperature, &b.ProductID, &b.SectionID)
-		batches = append(batches, b)
+		if err := rows.Scan(&b.ID, &b.BatchNumber, &b.CurrentQuantity, &b.CurrentTemperature, &b.DueDate, &b.InitialQuantity, &b.ManufacturingDate, &b.ManufacturingHour, &b.MinimumTemperature, &b.ProductID, &b.SectionID, overrideColumn{&b.ColdChainOverride}); err != nil {
+			return err
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
 	}
-	return
+	return rows.Err()
 }
 
 // Save stores a new Product Batch in the database
 func (r *repository) Save(ctx context.Context, b domain.ProductBatch) (int, error) {
 	// Check if foreign keys exist
 	// - check if associated product exists
-	exists := r.productExists(ctx, b.ProductID)
+	productType, exists := r.productType(ctx, b.ProductID)
 	if !exists {
 		return 0, ErrProductNotFound
 	}
 	// - check if associated section exists
-	exists = r.sectionExists(ctx, b.SectionID)
+	sectionType, exists := r.sectionType(ctx, b.SectionID)
 	if !exists {
 		return 0, ErrSectionNotFound
 	}
+	// - a section only stores the products of its type
+	if productType != sectionType {
+		return 0, ErrProductTypeMismatch
+	}
 
 	// Prepare query
-	query := "INSERT INTO productBatches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	query := "INSERT INTO productBatches (batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id, cold_chain_override) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return 0, err
 	}
 
 	// Run query
-	res, err := stmt.Exec(&b.BatchNumber, &b.CurrentQuantity, &b.CurrentTemperature, &b.DueDate, &b.InitialQuantity, &b.ManufacturingDate, &b.ManufacturingHour, &b.MinimumTemperature, &b.ProductID, &b.SectionID)
+	res, err := stmt.Exec(&b.BatchNumber, &b.CurrentQuantity, &b.CurrentTemperature, &b.DueDate, &b.InitialQuantity, &b.ManufacturingDate, &b.ManufacturingHour, &b.MinimumTemperature, &b.ProductID, &b.SectionID, overrideColumn{&b.ColdChainOverride})
 	if err != nil {
 		return 0, err
 	}
@@ -91,18 +166,158 @@ func (r *repository) Exists(ctx context.Context, batchNumber int) bool {
 	return err == nil
 }
 
-// productExists is an auxiliary function that checks if a product id exists in the database
-func (r *repository) productExists(ctx context.Context, id int) bool {
-	query := "SELECT id FROM products WHERE id=?;"
+// productType is an auxiliary function that returns the product type of a
+// product, and whether the product exists in the database
+func (r *repository) productType(ctx context.Context, id int) (int, bool) {
+	if r.products != nil {
+		p, err := r.products.Get(ctx, id)
+		return p.ProductTypeID, err == nil
+	}
+	query := "SELECT id_product_type FROM products WHERE id=? AND deleted_at IS NULL;"
 	row := r.db.QueryRow(query, id)
-	err := row.Scan(&id)
-	return err == nil
+	var productType int
+	err := row.Scan(&productType)
+	return productType, err == nil
 }
 
-// sectionExists is an auxiliary function that checks if a section id exists in the database
-func (r *repository) sectionExists(ctx context.Context, id int) bool {
-	query := "SELECT id FROM sections WHERE id=?;"
+// sectionType is an auxiliary function that returns the product type of a
+// section, and whether the section exists in the database
+func (r *repository) sectionType(ctx context.Context, id int) (int, bool) {
+	if r.sections != nil {
+		s, err := r.sections.Get(ctx, id)
+		return s.ProductTypeID, err == nil
+	}
+	query := "SELECT id_product_type FROM sections WHERE id=?;"
 	row := r.db.QueryRow(query, id)
-	err := row.Scan(&id)
-	return err == nil
+	var productType int
+	err := row.Scan(&productType)
+	return productType, err == nil
+}
+
+// GetByProductIDs returns the Product Batches of every product in productIDs
+func (r *repository) GetByProductIDs(ctx context.Context, productIDs []int) (batches []domain.ProductBatch, err error) {
+	if len(productIDs) == 0 {
+		return
+	}
+	in, args := sqlin.Ints(productIDs)
+	query := "SELECT id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id, cold_chain_override FROM productBatches WHERE product_id IN " + in
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		b := domain.ProductBatch{}
+		if err = rows.Scan(&b.ID, &b.BatchNumber, &b.CurrentQuantity, &b.CurrentTemperature, &b.DueDate, &b.InitialQuantity, &b.ManufacturingDate, &b.ManufacturingHour, &b.MinimumTemperature, &b.ProductID, &b.SectionID, overrideColumn{&b.ColdChainOverride}); err != nil {
+			return nil, err
+		}
+		batches = append(batches, b)
+	}
+	err = rows.Err()
+	return
+}
+
+// overrideColumn reads and writes the cold_chain_override column, the JSON of
+// the override of a batch or NULL.
+type overrideColumn struct {
+	o **domain.ColdChainOverride
+}
+
+// Scan reads the override, nil for NULL.
+func (c overrideColumn) Scan(src interface{}) error {
+	*c.o = nil
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("batch: cannot scan %T into a cold chain override", src)
+	}
+	return json.Unmarshal(b, c.o)
+}
+
+// Value writes the override, NULL for nil.
+func (c overrideColumn) Value() (driver.Value, error) {
+	if *c.o == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(*c.o)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Consume takes quantity from a batch in a single statement, which only
+// matches the batch while it holds quantity, so concurrent consumptions
+// cannot take it below zero.
+func (r *repository) Consume(ctx context.Context, id, quantity int) (domain.ProductBatch, error) {
+	query := "UPDATE productBatches SET current_quantity = current_quantity - ? WHERE id = ? AND current_quantity >= ?;"
+	res, err := r.db.ExecContext(ctx, query, quantity, id, quantity)
+	if err != nil {
+		return domain.ProductBatch{}, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return domain.ProductBatch{}, err
+	}
+
+	query = "SELECT id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id, cold_chain_override FROM productBatches WHERE id=?;"
+	b := domain.ProductBatch{}
+	err = r.db.QueryRowContext(ctx, query, id).Scan(&b.ID, &b.BatchNumber, &b.CurrentQuantity, &b.CurrentTemperature, &b.DueDate, &b.InitialQuantity, &b.ManufacturingDate, &b.ManufacturingHour, &b.MinimumTemperature, &b.ProductID, &b.SectionID, overrideColumn{&b.ColdChainOverride})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return domain.ProductBatch{}, ErrNotFound
+	case err != nil:
+		return domain.ProductBatch{}, err
+	case affected == 0:
+		return domain.ProductBatch{}, ErrNotEnoughStock
+	}
+	return b, nil
+}
+
+// AdjustSectionCapacity changes the current capacity of a section in a single
+// statement, which only matches the section while the capacity stays within
+// its bounds, so concurrent batches cannot take it over its maximum.
+func (r *repository) AdjustSectionCapacity(ctx context.Context, sectionID, quantity int) (domain.Section, error) {
+	// An update that changes nothing affects no rows, as a full section does
+	if quantity != 0 {
+		query := "UPDATE sections SET current_capacity = current_capacity + ? WHERE id = ? AND current_capacity + ? BETWEEN 0 AND maximum_capacity;"
+		res, err := r.db.ExecContext(ctx, query, quantity, sectionID, quantity)
+		if err != nil {
+			return domain.Section{}, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return domain.Section{}, err
+		}
+		if affected == 0 {
+			return domain.Section{}, ErrSectionFull
+		}
+	}
+
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id=?;"
+	var s domain.Section
+	err := r.db.QueryRowContext(ctx, query, sectionID).Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Section{}, ErrSectionNotFound
+	}
+	return s, err
+}
+
+// SaveEvent writes an event to the outbox table.
+func (r *repository) SaveEvent(ctx context.Context, e events.Event) error {
+	return outbox.Save(ctx, r.db, e)
+}
+
+// InTx runs fn on a copy of the repository bound to a transaction.
+func (r *repository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return dbtx.Run(ctx, r.db, func(tx dbtx.DB) error {
+		return fn(&repository{db: tx, products: r.products, sections: r.sections})
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/events"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -16,6 +17,16 @@ func (r *RepositoryMock) GetAll(ctx context.Context) ([]domain.ProductBatch, err
 	return args.Get(0).([]domain.ProductBatch), args.Error(1)
 }
 
+func (r *RepositoryMock) Each(ctx context.Context, fn func(domain.ProductBatch) error) error {
+	args := r.Called(ctx)
+	for _, b := range args.Get(0).([]domain.ProductBatch) {
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (r *RepositoryMock) Save(ctx context.Context, b domain.ProductBatch) (int, error) {
 	args := r.Called(ctx, b)
 	return args.Int(0), args.Error(1)
@@ -25,3 +36,31 @@ func (r *RepositoryMock) Exists(ctx context.Context, batchNumber int) bool {
 	args := r.Called(ctx, batchNumber)
 	return args.Bool(0)
 }
+
+func (r *RepositoryMock) GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatch, error) {
+	args := r.Called(ctx, productIDs)
+	return args.Get(0).([]domain.ProductBatch), args.Error(1)
+}
+
+func (r *RepositoryMock) Consume(ctx context.Context, id, quantity int) (domain.ProductBatch, error) {
+	args := r.Called(ctx, id, quantity)
+	return args.Get(0).(domain.ProductBatch), args.Error(1)
+}
+
+func (r *RepositoryMock) AdjustSectionCapacity(ctx context.Context, sectionID, quantity int) (domain.Section, error) {
+	args := r.Called(ctx, sectionID, quantity)
+	return args.Get(0).(domain.Section), args.Error(1)
+}
+
+func (r *RepositoryMock) SaveEvent(ctx context.Context, e events.Event) error {
+	args := r.Called(ctx, e)
+	return args.Error(0)
+}
+
+// InTx runs fn on the mock itself unless an error is set for the call.
+func (r *RepositoryMock) InTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := r.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(r)
+}
@@ -0,0 +1,69 @@
+package batch_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/batch/batchtest"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/product/producttest"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/producttype/producttypetest"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/section/sectiontest"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/internal/warehouse/warehousetest"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	batchtest.TestRepository(t, newRepository(false))
+}
+
+func TestRepository_MySQLCachedLookups(t *testing.T) {
+	batchtest.TestRepository(t, newRepository(true))
+}
+
+// newRepository returns the repositories the contract suite runs on, which
+// check the products and sections through cached repositories when cached is
+// set.
+func newRepository(cached bool) func(t *testing.T) (batch.Repository, batchtest.Fixtures) {
+	return func(t *testing.T) (batch.Repository, batchtest.Fixtures) {
+		db := mysqltest.Open(t)
+		producttypetest.AddProductTypes(t, producttype.NewRepository(db), 2)
+		warehousetest.AddWarehouses(t, warehouse.NewRepository(db), 1)
+		products := product.NewRepository(db)
+		sections := section.NewRepository(db)
+		next := 0
+
+		fixtures := batchtest.Fixtures{
+			AddProduct: func(t *testing.T, productTypeID int) int {
+				next++
+				p := producttest.NewProduct(fmt.Sprintf("P%d", next))
+				p.ProductTypeID = productTypeID
+				id, err := products.Save(context.Background(), p)
+				require.NoError(t, err)
+				return id
+			},
+			AddSection: func(t *testing.T, productTypeID int) int {
+				next++
+				s := sectiontest.NewSection(next)
+				s.ProductTypeID = productTypeID
+				id, err := sections.Save(context.Background(), s)
+				require.NoError(t, err)
+				return id
+			},
+		}
+		if cached {
+			lookups := cache.NewLRU(100)
+			return batch.NewRepositoryWithLookups(db, product.NewCachedRepository(products, lookups, time.Minute),
+				section.NewCachedRepository(sections, lookups, time.Minute)), fixtures
+		}
+		return batch.NewRepository(db), fixtures
+	}
+}
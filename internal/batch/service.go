@@ -5,26 +5,68 @@ import (
 	"errors"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
 )
 
 // Errors
 var (
 	ErrDuplicateBatchNumber = errors.New("duplicate batch number")
+	// ErrInvalidQuantity is returned when the quantity consumed from a batch
+	// is not positive.
+	ErrInvalidQuantity = errors.New("quantity must be positive")
 )
 
 type Service interface {
 	GetAll(ctx context.Context) (l []domain.ProductBatch, err error)
-	Save(ctx context.Context, batch domain.ProductBatch) (id int, err error)
+	Each(ctx context.Context, fn func(domain.ProductBatch) error) error
+	Save(ctx context.Context, batch domain.ProductBatch) (Saved, error)
+	GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatch, error)
+	// Consume takes quantity from the stock of a batch, and from the current
+	// capacity of its section, and returns the batch changed.
+	Consume(ctx context.Context, id, quantity int) (Consumed, error)
+}
+
+// Saved is the result of the creation of a batch.
+type Saved struct {
+	ID int
+	// Sections are the changes of the current capacity of the sections the
+	// stock of the batch made.
+	Sections []SectionChange
+}
+
+// Consumed is the result of the consumption of the stock of a batch.
+type Consumed struct {
+	Batch domain.ProductBatch
+	// Sections are the changes of the current capacity of the sections the
+	// consumption made.
+	Sections []SectionChange
+}
+
+// SectionChange is a change of the current capacity of a section made by the
+// stock of its batches.
+type SectionChange struct {
+	Before domain.Section
+	After  domain.Section
 }
 
 // service is a struct that represents a ProductBatch service
 type service struct {
 	r Repository
+	// coldChain checks the temperatures of the sections of the batches
+	// saved. They are not checked when nil.
+	coldChain *coldChain
 }
 
 // NewService returns a new instance of ProductBatch service
 func NewService(r Repository) Service {
-	return &service{r}
+	return &service{r: r}
+}
+
+// NewServiceWithColdChain returns a ProductBatch service rejecting the
+// batches whose section, read from sections, cannot keep them or their
+// product, read from products, at their temperatures.
+func NewServiceWithColdChain(r Repository, products ProductGetter, sections SectionGetter) Service {
+	return &service{r: r, coldChain: &coldChain{products: products, sections: sections}}
 }
 
 // GetAll returns all Product Batches
@@ -33,16 +75,101 @@ func (s *service) GetAll(ctx context.Context) (l []domain.ProductBatch, err erro
 	return
 }
 
-// Save stores a new Product Batch
-func (s *service) Save(ctx context.Context, b domain.ProductBatch) (id int, err error) {
+// Each calls fn with every Product Batch, one at a time
+func (s *service) Each(ctx context.Context, fn func(domain.ProductBatch) error) error {
+	return s.r.Each(ctx, fn)
+}
+
+// Save stores a new Product Batch, adding its current quantity to the current
+// capacity of its section
+func (s *service) Save(ctx context.Context, b domain.ProductBatch) (Saved, error) {
 	// Check if batch number is unique
 	exists := s.r.Exists(ctx, b.BatchNumber)
 	if exists {
-		err = ErrDuplicateBatchNumber
-		return
+		return Saved{}, ErrDuplicateBatchNumber
 	}
 
-	// Save new product batch
-	id, err = s.r.Save(ctx, b)
-	return
+	// Check the cold chain, which no batch may break
+	if s.coldChain == nil {
+		b.ColdChainOverride = nil
+	} else if err := s.coldChain.check(ctx, &b); err != nil {
+		return Saved{}, err
+	}
+
+	// Save new product batch, with the event of its creation
+	var saved Saved
+	err := s.r.InTx(ctx, func(r Repository) error {
+		var err error
+		if saved.ID, err = r.Save(ctx, b); err != nil {
+			return err
+		}
+		b.ID = saved.ID
+		e, err := outbox.NewEvent(outbox.ProductBatchCreated, saved.ID, b)
+		if err != nil {
+			return err
+		}
+		if err := r.SaveEvent(ctx, e); err != nil {
+			return err
+		}
+		saved.Sections, err = adjustCapacity(ctx, r, b.SectionID, b.CurrentQuantity)
+		return err
+	})
+	if err != nil {
+		return Saved{}, err
+	}
+	return saved, nil
+}
+
+// Consume takes quantity from the stock of a Product Batch and from the
+// current capacity of its section
+func (s *service) Consume(ctx context.Context, id, quantity int) (Consumed, error) {
+	if quantity <= 0 {
+		return Consumed{}, ErrInvalidQuantity
+	}
+	var consumed Consumed
+	err := s.r.InTx(ctx, func(r Repository) error {
+		var err error
+		if consumed.Batch, err = r.Consume(ctx, id, quantity); err != nil {
+			return err
+		}
+		consumed.Sections, err = adjustCapacity(ctx, r, consumed.Batch.SectionID, -quantity)
+		return err
+	})
+	if err != nil {
+		return Consumed{}, err
+	}
+	return consumed, nil
+}
+
+// adjustCapacity adds quantity, the stock a batch brings to a section or,
+// when negative, takes from it, to the current capacity of the section. A
+// section the change takes below its minimum capacity gets a
+// section.capacity_low event; one that already was below it does not get
+// another. r should be the transaction of the change of the batch. It
+// returns the change of the section, none when quantity is 0.
+func adjustCapacity(ctx context.Context, r Repository, sectionID, quantity int) ([]SectionChange, error) {
+	after, err := r.AdjustSectionCapacity(ctx, sectionID, quantity)
+	if err != nil {
+		return nil, err
+	}
+	if quantity == 0 {
+		return nil, nil
+	}
+	before := after
+	before.CurrentCapacity -= quantity
+	changes := []SectionChange{{Before: before, After: after}}
+
+	if before.CurrentCapacity < after.MinimumCapacity || after.CurrentCapacity >= after.MinimumCapacity {
+		return changes, nil
+	}
+	e, err := outbox.NewEvent(outbox.SectionCapacityLow, after.ID, after)
+	if err != nil {
+		return nil, err
+	}
+	return changes, r.SaveEvent(ctx, e)
+}
+
+// GetByProductIDs returns the Product Batches of every product in productIDs.
+func (s *service) GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatch, error) {
+	return s.r.GetByProductIDs(ctx, productIDs)
 }
@@ -16,7 +16,27 @@ func (s *ServiceMock) GetAll(ctx context.Context) ([]domain.ProductBatch, error)
 	return args.Get(0).([]domain.ProductBatch), args.Error(1)
 }
 
-func (s *ServiceMock) Save(ctx context.Context, b domain.ProductBatch) (int, error) {
+func (s *ServiceMock) Each(ctx context.Context, fn func(domain.ProductBatch) error) error {
+	args := s.Called(ctx)
+	for _, b := range args.Get(0).([]domain.ProductBatch) {
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (s *ServiceMock) Save(ctx context.Context, b domain.ProductBatch) (Saved, error) {
 	args := s.Called(ctx, b)
-	return args.Int(0), args.Error(1)
+	return args.Get(0).(Saved), args.Error(1)
+}
+
+func (s *ServiceMock) GetByProductIDs(ctx context.Context, productIDs []int) ([]domain.ProductBatch, error) {
+	args := s.Called(ctx, productIDs)
+	return args.Get(0).([]domain.ProductBatch), args.Error(1)
+}
+
+func (s *ServiceMock) Consume(ctx context.Context, id, quantity int) (Consumed, error) {
+	args := s.Called(ctx, id, quantity)
+	return args.Get(0).(Consumed), args.Error(1)
 }
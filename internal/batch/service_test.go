@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_GetAll(t *testing.T) {
@@ -93,19 +96,29 @@ func TestService_Save(t *testing.T) {
 		// - Mock the repository to first ensure that a batch with the expected ID does not already exist, and then to simulate the saving of the new batch.
 		repository := &RepositoryMock{}
 		repository.On("Exists", ctx, expectedID).Return(false)    // Simulate the batch does not already exist.
+		repository.On("InTx", ctx).Return(nil)                    // Simulate the transaction of the batch and its event.
 		repository.On("Save", ctx, batch).Return(expectedID, nil) // Simulate successful saving of the batch.
+		repository.On("SaveEvent", ctx, mock.MatchedBy(func(e events.Event) bool {
+			return e.Type == outbox.ProductBatchCreated && e.AggregateID == expectedID
+		})).Return(nil) // Simulate the event of the batch written to the outbox.
+		repository.On("AdjustSectionCapacity", ctx, 1, 1).Return(domain.Section{ID: 1, CurrentCapacity: 11, MinimumCapacity: 5}, nil) // Simulate the stock of the batch added to its section.
 		// - Instantiate the service with the mocked repository, allowing the service's save functionality to be tested independently of database operations.
 		service := NewService(repository)
 
 		// Act
-		// - Call the Save method on the service with the new batch, capturing the returned ID and any error.
-		obtainedID, obtainedError := service.Save(ctx, batch)
+		// - Call the Save method on the service with the new batch, capturing the result and any error.
+		obtained, obtainedError := service.Save(ctx, batch)
 
 		// Assert
 		// - Verify that no error was returned during the save operation. This check ensures that the service can save a batch without encountering issues.
 		assert.NoError(t, obtainedError)
 		// - Ensure the ID returned from the save operation matches the expected ID. This confirms that the service correctly returns the identifier of the saved batch.
-		assert.Equal(t, expectedID, obtainedID)
+		assert.Equal(t, expectedID, obtained.ID)
+		// - Ensure the change of the capacity of the section of the batch is returned for the decorators to record.
+		assert.Equal(t, []SectionChange{{
+			Before: domain.Section{ID: 1, CurrentCapacity: 10, MinimumCapacity: 5},
+			After:  domain.Section{ID: 1, CurrentCapacity: 11, MinimumCapacity: 5},
+		}}, obtained.Sections)
 		// - Confirm that the mock repository's expectations (i.e., calls to Exists and Save with the specified context and batch) were fulfilled.
 		//   This ensures that the service interacts with the repository as expected.
 		repository.AssertExpectations(t)
@@ -140,18 +153,124 @@ func TestService_Save(t *testing.T) {
 		service := NewService(repository)
 
 		// Act
-		// - Attempt to save the duplicate batch through the service, capturing the returned ID and any error.
-		obtainedID, obtainedError := service.Save(ctx, batch)
+		// - Attempt to save the duplicate batch through the service, capturing the result and any error.
+		obtained, obtainedError := service.Save(ctx, batch)
 
 		// Assert
 		// - Verify that the correct error is returned when attempting to save a duplicate product batch.
 		//   This ensures the service correctly identifies and handles duplicate entries according to business rules.
 		assert.ErrorIs(t, obtainedError, expectedError)
 		// - Check that the ID returned is 0, indicating that no new record was created due to the duplicate entry.
-		assert.Equal(t, 0, obtainedID)
+		assert.Equal(t, 0, obtained.ID)
 		// - Confirm that the repository's expectations, specifically the call to Exists with the specified context and ID, were met.
 		//   This check ensures that the service properly consults the repository to determine the existence of the batch before attempting to save.
 		repository.AssertExpectations(t)
 	})
 
 }
+
+func TestService_SaveCapacity(t *testing.T) {
+	ctx := context.Background()
+	b := domain.ProductBatch{BatchNumber: 1, CurrentQuantity: 30, ProductID: 2, SectionID: 3}
+
+	t.Run("it should reject a batch that exceeds the maximum capacity of its section", func(t *testing.T) {
+		// Arrange
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, 1).Return(false)
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Save", ctx, b).Return(4, nil)
+		repository.On("SaveEvent", ctx, mock.Anything).Return(nil)
+		repository.On("AdjustSectionCapacity", ctx, 3, 30).Return(domain.Section{}, ErrSectionFull)
+
+		// Act
+		saved, err := NewService(repository).Save(ctx, b)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSectionFull)
+		assert.Equal(t, Saved{}, saved)
+	})
+
+	t.Run("it should not warn when a batch adds stock to a section still below its minimum capacity", func(t *testing.T) {
+		// Arrange
+		low := domain.Section{ID: 3, SectionNumber: 7, CurrentCapacity: 40, MinimumCapacity: 50, MaximumCapacity: 100, WarehouseID: 1, ProductTypeID: 1}
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, 1).Return(false)
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Save", ctx, b).Return(4, nil)
+		repository.On("SaveEvent", ctx, mock.MatchedBy(func(e events.Event) bool { return e.Type == outbox.ProductBatchCreated })).Return(nil).Once()
+		repository.On("AdjustSectionCapacity", ctx, 3, 30).Return(low, nil)
+
+		// Act
+		saved, err := NewService(repository).Save(ctx, b)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4, saved.ID)
+		repository.AssertExpectations(t)
+		repository.AssertNumberOfCalls(t, "SaveEvent", 1)
+	})
+}
+
+func TestService_Consume(t *testing.T) {
+	ctx := context.Background()
+	consumed := domain.ProductBatch{ID: 4, BatchNumber: 1, CurrentQuantity: 5, ProductID: 2, SectionID: 3}
+
+	t.Run("it should warn when the consumption takes the section below its minimum capacity", func(t *testing.T) {
+		// Arrange
+		low := domain.Section{ID: 3, SectionNumber: 7, CurrentCapacity: 40, MinimumCapacity: 50, MaximumCapacity: 100, WarehouseID: 1, ProductTypeID: 1}
+		repository := &RepositoryMock{}
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Consume", ctx, 4, 20).Return(consumed, nil)
+		repository.On("AdjustSectionCapacity", ctx, 3, -20).Return(low, nil)
+		repository.On("SaveEvent", ctx, mock.MatchedBy(func(e events.Event) bool {
+			return e.Type == outbox.SectionCapacityLow && e.AggregateID == 3
+		})).Return(nil).Once()
+
+		// Act
+		obtained, err := NewService(repository).Consume(ctx, 4, 20)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, consumed, obtained.Batch)
+		before := low
+		before.CurrentCapacity = 60
+		assert.Equal(t, []SectionChange{{Before: before, After: low}}, obtained.Sections)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("it should not warn again for a section that already was below its minimum capacity", func(t *testing.T) {
+		// Arrange
+		low := domain.Section{ID: 3, CurrentCapacity: 40, MinimumCapacity: 50, MaximumCapacity: 100}
+		repository := &RepositoryMock{}
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Consume", ctx, 4, 5).Return(consumed, nil)
+		repository.On("AdjustSectionCapacity", ctx, 3, -5).Return(low, nil)
+
+		// Act
+		_, err := NewService(repository).Consume(ctx, 4, 5)
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should reject a quantity that is not positive", func(t *testing.T) {
+		repository := &RepositoryMock{}
+
+		_, err := NewService(repository).Consume(ctx, 4, 0)
+
+		assert.ErrorIs(t, err, ErrInvalidQuantity)
+		repository.AssertNotCalled(t, "InTx", mock.Anything)
+	})
+
+	t.Run("it should return the error of a batch that holds less than the quantity", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Consume", ctx, 4, 50).Return(domain.ProductBatch{}, ErrNotEnoughStock)
+
+		_, err := NewService(repository).Consume(ctx, 4, 50)
+
+		assert.ErrorIs(t, err, ErrNotEnoughStock)
+		repository.AssertNotCalled(t, "AdjustSectionCapacity", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,73 @@
+package buyer
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+)
+
+// entity is the name of buyers in the audit log.
+const entity = "buyer"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations, updates, deletions,
+// restorations and imports in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a buyer and records its creation.
+func (s *auditedService) Save(ctx context.Context, b domain.Buyer) (int, error) {
+	id, err := s.Service.Save(ctx, b)
+	if err != nil {
+		return 0, err
+	}
+	b.ID = id
+	audit.Created(ctx, s.log, entity, id, b)
+	return id, nil
+}
+
+// Update updates the buyer bs and records it as it was before and after. The
+// caller read bs, so it is not read again.
+func (s *auditedService) Update(ctx context.Context, id int, b domain.Buyer, bs *domain.Buyer) error {
+	before := *bs
+	if err := s.Service.Update(ctx, id, b, bs); err != nil {
+		return err
+	}
+	audit.Updated(ctx, s.log, entity, id, before, *bs)
+	return nil
+}
+
+// Delete deletes a buyer and records it as it was before.
+func (s *auditedService) Delete(ctx context.Context, id int) error {
+	return audit.Delete(ctx, s.log, entity, id, s.Service.Get, func() error {
+		return s.Service.Delete(ctx, id)
+	})
+}
+
+// Restore restores a buyer and records it as it was before and after.
+func (s *auditedService) Restore(ctx context.Context, id int) (domain.Buyer, error) {
+	return audit.Restore(ctx, s.log, entity, id, s.Service.Get, func() (domain.Buyer, error) {
+		return s.Service.Restore(ctx, id)
+	})
+}
+
+// Import imports buyers and records the buyers it saved.
+func (s *auditedService) Import(ctx context.Context, buyers []domain.Buyer, opts bulk.Options) ([]bulk.Outcome, error) {
+	outcomes, err := s.Service.Import(ctx, buyers, opts)
+	if err != nil {
+		return outcomes, err
+	}
+	audit.Imported(ctx, s.log, entity, buyers, outcomes, opts, func(b domain.Buyer, id int) domain.Buyer {
+		b.ID = id
+		return b
+	})
+	return outcomes, nil
+}
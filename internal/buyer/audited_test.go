@@ -0,0 +1,111 @@
+package buyer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditedService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should record a buyer before and after its update", func(t *testing.T) {
+		// Arrange
+		stored := domain.Buyer{ID: 1, CardNumberID: "402323", FirstName: "Jhon", LastName: "Doe"}
+		changed := domain.Buyer{ID: 1, CardNumberID: "402323", FirstName: "Jane", LastName: "Doe"}
+		inner, log := NewBuyerService(), &audit.ServiceMock{}
+		inner.On("Update", ctx, 1, changed, mock.Anything).Return(nil)
+		log.On("Record", mock.Anything, audit.OpUpdate, "buyer", 1, stored, changed).Return(nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		current := stored
+		err := s.Update(ctx, 1, changed, &current)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, changed, current)
+		log.AssertExpectations(t)
+	})
+
+	t.Run("it should record a buyer before and after its restoration", func(t *testing.T) {
+		// Arrange
+		deletedAt := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+		deleted := domain.Buyer{ID: 1, CardNumberID: "402323", FirstName: "Jhon", LastName: "Doe", DeletedAt: &deletedAt}
+		restored := deleted
+		restored.DeletedAt = nil
+		inner, log := NewBuyerService(), &audit.ServiceMock{}
+		inner.On("Get", mock.Anything, 1).Return(deleted, nil)
+		inner.On("Restore", ctx, 1).Return(restored, nil)
+		log.On("Record", mock.Anything, audit.OpRestore, "buyer", 1, deleted, restored).Return(nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		obtained, err := s.Restore(ctx, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, restored, obtained)
+		log.AssertExpectations(t)
+	})
+
+	t.Run("it should not record the restoration of a buyer that was not deleted", func(t *testing.T) {
+		// Arrange
+		stored := domain.Buyer{ID: 1, CardNumberID: "402323", FirstName: "Jhon", LastName: "Doe"}
+		inner, log := NewBuyerService(), &audit.ServiceMock{}
+		inner.On("Get", mock.Anything, 1).Return(stored, nil)
+		inner.On("Restore", ctx, 1).Return(stored, nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		_, err := s.Restore(ctx, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		log.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should record the buyers an import saved", func(t *testing.T) {
+		// Arrange
+		buyers := []domain.Buyer{
+			{CardNumberID: "402323", FirstName: "Jhon", LastName: "Doe"},
+			{CardNumberID: "402324", FirstName: "Jane", LastName: "Doe"},
+		}
+		opts := bulk.Options{Mode: bulk.ModeUpsert}
+		inner, log := NewBuyerService(), &audit.ServiceMock{}
+		inner.On("Import", ctx, buyers, opts).Return([]bulk.Outcome{bulk.Updated(1), bulk.Inserted(2)}, nil)
+		log.On("Record", mock.Anything, audit.OpUpdate, "buyer", 1, nil,
+			domain.Buyer{ID: 1, CardNumberID: "402323", FirstName: "Jhon", LastName: "Doe"}).Return(nil)
+		log.On("Record", mock.Anything, audit.OpCreate, "buyer", 2, nil,
+			domain.Buyer{ID: 2, CardNumberID: "402324", FirstName: "Jane", LastName: "Doe"}).Return(nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		_, err := s.Import(ctx, buyers, opts)
+
+		// Assert
+		assert.NoError(t, err)
+		log.AssertExpectations(t)
+	})
+
+	t.Run("it should not record an import that was rolled back", func(t *testing.T) {
+		// Arrange
+		buyers := []domain.Buyer{{CardNumberID: "402323"}, {CardNumberID: "402324"}}
+		inner, log := NewBuyerService(), &audit.ServiceMock{}
+		inner.On("Import", ctx, buyers, bulk.Options{}).Return([]bulk.Outcome{bulk.Inserted(1), bulk.Failed(ErrAlreadyExists)}, nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		_, err := s.Import(ctx, buyers, bulk.Options{})
+
+		// Assert
+		assert.NoError(t, err)
+		log.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,8 +2,10 @@ package buyer
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -47,3 +49,21 @@ func (b *BuyerServiceMock) Update(ctx context.Context, id int, buyerToUpdate dom
 	*buyerInDatabase = buyerToUpdate // Asegúrese de que buyerInDatabase se actualice con buyerToUpdate.
 	return args.Error(0)
 }
+
+// Import returns the outcome of every buyer and error if any
+func (b *BuyerServiceMock) Import(ctx context.Context, buyers []domain.Buyer, opts bulk.Options) ([]bulk.Outcome, error) {
+	args := b.Called(ctx, buyers, opts)
+	return args.Get(0).([]bulk.Outcome), args.Error(1)
+}
+
+// Restore returns the restored buyer and error if any
+func (b *BuyerServiceMock) Restore(ctx context.Context, id int) (domain.Buyer, error) {
+	args := b.Called(ctx, id)
+	return args.Get(0).(domain.Buyer), args.Error(1)
+}
+
+// Purge returns the number of purged buyers and error if any
+func (b *BuyerServiceMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := b.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,220 @@
+// Package buyertest provides a contract test suite for buyer.Repository.
+// Every implementation of the interface should pass it.
+package buyertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewBuyer returns a valid buyer with the given card number.
+func NewBuyer(cardNumberID string) domain.Buyer {
+	return domain.Buyer{
+		CardNumberID: cardNumberID,
+		FirstName:    "John",
+		LastName:     "Doe",
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) buyer.Repository) {
+	ctx := context.Background()
+
+	t.Run("it should save a buyer and read it back", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		b := NewBuyer("402323")
+
+		// Act
+		id, err := repo.Save(ctx, b)
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		b.ID = id
+		assert.Equal(t, b, obtained)
+	})
+
+	t.Run("it should return every saved buyer", func(t *testing.T) {
+		repo := newRepository(t)
+		for _, card := range []string{"402323", "402324"} {
+			_, err := repo.Save(ctx, NewBuyer(card))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, obtained, 2)
+	})
+
+	t.Run("it should return an error when the buyer does not exist", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.Get(ctx, 1)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it should report whether a card number is taken", func(t *testing.T) {
+		repo := newRepository(t)
+		_, err := repo.Save(ctx, NewBuyer("402323"))
+		require.NoError(t, err)
+
+		assert.True(t, repo.Exists(ctx, "402323"))
+		assert.False(t, repo.Exists(ctx, "402324"))
+	})
+
+	t.Run("it should update the names of a buyer", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewBuyer("402323"))
+		require.NoError(t, err)
+		updated := domain.Buyer{ID: id, CardNumberID: "402323", FirstName: "Jane", LastName: "Smith"}
+
+		// Act
+		err = repo.Update(ctx, updated)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, updated, obtained)
+	})
+
+	t.Run("it should delete a buyer", func(t *testing.T) {
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewBuyer("402323"))
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, id)
+
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, id)
+		assert.Error(t, err)
+	})
+
+	t.Run("it should hide a deleted buyer unless deleted buyers are included", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewBuyer("402323"))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewBuyer("402324"))
+		require.NoError(t, err)
+
+		// Act
+		err = repo.Delete(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		all, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+		assert.False(t, repo.Exists(ctx, "402323"))
+		_, err = repo.GetByCardNumberID(ctx, "402323")
+		assert.True(t, errors.Is(err, buyer.ErrNotFound))
+
+		withDeleted := softdelete.WithDeleted(ctx)
+		all, err = repo.GetAll(withDeleted)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+		obtained, err := repo.Get(withDeleted, id)
+		require.NoError(t, err)
+		assert.NotNil(t, obtained.DeletedAt)
+		assert.True(t, errors.Is(repo.Delete(ctx, id), buyer.ErrNotFound))
+	})
+
+	t.Run("it should restore a deleted buyer", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		b := NewBuyer("402323")
+		id, err := repo.Save(ctx, b)
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, id))
+
+		// Act
+		err = repo.Restore(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		b.ID = id
+		assert.Equal(t, b, obtained)
+		assert.True(t, errors.Is(repo.Restore(ctx, id), buyer.ErrNotFound))
+	})
+
+	t.Run("it should purge the buyers deleted before a time", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		deleted, err := repo.Save(ctx, NewBuyer("402323"))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewBuyer("402324"))
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, deleted))
+
+		// Act
+		early, errEarly := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		purged, err := repo.Purge(ctx, time.Now().Add(time.Hour))
+
+		// Assert
+		require.NoError(t, errEarly)
+		require.NoError(t, err)
+		assert.Equal(t, 0, early)
+		assert.Equal(t, 1, purged)
+		all, err := repo.GetAll(softdelete.WithDeleted(ctx))
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("it should return ErrNotFound when deleting a missing buyer", func(t *testing.T) {
+		repo := newRepository(t)
+
+		err := repo.Delete(ctx, 1)
+
+		assert.True(t, errors.Is(err, buyer.ErrNotFound))
+	})
+
+	t.Run("it should find a buyer by its card number id", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		b := NewBuyer("402323")
+		id, err := repo.Save(ctx, b)
+		require.NoError(t, err)
+
+		// Act
+		obtained, err := repo.GetByCardNumberID(ctx, "402323")
+		_, errMissing := repo.GetByCardNumberID(ctx, "402324")
+
+		// Assert
+		require.NoError(t, err)
+		b.ID = id
+		assert.Equal(t, b, obtained)
+		assert.True(t, errors.Is(errMissing, buyer.ErrNotFound))
+	})
+
+	t.Run("it should keep the writes of a committed transaction", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+
+		// Act
+		err := repo.InTx(ctx, func(tx buyer.Repository) error {
+			_, err := tx.Save(ctx, NewBuyer("402323"))
+			return err
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, repo.Exists(ctx, "402323"))
+	})
+}
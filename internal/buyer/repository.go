@@ -3,8 +3,12 @@ package buyer
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/softdelete"
 )
 
 // Repository encapsulates the storage of a buyer.
@@ -19,15 +23,28 @@ type Repository interface {
 	Save(ctx context.Context, b domain.Buyer) (int, error)
 	// Update a buyer
 	Update(ctx context.Context, b domain.Buyer) error
-	// Delete deletes a buyer by id
+	// Delete soft deletes a buyer by id, reads skip it unless their context
+	// includes deleted rows (see softdelete.WithDeleted)
 	Delete(ctx context.Context, id int) error
+	// Restore undoes the deletion of a buyer
+	Restore(ctx context.Context, id int) error
+	// Purge removes for good the buyers deleted before the given time
+	Purge(ctx context.Context, before time.Time) (int, error)
+	// GetByCardNumberID returns the buyer with a card number id, or ErrNotFound
+	GetByCardNumberID(ctx context.Context, cardNumberID string) (domain.Buyer, error)
+	// InTx calls fn with a repository whose queries run in one transaction,
+	// committed only if fn returns nil
+	InTx(ctx context.Context, fn func(r Repository) error) error
 }
 
 // repository is the concrete implementation of the Repository interface.
 type repository struct {
-	db *sql.DB
+	db dbtx.DB
 }
 
+// buyerColumns are the columns of the buyers table, in the order they are scanned
+const buyerColumns = "id, card_number_id, first_name, last_name, deleted_at"
+
 // NewRepository creates a new instance of the repository
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
@@ -38,8 +55,8 @@ func NewRepository(db *sql.DB) Repository {
 // GettAll obtains all buyers
 func (r *repository) GetAll(ctx context.Context) ([]domain.Buyer, error) {
 	// query to select all buyers
-	query := "SELECT * FROM buyers"
-	rows, err := r.db.Query(query)
+	query := "SELECT " + buyerColumns + " FROM buyers WHERE " + softdelete.Visible(ctx, "deleted_at")
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +66,7 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Buyer, error) {
 	for rows.Next() {
 		b := domain.Buyer{}
 		// scan the result of the query
-		_ = rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
+		_ = rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, softdelete.Scan(&b.DeletedAt))
 		// collect al the buyers
 		buyers = append(buyers, b)
 	}
@@ -61,11 +78,11 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Buyer, error) {
 // Get gets a single buyer by its ID
 func (r *repository) Get(ctx context.Context, id int) (domain.Buyer, error) {
 	// query to get a buyer by ide
-	query := "SELECT * FROM buyers WHERE id = ?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT " + buyerColumns + " FROM buyers WHERE id = ? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, id)
 	b := domain.Buyer{}
 	// scan the result of the query
-	err := row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
+	err := row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, softdelete.Scan(&b.DeletedAt))
 	if err != nil {
 		return domain.Buyer{}, err
 	}
@@ -77,14 +94,37 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Buyer, error) {
 // Exists check if buyer with certain card number id exists
 func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
 	// query to obtain a buyer by id
-	query := "SELECT card_number_id FROM buyers WHERE card_number_id=?;"
-	row := r.db.QueryRow(query, cardNumberID)
+	query := "SELECT card_number_id FROM buyers WHERE card_number_id=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, cardNumberID)
 	// scan result of the query
 	err := row.Scan(&cardNumberID)
 	// if any error occurs or there is no record with that id it returns false
 	return err == nil
 }
 
+// GetByCardNumberID gets a single buyer by its card number id
+func (r *repository) GetByCardNumberID(ctx context.Context, cardNumberID string) (domain.Buyer, error) {
+	query := "SELECT " + buyerColumns + " FROM buyers WHERE card_number_id = ? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, cardNumberID)
+	b := domain.Buyer{}
+	// scan the result of the query
+	err := row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, softdelete.Scan(&b.DeletedAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Buyer{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Buyer{}, err
+	}
+	return b, nil
+}
+
+// InTx runs fn on a copy of the repository bound to a transaction
+func (r *repository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return dbtx.Run(ctx, r.db, func(tx dbtx.DB) error {
+		return fn(&repository{db: tx})
+	})
+}
+
 // Save a new buyer into the database
 func (r *repository) Save(ctx context.Context, b domain.Buyer) (int, error) {
 	// query to insert a new buyer
@@ -112,7 +152,7 @@ func (r *repository) Save(ctx context.Context, b domain.Buyer) (int, error) {
 
 // Update an existing buyer in the database
 func (r *repository) Update(ctx context.Context, b domain.Buyer) error {
-	query := "UPDATE buyers SET first_name=?, last_name=?  WHERE id=?"
+	query := "UPDATE buyers SET first_name=?, last_name=?  WHERE id=? AND deleted_at IS NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
@@ -133,22 +173,39 @@ func (r *repository) Update(ctx context.Context, b domain.Buyer) error {
 	return nil
 }
 
-// Delete removes a buyer from the database
+// Delete soft deletes a buyer: it is hidden from the reads until it is restored or purged
 func (r *repository) Delete(ctx context.Context, id int) error {
-	// query searching a buyer by id
-	query := "DELETE FROM buyers WHERE id = ?"
-	// prepare query
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE buyers SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL"
+	return r.exec(ctx, query, softdelete.Format(time.Now()), id)
+}
+
+// Restore undoes the soft deletion of a buyer
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE buyers SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL"
+	return r.exec(ctx, query, id)
+}
+
+// Purge removes for good the buyers deleted before the given time, except
+// those that still have purchase orders
+func (r *repository) Purge(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM buyers WHERE deleted_at < ? AND id NOT IN (SELECT buyer_id FROM purchase_orders)"
+	res, err := r.db.ExecContext(ctx, query, softdelete.Format(before))
 	if err != nil {
-		return err
+		return 0, err
 	}
-	// Exec executes a prepared statement with the given arguments
-	res, err := stmt.Exec(id)
+	// RowsAffected returns the number of purged buyers
+	affect, err := res.RowsAffected()
+	return int(affect), err
+}
+
+// exec runs a statement changing a single buyer
+func (r *repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
 
-	// RowsAffected returns the number of rows affected by the delete.
+	// RowsAffected returns the number of rows affected by the statement.
 	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
@@ -2,6 +2,7 @@ package buyer
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
 
@@ -42,3 +43,26 @@ func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
 	args := r.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (r *RepositoryMock) Restore(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := r.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) GetByCardNumberID(ctx context.Context, cardNumberID string) (domain.Buyer, error) {
+	args := r.Called(ctx, cardNumberID)
+	return args.Get(0).(domain.Buyer), args.Error(1)
+}
+
+// InTx runs fn on the mock itself unless an error is set for the call.
+func (r *RepositoryMock) InTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := r.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(r)
+}
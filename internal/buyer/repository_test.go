@@ -0,0 +1,15 @@
+package buyer_test
+
+import (
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/buyer/buyertest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	buyertest.TestRepository(t, func(t *testing.T) buyer.Repository {
+		return buyer.NewRepository(mysqltest.Open(t))
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/davidop97/apiGo/pkg/softdelete"
 )
 
 // Errors
@@ -26,6 +29,12 @@ type Service interface {
 	Save(ctx context.Context, b domain.Buyer) (int, error)
 	// Update a buyer by id
 	Update(ctx context.Context, id int, b domain.Buyer, bs *domain.Buyer) error
+	// Import saves many buyers at once, all of them or none
+	Import(ctx context.Context, buyers []domain.Buyer, opts bulk.Options) ([]bulk.Outcome, error)
+	// Restore undoes the deletion of a buyer and returns it
+	Restore(ctx context.Context, id int) (domain.Buyer, error)
+	// Purge removes for good the buyers deleted before the given time
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
 
 // service is the concrete implementation of the service interface
@@ -113,3 +122,61 @@ func (s *service) Update(ctx context.Context, id int, u domain.Buyer, b *domain.
 	// return nil if there no error
 	return nil
 }
+
+// Import saves buyers in a single transaction and returns the outcome of each one.
+// A card number id that is already stored, or repeated in buyers, rejects the
+// buyer in insert mode and updates the names of the stored buyer in upsert mode.
+// Nothing is saved when a buyer is rejected or opts.DryRun is set
+func (s *service) Import(ctx context.Context, buyers []domain.Buyer, opts bulk.Options) ([]bulk.Outcome, error) {
+	var outcomes []bulk.Outcome
+	err := s.r.InTx(ctx, func(r Repository) error {
+		var err error
+		outcomes, err = bulk.Apply(buyers, opts, func(b domain.Buyer) (bulk.Outcome, error) {
+			// look for a buyer with the same card number id
+			stored, err := r.GetByCardNumberID(ctx, b.CardNumberID)
+			switch {
+			case errors.Is(err, ErrNotFound):
+				id, err := r.Save(ctx, b)
+				return bulk.Inserted(id), err
+			case err != nil:
+				return bulk.Outcome{}, err
+			case opts.Mode != bulk.ModeUpsert:
+				return bulk.Failed(ErrAlreadyExists), nil
+			}
+			b.ID = stored.ID
+			return bulk.Updated(b.ID), r.Update(ctx, b)
+		})
+		return err
+	})
+	// a rolled back import is not an error, the outcomes tell why it happened
+	if errors.Is(err, bulk.ErrRollback) {
+		err = nil
+	}
+	return outcomes, err
+}
+
+// Restore a deleted buyer. A buyer that is not deleted is returned as it is
+func (s *service) Restore(ctx context.Context, id int) (domain.Buyer, error) {
+	b, err := s.r.Get(softdelete.WithDeleted(ctx), id)
+	// check if buyer not found
+	if err != nil {
+		return domain.Buyer{}, ErrNotFound
+	}
+	if b.DeletedAt == nil {
+		return b, nil
+	}
+	// the card number of a deleted buyer is free to be taken by a new one
+	if s.r.Exists(ctx, b.CardNumberID) {
+		return domain.Buyer{}, ErrAlreadyExists
+	}
+	if err := s.r.Restore(ctx, id); err != nil {
+		return domain.Buyer{}, err
+	}
+	b.DeletedAt = nil
+	return b, nil
+}
+
+// Purge removes for good the buyers deleted before the given time
+func (s *service) Purge(ctx context.Context, before time.Time) (int, error) {
+	return s.r.Purge(ctx, before)
+}
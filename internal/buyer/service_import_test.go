@@ -0,0 +1,55 @@
+package buyer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestService_ImportBuyers
+// Test the cases of the Import function
+func TestService_ImportBuyers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("insert a new buyer and update the names of a stored one in upsert mode", func(t *testing.T) {
+		// arrange
+		fresh := domain.Buyer{CardNumberID: "402323", FirstName: "John", LastName: "Doe"}
+		stored := domain.Buyer{CardNumberID: "402324", FirstName: "Jane", LastName: "Roe"}
+		updated := stored
+		updated.ID = 2
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByCardNumberID", ctx, "402323").Return(domain.Buyer{}, ErrNotFound)
+		repositoryMock.On("GetByCardNumberID", ctx, "402324").Return(domain.Buyer{ID: 2, CardNumberID: "402324"}, nil)
+		repositoryMock.On("Save", ctx, fresh).Return(3, nil)
+		repositoryMock.On("Update", ctx, updated).Return(nil)
+		service := NewService(repositoryMock)
+
+		// act
+		outcomes, err := service.Import(ctx, []domain.Buyer{fresh, stored}, bulk.Options{Mode: bulk.ModeUpsert})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Inserted(3), bulk.Updated(2)}, outcomes)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("reject a stored card number id in insert mode", func(t *testing.T) {
+		// arrange
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByCardNumberID", ctx, "402324").Return(domain.Buyer{ID: 2, CardNumberID: "402324"}, nil)
+		service := NewService(repositoryMock)
+
+		// act
+		outcomes, err := service.Import(ctx, []domain.Buyer{{CardNumberID: "402324", FirstName: "Jane", LastName: "Roe"}}, bulk.Options{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Failed(ErrAlreadyExists)}, outcomes)
+		repositoryMock.AssertNotCalled(t, "Update")
+	})
+}
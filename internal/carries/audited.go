@@ -0,0 +1,33 @@
+package carries
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// entity is the name of carries in the audit log.
+const entity = "carry"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a carry and records its creation.
+func (s *auditedService) Save(ctx context.Context, c domain.Carries) (int, error) {
+	id, err := s.Service.Save(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	c.ID = id
+	audit.Created(ctx, s.log, entity, id, c)
+	return id, nil
+}
@@ -0,0 +1,109 @@
+// Package carriestest provides a contract test suite for carries.Repository.
+// Every implementation of the interface should pass it.
+package carriestest
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/carries"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows the carries repository references but does not write.
+type Fixtures struct {
+	// AddLocality stores a locality with the given postal code and name.
+	AddLocality func(t *testing.T, postalCode int, name string)
+}
+
+// NewCarry returns a valid carry with the given cid. Carries reference
+// localities by postal code.
+func NewCarry(cid string, postalCode int) domain.Carries {
+	return domain.Carries{
+		CID:         cid,
+		CompanyName: "Fast Delivery",
+		Address:     "Fake Street 123",
+		Telephone:   "555-0100",
+		LocalityID:  postalCode,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, each time it
+// is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (carries.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save a carry and list it", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		fixtures.AddLocality(t, 1425, "Palermo")
+		c := NewCarry("CID1", 1425)
+
+		// Act
+		id, err := repo.Save(ctx, c)
+		require.NoError(t, err)
+		obtained, err := repo.GetAll(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		c.ID = id
+		assert.Equal(t, []domain.Carries{c}, obtained)
+	})
+
+	t.Run("it should reject a duplicate cid", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		fixtures.AddLocality(t, 1425, "Palermo")
+		_, err := repo.Save(ctx, NewCarry("CID1", 1425))
+		require.NoError(t, err)
+
+		_, err = repo.Save(ctx, NewCarry("CID1", 1425))
+
+		assert.True(t, errors.Is(err, carries.ErrDuplicateCarry))
+	})
+
+	t.Run("it should reject a carry whose locality does not exist", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.Save(ctx, NewCarry("CID1", 1425))
+
+		assert.True(t, errors.Is(err, carries.ErrLocalityCarriesNotFound))
+	})
+
+	t.Run("it should count the carries of each locality", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		fixtures.AddLocality(t, 1425, "Palermo")
+		fixtures.AddLocality(t, 1426, "Belgrano")
+		for i, postalCode := range []int{1425, 1425, 1426} {
+			_, err := repo.Save(ctx, NewCarry("CID"+strconv.Itoa(i), postalCode))
+			require.NoError(t, err)
+		}
+
+		// Act
+		all, err := repo.GetAllCarriesByLocality(ctx)
+		require.NoError(t, err)
+		one, err := repo.GetAllCarriesByLocalityID(ctx, 1425)
+		require.NoError(t, err)
+
+		// Assert
+		palermo := domain.LocalityCarries{LocalityID: "1425", LocalityName: "Palermo", CarriesCount: 2}
+		assert.ElementsMatch(t, []domain.LocalityCarries{
+			palermo,
+			{LocalityID: "1426", LocalityName: "Belgrano", CarriesCount: 1},
+		}, all)
+		assert.Equal(t, palermo, one)
+	})
+
+	t.Run("it should return ErrLocalityCarriesNotFound when reporting a missing locality", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.GetAllCarriesByLocalityID(ctx, 1425)
+
+		assert.True(t, errors.Is(err, carries.ErrLocalityCarriesNotFound))
+	})
+}
@@ -100,8 +100,8 @@ func (r *repository) LocalityExists(ctx context.Context, id int) bool {
 
 // GetAllCarriesByLocality is a method that returns all carries by locality, returns empty list if there are no carries.
 func (r *repository) GetAllCarriesByLocality(ctx context.Context) ([]domain.LocalityCarries, error) {
-	query := `SELECT localities.postal_code, localities.locality_name, COUNT(*) FROM melisprint.carries as carries
-			JOIN melisprint.locality as localities ON carries.locality_id = localities.postal_code
+	query := `SELECT localities.postal_code, localities.locality_name, COUNT(*) FROM carries
+			JOIN locality as localities ON carries.locality_id = localities.postal_code
 			GROUP BY localities.postal_code, localities.locality_name;`
 
 	rows, err := r.db.Query(query)
@@ -133,8 +133,8 @@ func (r *repository) GetAllCarriesByLocalityID(ctx context.Context, localityID i
 		return lc, ErrLocalityCarriesNotFound
 	}
 
-	query := `SELECT localities.postal_code, localities.locality_name, COUNT(*) FROM melisprint.carries as carries
-			JOIN melisprint.locality as localities ON carries.locality_id = localities.postal_code
+	query := `SELECT localities.postal_code, localities.locality_name, COUNT(*) FROM carries
+			JOIN locality as localities ON carries.locality_id = localities.postal_code
 			WHERE postal_code = ?
 			GROUP BY localities.postal_code, localities.locality_name;`
 
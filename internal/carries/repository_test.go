@@ -0,0 +1,30 @@
+package carries_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/carries"
+	"github.com/davidop97/apiGo/internal/carries/carriestest"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/locality/localitytest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	carriestest.TestRepository(t, func(t *testing.T) (carries.Repository, carriestest.Fixtures) {
+		db := mysqltest.Open(t)
+		localities := locality.NewRepository(db)
+
+		fixtures := carriestest.Fixtures{
+			AddLocality: func(t *testing.T, postalCode int, name string) {
+				l := localitytest.NewLocality(postalCode)
+				l.LocalityName = name
+				_, err := localities.Save(context.Background(), l)
+				require.NoError(t, err)
+			},
+		}
+		return carries.NewRepository(db), fixtures
+	})
+}
@@ -0,0 +1,50 @@
+package currencyrate
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+)
+
+// entity is the name of currency rates in the audit log.
+const entity = "currency_rate"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations, updates and deletions
+// in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Set stores a rate and records its creation, or the rate it replaced.
+func (s *auditedService) Set(ctx context.Context, r domain.CurrencyRate) (domain.CurrencyRate, error) {
+	// A currency without a rate yet has no id
+	before, _ := s.Service.Get(ctx, r.Currency)
+	saved, err := s.Service.Set(ctx, r)
+	if err != nil {
+		return domain.CurrencyRate{}, err
+	}
+	if before.ID == 0 {
+		audit.Created(ctx, s.log, entity, saved.ID, saved)
+	} else {
+		audit.Updated(ctx, s.log, entity, saved.ID, before, saved)
+	}
+	return saved, nil
+}
+
+// Delete deletes a rate and records it as it was before.
+func (s *auditedService) Delete(ctx context.Context, currency money.Currency) error {
+	before, _ := s.Service.Get(ctx, currency)
+	if err := s.Service.Delete(ctx, currency); err != nil {
+		return err
+	}
+	audit.Deleted(ctx, s.log, entity, before.ID, before)
+	return nil
+}
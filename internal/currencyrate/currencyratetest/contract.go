@@ -0,0 +1,100 @@
+// Package currencyratetest provides a contract test suite for
+// currencyrate.Repository. Every implementation of the interface should pass
+// it.
+package currencyratetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/currencyrate"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewCurrencyRate returns the rate of a currency from its decimal text.
+func NewCurrencyRate(t *testing.T, currency money.Currency, rate string) domain.CurrencyRate {
+	t.Helper()
+	r, err := money.ParseRate(rate)
+	require.NoError(t, err)
+	return domain.CurrencyRate{Currency: currency, Rate: r}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) currencyrate.Repository) {
+	ctx := context.Background()
+
+	t.Run("it should save a rate and read it back", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		eur := NewCurrencyRate(t, "EUR", "1.08")
+
+		// Act
+		id, err := repo.Save(ctx, eur)
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, "EUR")
+
+		// Assert
+		require.NoError(t, err)
+		eur.ID = id
+		assert.Equal(t, eur, obtained)
+	})
+
+	t.Run("it should replace the rate of a currency and keep its id", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewCurrencyRate(t, "ARS", "0.001"))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewCurrencyRate(t, "EUR", "1.08"))
+		require.NoError(t, err)
+
+		// Act
+		replacedID, err := repo.Save(ctx, NewCurrencyRate(t, "ARS", "0.00095"))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, id, replacedID)
+		obtained, err := repo.Get(ctx, "ARS")
+		require.NoError(t, err)
+		assert.Equal(t, "0.00095", obtained.Rate.String())
+	})
+
+	t.Run("it should return every rate sorted by currency", func(t *testing.T) {
+		repo := newRepository(t)
+		for _, c := range []money.Currency{"GBP", "BRL", "EUR"} {
+			_, err := repo.Save(ctx, NewCurrencyRate(t, c, "1.5"))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetAll(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, obtained, 3)
+		assert.Equal(t, []money.Currency{"BRL", "EUR", "GBP"}, []money.Currency{obtained[0].Currency, obtained[1].Currency, obtained[2].Currency})
+	})
+
+	t.Run("it should delete a rate", func(t *testing.T) {
+		repo := newRepository(t)
+		_, err := repo.Save(ctx, NewCurrencyRate(t, "EUR", "1.08"))
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, "EUR")
+
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, "EUR")
+		assert.ErrorIs(t, err, currencyrate.ErrNotFound)
+	})
+
+	t.Run("it should return ErrNotFound when the currency has no rate", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, errGet := repo.Get(ctx, "EUR")
+		errDelete := repo.Delete(ctx, "EUR")
+
+		assert.ErrorIs(t, errGet, currencyrate.ErrNotFound)
+		assert.ErrorIs(t, errDelete, currencyrate.ErrNotFound)
+	})
+}
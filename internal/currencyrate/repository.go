@@ -0,0 +1,97 @@
+package currencyrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+)
+
+// Repository encapsulates the storage of the currency rates.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.CurrencyRate, error)
+	// Get returns ErrNotFound when the currency has no rate.
+	Get(ctx context.Context, currency money.Currency) (domain.CurrencyRate, error)
+	// Save stores the rate of a currency, replacing its current one, and
+	// returns its id.
+	Save(ctx context.Context, r domain.CurrencyRate) (int, error)
+	// Delete removes the rate of a currency, or returns ErrNotFound.
+	Delete(ctx context.Context, currency money.Currency) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.CurrencyRate, error) {
+	query := "SELECT id, currency, rate FROM currency_rates ORDER BY currency"
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []domain.CurrencyRate
+	for rows.Next() {
+		cr := domain.CurrencyRate{}
+		if err := rows.Scan(&cr.ID, &cr.Currency, &cr.Rate); err != nil {
+			return nil, err
+		}
+		rates = append(rates, cr)
+	}
+	return rates, rows.Err()
+}
+
+func (r *repository) Get(ctx context.Context, currency money.Currency) (domain.CurrencyRate, error) {
+	query := "SELECT id, currency, rate FROM currency_rates WHERE currency=?"
+	cr := domain.CurrencyRate{}
+	err := r.db.QueryRowContext(ctx, query, currency).Scan(&cr.ID, &cr.Currency, &cr.Rate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.CurrencyRate{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.CurrencyRate{}, err
+	}
+	return cr, nil
+}
+
+// Save inserts the rate or updates the one of the currency, whose id
+// LAST_INSERT_ID then returns.
+func (r *repository) Save(ctx context.Context, cr domain.CurrencyRate) (int, error) {
+	query := "INSERT INTO currency_rates (currency, rate) VALUES (?, ?) ON DUPLICATE KEY UPDATE rate=VALUES(rate), id=LAST_INSERT_ID(id)"
+	res, err := r.db.ExecContext(ctx, query, cr.Currency, cr.Rate)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (r *repository) Delete(ctx context.Context, currency money.Currency) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM currency_rates WHERE currency=?", currency)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package currencyrate
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context) ([]domain.CurrencyRate, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.CurrencyRate), args.Error(1)
+}
+
+func (r *RepositoryMock) Get(ctx context.Context, currency money.Currency) (domain.CurrencyRate, error) {
+	args := r.Called(ctx, currency)
+	return args.Get(0).(domain.CurrencyRate), args.Error(1)
+}
+
+func (r *RepositoryMock) Save(ctx context.Context, cr domain.CurrencyRate) (int, error) {
+	args := r.Called(ctx, cr)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, currency money.Currency) error {
+	args := r.Called(ctx, currency)
+	return args.Error(0)
+}
@@ -0,0 +1,15 @@
+package currencyrate_test
+
+import (
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/currencyrate"
+	"github.com/davidop97/apiGo/internal/currencyrate/currencyratetest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	currencyratetest.TestRepository(t, func(t *testing.T) currencyrate.Repository {
+		return currencyrate.NewRepository(mysqltest.Open(t))
+	})
+}
@@ -0,0 +1,88 @@
+package currencyrate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+)
+
+// Errors
+var (
+	ErrNotFound        = errors.New("currency rate not found")
+	ErrInvalidCurrency = errors.New("invalid currency")
+	ErrInvalidRate     = errors.New("the rate must be greater than 0")
+	// ErrBaseCurrency is returned when setting or deleting the rate of
+	// money.DefaultCurrency, which is always 1.
+	ErrBaseCurrency = errors.New("the rate of the base currency cannot change")
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.CurrencyRate, error)
+	// Get returns the rate of a currency. The rate of money.DefaultCurrency
+	// is 1, and has no id.
+	Get(ctx context.Context, currency money.Currency) (domain.CurrencyRate, error)
+	// Set stores the rate of a currency and returns it with its id.
+	Set(ctx context.Context, r domain.CurrencyRate) (domain.CurrencyRate, error)
+	Delete(ctx context.Context, currency money.Currency) error
+}
+
+type service struct {
+	rp Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{rp: r}
+}
+
+// GetAll returns the rates of every currency but the base one.
+func (s *service) GetAll(ctx context.Context) ([]domain.CurrencyRate, error) {
+	return s.rp.GetAll(ctx)
+}
+
+// Get returns the rate of a currency, in any case, or ErrNotFound.
+func (s *service) Get(ctx context.Context, currency money.Currency) (domain.CurrencyRate, error) {
+	c, err := money.ParseCurrency(string(currency))
+	if err != nil {
+		return domain.CurrencyRate{}, ErrInvalidCurrency
+	}
+	if c == money.DefaultCurrency {
+		return domain.CurrencyRate{Currency: c, Rate: money.OneRate}, nil
+	}
+	return s.rp.Get(ctx, c)
+}
+
+// Set stores the rate of a currency, returns error if the currency is not an
+// ISO 4217 code or the base currency, or the rate is not positive.
+func (s *service) Set(ctx context.Context, r domain.CurrencyRate) (domain.CurrencyRate, error) {
+	c, err := money.ParseCurrency(string(r.Currency))
+	if err != nil {
+		return domain.CurrencyRate{}, ErrInvalidCurrency
+	}
+	if c == money.DefaultCurrency {
+		return domain.CurrencyRate{}, ErrBaseCurrency
+	}
+	if r.Rate <= 0 {
+		return domain.CurrencyRate{}, ErrInvalidRate
+	}
+
+	r.Currency = c
+	if r.ID, err = s.rp.Save(ctx, r); err != nil {
+		return domain.CurrencyRate{}, err
+	}
+	return r, nil
+}
+
+// Delete deletes the rate of a currency, so that the prices in it cannot be
+// converted anymore.
+func (s *service) Delete(ctx context.Context, currency money.Currency) error {
+	c, err := money.ParseCurrency(string(currency))
+	if err != nil {
+		return ErrInvalidCurrency
+	}
+	if c == money.DefaultCurrency {
+		return ErrBaseCurrency
+	}
+	return s.rp.Delete(ctx, c)
+}
@@ -0,0 +1,33 @@
+package currencyrate
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context) ([]domain.CurrencyRate, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.CurrencyRate), args.Error(1)
+}
+
+func (s *ServiceMock) Get(ctx context.Context, currency money.Currency) (domain.CurrencyRate, error) {
+	args := s.Called(ctx, currency)
+	return args.Get(0).(domain.CurrencyRate), args.Error(1)
+}
+
+func (s *ServiceMock) Set(ctx context.Context, cr domain.CurrencyRate) (domain.CurrencyRate, error) {
+	args := s.Called(ctx, cr)
+	return args.Get(0).(domain.CurrencyRate), args.Error(1)
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, currency money.Currency) error {
+	args := s.Called(ctx, currency)
+	return args.Error(0)
+}
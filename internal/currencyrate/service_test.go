@@ -0,0 +1,105 @@
+package currencyrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_Get(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should return the stored rate of a currency in any case", func(t *testing.T) {
+		// Arrange
+		eur := domain.CurrencyRate{ID: 1, Currency: "EUR", Rate: 108000000}
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, money.Currency("EUR")).Return(eur, nil)
+		service := NewService(repository)
+
+		// Act
+		obtained, err := service.Get(ctx, "eur")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, eur, obtained)
+	})
+
+	t.Run("it should return a rate of 1 for the base currency", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		service := NewService(repository)
+
+		obtained, err := service.Get(ctx, "USD")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CurrencyRate{Currency: "USD", Rate: money.OneRate}, obtained)
+		repository.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should reject a code that is not a currency", func(t *testing.T) {
+		service := NewService(&RepositoryMock{})
+
+		_, err := service.Get(ctx, "EURO")
+
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+	})
+}
+
+func TestService_Set(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should store the rate of a currency", func(t *testing.T) {
+		// Arrange
+		repository := &RepositoryMock{}
+		repository.On("Save", ctx, domain.CurrencyRate{Currency: "GBP", Rate: 127000000}).Return(3, nil)
+		service := NewService(repository)
+
+		// Act
+		saved, err := service.Set(ctx, domain.CurrencyRate{Currency: "gbp", Rate: 127000000})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CurrencyRate{ID: 3, Currency: "GBP", Rate: 127000000}, saved)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("it should reject invalid rates and the base currency", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		service := NewService(repository)
+
+		_, errCurrency := service.Set(ctx, domain.CurrencyRate{Currency: "EURO", Rate: 1})
+		_, errBase := service.Set(ctx, domain.CurrencyRate{Currency: "usd", Rate: 1})
+		_, errRate := service.Set(ctx, domain.CurrencyRate{Currency: "EUR", Rate: 0})
+
+		assert.ErrorIs(t, errCurrency, ErrInvalidCurrency)
+		assert.ErrorIs(t, errBase, ErrBaseCurrency)
+		assert.ErrorIs(t, errRate, ErrInvalidRate)
+		repository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should delete the rate of a currency", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		repository.On("Delete", ctx, money.Currency("EUR")).Return(nil)
+		service := NewService(repository)
+
+		err := service.Delete(ctx, "eur")
+
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("it should not delete the rate of the base currency", func(t *testing.T) {
+		service := NewService(&RepositoryMock{})
+
+		err := service.Delete(ctx, "USD")
+
+		assert.ErrorIs(t, err, ErrBaseCurrency)
+	})
+}
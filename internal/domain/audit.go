@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditRecord is an entry of the append-only audit log: a mutation of an
+// entity, who made it and the entity before and after it.
+type AuditRecord struct {
+	ID         int       `json:"id"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Actor      string    `json:"actor"`
+	RequestID  string    `json:"request_id"`
+	Entity     string    `json:"entity"`
+	EntityID   int       `json:"entity_id"`
+	Operation  string    `json:"operation"`
+	// Before is null for a creation and After is null for a deletion.
+	Before json.RawMessage `json:"before" swaggertype:"object" extensions:"x-nullable"`
+	After  json.RawMessage `json:"after" swaggertype:"object" extensions:"x-nullable"`
+	// Changes holds the fields whose value differs between Before and After.
+	Changes map[string]AuditChange `json:"changes"`
+	// PrevHash and Hash chain the records when the log is tamper evident.
+	PrevHash string `json:"prev_hash,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+// AuditChange is the value of a field before and after a mutation, as
+// decoded from JSON. A field missing on one side is nil there.
+type AuditChange struct {
+	Before interface{} `json:"before" extensions:"x-nullable"`
+	After  interface{} `json:"after" extensions:"x-nullable"`
+}
@@ -12,4 +12,32 @@ type ProductBatch struct {
 	MinimumTemperature int    `json:"minimum_temperature"`
 	ProductID          int    `json:"product_id"`
 	SectionID          int    `json:"section_id"`
+	// ColdChainOverride is set on the batches stored in a section that
+	// breaks their cold chain.
+	ColdChainOverride *ColdChainOverride `json:"cold_chain_override,omitempty"`
+}
+
+// ColdChainViolation is a temperature of a section that a batch, or its
+// product, cannot be kept at.
+type ColdChainViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	// Temperature is the temperature of the section breaking Limit, the
+	// temperature of the batch or its product.
+	Temperature float64 `json:"temperature"`
+	Limit       float64 `json:"limit"`
+}
+
+// ColdChainOverride records why a batch was stored in spite of the
+// violations of its cold chain, and who allowed it.
+type ColdChainOverride struct {
+	Reason     string               `json:"reason"`
+	Actor      string               `json:"actor"`
+	Violations []ColdChainViolation `json:"violations"`
+}
+
+// String returns the reason of the override and who gave it, as the tables
+// of batches show it.
+func (o ColdChainOverride) String() string {
+	return o.Reason + " (" + o.Actor + ")"
 }
@@ -1,8 +1,12 @@
 package domain
 
+import "time"
+
 type Buyer struct {
 	ID           int    `json:"id"`
 	CardNumberID string `json:"card_number_id"`
 	FirstName    string `json:"first_name"`
 	LastName     string `json:"last_name"`
+	// DeletedAt is set while the buyer is soft deleted.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
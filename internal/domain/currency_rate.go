@@ -0,0 +1,11 @@
+package domain
+
+import "github.com/davidop97/apiGo/pkg/money"
+
+// CurrencyRate is the value of one unit of a currency in
+// money.DefaultCurrency.
+type CurrencyRate struct {
+	ID       int            `json:"id"`
+	Currency money.Currency `json:"currency" swaggertype:"string" example:"EUR"`
+	Rate     money.Rate     `json:"rate" swaggertype:"string" example:"1.08"`
+}
@@ -1,9 +1,13 @@
 package domain
 
+import "time"
+
 type Employee struct {
 	ID           int    `json:"id"`
 	CardNumberID string `json:"card_number_id"`
 	FirstName    string `json:"first_name"`
 	LastName     string `json:"last_name"`
 	WarehouseID  int    `json:"warehouse_id"`
+	// DeletedAt is set while the employee is soft deleted.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
@@ -1,6 +1,15 @@
 package domain
 
+import (
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/davidop97/apiGo/pkg/units"
+)
+
 // Product represents an underlying URL with statistics on how it is used.
+// Its height, length and width are in centimetres and its net weight in
+// kilograms.
 type Product struct {
 	ID             int     `json:"id"`
 	Description    string  `json:"description"`
@@ -14,22 +23,32 @@ type Product struct {
 	Width          float32 `json:"width"`
 	ProductTypeID  int     `json:"product_type_id"`
 	SellerID       int     `json:"seller_id"`
+	// DeletedAt is set while the product is soft deleted.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Volume returns the volume of the product in cubic metres.
+func (p Product) Volume() float64 {
+	return units.Volume(float64(p.Height), float64(p.Length), float64(p.Width))
 }
 
 // Struct for the product record
 type ProductRecord struct {
-	ID            int     `json:"id"`
-	LastUpdate    string  `json:"last_update_date"`
-	PurchasePrice float32 `json:"purchase_price"`
-	SalePrice     float32 `json:"sale_price"`
-	ProductID     int     `json:"product_id"` //Product_code of the product (fk)
+	ID            int            `json:"id"`
+	LastUpdate    string         `json:"last_update_date"`
+	PurchasePrice money.Amount   `json:"purchase_price" swaggertype:"string" example:"100.00"`
+	SalePrice     money.Amount   `json:"sale_price" swaggertype:"string" example:"110.00"`
+	Currency      money.Currency `json:"currency" swaggertype:"string" example:"USD"`
+	ProductID     int            `json:"product_id"` //Product_code of the product (fk)
 }
 
 type ProductRecordCreate struct {
-	LastUpdate    string  `json:"last_update_date"`
-	PurchasePrice float32 `json:"purchase_price"`
-	SalePrice     float32 `json:"sale_price"`
-	ProductID     int     `json:"product_id"` //Product_code of the product (fk)
+	LastUpdate    string       `json:"last_update_date"`
+	PurchasePrice money.Amount `json:"purchase_price" swaggertype:"string" example:"100.00"`
+	SalePrice     money.Amount `json:"sale_price" swaggertype:"string" example:"110.00"`
+	// Currency is money.DefaultCurrency when empty.
+	Currency  money.Currency `json:"currency,omitempty" swaggertype:"string" example:"USD"`
+	ProductID int            `json:"product_id"` //Product_code of the product (fk)
 }
 
 type ProductRecordGet struct {
@@ -37,3 +56,26 @@ type ProductRecordGet struct {
 	Description string `json:"description"`
 	RecordCount int    `json:"record_count"` // count of records
 }
+
+// ProductRecordFilter bounds the records of a product by their
+// last_update_date, formatted as 2006-01-02. An empty bound is open.
+type ProductRecordFilter struct {
+	From string
+	To   string
+	// Currency converts the prices of the records into it when set.
+	Currency money.Currency
+}
+
+// ProductRecordHistory is a record of the prices of a product with its
+// margins and the changes of its prices since the previous record.
+type ProductRecordHistory struct {
+	ProductRecord
+	// Margin is the share of the sale price that is profit.
+	Margin float64 `json:"margin"`
+	// Markup is the profit relative to the purchase price.
+	Markup float64 `json:"markup"`
+	// The changes are null on the first record of the product, and when the
+	// previous record is in another currency.
+	PurchasePriceChange *money.Amount `json:"purchase_price_change" swaggertype:"string" extensions:"x-nullable"`
+	SalePriceChange     *money.Amount `json:"sale_price_change" swaggertype:"string" extensions:"x-nullable"`
+}
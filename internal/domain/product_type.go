@@ -0,0 +1,8 @@
+package domain
+
+// ProductType is the type of a product. A section stores the batches of the
+// products of its type only.
+type ProductType struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
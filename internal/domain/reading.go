@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// SectionReading is a temperature measured by a sensor of a section.
+type SectionReading struct {
+	SectionID   int       `json:"section_id"`
+	Sensor      string    `json:"sensor,omitempty"`
+	Temperature float64   `json:"temperature" example:"-18.5"`
+	RecordedAt  time.Time `json:"recorded_at"`
+}
+
+// ReadingBucket summarises the readings of a section recorded from Start
+// until the start of the next bucket.
+type ReadingBucket struct {
+	Start time.Time `json:"start"`
+	Min   float64   `json:"min"`
+	Avg   float64   `json:"avg"`
+	Max   float64   `json:"max"`
+	Count int       `json:"count"`
+}
+
+// TemperatureExcursion is a period a section spent below its minimum
+// temperature. It is open, without EndedAt, until the section warms up.
+type TemperatureExcursion struct {
+	ID        int `json:"id"`
+	SectionID int `json:"section_id"`
+	// MinimumTemperature is the minimum temperature of the section when the
+	// excursion started.
+	MinimumTemperature int        `json:"minimum_temperature"`
+	LowestTemperature  float64    `json:"lowest_temperature"`
+	StartedAt          time.Time  `json:"started_at"`
+	EndedAt            *time.Time `json:"ended_at,omitempty"`
+}
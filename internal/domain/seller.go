@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type Seller struct {
 	ID          int    `json:"id"`
 	CID         int    `json:"cid"`
@@ -7,4 +9,6 @@ type Seller struct {
 	Address     string `json:"address"`
 	Telephone   string `json:"telephone"`
 	IDLocality  int    `json:"locality_id"`
+	// DeletedAt is set while the seller is soft deleted.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
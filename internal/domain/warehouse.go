@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type Warehouse struct {
 	ID                 int    `json:"id"`
 	Address            string `json:"address"`
@@ -7,4 +9,39 @@ type Warehouse struct {
 	WarehouseCode      string `json:"warehouse_code"`
 	MinimumCapacity    int    `json:"minimum_capacity"`
 	MinimumTemperature int    `json:"minimum_temperature"`
+	// DeletedAt is set while the warehouse is soft deleted.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// WarehouseSummary aggregates the sections of a warehouse.
+type WarehouseSummary struct {
+	WarehouseID  int `json:"warehouse_id"`
+	SectionCount int `json:"section_count"`
+	// Capacity sums the capacities of the sections.
+	Capacity WarehouseCapacity `json:"capacity"`
+	// Utilisation is the current capacity in percent of the maximum one.
+	Utilisation float64 `json:"utilisation_percentage"`
+	// TemperatureRange spans the current temperatures of the sections. It
+	// is missing when the warehouse has no sections.
+	TemperatureRange *TemperatureRange `json:"temperature_range,omitempty"`
+	BatchCount       int               `json:"batch_count"`
+	EmployeeCount    int               `json:"employee_count"`
+	// OverCapacity are the sections storing more than their maximum
+	// capacity, and UnderCapacity the ones storing less than their minimum.
+	OverCapacity  []Section `json:"over_capacity"`
+	UnderCapacity []Section `json:"under_capacity"`
+}
+
+// WarehouseCapacity is the current, minimum and maximum capacity of a
+// warehouse.
+type WarehouseCapacity struct {
+	Current int `json:"current"`
+	Minimum int `json:"minimum"`
+	Maximum int `json:"maximum"`
+}
+
+// TemperatureRange is the lowest and highest of a set of temperatures.
+type TemperatureRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
 }
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription asks for the events of the given types to be posted to
+// URL.
+type WebhookSubscription struct {
+	ID  int    `json:"id"`
+	URL string `json:"url"`
+	// Secret is the key the deliveries are signed with. It is never
+	// returned.
+	Secret     string    `json:"-"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is an event posted, or to be posted, to a subscription.
+type WebhookDelivery struct {
+	ID             int    `json:"id"`
+	SubscriptionID int    `json:"subscription_id"`
+	EventType      string `json:"event_type"`
+	// Payload is the exact body posted to the subscription.
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+	// Status is pending until the delivery succeeds (delivered) or runs out
+	// of attempts (dead).
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
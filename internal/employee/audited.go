@@ -0,0 +1,56 @@
+package employee
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// entity is the name of employees in the audit log.
+const entity = "employee"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations, updates, deletions
+// and restorations in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// SaveEmployee saves an employee and records its creation.
+func (s *auditedService) SaveEmployee(ctx context.Context, e domain.Employee) (int, error) {
+	id, err := s.Service.SaveEmployee(ctx, e)
+	if err != nil {
+		return 0, err
+	}
+	e.ID = id
+	audit.Created(ctx, s.log, entity, id, e)
+	return id, nil
+}
+
+// UpdateEmployee updates an employee and records it as it was before and
+// after.
+func (s *auditedService) UpdateEmployee(ctx context.Context, e domain.Employee) error {
+	return audit.Update(ctx, s.log, entity, e.ID, e, s.Service.GetEmployeeByID, func() error {
+		return s.Service.UpdateEmployee(ctx, e)
+	})
+}
+
+// DeleteEmployee deletes an employee and records it as it was before.
+func (s *auditedService) DeleteEmployee(ctx context.Context, id int) error {
+	return audit.Delete(ctx, s.log, entity, id, s.Service.GetEmployeeByID, func() error {
+		return s.Service.DeleteEmployee(ctx, id)
+	})
+}
+
+// RestoreEmployee restores an employee and records it as it was before and after.
+func (s *auditedService) RestoreEmployee(ctx context.Context, id int) (domain.Employee, error) {
+	return audit.Restore(ctx, s.log, entity, id, s.Service.GetEmployeeByID, func() (domain.Employee, error) {
+		return s.Service.RestoreEmployee(ctx, id)
+	})
+}
@@ -0,0 +1,208 @@
+// Package employeetest provides a contract test suite for
+// employee.Repository. Every implementation of the interface should pass it.
+package employeetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/employee"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewEmployee returns a valid employee with the given card number.
+func NewEmployee(cardNumberID string, warehouseID int) domain.Employee {
+	return domain.Employee{
+		CardNumberID: cardNumberID,
+		FirstName:    "John",
+		LastName:     "Doe",
+		WarehouseID:  warehouseID,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) employee.Repository) {
+	ctx := context.Background()
+
+	t.Run("it should save an employee and read it back", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		e := NewEmployee("A123", 1)
+
+		// Act
+		id, err := repo.Save(ctx, e)
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		e.ID = id
+		assert.Equal(t, e, obtained)
+	})
+
+	t.Run("it should return every saved employee", func(t *testing.T) {
+		repo := newRepository(t)
+		for _, card := range []string{"A123", "B456"} {
+			_, err := repo.Save(ctx, NewEmployee(card, 1))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, obtained, 2)
+	})
+
+	t.Run("it should return ErrNotFound when the employee does not exist", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.Get(ctx, 1)
+
+		assert.True(t, errors.Is(err, employee.ErrNotFound))
+	})
+
+	t.Run("it should report whether a card number is taken", func(t *testing.T) {
+		repo := newRepository(t)
+		_, err := repo.Save(ctx, NewEmployee("A123", 1))
+		require.NoError(t, err)
+
+		assert.True(t, repo.Exists(ctx, "A123"))
+		assert.False(t, repo.Exists(ctx, "B456"))
+	})
+
+	t.Run("it should update names and warehouse but keep the card number", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewEmployee("A123", 1))
+		require.NoError(t, err)
+		updated := domain.Employee{ID: id, CardNumberID: "Z999", FirstName: "Jane", LastName: "Smith", WarehouseID: 2}
+
+		// Act
+		err = repo.Update(ctx, updated)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		updated.CardNumberID = "A123"
+		assert.Equal(t, updated, obtained)
+	})
+
+	t.Run("it should delete an employee", func(t *testing.T) {
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewEmployee("A123", 1))
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, id)
+
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, id)
+		assert.True(t, errors.Is(err, employee.ErrNotFound))
+	})
+
+	t.Run("it should hide a deleted employee unless deleted employees are included", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewEmployee("A123", 1))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewEmployee("B456", 1))
+		require.NoError(t, err)
+
+		// Act
+		err = repo.Delete(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		all, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+		assert.False(t, repo.Exists(ctx, "A123"))
+		byWarehouse, err := repo.GetByWarehouseIDs(ctx, []int{1})
+		require.NoError(t, err)
+		assert.Len(t, byWarehouse, 1)
+
+		withDeleted := softdelete.WithDeleted(ctx)
+		all, err = repo.GetAll(withDeleted)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+		obtained, err := repo.Get(withDeleted, id)
+		require.NoError(t, err)
+		assert.NotNil(t, obtained.DeletedAt)
+		assert.True(t, errors.Is(repo.Delete(ctx, id), employee.ErrNotFound))
+	})
+
+	t.Run("it should restore a deleted employee", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		e := NewEmployee("A123", 1)
+		id, err := repo.Save(ctx, e)
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, id))
+
+		// Act
+		err = repo.Restore(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		e.ID = id
+		assert.Equal(t, e, obtained)
+		assert.True(t, errors.Is(repo.Restore(ctx, id), employee.ErrNotFound))
+	})
+
+	t.Run("it should purge the employees deleted before a time", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		deleted, err := repo.Save(ctx, NewEmployee("A123", 1))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewEmployee("B456", 1))
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, deleted))
+
+		// Act
+		early, errEarly := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		purged, err := repo.Purge(ctx, time.Now().Add(time.Hour))
+
+		// Assert
+		require.NoError(t, errEarly)
+		require.NoError(t, err)
+		assert.Equal(t, 0, early)
+		assert.Equal(t, 1, purged)
+		all, err := repo.GetAll(softdelete.WithDeleted(ctx))
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("it should return ErrNotFound when deleting a missing employee", func(t *testing.T) {
+		repo := newRepository(t)
+
+		err := repo.Delete(ctx, 1)
+
+		assert.True(t, errors.Is(err, employee.ErrNotFound))
+	})
+
+	t.Run("it should return the employees of the given warehouses", func(t *testing.T) {
+		repo := newRepository(t)
+		for i, warehouseID := range []int{1, 2, 2, 3} {
+			_, err := repo.Save(ctx, NewEmployee(fmt.Sprintf("E%d", i), warehouseID))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetByWarehouseIDs(ctx, []int{1, 2})
+
+		require.NoError(t, err)
+		var warehouses []int
+		for _, e := range obtained {
+			warehouses = append(warehouses, e.WarehouseID)
+		}
+		assert.ElementsMatch(t, []int{1, 2, 2}, warehouses)
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/davidop97/apiGo/pkg/sqlin"
 )
 
 var ErrNotFound = errors.New("section not found")
@@ -17,13 +20,23 @@ type Repository interface {
 	Exists(ctx context.Context, cardNumberID string) bool
 	Save(ctx context.Context, e domain.Employee) (int, error)
 	Update(ctx context.Context, e domain.Employee) error
+	// Delete soft deletes an employee, which reads skip unless their context
+	// includes deleted rows (see softdelete.WithDeleted).
 	Delete(ctx context.Context, id int) error
+	// Restore undoes the deletion of an employee.
+	Restore(ctx context.Context, id int) error
+	// Purge removes for good the employees deleted before the given time.
+	Purge(ctx context.Context, before time.Time) (int, error)
+	GetByWarehouseIDs(ctx context.Context, warehouseIDs []int) ([]domain.Employee, error)
 }
 
 type repository struct {
 	db *sql.DB
 }
 
+// employeeColumns are the columns of the employees table, in the order they are scanned.
+const employeeColumns = "id, card_number_id, first_name, last_name, warehouse_id, deleted_at"
+
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
 		db: db,
@@ -31,8 +44,8 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
-	query := "SELECT * FROM employees"
-	rows, err := r.db.Query(query)
+	query := "SELECT " + employeeColumns + " FROM employees WHERE " + softdelete.Visible(ctx, "deleted_at")
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +54,7 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
 
 	for rows.Next() {
 		e := domain.Employee{}
-		_ = rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+		_ = rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, softdelete.Scan(&e.DeletedAt))
 		employees = append(employees, e)
 	}
 
@@ -49,10 +62,10 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
-	query := "SELECT * FROM employees WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT " + employeeColumns + " FROM employees WHERE id=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, id)
 	e := domain.Employee{}
-	err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, softdelete.Scan(&e.DeletedAt))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -66,8 +79,8 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
 }
 
 func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
-	query := "SELECT card_number_id FROM employees WHERE card_number_id=?;"
-	row := r.db.QueryRow(query, cardNumberID)
+	query := "SELECT card_number_id FROM employees WHERE card_number_id=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, cardNumberID)
 	err := row.Scan(&cardNumberID)
 	return err == nil
 }
@@ -93,7 +106,7 @@ func (r *repository) Save(ctx context.Context, e domain.Employee) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, e domain.Employee) error {
-	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=?"
+	query := "UPDATE employees SET first_name=?, last_name=?, warehouse_id=?  WHERE id=? AND deleted_at IS NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
@@ -112,14 +125,34 @@ func (r *repository) Update(ctx context.Context, e domain.Employee) error {
 	return nil
 }
 
+// Delete soft deletes an employee: it is hidden from the reads until it is
+// restored or purged.
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM employees WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE employees SET deleted_at=? WHERE id=? AND deleted_at IS NULL"
+	return r.exec(ctx, query, softdelete.Format(time.Now()), id)
+}
+
+// Restore undoes the soft deletion of an employee.
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE employees SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	return r.exec(ctx, query, id)
+}
+
+// Purge removes for good the employees deleted before the given time.
+func (r *repository) Purge(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM employees WHERE deleted_at < ?"
+	res, err := r.db.ExecContext(ctx, query, softdelete.Format(before))
 	if err != nil {
-		return err
+		return 0, err
 	}
+	affect, err := res.RowsAffected()
+	return int(affect), err
+}
 
-	res, err := stmt.Exec(id)
+// exec runs a statement changing one employee and returns ErrNotFound if no
+// row was changed.
+func (r *repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -135,3 +168,27 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// GetByWarehouseIDs returns the employees of every warehouse in warehouseIDs.
+func (r *repository) GetByWarehouseIDs(ctx context.Context, warehouseIDs []int) ([]domain.Employee, error) {
+	if len(warehouseIDs) == 0 {
+		return nil, nil
+	}
+	in, args := sqlin.Ints(warehouseIDs)
+	query := "SELECT " + employeeColumns + " FROM employees WHERE warehouse_id IN " + in + " AND " + softdelete.Visible(ctx, "deleted_at")
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var employees []domain.Employee
+	for rows.Next() {
+		e := domain.Employee{}
+		if err := rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, softdelete.Scan(&e.DeletedAt)); err != nil {
+			return nil, err
+		}
+		employees = append(employees, e)
+	}
+	return employees, rows.Err()
+}
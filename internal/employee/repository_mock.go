@@ -2,6 +2,7 @@ package employee
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/stretchr/testify/mock"
@@ -40,3 +41,18 @@ func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
 	args := r.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (r *RepositoryMock) GetByWarehouseIDs(ctx context.Context, warehouseIDs []int) ([]domain.Employee, error) {
+	args := r.Called(ctx, warehouseIDs)
+	return args.Get(0).([]domain.Employee), args.Error(1)
+}
+
+func (r *RepositoryMock) Restore(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := r.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
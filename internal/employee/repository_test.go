@@ -0,0 +1,15 @@
+package employee_test
+
+import (
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/employee"
+	"github.com/davidop97/apiGo/internal/employee/employeetest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+		return employee.NewRepository(mysqltest.Open(t))
+	})
+}
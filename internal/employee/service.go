@@ -3,8 +3,10 @@ package employee
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/softdelete"
 )
 
 // Errors
@@ -17,6 +19,9 @@ type Service interface {
 	SaveEmployee(ctx context.Context, employee domain.Employee) (int, error)
 	UpdateEmployee(ctx context.Context, employee domain.Employee) error
 	DeleteEmployee(ctx context.Context, id int) error
+	GetEmployeesByWarehouseIDs(ctx context.Context, warehouseIDs []int) ([]domain.Employee, error)
+	RestoreEmployee(ctx context.Context, id int) (domain.Employee, error)
+	PurgeEmployees(ctx context.Context, before time.Time) (int, error)
 }
 
 // Struct contains repository
@@ -82,3 +87,32 @@ func (s *service) DeleteEmployee(ctx context.Context, id int) (err error) {
 
 	return
 }
+
+// GetEmployeesByWarehouseIDs returns the employees of every warehouse in warehouseIDs.
+func (s *service) GetEmployeesByWarehouseIDs(ctx context.Context, warehouseIDs []int) ([]domain.Employee, error) {
+	return s.repo.GetByWarehouseIDs(ctx, warehouseIDs)
+}
+
+// Function restore a deleted employee, an employee that isn't deleted is returned as it is
+func (s *service) RestoreEmployee(ctx context.Context, id int) (employee domain.Employee, err error) {
+	employee, err = s.repo.Get(softdelete.WithDeleted(ctx), id)
+	if err != nil || employee.DeletedAt == nil {
+		return
+	}
+
+	// - The card number id of a deleted employee may have been taken by a new one
+	if s.repo.Exists(ctx, employee.CardNumberID) {
+		return domain.Employee{}, ErrEmployeeAlreadyExists
+	}
+
+	if err = s.repo.Restore(ctx, id); err != nil {
+		return domain.Employee{}, err
+	}
+	employee.DeletedAt = nil
+	return
+}
+
+// Function purge the employees deleted before the given time
+func (s *service) PurgeEmployees(ctx context.Context, before time.Time) (int, error) {
+	return s.repo.Purge(ctx, before)
+}
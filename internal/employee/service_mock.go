@@ -2,6 +2,7 @@ package employee
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/stretchr/testify/mock"
@@ -35,3 +36,18 @@ func (s *ServiceMock) DeleteEmployee(ctx context.Context, id int) error {
 	args := s.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (s *ServiceMock) GetEmployeesByWarehouseIDs(ctx context.Context, warehouseIDs []int) ([]domain.Employee, error) {
+	args := s.Called(ctx, warehouseIDs)
+	return args.Get(0).([]domain.Employee), args.Error(1)
+}
+
+func (s *ServiceMock) RestoreEmployee(ctx context.Context, id int) (domain.Employee, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Employee), args.Error(1)
+}
+
+func (s *ServiceMock) PurgeEmployees(ctx context.Context, before time.Time) (int, error) {
+	args := s.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
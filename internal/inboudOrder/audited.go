@@ -0,0 +1,33 @@
+package inboudorder
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// entity is the name of inbound orders in the audit log.
+const entity = "inbound_order"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// CreateInboundOrder saves an inbound order and records its creation.
+func (s *auditedService) CreateInboundOrder(ctx context.Context, order domain.InboudOrder) (int, error) {
+	id, err := s.Service.CreateInboundOrder(ctx, order)
+	if err != nil {
+		return 0, err
+	}
+	order.ID = id
+	audit.Created(ctx, s.log, entity, id, order)
+	return id, nil
+}
@@ -0,0 +1,121 @@
+// Package inboudordertest provides a contract test suite for
+// inboudorder.Repository. Every implementation of the interface should pass
+// it.
+package inboudordertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows the inbound order repository references but does not
+// write.
+type Fixtures struct {
+	// AddWarehouse stores a warehouse and returns its id.
+	AddWarehouse func(t *testing.T) int
+	// AddEmployee stores an employee of the given warehouse and returns it.
+	AddEmployee func(t *testing.T, warehouseID int) domain.Employee
+}
+
+// NewInboudOrder returns a valid inbound order with the given number.
+func NewInboudOrder(number string, employeeID, warehouseID int) domain.InboudOrder {
+	return domain.InboudOrder{
+		OrderDate:      "2023-01-01",
+		OrderNumber:    number,
+		EmployeeID:     employeeID,
+		ProductBatchID: 1,
+		WarehouseID:    warehouseID,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, each time it
+// is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (inboudorder.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save an inbound order", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		warehouseID := fixtures.AddWarehouse(t)
+		employee := fixtures.AddEmployee(t, warehouseID)
+
+		id, err := repo.Save(ctx, NewInboudOrder("IO-1", employee.ID, warehouseID))
+
+		require.NoError(t, err)
+		assert.Positive(t, id)
+		assert.True(t, repo.ExistsInboundOrder(ctx, "IO-1"))
+		assert.False(t, repo.ExistsInboundOrder(ctx, "IO-2"))
+	})
+
+	t.Run("it should reject an inbound order whose warehouse does not exist", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		employee := fixtures.AddEmployee(t, fixtures.AddWarehouse(t))
+
+		_, err := repo.Save(ctx, NewInboudOrder("IO-1", employee.ID, 99))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it should report whether employees and warehouses exist", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		warehouseID := fixtures.AddWarehouse(t)
+		employee := fixtures.AddEmployee(t, warehouseID)
+
+		assert.True(t, repo.ExistsEmployee(ctx, employee.ID))
+		assert.False(t, repo.ExistsEmployee(ctx, employee.ID+1))
+		assert.True(t, repo.ExistsWarehouse(ctx, warehouseID))
+		assert.False(t, repo.ExistsWarehouse(ctx, warehouseID+1))
+	})
+
+	t.Run("it should find an employee or return nil when missing", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		employee := fixtures.AddEmployee(t, fixtures.AddWarehouse(t))
+
+		found, err := repo.Exists(ctx, employee.ID)
+		require.NoError(t, err)
+		missing, err := repo.Exists(ctx, employee.ID+1)
+		require.NoError(t, err)
+
+		assert.Equal(t, &employee, found)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("it should count the inbound orders of each employee", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		warehouseID := fixtures.AddWarehouse(t)
+		busy := fixtures.AddEmployee(t, warehouseID)
+		idle := fixtures.AddEmployee(t, warehouseID)
+		for _, number := range []string{"IO-1", "IO-2"} {
+			_, err := repo.Save(ctx, NewInboudOrder(number, busy.ID, warehouseID))
+			require.NoError(t, err)
+		}
+
+		// Act
+		all, err := repo.GetAllReports(ctx)
+		require.NoError(t, err)
+		one, err := repo.GenerateReport(ctx, busy.ID)
+		require.NoError(t, err)
+
+		// Assert
+		assert.ElementsMatch(t, []inboudorder.Report{
+			{Employee: &busy, InboudOrdersCount: 2},
+			{Employee: &idle, InboudOrdersCount: 0},
+		}, all)
+		assert.Equal(t, inboudorder.Report{Employee: &busy, InboudOrdersCount: 2}, one)
+	})
+
+	t.Run("it should return ErrEmployeeNotFound when reporting a missing employee", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.GenerateReport(ctx, 1)
+
+		assert.True(t, errors.Is(err, inboudorder.ErrEmployeeNotFound))
+	})
+}
@@ -0,0 +1,31 @@
+package inboudorder
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/activity"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// liveService publishes the inbound orders a Service receives to the live
+// feed of warehouse activity.
+type liveService struct {
+	Service
+	feed activity.Publisher
+}
+
+// NewLiveService returns s publishing the inbound orders it creates to feed.
+func NewLiveService(s Service, feed activity.Publisher) Service {
+	return &liveService{Service: s, feed: feed}
+}
+
+// CreateInboundOrder saves an inbound order and publishes its reception.
+func (s *liveService) CreateInboundOrder(ctx context.Context, order domain.InboudOrder) (int, error) {
+	id, err := s.Service.CreateInboundOrder(ctx, order)
+	if err != nil {
+		return 0, err
+	}
+	order.ID = id
+	s.feed.Publish(activity.InboundOrderReceived, order.WarehouseID, order)
+	return id, nil
+}
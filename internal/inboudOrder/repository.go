@@ -6,6 +6,9 @@ import (
 	"errors"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/events"
 )
 
 var ErrEmployeeNotFound = errors.New("section not found")
@@ -18,10 +21,15 @@ type Repository interface {
 	ExistsInboundOrder(ctx context.Context, orderNumber string) bool
 	ExistsWarehouse(ctx context.Context, warehouseID int) bool
 	Save(ctx context.Context, i domain.InboudOrder) (int, error)
+	// SaveEvent writes e to the outbox, to be published once committed.
+	SaveEvent(ctx context.Context, e events.Event) error
+	// InTx calls fn with a repository whose queries run in one transaction,
+	// committed only if fn returns nil.
+	InTx(ctx context.Context, fn func(r Repository) error) error
 }
 
 type repository struct {
-	db *sql.DB
+	db dbtx.DB
 }
 
 func NewRepository(db *sql.DB) Repository {
@@ -36,7 +44,7 @@ type Report struct {
 }
 
 func (r *repository) Exists(ctx context.Context, employeeID int) (*domain.Employee, error) {
-	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id = ?"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE id = ? AND deleted_at IS NULL"
 	var employee domain.Employee
 	err := r.db.QueryRowContext(ctx, query, employeeID).Scan(
 		&employee.ID,
@@ -87,7 +95,7 @@ func (r *repository) GenerateReport(ctx context.Context, employeeID int) (report
 
 func (r *repository) GetAllReports(ctx context.Context) ([]Report, error) {
 	// Obtener todos los empleados
-	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees"
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id FROM employees WHERE deleted_at IS NULL"
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -128,7 +136,7 @@ func (r *repository) GetAllReports(ctx context.Context) ([]Report, error) {
 }
 
 func (r *repository) ExistsEmployee(ctx context.Context, employeeID int) bool {
-	query := "SELECT id FROM employees WHERE id=?;"
+	query := "SELECT id FROM employees WHERE id=? AND deleted_at IS NULL;"
 	row := r.db.QueryRow(query, employeeID)
 	err := row.Scan(&employeeID)
 	return err == nil
@@ -142,7 +150,7 @@ func (r *repository) ExistsInboundOrder(ctx context.Context, orderNumber string)
 }
 
 func (r *repository) ExistsWarehouse(ctx context.Context, warehouseID int) bool {
-	query := "SELECT id FROM warehouses WHERE id=?;"
+	query := "SELECT id FROM warehouses WHERE id=? AND deleted_at IS NULL;"
 	row := r.db.QueryRow(query, warehouseID)
 	err := row.Scan(&warehouseID)
 	return err == nil
@@ -167,3 +175,15 @@ func (r *repository) Save(ctx context.Context, i domain.InboudOrder) (int, error
 
 	return int(id), nil
 }
+
+// SaveEvent writes an event to the outbox table.
+func (r *repository) SaveEvent(ctx context.Context, e events.Event) error {
+	return outbox.Save(ctx, r.db, e)
+}
+
+// InTx runs fn on a copy of the repository bound to a transaction.
+func (r *repository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return dbtx.Run(ctx, r.db, func(tx dbtx.DB) error {
+		return fn(&repository{db: tx})
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/events"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -45,3 +46,16 @@ func (r *RepositoryMock) Save(ctx context.Context, i domain.InboudOrder) (int, e
 	args := r.Called(ctx, i)
 	return args.Int(0), args.Error(1)
 }
+
+func (r *RepositoryMock) SaveEvent(ctx context.Context, e events.Event) error {
+	args := r.Called(ctx, e)
+	return args.Error(0)
+}
+
+// InTx runs fn on the mock itself unless an error is set for the call.
+func (r *RepositoryMock) InTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := r.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(r)
+}
@@ -0,0 +1,44 @@
+package inboudorder_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/employee"
+	"github.com/davidop97/apiGo/internal/employee/employeetest"
+	inboudorder "github.com/davidop97/apiGo/internal/inboudOrder"
+	"github.com/davidop97/apiGo/internal/inboudOrder/inboudordertest"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/internal/warehouse/warehousetest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	inboudordertest.TestRepository(t, func(t *testing.T) (inboudorder.Repository, inboudordertest.Fixtures) {
+		db := mysqltest.Open(t)
+		warehouses := warehouse.NewRepository(db)
+		employees := employee.NewRepository(db)
+		next := 0
+
+		fixtures := inboudordertest.Fixtures{
+			AddWarehouse: func(t *testing.T) int {
+				next++
+				id, err := warehouses.Save(context.Background(), warehousetest.NewWarehouse(fmt.Sprintf("WH%d", next)))
+				require.NoError(t, err)
+				return id
+			},
+			AddEmployee: func(t *testing.T, warehouseID int) domain.Employee {
+				next++
+				e := employeetest.NewEmployee(fmt.Sprintf("E%d", next), warehouseID)
+				id, err := employees.Save(context.Background(), e)
+				require.NoError(t, err)
+				e.ID = id
+				return e
+			},
+		}
+		return inboudorder.NewRepository(db), fixtures
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
 	//"errors"
 )
 
@@ -64,8 +65,19 @@ func (s *service) CreateInboundOrder(ctx context.Context, order domain.InboudOrd
 		return
 	}
 
-	// Crear la Inbound Order
-	id, err = s.repo.Save(ctx, order)
+	// Crear la Inbound Order, con el evento de su recepción
+	err = s.repo.InTx(ctx, func(r Repository) error {
+		var err error
+		if id, err = r.Save(ctx, order); err != nil {
+			return err
+		}
+		order.ID = id
+		e, err := outbox.NewEvent(outbox.InboundOrderReceived, id, order)
+		if err != nil {
+			return err
+		}
+		return r.SaveEvent(ctx, e)
+	})
 	if err != nil {
 		log.Printf("Error saving Inbound Order: %v", err) // Imprimir el error en el log
 		return
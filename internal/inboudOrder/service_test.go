@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_GetAllReports(t *testing.T) {
@@ -98,7 +101,11 @@ func TestService_CreateInboundOrder(t *testing.T) {
 		repository.On("ExistsEmployee", ctx, inboundOrder.EmployeeID).Return(existsTrue)
 		repository.On("ExistsWarehouse", ctx, inboundOrder.WarehouseID).Return(existsTrue)
 		repository.On("ExistsInboundOrder", ctx, inboundOrder.OrderNumber).Return(existsFalse)
+		repository.On("InTx", ctx).Return(nil)
 		repository.On("Save", ctx, inboundOrder).Return(expectedId, nil)
+		repository.On("SaveEvent", ctx, mock.MatchedBy(func(e events.Event) bool {
+			return e.Type == outbox.InboundOrderReceived && e.AggregateID == expectedId
+		})).Return(nil)
 		service := NewService(repository)
 
 		// When
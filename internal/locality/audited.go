@@ -0,0 +1,47 @@
+package locality
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+)
+
+// entity is the name of localities in the audit log.
+const entity = "locality"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations and imports in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a locality and records its creation.
+func (s *auditedService) Save(ctx context.Context, l domain.Locality) (int, error) {
+	id, err := s.Service.Save(ctx, l)
+	if err != nil {
+		return 0, err
+	}
+	l.ID = id
+	audit.Created(ctx, s.log, entity, id, l)
+	return id, nil
+}
+
+// Import imports localities and records the localities it saved.
+func (s *auditedService) Import(ctx context.Context, localities []domain.Locality, opts bulk.Options) ([]bulk.Outcome, error) {
+	outcomes, err := s.Service.Import(ctx, localities, opts)
+	if err != nil {
+		return outcomes, err
+	}
+	audit.Imported(ctx, s.log, entity, localities, outcomes, opts, func(l domain.Locality, id int) domain.Locality {
+		l.ID = id
+		return l
+	})
+	return outcomes, nil
+}
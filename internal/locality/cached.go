@@ -0,0 +1,67 @@
+package locality
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/cache"
+)
+
+// cacheEntity is the name of localities in the cache keys.
+const cacheEntity = "locality"
+
+// cachedRepository reads the localities of a Repository through a cache and
+// invalidates the ones it writes.
+type cachedRepository struct {
+	Repository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedRepository returns r reading the localities by id through c, where
+// they are kept for ttl, and removing from c the localities it updates.
+func NewCachedRepository(r Repository, c cache.Cache, ttl time.Duration) Repository {
+	return &cachedRepository{Repository: r, cache: c, ttl: ttl}
+}
+
+// GetLocality returns a locality from the cache, or from the repository when
+// it is missing.
+func (r *cachedRepository) GetLocality(ctx context.Context, id int) (domain.Locality, error) {
+	return cache.Through(ctx, r.cache, cache.Key(cacheEntity, id), r.ttl, func() (domain.Locality, error) {
+		return r.Repository.GetLocality(ctx, id)
+	})
+}
+
+func (r *cachedRepository) Update(ctx context.Context, l domain.Locality) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, l.ID))
+	return r.Repository.Update(ctx, l)
+}
+
+// InTx runs fn in a transaction of the repository, whose reads skip the cache
+// as they may see uncommitted writes, and invalidates the localities written
+// once it ends.
+func (r *cachedRepository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	tx := &txRepository{}
+	defer func() { cache.Invalidate(ctx, r.cache, tx.written...) }()
+	return r.Repository.InTx(ctx, func(inner Repository) error {
+		tx.Repository = inner
+		return fn(tx)
+	})
+}
+
+// txRepository records the keys of the localities written in a transaction.
+type txRepository struct {
+	Repository
+	written []string
+}
+
+func (r *txRepository) Update(ctx context.Context, l domain.Locality) error {
+	r.written = append(r.written, cache.Key(cacheEntity, l.ID))
+	return r.Repository.Update(ctx, l)
+}
+
+// InTx runs fn in the transaction already open.
+func (r *txRepository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return fn(r)
+}
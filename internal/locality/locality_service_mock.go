@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -49,3 +50,15 @@ func (s *ServiceMock) GetReportSellers(ctx context.Context, id int) (r []domain.
 	//Return the report of sellers and the error if exists.
 	return args.Get(0).([]domain.ReportSellers), args.Error(1)
 }
+
+// GetLocalitiesByIDs function. Mock of the GetLocalitiesByIDs function. Get the localities with the given ids or an error.
+func (s *ServiceMock) GetLocalitiesByIDs(ctx context.Context, ids []int) ([]domain.Locality, error) {
+	args := s.Called(ctx, ids)
+	return args.Get(0).([]domain.Locality), args.Error(1)
+}
+
+// Import function. Mock of the Import function. Get the outcome of every locality or an error.
+func (s *ServiceMock) Import(ctx context.Context, localities []domain.Locality, opts bulk.Options) ([]bulk.Outcome, error) {
+	args := s.Called(ctx, localities, opts)
+	return args.Get(0).([]bulk.Outcome), args.Error(1)
+}
@@ -0,0 +1,189 @@
+// Package localitytest provides a contract test suite for
+// locality.Repository. Every implementation of the interface should pass it.
+package localitytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows the locality repository reads but does not write.
+type Fixtures struct {
+	// AddSeller stores a seller in the given locality.
+	AddSeller func(t *testing.T, localityID int)
+}
+
+// NewLocality returns a valid locality with the given postal code.
+func NewLocality(postalCode int) domain.Locality {
+	return domain.Locality{
+		PostalCode:   postalCode,
+		LocalityName: "Palermo",
+		ProvinceName: "Buenos Aires",
+		CountryName:  "Argentina",
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, each time it
+// is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (locality.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save a locality and read it back", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		l := NewLocality(1425)
+
+		// Act
+		id, err := repo.Save(ctx, l)
+		require.NoError(t, err)
+		obtained, err := repo.GetLocality(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		l.ID = id
+		assert.Equal(t, l, obtained)
+	})
+
+	t.Run("it should return every saved locality", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		for _, code := range []int{1425, 1426} {
+			_, err := repo.Save(ctx, NewLocality(code))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, obtained, 2)
+	})
+
+	t.Run("it should return ErrNoRows when there are no localities", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.GetAll(ctx)
+
+		assert.True(t, errors.Is(err, locality.ErrNoRows))
+	})
+
+	t.Run("it should return ErrLocalityNotFound when the locality does not exist", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.GetLocality(ctx, 1)
+
+		assert.True(t, errors.Is(err, locality.ErrLocalityNotFound))
+	})
+
+	t.Run("it should report whether a postal code is taken", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		_, err := repo.Save(ctx, NewLocality(1425))
+		require.NoError(t, err)
+
+		assert.True(t, repo.Exists(ctx, 1425))
+		assert.False(t, repo.Exists(ctx, 1426))
+	})
+
+	t.Run("it should count the sellers of each locality", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		busy, err := repo.Save(ctx, NewLocality(1425))
+		require.NoError(t, err)
+		empty, err := repo.Save(ctx, NewLocality(1426))
+		require.NoError(t, err)
+		fixtures.AddSeller(t, busy)
+		fixtures.AddSeller(t, busy)
+
+		// Act
+		all, err := repo.GetReportSellers(ctx, 0)
+		require.NoError(t, err)
+		one, err := repo.GetReportSellers(ctx, busy)
+		require.NoError(t, err)
+
+		// Assert
+		assert.ElementsMatch(t, []domain.ReportSellers{
+			{Locality_id: busy, Locality_name: "Palermo", Postal_code: 1425, Sellers_count: 2},
+			{Locality_id: empty, Locality_name: "Palermo", Postal_code: 1426, Sellers_count: 0},
+		}, all)
+		assert.Equal(t, []domain.ReportSellers{{Locality_id: busy, Locality_name: "Palermo", Postal_code: 1425, Sellers_count: 2}}, one)
+	})
+
+	t.Run("it should return ErrNoRows when reporting a missing locality", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.GetReportSellers(ctx, 1)
+
+		assert.True(t, errors.Is(err, locality.ErrNoRows))
+	})
+
+	t.Run("it should return the localities with the given ids", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		var ids []int
+		for _, postalCode := range []int{1000, 2000, 3000} {
+			id, err := repo.Save(ctx, NewLocality(postalCode))
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		obtained, err := repo.GetByIDs(ctx, []int{ids[0], ids[2], ids[2] + 100})
+
+		require.NoError(t, err)
+		require.Len(t, obtained, 2)
+		assert.ElementsMatch(t, []int{ids[0], ids[2]}, []int{obtained[0].ID, obtained[1].ID})
+	})
+
+	t.Run("it should find a locality by its postal code", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		l := NewLocality(1425)
+		id, err := repo.Save(ctx, l)
+		require.NoError(t, err)
+
+		// Act
+		obtained, err := repo.GetByPostalCode(ctx, 1425)
+		_, errMissing := repo.GetByPostalCode(ctx, 1426)
+
+		// Assert
+		require.NoError(t, err)
+		l.ID = id
+		assert.Equal(t, l, obtained)
+		assert.True(t, errors.Is(errMissing, locality.ErrLocalityNotFound))
+	})
+
+	t.Run("it should update a locality", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		id, err := repo.Save(ctx, NewLocality(1425))
+		require.NoError(t, err)
+		updated := domain.Locality{ID: id, PostalCode: 1425, LocalityName: "Recoleta", ProvinceName: "Buenos Aires", CountryName: "Argentina"}
+
+		// Act
+		err = repo.Update(ctx, updated)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.GetLocality(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, updated, obtained)
+	})
+
+	t.Run("it should keep the writes of a committed transaction", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+
+		// Act
+		err := repo.InTx(ctx, func(tx locality.Repository) error {
+			_, err := tx.Save(ctx, NewLocality(1425))
+			return err
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, repo.Exists(ctx, 1425))
+	})
+}
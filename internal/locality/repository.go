@@ -6,6 +6,8 @@ import (
 	"errors"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/sqlin"
 )
 
 var (
@@ -19,10 +21,16 @@ type Repository interface {
 	Save(ctx context.Context, l domain.Locality) (int, error)
 	Exists(ctx context.Context, cid int) bool
 	GetReportSellers(ctx context.Context, id int) ([]domain.ReportSellers, error)
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Locality, error)
+	GetByPostalCode(ctx context.Context, postalCode int) (domain.Locality, error)
+	Update(ctx context.Context, l domain.Locality) error
+	// InTx calls fn with a repository whose queries run in one transaction,
+	// committed only if fn returns nil.
+	InTx(ctx context.Context, fn func(r Repository) error) error
 }
 
 type repository struct {
-	db *sql.DB
+	db dbtx.DB
 }
 
 func NewRepository(db *sql.DB) Repository {
@@ -112,6 +120,46 @@ func (r *repository) Save(ctx context.Context, l domain.Locality) (int, error) {
 	return int(id), nil
 }
 
+// Update a locality in the database. Return an error if an internal error occurs.
+func (r *repository) Update(ctx context.Context, l domain.Locality) error {
+	query := "UPDATE locality SET postal_code=?, locality_name=?, province_name=?, country_name=? WHERE id=?"
+	// Prepare the query
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	// Execute the query
+	res, err := stmt.Exec(l.PostalCode, l.LocalityName, l.ProvinceName, l.CountryName, l.ID)
+	if err != nil {
+		return err
+	}
+	// Check if the locality was updated.
+	_, err = res.RowsAffected()
+	return err
+}
+
+// Get a locality using its postal code. Return ErrLocalityNotFound if it doesn't exist.
+func (r *repository) GetByPostalCode(ctx context.Context, postalCode int) (domain.Locality, error) {
+	query := "SELECT id, postal_code, locality_name, province_name, country_name FROM locality WHERE postal_code=?;"
+	row := r.db.QueryRowContext(ctx, query, postalCode)
+	l := domain.Locality{}
+	err := row.Scan(&l.ID, &l.PostalCode, &l.LocalityName, &l.ProvinceName, &l.CountryName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Locality{}, ErrLocalityNotFound
+	}
+	if err != nil {
+		return domain.Locality{}, err
+	}
+	return l, nil
+}
+
+// InTx runs fn on a copy of the repository bound to a transaction.
+func (r *repository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return dbtx.Run(ctx, r.db, func(tx dbtx.DB) error {
+		return fn(&repository{db: tx})
+	})
+}
+
 // Check if a Postal_code locality exists using its id. Return true if it exists and false if it doesn't.
 func (r *repository) Exists(ctx context.Context, cid int) bool {
 	query := "SELECT postal_code FROM locality WHERE postal_code=?;"
@@ -125,7 +173,7 @@ func (r *repository) GetReportSellers(ctx context.Context, id int) ([]domain.Rep
 	var args []interface{}
 	query := `SELECT l.id AS locality_id, l.locality_name AS locality_name,l.postal_code, COUNT(s.id) AS seller_count
 	FROM locality l
-	LEFT JOIN sellers s ON l.id = s.locality_id `
+	LEFT JOIN sellers s ON l.id = s.locality_id AND s.deleted_at IS NULL `
 
 	//Check if id is provided and if is greater than 0.
 	if id > 0 {
@@ -171,3 +219,29 @@ func (r *repository) GetReportSellers(ctx context.Context, id int) ([]domain.Rep
 	// Everything is ok, return the requested ReportSellers.
 	return reportSellers, nil
 }
+
+// GetByIDs returns the localities whose id is in ids, in no particular order.
+// Ids without a locality are skipped.
+func (r *repository) GetByIDs(ctx context.Context, ids []int) ([]domain.Locality, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	in, args := sqlin.Ints(ids)
+	query := "SELECT id, postal_code, locality_name, province_name, country_name FROM locality WHERE id IN " + in
+	//Execute the query
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var localities []domain.Locality
+	for rows.Next() {
+		l := domain.Locality{}
+		if err := rows.Scan(&l.ID, &l.PostalCode, &l.LocalityName, &l.ProvinceName, &l.CountryName); err != nil {
+			return nil, err
+		}
+		localities = append(localities, l)
+	}
+	return localities, rows.Err()
+}
@@ -57,3 +57,29 @@ func (r *RepositoryMock) GetReportSellers(ctx context.Context, id int) (l []doma
 	//Return the report of sellers and the error if exists.
 	return args.Get(0).([]domain.ReportSellers), args.Error(1)
 }
+
+// GetByIDs function. Mock of the GetByIDs function. Get the localities with the given ids or an error.
+func (r *RepositoryMock) GetByIDs(ctx context.Context, ids []int) ([]domain.Locality, error) {
+	args := r.Called(ctx, ids)
+	return args.Get(0).([]domain.Locality), args.Error(1)
+}
+
+// GetByPostalCode function. Mock of the GetByPostalCode function. Get a locality by postal code if exists or an error.
+func (r *RepositoryMock) GetByPostalCode(ctx context.Context, postalCode int) (domain.Locality, error) {
+	args := r.Called(ctx, postalCode)
+	return args.Get(0).(domain.Locality), args.Error(1)
+}
+
+// Update function. Mock of the Update function. Update a locality or return an error.
+func (r *RepositoryMock) Update(ctx context.Context, l domain.Locality) error {
+	args := r.Called(ctx, l)
+	return args.Error(0)
+}
+
+// InTx function. Mock of the InTx function. Run fn on the mock itself unless an error is set for the call.
+func (r *RepositoryMock) InTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := r.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(r)
+}
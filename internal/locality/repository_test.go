@@ -0,0 +1,44 @@
+package locality_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/locality/localitytest"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/internal/seller/sellertest"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	localitytest.TestRepository(t, newRepository(func(r locality.Repository) locality.Repository { return r }))
+}
+
+func TestRepository_MySQLCached(t *testing.T) {
+	localitytest.TestRepository(t, newRepository(func(r locality.Repository) locality.Repository {
+		return locality.NewCachedRepository(r, cache.NewLRU(100), time.Minute)
+	}))
+}
+
+// newRepository returns the repositories the contract suite runs on, as
+// returned by wrap.
+func newRepository(wrap func(locality.Repository) locality.Repository) func(t *testing.T) (locality.Repository, localitytest.Fixtures) {
+	return func(t *testing.T) (locality.Repository, localitytest.Fixtures) {
+		db := mysqltest.Open(t)
+		sellers := seller.NewRepository(db)
+		next := 0
+
+		fixtures := localitytest.Fixtures{
+			AddSeller: func(t *testing.T, localityID int) {
+				next++
+				_, err := sellers.Save(context.Background(), sellertest.NewSeller(next, localityID))
+				require.NoError(t, err)
+			},
+		}
+		return wrap(locality.NewRepository(db)), fixtures
+	}
+}
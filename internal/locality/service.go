@@ -7,6 +7,7 @@ import (
 	//"errors"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
 )
 
 // Errors
@@ -20,6 +21,8 @@ type Service interface {
 	GetAll(ctx context.Context) ([]domain.Locality, error)
 	Save(ctx context.Context, l domain.Locality) (int, error)
 	GetReportSellers(ctx context.Context, id int) ([]domain.ReportSellers, error)
+	GetLocalitiesByIDs(ctx context.Context, ids []int) ([]domain.Locality, error)
+	Import(ctx context.Context, localities []domain.Locality, opts bulk.Options) ([]bulk.Outcome, error)
 }
 
 type service struct {
@@ -64,3 +67,39 @@ func (s *service) Save(ctx context.Context, locality domain.Locality) (int, erro
 func (s *service) GetReportSellers(ctx context.Context, id int) ([]domain.ReportSellers, error) {
 	return s.r.GetReportSellers(ctx, id)
 }
+
+// GetLocalitiesByIDs returns the localities whose id is in ids.
+func (s *service) GetLocalitiesByIDs(ctx context.Context, ids []int) ([]domain.Locality, error) {
+	return s.r.GetByIDs(ctx, ids)
+}
+
+// Import saves localities in a single transaction and returns the outcome of
+// each one. A postal code that is already stored, or repeated in localities,
+// rejects the locality in insert mode and updates the stored locality in
+// upsert mode. Nothing is saved when a locality is rejected or opts.DryRun is set.
+func (s *service) Import(ctx context.Context, localities []domain.Locality, opts bulk.Options) ([]bulk.Outcome, error) {
+	var outcomes []bulk.Outcome
+	err := s.r.InTx(ctx, func(r Repository) error {
+		var err error
+		outcomes, err = bulk.Apply(localities, opts, func(l domain.Locality) (bulk.Outcome, error) {
+			stored, err := r.GetByPostalCode(ctx, l.PostalCode)
+			switch {
+			case errors.Is(err, ErrLocalityNotFound):
+				id, err := r.Save(ctx, l)
+				return bulk.Inserted(id), err
+			case err != nil:
+				return bulk.Outcome{}, err
+			case opts.Mode != bulk.ModeUpsert:
+				return bulk.Failed(ErrLocalityAlreadyExists), nil
+			}
+			l.ID = stored.ID
+			return bulk.Updated(l.ID), r.Update(ctx, l)
+		})
+		return err
+	})
+	//A rolled back import is not an error, the outcomes tell why it happened.
+	if errors.Is(err, bulk.ErrRollback) {
+		err = nil
+	}
+	return outcomes, err
+}
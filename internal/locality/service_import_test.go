@@ -0,0 +1,75 @@
+package locality
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestService_Import function.
+// Test the Import function in the following cases:
+// - Insert a new locality and update a stored one in upsert mode.
+// - Reject a stored postal code in insert mode.
+// - Return the error if the transaction cannot be started.
+func TestService_Import(t *testing.T) {
+	ctx := context.Background()
+	newLocality := func(postalCode int) domain.Locality {
+		return domain.Locality{PostalCode: postalCode, LocalityName: "Palermo", ProvinceName: "Buenos Aires", CountryName: "Argentina"}
+	}
+
+	t.Run("Insert a new locality and update a stored one in upsert mode", func(t *testing.T) {
+		//Arrange
+		fresh, stored := newLocality(1425), newLocality(1426)
+		updated := stored
+		updated.ID = 4
+		repositoryMock := NewMockRepository()
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByPostalCode", ctx, 1425).Return(domain.Locality{}, ErrLocalityNotFound)
+		repositoryMock.On("GetByPostalCode", ctx, 1426).Return(domain.Locality{ID: 4, PostalCode: 1426}, nil)
+		repositoryMock.On("Save", ctx, fresh).Return(5, nil)
+		repositoryMock.On("Update", ctx, updated).Return(nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		outcomes, err := service.Import(ctx, []domain.Locality{fresh, stored}, bulk.Options{Mode: bulk.ModeUpsert})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Inserted(5), bulk.Updated(4)}, outcomes)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("Reject a stored postal code in insert mode", func(t *testing.T) {
+		//Arrange
+		repositoryMock := NewMockRepository()
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByPostalCode", ctx, 1426).Return(domain.Locality{ID: 4, PostalCode: 1426}, nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		outcomes, err := service.Import(ctx, []domain.Locality{newLocality(1426)}, bulk.Options{Mode: bulk.ModeInsert})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Failed(ErrLocalityAlreadyExists)}, outcomes)
+		repositoryMock.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("Return the error if the transaction cannot be started", func(t *testing.T) {
+		//Arrange
+		errBegin := errors.New("cannot begin")
+		repositoryMock := NewMockRepository()
+		repositoryMock.On("InTx", ctx).Return(errBegin)
+		service := NewService(repositoryMock)
+
+		//Act
+		_, err := service.Import(ctx, []domain.Locality{newLocality(1425)}, bulk.Options{})
+
+		//Assert
+		assert.ErrorIs(t, err, errBegin)
+	})
+}
@@ -0,0 +1,88 @@
+// Package outbox publishes the domain events reliably. An event is written to
+// the outbox table in the transaction of the change it describes, so it
+// exists if and only if the change was committed, and a relay publishes the
+// events written to an events.EventBus. An event is published at least once:
+// consumers skip the ones they already handled by their ID.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/google/uuid"
+)
+
+// Event types.
+const (
+	PurchaseOrderCreated = "purchase_order.created"
+	InboundOrderReceived = "inbound_order.received"
+	ProductBatchCreated  = "product_batch.created"
+	// SectionCapacityLow warns that a change of the stock of a section left
+	// it below its minimum capacity.
+	SectionCapacityLow = "section.capacity_low"
+	// SectionExcursionStarted warns that a reading of a section fell below
+	// its minimum temperature, and SectionExcursionEnded that the section
+	// warmed up again.
+	SectionExcursionStarted = "section.temperature_excursion_started"
+	SectionExcursionEnded   = "section.temperature_excursion_ended"
+)
+
+// Versions maps every event type to the version of the schema its events are
+// written with. A change of the data of an event that could break consumers
+// adds a schema with the next version instead of editing the current one.
+var Versions = map[string]int{
+	PurchaseOrderCreated:    1,
+	InboundOrderReceived:    1,
+	ProductBatchCreated:     1,
+	SectionCapacityLow:      1,
+	SectionExcursionStarted: 1,
+	SectionExcursionEnded:   1,
+}
+
+// Types lists the event types.
+var Types = []string{PurchaseOrderCreated, InboundOrderReceived, ProductBatchCreated, SectionCapacityLow, SectionExcursionStarted, SectionExcursionEnded}
+
+// Errors
+var (
+	ErrUnknownEvent = errors.New("unknown event type")
+	ErrInvalidEvent = errors.New("event does not match its schema")
+)
+
+// NewEvent returns the event of type eventType about the entity aggregateID,
+// with data encoded as JSON, in the current version of the type. It returns
+// ErrInvalidEvent when data does not match the schema of that version.
+func NewEvent(eventType string, aggregateID int, data interface{}) (events.Event, error) {
+	version, ok := Versions[eventType]
+	if !ok {
+		return events.Event{}, fmt.Errorf("%w: %s", ErrUnknownEvent, eventType)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return events.Event{}, err
+	}
+	if err := Validate(eventType, version, payload); err != nil {
+		return events.Event{}, err
+	}
+
+	return events.Event{
+		ID:          uuid.NewString(),
+		Type:        eventType,
+		Version:     version,
+		AggregateID: aggregateID,
+		OccurredAt:  time.Now().UTC().Truncate(time.Microsecond),
+		Data:        payload,
+	}, nil
+}
+
+// Save writes e to the outbox through db, which should be the transaction of
+// the change e describes.
+func Save(ctx context.Context, db dbtx.DB, e events.Event) error {
+	query := "INSERT INTO outbox (event_id, event_type, version, aggregate_id, payload, occurred_at) VALUES (?, ?, ?, ?, ?, ?)"
+	_, err := db.ExecContext(ctx, query, e.ID, e.Type, e.Version, e.AggregateID, string(e.Data), formatDatetime(e.OccurredAt))
+	return err
+}
@@ -0,0 +1,74 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEvent(t *testing.T) {
+	t.Run("it should encode the data in the current version of the type", func(t *testing.T) {
+		// Arrange
+		order := domain.PurchaseOrder{ID: 7, OrderNumber: "PO-7", OrderDate: "2026-10-18", TrackingCode: "TR-7", BuyerID: 1, ProductRecordID: 2, OrderStatusID: 1}
+
+		// Act
+		e, err := NewEvent(PurchaseOrderCreated, 7, order)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, e.ID, 36)
+		assert.Equal(t, PurchaseOrderCreated, e.Type)
+		assert.Equal(t, 1, e.Version)
+		assert.Equal(t, 7, e.AggregateID)
+		assert.False(t, e.OccurredAt.IsZero())
+		var data domain.PurchaseOrder
+		require.NoError(t, json.Unmarshal(e.Data, &data))
+		assert.Equal(t, order, data)
+	})
+
+	t.Run("it should refuse data that does not match the schema", func(t *testing.T) {
+		_, err := NewEvent(PurchaseOrderCreated, 7, map[string]interface{}{"id": 7, "order_number": 7})
+
+		assert.True(t, errors.Is(err, ErrInvalidEvent), err)
+	})
+
+	t.Run("it should refuse an unknown event type", func(t *testing.T) {
+		_, err := NewEvent("seller.created", 7, domain.Seller{})
+
+		assert.True(t, errors.Is(err, ErrUnknownEvent), err)
+	})
+}
+
+func TestSchema(t *testing.T) {
+	t.Run("it should have a valid schema for the current version of every type", func(t *testing.T) {
+		samples := map[string]interface{}{
+			PurchaseOrderCreated:    domain.PurchaseOrder{ID: 1},
+			InboundOrderReceived:    domain.InboudOrder{ID: 1},
+			ProductBatchCreated:     domain.ProductBatch{ID: 1},
+			SectionCapacityLow:      domain.Section{ID: 1},
+			SectionExcursionStarted: domain.TemperatureExcursion{ID: 1, SectionID: 1},
+			SectionExcursionEnded:   domain.TemperatureExcursion{ID: 1, SectionID: 1, EndedAt: &time.Time{}},
+		}
+		require.Len(t, samples, len(Types))
+		for _, eventType := range Types {
+			b, err := Schema(eventType, Versions[eventType])
+			require.NoError(t, err, eventType)
+			assert.True(t, json.Valid(b), eventType)
+
+			data, err := json.Marshal(samples[eventType])
+			require.NoError(t, err)
+			assert.NoError(t, Validate(eventType, Versions[eventType], data), eventType)
+		}
+	})
+
+	t.Run("it should not have a schema for a future version", func(t *testing.T) {
+		_, err := Schema(PurchaseOrderCreated, Versions[PurchaseOrderCreated]+1)
+
+		assert.True(t, errors.Is(err, ErrUnknownEvent), err)
+	})
+}
@@ -0,0 +1,179 @@
+// Package outboxtest provides a contract test suite for outbox.Repository.
+// Every implementation of the interface should pass it.
+package outboxtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// occurredAt is the time the events of the suite occurred at, and now the
+// time the suite reads the pending events at.
+var (
+	occurredAt = time.Date(2026, time.October, 18, 15, 4, 5, 123456000, time.UTC)
+	now        = occurredAt.Add(time.Minute)
+)
+
+// NewEvent returns the n-th purchase_order.created event of the suite.
+func NewEvent(n int) events.Event {
+	return events.Event{
+		ID:          fmt.Sprintf("00000000-0000-4000-8000-%012d", n),
+		Type:        outbox.PurchaseOrderCreated,
+		Version:     1,
+		AggregateID: n,
+		OccurredAt:  occurredAt,
+		Data:        json.RawMessage(fmt.Sprintf(`{"id":%d}`, n)),
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) outbox.Repository) {
+	ctx := context.Background()
+
+	t.Run("it should return the pending events in the order they were saved", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		for n := 1; n <= 3; n++ {
+			require.NoError(t, repo.Save(ctx, NewEvent(n)))
+		}
+
+		// Act
+		all, errAll := repo.Pending(ctx, now, 10)
+		first, errFirst := repo.Pending(ctx, now, 2)
+
+		// Assert
+		require.NoError(t, errAll)
+		require.NoError(t, errFirst)
+		require.Len(t, all, 3)
+		for i, rec := range all {
+			assert.Equal(t, NewEvent(i+1), rec.Event)
+			assert.Zero(t, rec.Attempts)
+			assert.Nil(t, rec.PublishedAt)
+		}
+		assert.Equal(t, all[:2], first)
+	})
+
+	t.Run("it should not return the published events", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		require.NoError(t, repo.Save(ctx, NewEvent(1)))
+		require.NoError(t, repo.Save(ctx, NewEvent(2)))
+		pending, err := repo.Pending(ctx, now, 10)
+		require.NoError(t, err)
+		publishedAt := occurredAt.Add(time.Second)
+
+		// Act
+		err = repo.MarkPublished(ctx, pending[0].ID, publishedAt)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Pending(ctx, now, 10)
+		require.NoError(t, err)
+		assert.Equal(t, pending[1:], obtained)
+		published, err := repo.Get(ctx, pending[0].ID)
+		require.NoError(t, err)
+		require.NotNil(t, published.PublishedAt)
+		assert.Equal(t, publishedAt, *published.PublishedAt)
+	})
+
+	t.Run("it should keep a failed event pending with the reason", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		require.NoError(t, repo.Save(ctx, NewEvent(1)))
+		pending, err := repo.Pending(ctx, now, 10)
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, repo.MarkFailed(ctx, pending[0].ID, "nats: timeout", now.Add(-time.Second)))
+		err = repo.MarkFailed(ctx, pending[0].ID, "nats: connection closed", now)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Pending(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, obtained, 1)
+		assert.Equal(t, 2, obtained[0].Attempts)
+		assert.Equal(t, "nats: connection closed", obtained[0].LastError)
+		require.NotNil(t, obtained[0].NextAttemptAt)
+		assert.Equal(t, now, *obtained[0].NextAttemptAt)
+	})
+
+	t.Run("it should not return a failed event before its retry", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		require.NoError(t, repo.Save(ctx, NewEvent(1)))
+		require.NoError(t, repo.Save(ctx, NewEvent(2)))
+		pending, err := repo.Pending(ctx, now, 10)
+		require.NoError(t, err)
+		retryAt := now.Add(time.Second)
+
+		// Act
+		err = repo.MarkFailed(ctx, pending[0].ID, "nats: timeout", retryAt)
+
+		// Assert
+		require.NoError(t, err)
+		before, err := repo.Pending(ctx, now, 10)
+		require.NoError(t, err)
+		assert.Equal(t, pending[1:], before)
+		after, err := repo.Pending(ctx, retryAt, 10)
+		require.NoError(t, err)
+		require.Len(t, after, 2)
+		assert.Equal(t, pending[0].ID, after[0].ID)
+	})
+
+	t.Run("it should not return a dead event", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		require.NoError(t, repo.Save(ctx, NewEvent(1)))
+		pending, err := repo.Pending(ctx, now, 10)
+		require.NoError(t, err)
+
+		// Act
+		err = repo.MarkDead(ctx, pending[0].ID, "nats: timeout", now)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Pending(ctx, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, obtained)
+		dead, err := repo.Get(ctx, pending[0].ID)
+		require.NoError(t, err)
+		require.NotNil(t, dead.DeadAt)
+		assert.Equal(t, now, *dead.DeadAt)
+		assert.Equal(t, 1, dead.Attempts)
+		assert.Equal(t, "nats: timeout", dead.LastError)
+	})
+
+	t.Run("it should refuse an event saved twice", func(t *testing.T) {
+		repo := newRepository(t)
+		require.NoError(t, repo.Save(ctx, NewEvent(1)))
+
+		err := repo.Save(ctx, NewEvent(1))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it should return ErrNotFound for a missing record", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, errGet := repo.Get(ctx, 1)
+		errPublished := repo.MarkPublished(ctx, 1, occurredAt)
+		errFailed := repo.MarkFailed(ctx, 1, "nats: timeout", now)
+		errDead := repo.MarkDead(ctx, 1, "nats: timeout", now)
+
+		assert.True(t, errors.Is(errGet, outbox.ErrNotFound))
+		assert.True(t, errors.Is(errPublished, outbox.ErrNotFound))
+		assert.True(t, errors.Is(errFailed, outbox.ErrNotFound))
+		assert.True(t, errors.Is(errDead, outbox.ErrNotFound))
+	})
+}
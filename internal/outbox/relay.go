@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/events"
+)
+
+// relayBatch is the number of events RelayPending publishes at most.
+const relayBatch = 100
+
+// Relay publishes the events of the outbox to a bus.
+type Relay interface {
+	// RelayPending publishes the pending events, in the order they were
+	// written, and returns how many it published. An event the bus refused
+	// is left pending, with the reason, and published again by a call once
+	// its retry is due; the events after it are still published. An event
+	// refused MaxAttempts times is dead and no longer published.
+	RelayPending(ctx context.Context) (int, error)
+	// Run calls RelayPending every interval until ctx is done.
+	Run(ctx context.Context, interval time.Duration)
+}
+
+// RelayOptions configures the retries of the events the bus refused. The
+// zero value of a field selects its default.
+type RelayOptions struct {
+	// MaxAttempts is the number of attempts after which an event is dead,
+	// 10 by default.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled before each of
+	// the next ones up to MaxBackoff. They are 5 seconds and 15 minutes by
+	// default.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+type relay struct {
+	repo        Repository
+	bus         events.EventBus
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	now         func() time.Time
+}
+
+func NewRelay(repo Repository, bus events.EventBus, opts RelayOptions) Relay {
+	r := &relay{
+		repo:        repo,
+		bus:         bus,
+		maxAttempts: opts.MaxAttempts,
+		backoff:     opts.Backoff,
+		maxBackoff:  opts.MaxBackoff,
+		now:         time.Now,
+	}
+	if r.maxAttempts <= 0 {
+		r.maxAttempts = 10
+	}
+	if r.backoff <= 0 {
+		r.backoff = 5 * time.Second
+	}
+	if r.maxBackoff <= 0 {
+		r.maxBackoff = 15 * time.Minute
+	}
+	return r
+}
+
+// RelayPending publishes a batch of pending events. Since the events waiting
+// for a retry are not pending, events the bus keeps refusing cannot fill the
+// batch and hold back the newer ones.
+func (r *relay) RelayPending(ctx context.Context) (int, error) {
+	pending, err := r.repo.Pending(ctx, r.now().UTC().Truncate(time.Microsecond), relayBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, rec := range pending {
+		if err := r.bus.Publish(ctx, rec.Event); err != nil {
+			if err := r.fail(ctx, rec, err); err != nil {
+				return published, err
+			}
+			continue
+		}
+		if err := r.repo.MarkPublished(ctx, rec.ID, r.now().UTC().Truncate(time.Microsecond)); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// fail records a failed attempt to publish rec: the event is retried after a
+// delay growing with its attempts, and dead once it ran out of them.
+func (r *relay) fail(ctx context.Context, rec Record, cause error) error {
+	now := r.now().UTC().Truncate(time.Microsecond)
+	attempts := rec.Attempts + 1
+	if attempts >= r.maxAttempts {
+		log.Printf("outbox: event %s dead after %d attempts: %v", rec.Event.ID, attempts, cause)
+		return r.repo.MarkDead(ctx, rec.ID, cause.Error(), now)
+	}
+	return r.repo.MarkFailed(ctx, rec.ID, cause.Error(), now.Add(r.delay(attempts)))
+}
+
+// delay returns the delay before the retry following the given attempt.
+func (r *relay) delay(attempts int) time.Duration {
+	d := r.backoff
+	for i := 1; i < attempts && d < r.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.maxBackoff {
+		d = r.maxBackoff
+	}
+	return d
+}
+
+// Run relays the pending events until ctx is done. A full batch is followed
+// by the next one at once.
+func (r *relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for {
+			n, err := r.RelayPending(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("outbox: relaying: %v", err)
+			}
+			if err != nil || n < relayBatch {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/davidop97/apiGo/pkg/natstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRelay returns a relay with the default options whose clock is
+// stopped at now.
+func newTestRelay(repo Repository, bus events.EventBus, now time.Time) *relay {
+	r := NewRelay(repo, bus, RelayOptions{}).(*relay)
+	r.now = func() time.Time { return now }
+	return r
+}
+
+// savePurchaseOrders writes the purchase_order.created events of the orders
+// ids to repo, and returns them.
+func savePurchaseOrders(t *testing.T, repo Repository, ids ...int) []events.Event {
+	t.Helper()
+	var saved []events.Event
+	for _, id := range ids {
+		e, err := NewEvent(PurchaseOrderCreated, id, map[string]interface{}{"id": id, "order_number": "PO", "order_date": "2026-10-18",
+			"tracking_code": "TR", "buyer_id": 1, "product_record_id": 1, "order_status_id": 1})
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(context.Background(), e))
+		saved = append(saved, e)
+	}
+	return saved
+}
+
+func TestRelay_RelayPending(t *testing.T) {
+	ctx := context.Background()
+	publishedAt := time.Date(2026, time.October, 18, 15, 4, 6, 0, time.UTC)
+
+	t.Run("it should publish the pending events once", func(t *testing.T) {
+		// Arrange
+		repo := NewRepository(mysqltest.Open(t))
+		bus := events.NewMemoryBus()
+		var received []events.Event
+		_, err := bus.Subscribe(PurchaseOrderCreated, func(ctx context.Context, e events.Event) error {
+			received = append(received, e)
+			return nil
+		})
+		require.NoError(t, err)
+		saved := savePurchaseOrders(t, repo, 1, 2)
+		r := newTestRelay(repo, bus, publishedAt)
+
+		// Act
+		first, errFirst := r.RelayPending(ctx)
+		second, errSecond := r.RelayPending(ctx)
+
+		// Assert
+		require.NoError(t, errFirst)
+		require.NoError(t, errSecond)
+		assert.Equal(t, 2, first)
+		assert.Equal(t, 0, second)
+		assert.Equal(t, saved, received)
+	})
+
+	t.Run("it should keep an event the bus refused for a retry and publish the next ones", func(t *testing.T) {
+		// Arrange
+		refused := errors.New("nats: timeout")
+		repo, bus := &RepositoryMock{}, events.NewMemoryBus()
+		_, err := bus.Subscribe(PurchaseOrderCreated, func(ctx context.Context, e events.Event) error {
+			if e.AggregateID == 1 {
+				return refused
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		pending := []Record{
+			{ID: 1, Event: events.Event{ID: "a", Type: PurchaseOrderCreated, AggregateID: 1}},
+			{ID: 2, Event: events.Event{ID: "b", Type: PurchaseOrderCreated, AggregateID: 2}},
+		}
+		repo.On("Pending", ctx, publishedAt, relayBatch).Return(pending, nil)
+		repo.On("MarkFailed", ctx, 1, "nats: timeout", publishedAt.Add(5*time.Second)).Return(nil)
+		repo.On("MarkPublished", ctx, 2, publishedAt).Return(nil)
+		r := newTestRelay(repo, bus, publishedAt)
+
+		// Act
+		published, err := r.RelayPending(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("it should wait longer before every retry, up to the maximum", func(t *testing.T) {
+		r := newTestRelay(&RepositoryMock{}, events.NewMemoryBus(), publishedAt)
+
+		obtained := []time.Duration{r.delay(1), r.delay(2), r.delay(3), r.delay(9)}
+
+		assert.Equal(t, []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 15 * time.Minute}, obtained)
+	})
+
+	t.Run("it should give up on an event refused on its last attempt", func(t *testing.T) {
+		// Arrange
+		repo, bus := &RepositoryMock{}, events.NewMemoryBus()
+		_, err := bus.Subscribe(PurchaseOrderCreated, func(ctx context.Context, e events.Event) error {
+			return errors.New("nats: timeout")
+		})
+		require.NoError(t, err)
+		pending := []Record{{ID: 1, Event: events.Event{ID: "a", Type: PurchaseOrderCreated, AggregateID: 1}, Attempts: 9}}
+		repo.On("Pending", ctx, publishedAt, relayBatch).Return(pending, nil)
+		repo.On("MarkDead", ctx, 1, "nats: timeout", publishedAt).Return(nil)
+		r := newTestRelay(repo, bus, publishedAt)
+
+		// Act
+		published, err := r.RelayPending(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Zero(t, published)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("it should publish a new event behind a full batch of refused ones", func(t *testing.T) {
+		// Arrange
+		repo := NewRepository(mysqltest.Open(t))
+		bus := events.NewMemoryBus()
+		var received []int
+		_, err := bus.Subscribe(PurchaseOrderCreated, func(ctx context.Context, e events.Event) error {
+			if e.AggregateID <= relayBatch {
+				return errors.New("nats: timeout")
+			}
+			received = append(received, e.AggregateID)
+			return nil
+		})
+		require.NoError(t, err)
+		ids := make([]int, 0, relayBatch+1)
+		for id := 1; id <= relayBatch+1; id++ {
+			ids = append(ids, id)
+		}
+		savePurchaseOrders(t, repo, ids...)
+		r := newTestRelay(repo, bus, publishedAt)
+
+		// Act
+		first, errFirst := r.RelayPending(ctx)
+		second, errSecond := r.RelayPending(ctx)
+
+		// Assert
+		require.NoError(t, errFirst)
+		require.NoError(t, errSecond)
+		assert.Zero(t, first)
+		assert.Equal(t, 1, second)
+		assert.Equal(t, []int{relayBatch + 1}, received)
+	})
+
+	t.Run("it should publish the pending events to a NATS server", func(t *testing.T) {
+		// Arrange
+		repo := NewRepository(mysqltest.Open(t))
+		url := natstest.Start(t).URL()
+		subscriber, err := events.ConnectNATS(url, events.NATSOptions{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = subscriber.Close() })
+		received := make(chan events.Event, 10)
+		_, err = subscriber.Subscribe(PurchaseOrderCreated, func(ctx context.Context, e events.Event) error {
+			received <- e
+			return nil
+		})
+		require.NoError(t, err)
+		publisher, err := events.ConnectNATS(url, events.NATSOptions{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = publisher.Close() })
+		saved := savePurchaseOrders(t, repo, 1)
+		r := newTestRelay(repo, publisher, publishedAt)
+
+		// Act
+		published, err := r.RelayPending(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+		select {
+		case e := <-received:
+			assert.Equal(t, saved[0], e)
+		case <-time.After(2 * time.Second):
+			t.Fatal("the event was not delivered")
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/events"
+)
+
+// Record is an event of the outbox and the state of its publication.
+type Record struct {
+	// ID is the position of the event in the outbox.
+	ID        int
+	Event     events.Event
+	Attempts  int
+	LastError string
+	// NextAttemptAt is when a failed event is published again.
+	NextAttemptAt *time.Time
+	PublishedAt   *time.Time
+	// DeadAt is when the relay gave up on the event, which is no longer
+	// pending.
+	DeadAt *time.Time
+}
+
+// ErrNotFound is returned for a record that is not in the outbox.
+var ErrNotFound = errors.New("outbox record not found")
+
+// Repository reads the outbox for the relay. The events are written by the
+// repositories of the entities with Save.
+type Repository interface {
+	// Save writes e to the outbox outside of any other change.
+	Save(ctx context.Context, e events.Event) error
+	// Pending returns up to limit events neither published nor dead, and
+	// due at now: never attempted, or retried at or before now. They are in
+	// the order they were written.
+	Pending(ctx context.Context, now time.Time, limit int) ([]Record, error)
+	// Get returns the record id, or ErrNotFound.
+	Get(ctx context.Context, id int) (Record, error)
+	// MarkPublished records that the event id was published at the given
+	// time.
+	MarkPublished(ctx context.Context, id int, at time.Time) error
+	// MarkFailed records a failed attempt to publish the event id, which is
+	// pending again from retryAt.
+	MarkFailed(ctx context.Context, id int, reason string, retryAt time.Time) error
+	// MarkDead records the last failed attempt to publish the event id,
+	// which stays in the outbox but is no longer pending.
+	MarkDead(ctx context.Context, id int, reason string, at time.Time) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// datetimeLayout is how the DATETIME(6) columns are written and read.
+const datetimeLayout = "2006-01-02 15:04:05.999999"
+
+const selectRecords = "SELECT id, event_id, event_type, version, aggregate_id, payload, occurred_at, attempts, last_error, next_attempt_at, published_at, dead_at FROM outbox"
+
+// Save writes an event to the outbox table.
+func (r *repository) Save(ctx context.Context, e events.Event) error {
+	return Save(ctx, r.db, e)
+}
+
+// Pending returns the events of the outbox table whose published_at and
+// dead_at are NULL, and next_attempt_at NULL or past.
+func (r *repository) Pending(ctx context.Context, now time.Time, limit int) ([]Record, error) {
+	query := selectRecords + " WHERE published_at IS NULL AND dead_at IS NULL AND (next_attempt_at IS NULL OR next_attempt_at <= ?) ORDER BY id LIMIT ?"
+	return r.records(ctx, query, formatDatetime(now), limit)
+}
+
+// Get returns a record of the outbox table.
+func (r *repository) Get(ctx context.Context, id int) (Record, error) {
+	records, err := r.records(ctx, selectRecords+" WHERE id = ?", id)
+	if err != nil {
+		return Record{}, err
+	}
+	if len(records) == 0 {
+		return Record{}, ErrNotFound
+	}
+	return records[0], nil
+}
+
+// MarkPublished sets the published_at column of an event.
+func (r *repository) MarkPublished(ctx context.Context, id int, at time.Time) error {
+	return r.exec(ctx, "UPDATE outbox SET published_at = ?, last_error = NULL WHERE id = ?", formatDatetime(at), id)
+}
+
+// MarkFailed counts a failed attempt of an event, keeps its reason and when
+// to retry it.
+func (r *repository) MarkFailed(ctx context.Context, id int, reason string, retryAt time.Time) error {
+	return r.exec(ctx, "UPDATE outbox SET attempts = attempts + 1, last_error = ?, next_attempt_at = ? WHERE id = ?", reason, formatDatetime(retryAt), id)
+}
+
+// MarkDead counts the last failed attempt of an event, keeps its reason and
+// sets its dead_at column.
+func (r *repository) MarkDead(ctx context.Context, id int, reason string, at time.Time) error {
+	return r.exec(ctx, "UPDATE outbox SET attempts = attempts + 1, last_error = ?, dead_at = ? WHERE id = ?", reason, formatDatetime(at), id)
+}
+
+// exec runs an update of one record, returning ErrNotFound when there is no
+// such record.
+func (r *repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repository) records(ctx context.Context, query string, args ...interface{}) ([]Record, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var (
+			rec                                         Record
+			payload, occurredAt                         string
+			lastError, nextAttemptAt, publishedAt, dead sql.NullString
+		)
+		e := &rec.Event
+		if err := rows.Scan(&rec.ID, &e.ID, &e.Type, &e.Version, &e.AggregateID, &payload, &occurredAt, &rec.Attempts, &lastError, &nextAttemptAt, &publishedAt, &dead); err != nil {
+			return nil, err
+		}
+		e.Data = []byte(payload)
+		if e.OccurredAt, err = parseDatetime(occurredAt); err != nil {
+			return nil, err
+		}
+		rec.LastError = lastError.String
+		for _, col := range []struct {
+			value sql.NullString
+			dst   **time.Time
+		}{
+			{nextAttemptAt, &rec.NextAttemptAt},
+			{publishedAt, &rec.PublishedAt},
+			{dead, &rec.DeadAt},
+		} {
+			if !col.value.Valid {
+				continue
+			}
+			t, err := parseDatetime(col.value.String)
+			if err != nil {
+				return nil, err
+			}
+			*col.dst = &t
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+func formatDatetime(t time.Time) string {
+	return t.UTC().Format(datetimeLayout)
+}
+
+// parseDatetime parses a DATETIME column, read as text or, with the
+// parseTime option of the driver, converted to RFC 3339 by database/sql.
+func parseDatetime(s string) (time.Time, error) {
+	if t, err := time.Parse(datetimeLayout, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, errors.New("outbox: invalid datetime " + s)
+	}
+	return t, nil
+}
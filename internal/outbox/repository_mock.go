@@ -0,0 +1,43 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) Save(ctx context.Context, e events.Event) error {
+	args := r.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Pending(ctx context.Context, now time.Time, limit int) ([]Record, error) {
+	args := r.Called(ctx, now, limit)
+	return args.Get(0).([]Record), args.Error(1)
+}
+
+func (r *RepositoryMock) Get(ctx context.Context, id int) (Record, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(Record), args.Error(1)
+}
+
+func (r *RepositoryMock) MarkPublished(ctx context.Context, id int, at time.Time) error {
+	args := r.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) MarkFailed(ctx context.Context, id int, reason string, retryAt time.Time) error {
+	args := r.Called(ctx, id, reason, retryAt)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) MarkDead(ctx context.Context, id int, reason string, at time.Time) error {
+	args := r.Called(ctx, id, reason, at)
+	return args.Error(0)
+}
@@ -0,0 +1,15 @@
+package outbox_test
+
+import (
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/internal/outbox/outboxtest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	outboxtest.TestRepository(t, func(t *testing.T) outbox.Repository {
+		return outbox.NewRepository(mysqltest.Open(t))
+	})
+}
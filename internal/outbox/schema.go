@@ -0,0 +1,68 @@
+package outbox
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// schemas holds the JSON schema of the data of every version of every event
+// type, in schemas/<type>.v<version>.json.
+//
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// parsed caches the schemas once parsed, by file name.
+var parsed sync.Map
+
+// Schema returns the JSON schema of the data of the events of type eventType
+// in the given version.
+func Schema(eventType string, version int) ([]byte, error) {
+	b, err := schemas.ReadFile(schemaFile(eventType, version))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s v%d", ErrUnknownEvent, eventType, version)
+	}
+	return b, err
+}
+
+// Validate checks data against the schema of eventType in the given version.
+func Validate(eventType string, version int, data []byte) error {
+	schema, err := loadSchema(eventType, version)
+	if err != nil {
+		return err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	if err := schema.VisitJSON(value); err != nil {
+		return fmt.Errorf("%w: %s v%d: %v", ErrInvalidEvent, eventType, version, err)
+	}
+	return nil
+}
+
+func loadSchema(eventType string, version int) (*openapi3.Schema, error) {
+	name := schemaFile(eventType, version)
+	if s, ok := parsed.Load(name); ok {
+		return s.(*openapi3.Schema), nil
+	}
+	b, err := Schema(eventType, version)
+	if err != nil {
+		return nil, err
+	}
+	s := &openapi3.Schema{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("outbox: parsing %s: %w", name, err)
+	}
+	parsed.Store(name, s)
+	return s, nil
+}
+
+func schemaFile(eventType string, version int) string {
+	return fmt.Sprintf("schemas/%s.v%d.json", eventType, version)
+}
@@ -0,0 +1,112 @@
+package product
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+)
+
+// Names of products and product records in the audit log.
+const (
+	entity       = "product"
+	recordEntity = "product_record"
+)
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations, updates, deletions,
+// restorations, imports and price updates in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a product and records its creation.
+func (s *auditedService) Save(ctx context.Context, p domain.Product) (int, error) {
+	id, err := s.Service.Save(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	p.ID = id
+	audit.Created(ctx, s.log, entity, id, p)
+	return id, nil
+}
+
+// Update updates a product and records it as it was before and after.
+func (s *auditedService) Update(ctx context.Context, p domain.Product) error {
+	return audit.Update(ctx, s.log, entity, p.ID, p, s.Service.Get, func() error {
+		return s.Service.Update(ctx, p)
+	})
+}
+
+// Delete deletes a product and records it as it was before.
+func (s *auditedService) Delete(ctx context.Context, id int) error {
+	return audit.Delete(ctx, s.log, entity, id, s.Service.Get, func() error {
+		return s.Service.Delete(ctx, id)
+	})
+}
+
+// CreateProductRecord saves a product record and records its creation.
+func (s *auditedService) CreateProductRecord(ctx context.Context, p domain.ProductRecordCreate) (int, error) {
+	id, err := s.Service.CreateProductRecord(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	audit.Created(ctx, s.log, recordEntity, id, domain.ProductRecord{
+		ID:            id,
+		LastUpdate:    p.LastUpdate,
+		PurchasePrice: p.PurchasePrice,
+		SalePrice:     p.SalePrice,
+		Currency:      p.Currency,
+		ProductID:     p.ProductID,
+	})
+	return id, nil
+}
+
+// UpdatePrices updates the prices of products and records the creation of
+// every record it kept.
+func (s *auditedService) UpdatePrices(ctx context.Context, u PriceUpdate) ([]PriceChange, error) {
+	changes, err := s.Service.UpdatePrices(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range changes {
+		if c.RecordID == 0 {
+			continue
+		}
+		audit.Created(ctx, s.log, recordEntity, c.RecordID, domain.ProductRecord{
+			ID:            c.RecordID,
+			LastUpdate:    u.LastUpdate,
+			PurchasePrice: c.PurchasePrice,
+			SalePrice:     c.NewSalePrice,
+			Currency:      c.Currency,
+			ProductID:     c.ProductID,
+		})
+	}
+	return changes, nil
+}
+
+// Restore restores a product and records it as it was before and after.
+func (s *auditedService) Restore(ctx context.Context, id int) (domain.Product, error) {
+	return audit.Restore(ctx, s.log, entity, id, s.Service.Get, func() (domain.Product, error) {
+		return s.Service.Restore(ctx, id)
+	})
+}
+
+// Import imports products and records the products it saved.
+func (s *auditedService) Import(ctx context.Context, ps []domain.Product, opts bulk.Options) ([]bulk.Outcome, error) {
+	outcomes, err := s.Service.Import(ctx, ps, opts)
+	if err != nil {
+		return outcomes, err
+	}
+	audit.Imported(ctx, s.log, entity, ps, outcomes, opts, func(p domain.Product, id int) domain.Product {
+		p.ID = id
+		return p
+	})
+	return outcomes, nil
+}
@@ -0,0 +1,92 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+)
+
+// cacheEntity is the name of products in the cache keys.
+const cacheEntity = "product"
+
+// cachedRepository reads the products of a Repository through a cache and
+// invalidates the ones it writes.
+type cachedRepository struct {
+	Repository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedRepository returns r reading the products by id through c, where
+// they are kept for ttl, and removing from c the products it updates, deletes
+// or restores.
+func NewCachedRepository(r Repository, c cache.Cache, ttl time.Duration) Repository {
+	return &cachedRepository{Repository: r, cache: c, ttl: ttl}
+}
+
+// Get returns a product from the cache, or from the repository when it is
+// missing. Reads including the deleted products skip the cache.
+func (r *cachedRepository) Get(ctx context.Context, id int) (domain.Product, error) {
+	if softdelete.Included(ctx) {
+		return r.Repository.Get(ctx, id)
+	}
+	return cache.Through(ctx, r.cache, cache.Key(cacheEntity, id), r.ttl, func() (domain.Product, error) {
+		return r.Repository.Get(ctx, id)
+	})
+}
+
+func (r *cachedRepository) Update(ctx context.Context, p domain.Product) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, p.ID))
+	return r.Repository.Update(ctx, p)
+}
+
+func (r *cachedRepository) Delete(ctx context.Context, id int) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, id))
+	return r.Repository.Delete(ctx, id)
+}
+
+func (r *cachedRepository) Restore(ctx context.Context, id int) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, id))
+	return r.Repository.Restore(ctx, id)
+}
+
+// InTx runs fn in a transaction of the repository, whose reads skip the cache
+// as they may see uncommitted writes, and invalidates the products written
+// once it ends.
+func (r *cachedRepository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	tx := &txRepository{}
+	defer func() { cache.Invalidate(ctx, r.cache, tx.written...) }()
+	return r.Repository.InTx(ctx, func(inner Repository) error {
+		tx.Repository = inner
+		return fn(tx)
+	})
+}
+
+// txRepository records the keys of the products written in a transaction.
+type txRepository struct {
+	Repository
+	written []string
+}
+
+func (r *txRepository) Update(ctx context.Context, p domain.Product) error {
+	r.written = append(r.written, cache.Key(cacheEntity, p.ID))
+	return r.Repository.Update(ctx, p)
+}
+
+func (r *txRepository) Delete(ctx context.Context, id int) error {
+	r.written = append(r.written, cache.Key(cacheEntity, id))
+	return r.Repository.Delete(ctx, id)
+}
+
+func (r *txRepository) Restore(ctx context.Context, id int) error {
+	r.written = append(r.written, cache.Key(cacheEntity, id))
+	return r.Repository.Restore(ctx, id)
+}
+
+// InTx runs fn in the transaction already open.
+func (r *txRepository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return fn(r)
+}
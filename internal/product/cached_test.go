@@ -0,0 +1,98 @@
+package product
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedRepository(t *testing.T) {
+	ctx := context.Background()
+	stored := domain.Product{ID: 12, ProductCode: "P12", Description: "Yogurt"}
+
+	t.Run("it should read a product once", func(t *testing.T) {
+		// Arrange
+		inner := &RepositoryMock{}
+		inner.On("Get", ctx, 12).Return(stored, nil).Once()
+		r := NewCachedRepository(inner, cache.NewLRU(10), time.Minute)
+
+		// Act
+		first, err := r.Get(ctx, 12)
+		require.NoError(t, err)
+		second, err := r.Get(ctx, 12)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, stored, first)
+		assert.Equal(t, stored, second)
+		inner.AssertExpectations(t)
+	})
+
+	t.Run("it should read a product again once updated", func(t *testing.T) {
+		// Arrange
+		updated := stored
+		updated.Description = "Greek yogurt"
+		inner := &RepositoryMock{}
+		inner.On("Get", ctx, 12).Return(stored, nil).Once()
+		inner.On("Update", ctx, updated).Return(nil)
+		inner.On("Get", ctx, 12).Return(updated, nil).Once()
+		r := NewCachedRepository(inner, cache.NewLRU(10), time.Minute)
+		_, err := r.Get(ctx, 12)
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, r.Update(ctx, updated))
+		obtained, err := r.Get(ctx, 12)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, updated, obtained)
+		inner.AssertExpectations(t)
+	})
+
+	t.Run("it should read a product again once deleted in a transaction", func(t *testing.T) {
+		// Arrange
+		inner := &RepositoryMock{}
+		inner.On("Get", ctx, 12).Return(stored, nil).Once()
+		inner.On("InTx", ctx).Return(nil)
+		inner.On("Delete", ctx, 12).Return(nil)
+		inner.On("Get", ctx, 12).Return(domain.Product{}, ErrNotFound).Once()
+		r := NewCachedRepository(inner, cache.NewLRU(10), time.Minute)
+		_, err := r.Get(ctx, 12)
+		require.NoError(t, err)
+
+		// Act
+		err = r.InTx(ctx, func(tx Repository) error {
+			return tx.Delete(ctx, 12)
+		})
+		require.NoError(t, err)
+		_, err = r.Get(ctx, 12)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNotFound)
+		inner.AssertExpectations(t)
+	})
+
+	t.Run("it should skip the cache when reading the deleted products", func(t *testing.T) {
+		// Arrange
+		withDeleted := softdelete.WithDeleted(ctx)
+		inner := &RepositoryMock{}
+		inner.On("Get", withDeleted, 12).Return(stored, nil).Twice()
+		r := NewCachedRepository(inner, cache.NewLRU(10), time.Minute)
+
+		// Act
+		_, err := r.Get(withDeleted, 12)
+		require.NoError(t, err)
+		_, err = r.Get(withDeleted, 12)
+
+		// Assert
+		require.NoError(t, err)
+		inner.AssertExpectations(t)
+	})
+}
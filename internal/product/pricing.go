@@ -0,0 +1,215 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/davidop97/apiGo/pkg/money"
+)
+
+// Errors of the bulk price updates
+var (
+	ErrEmptySelector      = errors.New("select the products by seller_id, product_type_id or product_ids")
+	ErrInvalidPriceRule   = errors.New("invalid price rule")
+	ErrNoProductsSelected = errors.New("no product matches the selector")
+	// ErrNoRecordToReprice rejects a product without a record to take its
+	// prices from.
+	ErrNoRecordToReprice = errors.New("product has no record to reprice")
+	// ErrInvalidSalePrice rejects a product whose new sale price would not
+	// be positive.
+	ErrInvalidSalePrice = errors.New("new sale price must be greater than 0")
+)
+
+// Types of PriceRule
+const (
+	// PriceRuleAbsolute sets the sale price to its value.
+	PriceRuleAbsolute = "absolute"
+	// PriceRulePercentage raises the sale price by its value in percent.
+	PriceRulePercentage = "percentage"
+	// PriceRuleMargin sets the sale price over the purchase price so that
+	// its value, in percent, is the margin of the sale price.
+	PriceRuleMargin = "margin"
+)
+
+// PriceSelector selects the products whose prices are updated: the ones
+// matching every field set.
+type PriceSelector struct {
+	SellerID      int
+	ProductTypeID int
+	ProductIDs    []int
+}
+
+// PriceRule computes the new sale price of a product from its latest record.
+// Value is an amount in the currency of the record for PriceRuleAbsolute, and
+// a percentage for the others.
+type PriceRule struct {
+	Type  string
+	Value money.Amount
+}
+
+// PriceUpdate records new prices for the products of Selector, dated
+// LastUpdate.
+type PriceUpdate struct {
+	Selector   PriceSelector
+	Rule       PriceRule
+	LastUpdate string
+	// DryRun computes the new prices without keeping any record.
+	DryRun bool
+}
+
+// PriceChange is the new record of a product, in the currency of its latest
+// record. Err is set when the product was rejected, and RecordID when its
+// record was created.
+type PriceChange struct {
+	ProductID     int
+	RecordID      int
+	PurchasePrice money.Amount
+	OldSalePrice  money.Amount
+	NewSalePrice  money.Amount
+	Currency      money.Currency
+	Err           error
+}
+
+// UpdatePrices creates a record with the prices of u.Rule for every product of
+// u.Selector, in a single transaction, and returns the changes of the products
+// sorted by id. The purchase price and the currency are kept from the latest
+// record of each product. A product without records, whose latest record is dated after
+// u.LastUpdate or whose new sale price is not positive is rejected, and then
+// no record is kept, as in a dry run.
+func (s *service) UpdatePrices(ctx context.Context, u PriceUpdate) ([]PriceChange, error) {
+	if err := u.Rule.validate(); err != nil {
+		return nil, err
+	}
+	products, err := s.selectProducts(ctx, u.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]PriceChange, 0, len(products))
+	err = s.repo.InTx(ctx, func(r Repository) error {
+		outcomes, err := bulk.Apply(products, bulk.Options{DryRun: u.DryRun}, func(p domain.Product) (bulk.Outcome, error) {
+			change := PriceChange{ProductID: p.ID}
+			defer func() { changes = append(changes, change) }()
+
+			// Locking the product serialises its records with the ones
+			// CreateProductRecord inserts.
+			_, err := r.GetForUpdate(ctx, p.ID)
+			if errors.Is(err, ErrNotFound) {
+				return bulk.Failed(err), nil
+			}
+			if err != nil {
+				return bulk.Outcome{}, err
+			}
+			latest, err := r.LatestRecord(ctx, p.ID)
+			if errors.Is(err, ErrRecordNotFound) {
+				return bulk.Failed(ErrNoRecordToReprice), nil
+			}
+			if err != nil {
+				return bulk.Outcome{}, err
+			}
+			change.PurchasePrice, change.OldSalePrice, change.Currency = latest.PurchasePrice, latest.SalePrice, latest.Currency
+			if change.NewSalePrice, err = u.Rule.apply(latest.PurchasePrice, latest.SalePrice, latest.Currency); err != nil {
+				return bulk.Failed(err), nil
+			}
+			switch {
+			case u.LastUpdate < latest.LastUpdate:
+				return bulk.Failed(ErrRecordPredatesLatest), nil
+			case change.NewSalePrice <= 0:
+				return bulk.Failed(ErrInvalidSalePrice), nil
+			}
+
+			id, err := r.CreateProductRecord(ctx, domain.ProductRecordCreate{
+				LastUpdate:    u.LastUpdate,
+				PurchasePrice: change.PurchasePrice,
+				SalePrice:     change.NewSalePrice,
+				Currency:      change.Currency,
+				ProductID:     p.ID,
+			})
+			return bulk.Inserted(id), err
+		})
+		for i, o := range outcomes {
+			changes[i].Err = o.Err
+			if o.Err == nil && !u.DryRun {
+				changes[i].RecordID = o.ID
+			}
+		}
+		return err
+	})
+	if errors.Is(err, bulk.ErrRollback) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// selectProducts returns the products matching sel, sorted by id.
+func (s *service) selectProducts(ctx context.Context, sel PriceSelector) ([]domain.Product, error) {
+	if sel.SellerID == 0 && sel.ProductTypeID == 0 && len(sel.ProductIDs) == 0 {
+		return nil, ErrEmptySelector
+	}
+	ids := make(map[int]bool, len(sel.ProductIDs))
+	for _, id := range sel.ProductIDs {
+		ids[id] = true
+	}
+
+	all, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var selected []domain.Product
+	for _, p := range all {
+		if (sel.SellerID == 0 || p.SellerID == sel.SellerID) &&
+			(sel.ProductTypeID == 0 || p.ProductTypeID == sel.ProductTypeID) &&
+			(len(ids) == 0 || ids[p.ID]) {
+			selected = append(selected, p)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, ErrNoProductsSelected
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].ID < selected[j].ID })
+	return selected, nil
+}
+
+func (r PriceRule) validate() error {
+	switch r.Type {
+	case PriceRuleAbsolute, PriceRulePercentage:
+		return nil
+	case PriceRuleMargin:
+		// The margin is a share of the sale price
+		if r.Value >= 0 && r.Value < hundred {
+			return nil
+		}
+	}
+	return ErrInvalidPriceRule
+}
+
+// hundred is 100 percent.
+var hundred = money.FromInt(100)
+
+// apply returns the sale price of the rule for the prices of a record,
+// rounded to the minor units of its currency, or money.ErrOutOfRange if it
+// does not fit an amount.
+func (r PriceRule) apply(purchase, sale money.Amount, currency money.Currency) (money.Amount, error) {
+	var (
+		price money.Amount
+		err   error
+	)
+	switch r.Type {
+	case PriceRuleAbsolute:
+		price = r.Value
+	case PriceRulePercentage:
+		price, err = sale.MulDiv(hundred+r.Value, hundred)
+	case PriceRuleMargin:
+		price, err = purchase.MulDiv(hundred, hundred-r.Value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return price.Round(currency.MinorUnits())
+}
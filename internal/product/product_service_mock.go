@@ -2,8 +2,11 @@ package product
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/davidop97/apiGo/pkg/money"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -23,6 +26,11 @@ func (m *ServiceMock) GetAll(ctx context.Context) ([]domain.Product, error) {
 	return args.Get(0).([]domain.Product), args.Error(1)
 }
 
+func (m *ServiceMock) GetBySeller(ctx context.Context, sellerID int) ([]domain.Product, error) {
+	args := m.Called(ctx, sellerID)
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
+
 func (m *ServiceMock) Save(ctx context.Context, product domain.Product) (int, error) {
 	args := m.Called(ctx, product)
 	return args.Get(0).(int), args.Error(1)
@@ -48,7 +56,52 @@ func (m *ServiceMock) CreateProductRecord(ctx context.Context, p domain.ProductR
 	return args.Get(0).(int), args.Error(1)
 }
 
+func (m *ServiceMock) EachProductRecord(ctx context.Context, idProduct int, fn func(domain.ProductRecordGet) error) error {
+	args := m.Called(ctx, idProduct)
+	for _, p := range args.Get(0).([]domain.ProductRecordGet) {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *ServiceMock) GetProductRecord(ctx context.Context, idProduct int) ([]domain.ProductRecordGet, error) {
 	args := m.Called(ctx, idProduct)
 	return args.Get(0).([]domain.ProductRecordGet), args.Error(1)
 }
+
+func (m *ServiceMock) ProductRecords(ctx context.Context, idProduct int, f domain.ProductRecordFilter) ([]domain.ProductRecordHistory, error) {
+	args := m.Called(ctx, idProduct, f)
+	return args.Get(0).([]domain.ProductRecordHistory), args.Error(1)
+}
+
+func (m *ServiceMock) PriceAsOf(ctx context.Context, idProduct int, date string, currency money.Currency) (domain.ProductRecordHistory, error) {
+	args := m.Called(ctx, idProduct, date, currency)
+	return args.Get(0).(domain.ProductRecordHistory), args.Error(1)
+}
+
+func (m *ServiceMock) UpdatePrices(ctx context.Context, u PriceUpdate) ([]PriceChange, error) {
+	args := m.Called(ctx, u)
+	return args.Get(0).([]PriceChange), args.Error(1)
+}
+
+func (m *ServiceMock) Import(ctx context.Context, ps []domain.Product, opts bulk.Options) ([]bulk.Outcome, error) {
+	args := m.Called(ctx, ps, opts)
+	return args.Get(0).([]bulk.Outcome), args.Error(1)
+}
+
+func (m *ServiceMock) Restore(ctx context.Context, id int) (domain.Product, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Product), args.Error(1)
+}
+
+func (m *ServiceMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) Search(ctx context.Context, q SearchQuery) ([]SearchResult, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).([]SearchResult), args.Error(1)
+}
@@ -0,0 +1,417 @@
+// Package producttest provides a contract test suite for product.Repository.
+// Every implementation of the interface should pass it.
+package producttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewProduct returns a valid product with the given code.
+func NewProduct(code string) domain.Product {
+	return domain.Product{
+		Description:    "Fresh Milk",
+		ExpirationRate: 0.1,
+		FreezingRate:   0.05,
+		Height:         25,
+		Length:         10,
+		Netweight:      1,
+		ProductCode:    code,
+		RecomFreezTemp: -4,
+		Width:          10,
+		ProductTypeID:  1,
+		SellerID:       1,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, whose storage has the product types 1 and 2, each time it is
+// called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) product.Repository) {
+	ctx := context.Background()
+
+	t.Run("it should save a product and read it back", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		p := NewProduct("MILK1001")
+
+		// Act
+		id, err := repo.Save(ctx, p)
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		p.ID = id
+		assert.Equal(t, p, obtained)
+	})
+
+	t.Run("it should return every saved product", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		_, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewProduct("PEAS2002"))
+		require.NoError(t, err)
+
+		// Act
+		obtained, err := repo.GetAll(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, obtained, 2)
+	})
+
+	t.Run("it should return an error when the product does not exist", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.Get(ctx, 1)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it should report whether a product code is taken", func(t *testing.T) {
+		repo := newRepository(t)
+		_, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+
+		assert.True(t, repo.Exists(ctx, "MILK1001"))
+		assert.False(t, repo.Exists(ctx, "PEAS2002"))
+	})
+
+	t.Run("it should update every field of a product", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+		updated := domain.Product{
+			ID:             id,
+			Description:    "Almond Milk",
+			ExpirationRate: 0.08,
+			FreezingRate:   0.04,
+			Height:         20,
+			Length:         12,
+			Netweight:      2,
+			ProductCode:    "AMLK0010",
+			RecomFreezTemp: -2,
+			Width:          11,
+			ProductTypeID:  2,
+			SellerID:       3,
+		}
+
+		// Act
+		err = repo.Update(ctx, updated)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, updated, obtained)
+	})
+
+	t.Run("it should reject a product whose type does not exist", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		p := NewProduct("MILK1001")
+		p.ProductTypeID = 99
+		id, err := repo.Save(ctx, NewProduct("PEAS2002"))
+		require.NoError(t, err)
+		update := NewProduct("PEAS2002")
+		update.ID, update.ProductTypeID = id, 99
+
+		// Act
+		_, errSave := repo.Save(ctx, p)
+		errUpdate := repo.Update(ctx, update)
+
+		// Assert
+		assert.True(t, errors.Is(errSave, product.ErrProductTypeNotFound))
+		assert.True(t, errors.Is(errUpdate, product.ErrProductTypeNotFound))
+		assert.False(t, repo.Exists(ctx, "MILK1001"))
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, 1, obtained.ProductTypeID)
+	})
+
+	t.Run("it should delete a product", func(t *testing.T) {
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, id)
+
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, id)
+		assert.Error(t, err)
+	})
+
+	t.Run("it should hide a deleted product unless deleted products are included", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewProduct("PEAS2002"))
+		require.NoError(t, err)
+
+		// Act
+		err = repo.Delete(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		all, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+		assert.False(t, repo.Exists(ctx, "MILK1001"))
+		_, err = repo.GetByCode(ctx, "MILK1001")
+		assert.True(t, errors.Is(err, product.ErrNotFound))
+
+		withDeleted := softdelete.WithDeleted(ctx)
+		all, err = repo.GetAll(withDeleted)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+		obtained, err := repo.Get(withDeleted, id)
+		require.NoError(t, err)
+		assert.NotNil(t, obtained.DeletedAt)
+		assert.True(t, errors.Is(repo.Delete(ctx, id), product.ErrNotFound))
+	})
+
+	t.Run("it should keep the records of a deleted product and restore them with it", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+		_, err = repo.CreateProductRecord(ctx, domain.ProductRecordCreate{LastUpdate: "2023-01-01", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(15), ProductID: id})
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, id))
+		hidden, err := repo.GetProductRecord(ctx, id)
+		require.NoError(t, err)
+
+		// Act
+		err = repo.Restore(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, hidden)
+		restored, err := repo.GetProductRecord(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ProductRecordGet{{ProductID: id, Description: "Fresh Milk", RecordCount: 1}}, restored)
+		assert.True(t, errors.Is(repo.Restore(ctx, id), product.ErrNotFound))
+	})
+
+	t.Run("it should purge the products deleted before a time", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		deleted, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewProduct("PEAS2002"))
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, deleted))
+
+		// Act
+		early, errEarly := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		purged, err := repo.Purge(ctx, time.Now().Add(time.Hour))
+
+		// Assert
+		require.NoError(t, errEarly)
+		require.NoError(t, err)
+		assert.Equal(t, 0, early)
+		assert.Equal(t, 1, purged)
+		all, err := repo.GetAll(softdelete.WithDeleted(ctx))
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("it should return ErrNotFound when deleting a missing product", func(t *testing.T) {
+		repo := newRepository(t)
+
+		err := repo.Delete(ctx, 1)
+
+		assert.True(t, errors.Is(err, product.ErrNotFound))
+	})
+
+	t.Run("it should create a record for an existing product", func(t *testing.T) {
+		repo := newRepository(t)
+		id, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+
+		recordID, err := repo.CreateProductRecord(ctx, domain.ProductRecordCreate{LastUpdate: "2023-01-01", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(15), ProductID: id})
+
+		require.NoError(t, err)
+		assert.Positive(t, recordID)
+	})
+
+	t.Run("it should reject a record whose product does not exist", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.CreateProductRecord(ctx, domain.ProductRecordCreate{LastUpdate: "2023-01-01", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(15), ProductID: 99})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it should count records per product", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		milk, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+		peas, err := repo.Save(ctx, NewProduct("PEAS2002"))
+		require.NoError(t, err)
+		for _, date := range []string{"2023-01-01", "2023-02-01"} {
+			_, err = repo.CreateProductRecord(ctx, domain.ProductRecordCreate{LastUpdate: date, PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(15), ProductID: milk})
+			require.NoError(t, err)
+		}
+
+		// Act
+		all, err := repo.GetProductRecord(ctx, 0)
+		require.NoError(t, err)
+		one, err := repo.GetProductRecord(ctx, milk)
+		require.NoError(t, err)
+
+		// Assert
+		assert.ElementsMatch(t, []domain.ProductRecordGet{
+			{ProductID: milk, Description: "Fresh Milk", RecordCount: 2},
+			{ProductID: peas, Description: "Fresh Milk", RecordCount: 0},
+		}, all)
+		assert.Equal(t, []domain.ProductRecordGet{{ProductID: milk, Description: "Fresh Milk", RecordCount: 2}}, one)
+	})
+
+	t.Run("it should return the records of a product within dates in date order", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		milk, err := repo.Save(ctx, NewProduct("MILK1001"))
+		require.NoError(t, err)
+		peas, err := repo.Save(ctx, NewProduct("PEAS2002"))
+		require.NoError(t, err)
+		purchase, err := money.Parse("10.1234")
+		require.NoError(t, err)
+		sale, err := money.Parse("15.5")
+		require.NoError(t, err)
+		var ids []int
+		for _, date := range []string{"2023-03-01", "2023-01-01", "2023-02-01"} {
+			id, err := repo.CreateProductRecord(ctx, domain.ProductRecordCreate{LastUpdate: date, PurchasePrice: purchase, SalePrice: sale, Currency: "EUR", ProductID: milk})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		// Act
+		all, err := repo.ProductRecords(ctx, milk, domain.ProductRecordFilter{})
+		require.NoError(t, err)
+		within, err := repo.ProductRecords(ctx, milk, domain.ProductRecordFilter{From: "2023-01-15", To: "2023-02-01"})
+		require.NoError(t, err)
+		none, err := repo.ProductRecords(ctx, peas, domain.ProductRecordFilter{})
+		require.NoError(t, err)
+		latest, err := repo.LatestRecordDate(ctx, milk)
+		require.NoError(t, err)
+		noLatest, err := repo.LatestRecordDate(ctx, peas)
+		require.NoError(t, err)
+		latestRecord, err := repo.LatestRecord(ctx, milk)
+		require.NoError(t, err)
+		_, errNoRecord := repo.LatestRecord(ctx, peas)
+
+		// Assert
+		assert.Equal(t, []int{ids[1], ids[2], ids[0]}, []int{all[0].ID, all[1].ID, all[2].ID})
+		assert.Equal(t, []domain.ProductRecord{
+			{ID: ids[2], LastUpdate: "2023-02-01", PurchasePrice: purchase, SalePrice: sale, Currency: "EUR", ProductID: milk},
+		}, within)
+		assert.Empty(t, none)
+		assert.Equal(t, "2023-03-01", latest)
+		assert.Equal(t, "", noLatest)
+		assert.Equal(t, all[2], latestRecord)
+		assert.ErrorIs(t, errNoRecord, product.ErrRecordNotFound)
+	})
+
+	t.Run("it should pass the record count of every product to fn and stop at its first error", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		for _, code := range []string{"MILK1001", "PEAS2002"} {
+			_, err := repo.Save(ctx, NewProduct(code))
+			require.NoError(t, err)
+		}
+		stop := errors.New("stop")
+
+		// Act
+		var all []domain.ProductRecordGet
+		err := repo.EachProductRecord(ctx, 0, func(p domain.ProductRecordGet) error {
+			all = append(all, p)
+			return nil
+		})
+		require.NoError(t, err)
+		calls := 0
+		errStopped := repo.EachProductRecord(ctx, 0, func(p domain.ProductRecordGet) error {
+			calls++
+			return stop
+		})
+
+		// Assert
+		assert.Len(t, all, 2)
+		assert.Equal(t, 1, calls)
+		assert.True(t, errors.Is(errStopped, stop))
+	})
+
+	t.Run("it should find a product by its code", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		p := NewProduct("MILK1001")
+		id, err := repo.Save(ctx, p)
+		require.NoError(t, err)
+
+		// Act
+		obtained, err := repo.GetByCode(ctx, "MILK1001")
+		_, errMissing := repo.GetByCode(ctx, "PEAS2002")
+
+		// Assert
+		require.NoError(t, err)
+		p.ID = id
+		assert.Equal(t, p, obtained)
+		assert.True(t, errors.Is(errMissing, product.ErrNotFound))
+	})
+
+	t.Run("it should lock a product in a transaction", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		p := NewProduct("MILK1001")
+		id, err := repo.Save(ctx, p)
+		require.NoError(t, err)
+
+		// Act
+		var obtained domain.Product
+		var errMissing error
+		err = repo.InTx(ctx, func(tx product.Repository) error {
+			var err error
+			obtained, err = tx.GetForUpdate(ctx, id)
+			_, errMissing = tx.GetForUpdate(ctx, id+1)
+			return err
+		})
+
+		// Assert
+		require.NoError(t, err)
+		p.ID = id
+		assert.Equal(t, p, obtained)
+		assert.True(t, errors.Is(errMissing, product.ErrNotFound))
+	})
+
+	t.Run("it should keep the writes of a committed transaction", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+
+		// Act
+		err := repo.InTx(ctx, func(tx product.Repository) error {
+			if _, err := tx.Save(ctx, NewProduct("MILK1001")); err != nil {
+				return err
+			}
+			_, err := tx.GetByCode(ctx, "MILK1001")
+			return err
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, repo.Exists(ctx, "MILK1001"))
+	})
+}
@@ -3,35 +3,90 @@ package product
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/davidop97/apiGo/pkg/softdelete"
 )
 
 // Repository encapsulates the storage of a Product.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Product, error)
 	Get(ctx context.Context, id int) (domain.Product, error)
+	// GetForUpdate returns a product, or ErrNotFound, and locks its row until
+	// the transaction of InTx ends, so that the writes checked against the
+	// product, such as its records, run one at a time.
+	GetForUpdate(ctx context.Context, id int) (domain.Product, error)
 	Exists(ctx context.Context, productCode string) bool
 	Save(ctx context.Context, p domain.Product) (int, error)
 	Update(ctx context.Context, p domain.Product) error
+	// Delete soft deletes a product, which reads skip unless their context
+	// includes deleted rows (see softdelete.WithDeleted).
 	Delete(ctx context.Context, id int) error
+	// Restore undoes the deletion of a product.
+	Restore(ctx context.Context, id int) error
+	// Purge removes for good the products deleted before the given time.
+	Purge(ctx context.Context, before time.Time) (int, error)
 	CreateProductRecord(ctx context.Context, p domain.ProductRecordCreate) (int, error)
 	GetProductRecord(ctx context.Context, idProduct int) ([]domain.ProductRecordGet, error)
+	// EachProductRecord calls fn with the record count of every product, or
+	// of idProduct when it is not 0, one row at a time, and stops at the first
+	// error fn returns.
+	EachProductRecord(ctx context.Context, idProduct int, fn func(domain.ProductRecordGet) error) error
+	// ProductRecords returns the records of idProduct within f, the oldest
+	// first.
+	ProductRecords(ctx context.Context, idProduct int, f domain.ProductRecordFilter) ([]domain.ProductRecord, error)
+	// LatestRecordDate returns the last_update_date of the latest record of
+	// idProduct, or "" when it has none.
+	LatestRecordDate(ctx context.Context, idProduct int) (string, error)
+	// LatestRecord returns the latest record of idProduct, the one
+	// ProductRecords lists last, or ErrRecordNotFound when it has none.
+	LatestRecord(ctx context.Context, idProduct int) (domain.ProductRecord, error)
+	GetByCode(ctx context.Context, productCode string) (domain.Product, error)
+	// InTx calls fn with a repository whose queries run in one transaction,
+	// committed only if fn returns nil.
+	InTx(ctx context.Context, fn func(r Repository) error) error
+}
+
+// ProductTypeGetter gets a product type, e.g. through the cached product type
+// repository.
+type ProductTypeGetter interface {
+	Get(ctx context.Context, id int) (domain.ProductType, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db dbtx.DB
+	// types checks the type of the products saved. It is queried directly
+	// when nil.
+	types ProductTypeGetter
 }
 
+// productColumns are the columns of the products table, in the order they are scanned.
+const productColumns = "id, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, id_product_type, id_seller, deleted_at"
+
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
 		db: db,
 	}
 }
 
+// NewRepositoryWithLookups returns a repository checking that the type of the
+// products it saves exists through types, which may be cached, instead of
+// querying it.
+func NewRepositoryWithLookups(db *sql.DB, types ProductTypeGetter) Repository {
+	return &repository{
+		db:    db,
+		types: types,
+	}
+}
+
 func (r *repository) GetAll(ctx context.Context) ([]domain.Product, error) {
-	query := "SELECT * FROM products;"
-	rows, err := r.db.Query(query)
+	query := "SELECT " + productColumns + " FROM products WHERE " + softdelete.Visible(ctx, "deleted_at")
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +95,7 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Product, error) {
 
 	for rows.Next() {
 		p := domain.Product{}
-		_ = rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+		_ = rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, softdelete.Scan(&p.DeletedAt))
 		products = append(products, p)
 	}
 
@@ -48,10 +103,10 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Product, error) {
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
-	query := "SELECT * FROM products WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT " + productColumns + " FROM products WHERE id=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, id)
 	p := domain.Product{}
-	err := row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+	err := row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, softdelete.Scan(&p.DeletedAt))
 	if err != nil {
 		return domain.Product{}, err
 	}
@@ -60,14 +115,60 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
 }
 
 func (r *repository) Exists(ctx context.Context, productCode string) bool {
-	query := "SELECT product_code FROM products WHERE product_code=?;"
-	row := r.db.QueryRow(query, productCode)
+	query := "SELECT product_code FROM products WHERE product_code=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, productCode)
 	err := row.Scan(&productCode)
 	return err == nil
 }
 
+// GetByCode returns the product with the given product_code, or ErrNotFound.
+func (r *repository) GetByCode(ctx context.Context, productCode string) (domain.Product, error) {
+	query := "SELECT " + productColumns + " FROM products WHERE product_code=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, productCode)
+	p := domain.Product{}
+	err := row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, softdelete.Scan(&p.DeletedAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Product{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return p, nil
+}
+
+// GetForUpdate returns the product with the given id, or ErrNotFound, locking
+// its row.
+func (r *repository) GetForUpdate(ctx context.Context, id int) (domain.Product, error) {
+	query := "SELECT " + productColumns + " FROM products WHERE id=? AND " + softdelete.Visible(ctx, "deleted_at") + " FOR UPDATE"
+	row := r.db.QueryRowContext(ctx, query, id)
+	p := domain.Product{}
+	err := row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, softdelete.Scan(&p.DeletedAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Product{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return p, nil
+}
+
+// InTx runs fn on a copy of the repository bound to a transaction.
+func (r *repository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return dbtx.Run(ctx, r.db, func(tx dbtx.DB) error {
+		return fn(&repository{db: tx, types: r.types})
+	})
+}
+
 func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
-	query := "INSERT INTO products(description,expiration_rate,freezing_rate,height,lenght,netweight,product_code,recommended_freezing_temperature,width,id_product_type,id_seller) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
+	if exists, err := r.productTypeExists(ctx, p.ProductTypeID); err != nil {
+		return 0, err
+	} else if !exists {
+		return 0, ErrProductTypeNotFound
+	}
+
+	query := "INSERT INTO products(description,expiration_rate,freezing_rate,height,length,netweight,product_code,recommended_freezing_temperature,width,id_product_type,id_seller) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return 0, err
@@ -87,7 +188,13 @@ func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, p domain.Product) error {
-	query := "UPDATE products SET description=?, expiration_rate=?, freezing_rate=?, height=?, lenght=?, netweight=?, product_code=?, recommended_freezing_temperature=?, width=?, id_product_type=?, id_seller=?  WHERE id=?"
+	if exists, err := r.productTypeExists(ctx, p.ProductTypeID); err != nil {
+		return err
+	} else if !exists {
+		return ErrProductTypeNotFound
+	}
+
+	query := "UPDATE products SET description=?, expiration_rate=?, freezing_rate=?, height=?, length=?, netweight=?, product_code=?, recommended_freezing_temperature=?, width=?, id_product_type=?, id_seller=?  WHERE id=? AND deleted_at IS NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
@@ -106,14 +213,56 @@ func (r *repository) Update(ctx context.Context, p domain.Product) error {
 	return nil
 }
 
+// productTypeExists checks that a product type id exists. It returns the
+// error of a product type that cannot be read.
+func (r *repository) productTypeExists(ctx context.Context, id int) (bool, error) {
+	var err error
+	if r.types != nil {
+		_, err = r.types.Get(ctx, id)
+	} else {
+		query := "SELECT id FROM product_types WHERE id=?"
+		err = r.db.QueryRowContext(ctx, query, id).Scan(&id)
+	}
+	switch {
+	case errors.Is(err, producttype.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete soft deletes a product: it is hidden from the reads, and its records
+// are kept, until it is restored or purged.
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM products WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE products SET deleted_at=? WHERE id=? AND deleted_at IS NULL"
+	return r.exec(ctx, query, softdelete.Format(time.Now()), id)
+}
+
+// Restore a soft deleted product. It returns ErrNotFound if there is no deleted
+// product with that id.
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE products SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	return r.exec(ctx, query, id)
+}
+
+// Purge removes for good the products deleted before the given time, along
+// with their records. Products that still have batches are kept.
+func (r *repository) Purge(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM products WHERE deleted_at < ?
+		AND id NOT IN (SELECT product_id FROM productBatches)`
+	res, err := r.db.ExecContext(ctx, query, softdelete.Format(before))
 	if err != nil {
-		return err
+		return 0, err
 	}
+	affect, err := res.RowsAffected()
+	return int(affect), err
+}
 
-	res, err := stmt.Exec(id)
+// exec runs a statement changing one product. It returns ErrNotFound if no
+// row was changed.
+func (r *repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -138,13 +287,18 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 // If the SQL statement execution is successful, it retrieves the ID of the last inserted record.
 // The function returns the ID of the created product record and an error if there is any.
 func (r *repository) CreateProductRecord(ctx context.Context, p domain.ProductRecordCreate) (int, error) {
-	query := "INSERT INTO productsRecord(last_update_date,purchase_price,sale_price,product_id) VALUES (STR_TO_DATE(?,'%Y-%m-%d'),?,?,?)"
+	query := "INSERT INTO productsRecord(last_update_date,purchase_price,sale_price,currency,product_id) VALUES (STR_TO_DATE(?,'%Y-%m-%d'),?,?,?,?)"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(p.LastUpdate, p.PurchasePrice, p.SalePrice, p.ProductID)
+	// Records without a currency are in the default one
+	currency := p.Currency
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	res, err := stmt.Exec(p.LastUpdate, p.PurchasePrice, p.SalePrice, currency, p.ProductID)
 	if err != nil {
 		return 0, err
 	}
@@ -159,11 +313,27 @@ func (r *repository) CreateProductRecord(ctx context.Context, p domain.ProductRe
 
 // GetProductRecord retrieves product records by product ID from the database.
 // If idProduct is 0, it retrieves all product records.
-// The function prepares an SQL statement for retrieving the product records from the database.
-// It then executes the SQL statement with the product ID as a parameter.
-// If the SQL statement execution is successful, it scans the result into a slice of ProductRecordGet structs.
+// The function collects the rows of EachProductRecord into a slice.
 // The function returns a slice of ProductRecordGet structs and an error if there is any.
 func (r *repository) GetProductRecord(ctx context.Context, idProduct int) ([]domain.ProductRecordGet, error) {
+	var products []domain.ProductRecordGet
+	err := r.EachProductRecord(ctx, idProduct, func(p domain.ProductRecordGet) error {
+		products = append(products, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// EachProductRecord retrieves product records by product ID from the database.
+// If idProduct is 0, it retrieves all product records.
+// The function prepares an SQL statement for retrieving the product records from the database.
+// It then executes the SQL statement with the product ID as a parameter.
+// Each row is scanned into a ProductRecordGet struct and passed to fn as soon as it is read.
+// The function returns the first error of the query, the scan or fn.
+func (r *repository) EachProductRecord(ctx context.Context, idProduct int, fn func(domain.ProductRecordGet) error) error {
 	var query string
 	var args []interface{}
 
@@ -176,9 +346,11 @@ func (r *repository) GetProductRecord(ctx context.Context, idProduct int) ([]dom
 		LEFT JOIN productsRecord AS pr
 			ON p.id = pr.product_id
 	`
-	// If idProduct is not 0, add a WHERE clause to the SQL statement
+	// Deleted products are only reported when ctx includes them
+	query += " WHERE " + softdelete.Visible(ctx, "p.deleted_at")
+	// If idProduct is not 0, add a condition on it to the SQL statement
 	if idProduct != 0 {
-		query += " WHERE p.id = ?"
+		query += " AND p.id = ?"
 		args = append(args, idProduct)
 	}
 
@@ -187,23 +359,78 @@ func (r *repository) GetProductRecord(ctx context.Context, idProduct int) ([]dom
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	var products []domain.ProductRecordGet
 	for rows.Next() {
 		var p domain.ProductRecordGet
 		// Scan the result into a ProductRecordGet struct
 		if err := rows.Scan(&p.ProductID, &p.Description, &p.RecordCount); err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(p); err != nil {
+			return err
 		}
-		products = append(products, p)
 	}
 
-	if err := rows.Err(); err != nil {
+	return rows.Err()
+}
+
+// ProductRecords retrieves the records of a product whose last_update_date is
+// within f, ordered by date and then by id, the order they were recorded in.
+func (r *repository) ProductRecords(ctx context.Context, idProduct int, f domain.ProductRecordFilter) ([]domain.ProductRecord, error) {
+	query := "SELECT id, DATE_FORMAT(last_update_date, '%Y-%m-%d'), purchase_price, sale_price, currency, product_id FROM productsRecord WHERE product_id = ?"
+	args := []interface{}{idProduct}
+	if f.From != "" {
+		query += " AND last_update_date >= STR_TO_DATE(?,'%Y-%m-%d')"
+		args = append(args, f.From)
+	}
+	if f.To != "" {
+		query += " AND last_update_date <= STR_TO_DATE(?,'%Y-%m-%d')"
+		args = append(args, f.To)
+	}
+	query += " ORDER BY last_update_date, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return products, nil
+	var records []domain.ProductRecord
+	for rows.Next() {
+		var pr domain.ProductRecord
+		if err := rows.Scan(&pr.ID, &pr.LastUpdate, &pr.PurchasePrice, &pr.SalePrice, &pr.Currency, &pr.ProductID); err != nil {
+			return nil, err
+		}
+		records = append(records, pr)
+	}
+	return records, rows.Err()
+}
+
+// LatestRecord retrieves the latest record of a product, reading that record
+// only.
+func (r *repository) LatestRecord(ctx context.Context, idProduct int) (domain.ProductRecord, error) {
+	query := "SELECT id, DATE_FORMAT(last_update_date, '%Y-%m-%d'), purchase_price, sale_price, currency, product_id FROM productsRecord WHERE product_id = ? ORDER BY last_update_date DESC, id DESC LIMIT 1"
+	var pr domain.ProductRecord
+	err := r.db.QueryRowContext(ctx, query, idProduct).Scan(&pr.ID, &pr.LastUpdate, &pr.PurchasePrice, &pr.SalePrice, &pr.Currency, &pr.ProductID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ProductRecord{}, ErrRecordNotFound
+	}
+	if err != nil {
+		return domain.ProductRecord{}, err
+	}
+	return pr, nil
+}
+
+// LatestRecordDate retrieves the last_update_date of the latest record of a
+// product, or "" when it has none.
+func (r *repository) LatestRecordDate(ctx context.Context, idProduct int) (string, error) {
+	var date sql.NullString
+	err := r.db.QueryRowContext(ctx, "SELECT DATE_FORMAT(MAX(last_update_date), '%Y-%m-%d') FROM productsRecord WHERE product_id = ?", idProduct).Scan(&date)
+	if err != nil {
+		return "", err
+	}
+	return date.String, nil
 }
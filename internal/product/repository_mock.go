@@ -2,6 +2,7 @@ package product
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/stretchr/testify/mock"
@@ -41,12 +42,65 @@ func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (r *RepositoryMock) Restore(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := r.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
 func (r *RepositoryMock) CreateProductRecord(ctx context.Context, p domain.ProductRecordCreate) (int, error) {
 	args := r.Called(ctx, p)
 	return args.Int(0), args.Error(1)
 }
 
+func (r *RepositoryMock) EachProductRecord(ctx context.Context, idProduct int, fn func(domain.ProductRecordGet) error) error {
+	args := r.Called(ctx, idProduct)
+	for _, p := range args.Get(0).([]domain.ProductRecordGet) {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (r *RepositoryMock) GetProductRecord(ctx context.Context, idProduct int) ([]domain.ProductRecordGet, error) {
 	args := r.Called(ctx, idProduct)
 	return args.Get(0).([]domain.ProductRecordGet), args.Error(1)
 }
+
+func (r *RepositoryMock) ProductRecords(ctx context.Context, idProduct int, f domain.ProductRecordFilter) ([]domain.ProductRecord, error) {
+	args := r.Called(ctx, idProduct, f)
+	return args.Get(0).([]domain.ProductRecord), args.Error(1)
+}
+
+func (r *RepositoryMock) LatestRecordDate(ctx context.Context, idProduct int) (string, error) {
+	args := r.Called(ctx, idProduct)
+	return args.String(0), args.Error(1)
+}
+
+func (r *RepositoryMock) LatestRecord(ctx context.Context, idProduct int) (domain.ProductRecord, error) {
+	args := r.Called(ctx, idProduct)
+	return args.Get(0).(domain.ProductRecord), args.Error(1)
+}
+
+func (r *RepositoryMock) GetForUpdate(ctx context.Context, id int) (domain.Product, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.Product), args.Error(1)
+}
+
+func (r *RepositoryMock) GetByCode(ctx context.Context, productCode string) (domain.Product, error) {
+	args := r.Called(ctx, productCode)
+	return args.Get(0).(domain.Product), args.Error(1)
+}
+
+// InTx runs fn on the mock itself unless an error is set for the call.
+func (r *RepositoryMock) InTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := r.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(r)
+}
@@ -0,0 +1,42 @@
+package product_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/product/producttest"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/producttype/producttypetest"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	producttest.TestRepository(t, func(t *testing.T) product.Repository {
+		return product.NewRepository(openWithTypes(t))
+	})
+}
+
+func TestRepository_MySQLCached(t *testing.T) {
+	producttest.TestRepository(t, func(t *testing.T) product.Repository {
+		return product.NewCachedRepository(product.NewRepository(openWithTypes(t)), cache.NewLRU(100), time.Minute)
+	})
+}
+
+func TestRepository_MySQLCachedLookups(t *testing.T) {
+	producttest.TestRepository(t, func(t *testing.T) product.Repository {
+		db := openWithTypes(t)
+		types := producttype.NewCachedRepository(producttype.NewRepository(db), cache.NewLRU(100), time.Minute)
+		return product.NewRepositoryWithLookups(db, types)
+	})
+}
+
+// openWithTypes returns a database with the product types the contract suite
+// references.
+func openWithTypes(t *testing.T) *sql.DB {
+	db := mysqltest.Open(t)
+	producttypetest.AddProductTypes(t, producttype.NewRepository(db), 2)
+	return db
+}
@@ -0,0 +1,139 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/search"
+)
+
+// ErrEmptyQuery is returned by a search without words.
+var ErrEmptyQuery = errors.New("search query has no words")
+
+// DefaultSearchLimit is how many products a search returns when no limit is
+// given, and MaxSearchLimit the most it returns.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// searchMaxAge is how long the search index is used before it is built
+// again, so the writes of other processes are found too.
+const searchMaxAge = time.Minute
+
+// The product_code weighs more than the description: a match there is
+// usually the product looked for.
+var searchWeights = []float64{1, 2}
+
+// SearchQuery is a search of the products.
+type SearchQuery struct {
+	// Text is matched against the description and the product_code.
+	Text string
+	// SellerID and ProductTypeID select the products of a seller and of a
+	// product type when not zero.
+	SellerID      int
+	ProductTypeID int
+	// Limit is the number of products returned, DefaultSearchLimit when
+	// zero.
+	Limit int
+}
+
+// SearchResult is a product found by a search, with its relevance.
+type SearchResult struct {
+	domain.Product
+	Score float64 `json:"score"`
+}
+
+// searchIndex is the index of the products, built from the repository on the
+// first search after it is invalidated or grows old.
+type searchIndex struct {
+	mu       sync.Mutex
+	index    *search.Index
+	products map[int]domain.Product
+	builtAt  time.Time
+	// generation counts the invalidations, so an index built from products
+	// read before one is not used after it.
+	generation int
+	built      int
+	now        func() time.Time
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{built: -1, now: time.Now}
+}
+
+// invalidate makes the next search build the index again.
+func (ix *searchIndex) invalidate() {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.generation++
+}
+
+// get returns the index and the products it indexes, built from repo when
+// it is stale.
+func (ix *searchIndex) get(ctx context.Context, repo Repository) (*search.Index, map[int]domain.Product, error) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if ix.built == ix.generation && ix.now().Sub(ix.builtAt) < searchMaxAge {
+		return ix.index, ix.products, nil
+	}
+
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	products := make(map[int]domain.Product, len(all))
+	docs := make([]search.Document, 0, len(all))
+	for _, p := range all {
+		products[p.ID] = p
+		docs = append(docs, search.Document{ID: p.ID, Fields: []string{p.Description, p.ProductCode + " " + compact(p.ProductCode)}})
+	}
+	ix.index, ix.products = search.Build(docs, searchWeights...), products
+	ix.built, ix.builtAt = ix.generation, ix.now()
+	return ix.index, ix.products, nil
+}
+
+// compact returns code without its separators, so "YOG-001" is also found as
+// "yog001".
+func compact(code string) string {
+	var compacted string
+	for _, w := range search.Tokenize(code) {
+		compacted += w
+	}
+	return compacted
+}
+
+// Search returns the products matching q, the most relevant first.
+func (s *service) Search(ctx context.Context, q SearchQuery) ([]SearchResult, error) {
+	if len(search.Tokenize(q.Text)) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	index, products, err := s.index.get(ctx, s.repo)
+	if err != nil {
+		return nil, err
+	}
+	hits := index.Search(q.Text, func(id int) bool {
+		p := products[id]
+		return (q.SellerID == 0 || p.SellerID == q.SellerID) &&
+			(q.ProductTypeID == 0 || p.ProductTypeID == q.ProductTypeID)
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	results := make([]SearchResult, 0, len(hits))
+	for _, h := range hits {
+		results = append(results, SearchResult{Product: products[h.ID], Score: h.Score})
+	}
+	return results, nil
+}
@@ -3,8 +3,12 @@ package product
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/davidop97/apiGo/pkg/softdelete"
 )
 
 // Errors
@@ -12,10 +16,27 @@ var (
 	ErrNotFound          = errors.New("product not found")
 	ErrProductCodeExists = errors.New("product_code already exists")
 	ErrorSavingProduct   = errors.New("error saving product")
+	// ErrProductTypeNotFound is returned when the product type of a product
+	// does not exist.
+	ErrProductTypeNotFound = errors.New("product type not found")
+	// ErrRecordPredatesLatest is returned when a product record is dated
+	// before the latest record of its product.
+	ErrRecordPredatesLatest = errors.New("product record predates the latest record of the product")
+	// ErrRecordNotFound is returned when a product has no record on or
+	// before a date.
+	ErrRecordNotFound = errors.New("product record not found")
+	// ErrInvalidCurrency is returned for a currency that is not an ISO 4217
+	// code.
+	ErrInvalidCurrency = errors.New("invalid currency")
+	// ErrCurrencyRateNotFound is returned when prices cannot be converted
+	// because a currency has no rate.
+	ErrCurrencyRateNotFound = errors.New("currency rate not found")
 )
 
 type Service interface {
 	GetAll(ctx context.Context) ([]domain.Product, error)
+	// GetBySeller returns the products of a seller.
+	GetBySeller(ctx context.Context, sellerID int) ([]domain.Product, error)
 	Get(ctx context.Context, id int) (domain.Product, error)
 	Exists(ctx context.Context, productCode string) bool
 	Save(ctx context.Context, p domain.Product) (int, error)
@@ -23,15 +44,54 @@ type Service interface {
 	Delete(ctx context.Context, id int) error
 	CreateProductRecord(ctx context.Context, p domain.ProductRecordCreate) (int, error)
 	GetProductRecord(ctx context.Context, idProduct int) ([]domain.ProductRecordGet, error)
+	EachProductRecord(ctx context.Context, idProduct int, fn func(domain.ProductRecordGet) error) error
+	// ProductRecords returns the price history of a product within f, the
+	// oldest record first.
+	ProductRecords(ctx context.Context, idProduct int, f domain.ProductRecordFilter) ([]domain.ProductRecordHistory, error)
+	// PriceAsOf returns the record of a product in force on date, the latest
+	// one dated on or before it.
+	PriceAsOf(ctx context.Context, idProduct int, date string, currency money.Currency) (domain.ProductRecordHistory, error)
+	// UpdatePrices records new prices for a selection of products at once.
+	UpdatePrices(ctx context.Context, u PriceUpdate) ([]PriceChange, error)
+	Import(ctx context.Context, ps []domain.Product, opts bulk.Options) ([]bulk.Outcome, error)
+	// Restore undoes the deletion of a product and returns it.
+	Restore(ctx context.Context, id int) (domain.Product, error)
+	// Purge removes for good the products deleted before the given time.
+	Purge(ctx context.Context, before time.Time) (int, error)
+	// Search returns the products matching the query, the most relevant
+	// first. It returns ErrEmptyQuery when the query has no words.
+	Search(ctx context.Context, q SearchQuery) ([]SearchResult, error)
+}
+
+// RateGetter gets the rate of a currency, e.g. through the currency rate
+// service.
+type RateGetter interface {
+	Get(ctx context.Context, c money.Currency) (domain.CurrencyRate, error)
 }
 
 type service struct {
 	repo Repository
+	// index is the search index, invalidated by every write.
+	index *searchIndex
+	// rates converts the prices of the records. Only the records in
+	// money.DefaultCurrency are converted when nil.
+	rates RateGetter
 }
 
 func NewService(repo Repository) Service {
 	return &service{
-		repo: repo,
+		repo:  repo,
+		index: newSearchIndex(),
+	}
+}
+
+// NewServiceWithRates returns a Service converting the prices of the records
+// with the rates of rates.
+func NewServiceWithRates(repo Repository, rates RateGetter) Service {
+	return &service{
+		repo:  repo,
+		index: newSearchIndex(),
+		rates: rates,
 	}
 }
 
@@ -46,6 +106,21 @@ func (s *service) GetAll(ctx context.Context) ([]domain.Product, error) {
 
 }
 
+// GetBySeller returns the products of a seller, in the order of GetAll.
+func (s *service) GetBySeller(ctx context.Context, sellerID int) ([]domain.Product, error) {
+	all, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	products := []domain.Product{}
+	for _, p := range all {
+		if p.SellerID == sellerID {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
 // Get retrieves a product by its ID from the database.
 // It returns the domain.Product and an error if there is any.
 func (s *service) Get(ctx context.Context, id int) (domain.Product, error) {
@@ -71,9 +146,13 @@ func (s *service) Save(ctx context.Context, p domain.Product) (int, error) {
 	}
 
 	product, err := s.repo.Save(ctx, p)
+	if errors.Is(err, ErrProductTypeNotFound) {
+		return 0, err
+	}
 	if err != nil {
 		return 0, ErrorSavingProduct
 	}
+	s.index.invalidate()
 	return product, nil
 }
 
@@ -95,6 +174,7 @@ func (s *service) Update(ctx context.Context, p domain.Product) error {
 	if err != nil {
 		return err
 	}
+	s.index.invalidate()
 	return nil
 }
 
@@ -110,23 +190,45 @@ func (s *service) Delete(ctx context.Context, id int) error {
 	if err != nil {
 		return err
 	}
+	s.index.invalidate()
 	return nil
 }
 
 // CreateProductRecord creates a new product record in the database.
 // It returns the ID of the created product record and an error if there is any.
 func (s *service) CreateProductRecord(ctx context.Context, p domain.ProductRecordCreate) (int, error) {
-	//check if product exists in table products
-	_, err := s.repo.Get(ctx, p.ProductID)
+	// The currency is optional, and case insensitive
+	if p.Currency == "" {
+		p.Currency = money.DefaultCurrency
+	}
+	currency, err := money.ParseCurrency(string(p.Currency))
 	if err != nil {
-		return 0, ErrNotFound
+		return 0, ErrInvalidCurrency
 	}
+	p.Currency = currency
 
-	product, err := s.repo.CreateProductRecord(ctx, p)
+	// Records are kept in date order: a new one cannot predate the latest.
+	// The row of the product stays locked until the record is inserted, so
+	// that concurrent records of a product are checked one after the other.
+	var id int
+	err = s.repo.InTx(ctx, func(r Repository) error {
+		if _, err := r.GetForUpdate(ctx, p.ProductID); err != nil {
+			return err
+		}
+		latest, err := r.LatestRecordDate(ctx, p.ProductID)
+		if err != nil {
+			return err
+		}
+		if p.LastUpdate < latest {
+			return ErrRecordPredatesLatest
+		}
+		id, err = r.CreateProductRecord(ctx, p)
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
-	return product, nil
+	return id, nil
 }
 
 // GetProductRecord retrieves product records by product ID from the database.
@@ -148,3 +250,205 @@ func (s *service) GetProductRecord(ctx context.Context, idProduct int) ([]domain
 	}
 	return product, nil
 }
+
+// EachProductRecord calls fn with the product records of GetProductRecord one
+// at a time, without holding them in memory together.
+// It returns ErrNotFound if idProduct is not 0 and the product does not exist.
+func (s *service) EachProductRecord(ctx context.Context, idProduct int, fn func(domain.ProductRecordGet) error) error {
+	if idProduct != 0 {
+		if _, err := s.repo.Get(ctx, idProduct); err != nil {
+			return ErrNotFound
+		}
+	}
+	return s.repo.EachProductRecord(ctx, idProduct, fn)
+}
+
+// ProductRecords retrieves the records of a product within f, with their
+// margin, markup and price changes. The changes of the first record within f
+// are relative to the record before it, even when f leaves it out, and are
+// only computed between records in the same currency: the prices are
+// converted into f.Currency at the current rates when it is set.
+// It returns ErrNotFound if the product does not exist, ErrInvalidCurrency if
+// f.Currency is not a currency and ErrCurrencyRateNotFound if a price cannot
+// be converted.
+func (s *service) ProductRecords(ctx context.Context, idProduct int, f domain.ProductRecordFilter) ([]domain.ProductRecordHistory, error) {
+	if _, err := s.repo.Get(ctx, idProduct); err != nil {
+		return nil, ErrNotFound
+	}
+
+	if f.Currency != "" {
+		currency, err := money.ParseCurrency(string(f.Currency))
+		if err != nil {
+			return nil, ErrInvalidCurrency
+		}
+		f.Currency = currency
+	}
+
+	// The records before From are read for the changes, and dropped after
+	records, err := s.repo.ProductRecords(ctx, idProduct, domain.ProductRecordFilter{To: f.To})
+	if err != nil {
+		return nil, err
+	}
+	if f.Currency != "" {
+		if records, err = s.convert(ctx, records, f.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	history := make([]domain.ProductRecordHistory, 0, len(records))
+	for i, r := range records {
+		if r.LastUpdate < f.From {
+			continue
+		}
+		h := domain.ProductRecordHistory{
+			ProductRecord: r,
+			Margin:        money.Ratio(r.SalePrice-r.PurchasePrice, r.SalePrice),
+			Markup:        money.Ratio(r.SalePrice-r.PurchasePrice, r.PurchasePrice),
+		}
+		if i > 0 && records[i-1].Currency == r.Currency {
+			purchase := r.PurchasePrice - records[i-1].PurchasePrice
+			sale := r.SalePrice - records[i-1].SalePrice
+			h.PurchasePriceChange, h.SalePriceChange = &purchase, &sale
+		}
+		history = append(history, h)
+	}
+	return history, nil
+}
+
+// convert returns records with their prices in currency, rounded to its minor
+// units.
+func (s *service) convert(ctx context.Context, records []domain.ProductRecord, currency money.Currency) ([]domain.ProductRecord, error) {
+	to, err := s.rate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	rates := map[money.Currency]money.Rate{currency: to}
+	converted := make([]domain.ProductRecord, len(records))
+	for i, r := range records {
+		from, ok := rates[r.Currency]
+		if !ok {
+			if from, err = s.rate(ctx, r.Currency); err != nil {
+				return nil, err
+			}
+			rates[r.Currency] = from
+		}
+		if r.PurchasePrice, err = convertAmount(r.PurchasePrice, from, to, currency); err != nil {
+			return nil, err
+		}
+		if r.SalePrice, err = convertAmount(r.SalePrice, from, to, currency); err != nil {
+			return nil, err
+		}
+		r.Currency = currency
+		converted[i] = r
+	}
+	return converted, nil
+}
+
+// convertAmount converts a from the rate from to the rate to of currency,
+// rounded to its minor units.
+func convertAmount(a money.Amount, from, to money.Rate, currency money.Currency) (money.Amount, error) {
+	converted, err := money.Convert(a, from, to)
+	if err != nil {
+		return 0, err
+	}
+	return converted.Round(currency.MinorUnits())
+}
+
+// rate returns the rate of currency, or ErrCurrencyRateNotFound.
+func (s *service) rate(ctx context.Context, currency money.Currency) (money.Rate, error) {
+	if currency == money.DefaultCurrency {
+		return money.OneRate, nil
+	}
+	if s.rates == nil {
+		return 0, ErrCurrencyRateNotFound
+	}
+	rate, err := s.rates.Get(ctx, currency)
+	if err != nil || rate.Rate <= 0 {
+		return 0, ErrCurrencyRateNotFound
+	}
+	return rate.Rate, nil
+}
+
+// PriceAsOf retrieves the latest record of a product dated on or before date.
+// It returns ErrNotFound if the product does not exist and ErrRecordNotFound
+// if it has no such record.
+func (s *service) PriceAsOf(ctx context.Context, idProduct int, date string, currency money.Currency) (domain.ProductRecordHistory, error) {
+	history, err := s.ProductRecords(ctx, idProduct, domain.ProductRecordFilter{To: date, Currency: currency})
+	if err != nil {
+		return domain.ProductRecordHistory{}, err
+	}
+	if len(history) == 0 {
+		return domain.ProductRecordHistory{}, ErrRecordNotFound
+	}
+	return history[len(history)-1], nil
+}
+
+// Import saves ps in a single transaction and returns the outcome of each one.
+// A product_code that is already stored, or repeated in ps, rejects the product
+// in insert mode and updates the stored product in upsert mode. A product whose
+// type does not exist is rejected.
+// Nothing is saved when a product is rejected or opts.DryRun is set.
+func (s *service) Import(ctx context.Context, ps []domain.Product, opts bulk.Options) ([]bulk.Outcome, error) {
+	var outcomes []bulk.Outcome
+	err := s.repo.InTx(ctx, func(r Repository) error {
+		var err error
+		outcomes, err = bulk.Apply(ps, opts, func(p domain.Product) (bulk.Outcome, error) {
+			stored, err := r.GetByCode(ctx, p.ProductCode)
+			switch {
+			case errors.Is(err, ErrNotFound):
+				id, err := r.Save(ctx, p)
+				return importOutcome(bulk.Inserted(id), err)
+			case err != nil:
+				return bulk.Outcome{}, err
+			case opts.Mode != bulk.ModeUpsert:
+				return bulk.Failed(ErrProductCodeExists), nil
+			}
+			p.ID = stored.ID
+			return importOutcome(bulk.Updated(p.ID), r.Update(ctx, p))
+		})
+		return err
+	})
+	if errors.Is(err, bulk.ErrRollback) {
+		err = nil
+	}
+	s.index.invalidate()
+	return outcomes, err
+}
+
+// importOutcome returns the outcome of a row saved with err: a product whose
+// type does not exist rejects the row, any other error stops the import.
+func importOutcome(saved bulk.Outcome, err error) (bulk.Outcome, error) {
+	if errors.Is(err, ErrProductTypeNotFound) {
+		return bulk.Failed(err), nil
+	}
+	return saved, err
+}
+
+// Restore restores a deleted product, along with its records.
+// A product that is not deleted is returned as it is.
+// It returns ErrProductCodeExists if another product took its product_code meanwhile.
+func (s *service) Restore(ctx context.Context, id int) (domain.Product, error) {
+	product, err := s.repo.Get(softdelete.WithDeleted(ctx), id)
+	if err != nil {
+		return domain.Product{}, ErrNotFound
+	}
+	if product.DeletedAt == nil {
+		return product, nil
+	}
+	// the product_code of a deleted product is free to be taken by a new one
+	if s.repo.Exists(ctx, product.ProductCode) {
+		return domain.Product{}, ErrProductCodeExists
+	}
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return domain.Product{}, err
+	}
+	s.index.invalidate()
+	product.DeletedAt = nil
+	return product, nil
+}
+
+// Purge removes for good the products deleted before the given time.
+// It returns how many were removed.
+func (s *service) Purge(ctx context.Context, before time.Time) (int, error) {
+	return s.repo.Purge(ctx, before)
+}
@@ -0,0 +1,168 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newImportProduct(code string) domain.Product {
+	return domain.Product{
+		Description:    "Fresh Milk",
+		ExpirationRate: 0.1,
+		FreezingRate:   0.05,
+		Height:         25,
+		Length:         10,
+		Netweight:      1,
+		ProductCode:    code,
+		RecomFreezTemp: -4,
+		Width:          10,
+		ProductTypeID:  1,
+		SellerID:       1,
+	}
+}
+
+func TestService_Import(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should insert the new products", func(t *testing.T) {
+		// Arrange
+		milk, peas := newImportProduct("MILK1001"), newImportProduct("PEAS2002")
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByCode", ctx, "MILK1001").Return(domain.Product{}, ErrNotFound)
+		repositoryMock.On("GetByCode", ctx, "PEAS2002").Return(domain.Product{}, ErrNotFound)
+		repositoryMock.On("Save", ctx, milk).Return(1, nil)
+		repositoryMock.On("Save", ctx, peas).Return(2, nil)
+		service := NewService(repositoryMock)
+
+		// Act
+		outcomes, err := service.Import(ctx, []domain.Product{milk, peas}, bulk.Options{Mode: bulk.ModeInsert})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Inserted(1), bulk.Inserted(2)}, outcomes)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("it should reject a stored product_code in insert mode", func(t *testing.T) {
+		// Arrange
+		milk := newImportProduct("MILK1001")
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByCode", ctx, "MILK1001").Return(domain.Product{ID: 7}, nil)
+		service := NewService(repositoryMock)
+
+		// Act
+		outcomes, err := service.Import(ctx, []domain.Product{milk}, bulk.Options{Mode: bulk.ModeInsert})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Failed(ErrProductCodeExists)}, outcomes)
+		repositoryMock.AssertNotCalled(t, "Save")
+	})
+
+	t.Run("it should update a stored product_code in upsert mode", func(t *testing.T) {
+		// Arrange
+		milk := newImportProduct("MILK1001")
+		updated := milk
+		updated.ID = 7
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByCode", ctx, "MILK1001").Return(domain.Product{ID: 7}, nil)
+		repositoryMock.On("Update", ctx, updated).Return(nil)
+		service := NewService(repositoryMock)
+
+		// Act
+		outcomes, err := service.Import(ctx, []domain.Product{milk}, bulk.Options{Mode: bulk.ModeUpsert})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Updated(7)}, outcomes)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("it should return the error of the repository", func(t *testing.T) {
+		// Arrange
+		errConn := errors.New("connection lost")
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByCode", ctx, "MILK1001").Return(domain.Product{}, errConn)
+		service := NewService(repositoryMock)
+
+		// Act
+		outcomes, err := service.Import(ctx, []domain.Product{newImportProduct("MILK1001")}, bulk.Options{})
+
+		// Assert
+		assert.ErrorIs(t, err, errConn)
+		assert.Nil(t, outcomes)
+	})
+
+	t.Run("it should reject a product whose type does not exist", func(t *testing.T) {
+		// Arrange
+		milk := newImportProduct("MILK1001")
+		milk.ProductTypeID = 99
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetByCode", ctx, "MILK1001").Return(domain.Product{}, ErrNotFound)
+		repositoryMock.On("Save", ctx, milk).Return(0, ErrProductTypeNotFound)
+		service := NewService(repositoryMock)
+
+		// Act
+		outcomes, err := service.Import(ctx, []domain.Product{milk}, bulk.Options{Mode: bulk.ModeInsert})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Failed(ErrProductTypeNotFound)}, outcomes)
+	})
+
+	t.Run("it should stop the import when the product type of a row cannot be read", func(t *testing.T) {
+		// Arrange
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		errConn := errors.New("connection lost")
+		columns := []string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "id_product_type", "id_seller", "deleted_at"}
+		m.ExpectBegin()
+		m.ExpectQuery("SELECT (.+) FROM products WHERE product_code").WithArgs("MILK1001").WillReturnRows(sqlmock.NewRows(columns))
+		m.ExpectQuery("SELECT id FROM product_types").WithArgs(1).WillReturnError(errConn)
+		m.ExpectRollback()
+		service := NewService(NewRepository(db))
+
+		// Act
+		outcomes, err := service.Import(ctx, []domain.Product{newImportProduct("MILK1001")}, bulk.Options{})
+
+		// Assert
+		assert.ErrorIs(t, err, errConn)
+		assert.Nil(t, outcomes)
+		assert.NoError(t, m.ExpectationsWereMet())
+	})
+
+	t.Run("it should roll back every row when one is rejected", func(t *testing.T) {
+		// Arrange
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		columns := []string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "netweight", "product_code", "recommended_freezing_temperature", "width", "id_product_type", "id_seller", "deleted_at"}
+		m.ExpectBegin()
+		m.ExpectQuery("SELECT (.+) FROM products WHERE product_code").WithArgs("MILK1001").WillReturnRows(sqlmock.NewRows(columns))
+		m.ExpectQuery("SELECT id FROM product_types").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		m.ExpectPrepare("INSERT INTO products").ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+		m.ExpectQuery("SELECT (.+) FROM products WHERE product_code").WithArgs("MILK1001").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Fresh Milk", 0.1, 0.05, 25, 10, 1, "MILK1001", -4, 10, 1, 1, nil))
+		m.ExpectRollback()
+		service := NewService(NewRepository(db))
+
+		// Act
+		outcomes, err := service.Import(ctx, []domain.Product{newImportProduct("MILK1001"), newImportProduct("MILK1001")}, bulk.Options{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Inserted(1), bulk.Failed(ErrProductCodeExists)}, outcomes)
+		assert.NoError(t, m.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,188 @@
+package product
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_UpdatePrices(t *testing.T) {
+	ctx := context.Background()
+	catalog := []domain.Product{
+		newSearchProduct(2, "YOG-002", "Strawberry yogurt drink", 14, 1),
+		newSearchProduct(1, "YOG-001", "Greek yogurt", 14, 1),
+		newSearchProduct(3, "FRZ-010", "Frozen strawberries", 7, 2),
+	}
+	latest := func(id int, date string, purchase, sale float64) domain.ProductRecord {
+		return domain.ProductRecord{ID: 10 + id, LastUpdate: date, PurchasePrice: amount(purchase), SalePrice: amount(sale), Currency: "USD", ProductID: id}
+	}
+	update := PriceUpdate{
+		Selector:   PriceSelector{SellerID: 14},
+		Rule:       PriceRule{Type: PriceRulePercentage, Value: money.FromInt(8)},
+		LastUpdate: "2026-11-01",
+	}
+
+	t.Run("it should record the new prices of every selected product", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		repo.On("InTx", ctx).Return(nil)
+		repo.On("GetForUpdate", ctx, mock.Anything).Return(domain.Product{}, nil)
+		repo.On("LatestRecord", ctx, 1).Return(latest(1, "2026-10-01", 10, 15), nil)
+		repo.On("LatestRecord", ctx, 2).Return(latest(2, "2026-11-01", 4, 5.5), nil)
+		repo.On("CreateProductRecord", ctx, domain.ProductRecordCreate{LastUpdate: "2026-11-01", PurchasePrice: amount(10), SalePrice: amount(16.2), Currency: "USD", ProductID: 1}).Return(21, nil)
+		repo.On("CreateProductRecord", ctx, domain.ProductRecordCreate{LastUpdate: "2026-11-01", PurchasePrice: amount(4), SalePrice: amount(5.94), Currency: "USD", ProductID: 2}).Return(22, nil)
+		service := NewService(repo)
+
+		// Act
+		changes, err := service.UpdatePrices(ctx, update)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []PriceChange{
+			{ProductID: 1, RecordID: 21, PurchasePrice: amount(10), OldSalePrice: amount(15), NewSalePrice: amount(16.2), Currency: "USD"},
+			{ProductID: 2, RecordID: 22, PurchasePrice: amount(4), OldSalePrice: amount(5.5), NewSalePrice: amount(5.94), Currency: "USD"},
+		}, changes)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("it should preview the new prices of a dry run", func(t *testing.T) {
+		// Arrange
+		dryRun := update
+		dryRun.Selector = PriceSelector{ProductIDs: []int{3, 9}}
+		dryRun.Rule = PriceRule{Type: PriceRuleMargin, Value: money.FromInt(20)}
+		dryRun.DryRun = true
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		repo.On("InTx", ctx).Return(nil)
+		repo.On("GetForUpdate", ctx, mock.Anything).Return(domain.Product{}, nil)
+		repo.On("LatestRecord", ctx, 3).Return(latest(3, "2026-10-01", 8, 9), nil)
+		repo.On("CreateProductRecord", ctx, mock.Anything).Return(23, nil)
+		service := NewService(repo)
+
+		// Act
+		changes, err := service.UpdatePrices(ctx, dryRun)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []PriceChange{{ProductID: 3, PurchasePrice: amount(8), OldSalePrice: amount(9), NewSalePrice: amount(10), Currency: "USD"}}, changes)
+	})
+
+	t.Run("it should set the sale price of an absolute rule", func(t *testing.T) {
+		// Arrange
+		absolute := update
+		absolute.Selector = PriceSelector{ProductIDs: []int{1, 2}}
+		absolute.Rule = PriceRule{Type: PriceRuleAbsolute, Value: amount(12.5)}
+		absolute.DryRun = true
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		repo.On("InTx", ctx).Return(nil)
+		repo.On("GetForUpdate", ctx, mock.Anything).Return(domain.Product{}, nil)
+		repo.On("LatestRecord", ctx, 1).Return(latest(1, "2026-10-01", 10, 15), nil)
+		repo.On("LatestRecord", ctx, 2).Return(latest(2, "2026-10-01", 4, 5.5), nil)
+		repo.On("CreateProductRecord", ctx, mock.Anything).Return(23, nil)
+		service := NewService(repo)
+
+		// Act
+		changes, err := service.UpdatePrices(ctx, absolute)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []PriceChange{
+			{ProductID: 1, PurchasePrice: amount(10), OldSalePrice: amount(15), NewSalePrice: amount(12.5), Currency: "USD"},
+			{ProductID: 2, PurchasePrice: amount(4), OldSalePrice: amount(5.5), NewSalePrice: amount(12.5), Currency: "USD"},
+		}, changes)
+		repo.AssertNotCalled(t, "ProductRecords", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should reject the products that cannot be repriced", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		repo.On("InTx", ctx).Return(nil)
+		repo.On("GetForUpdate", ctx, mock.Anything).Return(domain.Product{}, nil)
+		repo.On("LatestRecord", ctx, 1).Return(domain.ProductRecord{}, ErrRecordNotFound)
+		repo.On("LatestRecord", ctx, 2).Return(latest(2, "2026-12-01", 4, 5), nil)
+		service := NewService(repo)
+
+		// Act
+		changes, err := service.UpdatePrices(ctx, update)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []PriceChange{
+			{ProductID: 1, Err: ErrNoRecordToReprice},
+			{ProductID: 2, PurchasePrice: amount(4), OldSalePrice: amount(5), NewSalePrice: amount(5.4), Currency: "USD", Err: ErrRecordPredatesLatest},
+		}, changes)
+		repo.AssertNotCalled(t, "CreateProductRecord", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should reject the products whose new sale price is out of range", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		repo.On("InTx", ctx).Return(nil)
+		repo.On("GetForUpdate", ctx, mock.Anything).Return(domain.Product{}, nil)
+		repo.On("LatestRecord", ctx, 1).Return(latest(1, "2026-10-01", 10, 9e14), nil)
+		repo.On("LatestRecord", ctx, 2).Return(latest(2, "2026-12-01", 4, 5), nil)
+		service := NewService(repo)
+
+		// Act
+		changes, err := service.UpdatePrices(ctx, update)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []PriceChange{
+			{ProductID: 1, PurchasePrice: amount(10), OldSalePrice: amount(9e14), Currency: "USD", Err: money.ErrOutOfRange},
+			{ProductID: 2, PurchasePrice: amount(4), OldSalePrice: amount(5), NewSalePrice: amount(5.4), Currency: "USD", Err: ErrRecordPredatesLatest},
+		}, changes)
+		repo.AssertNotCalled(t, "CreateProductRecord", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should return an error for an invalid selector or rule", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		service := NewService(repo)
+		empty, margin, unmatched := update, update, update
+		empty.Selector = PriceSelector{}
+		margin.Rule = PriceRule{Type: PriceRuleMargin, Value: money.FromInt(100)}
+		unmatched.Selector = PriceSelector{SellerID: 14, ProductTypeID: 2}
+
+		// Act
+		_, errEmpty := service.UpdatePrices(ctx, empty)
+		_, errMargin := service.UpdatePrices(ctx, margin)
+		_, errUnmatched := service.UpdatePrices(ctx, unmatched)
+
+		// Assert
+		assert.ErrorIs(t, errEmpty, ErrEmptySelector)
+		assert.ErrorIs(t, errMargin, ErrInvalidPriceRule)
+		assert.ErrorIs(t, errUnmatched, ErrNoProductsSelected)
+	})
+}
+
+func TestPriceRule_apply(t *testing.T) {
+	purchase, sale := money.FromInt(10), money.FromInt(15)
+	for _, tc := range []struct {
+		rule     PriceRule
+		purchase money.Amount
+		currency money.Currency
+		want     string
+	}{
+		{PriceRule{Type: PriceRuleAbsolute, Value: amount(2.5)}, purchase, "USD", "2.50"},
+		{PriceRule{Type: PriceRulePercentage, Value: money.FromInt(-10)}, purchase, "USD", "13.50"},
+		{PriceRule{Type: PriceRuleMargin, Value: money.FromInt(25)}, purchase, "USD", "13.33"},
+		{PriceRule{Type: PriceRuleMargin, Value: money.FromInt(25)}, money.FromInt(1000), "JPY", "1333.00"},
+	} {
+		price, err := tc.rule.apply(tc.purchase, sale, tc.currency)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, price.String())
+	}
+	_, err := PriceRule{Type: PriceRulePercentage, Value: money.FromInt(8)}.apply(purchase, amount(9e14), "USD")
+	assert.ErrorIs(t, err, money.ErrOutOfRange)
+}
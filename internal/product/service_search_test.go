@@ -0,0 +1,136 @@
+package product
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSearchProduct(id int, code, description string, sellerID, productTypeID int) domain.Product {
+	p := newImportProduct(code)
+	p.ID, p.Description, p.SellerID, p.ProductTypeID = id, description, sellerID, productTypeID
+	return p
+}
+
+func codes(results []SearchResult) []string {
+	codes := []string{}
+	for _, r := range results {
+		codes = append(codes, r.ProductCode)
+	}
+	return codes
+}
+
+func TestService_Search(t *testing.T) {
+	ctx := context.Background()
+	catalog := []domain.Product{
+		newSearchProduct(1, "YOG-001", "Greek yogurt", 1, 1),
+		newSearchProduct(2, "YOG-002", "Strawberry yogurt drink", 2, 1),
+		newSearchProduct(3, "FRZ-010", "Frozen strawberries", 1, 2),
+	}
+
+	t.Run("it should rank the matching products of the seller and product type", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		service := NewService(repo)
+
+		// Act
+		all, err := service.Search(ctx, SearchQuery{Text: "straw"})
+		require.NoError(t, err)
+		filtered, err := service.Search(ctx, SearchQuery{Text: "straw", SellerID: 1, ProductTypeID: 2})
+		require.NoError(t, err)
+
+		// Assert
+		assert.ElementsMatch(t, []string{"YOG-002", "FRZ-010"}, codes(all))
+		assert.Equal(t, []string{"FRZ-010"}, codes(filtered))
+		assert.Greater(t, filtered[0].Score, 0.0)
+		repo.AssertNumberOfCalls(t, "GetAll", 1)
+	})
+
+	t.Run("it should find a product by its code without separators first", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		service := NewService(repo)
+
+		// Act
+		byCode, err := service.Search(ctx, SearchQuery{Text: "yog001"})
+		require.NoError(t, err)
+		byTypo, err := service.Search(ctx, SearchQuery{Text: "yoghurt greek"})
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, []string{"YOG-001", "YOG-002"}, codes(byCode))
+		assert.Greater(t, byCode[0].Score, byCode[1].Score)
+		assert.Equal(t, []string{"YOG-001"}, codes(byTypo))
+	})
+
+	t.Run("it should index the products again once one is saved", func(t *testing.T) {
+		// Arrange
+		saved := newSearchProduct(4, "CHS-001", "Cheddar cheese", 1, 3)
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil).Once()
+		repo.On("Exists", ctx, saved.ProductCode).Return(false)
+		repo.On("Save", ctx, saved).Return(4, nil)
+		repo.On("GetAll", ctx).Return(append(catalog, saved), nil).Once()
+		service := NewService(repo)
+		before, err := service.Search(ctx, SearchQuery{Text: "cheddar"})
+		require.NoError(t, err)
+
+		// Act
+		_, err = service.Save(ctx, saved)
+		require.NoError(t, err)
+		after, err := service.Search(ctx, SearchQuery{Text: "cheddar"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, before)
+		assert.Equal(t, []string{"CHS-001"}, codes(after))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("it should index the products again once the index is old", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, time.October, 18, 15, 0, 0, 0, time.UTC)
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		s := NewService(repo).(*service)
+		s.index.now = func() time.Time { return now }
+		_, err := s.Search(ctx, SearchQuery{Text: "yogurt"})
+		require.NoError(t, err)
+
+		// Act
+		now = now.Add(searchMaxAge)
+		_, err = s.Search(ctx, SearchQuery{Text: "yogurt"})
+
+		// Assert
+		require.NoError(t, err)
+		repo.AssertNumberOfCalls(t, "GetAll", 2)
+	})
+
+	t.Run("it should return at most the limit", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		repo.On("GetAll", ctx).Return(catalog, nil)
+		service := NewService(repo)
+
+		// Act
+		results, err := service.Search(ctx, SearchQuery{Text: "yog", Limit: 1})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("it should reject a query without words", func(t *testing.T) {
+		// Act
+		_, err := NewService(&RepositoryMock{}).Search(ctx, SearchQuery{Text: " -- "})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrEmptyQuery)
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/money"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // Test for create product method
@@ -110,6 +112,21 @@ func Test_Service_Create(t *testing.T) {
 		assert.Equal(t, 0, productID)
 		repositoryMock.AssertExpectations(t)
 	})
+
+	t.Run("should return ErrProductTypeNotFound if the product type does not exist", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		p := domain.Product{ProductCode: "123456", ProductTypeID: 99, SellerID: 1}
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Exists", ctx, p.ProductCode).Return(false)
+		repositoryMock.On("Save", ctx, p).Return(0, ErrProductTypeNotFound)
+		service := NewService(repositoryMock)
+
+		//Act
+		_, err := service.Save(ctx, p)
+		//Assert
+		assert.ErrorIs(t, err, ErrProductTypeNotFound)
+	})
 }
 
 // Test for Get method
@@ -228,6 +245,29 @@ func Test_Service_Read(t *testing.T) {
 // Test fot update method
 // User story: UPDATE
 // update_exist, update_non_exist
+func TestService_GetBySeller(t *testing.T) {
+	t.Run("should return the products of the seller only", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		products := []domain.Product{{ID: 1, SellerID: 1}, {ID: 2, SellerID: 2}, {ID: 3, SellerID: 1}}
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("GetAll", ctx).Return(products, nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		obtained, err := service.GetBySeller(ctx, 1)
+		none, errNone := service.GetBySeller(ctx, 3)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Product{products[0], products[2]}, obtained)
+		assert.NoError(t, errNone)
+		assert.Empty(t, none)
+		assert.NotNil(t, none)
+	})
+}
+
 func Test_Service_Update(t *testing.T) {
 	//update_exist
 	t.Run("should return a domain.Product and nil if there is no error", func(t *testing.T) {
@@ -466,13 +506,16 @@ func TestService_CreateProductRecord(t *testing.T) {
 
 		expectedProductRecord := domain.ProductRecordCreate{
 			LastUpdate:    "2021-04-04",
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     44,
 		}
 
 		repositoryMock := &RepositoryMock{}
-		repositoryMock.On("Get", ctx, expectedProductRecord.ProductID).Return(domain.Product{}, nil)
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetForUpdate", ctx, expectedProductRecord.ProductID).Return(domain.Product{}, nil)
+		repositoryMock.On("LatestRecordDate", ctx, expectedProductRecord.ProductID).Return("2021-04-04", nil)
 		repositoryMock.On("CreateProductRecord", ctx, expectedProductRecord).Return(0, nil)
 		service := NewService(repositoryMock)
 
@@ -491,13 +534,15 @@ func TestService_CreateProductRecord(t *testing.T) {
 
 		expectedProductRecord := domain.ProductRecordCreate{
 			LastUpdate:    "2021-04-04",
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     44,
 		}
 
 		repositoryMock := &RepositoryMock{}
-		repositoryMock.On("Get", ctx, expectedProductRecord.ProductID).Return(domain.Product{}, ErrNotFound)
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetForUpdate", ctx, expectedProductRecord.ProductID).Return(domain.Product{}, ErrNotFound)
 		service := NewService(repositoryMock)
 
 		//Act
@@ -515,13 +560,16 @@ func TestService_CreateProductRecord(t *testing.T) {
 
 		expectedProductRecord := domain.ProductRecordCreate{
 			LastUpdate:    "2021-04-04",
-			PurchasePrice: 10,
-			SalePrice:     15,
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
 			ProductID:     44,
 		}
 
 		repositoryMock := &RepositoryMock{}
-		repositoryMock.On("Get", ctx, expectedProductRecord.ProductID).Return(domain.Product{}, nil)
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetForUpdate", ctx, expectedProductRecord.ProductID).Return(domain.Product{}, nil)
+		repositoryMock.On("LatestRecordDate", ctx, expectedProductRecord.ProductID).Return("", nil)
 		repositoryMock.On("CreateProductRecord", ctx, expectedProductRecord).Return(0, errors.New("create fail"))
 		service := NewService(repositoryMock)
 
@@ -533,6 +581,261 @@ func TestService_CreateProductRecord(t *testing.T) {
 		assert.Equal(t, 0, id)
 		repositoryMock.AssertExpectations(t)
 	})
+	//create_err
+	t.Run("should return err if the product cannot be locked", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+		record := domain.ProductRecordCreate{LastUpdate: "2021-04-04", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(15), Currency: "USD", ProductID: 44}
+		errLock := errors.New("lock wait timeout exceeded")
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetForUpdate", ctx, 44).Return(domain.Product{}, errLock)
+		service := NewService(repositoryMock)
+
+		//Act
+		id, err := service.CreateProductRecord(ctx, record)
+
+		//Assert
+		assert.ErrorIs(t, err, errLock)
+		assert.Equal(t, 0, id)
+		repositoryMock.AssertNotCalled(t, "CreateProductRecord", ctx, record)
+	})
+	//create_conflict
+	t.Run("should return err if the record predates the latest one", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		expectedProductRecord := domain.ProductRecordCreate{
+			LastUpdate:    "2021-04-03",
+			PurchasePrice: money.FromInt(10),
+			SalePrice:     money.FromInt(15),
+			Currency:      "USD",
+			ProductID:     44,
+		}
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetForUpdate", ctx, expectedProductRecord.ProductID).Return(domain.Product{}, nil)
+		repositoryMock.On("LatestRecordDate", ctx, expectedProductRecord.ProductID).Return("2021-04-04", nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		id, err := service.CreateProductRecord(ctx, expectedProductRecord)
+
+		//Assert
+		assert.ErrorIs(t, err, ErrRecordPredatesLatest)
+		assert.Equal(t, 0, id)
+		repositoryMock.AssertNotCalled(t, "CreateProductRecord", ctx, expectedProductRecord)
+	})
+	//create_ok
+	t.Run("should record the prices in USD by default", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		record := domain.ProductRecordCreate{LastUpdate: "2021-04-04", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(15), ProductID: 44}
+		expectedProductRecord := record
+		expectedProductRecord.Currency = "USD"
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetForUpdate", ctx, 44).Return(domain.Product{}, nil)
+		repositoryMock.On("LatestRecordDate", ctx, 44).Return("", nil)
+		repositoryMock.On("CreateProductRecord", ctx, expectedProductRecord).Return(7, nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		id, err := service.CreateProductRecord(ctx, record)
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, id)
+		repositoryMock.AssertExpectations(t)
+	})
+	//create_err
+	t.Run("should return err if the currency is not an ISO 4217 code", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		repositoryMock := &RepositoryMock{}
+		service := NewService(repositoryMock)
+
+		//Act
+		_, err := service.CreateProductRecord(ctx, domain.ProductRecordCreate{LastUpdate: "2021-04-04", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(15), Currency: "EURO", ProductID: 44})
+
+		//Assert
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+		repositoryMock.AssertNotCalled(t, "CreateProductRecord", mock.Anything, mock.Anything)
+	})
+}
+
+// Test for ProductRecords and PriceAsOf
+// User story: GET
+// get_ok, get_err
+func TestService_ProductRecords(t *testing.T) {
+	records := []domain.ProductRecord{
+		{ID: 1, LastUpdate: "2021-01-01", PurchasePrice: money.FromInt(10), SalePrice: money.FromInt(20), Currency: "USD", ProductID: 44},
+		{ID: 2, LastUpdate: "2021-02-01", PurchasePrice: money.FromInt(12), SalePrice: money.FromInt(16), Currency: "USD", ProductID: 44},
+		{ID: 3, LastUpdate: "2021-03-01", PurchasePrice: money.FromInt(8), SalePrice: money.FromInt(10), Currency: "EUR", ProductID: 44},
+	}
+	change := func(v int64) *money.Amount { a := money.FromInt(v); return &a }
+
+	// get_ok
+	t.Run("should return the records within the dates with their margins and changes", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Get", ctx, 44).Return(domain.Product{}, nil)
+		repositoryMock.On("ProductRecords", ctx, 44, domain.ProductRecordFilter{To: "2021-02-15"}).Return(records[:2], nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		history, err := service.ProductRecords(ctx, 44, domain.ProductRecordFilter{From: "2021-01-15", To: "2021-02-15"})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ProductRecordHistory{{
+			ProductRecord:       records[1],
+			Margin:              0.25,
+			Markup:              0.3333,
+			PurchasePriceChange: change(2),
+			SalePriceChange:     change(-4),
+		}}, history)
+	})
+	// get_ok
+	t.Run("should return the record in force on a date", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Get", ctx, 44).Return(domain.Product{}, nil)
+		repositoryMock.On("ProductRecords", ctx, 44, domain.ProductRecordFilter{To: "2021-01-31"}).Return(records[:1], nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		record, err := service.PriceAsOf(ctx, 44, "2021-01-31", "")
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ProductRecordHistory{ProductRecord: records[0], Margin: 0.5, Markup: 1}, record)
+	})
+	// get_err
+	t.Run("should return err if there is no record on or before the date", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Get", ctx, 44).Return(domain.Product{}, nil)
+		repositoryMock.On("ProductRecords", ctx, 44, domain.ProductRecordFilter{To: "2020-12-31"}).Return([]domain.ProductRecord(nil), nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		_, err := service.PriceAsOf(ctx, 44, "2020-12-31", "")
+
+		//Assert
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+	// get_err
+	t.Run("should return err if the product does not exist", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Get", ctx, 44).Return(domain.Product{}, ErrNotFound)
+		service := NewService(repositoryMock)
+
+		//Act
+		_, err := service.ProductRecords(ctx, 44, domain.ProductRecordFilter{})
+
+		//Assert
+		assert.ErrorIs(t, err, ErrNotFound)
+	}) // get_ok
+	t.Run("should not compare the prices of records in different currencies", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Get", ctx, 44).Return(domain.Product{}, nil)
+		repositoryMock.On("ProductRecords", ctx, 44, domain.ProductRecordFilter{}).Return(records, nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		history, err := service.ProductRecords(ctx, 44, domain.ProductRecordFilter{From: "2021-03-01"})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ProductRecordHistory{{ProductRecord: records[2], Margin: 0.2, Markup: 0.25}}, history)
+	})
+	// get_ok
+	t.Run("should convert the prices to the currency asked for", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Get", ctx, 44).Return(domain.Product{}, nil)
+		repositoryMock.On("ProductRecords", ctx, 44, domain.ProductRecordFilter{}).Return(records, nil)
+		service := NewServiceWithRates(repositoryMock, rateTable{"EUR": 108000000})
+
+		//Act
+		history, err := service.ProductRecords(ctx, 44, domain.ProductRecordFilter{From: "2021-03-01", Currency: "usd"})
+
+		//Assert
+		assert.NoError(t, err)
+		converted := records[2]
+		converted.PurchasePrice, converted.SalePrice, converted.Currency = amount(8.64), amount(10.8), "USD"
+		assert.Equal(t, []domain.ProductRecordHistory{{
+			ProductRecord:       converted,
+			Margin:              0.2,
+			Markup:              0.25,
+			PurchasePriceChange: amountChange(-3.36),
+			SalePriceChange:     amountChange(-5.2),
+		}}, history)
+	})
+	// get_err
+	t.Run("should return err if a currency has no rate", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Get", ctx, 44).Return(domain.Product{}, nil)
+		repositoryMock.On("ProductRecords", ctx, 44, mock.Anything).Return(records, nil)
+
+		//Act
+		_, errNoRates := NewService(repositoryMock).ProductRecords(ctx, 44, domain.ProductRecordFilter{Currency: "USD"})
+		_, errNoRate := NewServiceWithRates(repositoryMock, rateTable{"EUR": 108000000}).PriceAsOf(ctx, 44, "2021-03-31", "GBP")
+		_, errInvalid := NewService(repositoryMock).PriceAsOf(ctx, 44, "2021-03-31", "EURO")
+
+		//Assert
+		assert.ErrorIs(t, errNoRates, ErrCurrencyRateNotFound)
+		assert.ErrorIs(t, errNoRate, ErrCurrencyRateNotFound)
+		assert.ErrorIs(t, errInvalid, ErrInvalidCurrency)
+	})
+}
+
+// rateTable is a RateGetter of fixed rates.
+type rateTable map[money.Currency]money.Rate
+
+func (t rateTable) Get(_ context.Context, c money.Currency) (domain.CurrencyRate, error) {
+	rate, ok := t[c]
+	if !ok {
+		return domain.CurrencyRate{}, errors.New("rate not found")
+	}
+	return domain.CurrencyRate{Currency: c, Rate: rate}, nil
+}
+
+func amountChange(v float64) *money.Amount {
+	a := amount(v)
+	return &a
+}
+
+// amount returns the Amount of v, a price the tests know to be in range.
+func amount(v float64) money.Amount {
+	a, err := money.FromFloat(v)
+	if err != nil {
+		panic(err)
+	}
+	return a
 }
 
 // Test for getProductRecord method
@@ -606,3 +909,53 @@ func TestService_GetProductRecord(t *testing.T) {
 		repositoryMock.AssertExpectations(t)
 	})
 }
+
+func TestService_EachProductRecord(t *testing.T) {
+	// each_ok
+	t.Run("should pass every product record to fn", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		expectedProductRecord := []domain.ProductRecordGet{
+			{ProductID: 44, Description: "Test", RecordCount: 1},
+			{ProductID: 45, Description: "Other", RecordCount: 0},
+		}
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("EachProductRecord", ctx, 0).Return(expectedProductRecord, nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		var productRecord []domain.ProductRecordGet
+		err := service.EachProductRecord(ctx, 0, func(p domain.ProductRecordGet) error {
+			productRecord = append(productRecord, p)
+			return nil
+		})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedProductRecord, productRecord)
+		repositoryMock.AssertExpectations(t)
+	})
+	// each_err for product not found
+	t.Run("should return ErrNotFound if the product does not exist", func(t *testing.T) {
+		//Arrange
+		ctx := context.Background()
+
+		idProduct := 44
+
+		repositoryMock := &RepositoryMock{}
+		repositoryMock.On("Get", ctx, idProduct).Return(domain.Product{}, ErrNotFound)
+		service := NewService(repositoryMock)
+
+		//Act
+		err := service.EachProductRecord(ctx, idProduct, func(p domain.ProductRecordGet) error {
+			t.Fatal("fn should not be called")
+			return nil
+		})
+
+		//Assert
+		assert.ErrorIs(t, err, ErrNotFound)
+		repositoryMock.AssertExpectations(t)
+	})
+}
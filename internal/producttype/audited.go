@@ -0,0 +1,48 @@
+package producttype
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// entity is the name of product types in the audit log.
+const entity = "product_type"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations, updates and deletions
+// in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a product type and records its creation.
+func (s *auditedService) Save(ctx context.Context, pt domain.ProductType) (int, error) {
+	id, err := s.Service.Save(ctx, pt)
+	if err != nil {
+		return 0, err
+	}
+	pt.ID = id
+	audit.Created(ctx, s.log, entity, id, pt)
+	return id, nil
+}
+
+// Update updates a product type and records it as it was before and after.
+func (s *auditedService) Update(ctx context.Context, pt domain.ProductType) error {
+	return audit.Update(ctx, s.log, entity, pt.ID, pt, s.Service.Get, func() error {
+		return s.Service.Update(ctx, pt)
+	})
+}
+
+// Delete deletes a product type and records it as it was before.
+func (s *auditedService) Delete(ctx context.Context, id int) error {
+	return audit.Delete(ctx, s.log, entity, id, s.Service.Get, func() error {
+		return s.Service.Delete(ctx, id)
+	})
+}
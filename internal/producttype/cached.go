@@ -0,0 +1,45 @@
+package producttype
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/cache"
+)
+
+// cacheEntity is the name of product types in the cache keys.
+const cacheEntity = "product_type"
+
+// cachedRepository reads the product types of a Repository through a cache
+// and invalidates the ones it writes.
+type cachedRepository struct {
+	Repository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedRepository returns r reading the product types by id through c,
+// where they are kept for ttl, and removing from c the product types it
+// updates or deletes.
+func NewCachedRepository(r Repository, c cache.Cache, ttl time.Duration) Repository {
+	return &cachedRepository{Repository: r, cache: c, ttl: ttl}
+}
+
+// Get returns a product type from the cache, or from the repository when it
+// is missing.
+func (r *cachedRepository) Get(ctx context.Context, id int) (domain.ProductType, error) {
+	return cache.Through(ctx, r.cache, cache.Key(cacheEntity, id), r.ttl, func() (domain.ProductType, error) {
+		return r.Repository.Get(ctx, id)
+	})
+}
+
+func (r *cachedRepository) Update(ctx context.Context, pt domain.ProductType) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, pt.ID))
+	return r.Repository.Update(ctx, pt)
+}
+
+func (r *cachedRepository) Delete(ctx context.Context, id int) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, id))
+	return r.Repository.Delete(ctx, id)
+}
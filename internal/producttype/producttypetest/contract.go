@@ -0,0 +1,153 @@
+// Package producttypetest provides a contract test suite for
+// producttype.Repository. Every implementation of the interface should pass
+// it.
+package producttypetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows that reference the product types but that the product
+// type repository does not write.
+type Fixtures struct {
+	// AddProduct stores a product of the given type.
+	AddProduct func(t *testing.T, productTypeID int)
+	// AddSection stores a section of the given type.
+	AddSection func(t *testing.T, productTypeID int)
+}
+
+// NewProductType returns a valid product type with the given description.
+func NewProductType(description string) domain.ProductType {
+	return domain.ProductType{Description: description}
+}
+
+// AddProductTypes saves n product types to repo, which take the ids 1 to n
+// when it is empty, so that the products and the sections of other tests can
+// reference them.
+func AddProductTypes(t *testing.T, repo producttype.Repository, n int) {
+	t.Helper()
+	for i := 1; i <= n; i++ {
+		_, err := repo.Save(context.Background(), NewProductType(fmt.Sprintf("Type %d", i)))
+		require.NoError(t, err)
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, each time it
+// is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (producttype.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save a product type and read it back", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		pt := NewProductType("Dairy")
+
+		// Act
+		id, err := repo.Save(ctx, pt)
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		pt.ID = id
+		assert.Equal(t, pt, obtained)
+	})
+
+	t.Run("it should return every saved product type", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		for _, description := range []string{"Dairy", "Bakery"} {
+			_, err := repo.Save(ctx, NewProductType(description))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, obtained, 2)
+	})
+
+	t.Run("it should return ErrNotFound when the product type does not exist", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.Get(ctx, 1)
+
+		assert.True(t, errors.Is(err, producttype.ErrNotFound))
+	})
+
+	t.Run("it should report whether a description is taken", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		_, err := repo.Save(ctx, NewProductType("Dairy"))
+		require.NoError(t, err)
+
+		assert.True(t, repo.Exists(ctx, "Dairy"))
+		assert.False(t, repo.Exists(ctx, "Bakery"))
+	})
+
+	t.Run("it should update a product type", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		id, err := repo.Save(ctx, NewProductType("Dairy"))
+		require.NoError(t, err)
+		updated := domain.ProductType{ID: id, Description: "Dairy and Eggs"}
+
+		// Act
+		err = repo.Update(ctx, updated)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, updated, obtained)
+	})
+
+	t.Run("it should delete a product type", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		id, err := repo.Save(ctx, NewProductType("Dairy"))
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, id)
+
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, id)
+		assert.True(t, errors.Is(err, producttype.ErrNotFound))
+	})
+
+	t.Run("it should return ErrNotFound when deleting a missing product type", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		err := repo.Delete(ctx, 1)
+
+		assert.True(t, errors.Is(err, producttype.ErrNotFound))
+	})
+
+	t.Run("it should not delete a product type a product or a section has", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		ofProduct, err := repo.Save(ctx, NewProductType("Dairy"))
+		require.NoError(t, err)
+		ofSection, err := repo.Save(ctx, NewProductType("Bakery"))
+		require.NoError(t, err)
+		fixtures.AddProduct(t, ofProduct)
+		fixtures.AddSection(t, ofSection)
+
+		// Act
+		errProduct := repo.Delete(ctx, ofProduct)
+		errSection := repo.Delete(ctx, ofSection)
+
+		// Assert
+		assert.True(t, errors.Is(errProduct, producttype.ErrInUse))
+		assert.True(t, errors.Is(errSection, producttype.ErrInUse))
+		all, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+	})
+}
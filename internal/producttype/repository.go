@@ -0,0 +1,118 @@
+package producttype
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// Repository encapsulates the storage of a product type.
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.ProductType, error)
+	// Get returns ErrNotFound when the product type does not exist.
+	Get(ctx context.Context, id int) (domain.ProductType, error)
+	Exists(ctx context.Context, description string) bool
+	Save(ctx context.Context, pt domain.ProductType) (int, error)
+	Update(ctx context.Context, pt domain.ProductType) error
+	// Delete removes a product type. It returns ErrInUse when a product or a
+	// section, even a deleted one, still has the type.
+	Delete(ctx context.Context, id int) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]domain.ProductType, error) {
+	query := "SELECT id, description FROM product_types ORDER BY id"
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []domain.ProductType
+	for rows.Next() {
+		pt := domain.ProductType{}
+		if err := rows.Scan(&pt.ID, &pt.Description); err != nil {
+			return nil, err
+		}
+		types = append(types, pt)
+	}
+	return types, rows.Err()
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.ProductType, error) {
+	query := "SELECT id, description FROM product_types WHERE id=?"
+	pt := domain.ProductType{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&pt.ID, &pt.Description)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ProductType{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.ProductType{}, err
+	}
+	return pt, nil
+}
+
+func (r *repository) Exists(ctx context.Context, description string) bool {
+	query := "SELECT description FROM product_types WHERE description=?"
+	err := r.db.QueryRowContext(ctx, query, description).Scan(&description)
+	return err == nil
+}
+
+func (r *repository) Save(ctx context.Context, pt domain.ProductType) (int, error) {
+	query := "INSERT INTO product_types (description) VALUES (?)"
+	res, err := r.db.ExecContext(ctx, query, pt.Description)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (r *repository) Update(ctx context.Context, pt domain.ProductType) error {
+	query := "UPDATE product_types SET description=? WHERE id=?"
+	_, err := r.db.ExecContext(ctx, query, pt.Description, pt.ID)
+	return err
+}
+
+// Delete removes a product type nothing has, since the products and the
+// sections are not kept from referencing a missing one by a foreign key.
+func (r *repository) Delete(ctx context.Context, id int) error {
+	query := "SELECT (SELECT COUNT(*) FROM products WHERE id_product_type=?) + (SELECT COUNT(*) FROM sections WHERE id_product_type=?)"
+	var uses int
+	if err := r.db.QueryRowContext(ctx, query, id, id).Scan(&uses); err != nil {
+		return err
+	}
+	if uses > 0 {
+		return ErrInUse
+	}
+
+	res, err := r.db.ExecContext(ctx, "DELETE FROM product_types WHERE id=?", id)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package producttype
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) GetAll(ctx context.Context) ([]domain.ProductType, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.ProductType), args.Error(1)
+}
+
+func (r *RepositoryMock) Get(ctx context.Context, id int) (domain.ProductType, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.ProductType), args.Error(1)
+}
+
+func (r *RepositoryMock) Exists(ctx context.Context, description string) bool {
+	args := r.Called(ctx, description)
+	return args.Bool(0)
+}
+
+func (r *RepositoryMock) Save(ctx context.Context, pt domain.ProductType) (int, error) {
+	args := r.Called(ctx, pt)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) Update(ctx context.Context, pt domain.ProductType) error {
+	args := r.Called(ctx, pt)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
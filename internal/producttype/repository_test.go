@@ -0,0 +1,56 @@
+package producttype_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/product/producttest"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/producttype/producttypetest"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/section/sectiontest"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/internal/warehouse/warehousetest"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	producttypetest.TestRepository(t, newRepository(func(r producttype.Repository) producttype.Repository { return r }))
+}
+
+func TestRepository_MySQLCached(t *testing.T) {
+	producttypetest.TestRepository(t, newRepository(func(r producttype.Repository) producttype.Repository {
+		return producttype.NewCachedRepository(r, cache.NewLRU(100), time.Minute)
+	}))
+}
+
+// newRepository returns the repositories the contract suite runs on, as
+// returned by wrap.
+func newRepository(wrap func(producttype.Repository) producttype.Repository) func(t *testing.T) (producttype.Repository, producttypetest.Fixtures) {
+	return func(t *testing.T) (producttype.Repository, producttypetest.Fixtures) {
+		db := mysqltest.Open(t)
+		warehousetest.AddWarehouses(t, warehouse.NewRepository(db), 1)
+		products := product.NewRepository(db)
+		sections := section.NewRepository(db)
+
+		fixtures := producttypetest.Fixtures{
+			AddProduct: func(t *testing.T, productTypeID int) {
+				p := producttest.NewProduct("MILK1001")
+				p.ProductTypeID = productTypeID
+				_, err := products.Save(context.Background(), p)
+				require.NoError(t, err)
+			},
+			AddSection: func(t *testing.T, productTypeID int) {
+				s := sectiontest.NewSection(1)
+				s.ProductTypeID = productTypeID
+				_, err := sections.Save(context.Background(), s)
+				require.NoError(t, err)
+			},
+		}
+		return wrap(producttype.NewRepository(db)), fixtures
+	}
+}
@@ -0,0 +1,79 @@
+package producttype
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// Errors
+var (
+	ErrNotFound             = errors.New("product type not found")
+	ErrIncorrectData        = errors.New("incorrect data")
+	ErrDuplicateDescription = errors.New("product type already exists")
+	ErrInUse                = errors.New("product type in use")
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.ProductType, error)
+	Get(ctx context.Context, id int) (domain.ProductType, error)
+	Save(ctx context.Context, pt domain.ProductType) (int, error)
+	Update(ctx context.Context, pt domain.ProductType) error
+	Delete(ctx context.Context, id int) error
+}
+
+type service struct {
+	rp Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{rp: r}
+}
+
+// GetAll returns all the product types.
+func (s *service) GetAll(ctx context.Context) ([]domain.ProductType, error) {
+	return s.rp.GetAll(ctx)
+}
+
+// Get returns a product type by ID, returns ErrNotFound if it doesn't exist.
+func (s *service) Get(ctx context.Context, id int) (domain.ProductType, error) {
+	return s.rp.Get(ctx, id)
+}
+
+// Save saves a product type, returns error if its description is empty or
+// another type already has it.
+func (s *service) Save(ctx context.Context, pt domain.ProductType) (int, error) {
+	if strings.TrimSpace(pt.Description) == "" {
+		return 0, ErrIncorrectData
+	}
+	if s.rp.Exists(ctx, pt.Description) {
+		return 0, ErrDuplicateDescription
+	}
+
+	return s.rp.Save(ctx, pt)
+}
+
+// Update updates a product type, returns error if it doesn't exist, its
+// description is empty or another type already has it.
+func (s *service) Update(ctx context.Context, pt domain.ProductType) error {
+	if strings.TrimSpace(pt.Description) == "" {
+		return ErrIncorrectData
+	}
+	current, err := s.rp.Get(ctx, pt.ID)
+	if err != nil {
+		return err
+	}
+	if current.Description != pt.Description && s.rp.Exists(ctx, pt.Description) {
+		return ErrDuplicateDescription
+	}
+
+	return s.rp.Update(ctx, pt)
+}
+
+// Delete deletes a product type by ID, returns ErrInUse if a product or a
+// section has it.
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.rp.Delete(ctx, id)
+}
@@ -0,0 +1,37 @@
+package producttype
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func (s *ServiceMock) GetAll(ctx context.Context) ([]domain.ProductType, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.ProductType), args.Error(1)
+}
+
+func (s *ServiceMock) Get(ctx context.Context, id int) (domain.ProductType, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.ProductType), args.Error(1)
+}
+
+func (s *ServiceMock) Save(ctx context.Context, pt domain.ProductType) (int, error) {
+	args := s.Called(ctx, pt)
+	return args.Int(0), args.Error(1)
+}
+
+func (s *ServiceMock) Update(ctx context.Context, pt domain.ProductType) error {
+	args := s.Called(ctx, pt)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) Delete(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,122 @@
+package producttype
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Save(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should save a product type", func(t *testing.T) {
+		// Arrange
+		pt := domain.ProductType{Description: "Dairy"}
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, "Dairy").Return(false)
+		repository.On("Save", ctx, pt).Return(1, nil)
+		service := NewService(repository)
+
+		// Act
+		id, err := service.Save(ctx, pt)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, id)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("it should reject a blank description", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		service := NewService(repository)
+
+		_, err := service.Save(ctx, domain.ProductType{Description: "  "})
+
+		assert.ErrorIs(t, err, ErrIncorrectData)
+		repository.AssertNotCalled(t, "Save")
+	})
+
+	t.Run("it should reject a description another type has", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		repository.On("Exists", ctx, "Dairy").Return(true)
+		service := NewService(repository)
+
+		_, err := service.Save(ctx, domain.ProductType{Description: "Dairy"})
+
+		assert.ErrorIs(t, err, ErrDuplicateDescription)
+		repository.AssertNotCalled(t, "Save")
+	})
+}
+
+func TestService_Update(t *testing.T) {
+	ctx := context.Background()
+	stored := domain.ProductType{ID: 1, Description: "Dairy"}
+
+	t.Run("it should update a product type", func(t *testing.T) {
+		// Arrange
+		updated := domain.ProductType{ID: 1, Description: "Dairy and Eggs"}
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, 1).Return(stored, nil)
+		repository.On("Exists", ctx, "Dairy and Eggs").Return(false)
+		repository.On("Update", ctx, updated).Return(nil)
+		service := NewService(repository)
+
+		// Act
+		err := service.Update(ctx, updated)
+
+		// Assert
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("it should keep the description of the product type itself", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, 1).Return(stored, nil)
+		repository.On("Update", ctx, stored).Return(nil)
+		service := NewService(repository)
+
+		err := service.Update(ctx, stored)
+
+		assert.NoError(t, err)
+		repository.AssertNotCalled(t, "Exists")
+	})
+
+	t.Run("it should return ErrNotFound when the product type does not exist", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, 1).Return(domain.ProductType{}, ErrNotFound)
+		service := NewService(repository)
+
+		err := service.Update(ctx, stored)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		repository.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("it should reject a description another type has", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, 1).Return(stored, nil)
+		repository.On("Exists", ctx, "Bakery").Return(true)
+		service := NewService(repository)
+
+		err := service.Update(ctx, domain.ProductType{ID: 1, Description: "Bakery"})
+
+		assert.ErrorIs(t, err, ErrDuplicateDescription)
+		repository.AssertNotCalled(t, "Update")
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should return ErrInUse when a product or a section has the type", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		repository.On("Delete", ctx, 1).Return(ErrInUse)
+		service := NewService(repository)
+
+		err := service.Delete(ctx, 1)
+
+		assert.ErrorIs(t, err, ErrInUse)
+	})
+}
@@ -0,0 +1,33 @@
+package purchase_order
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// entity is the name of purchase orders in the audit log.
+const entity = "purchase_order"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a purchase order and records its creation.
+func (s *auditedService) Save(ctx context.Context, purchaseOrder domain.PurchaseOrder) (int, error) {
+	id, err := s.Service.Save(ctx, purchaseOrder)
+	if err != nil {
+		return 0, err
+	}
+	purchaseOrder.ID = id
+	audit.Created(ctx, s.log, entity, id, purchaseOrder)
+	return id, nil
+}
@@ -0,0 +1,148 @@
+// Package purchaseordertest provides a contract test suite for
+// purchase_order.Repository. Every implementation of the interface should
+// pass it.
+package purchaseordertest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows the purchase order repository references but does
+// not write.
+type Fixtures struct {
+	// AddBuyer stores a buyer and returns it.
+	AddBuyer func(t *testing.T) domain.Buyer
+	// AddProductRecord stores a product record and returns its id.
+	AddProductRecord func(t *testing.T) int
+	// PendingEvents returns the events of the outbox not published yet.
+	PendingEvents func(t *testing.T) []events.Event
+}
+
+// NewPurchaseOrder returns a valid purchase order with the given number.
+func NewPurchaseOrder(number string, buyerID, productRecordID int) domain.PurchaseOrder {
+	return domain.PurchaseOrder{
+		OrderNumber:     number,
+		OrderDate:       "2023-01-01",
+		TrackingCode:    "TRK0001",
+		BuyerID:         buyerID,
+		ProductRecordID: productRecordID,
+		OrderStatusID:   1,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, each time it
+// is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (purchase_order.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save a purchase order", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		buyer := fixtures.AddBuyer(t)
+
+		id, err := repo.Save(ctx, NewPurchaseOrder("PO-1", buyer.ID, fixtures.AddProductRecord(t)))
+
+		require.NoError(t, err)
+		assert.True(t, repo.ExistsPurchaseOrder(ctx, id))
+		assert.False(t, repo.ExistsPurchaseOrder(ctx, id+1))
+	})
+
+	t.Run("it should reject a purchase order whose buyer does not exist", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+
+		_, err := repo.Save(ctx, NewPurchaseOrder("PO-1", 99, fixtures.AddProductRecord(t)))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it should report whether buyers and product records exist", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		buyer := fixtures.AddBuyer(t)
+		record := fixtures.AddProductRecord(t)
+
+		assert.True(t, repo.ExistsBuyer(ctx, buyer.ID))
+		assert.False(t, repo.ExistsBuyer(ctx, buyer.ID+1))
+		assert.True(t, repo.ExistsProductsRecord(ctx, record))
+		assert.False(t, repo.ExistsProductsRecord(ctx, record+1))
+	})
+
+	t.Run("it should count the purchase orders of each buyer", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		busy := fixtures.AddBuyer(t)
+		idle := fixtures.AddBuyer(t)
+		record := fixtures.AddProductRecord(t)
+		for _, number := range []string{"PO-1", "PO-2"} {
+			_, err := repo.Save(ctx, NewPurchaseOrder(number, busy.ID, record))
+			require.NoError(t, err)
+		}
+
+		// Act
+		all, err := repo.PurchaseOrdersByBuyers(ctx, 0)
+		require.NoError(t, err)
+		one, err := repo.PurchaseOrdersByBuyers(ctx, busy.ID)
+		require.NoError(t, err)
+
+		// Assert
+		busyReport := domain.PurchaseOrdersByBuyer{ID: busy.ID, CardNumberID: busy.CardNumberID, FirstName: busy.FirstName, LastName: busy.LastName, PurchaseOrdersCount: 2}
+		idleReport := domain.PurchaseOrdersByBuyer{ID: idle.ID, CardNumberID: idle.CardNumberID, FirstName: idle.FirstName, LastName: idle.LastName, PurchaseOrdersCount: 0}
+		assert.ElementsMatch(t, []domain.PurchaseOrdersByBuyer{busyReport, idleReport}, all)
+		assert.Equal(t, []domain.PurchaseOrdersByBuyer{busyReport}, one)
+	})
+
+	t.Run("it should return the purchase orders of the given buyers", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		busy := fixtures.AddBuyer(t)
+		other := fixtures.AddBuyer(t)
+		record := fixtures.AddProductRecord(t)
+		po := NewPurchaseOrder("PO-1", busy.ID, record)
+		id, err := repo.Save(ctx, po)
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewPurchaseOrder("PO-2", other.ID, record))
+		require.NoError(t, err)
+
+		// Act
+		obtained, err := repo.GetByBuyerIDs(ctx, []int{busy.ID})
+
+		// Assert
+		require.NoError(t, err)
+		po.ID = id
+		assert.Equal(t, []domain.PurchaseOrder{po}, obtained)
+	})
+	t.Run("it should keep the purchase order and its event of a committed transaction", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		po := NewPurchaseOrder("PO-1", fixtures.AddBuyer(t).ID, fixtures.AddProductRecord(t))
+
+		// Act
+		var id int
+		err := repo.InTx(ctx, func(tx purchase_order.Repository) (err error) {
+			if id, err = tx.Save(ctx, po); err != nil {
+				return err
+			}
+			po.ID = id
+			e, err := outbox.NewEvent(outbox.PurchaseOrderCreated, id, po)
+			if err != nil {
+				return err
+			}
+			return tx.SaveEvent(ctx, e)
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, repo.ExistsPurchaseOrder(ctx, id))
+		pending := fixtures.PendingEvents(t)
+		require.Len(t, pending, 1)
+		assert.Equal(t, outbox.PurchaseOrderCreated, pending[0].Type)
+		assert.Equal(t, id, pending[0].AggregateID)
+	})
+}
@@ -6,6 +6,10 @@ import (
 	"fmt"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/davidop97/apiGo/pkg/sqlin"
 )
 
 // Repository is an interface that defines the methods for a repository
@@ -21,11 +25,18 @@ type Repository interface {
 	ExistsProductsRecord(ctx context.Context, id int) bool
 	// PurchaseOrdersByBuyers returns all purchase orders made by a specific buyer.
 	PurchaseOrdersByBuyers(ctx context.Context, buyerID int) ([]domain.PurchaseOrdersByBuyer, error)
+	// GetByBuyerIDs returns the purchase orders made by every buyer in buyerIDs.
+	GetByBuyerIDs(ctx context.Context, buyerIDs []int) ([]domain.PurchaseOrder, error)
+	// SaveEvent writes e to the outbox, to be published once committed.
+	SaveEvent(ctx context.Context, e events.Event) error
+	// InTx calls fn with a repository whose queries run in one transaction,
+	// committed only if fn returns nil.
+	InTx(ctx context.Context, fn func(r Repository) error) error
 }
 
 // repository is the concrete implementation of the Repository interface.
 type repository struct {
-	db *sql.DB
+	db dbtx.DB
 }
 
 // NewRepository creates a new instance of the repository.
@@ -47,7 +58,7 @@ func (r *repository) ExistsPurchaseOrder(ctx context.Context, purchaseOrderID in
 // check if id field of buyers table exists into the database
 func (r *repository) ExistsBuyer(ctx context.Context, id int) bool {
 	// SQL query to select the id field from the buyers table where the id matches the input id
-	query := "SELECT id FROM buyers WHERE id=?;"
+	query := "SELECT id FROM buyers WHERE id=? AND deleted_at IS NULL;"
 	row := r.db.QueryRow(query, id)
 	// scan the result of the query into the input id variable
 	err := row.Scan(&id)
@@ -100,11 +111,11 @@ func (r *repository) PurchaseOrdersByBuyers(ctx context.Context, buyerID int) ([
 			  FROM
 			   buyers b
 			  LEFT JOIN
-			   Purchase_Orders po ON b.id = po.buyer_id
-			  `
+			   purchase_orders po ON b.id = po.buyer_id
+			  WHERE b.deleted_at IS NULL`
 	// if buyer id is present, filter with that id
 	if buyerID != 0 {
-		query += fmt.Sprintf(" WHERE b.id = %d", buyerID)
+		query += fmt.Sprintf(" AND b.id = %d", buyerID)
 	}
 	// complete the rest of the query group by the fields require
 	query += " GROUP BY b.id, b.card_number_id, b.first_name, b.last_name"
@@ -141,3 +152,40 @@ func (r *repository) PurchaseOrdersByBuyers(ctx context.Context, buyerID int) ([
 	// Return the results slice containing the purchase order data
 	return results, nil
 }
+
+// GetByBuyerIDs returns the purchase orders made by every buyer in buyerIDs.
+func (r *repository) GetByBuyerIDs(ctx context.Context, buyerIDs []int) ([]domain.PurchaseOrder, error) {
+	if len(buyerIDs) == 0 {
+		return nil, nil
+	}
+	in, args := sqlin.Ints(buyerIDs)
+	query := "SELECT id, order_number, order_date, tracking_code, buyer_id, product_record_id, order_status_id FROM purchase_orders WHERE buyer_id IN " + in
+	// Execute query
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []domain.PurchaseOrder
+	for rows.Next() {
+		po := domain.PurchaseOrder{}
+		if err := rows.Scan(&po.ID, &po.OrderNumber, &po.OrderDate, &po.TrackingCode, &po.BuyerID, &po.ProductRecordID, &po.OrderStatusID); err != nil {
+			return nil, err
+		}
+		orders = append(orders, po)
+	}
+	return orders, rows.Err()
+}
+
+// SaveEvent writes an event to the outbox table.
+func (r *repository) SaveEvent(ctx context.Context, e events.Event) error {
+	return outbox.Save(ctx, r.db, e)
+}
+
+// InTx runs fn on a copy of the repository bound to a transaction.
+func (r *repository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return dbtx.Run(ctx, r.db, func(tx dbtx.DB) error {
+		return fn(&repository{db: tx})
+	})
+}
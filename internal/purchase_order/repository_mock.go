@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/events"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -35,3 +36,21 @@ func (m *RepositoryMock) PurchaseOrdersByBuyers(ctx context.Context, buyerID int
 	args := m.Called(ctx, buyerID)
 	return args.Get(0).([]domain.PurchaseOrdersByBuyer), args.Error(1)
 }
+
+func (m *RepositoryMock) GetByBuyerIDs(ctx context.Context, buyerIDs []int) ([]domain.PurchaseOrder, error) {
+	args := m.Called(ctx, buyerIDs)
+	return args.Get(0).([]domain.PurchaseOrder), args.Error(1)
+}
+
+func (m *RepositoryMock) SaveEvent(ctx context.Context, e events.Event) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+// InTx runs fn on the mock itself unless an error is set for the call.
+func (m *RepositoryMock) InTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := m.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(m)
+}
@@ -0,0 +1,61 @@
+package purchase_order_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/buyer"
+	"github.com/davidop97/apiGo/internal/buyer/buyertest"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/product/producttest"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/producttype/producttypetest"
+	"github.com/davidop97/apiGo/internal/purchase_order"
+	"github.com/davidop97/apiGo/internal/purchase_order/purchaseordertest"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	purchaseordertest.TestRepository(t, func(t *testing.T) (purchase_order.Repository, purchaseordertest.Fixtures) {
+		db := mysqltest.Open(t)
+		producttypetest.AddProductTypes(t, producttype.NewRepository(db), 1)
+		buyers := buyer.NewRepository(db)
+		products := product.NewRepository(db)
+		next := 0
+
+		fixtures := purchaseordertest.Fixtures{
+			AddBuyer: func(t *testing.T) domain.Buyer {
+				next++
+				b := buyertest.NewBuyer(fmt.Sprintf("C%d", next))
+				id, err := buyers.Save(context.Background(), b)
+				require.NoError(t, err)
+				b.ID = id
+				return b
+			},
+			AddProductRecord: func(t *testing.T) int {
+				next++
+				productID, err := products.Save(context.Background(), producttest.NewProduct(fmt.Sprintf("P%d", next)))
+				require.NoError(t, err)
+				id, err := products.CreateProductRecord(context.Background(), domain.ProductRecordCreate{LastUpdate: "2023-01-01", PurchasePrice: 10, SalePrice: 15, ProductID: productID})
+				require.NoError(t, err)
+				return id
+			},
+			PendingEvents: func(t *testing.T) []events.Event {
+				records, err := outbox.NewRepository(db).Pending(context.Background(), time.Now(), 100)
+				require.NoError(t, err)
+				var pending []events.Event
+				for _, rec := range records {
+					pending = append(pending, rec.Event)
+				}
+				return pending
+			},
+		}
+		return purchase_order.NewRepository(db), fixtures
+	})
+}
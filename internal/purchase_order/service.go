@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
 )
 
 // Errors
@@ -19,6 +20,7 @@ var (
 type Service interface {
 	Save(ctx context.Context, purchaseOrder domain.PurchaseOrder) (int, error)
 	PurchaseOrdersByBuyer(ctx context.Context, buyerID int) ([]domain.PurchaseOrdersByBuyer, error)
+	GetByBuyerIDs(ctx context.Context, buyerIDs []int) ([]domain.PurchaseOrder, error)
 }
 
 // service struct is the concrete implementation of the Service interface
@@ -49,8 +51,20 @@ func (s *service) Save(ctx context.Context, purchaseOrder domain.PurchaseOrder)
 		return 0, ErrProductsRecordIDNotExits
 	}
 
-	// save purchase order into the database
-	id, err := s.repo.Save(ctx, purchaseOrder)
+	// save purchase order into the database, with the event of its creation
+	var id int
+	err := s.repo.InTx(ctx, func(r Repository) error {
+		var err error
+		if id, err = r.Save(ctx, purchaseOrder); err != nil {
+			return err
+		}
+		purchaseOrder.ID = id
+		e, err := outbox.NewEvent(outbox.PurchaseOrderCreated, id, purchaseOrder)
+		if err != nil {
+			return err
+		}
+		return r.SaveEvent(ctx, e)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -76,3 +90,8 @@ func (s *service) PurchaseOrdersByBuyer(ctx context.Context, buyerID int) ([]dom
 	return purchases, nil
 
 }
+
+// GetByBuyerIDs returns the purchase orders made by every buyer in buyerIDs.
+func (s *service) GetByBuyerIDs(ctx context.Context, buyerIDs []int) ([]domain.PurchaseOrder, error) {
+	return s.repo.GetByBuyerIDs(ctx, buyerIDs)
+}
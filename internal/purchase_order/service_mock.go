@@ -20,3 +20,8 @@ func (s *ServiceMock) PurchaseOrdersByBuyer(ctx context.Context, buyerID int) ([
 	args := s.Called(ctx, buyerID)
 	return args.Get(0).([]domain.PurchaseOrdersByBuyer), args.Error(1)
 }
+
+func (s *ServiceMock) GetByBuyerIDs(ctx context.Context, buyerIDs []int) ([]domain.PurchaseOrder, error) {
+	args := s.Called(ctx, buyerIDs)
+	return args.Get(0).([]domain.PurchaseOrder), args.Error(1)
+}
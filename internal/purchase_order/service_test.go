@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // Tests for Purchase Order services
@@ -42,7 +45,11 @@ func TestService_SavePurchaseOrder(t *testing.T) {
 		repoMock.On("ExistsPurchaseOrder", ctx, expectedPurchaseOrderID).Return(false)
 		repoMock.On("ExistsBuyer", ctx, buyerID).Return(true)
 		repoMock.On("ExistsProductsRecord", ctx, productRecordID).Return(true)
+		repoMock.On("InTx", ctx).Return(nil)
 		repoMock.On("Save", ctx, poToSave).Return(expectedPurchaseOrderID, nil)
+		repoMock.On("SaveEvent", ctx, mock.MatchedBy(func(e events.Event) bool {
+			return e.Type == outbox.PurchaseOrderCreated && e.AggregateID == expectedPurchaseOrderID
+		})).Return(nil)
 
 		// call to service interface
 		service := NewService(repoMock)
@@ -216,6 +223,7 @@ func TestService_SavePurchaseOrder(t *testing.T) {
 		repoMock.On("ExistsPurchaseOrder", ctx, expectedPurchaseOrderID).Return(false)
 		repoMock.On("ExistsBuyer", ctx, buyerID).Return(true)
 		repoMock.On("ExistsProductsRecord", ctx, productRecordID).Return(true)
+		repoMock.On("InTx", ctx).Return(nil)
 		repoMock.On("Save", ctx, poToSave).Return(expectedPurchaseOrderID, errors.New("some errors"))
 
 		// call to service interface
@@ -0,0 +1,55 @@
+package section
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// entity is the name of sections in the audit log.
+const entity = "section"
+
+// RecordUpdate records in log the update of a section from before to after
+// made outside of the section service, e.g. the change of its capacity by its
+// batches or of its current temperature by its readings.
+func RecordUpdate(ctx context.Context, log audit.Recorder, before, after domain.Section) {
+	audit.Updated(ctx, log, entity, after.ID, before, after)
+}
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations, updates and deletions
+// in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a section and records its creation.
+func (s *auditedService) Save(ctx context.Context, sect domain.Section) (int, error) {
+	id, err := s.Service.Save(ctx, sect)
+	if err != nil {
+		return 0, err
+	}
+	sect.ID = id
+	audit.Created(ctx, s.log, entity, id, sect)
+	return id, nil
+}
+
+// Update updates a section and records it as it was before and after.
+func (s *auditedService) Update(ctx context.Context, sect domain.Section) error {
+	return audit.Update(ctx, s.log, entity, sect.ID, sect, s.Service.Get, func() error {
+		return s.Service.Update(ctx, sect)
+	})
+}
+
+// Delete deletes a section and records it as it was before.
+func (s *auditedService) Delete(ctx context.Context, id int) error {
+	return audit.Delete(ctx, s.log, entity, id, s.Service.Get, func() error {
+		return s.Service.Delete(ctx, id)
+	})
+}
@@ -0,0 +1,86 @@
+package section
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditedService(t *testing.T) {
+	ctx := context.Background()
+	stored := domain.Section{ID: 12, SectionNumber: 3, CurrentCapacity: 10, MaximumCapacity: 50, WarehouseID: 1, ProductTypeID: 1}
+	updated := stored
+	updated.CurrentCapacity = 20
+
+	t.Run("it should record the creation of a section", func(t *testing.T) {
+		// Arrange
+		created := stored
+		created.ID = 0
+		inner, log := &ServiceMock{}, &audit.ServiceMock{}
+		inner.On("Save", ctx, created).Return(12, nil)
+		log.On("Record", mock.Anything, audit.OpCreate, "section", 12, nil, stored).Return(nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		id, err := s.Save(ctx, created)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 12, id)
+		log.AssertExpectations(t)
+	})
+
+	t.Run("it should record a section before and after its update", func(t *testing.T) {
+		// Arrange
+		inner, log := &ServiceMock{}, &audit.ServiceMock{}
+		inner.On("Get", ctx, 12).Return(stored, nil).Once()
+		inner.On("Update", ctx, updated).Return(nil)
+		inner.On("Get", ctx, 12).Return(updated, nil).Once()
+		log.On("Record", mock.Anything, audit.OpUpdate, "section", 12, stored, updated).Return(nil)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		err := s.Update(ctx, updated)
+
+		// Assert
+		assert.NoError(t, err)
+		inner.AssertExpectations(t)
+		log.AssertExpectations(t)
+	})
+
+	t.Run("it should not record a failed update", func(t *testing.T) {
+		// Arrange
+		inner, log := &ServiceMock{}, &audit.ServiceMock{}
+		inner.On("Get", ctx, 12).Return(stored, nil)
+		inner.On("Update", ctx, updated).Return(ErrDuplicateSectNumber)
+		s := NewAuditedService(inner, log)
+
+		// Act
+		err := s.Update(ctx, updated)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrDuplicateSectNumber)
+		log.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should record a deleted section as it was, even if the record fails", func(t *testing.T) {
+		// Arrange
+		inner, log := &ServiceMock{}, &audit.ServiceMock{}
+		inner.On("Get", ctx, 12).Return(stored, nil)
+		inner.On("Delete", ctx, 12).Return(nil)
+		log.On("Record", mock.Anything, audit.OpDelete, "section", 12, stored, nil).Return(errors.New("connection refused"))
+		s := NewAuditedService(inner, log)
+
+		// Act
+		err := s.Delete(ctx, 12)
+
+		// Assert
+		assert.NoError(t, err)
+		log.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,51 @@
+package section
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/cache"
+)
+
+// cacheEntity is the name of sections in the cache keys.
+const cacheEntity = "section"
+
+// CacheKey returns the key of the section id in the cache, for the writes of
+// the sections made outside this package to invalidate.
+func CacheKey(id int) string {
+	return cache.Key(cacheEntity, id)
+}
+
+// cachedRepository reads the sections of a Repository through a cache and
+// invalidates the ones it writes.
+type cachedRepository struct {
+	Repository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedRepository returns r reading the sections by id through c, where
+// they are kept for ttl, and removing from c the sections it updates or
+// deletes.
+func NewCachedRepository(r Repository, c cache.Cache, ttl time.Duration) Repository {
+	return &cachedRepository{Repository: r, cache: c, ttl: ttl}
+}
+
+// Get returns a section from the cache, or from the repository when it is
+// missing.
+func (r *cachedRepository) Get(ctx context.Context, id int) (domain.Section, error) {
+	return cache.Through(ctx, r.cache, cache.Key(cacheEntity, id), r.ttl, func() (domain.Section, error) {
+		return r.Repository.Get(ctx, id)
+	})
+}
+
+func (r *cachedRepository) Update(ctx context.Context, s domain.Section) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, s.ID))
+	return r.Repository.Update(ctx, s)
+}
+
+func (r *cachedRepository) Delete(ctx context.Context, id int) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, id))
+	return r.Repository.Delete(ctx, id)
+}
@@ -0,0 +1,81 @@
+package section
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/activity"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// CapacityChange is the data of the section.capacity_changed events of the
+// live feed of warehouse activity.
+type CapacityChange struct {
+	SectionID     int `json:"section_id"`
+	SectionNumber int `json:"section_number"`
+	// PreviousCapacity is the current capacity before the change, zero for a
+	// new section.
+	PreviousCapacity int `json:"previous_capacity"`
+	CurrentCapacity  int `json:"current_capacity"`
+	MinimumCapacity  int `json:"minimum_capacity"`
+	MaximumCapacity  int `json:"maximum_capacity"`
+}
+
+// liveService publishes the capacity changes of the sections of a Service to
+// the live feed of warehouse activity.
+type liveService struct {
+	Service
+	feed activity.Publisher
+}
+
+// NewLiveService returns s publishing to feed the capacity of the sections it
+// creates and the changes of capacity of the ones it updates.
+func NewLiveService(s Service, feed activity.Publisher) Service {
+	return &liveService{Service: s, feed: feed}
+}
+
+// Save saves a section and publishes its capacity.
+func (s *liveService) Save(ctx context.Context, sect domain.Section) (int, error) {
+	id, err := s.Service.Save(ctx, sect)
+	if err != nil {
+		return 0, err
+	}
+	sect.ID = id
+	s.publish(domain.Section{}, sect)
+	return id, nil
+}
+
+// Update updates a section and publishes the change of its capacity, if any.
+// A section that cannot be read before the update is published as changed.
+func (s *liveService) Update(ctx context.Context, sect domain.Section) error {
+	before, _ := s.Service.Get(ctx, sect.ID)
+	if err := s.Service.Update(ctx, sect); err != nil {
+		return err
+	}
+	after, err := s.Service.Get(ctx, sect.ID)
+	if err != nil {
+		after = sect
+	}
+	if before.ID == 0 || before.CurrentCapacity != after.CurrentCapacity ||
+		before.MinimumCapacity != after.MinimumCapacity || before.MaximumCapacity != after.MaximumCapacity {
+		s.publish(before, after)
+	}
+	return nil
+}
+
+func (s *liveService) publish(before, after domain.Section) {
+	PublishCapacity(s.feed, before, after)
+}
+
+// PublishCapacity publishes to feed the change of the capacity of a section
+// from before to after, as the section service does, for the changes made
+// elsewhere, e.g. by the batches of the section.
+func PublishCapacity(feed activity.Publisher, before, after domain.Section) {
+	feed.Publish(activity.SectionCapacityChanged, after.WarehouseID, CapacityChange{
+		SectionID:        after.ID,
+		SectionNumber:    after.SectionNumber,
+		PreviousCapacity: before.CurrentCapacity,
+		CurrentCapacity:  after.CurrentCapacity,
+		MinimumCapacity:  after.MinimumCapacity,
+		MaximumCapacity:  after.MaximumCapacity,
+	})
+}
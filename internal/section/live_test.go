@@ -0,0 +1,79 @@
+package section
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/activity"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveService(t *testing.T) {
+	ctx := context.Background()
+	stored := domain.Section{ID: 12, SectionNumber: 3, CurrentCapacity: 10, MinimumCapacity: 5, MaximumCapacity: 50, WarehouseID: 1, ProductTypeID: 1}
+
+	t.Run("it should publish the change of capacity of a section", func(t *testing.T) {
+		// Arrange
+		updated := stored
+		updated.CurrentCapacity = 20
+		inner, feed := &ServiceMock{}, activity.NewFeed(10)
+		inner.On("Get", ctx, 12).Return(stored, nil).Once()
+		inner.On("Update", ctx, updated).Return(nil)
+		inner.On("Get", ctx, 12).Return(updated, nil).Once()
+		s := NewLiveService(inner, feed)
+
+		// Act
+		err := s.Update(ctx, updated)
+
+		// Assert
+		assert.NoError(t, err)
+		events, sub := feed.Subscribe(activity.Filter{}, activity.EventID{})
+		defer sub.Close()
+		require.Len(t, events, 1)
+		assert.Equal(t, activity.SectionCapacityChanged, events[0].Type)
+		assert.Equal(t, 1, events[0].WarehouseID)
+		assert.Equal(t, CapacityChange{SectionID: 12, SectionNumber: 3, PreviousCapacity: 10, CurrentCapacity: 20,
+			MinimumCapacity: 5, MaximumCapacity: 50}, events[0].Data)
+	})
+
+	t.Run("it should not publish an update keeping the capacity", func(t *testing.T) {
+		// Arrange
+		updated := stored
+		updated.CurrentTemperature = 4
+		inner, feed := &ServiceMock{}, activity.NewFeed(10)
+		inner.On("Get", ctx, 12).Return(stored, nil).Once()
+		inner.On("Update", ctx, updated).Return(nil)
+		inner.On("Get", ctx, 12).Return(updated, nil).Once()
+		s := NewLiveService(inner, feed)
+
+		// Act
+		err := s.Update(ctx, updated)
+
+		// Assert
+		assert.NoError(t, err)
+		events, sub := feed.Subscribe(activity.Filter{}, activity.EventID{})
+		defer sub.Close()
+		assert.Empty(t, events)
+	})
+
+	t.Run("it should not publish a failed update", func(t *testing.T) {
+		// Arrange
+		updated := stored
+		updated.CurrentCapacity = 20
+		inner, feed := &ServiceMock{}, activity.NewFeed(10)
+		inner.On("Get", ctx, 12).Return(stored, nil)
+		inner.On("Update", ctx, updated).Return(ErrDuplicateSectNumber)
+		s := NewLiveService(inner, feed)
+
+		// Act
+		err := s.Update(ctx, updated)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrDuplicateSectNumber)
+		events, sub := feed.Subscribe(activity.Filter{}, activity.EventID{})
+		defer sub.Close()
+		assert.Empty(t, events)
+	})
+}
@@ -7,11 +7,20 @@ import (
 	"fmt"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/pkg/sqlin"
 )
 
 // Errors
 var (
 	ErrNotFound = errors.New("section not found")
+	// ErrProductTypeNotFound is returned when the product type of a section
+	// does not exist.
+	ErrProductTypeNotFound = errors.New("product type not found")
+	// ErrWarehouseNotFound is returned when the warehouse of a section does
+	// not exist, or is deleted.
+	ErrWarehouseNotFound = errors.New("warehouse not found")
 )
 
 type ProdCountResponse struct {
@@ -29,10 +38,27 @@ type Repository interface {
 	Update(ctx context.Context, s domain.Section) error
 	Delete(ctx context.Context, id int) error
 	ProductCount(ctx context.Context, id int) ([]ProdCountResponse, error)
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error)
+}
+
+// ProductTypeGetter gets a product type, e.g. through the cached product type
+// repository.
+type ProductTypeGetter interface {
+	Get(ctx context.Context, id int) (domain.ProductType, error)
+}
+
+// WarehouseGetter gets a warehouse that is not deleted, e.g. through the
+// cached warehouse repository.
+type WarehouseGetter interface {
+	Get(ctx context.Context, id int) (domain.Warehouse, error)
 }
 
 type repository struct {
 	db *sql.DB
+	// types and warehouses check the product type and the warehouse of the
+	// sections saved. They are queried directly when nil.
+	types      ProductTypeGetter
+	warehouses WarehouseGetter
 }
 
 func NewRepository(db *sql.DB) Repository {
@@ -41,8 +67,19 @@ func NewRepository(db *sql.DB) Repository {
 	}
 }
 
+// NewRepositoryWithLookups returns a repository checking that the product
+// type and the warehouse of the sections it saves exist through types and
+// warehouses, which may be cached, instead of querying them.
+func NewRepositoryWithLookups(db *sql.DB, types ProductTypeGetter, warehouses WarehouseGetter) Repository {
+	return &repository{
+		db:         db,
+		types:      types,
+		warehouses: warehouses,
+	}
+}
+
 func (r *repository) GetAll(ctx context.Context) ([]domain.Section, error) {
-	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections;"
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections;"
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -60,7 +97,7 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Section, error) {
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Section, error) {
-	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE id=?;"
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id=?;"
 	row := r.db.QueryRow(query, id)
 	s := domain.Section{}
 	err := row.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
@@ -83,6 +120,17 @@ func (r *repository) Exists(ctx context.Context, sectionNumber int) bool {
 }
 
 func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
+	if exists, err := r.productTypeExists(ctx, s.ProductTypeID); err != nil {
+		return 0, err
+	} else if !exists {
+		return 0, ErrProductTypeNotFound
+	}
+	if exists, err := r.warehouseExists(ctx, s.WarehouseID); err != nil {
+		return 0, err
+	} else if !exists {
+		return 0, ErrWarehouseNotFound
+	}
+
 	query := "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
@@ -103,6 +151,17 @@ func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, s domain.Section) error {
+	if exists, err := r.productTypeExists(ctx, s.ProductTypeID); err != nil {
+		return err
+	} else if !exists {
+		return ErrProductTypeNotFound
+	}
+	if exists, err := r.warehouseExists(ctx, s.WarehouseID); err != nil {
+		return err
+	} else if !exists {
+		return ErrWarehouseNotFound
+	}
+
 	query := "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=? WHERE id=?;"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
@@ -122,6 +181,44 @@ func (r *repository) Update(ctx context.Context, s domain.Section) error {
 	return nil
 }
 
+// productTypeExists checks that a product type id exists. It returns the
+// error of a product type that cannot be read.
+func (r *repository) productTypeExists(ctx context.Context, id int) (bool, error) {
+	var err error
+	if r.types != nil {
+		_, err = r.types.Get(ctx, id)
+	} else {
+		query := "SELECT id FROM product_types WHERE id=?;"
+		err = r.db.QueryRowContext(ctx, query, id).Scan(&id)
+	}
+	switch {
+	case errors.Is(err, producttype.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+// warehouseExists checks that a warehouse id exists and is not deleted. It
+// returns the error of a warehouse that cannot be read.
+func (r *repository) warehouseExists(ctx context.Context, id int) (bool, error) {
+	var err error
+	if r.warehouses != nil {
+		_, err = r.warehouses.Get(ctx, id)
+	} else {
+		query := "SELECT id FROM warehouses WHERE id=? AND deleted_at IS NULL;"
+		err = r.db.QueryRowContext(ctx, query, id).Scan(&id)
+	}
+	switch {
+	case errors.Is(err, warehouse.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *repository) Delete(ctx context.Context, id int) error {
 	// Delete associated ProductBatches
 	err := r.deleteBatches(ctx, id)
@@ -202,3 +299,28 @@ func (r *repository) ProductCount(ctx context.Context, id int) (l []ProdCountRes
 	}
 	return
 }
+
+// GetByIDs returns the sections whose id is in ids. Ids without a section are
+// skipped.
+func (r *repository) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	in, args := sqlin.Ints(ids)
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE id IN " + in
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []domain.Section
+	for rows.Next() {
+		s := domain.Section{}
+		if err := rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID); err != nil {
+			return nil, err
+		}
+		sections = append(sections, s)
+	}
+	return sections, rows.Err()
+}
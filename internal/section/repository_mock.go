@@ -45,3 +45,8 @@ func (r *RepositoryMock) ProductCount(ctx context.Context, id int) ([]ProdCountR
 	args := r.Called(ctx, id)
 	return args.Get(0).([]ProdCountResponse), args.Error(1)
 }
+
+func (r *RepositoryMock) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	args := r.Called(ctx, ids)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
@@ -0,0 +1,126 @@
+package section_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/product/producttest"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/producttype/producttypetest"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/section/sectiontest"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/internal/warehouse/warehousetest"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	sectiontest.TestRepository(t, newRepository(section.NewRepository))
+}
+
+func TestRepository_MySQLCached(t *testing.T) {
+	sectiontest.TestRepository(t, newRepository(func(db *sql.DB) section.Repository {
+		return section.NewCachedRepository(section.NewRepository(db), cache.NewLRU(100), time.Minute)
+	}))
+}
+
+func TestRepository_MySQLCachedLookups(t *testing.T) {
+	sectiontest.TestRepository(t, newRepository(func(db *sql.DB) section.Repository {
+		lookups := cache.NewLRU(100)
+		types := producttype.NewCachedRepository(producttype.NewRepository(db), lookups, time.Minute)
+		warehouses := warehouse.NewCachedRepository(warehouse.NewRepository(db), lookups, time.Minute)
+		return section.NewRepositoryWithLookups(db, types, warehouses)
+	}))
+}
+
+func TestRepository_Lookups(t *testing.T) {
+	ctx := context.Background()
+	s := domain.Section{SectionNumber: 1, WarehouseID: 2, ProductTypeID: 3}
+	newRepository := func(typeErr, warehouseErr error) section.Repository {
+		types, warehouses := &producttype.RepositoryMock{}, &warehouse.RepositoryMock{}
+		types.On("Get", mock.Anything, 3).Return(domain.ProductType{}, typeErr)
+		warehouses.On("Get", mock.Anything, 2).Return(domain.Warehouse{}, warehouseErr)
+		// The lookups fail before the database is used
+		return section.NewRepositoryWithLookups(nil, types, warehouses)
+	}
+
+	t.Run("it should reject a section whose warehouse does not exist", func(t *testing.T) {
+		_, errSave := newRepository(nil, warehouse.ErrNotFound).Save(ctx, s)
+		errUpdate := newRepository(nil, warehouse.ErrNotFound).Update(ctx, s)
+
+		assert.ErrorIs(t, errSave, section.ErrWarehouseNotFound)
+		assert.ErrorIs(t, errUpdate, section.ErrWarehouseNotFound)
+	})
+
+	t.Run("it should reject a section whose product type does not exist", func(t *testing.T) {
+		_, errSave := newRepository(producttype.ErrNotFound, nil).Save(ctx, s)
+		errUpdate := newRepository(producttype.ErrNotFound, nil).Update(ctx, s)
+
+		assert.ErrorIs(t, errSave, section.ErrProductTypeNotFound)
+		assert.ErrorIs(t, errUpdate, section.ErrProductTypeNotFound)
+	})
+
+	t.Run("it should return the error of a product type that cannot be read", func(t *testing.T) {
+		unreachable := errors.New("connection refused")
+
+		_, errSave := newRepository(unreachable, nil).Save(ctx, s)
+		errUpdate := newRepository(unreachable, nil).Update(ctx, s)
+
+		assert.ErrorIs(t, errSave, unreachable)
+		assert.ErrorIs(t, errUpdate, unreachable)
+	})
+
+	t.Run("it should return the error of a warehouse that cannot be read", func(t *testing.T) {
+		unreachable := errors.New("connection refused")
+
+		_, errSave := newRepository(nil, unreachable).Save(ctx, s)
+		errUpdate := newRepository(nil, unreachable).Update(ctx, s)
+
+		assert.ErrorIs(t, errSave, unreachable)
+		assert.ErrorIs(t, errUpdate, unreachable)
+	})
+}
+
+// newRepository returns the repositories the contract suite runs on, as
+// built by build on a database with the product types and the warehouses it
+// references.
+func newRepository(build func(*sql.DB) section.Repository) func(t *testing.T) (section.Repository, sectiontest.Fixtures) {
+	return func(t *testing.T) (section.Repository, sectiontest.Fixtures) {
+		db := mysqltest.Open(t)
+		producttypetest.AddProductTypes(t, producttype.NewRepository(db), 3)
+		warehousetest.AddWarehouses(t, warehouse.NewRepository(db), 2)
+		batches := batch.NewRepository(db)
+		products := product.NewRepository(db)
+		next := 0
+
+		fixtures := sectiontest.Fixtures{
+			AddBatch: func(t *testing.T, sectionID, quantity int) {
+				next++
+				productID, err := products.Save(context.Background(), producttest.NewProduct(fmt.Sprintf("P%d", next)))
+				require.NoError(t, err)
+				_, err = batches.Save(context.Background(), domain.ProductBatch{
+					BatchNumber:       next,
+					CurrentQuantity:   quantity,
+					InitialQuantity:   quantity,
+					DueDate:           "2024-01-01",
+					ManufacturingDate: "2023-01-01",
+					ProductID:         productID,
+					SectionID:         sectionID,
+				})
+				require.NoError(t, err)
+			},
+		}
+		return build(db), fixtures
+	}
+}
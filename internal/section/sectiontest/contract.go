@@ -0,0 +1,230 @@
+// Package sectiontest provides a contract test suite for section.Repository.
+// Every implementation of the interface should pass it.
+package sectiontest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows the section repository reads but does not write.
+type Fixtures struct {
+	// AddBatch stores a product batch with the given quantity in a section.
+	AddBatch func(t *testing.T, sectionID, quantity int)
+}
+
+// NewSection returns a valid section with the given number.
+func NewSection(number int) domain.Section {
+	return domain.Section{
+		SectionNumber:      number,
+		CurrentTemperature: 2,
+		MinimumTemperature: -5,
+		CurrentCapacity:    10,
+		MinimumCapacity:    5,
+		MaximumCapacity:    100,
+		WarehouseID:        1,
+		ProductTypeID:      1,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, where the
+// product types 1 to 3 and the warehouses 1 and 2 exist, each time it is
+// called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (section.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save a section and read it back", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		s := NewSection(1)
+
+		// Act
+		id, err := repo.Save(ctx, s)
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		s.ID = id
+		assert.Equal(t, s, obtained)
+	})
+
+	t.Run("it should return every saved section", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		for _, number := range []int{1, 2} {
+			_, err := repo.Save(ctx, NewSection(number))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, obtained, 2)
+	})
+
+	t.Run("it should return ErrNotFound when the section does not exist", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.Get(ctx, 1)
+
+		assert.True(t, errors.Is(err, section.ErrNotFound))
+	})
+
+	t.Run("it should report whether a section number is taken", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		_, err := repo.Save(ctx, NewSection(1))
+		require.NoError(t, err)
+
+		assert.True(t, repo.Exists(ctx, 1))
+		assert.False(t, repo.Exists(ctx, 2))
+	})
+
+	t.Run("it should update every field of a section", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		id, err := repo.Save(ctx, NewSection(1))
+		require.NoError(t, err)
+		updated := domain.Section{
+			ID:                 id,
+			SectionNumber:      7,
+			CurrentTemperature: -10,
+			MinimumTemperature: -20,
+			CurrentCapacity:    40,
+			MinimumCapacity:    20,
+			MaximumCapacity:    80,
+			WarehouseID:        2,
+			ProductTypeID:      3,
+		}
+
+		// Act
+		err = repo.Update(ctx, updated)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, updated, obtained)
+	})
+
+	t.Run("it should reject a section whose product type does not exist", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		s := NewSection(1)
+		s.ProductTypeID = 99
+		id, err := repo.Save(ctx, NewSection(2))
+		require.NoError(t, err)
+		update := NewSection(2)
+		update.ID, update.ProductTypeID = id, 99
+
+		// Act
+		_, errSave := repo.Save(ctx, s)
+		errUpdate := repo.Update(ctx, update)
+
+		// Assert
+		assert.True(t, errors.Is(errSave, section.ErrProductTypeNotFound))
+		assert.True(t, errors.Is(errUpdate, section.ErrProductTypeNotFound))
+		assert.False(t, repo.Exists(ctx, 1))
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, 1, obtained.ProductTypeID)
+	})
+
+	t.Run("it should reject a section whose warehouse does not exist", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		s := NewSection(1)
+		s.WarehouseID = 99
+		id, err := repo.Save(ctx, NewSection(2))
+		require.NoError(t, err)
+		update := NewSection(2)
+		update.ID, update.WarehouseID = id, 99
+
+		// Act
+		_, errSave := repo.Save(ctx, s)
+		errUpdate := repo.Update(ctx, update)
+
+		// Assert
+		assert.True(t, errors.Is(errSave, section.ErrWarehouseNotFound))
+		assert.True(t, errors.Is(errUpdate, section.ErrWarehouseNotFound))
+		assert.False(t, repo.Exists(ctx, 1))
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, 1, obtained.WarehouseID)
+	})
+
+	t.Run("it should delete a section together with its batches", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		id, err := repo.Save(ctx, NewSection(1))
+		require.NoError(t, err)
+		fixtures.AddBatch(t, id, 5)
+
+		err = repo.Delete(ctx, id)
+
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, id)
+		assert.True(t, errors.Is(err, section.ErrNotFound))
+	})
+
+	t.Run("it should return ErrNotFound when deleting a missing section", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		err := repo.Delete(ctx, 1)
+
+		assert.True(t, errors.Is(err, section.ErrNotFound))
+	})
+
+	t.Run("it should count the products stored in each section", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		full, err := repo.Save(ctx, NewSection(1))
+		require.NoError(t, err)
+		empty, err := repo.Save(ctx, NewSection(2))
+		require.NoError(t, err)
+		fixtures.AddBatch(t, full, 5)
+		fixtures.AddBatch(t, full, 7)
+
+		// Act
+		all, err := repo.ProductCount(ctx, 0)
+		require.NoError(t, err)
+		one, err := repo.ProductCount(ctx, full)
+		require.NoError(t, err)
+
+		// Assert
+		assert.ElementsMatch(t, []section.ProdCountResponse{
+			{ID: full, SectionNumber: 1, ProductCount: 12},
+			{ID: empty, SectionNumber: 2, ProductCount: 0},
+		}, all)
+		assert.Equal(t, []section.ProdCountResponse{{ID: full, SectionNumber: 1, ProductCount: 12}}, one)
+	})
+
+	t.Run("it should return ErrNotFound when counting products of a missing section", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.ProductCount(ctx, 1)
+
+		assert.True(t, errors.Is(err, section.ErrNotFound))
+	})
+
+	t.Run("it should return the sections with the given ids", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		var ids []int
+		for _, number := range []int{1, 2, 3} {
+			id, err := repo.Save(ctx, NewSection(number))
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		obtained, err := repo.GetByIDs(ctx, []int{ids[0], ids[2], ids[2] + 100})
+
+		require.NoError(t, err)
+		require.Len(t, obtained, 2)
+		assert.ElementsMatch(t, []int{ids[0], ids[2]}, []int{obtained[0].ID, obtained[1].ID})
+	})
+}
@@ -19,6 +19,7 @@ type Service interface {
 	Delete(ctx context.Context, id int) (err error)
 	Update(ctx context.Context, sect domain.Section) (err error)
 	ProductCount(ctx context.Context, id int) ([]ProdCountResponse, error)
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error)
 }
 
 // service is a struct that represents a section service
@@ -89,3 +90,8 @@ func (s *service) ProductCount(ctx context.Context, id int) (l []ProdCountRespon
 	l, err = s.r.ProductCount(ctx, id)
 	return
 }
+
+// GetByIDs returns the sections whose id is in ids.
+func (s *service) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	return s.r.GetByIDs(ctx, ids)
+}
@@ -40,3 +40,8 @@ func (r *ServiceMock) ProductCount(ctx context.Context, id int) ([]ProdCountResp
 	args := r.Called(ctx, id)
 	return args.Get(0).([]ProdCountResponse), args.Error(1)
 }
+
+func (s *ServiceMock) GetByIDs(ctx context.Context, ids []int) ([]domain.Section, error) {
+	args := s.Called(ctx, ids)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
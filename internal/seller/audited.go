@@ -0,0 +1,69 @@
+package seller
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+)
+
+// entity is the name of sellers in the audit log.
+const entity = "seller"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations, updates, deletions,
+// restorations and imports in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a seller and records its creation.
+func (s *auditedService) Save(ctx context.Context, seller domain.Seller) (int, error) {
+	id, err := s.Service.Save(ctx, seller)
+	if err != nil {
+		return 0, err
+	}
+	seller.ID = id
+	audit.Created(ctx, s.log, entity, id, seller)
+	return id, nil
+}
+
+// Update updates a seller and records it as it was before and after.
+func (s *auditedService) Update(ctx context.Context, seller domain.Seller, id int) error {
+	return audit.Update(ctx, s.log, entity, id, seller, s.Service.GetSellerByID, func() error {
+		return s.Service.Update(ctx, seller, id)
+	})
+}
+
+// Delete deletes a seller and records it as it was before.
+func (s *auditedService) Delete(ctx context.Context, id int) error {
+	return audit.Delete(ctx, s.log, entity, id, s.Service.GetSellerByID, func() error {
+		return s.Service.Delete(ctx, id)
+	})
+}
+
+// Restore restores a seller and records it as it was before and after.
+func (s *auditedService) Restore(ctx context.Context, id int) (domain.Seller, error) {
+	return audit.Restore(ctx, s.log, entity, id, s.Service.GetSellerByID, func() (domain.Seller, error) {
+		return s.Service.Restore(ctx, id)
+	})
+}
+
+// Import imports sellers and records the sellers it saved.
+func (s *auditedService) Import(ctx context.Context, sellers []domain.Seller, opts bulk.Options) ([]bulk.Outcome, error) {
+	outcomes, err := s.Service.Import(ctx, sellers, opts)
+	if err != nil {
+		return outcomes, err
+	}
+	audit.Imported(ctx, s.log, entity, sellers, outcomes, opts, func(seller domain.Seller, id int) domain.Seller {
+		seller.ID = id
+		return seller
+	})
+	return outcomes, nil
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/davidop97/apiGo/pkg/sqlin"
 )
 
 // Errors
@@ -20,12 +24,32 @@ type Repository interface {
 	Exists(ctx context.Context, cid int) bool
 	Save(ctx context.Context, s domain.Seller) (int, error)
 	Update(ctx context.Context, s domain.Seller) error
+	// Delete soft deletes a seller, which reads skip unless their context
+	// includes deleted rows (see softdelete.WithDeleted).
 	Delete(ctx context.Context, id int) error
+	// Restore undoes the deletion of a seller.
+	Restore(ctx context.Context, id int) error
+	// Purge removes for good the sellers deleted before the given time.
+	Purge(ctx context.Context, before time.Time) (int, error)
 	GetLocalityIdFromSeller(ctx context.Context, id int) bool
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Seller, error)
+	GetByCID(ctx context.Context, cid int) (domain.Seller, error)
+	// InTx calls fn with a repository whose queries run in one transaction,
+	// committed only if fn returns nil.
+	InTx(ctx context.Context, fn func(r Repository) error) error
+}
+
+// LocalityGetter gets a locality, e.g. through the cached locality
+// repository.
+type LocalityGetter interface {
+	GetLocality(ctx context.Context, id int) (domain.Locality, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db dbtx.DB
+	// localities checks the locality of the sellers. It is queried directly
+	// when nil.
+	localities LocalityGetter
 }
 
 func NewRepository(db *sql.DB) Repository {
@@ -34,12 +58,22 @@ func NewRepository(db *sql.DB) Repository {
 	}
 }
 
+// NewRepositoryWithLookups returns a repository checking that the locality
+// of a seller exists through localities, which may be cached, instead of
+// querying it.
+func NewRepositoryWithLookups(db *sql.DB, localities LocalityGetter) Repository {
+	return &repository{
+		db:         db,
+		localities: localities,
+	}
+}
+
 // Get all the sellers in the database. Return an error if the list is empty
 // or another internal error occurs, it will be returned to be controlled in the handler.
 func (r *repository) GetAll(ctx context.Context) ([]domain.Seller, error) {
-	query := "SELECT id, cid, company_name, address, telephone, locality_id FROM sellers"
+	query := "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM sellers WHERE " + softdelete.Visible(ctx, "deleted_at")
 	//Execute the query.
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query)
 	//If an internal error occurs, it will be returned to be controlled in the handler.
 	if err != nil {
 		return nil, err
@@ -50,7 +84,7 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Seller, error) {
 	for rows.Next() {
 		s := domain.Seller{}
 		// Scan the row into the Seller struct.
-		_ = rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.IDLocality)
+		_ = rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.IDLocality, softdelete.Scan(&s.DeletedAt))
 		sellers = append(sellers, s)
 	}
 	//If another internal error occurs, it will be returned to be controlled in the handler.
@@ -68,12 +102,12 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Seller, error) {
 
 // Get a seller using its id. Return an error if it doesn't exist.
 func (r *repository) Get(ctx context.Context, id int) (domain.Seller, error) {
-	query := "SELECT id, cid, company_name, address, telephone, locality_id FROM sellers WHERE id=?;"
+	query := "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM sellers WHERE id=? AND " + softdelete.Visible(ctx, "deleted_at")
 	// Execute the query.
-	row := r.db.QueryRow(query, id)
+	row := r.db.QueryRowContext(ctx, query, id)
 	s := domain.Seller{}
 	// Scan the row into the Seller struct.
-	err := row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.IDLocality)
+	err := row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.IDLocality, softdelete.Scan(&s.DeletedAt))
 	if err != nil {
 		//Check if the query returns a row or not.
 		if errors.Is(err, sql.ErrNoRows) {
@@ -88,12 +122,34 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Seller, error) {
 }
 
 func (r *repository) Exists(ctx context.Context, cid int) bool {
-	query := "SELECT cid FROM sellers WHERE cid=?;"
-	row := r.db.QueryRow(query, cid)
+	query := "SELECT cid FROM sellers WHERE cid=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, cid)
 	err := row.Scan(&cid)
 	return err == nil
 }
 
+// GetByCID returns the seller with the given cid, or ErrNotFound.
+func (r *repository) GetByCID(ctx context.Context, cid int) (domain.Seller, error) {
+	query := "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM sellers WHERE cid=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, cid)
+	s := domain.Seller{}
+	err := row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.IDLocality, softdelete.Scan(&s.DeletedAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Seller{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.Seller{}, err
+	}
+	return s, nil
+}
+
+// InTx runs fn on a copy of the repository bound to a transaction.
+func (r *repository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return dbtx.Run(ctx, r.db, func(tx dbtx.DB) error {
+		return fn(&repository{db: tx, localities: r.localities})
+	})
+}
+
 // Save a seller in the database. Return the last inserted id or an error if it occurs
 // to be controlled in the handler.
 func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
@@ -120,7 +176,7 @@ func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
 // Update a seller in the database. Return an error if it doesn't exist or another internal error occurs
 // to be controlled in the handler.
 func (r *repository) Update(ctx context.Context, s domain.Seller) error {
-	query := "UPDATE sellers SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=?"
+	query := "UPDATE sellers SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=? AND deleted_at IS NULL"
 	// Prepare the query.
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
@@ -128,7 +184,7 @@ func (r *repository) Update(ctx context.Context, s domain.Seller) error {
 	}
 
 	// Execute the query.
-	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.ID, s.IDLocality)
+	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.IDLocality, s.ID)
 	if err != nil {
 		return err
 	}
@@ -143,17 +199,36 @@ func (r *repository) Update(ctx context.Context, s domain.Seller) error {
 	return nil
 }
 
-// Delete a seller from the database. Return an error if it doesn't exist or another internal error occurs
+// Delete soft deletes a seller: it is hidden from the reads until it is restored
+// or purged. Return an error if it doesn't exist or another internal error occurs
 // to be controlled in the handler.
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM sellers WHERE id=?"
-	// Prepare the query.
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE sellers SET deleted_at=? WHERE id=? AND deleted_at IS NULL"
+	return r.exec(ctx, query, softdelete.Format(time.Now()), id)
+}
+
+// Restore a soft deleted seller. Return ErrNotFound if there is no deleted seller
+// with that id.
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE sellers SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	return r.exec(ctx, query, id)
+}
+
+// Purge removes for good the sellers deleted before the given time and returns
+// how many were removed.
+func (r *repository) Purge(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM sellers WHERE deleted_at < ?"
+	res, err := r.db.ExecContext(ctx, query, softdelete.Format(before))
 	if err != nil {
-		return err
+		return 0, err
 	}
+	affect, err := res.RowsAffected()
+	return int(affect), err
+}
 
-	res, err := stmt.Exec(id)
+// exec runs a statement changing one seller. Return ErrNotFound if no row was changed.
+func (r *repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -172,8 +247,38 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 // Check if a locality_id exists using its id. Return true if it exists and false otherwise.
 func (r *repository) GetLocalityIdFromSeller(ctx context.Context, id int) bool {
+	if r.localities != nil {
+		_, err := r.localities.GetLocality(ctx, id)
+		return err == nil
+	}
 	query := "SELECT id FROM locality WHERE id = ?;"
 	row := r.db.QueryRow(query, id)
 	err := row.Scan(&id)
 	return err == nil
 }
+
+// GetByIDs returns the sellers whose id is in ids, in no particular order.
+// Ids without a seller are skipped.
+func (r *repository) GetByIDs(ctx context.Context, ids []int) ([]domain.Seller, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	in, args := sqlin.Ints(ids)
+	query := "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM sellers WHERE id IN " + in + " AND " + softdelete.Visible(ctx, "deleted_at")
+	// Execute the query.
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sellers []domain.Seller
+	for rows.Next() {
+		s := domain.Seller{}
+		if err := rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.IDLocality, softdelete.Scan(&s.DeletedAt)); err != nil {
+			return nil, err
+		}
+		sellers = append(sellers, s)
+	}
+	return sellers, rows.Err()
+}
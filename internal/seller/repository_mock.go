@@ -2,6 +2,7 @@ package seller
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
 
@@ -46,7 +47,35 @@ func (r *RepositoryMock) Delete(ctx context.Context, id int) (err error) {
 	return args.Error(0)
 }
 
+func (r *RepositoryMock) Restore(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := r.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
 func (r *RepositoryMock) GetLocalityIdFromSeller(ctx context.Context, id int) bool {
 	args := r.Called(ctx, id)
 	return args.Bool(0)
 }
+
+func (r *RepositoryMock) GetByIDs(ctx context.Context, ids []int) ([]domain.Seller, error) {
+	args := r.Called(ctx, ids)
+	return args.Get(0).([]domain.Seller), args.Error(1)
+}
+
+func (r *RepositoryMock) GetByCID(ctx context.Context, cid int) (domain.Seller, error) {
+	args := r.Called(ctx, cid)
+	return args.Get(0).(domain.Seller), args.Error(1)
+}
+
+// InTx runs fn on the mock itself unless an error is set for the call.
+func (r *RepositoryMock) InTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := r.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(r)
+}
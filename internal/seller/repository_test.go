@@ -0,0 +1,46 @@
+package seller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/locality"
+	"github.com/davidop97/apiGo/internal/locality/localitytest"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/internal/seller/sellertest"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	sellertest.TestRepository(t, newRepository(false))
+}
+
+func TestRepository_MySQLCachedLookups(t *testing.T) {
+	sellertest.TestRepository(t, newRepository(true))
+}
+
+// newRepository returns the repositories the contract suite runs on, which
+// check the localities through a cached repository when cached is set.
+func newRepository(cached bool) func(t *testing.T) (seller.Repository, sellertest.Fixtures) {
+	return func(t *testing.T) (seller.Repository, sellertest.Fixtures) {
+		db := mysqltest.Open(t)
+		localities := locality.NewRepository(db)
+		next := 0
+
+		fixtures := sellertest.Fixtures{
+			AddLocality: func(t *testing.T) int {
+				next++
+				id, err := localities.Save(context.Background(), localitytest.NewLocality(next))
+				require.NoError(t, err)
+				return id
+			},
+		}
+		if cached {
+			return seller.NewRepositoryWithLookups(db, locality.NewCachedRepository(localities, cache.NewLRU(100), time.Minute)), fixtures
+		}
+		return seller.NewRepository(db), fixtures
+	}
+}
@@ -2,8 +2,10 @@ package seller
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -66,3 +68,27 @@ func (s *ServiceMock) GetLocalityIdFromSeller(ctx context.Context, id int) bool
 	//Return true if exists. Otherwise returns false.
 	return args.Bool(0)
 }
+
+// GetSellersByIDs function. Mock of the GetSellersByIDs function. Get the sellers with the given ids or an error.
+func (s *ServiceMock) GetSellersByIDs(ctx context.Context, ids []int) ([]domain.Seller, error) {
+	args := s.Called(ctx, ids)
+	return args.Get(0).([]domain.Seller), args.Error(1)
+}
+
+// Import function. Mock of the Import function. Get the outcome of every seller or an error.
+func (s *ServiceMock) Import(ctx context.Context, sellers []domain.Seller, opts bulk.Options) ([]bulk.Outcome, error) {
+	args := s.Called(ctx, sellers, opts)
+	return args.Get(0).([]bulk.Outcome), args.Error(1)
+}
+
+// Restore function. Mock of the Restore function. Get the restored seller or an error.
+func (s *ServiceMock) Restore(ctx context.Context, id int) (domain.Seller, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Seller), args.Error(1)
+}
+
+// Purge function. Mock of the Purge function. Get the number of purged sellers or an error.
+func (s *ServiceMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := s.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,273 @@
+// Package sellertest provides a contract test suite for seller.Repository.
+// Every implementation of the interface should pass it.
+package sellertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/seller"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows the seller repository references but does not write.
+type Fixtures struct {
+	// AddLocality stores a locality and returns its id.
+	AddLocality func(t *testing.T) int
+}
+
+// NewSeller returns a valid seller with the given cid.
+func NewSeller(cid, localityID int) domain.Seller {
+	return domain.Seller{
+		CID:         cid,
+		CompanyName: "Meli",
+		Address:     "Fake Street 123",
+		Telephone:   "555-0100",
+		IDLocality:  localityID,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, each time it
+// is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (seller.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save a seller and read it back", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		s := NewSeller(1, fixtures.AddLocality(t))
+
+		// Act
+		id, err := repo.Save(ctx, s)
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		s.ID = id
+		assert.Equal(t, s, obtained)
+	})
+
+	t.Run("it should return every saved seller", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		locality := fixtures.AddLocality(t)
+		for _, cid := range []int{1, 2} {
+			_, err := repo.Save(ctx, NewSeller(cid, locality))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, obtained, 2)
+	})
+
+	t.Run("it should return ErrNotFound when there are no sellers", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.GetAll(ctx)
+
+		assert.True(t, errors.Is(err, seller.ErrNotFound))
+	})
+
+	t.Run("it should return ErrNotFound when the seller does not exist", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.Get(ctx, 1)
+
+		assert.True(t, errors.Is(err, seller.ErrNotFound))
+	})
+
+	t.Run("it should report whether a cid is taken", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		_, err := repo.Save(ctx, NewSeller(1, fixtures.AddLocality(t)))
+		require.NoError(t, err)
+
+		assert.True(t, repo.Exists(ctx, 1))
+		assert.False(t, repo.Exists(ctx, 2))
+	})
+
+	t.Run("it should update every field of a seller", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		id, err := repo.Save(ctx, NewSeller(1, fixtures.AddLocality(t)))
+		require.NoError(t, err)
+		updated := domain.Seller{
+			ID:          id,
+			CID:         2,
+			CompanyName: "Mercado",
+			Address:     "Real Street 321",
+			Telephone:   "555-0199",
+			IDLocality:  fixtures.AddLocality(t),
+		}
+
+		// Act
+		err = repo.Update(ctx, updated)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, updated, obtained)
+	})
+
+	t.Run("it should delete a seller", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		id, err := repo.Save(ctx, NewSeller(1, fixtures.AddLocality(t)))
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, id)
+
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, id)
+		assert.True(t, errors.Is(err, seller.ErrNotFound))
+	})
+
+	t.Run("it should hide a deleted seller unless deleted sellers are included", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		locality := fixtures.AddLocality(t)
+		id, err := repo.Save(ctx, NewSeller(1, locality))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewSeller(2, locality))
+		require.NoError(t, err)
+
+		// Act
+		err = repo.Delete(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		all, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+		assert.False(t, repo.Exists(ctx, 1))
+		_, err = repo.GetByCID(ctx, 1)
+		assert.True(t, errors.Is(err, seller.ErrNotFound))
+
+		withDeleted := softdelete.WithDeleted(ctx)
+		all, err = repo.GetAll(withDeleted)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+		obtained, err := repo.Get(withDeleted, id)
+		require.NoError(t, err)
+		assert.NotNil(t, obtained.DeletedAt)
+		assert.True(t, errors.Is(repo.Delete(ctx, id), seller.ErrNotFound))
+	})
+
+	t.Run("it should restore a deleted seller", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		s := NewSeller(1, fixtures.AddLocality(t))
+		id, err := repo.Save(ctx, s)
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, id))
+
+		// Act
+		err = repo.Restore(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		s.ID = id
+		assert.Equal(t, s, obtained)
+		assert.True(t, errors.Is(repo.Restore(ctx, id), seller.ErrNotFound))
+	})
+
+	t.Run("it should purge the sellers deleted before a time", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		locality := fixtures.AddLocality(t)
+		deleted, err := repo.Save(ctx, NewSeller(1, locality))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewSeller(2, locality))
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, deleted))
+
+		// Act
+		early, errEarly := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		purged, err := repo.Purge(ctx, time.Now().Add(time.Hour))
+
+		// Assert
+		require.NoError(t, errEarly)
+		require.NoError(t, err)
+		assert.Equal(t, 0, early)
+		assert.Equal(t, 1, purged)
+		all, err := repo.GetAll(softdelete.WithDeleted(ctx))
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("it should return ErrNotFound when deleting a missing seller", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		err := repo.Delete(ctx, 1)
+
+		assert.True(t, errors.Is(err, seller.ErrNotFound))
+	})
+
+	t.Run("it should report whether a locality exists", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		locality := fixtures.AddLocality(t)
+
+		assert.True(t, repo.GetLocalityIdFromSeller(ctx, locality))
+		assert.False(t, repo.GetLocalityIdFromSeller(ctx, locality+1))
+	})
+
+	t.Run("it should return the sellers with the given ids", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		locality := fixtures.AddLocality(t)
+		var ids []int
+		for _, cid := range []int{1, 2, 3} {
+			id, err := repo.Save(ctx, NewSeller(cid, locality))
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		obtained, err := repo.GetByIDs(ctx, []int{ids[0], ids[2], ids[2] + 100})
+
+		require.NoError(t, err)
+		require.Len(t, obtained, 2)
+		assert.ElementsMatch(t, []int{ids[0], ids[2]}, []int{obtained[0].ID, obtained[1].ID})
+	})
+
+	t.Run("it should find a seller by its cid", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		s := NewSeller(7, fixtures.AddLocality(t))
+		id, err := repo.Save(ctx, s)
+		require.NoError(t, err)
+
+		// Act
+		obtained, err := repo.GetByCID(ctx, 7)
+		_, errMissing := repo.GetByCID(ctx, 8)
+
+		// Assert
+		require.NoError(t, err)
+		s.ID = id
+		assert.Equal(t, s, obtained)
+		assert.True(t, errors.Is(errMissing, seller.ErrNotFound))
+	})
+
+	t.Run("it should keep the writes of a committed transaction", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		locality := fixtures.AddLocality(t)
+
+		// Act
+		err := repo.InTx(ctx, func(tx seller.Repository) error {
+			_, err := tx.Save(ctx, NewSeller(1, locality))
+			return err
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, repo.Exists(ctx, 1))
+	})
+}
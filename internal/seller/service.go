@@ -3,8 +3,11 @@ package seller
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/davidop97/apiGo/pkg/softdelete"
 )
 
 // Errors
@@ -13,6 +16,7 @@ var (
 	ErrCannotSaveSeller    = errors.New("cannot add new seller")
 	ErrSellerNotExists     = errors.New("seller not exists")
 	ErrUpdateSeller        = errors.New("cannot update this seller")
+	ErrLocalityNotExists   = errors.New("locality does not exist")
 )
 
 type Service interface {
@@ -22,6 +26,12 @@ type Service interface {
 	Delete(ctx context.Context, id int) error
 	Update(ctx context.Context, seller domain.Seller, id int) error
 	GetLocalityIdFromSeller(ctx context.Context, id int) bool
+	GetSellersByIDs(ctx context.Context, ids []int) ([]domain.Seller, error)
+	Import(ctx context.Context, sellers []domain.Seller, opts bulk.Options) ([]bulk.Outcome, error)
+	// Restore undoes the deletion of a seller and returns it.
+	Restore(ctx context.Context, id int) (domain.Seller, error)
+	// Purge removes for good the sellers deleted before the given time.
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
 
 type service struct {
@@ -98,3 +108,70 @@ func (s *service) GetLocalityIdFromSeller(ctx context.Context, id int) bool {
 	err := s.r.GetLocalityIdFromSeller(ctx, id)
 	return err
 }
+
+// GetSellersByIDs returns the sellers whose id is in ids.
+func (s *service) GetSellersByIDs(ctx context.Context, ids []int) ([]domain.Seller, error) {
+	return s.r.GetByIDs(ctx, ids)
+}
+
+// Import saves sellers in a single transaction and returns the outcome of each
+// one. A seller whose locality does not exist is rejected. A cid that is
+// already stored, or repeated in sellers, rejects the seller in insert mode and
+// updates the stored seller in upsert mode. Nothing is saved when a seller is
+// rejected or opts.DryRun is set.
+func (s *service) Import(ctx context.Context, sellers []domain.Seller, opts bulk.Options) ([]bulk.Outcome, error) {
+	var outcomes []bulk.Outcome
+	err := s.r.InTx(ctx, func(r Repository) error {
+		var err error
+		outcomes, err = bulk.Apply(sellers, opts, func(seller domain.Seller) (bulk.Outcome, error) {
+			//Check the locality first, the seller cannot be saved without it.
+			if !r.GetLocalityIdFromSeller(ctx, seller.IDLocality) {
+				return bulk.Failed(ErrLocalityNotExists), nil
+			}
+			stored, err := r.GetByCID(ctx, seller.CID)
+			switch {
+			case errors.Is(err, ErrNotFound):
+				id, err := r.Save(ctx, seller)
+				return bulk.Inserted(id), err
+			case err != nil:
+				return bulk.Outcome{}, err
+			case opts.Mode != bulk.ModeUpsert:
+				return bulk.Failed(ErrSellerAlreadyExists), nil
+			}
+			seller.ID = stored.ID
+			return bulk.Updated(seller.ID), r.Update(ctx, seller)
+		})
+		return err
+	})
+	//A rolled back import is not an error, the outcomes tell why it happened.
+	if errors.Is(err, bulk.ErrRollback) {
+		err = nil
+	}
+	return outcomes, err
+}
+
+// Restore a deleted seller. A seller that is not deleted is returned as it is.
+// Return ErrSellerAlreadyExists if another seller took its cid meanwhile.
+func (s *service) Restore(ctx context.Context, id int) (domain.Seller, error) {
+	seller, err := s.r.Get(softdelete.WithDeleted(ctx), id)
+	if err != nil {
+		return domain.Seller{}, err
+	}
+	if seller.DeletedAt == nil {
+		return seller, nil
+	}
+	//The cid of a deleted seller is free to be taken by a new one.
+	if s.r.Exists(ctx, seller.CID) {
+		return domain.Seller{}, ErrSellerAlreadyExists
+	}
+	if err := s.r.Restore(ctx, id); err != nil {
+		return domain.Seller{}, err
+	}
+	seller.DeletedAt = nil
+	return seller, nil
+}
+
+// Purge removes for good the sellers deleted before the given time.
+func (s *service) Purge(ctx context.Context, before time.Time) (int, error) {
+	return s.r.Purge(ctx, before)
+}
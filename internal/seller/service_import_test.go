@@ -0,0 +1,62 @@
+package seller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/bulk"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestService_Import function.
+// Test the Import function in the following cases:
+// - Insert a new seller and update a stored one in upsert mode.
+// - Reject a seller whose locality does not exist and a stored cid in insert mode.
+func TestService_Import(t *testing.T) {
+	ctx := context.Background()
+	newSeller := func(cid, localityID int) domain.Seller {
+		return domain.Seller{CID: cid, CompanyName: "Meli", Address: "Fake Street 123", Telephone: "555-0100", IDLocality: localityID}
+	}
+
+	t.Run("Insert a new seller and update a stored one in upsert mode", func(t *testing.T) {
+		//Arrange
+		fresh, stored := newSeller(1, 1), newSeller(2, 1)
+		updated := stored
+		updated.ID = 9
+		repositoryMock := NewMockRepository()
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetLocalityIdFromSeller", ctx, 1).Return(true)
+		repositoryMock.On("GetByCID", ctx, 1).Return(domain.Seller{}, ErrNotFound)
+		repositoryMock.On("GetByCID", ctx, 2).Return(domain.Seller{ID: 9, CID: 2}, nil)
+		repositoryMock.On("Save", ctx, fresh).Return(3, nil)
+		repositoryMock.On("Update", ctx, updated).Return(nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		outcomes, err := service.Import(ctx, []domain.Seller{fresh, stored}, bulk.Options{Mode: bulk.ModeUpsert})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Inserted(3), bulk.Updated(9)}, outcomes)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("Reject a seller whose locality does not exist and a stored cid in insert mode", func(t *testing.T) {
+		//Arrange
+		repositoryMock := NewMockRepository()
+		repositoryMock.On("InTx", ctx).Return(nil)
+		repositoryMock.On("GetLocalityIdFromSeller", ctx, 1).Return(true)
+		repositoryMock.On("GetLocalityIdFromSeller", ctx, 5).Return(false)
+		repositoryMock.On("GetByCID", ctx, 2).Return(domain.Seller{ID: 9, CID: 2}, nil)
+		service := NewService(repositoryMock)
+
+		//Act
+		outcomes, err := service.Import(ctx, []domain.Seller{newSeller(1, 5), newSeller(2, 1)}, bulk.Options{Mode: bulk.ModeInsert})
+
+		//Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []bulk.Outcome{bulk.Failed(ErrLocalityNotExists), bulk.Failed(ErrSellerAlreadyExists)}, outcomes)
+		repositoryMock.AssertNotCalled(t, "Save")
+	})
+}
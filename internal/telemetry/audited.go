@@ -0,0 +1,34 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/section"
+)
+
+// auditedService records the changes the readings make in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording in log the updates of the current
+// temperature of the sections made by their readings.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Ingest ingests the readings of a section and records the update of its
+// current temperature, if they change it.
+func (s *auditedService) Ingest(ctx context.Context, sectionID int, readings []domain.SectionReading) (Ingested, error) {
+	res, err := s.Service.Ingest(ctx, sectionID, readings)
+	if err != nil {
+		return Ingested{}, err
+	}
+	if res.Section != nil {
+		section.RecordUpdate(ctx, s.log, res.Section.Before, res.Section.After)
+	}
+	return res, nil
+}
@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditedService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should record the update of the current temperature of the section", func(t *testing.T) {
+		// Arrange
+		repository, log := &RepositoryMock{}, &audit.ServiceMock{}
+		rs := readings(-17)
+		repository.On("InTx", mock.Anything).Return(nil)
+		repository.On("Latest", mock.Anything, 3).Return(domain.SectionReading{}, ErrNoReadings)
+		repository.On("Save", mock.Anything, rs).Return(nil)
+		repository.On("OpenExcursion", mock.Anything, 3).Return(domain.TemperatureExcursion{}, ErrNoExcursion)
+		repository.On("SetSectionTemperature", mock.Anything, 3, -17).Return(nil)
+		before := domain.Section{ID: 3, CurrentTemperature: -18, MinimumTemperature: -20}
+		after := before
+		after.CurrentTemperature = -17
+		log.On("Record", mock.Anything, audit.OpUpdate, "section", 3, before, after).Return(nil)
+		s := NewAuditedService(newService(repository), log)
+
+		// Act
+		_, err := s.Ingest(ctx, 3, rs)
+
+		// Assert
+		assert.NoError(t, err)
+		log.AssertExpectations(t)
+	})
+
+	t.Run("it should record nothing when the readings keep the temperature of the section", func(t *testing.T) {
+		// Arrange
+		repository, log := &RepositoryMock{}, &audit.ServiceMock{}
+		rs := readings(-25)
+		repository.On("InTx", mock.Anything).Return(nil)
+		repository.On("Latest", mock.Anything, 3).Return(domain.SectionReading{RecordedAt: at(30)}, nil)
+		repository.On("Save", mock.Anything, rs).Return(nil)
+		repository.On("OpenExcursion", mock.Anything, 3).Return(domain.TemperatureExcursion{}, ErrNoExcursion)
+		s := NewAuditedService(newService(repository), log)
+
+		// Act
+		_, err := s.Ingest(ctx, 3, rs)
+
+		// Assert
+		assert.NoError(t, err)
+		log.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should record nothing when the readings are rejected", func(t *testing.T) {
+		repository, log := &RepositoryMock{}, &audit.ServiceMock{}
+		s := NewAuditedService(newService(repository), log)
+
+		_, err := s.Ingest(ctx, 3, readings(10000))
+
+		assert.ErrorIs(t, err, ErrTemperatureOutOfRange)
+		log.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,39 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should remove from the cache a section whose temperature is set in a transaction", func(t *testing.T) {
+		// Arrange
+		c := cache.NewLRU(10)
+		require.NoError(t, c.Set(ctx, section.CacheKey(3), []byte(`{"id":3}`), time.Minute))
+		require.NoError(t, c.Set(ctx, section.CacheKey(4), []byte(`{"id":4}`), time.Minute))
+		inner := &RepositoryMock{}
+		inner.On("InTx", ctx).Return(nil)
+		inner.On("SetSectionTemperature", ctx, 3, -18).Return(nil)
+		r := NewCachedRepository(inner, c)
+
+		// Act
+		err := r.InTx(ctx, func(tx Repository) error {
+			return tx.SetSectionTemperature(ctx, 3, -18)
+		})
+
+		// Assert
+		require.NoError(t, err)
+		_, found, _ := c.Get(ctx, section.CacheKey(3))
+		assert.False(t, found)
+		_, found, _ = c.Get(ctx, section.CacheKey(4))
+		assert.True(t, found)
+	})
+}
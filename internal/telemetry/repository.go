@@ -0,0 +1,196 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/dbtx"
+	"github.com/davidop97/apiGo/pkg/events"
+)
+
+// Errors
+var (
+	// ErrNoReadings is returned by Latest for a section without readings.
+	ErrNoReadings = errors.New("section has no readings")
+	// ErrNoExcursion is returned by OpenExcursion for a section within its
+	// temperatures.
+	ErrNoExcursion = errors.New("section has no open excursion")
+)
+
+// Repository stores the readings of the sections and their excursions.
+type Repository interface {
+	// Save stores readings, in a single statement.
+	Save(ctx context.Context, readings []domain.SectionReading) error
+	// Latest returns the reading of a section recorded last, or
+	// ErrNoReadings.
+	Latest(ctx context.Context, sectionID int) (domain.SectionReading, error)
+	// Buckets returns the readings of a section recorded from from until to
+	// summarised by bucket, a whole number of seconds. Buckets are aligned to
+	// the Unix epoch and the ones without readings are left out.
+	Buckets(ctx context.Context, sectionID int, from, to time.Time, bucket time.Duration) ([]domain.ReadingBucket, error)
+	// SetSectionTemperature sets the current temperature of a section.
+	SetSectionTemperature(ctx context.Context, sectionID, temperature int) error
+	// OpenExcursion returns the excursion of a section not ended yet, or
+	// ErrNoExcursion.
+	OpenExcursion(ctx context.Context, sectionID int) (domain.TemperatureExcursion, error)
+	// SaveExcursion stores a new excursion, without an id, or updates the
+	// lowest temperature and the end of an existing one, and returns its id.
+	SaveExcursion(ctx context.Context, e domain.TemperatureExcursion) (int, error)
+	// SaveEvent writes e to the outbox, to be published once committed.
+	SaveEvent(ctx context.Context, e events.Event) error
+	// InTx calls fn with a repository whose queries run in one transaction,
+	// committed only if fn returns nil.
+	InTx(ctx context.Context, fn func(r Repository) error) error
+}
+
+type repository struct {
+	db dbtx.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (r *repository) Save(ctx context.Context, readings []domain.SectionReading) error {
+	if len(readings) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(readings))
+	args := make([]interface{}, 0, 4*len(readings))
+	for _, rd := range readings {
+		values = append(values, "(?, ?, ?, ?)")
+		args = append(args, rd.SectionID, rd.Sensor, rd.Temperature, formatDatetime(rd.RecordedAt))
+	}
+	query := "INSERT INTO section_readings (section_id, sensor, temperature, recorded_at) VALUES " + strings.Join(values, ", ")
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *repository) Latest(ctx context.Context, sectionID int) (domain.SectionReading, error) {
+	query := "SELECT section_id, sensor, temperature, recorded_at FROM section_readings WHERE section_id=? ORDER BY recorded_at DESC, id DESC LIMIT 1"
+	rd := domain.SectionReading{}
+	var recordedAt string
+	err := r.db.QueryRowContext(ctx, query, sectionID).Scan(&rd.SectionID, &rd.Sensor, &rd.Temperature, &recordedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.SectionReading{}, ErrNoReadings
+	}
+	if err != nil {
+		return domain.SectionReading{}, err
+	}
+	if rd.RecordedAt, err = parseDatetime(recordedAt); err != nil {
+		return domain.SectionReading{}, err
+	}
+	return rd, nil
+}
+
+// Buckets groups the readings in the database, by the number of buckets
+// elapsed since the epoch, so only the summaries are read.
+func (r *repository) Buckets(ctx context.Context, sectionID int, from, to time.Time, bucket time.Duration) ([]domain.ReadingBucket, error) {
+	seconds := int64(bucket / time.Second)
+	query := "SELECT FLOOR(TIMESTAMPDIFF(SECOND, '1970-01-01 00:00:00', recorded_at) / ?) AS n, MIN(temperature), AVG(temperature), MAX(temperature), COUNT(*) " +
+		"FROM section_readings WHERE section_id=? AND recorded_at >= ? AND recorded_at < ? GROUP BY n ORDER BY n"
+	rows, err := r.db.QueryContext(ctx, query, seconds, sectionID, formatDatetime(from), formatDatetime(to))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buckets []domain.ReadingBucket
+	for rows.Next() {
+		var n int64
+		b := domain.ReadingBucket{}
+		if err := rows.Scan(&n, &b.Min, &b.Avg, &b.Max, &b.Count); err != nil {
+			return nil, err
+		}
+		b.Start = time.Unix(n*seconds, 0).UTC()
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
+
+func (r *repository) SetSectionTemperature(ctx context.Context, sectionID, temperature int) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE sections SET current_temperature=? WHERE id=?", temperature, sectionID)
+	return err
+}
+
+func (r *repository) OpenExcursion(ctx context.Context, sectionID int) (domain.TemperatureExcursion, error) {
+	query := "SELECT id, section_id, minimum_temperature, lowest_temperature, started_at FROM temperature_excursions WHERE section_id=? AND ended_at IS NULL ORDER BY started_at DESC LIMIT 1"
+	e := domain.TemperatureExcursion{}
+	var startedAt string
+	err := r.db.QueryRowContext(ctx, query, sectionID).Scan(&e.ID, &e.SectionID, &e.MinimumTemperature, &e.LowestTemperature, &startedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.TemperatureExcursion{}, ErrNoExcursion
+	}
+	if err != nil {
+		return domain.TemperatureExcursion{}, err
+	}
+	if e.StartedAt, err = parseDatetime(startedAt); err != nil {
+		return domain.TemperatureExcursion{}, err
+	}
+	return e, nil
+}
+
+func (r *repository) SaveExcursion(ctx context.Context, e domain.TemperatureExcursion) (int, error) {
+	if e.ID != 0 {
+		query := "UPDATE temperature_excursions SET lowest_temperature=?, ended_at=? WHERE id=?"
+		_, err := r.db.ExecContext(ctx, query, e.LowestTemperature, nullDatetime(e.EndedAt), e.ID)
+		return e.ID, err
+	}
+
+	query := "INSERT INTO temperature_excursions (section_id, minimum_temperature, lowest_temperature, started_at, ended_at) VALUES (?, ?, ?, ?, ?)"
+	res, err := r.db.ExecContext(ctx, query, e.SectionID, e.MinimumTemperature, e.LowestTemperature, formatDatetime(e.StartedAt), nullDatetime(e.EndedAt))
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// SaveEvent writes an event to the outbox table.
+func (r *repository) SaveEvent(ctx context.Context, e events.Event) error {
+	return outbox.Save(ctx, r.db, e)
+}
+
+// InTx runs fn on a copy of the repository bound to a transaction.
+func (r *repository) InTx(ctx context.Context, fn func(r Repository) error) error {
+	return dbtx.Run(ctx, r.db, func(tx dbtx.DB) error {
+		return fn(&repository{db: tx})
+	})
+}
+
+const datetimeLayout = "2006-01-02 15:04:05.999999"
+
+func formatDatetime(t time.Time) string {
+	return t.UTC().Format(datetimeLayout)
+}
+
+// nullDatetime stores a missing time as NULL.
+func nullDatetime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatDatetime(*t), Valid: true}
+}
+
+// parseDatetime parses a DATETIME column, read as text or, with the
+// parseTime option of the driver, converted to RFC 3339 by database/sql.
+func parseDatetime(s string) (time.Time, error) {
+	if t, err := time.Parse(datetimeLayout, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, errors.New("telemetry: invalid datetime " + s)
+	}
+	return t, nil
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) Save(ctx context.Context, readings []domain.SectionReading) error {
+	args := r.Called(ctx, readings)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Latest(ctx context.Context, sectionID int) (domain.SectionReading, error) {
+	args := r.Called(ctx, sectionID)
+	return args.Get(0).(domain.SectionReading), args.Error(1)
+}
+
+func (r *RepositoryMock) Buckets(ctx context.Context, sectionID int, from, to time.Time, bucket time.Duration) ([]domain.ReadingBucket, error) {
+	args := r.Called(ctx, sectionID, from, to, bucket)
+	return args.Get(0).([]domain.ReadingBucket), args.Error(1)
+}
+
+func (r *RepositoryMock) SetSectionTemperature(ctx context.Context, sectionID, temperature int) error {
+	args := r.Called(ctx, sectionID, temperature)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) OpenExcursion(ctx context.Context, sectionID int) (domain.TemperatureExcursion, error) {
+	args := r.Called(ctx, sectionID)
+	return args.Get(0).(domain.TemperatureExcursion), args.Error(1)
+}
+
+func (r *RepositoryMock) SaveExcursion(ctx context.Context, e domain.TemperatureExcursion) (int, error) {
+	args := r.Called(ctx, e)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) SaveEvent(ctx context.Context, e events.Event) error {
+	args := r.Called(ctx, e)
+	return args.Error(0)
+}
+
+// InTx runs fn on the mock itself unless an error is set for the call.
+func (r *RepositoryMock) InTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := r.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(r)
+}
@@ -0,0 +1,41 @@
+package telemetry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/producttype/producttypetest"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/section/sectiontest"
+	"github.com/davidop97/apiGo/internal/telemetry"
+	"github.com/davidop97/apiGo/internal/telemetry/telemetrytest"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/internal/warehouse/warehousetest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	telemetrytest.TestRepository(t, func(t *testing.T) (telemetry.Repository, telemetrytest.Fixtures) {
+		db := mysqltest.Open(t)
+		producttypetest.AddProductTypes(t, producttype.NewRepository(db), 1)
+		warehousetest.AddWarehouses(t, warehouse.NewRepository(db), 1)
+		sections := section.NewRepository(db)
+		next := 0
+
+		return telemetry.NewRepository(db), telemetrytest.Fixtures{
+			AddSection: func(t *testing.T) int {
+				next++
+				id, err := sections.Save(context.Background(), sectiontest.NewSection(next))
+				require.NoError(t, err)
+				return id
+			},
+			SectionTemperature: func(t *testing.T, id int) int {
+				s, err := sections.Get(context.Background(), id)
+				require.NoError(t, err)
+				return s.CurrentTemperature
+			},
+		}
+	})
+}
@@ -0,0 +1,279 @@
+// Package telemetry ingests the readings of the temperature sensors of the
+// sections. The latest reading of a section is its current temperature, and
+// a section colder than its minimum temperature is in an excursion, which
+// the outbox announces when it starts and when it ends.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/internal/section"
+)
+
+// Errors
+var (
+	ErrSectionNotFound    = errors.New("section not found")
+	ErrEmpty              = errors.New("no readings")
+	ErrInvalidTemperature = errors.New("temperature must be a finite number")
+	// ErrTemperatureOutOfRange is returned for a temperature the readings
+	// cannot store, see MaxTemperature.
+	ErrTemperatureOutOfRange = errors.New("temperature out of range")
+	// ErrFutureReading is returned for a reading recorded more than
+	// MaxClockSkew after now, which would hold the current temperature of
+	// its section until then.
+	ErrFutureReading = errors.New("reading recorded in the future")
+	ErrInvalidRange  = errors.New("from must be before to")
+	// ErrInvalidBucket is returned for a bucket that is not a whole number
+	// of seconds, or that splits the range in more than MaxBuckets.
+	ErrInvalidBucket = errors.New("invalid bucket")
+)
+
+const (
+	// DefaultHysteresis is how many degrees above its minimum temperature a
+	// section in an excursion must warm up to for the excursion to end, so
+	// a sensor hovering around the minimum does not start a new one with
+	// every reading.
+	DefaultHysteresis = 1.0
+	// DefaultBucket and DefaultRange are the bucket and the range of the
+	// readings summarised when not given.
+	DefaultBucket = 5 * time.Minute
+	DefaultRange  = 24 * time.Hour
+	// MaxBuckets is the most buckets a range may be summarised in.
+	MaxBuckets = 10000
+	// MaxTemperature is the warmest temperature a reading may have, and its
+	// opposite the coldest: the readings are stored as DECIMAL(6,2).
+	MaxTemperature = 9999.99
+	// MaxClockSkew is how far the clock of a sensor may run ahead of the
+	// server's.
+	MaxClockSkew = 5 * time.Minute
+)
+
+// SectionGetter gets a section, e.g. through the cached section repository.
+type SectionGetter interface {
+	Get(ctx context.Context, id int) (domain.Section, error)
+}
+
+type Service interface {
+	// Ingest stores the readings of a section. Those recorded after the
+	// latest one stored set the current temperature of the section and
+	// start or end its excursions.
+	Ingest(ctx context.Context, sectionID int, readings []domain.SectionReading) (Ingested, error)
+	// Readings summarises the readings of a section recorded from from until
+	// to by bucket.
+	Readings(ctx context.Context, sectionID int, from, to time.Time, bucket time.Duration) ([]domain.ReadingBucket, error)
+}
+
+// Ingested is the result of the ingestion of the readings of a section.
+type Ingested struct {
+	Accepted           int `json:"accepted"`
+	CurrentTemperature int `json:"current_temperature"`
+	// Excursion is the excursion the section is in after the readings, if
+	// any.
+	Excursion *domain.TemperatureExcursion `json:"excursion,omitempty"`
+	// Section is the change of the current temperature of the section the
+	// readings made, nil when they did not change it, for the decorators to
+	// record.
+	Section *SectionChange `json:"-"`
+}
+
+// SectionChange is a change of a section made by its readings.
+type SectionChange struct {
+	Before domain.Section
+	After  domain.Section
+}
+
+type service struct {
+	r          Repository
+	sections   SectionGetter
+	hysteresis float64
+	now        func() time.Time
+}
+
+// NewService returns a telemetry service reading the sections from sections
+// and ending the excursions DefaultHysteresis degrees above the minimum.
+func NewService(r Repository, sections SectionGetter) Service {
+	return NewServiceWithHysteresis(r, sections, DefaultHysteresis)
+}
+
+// NewServiceWithHysteresis returns a telemetry service ending the
+// excursions hysteresis degrees above the minimum temperature.
+func NewServiceWithHysteresis(r Repository, sections SectionGetter, hysteresis float64) Service {
+	return &service{r: r, sections: sections, hysteresis: hysteresis, now: time.Now}
+}
+
+// Ingest checks the readings, recorded now when they have no time, and
+// stores them in time order with the changes of the section they bring, in
+// one transaction. It returns the change of the current temperature of the
+// section, if any, in the Section of the result.
+func (s *service) Ingest(ctx context.Context, sectionID int, readings []domain.SectionReading) (Ingested, error) {
+	if len(readings) == 0 {
+		return Ingested{}, ErrEmpty
+	}
+	sect, err := s.section(ctx, sectionID)
+	if err != nil {
+		return Ingested{}, err
+	}
+
+	now := s.now().UTC()
+	sorted := make([]domain.SectionReading, len(readings))
+	for i, rd := range readings {
+		if math.IsNaN(rd.Temperature) || math.IsInf(rd.Temperature, 0) {
+			return Ingested{}, ErrInvalidTemperature
+		}
+		// The database rounds the temperature to two decimals
+		if math.Abs(math.Round(rd.Temperature*100)/100) > MaxTemperature {
+			return Ingested{}, ErrTemperatureOutOfRange
+		}
+		rd.SectionID = sectionID
+		if rd.RecordedAt.IsZero() {
+			rd.RecordedAt = now
+		}
+		if rd.RecordedAt.After(now.Add(MaxClockSkew)) {
+			return Ingested{}, ErrFutureReading
+		}
+		// The database keeps microseconds
+		rd.RecordedAt = rd.RecordedAt.UTC().Truncate(time.Microsecond)
+		sorted[i] = rd
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].RecordedAt.Before(sorted[j].RecordedAt) })
+
+	res := Ingested{Accepted: len(sorted), CurrentTemperature: sect.CurrentTemperature}
+	err = s.r.InTx(ctx, func(r Repository) error {
+		// Late readings are stored, but the section is past them
+		fresh := sorted
+		latest, err := r.Latest(ctx, sectionID)
+		switch {
+		case err == nil:
+			i := sort.Search(len(sorted), func(i int) bool { return sorted[i].RecordedAt.After(latest.RecordedAt) })
+			fresh = sorted[i:]
+		case !errors.Is(err, ErrNoReadings):
+			return err
+		}
+		if err := r.Save(ctx, sorted); err != nil {
+			return err
+		}
+
+		open, err := r.OpenExcursion(ctx, sectionID)
+		switch {
+		case err == nil:
+			res.Excursion = &open
+		case !errors.Is(err, ErrNoExcursion):
+			return err
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+
+		res.CurrentTemperature = int(math.Round(fresh[len(fresh)-1].Temperature))
+		if err := r.SetSectionTemperature(ctx, sectionID, res.CurrentTemperature); err != nil {
+			return err
+		}
+		res.Excursion, err = s.track(ctx, r, sect, res.Excursion, fresh)
+		return err
+	})
+	if err != nil {
+		return Ingested{}, err
+	}
+	if res.CurrentTemperature != sect.CurrentTemperature {
+		after := sect
+		after.CurrentTemperature = res.CurrentTemperature
+		res.Section = &SectionChange{Before: sect, After: after}
+	}
+	return res, nil
+}
+
+// track follows the excursion of sect, open or nil, through readings in time
+// order. A reading below the minimum temperature of sect starts an
+// excursion, whose lowest temperature the next ones lower, and a reading at
+// least the hysteresis above the minimum ends it. It returns the excursion
+// still open after readings.
+func (s *service) track(ctx context.Context, r Repository, sect domain.Section, open *domain.TemperatureExcursion, readings []domain.SectionReading) (*domain.TemperatureExcursion, error) {
+	minimum := float64(sect.MinimumTemperature)
+	// lowered is set when the lowest temperature of open is not saved yet
+	lowered := false
+	for _, rd := range readings {
+		switch {
+		case open == nil && rd.Temperature < minimum:
+			open = &domain.TemperatureExcursion{
+				SectionID:          sect.ID,
+				MinimumTemperature: sect.MinimumTemperature,
+				LowestTemperature:  rd.Temperature,
+				StartedAt:          rd.RecordedAt,
+			}
+			if err := s.saveExcursion(ctx, r, open, outbox.SectionExcursionStarted); err != nil {
+				return nil, err
+			}
+			lowered = false
+		case open == nil:
+		case rd.Temperature >= minimum+s.hysteresis:
+			endedAt := rd.RecordedAt
+			open.EndedAt = &endedAt
+			if err := s.saveExcursion(ctx, r, open, outbox.SectionExcursionEnded); err != nil {
+				return nil, err
+			}
+			open, lowered = nil, false
+		case rd.Temperature < open.LowestTemperature:
+			open.LowestTemperature = rd.Temperature
+			lowered = true
+		}
+	}
+	if lowered {
+		if _, err := r.SaveExcursion(ctx, *open); err != nil {
+			return nil, err
+		}
+	}
+	return open, nil
+}
+
+// saveExcursion stores e, setting its id, with an event of eventType.
+func (s *service) saveExcursion(ctx context.Context, r Repository, e *domain.TemperatureExcursion, eventType string) error {
+	id, err := r.SaveExcursion(ctx, *e)
+	if err != nil {
+		return err
+	}
+	e.ID = id
+	event, err := outbox.NewEvent(eventType, e.SectionID, *e)
+	if err != nil {
+		return err
+	}
+	return r.SaveEvent(ctx, event)
+}
+
+// Readings fills the range and the bucket not given with the defaults: the
+// DefaultRange until now, in buckets of DefaultBucket.
+func (s *service) Readings(ctx context.Context, sectionID int, from, to time.Time, bucket time.Duration) ([]domain.ReadingBucket, error) {
+	if to.IsZero() {
+		to = s.now()
+	}
+	if from.IsZero() {
+		from = to.Add(-DefaultRange)
+	}
+	if bucket == 0 {
+		bucket = DefaultBucket
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+	if bucket < time.Second || bucket%time.Second != 0 || (to.Sub(from)+bucket-1)/bucket > MaxBuckets {
+		return nil, ErrInvalidBucket
+	}
+	if _, err := s.section(ctx, sectionID); err != nil {
+		return nil, err
+	}
+	return s.r.Buckets(ctx, sectionID, from, to, bucket)
+}
+
+// section returns the section id, or ErrSectionNotFound.
+func (s *service) section(ctx context.Context, id int) (domain.Section, error) {
+	sect, err := s.sections.Get(ctx, id)
+	if errors.Is(err, section.ErrNotFound) {
+		return domain.Section{}, ErrSectionNotFound
+	}
+	return sect, err
+}
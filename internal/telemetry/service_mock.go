@@ -0,0 +1,23 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func (s *ServiceMock) Ingest(ctx context.Context, sectionID int, readings []domain.SectionReading) (Ingested, error) {
+	args := s.Called(ctx, sectionID, readings)
+	return args.Get(0).(Ingested), args.Error(1)
+}
+
+func (s *ServiceMock) Readings(ctx context.Context, sectionID int, from, to time.Time, bucket time.Duration) ([]domain.ReadingBucket, error) {
+	args := s.Called(ctx, sectionID, from, to, bucket)
+	return args.Get(0).([]domain.ReadingBucket), args.Error(1)
+}
@@ -0,0 +1,230 @@
+package telemetry
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// at returns a time minutes after a fixed hour.
+func at(minutes float64) time.Time {
+	return time.Date(2023, 10, 11, 12, 0, 0, 0, time.UTC).Add(time.Duration(minutes * float64(time.Minute)))
+}
+
+// newService returns a service over repository whose section 3 has a minimum
+// temperature of -20 and whose clock stops at at(60).
+func newService(repository *RepositoryMock) Service {
+	sections := &section.ServiceMock{}
+	sections.On("Get", mock.Anything, 3).Return(domain.Section{ID: 3, CurrentTemperature: -18, MinimumTemperature: -20}, nil)
+	sections.On("Get", mock.Anything, mock.Anything).Return(domain.Section{}, section.ErrNotFound)
+	s := NewService(repository, sections).(*service)
+	s.now = func() time.Time { return at(60) }
+	return s
+}
+
+func readings(temperatures ...float64) []domain.SectionReading {
+	var rs []domain.SectionReading
+	for i, t := range temperatures {
+		rs = append(rs, domain.SectionReading{SectionID: 3, Temperature: t, RecordedAt: at(float64(i))})
+	}
+	return rs
+}
+
+func TestService_Ingest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should start an excursion below the minimum and end it past the hysteresis", func(t *testing.T) {
+		// Arrange
+		repository := &RepositoryMock{}
+		rs := readings(-19, -21, -20.5, -22, -19.5, -18.5, -20.5)
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Latest", ctx, 3).Return(domain.SectionReading{}, ErrNoReadings)
+		repository.On("Save", ctx, rs).Return(nil)
+		repository.On("OpenExcursion", ctx, 3).Return(domain.TemperatureExcursion{}, ErrNoExcursion)
+		repository.On("SetSectionTemperature", ctx, 3, -21).Return(nil)
+		var saved []domain.TemperatureExcursion
+		repository.On("SaveExcursion", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(domain.TemperatureExcursion))
+		}).Return(1, nil)
+		var published []events.Event
+		repository.On("SaveEvent", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			published = append(published, args.Get(1).(events.Event))
+		}).Return(nil)
+
+		// Act
+		ingested, err := newService(repository).Ingest(ctx, 3, rs)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 7, ingested.Accepted)
+		assert.Equal(t, -21, ingested.CurrentTemperature)
+		require.NotNil(t, ingested.Section)
+		assert.Equal(t, -18, ingested.Section.Before.CurrentTemperature)
+		assert.Equal(t, -21, ingested.Section.After.CurrentTemperature)
+		// The second excursion started with the last reading
+		require.NotNil(t, ingested.Excursion)
+		assert.Equal(t, at(6), ingested.Excursion.StartedAt)
+		require.Len(t, saved, 3)
+		assert.Equal(t, -21.0, saved[0].LowestTemperature)
+		assert.Equal(t, -22.0, saved[1].LowestTemperature)
+		assert.Equal(t, at(1), saved[1].StartedAt)
+		assert.Equal(t, at(5), *saved[1].EndedAt)
+		require.Len(t, published, 3)
+		assert.Equal(t, outbox.SectionExcursionStarted, published[0].Type)
+		assert.Equal(t, outbox.SectionExcursionEnded, published[1].Type)
+		assert.Equal(t, outbox.SectionExcursionStarted, published[2].Type)
+		assert.Equal(t, 3, published[0].AggregateID)
+	})
+
+	t.Run("it should lower the lowest temperature of the excursion the section is in", func(t *testing.T) {
+		// Arrange
+		repository := &RepositoryMock{}
+		open := domain.TemperatureExcursion{ID: 8, SectionID: 3, MinimumTemperature: -20, LowestTemperature: -21, StartedAt: at(-10)}
+		rs := readings(-20.5, -23, -19.5)
+		lowered := open
+		lowered.LowestTemperature = -23
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Latest", ctx, 3).Return(domain.SectionReading{RecordedAt: at(-1)}, nil)
+		repository.On("Save", ctx, rs).Return(nil)
+		repository.On("OpenExcursion", ctx, 3).Return(open, nil)
+		repository.On("SetSectionTemperature", ctx, 3, -20).Return(nil)
+		repository.On("SaveExcursion", ctx, lowered).Return(8, nil)
+
+		// Act
+		ingested, err := newService(repository).Ingest(ctx, 3, rs)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &lowered, ingested.Excursion)
+		repository.AssertExpectations(t)
+		repository.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should store late readings without changing the section", func(t *testing.T) {
+		// Arrange
+		repository := &RepositoryMock{}
+		rs := readings(-25, -24)
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Latest", ctx, 3).Return(domain.SectionReading{RecordedAt: at(30)}, nil)
+		repository.On("Save", ctx, rs).Return(nil)
+		repository.On("OpenExcursion", ctx, 3).Return(domain.TemperatureExcursion{}, ErrNoExcursion)
+
+		// Act
+		ingested, err := newService(repository).Ingest(ctx, 3, rs)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, Ingested{Accepted: 2, CurrentTemperature: -18}, ingested)
+		repository.AssertNotCalled(t, "SetSectionTemperature", mock.Anything, mock.Anything, mock.Anything)
+		repository.AssertNotCalled(t, "SaveExcursion", mock.Anything, mock.Anything)
+	})
+
+	t.Run("it should sort the readings and record the ones without a time now", func(t *testing.T) {
+		// Arrange
+		repository := &RepositoryMock{}
+		sorted := []domain.SectionReading{
+			{SectionID: 3, Temperature: -18, RecordedAt: at(10)},
+			{SectionID: 3, Temperature: -17, RecordedAt: at(60)},
+		}
+		repository.On("InTx", ctx).Return(nil)
+		repository.On("Latest", ctx, 3).Return(domain.SectionReading{}, ErrNoReadings)
+		repository.On("Save", ctx, sorted).Return(nil)
+		repository.On("OpenExcursion", ctx, 3).Return(domain.TemperatureExcursion{}, ErrNoExcursion)
+		repository.On("SetSectionTemperature", ctx, 3, -17).Return(nil)
+
+		// Act
+		_, err := newService(repository).Ingest(ctx, 3, []domain.SectionReading{{Temperature: -17}, {Temperature: -18, RecordedAt: at(10)}})
+
+		// Assert
+		require.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("it should reject the readings of a section that does not exist", func(t *testing.T) {
+		repository := &RepositoryMock{}
+
+		_, err := newService(repository).Ingest(ctx, 4, readings(-18))
+
+		assert.ErrorIs(t, err, ErrSectionNotFound)
+		repository.AssertNotCalled(t, "InTx", mock.Anything)
+	})
+
+	t.Run("it should reject a temperature that is not a number", func(t *testing.T) {
+		repository := &RepositoryMock{}
+
+		_, err := newService(repository).Ingest(ctx, 3, readings(-18, math.NaN()))
+
+		assert.ErrorIs(t, err, ErrInvalidTemperature)
+		repository.AssertNotCalled(t, "InTx", mock.Anything)
+	})
+
+	t.Run("it should reject a temperature the readings cannot store", func(t *testing.T) {
+		repository := &RepositoryMock{}
+
+		_, errWarm := newService(repository).Ingest(ctx, 3, readings(-18, 10000))
+		_, errCold := newService(repository).Ingest(ctx, 3, readings(-9999.996))
+
+		assert.ErrorIs(t, errWarm, ErrTemperatureOutOfRange)
+		assert.ErrorIs(t, errCold, ErrTemperatureOutOfRange)
+		repository.AssertNotCalled(t, "InTx", mock.Anything)
+	})
+
+	t.Run("it should reject a reading recorded more than the clock skew after now", func(t *testing.T) {
+		repository := &RepositoryMock{}
+		skewed := []domain.SectionReading{{Temperature: -18, RecordedAt: at(60).Add(MaxClockSkew)}, {Temperature: -19, RecordedAt: at(60).Add(MaxClockSkew + time.Microsecond)}}
+
+		_, err := newService(repository).Ingest(ctx, 3, skewed)
+
+		assert.ErrorIs(t, err, ErrFutureReading)
+		repository.AssertNotCalled(t, "InTx", mock.Anything)
+	})
+}
+
+func TestService_Readings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should summarise the last day in buckets of five minutes by default", func(t *testing.T) {
+		// Arrange
+		repository := &RepositoryMock{}
+		buckets := []domain.ReadingBucket{{Start: at(0), Min: -20, Avg: -19, Max: -18, Count: 3}}
+		repository.On("Buckets", ctx, 3, at(60).Add(-DefaultRange), at(60), DefaultBucket).Return(buckets, nil)
+
+		// Act
+		obtained, err := newService(repository).Readings(ctx, 3, time.Time{}, time.Time{}, 0)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, buckets, obtained)
+	})
+
+	t.Run("it should reject an empty range", func(t *testing.T) {
+		_, err := newService(&RepositoryMock{}).Readings(ctx, 3, at(10), at(10), time.Minute)
+
+		assert.ErrorIs(t, err, ErrInvalidRange)
+	})
+
+	t.Run("it should reject a bucket that is not a whole number of seconds or too small for the range", func(t *testing.T) {
+		service := newService(&RepositoryMock{})
+
+		_, errFraction := service.Readings(ctx, 3, at(0), at(10), 1500*time.Millisecond)
+		_, errTooMany := service.Readings(ctx, 3, at(0), at(0).Add(MaxBuckets*time.Second+1), time.Second)
+
+		assert.ErrorIs(t, errFraction, ErrInvalidBucket)
+		assert.ErrorIs(t, errTooMany, ErrInvalidBucket)
+	})
+
+	t.Run("it should return ErrSectionNotFound for a section that does not exist", func(t *testing.T) {
+		_, err := newService(&RepositoryMock{}).Readings(ctx, 4, at(0), at(10), time.Minute)
+
+		assert.ErrorIs(t, err, ErrSectionNotFound)
+	})
+}
@@ -0,0 +1,122 @@
+// Package telemetrytest provides a contract test suite for
+// telemetry.Repository. Every implementation of the interface should pass it.
+package telemetrytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores and reads the sections the telemetry repository writes
+// the readings of.
+type Fixtures struct {
+	// AddSection stores a section and returns its id.
+	AddSection func(t *testing.T) int
+	// SectionTemperature returns the current temperature of a section.
+	SectionTemperature func(t *testing.T, id int) int
+}
+
+// At returns the time of a reading, minutes after a fixed hour.
+func At(minutes float64) time.Time {
+	return time.Date(2023, 10, 11, 12, 0, 0, 0, time.UTC).Add(time.Duration(minutes * float64(time.Minute)))
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (telemetry.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save readings and return the latest one of a section", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		id, other := fixtures.AddSection(t), fixtures.AddSection(t)
+		readings := []domain.SectionReading{
+			{SectionID: id, Sensor: "north", Temperature: -18.5, RecordedAt: At(0)},
+			{SectionID: id, Sensor: "south", Temperature: -17.25, RecordedAt: At(1.5)},
+			{SectionID: other, Temperature: 4, RecordedAt: At(5)},
+		}
+
+		// Act
+		err := repo.Save(ctx, readings)
+		require.NoError(t, err)
+		latest, err := repo.Latest(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, readings[1].Sensor, latest.Sensor)
+		assert.Equal(t, readings[1].Temperature, latest.Temperature)
+		assert.True(t, readings[1].RecordedAt.Equal(latest.RecordedAt), latest.RecordedAt)
+	})
+
+	t.Run("it should return ErrNoReadings for a section without readings", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+
+		_, err := repo.Latest(ctx, fixtures.AddSection(t))
+
+		assert.ErrorIs(t, err, telemetry.ErrNoReadings)
+	})
+
+	t.Run("it should summarise the readings of a range by bucket", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		id := fixtures.AddSection(t)
+		var readings []domain.SectionReading
+		for i, temperature := range []float64{-20, -18, -16, -10, -12, 0} {
+			readings = append(readings, domain.SectionReading{SectionID: id, Temperature: temperature, RecordedAt: At(float64(2 * i))})
+		}
+		require.NoError(t, repo.Save(ctx, readings))
+
+		// Act
+		buckets, err := repo.Buckets(ctx, id, At(0), At(10), 5*time.Minute)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, buckets, 2)
+		assert.True(t, At(0).Equal(buckets[0].Start), buckets[0].Start)
+		assert.Equal(t, domain.ReadingBucket{Start: buckets[0].Start, Min: -20, Avg: -18, Max: -16, Count: 3}, buckets[0])
+		assert.True(t, At(5).Equal(buckets[1].Start), buckets[1].Start)
+		assert.Equal(t, domain.ReadingBucket{Start: buckets[1].Start, Min: -12, Avg: -11, Max: -10, Count: 2}, buckets[1])
+	})
+
+	t.Run("it should set the current temperature of a section", func(t *testing.T) {
+		repo, fixtures := newRepository(t)
+		id := fixtures.AddSection(t)
+
+		err := repo.SetSectionTemperature(ctx, id, -19)
+
+		require.NoError(t, err)
+		assert.Equal(t, -19, fixtures.SectionTemperature(t, id))
+	})
+
+	t.Run("it should keep an excursion open until it ends", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		id := fixtures.AddSection(t)
+		_, err := repo.OpenExcursion(ctx, id)
+		require.ErrorIs(t, err, telemetry.ErrNoExcursion)
+		e := domain.TemperatureExcursion{SectionID: id, MinimumTemperature: -20, LowestTemperature: -21.5, StartedAt: At(0)}
+
+		// Act
+		e.ID, err = repo.SaveExcursion(ctx, e)
+		require.NoError(t, err)
+		open, err := repo.OpenExcursion(ctx, id)
+		require.NoError(t, err)
+		endedAt := At(30)
+		e.LowestTemperature, e.EndedAt = -23, &endedAt
+		_, err = repo.SaveExcursion(ctx, e)
+		require.NoError(t, err)
+		_, errEnded := repo.OpenExcursion(ctx, id)
+
+		// Assert
+		assert.Equal(t, e.ID, open.ID)
+		assert.Equal(t, -21.5, open.LowestTemperature)
+		assert.True(t, At(0).Equal(open.StartedAt), open.StartedAt)
+		assert.ErrorIs(t, errEnded, telemetry.ErrNoExcursion)
+	})
+}
@@ -0,0 +1,55 @@
+package warehouse
+
+import (
+	"context"
+
+	"github.com/davidop97/apiGo/internal/audit"
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// entity is the name of warehouses in the audit log.
+const entity = "warehouse"
+
+// auditedService records the mutations of a Service in the audit log.
+type auditedService struct {
+	Service
+	log audit.Recorder
+}
+
+// NewAuditedService returns s recording its creations, updates, deletions
+// and restorations in log.
+func NewAuditedService(s Service, log audit.Recorder) Service {
+	return &auditedService{Service: s, log: log}
+}
+
+// Save saves a warehouse and records its creation.
+func (s *auditedService) Save(ctx context.Context, w domain.Warehouse) (int, error) {
+	id, err := s.Service.Save(ctx, w)
+	if err != nil {
+		return 0, err
+	}
+	w.ID = id
+	audit.Created(ctx, s.log, entity, id, w)
+	return id, nil
+}
+
+// Update updates a warehouse and records it as it was before and after.
+func (s *auditedService) Update(ctx context.Context, w domain.Warehouse) error {
+	return audit.Update(ctx, s.log, entity, w.ID, w, s.Service.Get, func() error {
+		return s.Service.Update(ctx, w)
+	})
+}
+
+// Delete deletes a warehouse and records it as it was before.
+func (s *auditedService) Delete(ctx context.Context, id int) error {
+	return audit.Delete(ctx, s.log, entity, id, s.Service.Get, func() error {
+		return s.Service.Delete(ctx, id)
+	})
+}
+
+// Restore restores a warehouse and records it as it was before and after.
+func (s *auditedService) Restore(ctx context.Context, id int) (domain.Warehouse, error) {
+	return audit.Restore(ctx, s.log, entity, id, s.Service.Get, func() (domain.Warehouse, error) {
+		return s.Service.Restore(ctx, id)
+	})
+}
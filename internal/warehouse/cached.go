@@ -0,0 +1,54 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+)
+
+// cacheEntity is the name of warehouses in the cache keys.
+const cacheEntity = "warehouse"
+
+// cachedRepository reads the warehouses of a Repository through a cache and
+// invalidates the ones it writes.
+type cachedRepository struct {
+	Repository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedRepository returns r reading the warehouses by id through c, where
+// they are kept for ttl, and removing from c the warehouses it updates,
+// deletes or restores.
+func NewCachedRepository(r Repository, c cache.Cache, ttl time.Duration) Repository {
+	return &cachedRepository{Repository: r, cache: c, ttl: ttl}
+}
+
+// Get returns a warehouse from the cache, or from the repository when it is
+// missing. Reads including the deleted warehouses skip the cache.
+func (r *cachedRepository) Get(ctx context.Context, id int) (domain.Warehouse, error) {
+	if softdelete.Included(ctx) {
+		return r.Repository.Get(ctx, id)
+	}
+	return cache.Through(ctx, r.cache, cache.Key(cacheEntity, id), r.ttl, func() (domain.Warehouse, error) {
+		return r.Repository.Get(ctx, id)
+	})
+}
+
+func (r *cachedRepository) Update(ctx context.Context, w domain.Warehouse) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, w.ID))
+	return r.Repository.Update(ctx, w)
+}
+
+func (r *cachedRepository) Delete(ctx context.Context, id int) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, id))
+	return r.Repository.Delete(ctx, id)
+}
+
+func (r *cachedRepository) Restore(ctx context.Context, id int) error {
+	defer cache.Invalidate(ctx, r.cache, cache.Key(cacheEntity, id))
+	return r.Repository.Restore(ctx, id)
+}
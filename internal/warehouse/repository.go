@@ -3,8 +3,12 @@ package warehouse
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/davidop97/apiGo/pkg/sqlin"
 )
 
 // Repository encapsulates the storage of a warehouse.
@@ -14,13 +18,30 @@ type Repository interface {
 	Exists(ctx context.Context, warehouseCode string) bool
 	Save(ctx context.Context, w domain.Warehouse) (int, error)
 	Update(ctx context.Context, w domain.Warehouse) error
+	// Delete soft deletes a warehouse, which reads skip unless their context
+	// includes deleted rows (see softdelete.WithDeleted).
 	Delete(ctx context.Context, id int) error
+	// Restore undoes the deletion of a warehouse.
+	Restore(ctx context.Context, id int) error
+	// Purge removes for good the warehouses deleted before the given time.
+	Purge(ctx context.Context, before time.Time) (int, error)
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error)
+	// Sections returns the sections of a warehouse, by section number.
+	Sections(ctx context.Context, id int) ([]domain.Section, error)
+	// CountBatches counts the product batches stored in the sections of a
+	// warehouse.
+	CountBatches(ctx context.Context, id int) (int, error)
+	// CountEmployees counts the employees, not deleted, of a warehouse.
+	CountEmployees(ctx context.Context, id int) (int, error)
 }
 
 type repository struct {
 	db *sql.DB
 }
 
+// warehouseColumns are the columns of the warehouses table, in the order they are scanned.
+const warehouseColumns = "id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, deleted_at"
+
 func NewRepository(db *sql.DB) Repository {
 	return &repository{
 		db: db,
@@ -28,8 +49,8 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
-	query := "SELECT * FROM warehouses"
-	rows, err := r.db.Query(query)
+	query := "SELECT " + warehouseColumns + " FROM warehouses WHERE " + softdelete.Visible(ctx, "deleted_at")
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +59,7 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
 
 	for rows.Next() {
 		w := domain.Warehouse{}
-		_ = rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+		_ = rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, softdelete.Scan(&w.DeletedAt))
 		warehouses = append(warehouses, w)
 	}
 
@@ -46,10 +67,13 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
 }
 
 func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error) {
-	query := "SELECT * FROM warehouses WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT " + warehouseColumns + " FROM warehouses WHERE id=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, id)
 	w := domain.Warehouse{}
-	err := row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	err := row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, softdelete.Scan(&w.DeletedAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Warehouse{}, ErrNotFound
+	}
 	if err != nil {
 		return domain.Warehouse{}, err
 	}
@@ -58,8 +82,8 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error)
 }
 
 func (r *repository) Exists(ctx context.Context, warehouseCode string) bool {
-	query := "SELECT warehouse_code FROM warehouses WHERE warehouse_code=?;"
-	row := r.db.QueryRow(query, warehouseCode)
+	query := "SELECT warehouse_code FROM warehouses WHERE warehouse_code=? AND " + softdelete.Visible(ctx, "deleted_at")
+	row := r.db.QueryRowContext(ctx, query, warehouseCode)
 	err := row.Scan(&warehouseCode)
 	return err == nil
 }
@@ -89,7 +113,7 @@ func (r *repository) Save(ctx context.Context, w domain.Warehouse) (int, error)
 }
 
 func (r *repository) Update(ctx context.Context, w domain.Warehouse) error {
-	query := "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"
+	query := "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=? AND deleted_at IS NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
@@ -108,14 +132,35 @@ func (r *repository) Update(ctx context.Context, w domain.Warehouse) error {
 	return nil
 }
 
+// Delete soft deletes a warehouse: it is hidden from the reads until it is
+// restored or purged.
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM warehouses WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE warehouses SET deleted_at=? WHERE id=? AND deleted_at IS NULL"
+	return r.exec(ctx, query, softdelete.Format(time.Now()), id)
+}
+
+// Restore undoes the soft deletion of a warehouse.
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE warehouses SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	return r.exec(ctx, query, id)
+}
+
+// Purge removes for good the warehouses deleted before the given time, except
+// those that still have inbound orders.
+func (r *repository) Purge(ctx context.Context, before time.Time) (int, error) {
+	query := "DELETE FROM warehouses WHERE deleted_at < ? AND id NOT IN (SELECT warehouse_id FROM inboudOrders)"
+	res, err := r.db.ExecContext(ctx, query, softdelete.Format(before))
 	if err != nil {
-		return err
+		return 0, err
 	}
+	affect, err := res.RowsAffected()
+	return int(affect), err
+}
 
-	res, err := stmt.Exec(id)
+// exec runs a statement changing one warehouse and returns ErrNotFound if no
+// row was changed.
+func (r *repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -131,3 +176,64 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// GetByIDs returns the warehouses whose id is in ids. Ids without a warehouse
+// are skipped.
+func (r *repository) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	in, args := sqlin.Ints(ids)
+	query := "SELECT " + warehouseColumns + " FROM warehouses WHERE id IN " + in + " AND " + softdelete.Visible(ctx, "deleted_at")
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var warehouses []domain.Warehouse
+	for rows.Next() {
+		w := domain.Warehouse{}
+		if err := rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, softdelete.Scan(&w.DeletedAt)); err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, w)
+	}
+	return warehouses, rows.Err()
+}
+
+func (r *repository) Sections(ctx context.Context, id int) ([]domain.Section, error) {
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type FROM sections WHERE warehouse_id=? ORDER BY section_number"
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []domain.Section
+	for rows.Next() {
+		s := domain.Section{}
+		if err := rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID); err != nil {
+			return nil, err
+		}
+		sections = append(sections, s)
+	}
+	return sections, rows.Err()
+}
+
+func (r *repository) CountBatches(ctx context.Context, id int) (int, error) {
+	query := "SELECT COUNT(*) FROM productBatches JOIN sections ON sections.id = productBatches.section_id WHERE sections.warehouse_id=?"
+	return r.count(ctx, query, id)
+}
+
+func (r *repository) CountEmployees(ctx context.Context, id int) (int, error) {
+	query := "SELECT COUNT(*) FROM employees WHERE warehouse_id=? AND deleted_at IS NULL"
+	return r.count(ctx, query, id)
+}
+
+// count runs a query counting rows.
+func (r *repository) count(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&n)
+	return n, err
+}
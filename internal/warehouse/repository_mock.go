@@ -2,6 +2,7 @@ package warehouse
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/stretchr/testify/mock"
@@ -40,3 +41,33 @@ func (r *RepositoryMock) Delete(ctx context.Context, id int) error {
 	args := r.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (r *RepositoryMock) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	args := r.Called(ctx, ids)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+
+func (r *RepositoryMock) Restore(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := r.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) Sections(ctx context.Context, id int) ([]domain.Section, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
+
+func (r *RepositoryMock) CountBatches(ctx context.Context, id int) (int, error) {
+	args := r.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) CountEmployees(ctx context.Context, id int) (int, error) {
+	args := r.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,74 @@
+package warehouse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/batch"
+	"github.com/davidop97/apiGo/internal/batch/batchtest"
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/employee"
+	"github.com/davidop97/apiGo/internal/employee/employeetest"
+	"github.com/davidop97/apiGo/internal/product"
+	"github.com/davidop97/apiGo/internal/product/producttest"
+	"github.com/davidop97/apiGo/internal/producttype"
+	"github.com/davidop97/apiGo/internal/producttype/producttypetest"
+	"github.com/davidop97/apiGo/internal/section"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/internal/warehouse/warehousetest"
+	"github.com/davidop97/apiGo/pkg/cache"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	warehousetest.TestRepository(t, newRepository(func(r warehouse.Repository) warehouse.Repository { return r }))
+}
+
+func TestRepository_MySQLCached(t *testing.T) {
+	warehousetest.TestRepository(t, newRepository(func(r warehouse.Repository) warehouse.Repository {
+		return warehouse.NewCachedRepository(r, cache.NewLRU(100), time.Minute)
+	}))
+}
+
+// newRepository returns the repositories the contract suite runs on, as
+// returned by wrap, on a database with the product type its sections
+// reference.
+func newRepository(wrap func(warehouse.Repository) warehouse.Repository) func(t *testing.T) (warehouse.Repository, warehousetest.Fixtures) {
+	return func(t *testing.T) (warehouse.Repository, warehousetest.Fixtures) {
+		db := mysqltest.Open(t)
+		producttypetest.AddProductTypes(t, producttype.NewRepository(db), 1)
+		sections := section.NewRepository(db)
+		products := product.NewRepository(db)
+		batches := batch.NewRepository(db)
+		employees := employee.NewRepository(db)
+		next := 0
+
+		fixtures := warehousetest.Fixtures{
+			AddSection: func(t *testing.T, s domain.Section) int {
+				id, err := sections.Save(context.Background(), s)
+				require.NoError(t, err)
+				return id
+			},
+			AddBatch: func(t *testing.T, sectionID int) {
+				next++
+				productID, err := products.Save(context.Background(), producttest.NewProduct(fmt.Sprintf("P%d", next)))
+				require.NoError(t, err)
+				_, err = batches.Save(context.Background(), batchtest.NewBatch(next, productID, sectionID))
+				require.NoError(t, err)
+			},
+			AddEmployee: func(t *testing.T, warehouseID int) int {
+				next++
+				id, err := employees.Save(context.Background(), employeetest.NewEmployee(fmt.Sprintf("E%d", next), warehouseID))
+				require.NoError(t, err)
+				return id
+			},
+			DeleteEmployee: func(t *testing.T, id int) {
+				require.NoError(t, employees.Delete(context.Background(), id))
+			},
+		}
+		return wrap(warehouse.NewRepository(db)), fixtures
+	}
+}
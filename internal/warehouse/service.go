@@ -3,8 +3,11 @@ package warehouse
 import (
 	"context"
 	"errors"
+	"math"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/softdelete"
 )
 
 // Errors
@@ -20,6 +23,14 @@ type Service interface {
 	Save(ctx context.Context, w domain.Warehouse) (int, error)
 	Delete(ctx context.Context, id int) error
 	Update(ctx context.Context, w domain.Warehouse) error
+	GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error)
+	Restore(ctx context.Context, id int) (domain.Warehouse, error)
+	Purge(ctx context.Context, before time.Time) (int, error)
+	// Sections returns the sections of a warehouse.
+	Sections(ctx context.Context, id int) ([]domain.Section, error)
+	// Summary aggregates the capacity, temperatures, batches and employees
+	// of a warehouse.
+	Summary(ctx context.Context, id int) (domain.WarehouseSummary, error)
 }
 
 type service struct {
@@ -98,3 +109,91 @@ func (s *service) Update(ctx context.Context, w domain.Warehouse) error {
 
 	return nil
 }
+
+// GetByIDs returns the warehouses whose id is in ids.
+func (s *service) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	return s.rp.GetByIDs(ctx, ids)
+}
+
+// Restore restores a deleted warehouse and returns it, a warehouse that isn't deleted is returned as it is.
+// Returns error if the warehouse doesn't exists or if another warehouse took its code meanwhile
+func (s *service) Restore(ctx context.Context, id int) (domain.Warehouse, error) {
+	warehouse, err := s.rp.Get(softdelete.WithDeleted(ctx), id)
+	if err != nil {
+		return domain.Warehouse{}, ErrNotFound
+	}
+	if warehouse.DeletedAt == nil {
+		return warehouse, nil
+	}
+	if s.rp.Exists(ctx, warehouse.WarehouseCode) {
+		return domain.Warehouse{}, ErrDuplicateWarehouse
+	}
+
+	if err := s.rp.Restore(ctx, id); err != nil {
+		return domain.Warehouse{}, err
+	}
+	warehouse.DeletedAt = nil
+	return warehouse, nil
+}
+
+// Purge removes for good the warehouses deleted before the given time
+func (s *service) Purge(ctx context.Context, before time.Time) (int, error) {
+	return s.rp.Purge(ctx, before)
+}
+
+// Sections returns the sections of a warehouse, returns ErrNotFound if the warehouse doesn't exists
+func (s *service) Sections(ctx context.Context, id int) ([]domain.Section, error) {
+	if _, err := s.rp.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	sections, err := s.rp.Sections(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sections == nil {
+		sections = []domain.Section{}
+	}
+	return sections, nil
+}
+
+// Summary aggregates the sections of a warehouse, returns error if the warehouse doesn't exists
+func (s *service) Summary(ctx context.Context, id int) (domain.WarehouseSummary, error) {
+	sections, err := s.Sections(ctx, id)
+	if err != nil {
+		return domain.WarehouseSummary{}, err
+	}
+	summary := domain.WarehouseSummary{
+		WarehouseID:   id,
+		SectionCount:  len(sections),
+		OverCapacity:  []domain.Section{},
+		UnderCapacity: []domain.Section{},
+	}
+	if summary.BatchCount, err = s.rp.CountBatches(ctx, id); err != nil {
+		return domain.WarehouseSummary{}, err
+	}
+	if summary.EmployeeCount, err = s.rp.CountEmployees(ctx, id); err != nil {
+		return domain.WarehouseSummary{}, err
+	}
+
+	for i, section := range sections {
+		summary.Capacity.Current += section.CurrentCapacity
+		summary.Capacity.Minimum += section.MinimumCapacity
+		summary.Capacity.Maximum += section.MaximumCapacity
+		if i == 0 {
+			summary.TemperatureRange = &domain.TemperatureRange{Min: section.CurrentTemperature, Max: section.CurrentTemperature}
+		}
+		summary.TemperatureRange.Min = min(summary.TemperatureRange.Min, section.CurrentTemperature)
+		summary.TemperatureRange.Max = max(summary.TemperatureRange.Max, section.CurrentTemperature)
+		switch {
+		case section.CurrentCapacity > section.MaximumCapacity:
+			summary.OverCapacity = append(summary.OverCapacity, section)
+		case section.CurrentCapacity < section.MinimumCapacity:
+			summary.UnderCapacity = append(summary.UnderCapacity, section)
+		}
+	}
+	if summary.Capacity.Maximum > 0 {
+		utilisation := float64(summary.Capacity.Current) / float64(summary.Capacity.Maximum) * 100
+		summary.Utilisation = math.Round(utilisation*100) / 100
+	}
+	return summary, nil
+}
@@ -2,6 +2,7 @@ package warehouse
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
 	"github.com/stretchr/testify/mock"
@@ -35,3 +36,28 @@ func (s *ServiceMock) Update(ctx context.Context, w domain.Warehouse) error {
 	args := s.Called(ctx, w)
 	return args.Error(0)
 }
+
+func (s *ServiceMock) GetByIDs(ctx context.Context, ids []int) ([]domain.Warehouse, error) {
+	args := s.Called(ctx, ids)
+	return args.Get(0).([]domain.Warehouse), args.Error(1)
+}
+
+func (s *ServiceMock) Restore(ctx context.Context, id int) (domain.Warehouse, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Warehouse), args.Error(1)
+}
+
+func (s *ServiceMock) Purge(ctx context.Context, before time.Time) (int, error) {
+	args := s.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (s *ServiceMock) Sections(ctx context.Context, id int) ([]domain.Section, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
+
+func (s *ServiceMock) Summary(ctx context.Context, id int) (domain.WarehouseSummary, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.WarehouseSummary), args.Error(1)
+}
@@ -2,10 +2,14 @@ package warehouse
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/softdelete"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_Read(t *testing.T) {
@@ -272,3 +276,166 @@ func TestService_Update(t *testing.T) {
 		repository.AssertExpectations(t)
 	})
 }
+
+func TestService_Restore(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	deleted := domain.Warehouse{ID: 1, Address: "Test Address", Telephone: "Test Telephone", WarehouseCode: "W1", DeletedAt: &deletedAt}
+
+	// ASSOCIATED USER STORY: RESTORE
+	// EDGE CASE: restore a deleted warehouse
+	// DESCRIPTION: Should restore the warehouse and return it without its deletion time
+	t.Run("it should restore a deleted warehouse", func(t *testing.T) {
+		// Arrange.
+		repository := &RepositoryMock{}
+		repository.On("Get", mock.MatchedBy(softdelete.Included), 1).Return(deleted, nil)
+		repository.On("Exists", ctx, "W1").Return(false)
+		repository.On("Restore", ctx, 1).Return(nil)
+		service := NewService(repository)
+
+		// Act.
+		obtained, err := service.Restore(ctx, 1)
+
+		// Assert.
+		assert.NoError(t, err)
+		restored := deleted
+		restored.DeletedAt = nil
+		assert.Equal(t, restored, obtained)
+		repository.AssertExpectations(t)
+	})
+
+	// ASSOCIATED USER STORY: RESTORE
+	// EDGE CASE: the warehouse code was taken meanwhile
+	// DESCRIPTION: Should return ErrDuplicateWarehouse and leave the warehouse deleted
+	t.Run("it should not restore a warehouse whose code was taken", func(t *testing.T) {
+		// Arrange.
+		repository := &RepositoryMock{}
+		repository.On("Get", mock.Anything, 1).Return(deleted, nil)
+		repository.On("Exists", ctx, "W1").Return(true)
+		service := NewService(repository)
+
+		// Act.
+		_, err := service.Restore(ctx, 1)
+
+		// Assert.
+		assert.ErrorIs(t, err, ErrDuplicateWarehouse)
+		repository.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	// ASSOCIATED USER STORY: RESTORE
+	// EDGE CASE: warehouse doesn't exist
+	// DESCRIPTION: Should return ErrNotFound
+	t.Run("it should return ErrNotFound when the warehouse does not exist", func(t *testing.T) {
+		// Arrange.
+		repository := &RepositoryMock{}
+		repository.On("Get", mock.Anything, 1).Return(domain.Warehouse{}, sql.ErrNoRows)
+		service := NewService(repository)
+
+		// Act.
+		_, err := service.Restore(ctx, 1)
+
+		// Assert.
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestService_Summary(t *testing.T) {
+	ctx := context.Background()
+
+	// ASSOCIATED USER STORY: SUMMARY
+	// EDGE CASE: warehouse with sections
+	// DESCRIPTION: Should aggregate the capacity and temperatures of the sections
+	t.Run("it should aggregate the sections of a warehouse", func(t *testing.T) {
+		// Arrange.
+		sections := []domain.Section{
+			{ID: 1, SectionNumber: 1, CurrentTemperature: -18, CurrentCapacity: 50, MinimumCapacity: 10, MaximumCapacity: 100, WarehouseID: 2},
+			{ID: 2, SectionNumber: 2, CurrentTemperature: 4, CurrentCapacity: 130, MinimumCapacity: 20, MaximumCapacity: 120, WarehouseID: 2},
+			{ID: 3, SectionNumber: 3, CurrentTemperature: 2, CurrentCapacity: 5, MinimumCapacity: 10, MaximumCapacity: 80, WarehouseID: 2},
+		}
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, 2).Return(domain.Warehouse{ID: 2}, nil)
+		repository.On("Sections", ctx, 2).Return(sections, nil)
+		repository.On("CountBatches", ctx, 2).Return(7, nil)
+		repository.On("CountEmployees", ctx, 2).Return(3, nil)
+		service := NewService(repository)
+
+		// Act.
+		summary, err := service.Summary(ctx, 2)
+
+		// Assert.
+		assert.NoError(t, err)
+		assert.Equal(t, domain.WarehouseSummary{
+			WarehouseID:      2,
+			SectionCount:     3,
+			Capacity:         domain.WarehouseCapacity{Current: 185, Minimum: 40, Maximum: 300},
+			Utilisation:      61.67,
+			TemperatureRange: &domain.TemperatureRange{Min: -18, Max: 4},
+			BatchCount:       7,
+			EmployeeCount:    3,
+			OverCapacity:     []domain.Section{sections[1]},
+			UnderCapacity:    []domain.Section{sections[2]},
+		}, summary)
+	})
+
+	// ASSOCIATED USER STORY: SUMMARY
+	// EDGE CASE: warehouse without sections
+	// DESCRIPTION: Should return an empty summary without a temperature range
+	t.Run("it should summarise a warehouse without sections", func(t *testing.T) {
+		// Arrange.
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, 2).Return(domain.Warehouse{ID: 2}, nil)
+		repository.On("Sections", ctx, 2).Return([]domain.Section(nil), nil)
+		repository.On("CountBatches", ctx, 2).Return(0, nil)
+		repository.On("CountEmployees", ctx, 2).Return(1, nil)
+		service := NewService(repository)
+
+		// Act.
+		summary, err := service.Summary(ctx, 2)
+
+		// Assert.
+		assert.NoError(t, err)
+		assert.Nil(t, summary.TemperatureRange)
+		assert.Zero(t, summary.Utilisation)
+		assert.Equal(t, 1, summary.EmployeeCount)
+		assert.Equal(t, []domain.Section{}, summary.OverCapacity)
+	})
+
+	// ASSOCIATED USER STORY: SUMMARY
+	// EDGE CASE: warehouse doesn't exist
+	// DESCRIPTION: Should return ErrNotFound
+	t.Run("it should return ErrNotFound when the warehouse does not exist", func(t *testing.T) {
+		// Arrange.
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, 9).Return(domain.Warehouse{}, ErrNotFound)
+		service := NewService(repository)
+
+		// Act.
+		_, errSummary := service.Summary(ctx, 9)
+		_, errSections := service.Sections(ctx, 9)
+
+		// Assert.
+		assert.ErrorIs(t, errSummary, ErrNotFound)
+		assert.ErrorIs(t, errSections, ErrNotFound)
+		repository.AssertNotCalled(t, "Sections", mock.Anything, mock.Anything)
+	})
+
+	// ASSOCIATED USER STORY: SUMMARY
+	// EDGE CASE: the warehouse cannot be read
+	// DESCRIPTION: Should return the error of the repository, not ErrNotFound
+	t.Run("it should return the error of the repository when the warehouse cannot be read", func(t *testing.T) {
+		// Arrange.
+		repository := &RepositoryMock{}
+		repository.On("Get", ctx, 9).Return(domain.Warehouse{}, sql.ErrConnDone)
+		service := NewService(repository)
+
+		// Act.
+		_, errSummary := service.Summary(ctx, 9)
+		_, errSections := service.Sections(ctx, 9)
+
+		// Assert.
+		assert.ErrorIs(t, errSummary, sql.ErrConnDone)
+		assert.ErrorIs(t, errSections, sql.ErrConnDone)
+		assert.NotErrorIs(t, errSections, ErrNotFound)
+		repository.AssertNotCalled(t, "Sections", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,280 @@
+// Package warehousetest provides a contract test suite for
+// warehouse.Repository. Every implementation of the interface should pass it.
+package warehousetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/warehouse"
+	"github.com/davidop97/apiGo/pkg/softdelete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures stores rows the warehouse repository reads but does not write.
+type Fixtures struct {
+	// AddSection stores a section and returns its id.
+	AddSection func(t *testing.T, s domain.Section) int
+	// AddBatch stores a product batch in a section.
+	AddBatch func(t *testing.T, sectionID int)
+	// AddEmployee stores an employee of a warehouse and returns its id.
+	AddEmployee func(t *testing.T, warehouseID int) int
+	// DeleteEmployee soft deletes an employee.
+	DeleteEmployee func(t *testing.T, id int)
+}
+
+// NewWarehouse returns a valid warehouse with the given code.
+func NewWarehouse(code string) domain.Warehouse {
+	return domain.Warehouse{
+		Address:            "Fake Street 123",
+		Telephone:          "555-0100",
+		WarehouseCode:      code,
+		MinimumCapacity:    10,
+		MinimumTemperature: -5,
+	}
+}
+
+// AddWarehouses saves n warehouses to repo, which get the ids 1 to n in an
+// empty repository.
+func AddWarehouses(t *testing.T, repo warehouse.Repository, n int) {
+	t.Helper()
+	for i := 1; i <= n; i++ {
+		_, err := repo.Save(context.Background(), NewWarehouse(fmt.Sprintf("WH%d", i)))
+		require.NoError(t, err)
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository, and the fixtures that write to the same storage, where the
+// product type 1 exists, each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) (warehouse.Repository, Fixtures)) {
+	ctx := context.Background()
+
+	t.Run("it should save a warehouse and read it back", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		w := NewWarehouse("WH1")
+
+		// Act
+		id, err := repo.Save(ctx, w)
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		w.ID = id
+		assert.Equal(t, w, obtained)
+	})
+
+	t.Run("it should return every saved warehouse", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		for _, code := range []string{"WH1", "WH2"} {
+			_, err := repo.Save(ctx, NewWarehouse(code))
+			require.NoError(t, err)
+		}
+
+		obtained, err := repo.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, obtained, 2)
+	})
+
+	t.Run("it should return an error when the warehouse does not exist", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		_, err := repo.Get(ctx, 1)
+
+		assert.ErrorIs(t, err, warehouse.ErrNotFound)
+	})
+
+	t.Run("it should reject a duplicate warehouse code", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		_, err := repo.Save(ctx, NewWarehouse("WH1"))
+		require.NoError(t, err)
+
+		_, err = repo.Save(ctx, NewWarehouse("WH1"))
+
+		assert.True(t, repo.Exists(ctx, "WH1"))
+		assert.False(t, repo.Exists(ctx, "WH2"))
+		assert.True(t, errors.Is(err, warehouse.ErrDuplicateWarehouse))
+	})
+
+	t.Run("it should update every field of a warehouse", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		id, err := repo.Save(ctx, NewWarehouse("WH1"))
+		require.NoError(t, err)
+		updated := domain.Warehouse{
+			ID:                 id,
+			Address:            "Real Street 321",
+			Telephone:          "555-0199",
+			WarehouseCode:      "WH9",
+			MinimumCapacity:    30,
+			MinimumTemperature: -20,
+		}
+
+		// Act
+		err = repo.Update(ctx, updated)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, updated, obtained)
+	})
+
+	t.Run("it should delete a warehouse", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		id, err := repo.Save(ctx, NewWarehouse("WH1"))
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, id)
+
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, id)
+		assert.Error(t, err)
+	})
+
+	t.Run("it should hide a deleted warehouse unless deleted warehouses are included", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		id, err := repo.Save(ctx, NewWarehouse("WH1"))
+		require.NoError(t, err)
+		other, err := repo.Save(ctx, NewWarehouse("WH2"))
+		require.NoError(t, err)
+
+		// Act
+		err = repo.Delete(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		all, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+		assert.False(t, repo.Exists(ctx, "WH1"))
+		byIDs, err := repo.GetByIDs(ctx, []int{id, other})
+		require.NoError(t, err)
+		assert.Len(t, byIDs, 1)
+
+		withDeleted := softdelete.WithDeleted(ctx)
+		all, err = repo.GetAll(withDeleted)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+		obtained, err := repo.Get(withDeleted, id)
+		require.NoError(t, err)
+		assert.NotNil(t, obtained.DeletedAt)
+		assert.True(t, errors.Is(repo.Delete(ctx, id), warehouse.ErrNotFound))
+	})
+
+	t.Run("it should restore a deleted warehouse", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		w := NewWarehouse("WH1")
+		id, err := repo.Save(ctx, w)
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, id))
+
+		// Act
+		err = repo.Restore(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		w.ID = id
+		assert.Equal(t, w, obtained)
+		assert.True(t, errors.Is(repo.Restore(ctx, id), warehouse.ErrNotFound))
+	})
+
+	t.Run("it should purge the warehouses deleted before a time", func(t *testing.T) {
+		// Arrange
+		repo, _ := newRepository(t)
+		deleted, err := repo.Save(ctx, NewWarehouse("WH1"))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, NewWarehouse("WH2"))
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, deleted))
+
+		// Act
+		early, errEarly := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		purged, err := repo.Purge(ctx, time.Now().Add(time.Hour))
+
+		// Assert
+		require.NoError(t, errEarly)
+		require.NoError(t, err)
+		assert.Equal(t, 0, early)
+		assert.Equal(t, 1, purged)
+		all, err := repo.GetAll(softdelete.WithDeleted(ctx))
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+
+	t.Run("it should return ErrNotFound when deleting a missing warehouse", func(t *testing.T) {
+		repo, _ := newRepository(t)
+
+		err := repo.Delete(ctx, 1)
+
+		assert.True(t, errors.Is(err, warehouse.ErrNotFound))
+	})
+
+	t.Run("it should return the warehouses with the given ids", func(t *testing.T) {
+		repo, _ := newRepository(t)
+		var ids []int
+		for _, code := range []string{"W1", "W2", "W3"} {
+			id, err := repo.Save(ctx, NewWarehouse(code))
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		obtained, err := repo.GetByIDs(ctx, []int{ids[0], ids[2], ids[2] + 100})
+
+		require.NoError(t, err)
+		require.Len(t, obtained, 2)
+		assert.ElementsMatch(t, []int{ids[0], ids[2]}, []int{obtained[0].ID, obtained[1].ID})
+	})
+
+	t.Run("it should return the sections of a warehouse and count their batches and its employees", func(t *testing.T) {
+		// Arrange
+		repo, fixtures := newRepository(t)
+		id, err := repo.Save(ctx, NewWarehouse("WH1"))
+		require.NoError(t, err)
+		other, err := repo.Save(ctx, NewWarehouse("WH2"))
+		require.NoError(t, err)
+		empty, err := repo.Save(ctx, NewWarehouse("WH3"))
+		require.NoError(t, err)
+		var sections []int
+		for _, s := range []domain.Section{{SectionNumber: 2, WarehouseID: id}, {SectionNumber: 1, WarehouseID: id}, {SectionNumber: 3, WarehouseID: other}} {
+			s.MaximumCapacity, s.ProductTypeID = 100, 1
+			sections = append(sections, fixtures.AddSection(t, s))
+		}
+		for _, sectionID := range []int{sections[0], sections[1], sections[1], sections[2]} {
+			fixtures.AddBatch(t, sectionID)
+		}
+		fixtures.AddEmployee(t, id)
+		fixtures.DeleteEmployee(t, fixtures.AddEmployee(t, id))
+		fixtures.AddEmployee(t, other)
+
+		// Act
+		obtained, err := repo.Sections(ctx, id)
+		none, errNone := repo.Sections(ctx, empty)
+		batches, errBatches := repo.CountBatches(ctx, id)
+		employees, errEmployees := repo.CountEmployees(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, errNone)
+		require.NoError(t, errBatches)
+		require.NoError(t, errEmployees)
+		require.Len(t, obtained, 2)
+		assert.Equal(t, []int{sections[1], sections[0]}, []int{obtained[0].ID, obtained[1].ID})
+		assert.Equal(t, id, obtained[0].WarehouseID)
+		assert.Empty(t, none)
+		assert.Equal(t, 3, batches)
+		assert.Equal(t, 1, employees)
+	})
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is the error of a delivery to an address of the API
+// host or of its networks, which subscriptions cannot reach.
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// timeout bounds an attempt of the default client, from dialling to reading
+// the response.
+const timeout = 5 * time.Second
+
+// newClient returns the default client of the deliveries. It only connects to
+// public addresses, see publicOnly, and ignores the proxy of the environment,
+// which it would otherwise dial instead of the subscriptions.
+func newClient() *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// publicOnly refuses the connections to loopback, link-local, private,
+// unspecified and multicast addresses. It runs once the host of the URL is
+// resolved, for every address dialled, so names resolving to those addresses,
+// redirects to them and DNS rebinding are refused too.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("%w %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
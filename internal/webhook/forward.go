@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"github.com/davidop97/apiGo/pkg/events"
+)
+
+// Forward subscribes p to the events of every type a subscription can ask
+// for, so the events published to bus are delivered to the webhooks. A
+// failure to queue the deliveries of an event is returned to the bus, which
+// may publish it again.
+func Forward(bus events.EventBus, p Publisher) error {
+	for _, eventType := range EventTypes {
+		if _, err := bus.Subscribe(eventType, p.Publish); err != nil {
+			return err
+		}
+	}
+	return nil
+}
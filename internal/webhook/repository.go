@@ -0,0 +1,261 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+)
+
+// Repository stores the subscriptions and their deliveries.
+type Repository interface {
+	// SaveSubscription stores s and returns its id.
+	SaveSubscription(ctx context.Context, s domain.WebhookSubscription) (int, error)
+	// GetSubscription returns the subscription id, or ErrNotFound.
+	GetSubscription(ctx context.Context, id int) (domain.WebhookSubscription, error)
+	// ListSubscriptions returns every subscription, oldest first.
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	// UpdateSubscription stores the url, secret and event types of s, or
+	// returns ErrNotFound.
+	UpdateSubscription(ctx context.Context, s domain.WebhookSubscription) error
+	// DeleteSubscription deletes the subscription id and its deliveries, or
+	// returns ErrNotFound.
+	DeleteSubscription(ctx context.Context, id int) error
+
+	// SaveDelivery stores d and returns its id.
+	SaveDelivery(ctx context.Context, d domain.WebhookDelivery) (int, error)
+	// GetDelivery returns the delivery id, or ErrDeliveryNotFound.
+	GetDelivery(ctx context.Context, id int) (domain.WebhookDelivery, error)
+	// UpdateDelivery stores the outcome of an attempt of d: its status,
+	// attempts, next attempt, last error and delivery time.
+	UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) error
+	// DueDeliveries returns up to limit pending deliveries whose next attempt
+	// is not after now, the most overdue first.
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+	// ListDeliveries returns the deliveries with the given status, or every
+	// delivery when status is "", newest first.
+	ListDeliveries(ctx context.Context, status string) ([]domain.WebhookDelivery, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// datetimeLayout is how the DATETIME(6) columns are written and read.
+const datetimeLayout = "2006-01-02 15:04:05.999999"
+
+const (
+	selectSubscriptions = "SELECT id, url, secret, event_types, created_at FROM webhook_subscriptions"
+	selectDeliveries    = "SELECT id, subscription_id, event_type, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at FROM webhook_deliveries"
+)
+
+// SaveSubscription stores a subscription in the webhook_subscriptions table.
+func (r *repository) SaveSubscription(ctx context.Context, s domain.WebhookSubscription) (int, error) {
+	query := "INSERT INTO webhook_subscriptions (url, secret, event_types, created_at) VALUES (?, ?, ?, ?)"
+	res, err := r.db.ExecContext(ctx, query, s.URL, s.Secret, strings.Join(s.EventTypes, ","), formatDatetime(s.CreatedAt))
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetSubscription returns a subscription of the webhook_subscriptions table.
+func (r *repository) GetSubscription(ctx context.Context, id int) (domain.WebhookSubscription, error) {
+	subs, err := r.subscriptions(ctx, selectSubscriptions+" WHERE id = ?", id)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	if len(subs) == 0 {
+		return domain.WebhookSubscription{}, ErrNotFound
+	}
+	return subs[0], nil
+}
+
+// ListSubscriptions returns the subscriptions of the webhook_subscriptions table.
+func (r *repository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return r.subscriptions(ctx, selectSubscriptions+" ORDER BY id")
+}
+
+// UpdateSubscription updates a subscription of the webhook_subscriptions table.
+func (r *repository) UpdateSubscription(ctx context.Context, s domain.WebhookSubscription) error {
+	// The row is read first: MySQL does not count a row updated with the
+	// values it already had as affected.
+	if _, err := r.GetSubscription(ctx, s.ID); err != nil {
+		return err
+	}
+	query := "UPDATE webhook_subscriptions SET url = ?, secret = ?, event_types = ? WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, s.URL, s.Secret, strings.Join(s.EventTypes, ","), s.ID)
+	return err
+}
+
+// DeleteSubscription deletes a subscription of the webhook_subscriptions
+// table, and its deliveries with it.
+func (r *repository) DeleteSubscription(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affect < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SaveDelivery stores a delivery in the webhook_deliveries table.
+func (r *repository) SaveDelivery(ctx context.Context, d domain.WebhookDelivery) (int, error) {
+	query := "INSERT INTO webhook_deliveries (subscription_id, event_type, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	res, err := r.db.ExecContext(ctx, query, d.SubscriptionID, d.EventType, string(d.Payload), d.Status, d.Attempts,
+		formatDatetime(d.NextAttemptAt), d.LastError, formatDatetime(d.CreatedAt), nullDatetime(d.DeliveredAt))
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetDelivery returns a delivery of the webhook_deliveries table.
+func (r *repository) GetDelivery(ctx context.Context, id int) (domain.WebhookDelivery, error) {
+	deliveries, err := r.deliveries(ctx, selectDeliveries+" WHERE id = ?", id)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if len(deliveries) == 0 {
+		return domain.WebhookDelivery{}, ErrDeliveryNotFound
+	}
+	return deliveries[0], nil
+}
+
+// UpdateDelivery updates a delivery of the webhook_deliveries table.
+func (r *repository) UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) error {
+	query := "UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, delivered_at = ? WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, d.Status, d.Attempts, formatDatetime(d.NextAttemptAt), d.LastError,
+		nullDatetime(d.DeliveredAt), d.ID)
+	return err
+}
+
+// DueDeliveries returns the due deliveries of the webhook_deliveries table.
+func (r *repository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	query := selectDeliveries + " WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ?"
+	return r.deliveries(ctx, query, StatusPending, formatDatetime(now), limit)
+}
+
+// ListDeliveries returns the deliveries of the webhook_deliveries table with
+// a status.
+func (r *repository) ListDeliveries(ctx context.Context, status string) ([]domain.WebhookDelivery, error) {
+	if status == "" {
+		return r.deliveries(ctx, selectDeliveries+" ORDER BY id DESC")
+	}
+	return r.deliveries(ctx, selectDeliveries+" WHERE status = ? ORDER BY id DESC", status)
+}
+
+// subscriptions runs a query selecting the columns of selectSubscriptions.
+func (r *repository) subscriptions(ctx context.Context, query string, args ...interface{}) ([]domain.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []domain.WebhookSubscription
+	for rows.Next() {
+		var s domain.WebhookSubscription
+		var eventTypes, createdAt string
+		if err := rows.Scan(&s.ID, &s.URL, &s.Secret, &eventTypes, &createdAt); err != nil {
+			return nil, err
+		}
+		if eventTypes != "" {
+			s.EventTypes = strings.Split(eventTypes, ",")
+		}
+		if s.CreatedAt, err = parseDatetime(createdAt); err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// deliveries runs a query selecting the columns of selectDeliveries.
+func (r *repository) deliveries(ctx context.Context, query string, args ...interface{}) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		var payload, nextAttemptAt, createdAt string
+		var lastError, deliveredAt sql.NullString
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.EventType, &payload, &d.Status, &d.Attempts,
+			&nextAttemptAt, &lastError, &createdAt, &deliveredAt); err != nil {
+			return nil, err
+		}
+		d.Payload = json.RawMessage(payload)
+		d.LastError = lastError.String
+		if d.NextAttemptAt, err = parseDatetime(nextAttemptAt); err != nil {
+			return nil, err
+		}
+		if d.CreatedAt, err = parseDatetime(createdAt); err != nil {
+			return nil, err
+		}
+		if deliveredAt.Valid {
+			t, err := parseDatetime(deliveredAt.String)
+			if err != nil {
+				return nil, err
+			}
+			d.DeliveredAt = &t
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func formatDatetime(t time.Time) string {
+	return t.UTC().Format(datetimeLayout)
+}
+
+// nullDatetime stores a missing time as NULL.
+func nullDatetime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatDatetime(*t), Valid: true}
+}
+
+// parseDatetime parses a DATETIME column, read as text or, with the
+// parseTime option of the driver, converted to RFC 3339 by database/sql.
+func parseDatetime(s string) (time.Time, error) {
+	if t, err := time.Parse(datetimeLayout, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, errors.New("webhook: invalid datetime " + s)
+	}
+	return t, nil
+}
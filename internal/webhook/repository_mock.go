@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RepositoryMock) SaveSubscription(ctx context.Context, s domain.WebhookSubscription) (int, error) {
+	args := r.Called(ctx, s)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) GetSubscription(ctx context.Context, id int) (domain.WebhookSubscription, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.WebhookSubscription), args.Error(1)
+}
+
+func (r *RepositoryMock) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (r *RepositoryMock) UpdateSubscription(ctx context.Context, s domain.WebhookSubscription) error {
+	args := r.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) DeleteSubscription(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) SaveDelivery(ctx context.Context, d domain.WebhookDelivery) (int, error) {
+	args := r.Called(ctx, d)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RepositoryMock) GetDelivery(ctx context.Context, id int) (domain.WebhookDelivery, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}
+
+func (r *RepositoryMock) UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) error {
+	args := r.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (r *RepositoryMock) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	args := r.Called(ctx, now, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (r *RepositoryMock) ListDeliveries(ctx context.Context, status string) ([]domain.WebhookDelivery, error) {
+	args := r.Called(ctx, status)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
@@ -0,0 +1,15 @@
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/davidop97/apiGo/internal/webhook"
+	"github.com/davidop97/apiGo/internal/webhook/webhooktest"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+)
+
+func TestRepository_MySQL(t *testing.T) {
+	webhooktest.TestRepository(t, func(t *testing.T) webhook.Repository {
+		return webhook.NewRepository(mysqltest.Open(t))
+	})
+}
@@ -0,0 +1,435 @@
+// Package webhook posts the events of the API, such as the creation of a
+// purchase order, to the URLs subscribed to them. Every delivery is signed
+// with the secret of its subscription and retried with an exponential
+// backoff until it succeeds or runs out of attempts, when it is kept as dead
+// until it is redelivered by hand.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/events"
+)
+
+// Errors
+var (
+	ErrNotFound         = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidURL       = errors.New("url must be an absolute http or https URL")
+	ErrMissingSecret    = errors.New("secret is required")
+	ErrNoEventTypes     = errors.New("at least one event type is required")
+	ErrUnknownEventType = errors.New("unknown event type")
+)
+
+// Event types.
+const (
+	EventPurchaseOrderCreated = outbox.PurchaseOrderCreated
+	EventInboundOrderReceived = outbox.InboundOrderReceived
+	EventBatchCreated         = outbox.ProductBatchCreated
+	EventSectionCapacityLow   = outbox.SectionCapacityLow
+	EventExcursionStarted     = outbox.SectionExcursionStarted
+	EventExcursionEnded       = outbox.SectionExcursionEnded
+)
+
+// EventTypes lists the event types a subscription can ask for.
+var EventTypes = outbox.Types
+
+// Statuses of a delivery.
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+// Publisher posts events to their subscriptions. It is the part of Service
+// the event bus hands the events to, see Forward.
+type Publisher interface {
+	// Publish queues a delivery of e for every subscription to its type.
+	// The body posted is e encoded as JSON.
+	Publish(ctx context.Context, e events.Event) error
+}
+
+type Service interface {
+	Publisher
+
+	CreateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int) (domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int) error
+
+	// ListDeliveries returns the deliveries with the given status, or every
+	// delivery when status is "", newest first. The dead deliveries are the
+	// dead-letter list.
+	ListDeliveries(ctx context.Context, status string) ([]domain.WebhookDelivery, error)
+	// Redeliver attempts a delivery at once, whatever its status, and
+	// returns it with the outcome. A failed redelivery is retried like a new
+	// delivery.
+	Redeliver(ctx context.Context, id int) (domain.WebhookDelivery, error)
+	// DeliverDue attempts the pending deliveries that are due and returns
+	// how many it attempted. The deliveries of a subscription are attempted
+	// in order, those of different subscriptions concurrently.
+	DeliverDue(ctx context.Context) (int, error)
+	// Run calls DeliverDue every interval, and as soon as an event is
+	// published, until ctx is done.
+	Run(ctx context.Context, interval time.Duration)
+}
+
+// Options configures the deliveries. The zero value of a field selects its
+// default.
+type Options struct {
+	// Client posts the deliveries. The default times out after 5 seconds
+	// and refuses to connect to loopback, link-local and private addresses.
+	Client *http.Client
+	// Workers is the number of subscriptions DeliverDue delivers to at a
+	// time, 8 by default.
+	Workers int
+	// MaxAttempts is the number of attempts after which a delivery is dead,
+	// 8 by default.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled before each of
+	// the next ones up to MaxBackoff. They are 30 seconds and an hour by
+	// default.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// dueBatch is the number of deliveries DeliverDue attempts at most.
+const dueBatch = 100
+
+type service struct {
+	repo        Repository
+	client      *http.Client
+	workers     int
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	now         func() time.Time
+
+	// wake tells Run an event was published.
+	wake chan struct{}
+}
+
+func NewService(repo Repository, opts Options) Service {
+	s := &service{
+		repo:        repo,
+		client:      opts.Client,
+		workers:     opts.Workers,
+		maxAttempts: opts.MaxAttempts,
+		backoff:     opts.Backoff,
+		maxBackoff:  opts.MaxBackoff,
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+	}
+	if s.client == nil {
+		s.client = newClient()
+	}
+	if s.workers <= 0 {
+		s.workers = 8
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = 8
+	}
+	if s.backoff <= 0 {
+		s.backoff = 30 * time.Second
+	}
+	if s.maxBackoff <= 0 {
+		s.maxBackoff = time.Hour
+	}
+	return s
+}
+
+// Publish queues a delivery of the event for every subscription to its type.
+func (s *service) Publish(ctx context.Context, e events.Event) error {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now().UTC().Truncate(time.Microsecond)
+	var payload json.RawMessage
+	for _, sub := range subs {
+		if !contains(sub.EventTypes, e.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(e); err != nil {
+				return err
+			}
+		}
+		d := domain.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventType:      e.Type,
+			Payload:        payload,
+			Status:         StatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+		if _, err := s.repo.SaveDelivery(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	if payload != nil {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// CreateSubscription checks and saves a subscription and returns it.
+func (s *service) CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	if err := check(&sub); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	sub.CreatedAt = s.now().UTC().Truncate(time.Microsecond)
+
+	id, err := s.repo.SaveSubscription(ctx, sub)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	sub.ID = id
+	return sub, nil
+}
+
+// GetSubscription returns a subscription.
+func (s *service) GetSubscription(ctx context.Context, id int) (domain.WebhookSubscription, error) {
+	return s.repo.GetSubscription(ctx, id)
+}
+
+// ListSubscriptions returns every subscription.
+func (s *service) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.repo.ListSubscriptions(ctx)
+}
+
+// UpdateSubscription checks and updates a subscription and returns it.
+func (s *service) UpdateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	if err := check(&sub); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	if err := s.repo.UpdateSubscription(ctx, sub); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	return sub, nil
+}
+
+// DeleteSubscription deletes a subscription and its deliveries.
+func (s *service) DeleteSubscription(ctx context.Context, id int) error {
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+// ListDeliveries returns the deliveries with a status.
+func (s *service) ListDeliveries(ctx context.Context, status string) ([]domain.WebhookDelivery, error) {
+	return s.repo.ListDeliveries(ctx, status)
+}
+
+// Redeliver resets the attempts of a delivery and attempts it.
+func (s *service) Redeliver(ctx context.Context, id int) (domain.WebhookDelivery, error) {
+	d, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	sub, err := s.repo.GetSubscription(ctx, d.SubscriptionID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	d.Status = StatusPending
+	d.Attempts = 0
+	d.DeliveredAt = nil
+	return s.attempt(ctx, d, sub)
+}
+
+// DeliverDue attempts the due deliveries, those of up to s.workers
+// subscriptions at a time, so that a slow endpoint only holds back its own.
+// The error returned is the first one of the repository.
+func (s *service) DeliverDue(ctx context.Context) (int, error) {
+	due, err := s.repo.DueDeliveries(ctx, s.now(), dueBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	var subs []int
+	bySub := make(map[int][]domain.WebhookDelivery)
+	for _, d := range due {
+		if _, ok := bySub[d.SubscriptionID]; !ok {
+			subs = append(subs, d.SubscriptionID)
+		}
+		bySub[d.SubscriptionID] = append(bySub[d.SubscriptionID], d)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		attempted int
+		firstErr  error
+	)
+	workers := make(chan struct{}, s.workers)
+	for _, id := range subs {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func(deliveries []domain.WebhookDelivery) {
+			defer wg.Done()
+			n, err := s.deliver(ctx, deliveries)
+			<-workers
+
+			mu.Lock()
+			defer mu.Unlock()
+			attempted += n
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}(bySub[id])
+	}
+	wg.Wait()
+	return attempted, firstErr
+}
+
+// deliver attempts the deliveries of a subscription in order and returns how
+// many it attempted before an error of the repository.
+func (s *service) deliver(ctx context.Context, deliveries []domain.WebhookDelivery) (int, error) {
+	sub, err := s.repo.GetSubscription(ctx, deliveries[0].SubscriptionID)
+	if err != nil {
+		return 0, err
+	}
+	for i, d := range deliveries {
+		if _, err := s.attempt(ctx, d, sub); err != nil {
+			return i, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// Run delivers the due deliveries until ctx is done.
+func (s *service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		if _, err := s.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("webhook: delivering: %v", err)
+		}
+	}
+}
+
+// attempt posts a delivery to its subscription and stores the outcome: the
+// delivery is delivered on a 2xx response, retried later on any other
+// outcome, and dead once it ran out of attempts. The error returned is the
+// one of the repository; the one of the attempt is kept in LastError.
+func (s *service) attempt(ctx context.Context, d domain.WebhookDelivery, sub domain.WebhookSubscription) (domain.WebhookDelivery, error) {
+	now := s.now().UTC().Truncate(time.Microsecond)
+	err := s.post(ctx, d, sub, now)
+
+	d.Attempts++
+	switch {
+	case err == nil:
+		d.Status = StatusDelivered
+		d.DeliveredAt = &now
+		d.LastError = ""
+	case d.Attempts >= s.maxAttempts:
+		d.Status = StatusDead
+		d.LastError = err.Error()
+	default:
+		d.NextAttemptAt = now.Add(s.delay(d.Attempts))
+		d.LastError = err.Error()
+	}
+
+	if err := s.repo.UpdateDelivery(ctx, d); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return d, nil
+}
+
+// post posts the payload of a delivery, signed at now, to the URL of sub.
+func (s *service) post(ctx context.Context, d domain.WebhookDelivery, sub domain.WebhookSubscription, now time.Time) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(d.ID))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Draining the body lets the client reuse the connection.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// delay returns the delay before the retry following the given attempt.
+func (s *service) delay(attempts int) time.Duration {
+	d := s.backoff
+	for i := 1; i < attempts && d < s.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > s.maxBackoff {
+		d = s.maxBackoff
+	}
+	return d
+}
+
+// check validates a subscription and removes its duplicated event types.
+func check(sub *domain.WebhookSubscription) error {
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if sub.Secret == "" {
+		return ErrMissingSecret
+	}
+	if len(sub.EventTypes) == 0 {
+		return ErrNoEventTypes
+	}
+
+	var types []string
+	for _, t := range sub.EventTypes {
+		if !contains(EventTypes, t) {
+			return fmt.Errorf("%w: %s", ErrUnknownEventType, t)
+		}
+		if !contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	sub.EventTypes = types
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
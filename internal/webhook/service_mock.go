@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+type ServiceMock struct {
+	mock.Mock
+}
+
+func (s *ServiceMock) Publish(ctx context.Context, e events.Event) error {
+	args := s.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	args := s.Called(ctx, sub)
+	return args.Get(0).(domain.WebhookSubscription), args.Error(1)
+}
+
+func (s *ServiceMock) GetSubscription(ctx context.Context, id int) (domain.WebhookSubscription, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.WebhookSubscription), args.Error(1)
+}
+
+func (s *ServiceMock) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (s *ServiceMock) UpdateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	args := s.Called(ctx, sub)
+	return args.Get(0).(domain.WebhookSubscription), args.Error(1)
+}
+
+func (s *ServiceMock) DeleteSubscription(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (s *ServiceMock) ListDeliveries(ctx context.Context, status string) ([]domain.WebhookDelivery, error) {
+	args := s.Called(ctx, status)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (s *ServiceMock) Redeliver(ctx context.Context, id int) (domain.WebhookDelivery, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}
+
+func (s *ServiceMock) DeliverDue(ctx context.Context) (int, error) {
+	args := s.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (s *ServiceMock) Run(ctx context.Context, interval time.Duration) {
+	s.Called(ctx, interval)
+}
@@ -0,0 +1,329 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/outbox"
+	"github.com/davidop97/apiGo/pkg/events"
+	"github.com/davidop97/apiGo/pkg/mysqltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver is a webhook endpoint answering with the given statuses in turn,
+// and with 204 once they are exhausted.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	status := http.StatusNoContent
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.requests)
+}
+
+// clock is a settable time source.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestService(repo Repository, opts Options, now func() time.Time) Service {
+	s := NewService(repo, opts).(*service)
+	s.now = now
+	return s
+}
+
+func newService(t *testing.T, opts Options, clk *clock) Service {
+	// The test servers listen on loopback, which the default client refuses.
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: timeout}
+	}
+	return newTestService(NewRepository(mysqltest.Open(t)), opts, clk.Now)
+}
+
+func TestService_Deliver(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+	order := domain.PurchaseOrder{ID: 7, OrderNumber: "PO-7", BuyerID: 1}
+	event, err := outbox.NewEvent(EventPurchaseOrderCreated, order.ID, order)
+	require.NoError(t, err)
+
+	t.Run("it should post a signed event to the subscriptions to its type", func(t *testing.T) {
+		// Arrange
+		rc := &receiver{}
+		srv := httptest.NewServer(rc)
+		defer srv.Close()
+		clk := &clock{now: start}
+		s := newService(t, Options{}, clk)
+		sub, err := s.CreateSubscription(ctx, domain.WebhookSubscription{URL: srv.URL, Secret: "s3cr3t",
+			EventTypes: []string{EventPurchaseOrderCreated}})
+		require.NoError(t, err)
+		_, err = s.CreateSubscription(ctx, domain.WebhookSubscription{URL: srv.URL + "/batches", Secret: "other",
+			EventTypes: []string{EventBatchCreated}})
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, s.Publish(ctx, event))
+		attempted, err := s.DeliverDue(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempted)
+		require.Equal(t, 1, rc.count())
+		req, body := rc.requests[0], rc.bodies[0]
+		assert.Equal(t, "/", req.URL.Path)
+		assert.Equal(t, EventPurchaseOrderCreated, req.Header.Get(HeaderEvent))
+		assert.Equal(t, strconv.FormatInt(start.Unix(), 10), req.Header.Get(HeaderTimestamp))
+		assert.True(t, Verify("s3cr3t", req.Header.Get(HeaderTimestamp), body, req.Header.Get(HeaderSignature)))
+		var posted events.Event
+		require.NoError(t, json.Unmarshal(body, &posted))
+		assert.Equal(t, event, posted)
+
+		deliveries, err := s.ListDeliveries(ctx, StatusDelivered)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, sub.ID, deliveries[0].SubscriptionID)
+		assert.Equal(t, strconv.Itoa(deliveries[0].ID), req.Header.Get(HeaderDelivery))
+		assert.Equal(t, 1, deliveries[0].Attempts)
+	})
+
+	t.Run("it should retry with an exponential backoff and give up after the last attempt", func(t *testing.T) {
+		// Arrange
+		rc := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable}}
+		srv := httptest.NewServer(rc)
+		defer srv.Close()
+		clk := &clock{now: start}
+		s := newService(t, Options{MaxAttempts: 3, Backoff: time.Minute}, clk)
+		_, err := s.CreateSubscription(ctx, domain.WebhookSubscription{URL: srv.URL, Secret: "s3cr3t",
+			EventTypes: []string{EventPurchaseOrderCreated}})
+		require.NoError(t, err)
+		require.NoError(t, s.Publish(ctx, event))
+
+		// Act
+		first, err := s.DeliverDue(ctx)
+		require.NoError(t, err)
+		clk.now = start.Add(59 * time.Second)
+		early, err := s.DeliverDue(ctx)
+		require.NoError(t, err)
+		clk.now = start.Add(time.Minute)
+		second, err := s.DeliverDue(ctx)
+		require.NoError(t, err)
+		pending, err := s.ListDeliveries(ctx, StatusPending)
+		require.NoError(t, err)
+		clk.now = start.Add(3 * time.Minute)
+		third, err := s.DeliverDue(ctx)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, []int{1, 0, 1, 1}, []int{first, early, second, third})
+		require.Len(t, pending, 1)
+		assert.Equal(t, start.Add(3*time.Minute), pending[0].NextAttemptAt)
+		assert.Equal(t, "unexpected status 502", pending[0].LastError)
+		dead, err := s.ListDeliveries(ctx, StatusDead)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		assert.Equal(t, 3, dead[0].Attempts)
+		assert.Equal(t, "unexpected status 503", dead[0].LastError)
+		assert.Equal(t, 3, rc.count())
+	})
+
+	t.Run("it should deliver to the subscriptions concurrently", func(t *testing.T) {
+		// Arrange
+		fast := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-fast:
+				w.WriteHeader(http.StatusNoContent)
+			case <-time.After(2 * time.Second):
+				w.WriteHeader(http.StatusGatewayTimeout)
+			}
+		}))
+		defer slow.Close()
+		quick := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(fast)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer quick.Close()
+		clk := &clock{now: start}
+		s := newService(t, Options{}, clk)
+		for _, url := range []string{slow.URL, quick.URL} {
+			_, err := s.CreateSubscription(ctx, domain.WebhookSubscription{URL: url, Secret: "s3cr3t",
+				EventTypes: []string{EventPurchaseOrderCreated}})
+			require.NoError(t, err)
+		}
+		require.NoError(t, s.Publish(ctx, event))
+
+		// Act
+		attempted, err := s.DeliverDue(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempted)
+		delivered, err := s.ListDeliveries(ctx, StatusDelivered)
+		require.NoError(t, err)
+		assert.Len(t, delivered, 2)
+	})
+
+	t.Run("it should refuse to deliver to loopback and private addresses", func(t *testing.T) {
+		// Arrange
+		rc := &receiver{}
+		srv := httptest.NewServer(rc)
+		defer srv.Close()
+		clk := &clock{now: start}
+		s := newService(t, Options{Client: newClient()}, clk)
+		_, err := s.CreateSubscription(ctx, domain.WebhookSubscription{URL: srv.URL, Secret: "s3cr3t",
+			EventTypes: []string{EventPurchaseOrderCreated}})
+		require.NoError(t, err)
+		require.NoError(t, s.Publish(ctx, event))
+
+		// Act
+		attempted, err := s.DeliverDue(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempted)
+		assert.Zero(t, rc.count())
+		pending, err := s.ListDeliveries(ctx, StatusPending)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Contains(t, pending[0].LastError, "forbidden address 127.0.0.1")
+		for _, address := range []string{"127.0.0.1:80", "[::1]:443", "10.0.0.5:80", "192.168.1.1:80", "172.16.0.1:80",
+			"169.254.169.254:80", "[fe80::1]:80", "0.0.0.0:80", "[::ffff:127.0.0.1]:80"} {
+			assert.ErrorIs(t, publicOnly("tcp", address, nil), ErrForbiddenAddress, address)
+		}
+		assert.NoError(t, publicOnly("tcp", "93.184.216.34:443", nil))
+	})
+
+	t.Run("it should redeliver a dead delivery at once", func(t *testing.T) {
+		// Arrange
+		rc := &receiver{statuses: []int{http.StatusInternalServerError}}
+		srv := httptest.NewServer(rc)
+		defer srv.Close()
+		clk := &clock{now: start}
+		s := newService(t, Options{MaxAttempts: 1}, clk)
+		_, err := s.CreateSubscription(ctx, domain.WebhookSubscription{URL: srv.URL, Secret: "s3cr3t",
+			EventTypes: []string{EventPurchaseOrderCreated}})
+		require.NoError(t, err)
+		require.NoError(t, s.Publish(ctx, event))
+		_, err = s.DeliverDue(ctx)
+		require.NoError(t, err)
+		dead, err := s.ListDeliveries(ctx, StatusDead)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+
+		// Act
+		clk.now = start.Add(time.Hour)
+		obtained, err := s.Redeliver(ctx, dead[0].ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, StatusDelivered, obtained.Status)
+		assert.Equal(t, 1, obtained.Attempts)
+		require.NotNil(t, obtained.DeliveredAt)
+		assert.Equal(t, start.Add(time.Hour), *obtained.DeliveredAt)
+		assert.Equal(t, 2, rc.count())
+		assert.Equal(t, rc.bodies[0], rc.bodies[1])
+		_, err = s.Redeliver(ctx, dead[0].ID+1)
+		assert.True(t, errors.Is(err, ErrDeliveryNotFound))
+	})
+}
+
+func TestService_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should reject an invalid subscription", func(t *testing.T) {
+		cases := map[string]struct {
+			sub      domain.WebhookSubscription
+			expected error
+		}{
+			"relative url":       {domain.WebhookSubscription{URL: "/hooks", Secret: "s", EventTypes: []string{EventBatchCreated}}, ErrInvalidURL},
+			"ftp url":            {domain.WebhookSubscription{URL: "ftp://erp.example.com", Secret: "s", EventTypes: []string{EventBatchCreated}}, ErrInvalidURL},
+			"missing secret":     {domain.WebhookSubscription{URL: "https://erp.example.com", EventTypes: []string{EventBatchCreated}}, ErrMissingSecret},
+			"no event types":     {domain.WebhookSubscription{URL: "https://erp.example.com", Secret: "s"}, ErrNoEventTypes},
+			"unknown event type": {domain.WebhookSubscription{URL: "https://erp.example.com", Secret: "s", EventTypes: []string{"seller.created"}}, ErrUnknownEventType},
+		}
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				repo := &RepositoryMock{}
+				s := NewService(repo, Options{})
+
+				_, err := s.CreateSubscription(ctx, c.sub)
+
+				assert.True(t, errors.Is(err, c.expected), err)
+				repo.AssertNotCalled(t, "SaveSubscription")
+			})
+		}
+	})
+
+	t.Run("it should save a subscription once per event type", func(t *testing.T) {
+		// Arrange
+		repo := &RepositoryMock{}
+		createdAt := time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+		expected := domain.WebhookSubscription{URL: "https://erp.example.com/hooks", Secret: "s3cr3t",
+			EventTypes: []string{EventBatchCreated, EventPurchaseOrderCreated}, CreatedAt: createdAt}
+		repo.On("SaveSubscription", ctx, expected).Return(3, nil)
+		s := newTestService(repo, Options{}, func() time.Time { return createdAt })
+		sub := expected
+		sub.EventTypes = []string{EventBatchCreated, EventPurchaseOrderCreated, EventBatchCreated}
+
+		// Act
+		obtained, err := s.CreateSubscription(ctx, sub)
+
+		// Assert
+		require.NoError(t, err)
+		expected.ID = 3
+		assert.Equal(t, expected, obtained)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestForward(t *testing.T) {
+	t.Run("it should queue the deliveries of the events published to the bus", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		bus := events.NewMemoryBus()
+		s := newService(t, Options{}, &clock{now: time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)})
+		_, err := s.CreateSubscription(ctx, domain.WebhookSubscription{URL: "https://erp.example.com/hooks", Secret: "s3cr3t",
+			EventTypes: []string{EventBatchCreated}})
+		require.NoError(t, err)
+		event, err := outbox.NewEvent(EventBatchCreated, 3, domain.ProductBatch{ID: 3})
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, Forward(bus, s))
+		err = bus.Publish(ctx, event)
+
+		// Assert
+		require.NoError(t, err)
+		pending, err := s.ListDeliveries(ctx, StatusPending)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, EventBatchCreated, pending[0].EventType)
+	})
+}
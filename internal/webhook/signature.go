@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Headers of a delivery.
+const (
+	// HeaderEvent is the type of the event posted.
+	HeaderEvent = "X-Webhook-Event"
+	// HeaderDelivery is the id of the delivery. It stays the same across the
+	// attempts, so receivers can skip a delivery they already processed.
+	HeaderDelivery = "X-Webhook-Delivery"
+	// HeaderTimestamp is the Unix time of the attempt, in seconds.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is the signature of the attempt, see Sign.
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// signaturePrefix names the algorithm of the signature.
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of an attempt posting body at timestamp: the
+// hex encoded HMAC-SHA256, keyed with the secret of the subscription, of the
+// timestamp, a dot and the body, prefixed by "sha256=". Signing the timestamp
+// lets receivers reject old attempts replayed to them.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of an attempt posting
+// body at timestamp, in constant time.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,201 @@
+// Package webhooktest provides a contract test suite for webhook.Repository.
+// Every implementation of the interface should pass it.
+package webhooktest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/internal/domain"
+	"github.com/davidop97/apiGo/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createdAt is the creation time of the subscriptions and deliveries of the
+// suite.
+var createdAt = time.Date(2026, time.October, 18, 15, 4, 5, 123456000, time.UTC)
+
+// NewSubscription returns a valid subscription to the given event types.
+func NewSubscription(eventTypes ...string) domain.WebhookSubscription {
+	return domain.WebhookSubscription{
+		URL:        "https://erp.example.com/hooks",
+		Secret:     "s3cr3t",
+		EventTypes: eventTypes,
+		CreatedAt:  createdAt,
+	}
+}
+
+// NewDelivery returns a pending delivery to the given subscription, due at
+// createdAt.
+func NewDelivery(subscriptionID int) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		SubscriptionID: subscriptionID,
+		EventType:      webhook.EventPurchaseOrderCreated,
+		Payload:        json.RawMessage(`{"type":"purchase_order.created","data":{"id":1}}`),
+		Status:         webhook.StatusPending,
+		NextAttemptAt:  createdAt,
+		CreatedAt:      createdAt,
+	}
+}
+
+// TestRepository runs the contract suite. newRepository must return an empty
+// repository each time it is called.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) webhook.Repository) {
+	ctx := context.Background()
+
+	t.Run("it should save a subscription and read it back", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		sub := NewSubscription(webhook.EventPurchaseOrderCreated, webhook.EventBatchCreated)
+
+		// Act
+		id, err := repo.SaveSubscription(ctx, sub)
+		require.NoError(t, err)
+		obtained, err := repo.GetSubscription(ctx, id)
+
+		// Assert
+		require.NoError(t, err)
+		sub.ID = id
+		assert.Equal(t, sub, obtained)
+	})
+
+	t.Run("it should list, update and delete subscriptions", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		first, err := repo.SaveSubscription(ctx, NewSubscription(webhook.EventPurchaseOrderCreated))
+		require.NoError(t, err)
+		second, err := repo.SaveSubscription(ctx, NewSubscription(webhook.EventBatchCreated))
+		require.NoError(t, err)
+		updated := NewSubscription(webhook.EventInboundOrderReceived)
+		updated.ID = first
+		updated.URL = "https://erp.example.com/other"
+
+		// Act
+		errUpdate := repo.UpdateSubscription(ctx, updated)
+		errDelete := repo.DeleteSubscription(ctx, second)
+
+		// Assert
+		require.NoError(t, errUpdate)
+		require.NoError(t, errDelete)
+		obtained, err := repo.ListSubscriptions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.WebhookSubscription{updated}, obtained)
+	})
+
+	t.Run("it should return ErrNotFound for a missing subscription", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, errGet := repo.GetSubscription(ctx, 1)
+		errUpdate := repo.UpdateSubscription(ctx, domain.WebhookSubscription{ID: 1})
+		errDelete := repo.DeleteSubscription(ctx, 1)
+
+		assert.True(t, errors.Is(errGet, webhook.ErrNotFound))
+		assert.True(t, errors.Is(errUpdate, webhook.ErrNotFound))
+		assert.True(t, errors.Is(errDelete, webhook.ErrNotFound))
+	})
+
+	t.Run("it should save a delivery and store the outcome of its attempts", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		sub, err := repo.SaveSubscription(ctx, NewSubscription(webhook.EventPurchaseOrderCreated))
+		require.NoError(t, err)
+		id, err := repo.SaveDelivery(ctx, NewDelivery(sub))
+		require.NoError(t, err)
+		delivered := NewDelivery(sub)
+		delivered.ID = id
+		delivered.Status = webhook.StatusDelivered
+		delivered.Attempts = 2
+		delivered.LastError = ""
+		deliveredAt := createdAt.Add(time.Minute)
+		delivered.DeliveredAt = &deliveredAt
+
+		// Act
+		err = repo.UpdateDelivery(ctx, delivered)
+
+		// Assert
+		require.NoError(t, err)
+		obtained, err := repo.GetDelivery(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, delivered, obtained)
+		_, err = repo.GetDelivery(ctx, id+1)
+		assert.True(t, errors.Is(err, webhook.ErrDeliveryNotFound))
+	})
+
+	t.Run("it should return the pending deliveries that are due", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		sub, err := repo.SaveSubscription(ctx, NewSubscription(webhook.EventPurchaseOrderCreated))
+		require.NoError(t, err)
+		later := NewDelivery(sub)
+		later.NextAttemptAt = createdAt.Add(time.Minute)
+		dead := NewDelivery(sub)
+		dead.Status = webhook.StatusDead
+		var ids []int
+		for _, d := range []domain.WebhookDelivery{later, NewDelivery(sub), dead, NewDelivery(sub)} {
+			id, err := repo.SaveDelivery(ctx, d)
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		// Act
+		obtained, err := repo.DueDeliveries(ctx, createdAt, 10)
+		limited, errLimited := repo.DueDeliveries(ctx, createdAt.Add(time.Hour), 1)
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, errLimited)
+		require.Len(t, obtained, 2)
+		assert.Equal(t, []int{ids[1], ids[3]}, []int{obtained[0].ID, obtained[1].ID})
+		require.Len(t, limited, 1)
+		assert.Equal(t, ids[1], limited[0].ID)
+	})
+
+	t.Run("it should list the deliveries with a status, newest first", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		sub, err := repo.SaveSubscription(ctx, NewSubscription(webhook.EventPurchaseOrderCreated))
+		require.NoError(t, err)
+		dead := NewDelivery(sub)
+		dead.Status = webhook.StatusDead
+		dead.LastError = "unexpected status 500"
+		var ids []int
+		for _, d := range []domain.WebhookDelivery{dead, NewDelivery(sub), dead} {
+			id, err := repo.SaveDelivery(ctx, d)
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		// Act
+		obtained, err := repo.ListDeliveries(ctx, webhook.StatusDead)
+		all, errAll := repo.ListDeliveries(ctx, "")
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, errAll)
+		require.Len(t, obtained, 2)
+		assert.Equal(t, []int{ids[2], ids[0]}, []int{obtained[0].ID, obtained[1].ID})
+		assert.Equal(t, "unexpected status 500", obtained[0].LastError)
+		assert.Len(t, all, 3)
+	})
+
+	t.Run("it should delete the deliveries of a deleted subscription", func(t *testing.T) {
+		// Arrange
+		repo := newRepository(t)
+		sub, err := repo.SaveSubscription(ctx, NewSubscription(webhook.EventPurchaseOrderCreated))
+		require.NoError(t, err)
+		id, err := repo.SaveDelivery(ctx, NewDelivery(sub))
+		require.NoError(t, err)
+
+		// Act
+		err = repo.DeleteSubscription(ctx, sub)
+
+		// Assert
+		require.NoError(t, err)
+		_, err = repo.GetDelivery(ctx, id)
+		assert.True(t, errors.Is(err, webhook.ErrDeliveryNotFound))
+	})
+}
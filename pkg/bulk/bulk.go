@@ -0,0 +1,91 @@
+// Package bulk imports many rows of a resource at once: it decodes CSV and
+// NDJSON files and applies their rows all together or not at all.
+package bulk
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Mode tells what to do with a row whose natural key is already stored.
+type Mode string
+
+const (
+	// ModeInsert fails the row.
+	ModeInsert Mode = "insert"
+	// ModeUpsert updates the stored entity with the row.
+	ModeUpsert Mode = "upsert"
+)
+
+// ErrInvalidMode is returned by ParseMode for unknown modes.
+var ErrInvalidMode = errors.New("mode must be insert or upsert")
+
+// ParseMode parses the name of a mode. The empty string is ModeInsert.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case "", ModeInsert:
+		return ModeInsert, nil
+	case ModeUpsert:
+		return ModeUpsert, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidMode, s)
+}
+
+// Options configures an import.
+type Options struct {
+	Mode Mode
+	// DryRun checks every row, and reports what would be done, without
+	// keeping any write.
+	DryRun bool
+}
+
+// Action is what an import did with a row.
+type Action string
+
+const (
+	ActionInserted Action = "inserted"
+	ActionUpdated  Action = "updated"
+)
+
+// Outcome is the result of importing one row. Err is set when the row was
+// rejected, and Action and ID are set otherwise.
+type Outcome struct {
+	ID     int
+	Action Action
+	Err    error
+}
+
+// Inserted is the outcome of a row stored as a new entity with the given id.
+func Inserted(id int) Outcome { return Outcome{ID: id, Action: ActionInserted} }
+
+// Updated is the outcome of a row written over the entity with the given id.
+func Updated(id int) Outcome { return Outcome{ID: id, Action: ActionUpdated} }
+
+// Failed is the outcome of a row rejected because of err.
+func Failed(err error) Outcome { return Outcome{Err: err} }
+
+// ErrRollback is returned by Apply when the writes of an import must not be
+// kept.
+var ErrRollback = errors.New("bulk: import rolled back")
+
+// Apply calls row for every item, in order, and returns the outcomes. It goes
+// on after a rejected row so every row is reported, and returns ErrRollback
+// with the outcomes when a row was rejected or opts.DryRun is set: run inside
+// a transaction, that discards every write of the import. An error returned
+// by row stops the import and is returned as is.
+func Apply[T any](items []T, opts Options, row func(item T) (Outcome, error)) ([]Outcome, error) {
+	outcomes := make([]Outcome, 0, len(items))
+	rejected := false
+	for _, item := range items {
+		o, err := row(item)
+		if err != nil {
+			return nil, err
+		}
+		rejected = rejected || o.Err != nil
+		outcomes = append(outcomes, o)
+	}
+	if rejected || opts.DryRun {
+		return outcomes, ErrRollback
+	}
+	return outcomes, nil
+}
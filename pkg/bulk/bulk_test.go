@@ -0,0 +1,61 @@
+package bulk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]Mode{"": ModeInsert, "insert": ModeInsert, "upsert": ModeUpsert} {
+		got, err := ParseMode(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseMode("replace")
+	assert.ErrorIs(t, err, ErrInvalidMode)
+}
+
+func TestApply(t *testing.T) {
+	errTaken := errors.New("code already exists")
+	row := func(code string) (Outcome, error) {
+		switch code {
+		case "taken":
+			return Failed(errTaken), nil
+		case "broken":
+			return Outcome{}, errors.New("connection lost")
+		}
+		return Inserted(len(code)), nil
+	}
+
+	t.Run("it should keep the writes when every row is applied", func(t *testing.T) {
+		outcomes, err := Apply([]string{"a", "bb"}, Options{}, row)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Outcome{Inserted(1), Inserted(2)}, outcomes)
+	})
+
+	t.Run("it should report every row and roll back when one is rejected", func(t *testing.T) {
+		outcomes, err := Apply([]string{"taken", "bb", "taken"}, Options{}, row)
+
+		assert.ErrorIs(t, err, ErrRollback)
+		assert.Equal(t, []Outcome{Failed(errTaken), Inserted(2), Failed(errTaken)}, outcomes)
+	})
+
+	t.Run("it should roll back a dry run", func(t *testing.T) {
+		outcomes, err := Apply([]string{"a"}, Options{DryRun: true}, row)
+
+		assert.ErrorIs(t, err, ErrRollback)
+		assert.Equal(t, []Outcome{Inserted(1)}, outcomes)
+	})
+
+	t.Run("it should stop at the first error of row", func(t *testing.T) {
+		outcomes, err := Apply([]string{"a", "broken", "taken"}, Options{}, row)
+
+		assert.EqualError(t, err, "connection lost")
+		assert.Nil(t, outcomes)
+	})
+}
@@ -0,0 +1,230 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MaxRows is the largest number of rows a file may have.
+const MaxRows = 10000
+
+// Errors returned for files that cannot be imported at all.
+var (
+	ErrUnsupportedFormat = errors.New("file must be CSV (text/csv) or NDJSON (application/x-ndjson)")
+	ErrNoRows            = errors.New("file has no rows")
+	ErrTooManyRows       = fmt.Errorf("file has more than %d rows", MaxRows)
+)
+
+// Format is the encoding of an import file.
+type Format int
+
+const (
+	CSV Format = iota + 1
+	NDJSON
+)
+
+// FormatOf returns the format of a file from its media type, or from the
+// extension of its name when the media type is missing or generic.
+func FormatOf(contentType, filename string) (Format, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return CSV, nil
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return NDJSON, nil
+	}
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".csv":
+		return CSV, nil
+	case ".ndjson", ".jsonl":
+		return NDJSON, nil
+	}
+	return 0, ErrUnsupportedFormat
+}
+
+// Row is a decoded row of a file. Err is set when the row could not be
+// decoded into Value.
+type Row[T any] struct {
+	Line  int
+	Value T
+	Err   error
+}
+
+// Decode reads the rows of a file into values of T, a struct whose fields are
+// named by their json tags.
+//
+// An NDJSON file holds a JSON object per line; blank lines are skipped. A CSV
+// file starts with a header naming a field per column; empty cells leave the
+// zero value. An unknown column, or a malformed file, is an error for the
+// whole file, while a row that does not fit T only has its Err set.
+func Decode[T any](r io.Reader, f Format) ([]Row[T], error) {
+	var rows []Row[T]
+	var err error
+	switch f {
+	case CSV:
+		rows, err = decodeCSV[T](r)
+	case NDJSON:
+		rows, err = decodeNDJSON[T](r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrNoRows
+	}
+	return rows, nil
+}
+
+func decodeNDJSON[T any](r io.Reader) ([]Row[T], error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var rows []Row[T]
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, ErrTooManyRows
+		}
+
+		row := Row[T]{Line: line}
+		if err := json.Unmarshal(text, &row.Value); err != nil {
+			row.Err = errors.New("invalid JSON")
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+func decodeCSV[T any](r io.Reader) ([]Row[T], error) {
+	reader := csv.NewReader(skipBOM(r))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields, err := columns(reflect.TypeOf((*T)(nil)).Elem(), header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row[T]
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == MaxRows {
+			return nil, ErrTooManyRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row[T]{Line: line}
+		if len(record) != len(header) {
+			row.Err = fmt.Errorf("row has %d columns, the header has %d", len(record), len(header))
+		} else {
+			row.Err = setFields(reflect.ValueOf(&row.Value).Elem(), fields, header, record)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// columns returns, for every column of header, the index of the field of t
+// whose json tag names it.
+func columns(t reflect.Type, header []string) ([]int, error) {
+	byName := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			byName[name] = i
+		}
+	}
+
+	fields := make([]int, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		field, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicated column %q", name)
+		}
+		seen[name] = true
+		fields[i] = field
+	}
+	return fields, nil
+}
+
+// setFields parses the cells of record into the fields of v.
+func setFields(v reflect.Value, fields []int, header, record []string) error {
+	var errs []error
+	for i, cell := range record {
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+
+		f := v.Field(fields[i])
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(cell)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(cell, 10, f.Type().Bits())
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be an integer", header[i]))
+				continue
+			}
+			f.SetInt(n)
+		case reflect.Float32, reflect.Float64:
+			n, err := strconv.ParseFloat(cell, f.Type().Bits())
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be a number", header[i]))
+				continue
+			}
+			f.SetFloat(n)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(cell)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s must be true or false", header[i]))
+				continue
+			}
+			f.SetBool(b)
+		default:
+			errs = append(errs, fmt.Errorf("%s cannot be imported from CSV", header[i]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// skipBOM drops the byte order mark spreadsheets put at the start of the CSV
+// files they export.
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(3); err == nil && bytes.Equal(b, []byte("\xef\xbb\xbf")) {
+		_, _ = br.Discard(3)
+	}
+	return br
+}
@@ -0,0 +1,95 @@
+package bulk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Code   string  `json:"code"`
+	Amount int     `json:"amount"`
+	Weight float32 `json:"weight"`
+	Note   string  `json:"-"`
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		contentType, filename string
+		want                  Format
+	}{
+		{"text/csv; charset=utf-8", "", CSV},
+		{"application/x-ndjson", "", NDJSON},
+		{"application/octet-stream", "products.CSV", CSV},
+		{"", "products.jsonl", NDJSON},
+	}
+	for _, tt := range tests {
+		got, err := FormatOf(tt.contentType, tt.filename)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := FormatOf("application/json", "products.json")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestDecode_CSV(t *testing.T) {
+	t.Run("it should decode the rows by header name with their line", func(t *testing.T) {
+		// Arrange
+		file := "\xef\xbb\xbfweight,code,amount\n" +
+			"1.5,A1,3\n" +
+			"\n" +
+			"2,\"B\n2\",\n"
+
+		// Act
+		rows, err := Decode[item](strings.NewReader(file), CSV)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []Row[item]{
+			{Line: 2, Value: item{Code: "A1", Amount: 3, Weight: 1.5}},
+			{Line: 4, Value: item{Code: "B\n2", Weight: 2}},
+		}, rows)
+	})
+
+	t.Run("it should report the cells that do not fit a row", func(t *testing.T) {
+		file := "code,amount,weight\nA1,three,heavy\nB2,1\n"
+
+		rows, err := Decode[item](strings.NewReader(file), CSV)
+
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.EqualError(t, rows[0].Err, "amount must be an integer\nweight must be a number")
+		assert.EqualError(t, rows[1].Err, "row has 2 columns, the header has 3")
+	})
+
+	t.Run("it should reject an unknown column", func(t *testing.T) {
+		_, err := Decode[item](strings.NewReader("code,Note\nA1,x\n"), CSV)
+
+		assert.EqualError(t, err, `unknown column "Note"`)
+	})
+
+	t.Run("it should reject a file without rows", func(t *testing.T) {
+		_, err := Decode[item](strings.NewReader("code,amount\n"), CSV)
+
+		assert.ErrorIs(t, err, ErrNoRows)
+	})
+}
+
+func TestDecode_NDJSON(t *testing.T) {
+	// Arrange
+	file := `{"code":"A1","amount":3}` + "\n\n" + `{"code":` + "\n" + `{"code":"B2","weight":2.5}`
+
+	// Act
+	rows, err := Decode[item](strings.NewReader(file), NDJSON)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, Row[item]{Line: 1, Value: item{Code: "A1", Amount: 3}}, rows[0])
+	assert.Equal(t, 3, rows[1].Line)
+	assert.EqualError(t, rows[1].Err, "invalid JSON")
+	assert.Equal(t, Row[item]{Line: 4, Value: item{Code: "B2", Weight: 2.5}}, rows[2])
+}
@@ -0,0 +1,75 @@
+// Package cache keeps the results of hot lookups, such as the reference data
+// every create checks, so they do not hit the database each time. The values
+// are stored as JSON, in the process (NewLRU) or in Redis (NewRedis), and
+// expire after a TTL; the writes are expected to invalidate the entries they
+// change.
+//
+// A cache is an optimization: when it fails the lookups fall back to their
+// source and the failure is logged, never returned.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+)
+
+// DefaultTTL is how long an entry is kept when no TTL is configured.
+const DefaultTTL = 5 * time.Minute
+
+// Cache stores values under string keys.
+type Cache interface {
+	// Get returns the value of key and whether it was found and not expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key until ttl elapses.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes keys. Missing keys are ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Key returns the key of the entity id, e.g. "product:12".
+func Key(entity string, id int) string {
+	return entity + ":" + strconv.Itoa(id)
+}
+
+// Through returns the value of key in c or, when it is missing, the value
+// load returns, which is then stored for ttl. Errors are not stored.
+func Through[T any](ctx context.Context, c Cache, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	raw, ok, err := c.Get(ctx, key)
+	if err != nil {
+		log.Printf("cache: reading %s: %v", key, err)
+	}
+	if ok {
+		var v T
+		if err := json.Unmarshal(raw, &v); err == nil {
+			return v, nil
+		}
+		log.Printf("cache: decoding %s: %v", key, err)
+	}
+
+	v, err := load()
+	if err != nil {
+		return v, err
+	}
+	if raw, err = json.Marshal(v); err == nil {
+		err = c.Set(ctx, key, raw, ttl)
+	}
+	if err != nil {
+		log.Printf("cache: writing %s: %v", key, err)
+	}
+	return v, nil
+}
+
+// Invalidate removes keys from c. The entries are removed even if ctx was
+// canceled meanwhile, as the writes that changed them were made; a failure
+// is logged and leaves them until they expire.
+func Invalidate(ctx context.Context, c Cache, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if err := c.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+		log.Printf("cache: invalidating %v: %v", keys, err)
+	}
+}
@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/redistest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	caches := map[string]func(t *testing.T) Cache{
+		"lru": func(t *testing.T) Cache {
+			return NewLRU(10)
+		},
+		"redis": func(t *testing.T) Cache {
+			c, err := ConnectRedis(redistest.Start(t).URL())
+			require.NoError(t, err)
+			return c
+		},
+	}
+
+	for name, newCache := range caches {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("it should return the value stored under a key", func(t *testing.T) {
+				// Arrange
+				c := newCache(t)
+				require.NoError(t, c.Set(ctx, "product:1", []byte(`{"id":1}`), time.Minute))
+
+				// Act
+				value, ok, err := c.Get(ctx, "product:1")
+				_, missing, errMissing := c.Get(ctx, "product:2")
+
+				// Assert
+				require.NoError(t, err)
+				require.NoError(t, errMissing)
+				assert.True(t, ok)
+				assert.Equal(t, []byte(`{"id":1}`), value)
+				assert.False(t, missing)
+			})
+
+			t.Run("it should forget the deleted keys", func(t *testing.T) {
+				// Arrange
+				c := newCache(t)
+				require.NoError(t, c.Set(ctx, "product:1", []byte(`{}`), time.Minute))
+				require.NoError(t, c.Set(ctx, "product:2", []byte(`{}`), time.Minute))
+
+				// Act
+				err := c.Delete(ctx, "product:1", "product:3")
+
+				// Assert
+				require.NoError(t, err)
+				_, ok, _ := c.Get(ctx, "product:1")
+				assert.False(t, ok)
+				_, ok, _ = c.Get(ctx, "product:2")
+				assert.True(t, ok)
+			})
+
+			t.Run("it should forget the expired keys", func(t *testing.T) {
+				// Arrange
+				c := newCache(t)
+				require.NoError(t, c.Set(ctx, "product:1", []byte(`{}`), 10*time.Millisecond))
+
+				// Act
+				time.Sleep(30 * time.Millisecond)
+				_, ok, err := c.Get(ctx, "product:1")
+
+				// Assert
+				require.NoError(t, err)
+				assert.False(t, ok)
+			})
+		})
+	}
+}
+
+func TestLRU(t *testing.T) {
+	t.Run("it should evict the entry used last", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		c := NewLRU(2)
+		require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+		require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+		_, _, _ = c.Get(ctx, "a")
+
+		// Act
+		require.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+
+		// Assert
+		_, ok, _ := c.Get(ctx, "b")
+		assert.False(t, ok)
+		_, ok, _ = c.Get(ctx, "a")
+		assert.True(t, ok)
+		_, ok, _ = c.Get(ctx, "c")
+		assert.True(t, ok)
+	})
+}
+
+// brokenCache fails every operation, like an unreachable Redis.
+type brokenCache struct{}
+
+func (brokenCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (brokenCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (brokenCache) Delete(ctx context.Context, keys ...string) error {
+	return errors.New("connection refused")
+}
+
+type product struct {
+	ID   int    `json:"id"`
+	Code string `json:"code"`
+}
+
+func TestThrough(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should load a missing value once", func(t *testing.T) {
+		// Arrange
+		c, loads := NewLRU(10), 0
+		load := func() (product, error) {
+			loads++
+			return product{ID: 1, Code: "P1"}, nil
+		}
+
+		// Act
+		first, err := Through(ctx, c, Key("product", 1), time.Minute, load)
+		require.NoError(t, err)
+		second, err := Through(ctx, c, Key("product", 1), time.Minute, load)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, product{ID: 1, Code: "P1"}, first)
+		assert.Equal(t, first, second)
+		assert.Equal(t, 1, loads)
+	})
+
+	t.Run("it should not store errors", func(t *testing.T) {
+		// Arrange
+		c, loads := NewLRU(10), 0
+		expected := errors.New("product not found")
+		load := func() (product, error) {
+			loads++
+			return product{}, expected
+		}
+
+		// Act
+		_, err := Through(ctx, c, Key("product", 1), time.Minute, load)
+		_, _ = Through(ctx, c, Key("product", 1), time.Minute, load)
+
+		// Assert
+		assert.ErrorIs(t, err, expected)
+		assert.Equal(t, 2, loads)
+	})
+
+	t.Run("it should load the value when the cache fails", func(t *testing.T) {
+		// Act
+		obtained, err := Through(ctx, brokenCache{}, Key("product", 1), time.Minute, func() (product, error) {
+			return product{ID: 1}, nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, product{ID: 1}, obtained)
+	})
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultPrefix is the prefix of the keys a Redis cache writes.
+const DefaultPrefix = "apigo:"
+
+// NewRedis returns a cache stored in Redis through client, shared by the
+// processes using the same server, under keys starting with prefix.
+func NewRedis(client redis.UniversalClient, prefix string) Cache {
+	return &redisCache{client: client, prefix: prefix}
+}
+
+// ConnectRedis returns a cache stored in the Redis server at url, e.g.
+// redis://localhost:6379/0, under keys starting with DefaultPrefix.
+func ConnectRedis(url string) (Cache, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedis(redis.NewClient(opts), DefaultPrefix), nil
+}
+
+type redisCache struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefix+key, value, ttl).Err()
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	return c.client.Del(ctx, prefixed...).Err()
+}
@@ -0,0 +1,92 @@
+// Package dataloader batches and caches the lookups that concurrent resolvers
+// make during one request, so that resolving a relation on N parents takes a
+// single query instead of N.
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultWait is how long a Loader collects keys before it fetches them.
+const DefaultWait = 2 * time.Millisecond
+
+// FetchFunc loads the values of keys in a single call. Keys missing from the
+// returned map resolve to the zero value of V.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects the keys requested within a short window and fetches them
+// together. Every key is fetched at most once, so a Loader must not outlive the
+// request it was created for.
+type Loader[K comparable, V any] struct {
+	fetch FetchFunc[K, V]
+	wait  time.Duration
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results []*result[V]
+}
+
+// New returns a Loader that calls fetch with the keys requested within wait of
+// each other.
+func New[K comparable, V any](wait time.Duration, fetch FetchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		wait:    wait,
+		results: make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value of key, waiting for the batch it joins to be fetched.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.results[key] = r
+		if l.pending == nil {
+			b := &batch[K, V]{}
+			l.pending = b
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx, b) })
+		}
+		l.pending.keys = append(l.pending.keys, key)
+		l.pending.results = append(l.pending.results, r)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch fetches the keys of b and hands the values to the waiting callers.
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending == b {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, b.keys)
+	for i, key := range b.keys {
+		r := b.results[i]
+		r.value, r.err = values[key], err
+		close(r.done)
+	}
+}
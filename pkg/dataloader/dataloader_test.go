@@ -0,0 +1,76 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_Load(t *testing.T) {
+	t.Run("it should fetch concurrent keys in a single batch", func(t *testing.T) {
+		// Arrange
+		var calls [][]int
+		loader := New(DefaultWait, func(ctx context.Context, keys []int) (map[int]string, error) {
+			calls = append(calls, keys)
+			values := map[int]string{}
+			for _, k := range keys {
+				if k != 3 {
+					values[k] = string(rune('a' + k))
+				}
+			}
+			return values, nil
+		})
+
+		// Act
+		keys := []int{0, 1, 2, 1, 3}
+		obtained := make([]string, len(keys))
+		var wg sync.WaitGroup
+		for i, k := range keys {
+			wg.Add(1)
+			go func(i, k int) {
+				defer wg.Done()
+				v, err := loader.Load(context.Background(), k)
+				require.NoError(t, err)
+				obtained[i] = v
+			}(i, k)
+		}
+		wg.Wait()
+
+		// Assert
+		require.Len(t, calls, 1)
+		assert.ElementsMatch(t, []int{0, 1, 2, 3}, calls[0])
+		assert.Equal(t, []string{"a", "b", "c", "b", ""}, obtained)
+	})
+
+	t.Run("it should cache the keys already fetched", func(t *testing.T) {
+		calls := 0
+		loader := New(DefaultWait, func(ctx context.Context, keys []int) (map[int]int, error) {
+			calls++
+			return map[int]int{1: 10}, nil
+		})
+
+		first, err := loader.Load(context.Background(), 1)
+		require.NoError(t, err)
+		second, err := loader.Load(context.Background(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 10, first)
+		assert.Equal(t, 10, second)
+	})
+
+	t.Run("it should return the fetch error to every caller of the batch", func(t *testing.T) {
+		errFetch := errors.New("fetch failed")
+		loader := New(DefaultWait, func(ctx context.Context, keys []int) (map[int]int, error) {
+			return nil, errFetch
+		})
+
+		_, err := loader.Load(context.Background(), 1)
+
+		assert.ErrorIs(t, err, errFetch)
+	})
+}
@@ -0,0 +1,46 @@
+// Package dbtx lets repositories run the same queries on a database or inside
+// a transaction.
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// DB is the part of *sql.DB and *sql.Tx the repositories use.
+type DB interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Run calls fn with a transaction of db. The transaction is committed when fn
+// returns nil and rolled back otherwise, in which case Run returns the error
+// of fn. When db already is a transaction fn runs in it, and committing is left
+// to whoever began it.
+func Run(ctx context.Context, db DB, fn func(tx DB) error) error {
+	beginner, ok := db.(interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	})
+	if !ok {
+		return fn(db)
+	}
+
+	tx, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,79 @@
+package dbtx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should commit when fn succeeds", func(t *testing.T) {
+		// Arrange
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		m.ExpectBegin()
+		m.ExpectExec("INSERT INTO buyers").WillReturnResult(sqlmock.NewResult(1, 1))
+		m.ExpectCommit()
+
+		// Act
+		err = Run(ctx, db, func(tx DB) error {
+			_, err := tx.ExecContext(ctx, "INSERT INTO buyers(card_number_id) VALUES (?)", "C1")
+			return err
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, m.ExpectationsWereMet())
+	})
+
+	t.Run("it should roll back and return the error of fn", func(t *testing.T) {
+		// Arrange
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		m.ExpectBegin()
+		m.ExpectExec("INSERT INTO buyers").WillReturnResult(sqlmock.NewResult(1, 1))
+		m.ExpectRollback()
+		errRow := errors.New("row 2 is invalid")
+
+		// Act
+		err = Run(ctx, db, func(tx DB) error {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO buyers(card_number_id) VALUES (?)", "C1"); err != nil {
+				return err
+			}
+			return errRow
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, errRow)
+		assert.NoError(t, m.ExpectationsWereMet())
+	})
+
+	t.Run("it should reuse a transaction it is given", func(t *testing.T) {
+		// Arrange
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		m.ExpectBegin()
+		m.ExpectCommit()
+		tx, err := db.Begin()
+		require.NoError(t, err)
+
+		// Act
+		var inner DB
+		err = Run(ctx, tx, func(t DB) error {
+			inner = t
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Same(t, tx, inner)
+		require.NoError(t, tx.Commit())
+		assert.NoError(t, m.ExpectationsWereMet())
+	})
+}
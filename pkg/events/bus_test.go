@@ -0,0 +1,167 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/natstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wait is how long a test waits for an event to arrive, or not to.
+const wait = 200 * time.Millisecond
+
+func newEvent(eventType string) Event {
+	return Event{
+		ID:          "0b5c4f0e-8d7b-4d3c-9a44-5c8e3b1f2a60",
+		Type:        eventType,
+		Version:     1,
+		AggregateID: 7,
+		OccurredAt:  time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC),
+		Data:        json.RawMessage(`{"id":7}`),
+	}
+}
+
+// receive subscribes to eventType and returns the channel the events
+// received are sent to.
+func receive(t *testing.T, bus EventBus, eventType string) (<-chan Event, Subscription) {
+	t.Helper()
+	received := make(chan Event, 10)
+	sub, err := bus.Subscribe(eventType, func(ctx context.Context, e Event) error {
+		received <- e
+		return nil
+	})
+	require.NoError(t, err)
+	return received, sub
+}
+
+func TestBus(t *testing.T) {
+	buses := map[string]func(t *testing.T) EventBus{
+		"memory": func(t *testing.T) EventBus {
+			return NewMemoryBus()
+		},
+		"nats": func(t *testing.T) EventBus {
+			bus, err := ConnectNATS(natstest.Start(t).URL(), NATSOptions{})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = bus.Close() })
+			return bus
+		},
+	}
+
+	for name, newBus := range buses {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("it should deliver an event to the handlers of its type only", func(t *testing.T) {
+				// Arrange
+				bus := newBus(t)
+				created, _ := receive(t, bus, "purchase_order.created")
+				received, _ := receive(t, bus, "inbound_order.received")
+
+				// Act
+				err := bus.Publish(ctx, newEvent("purchase_order.created"))
+
+				// Assert
+				require.NoError(t, err)
+				select {
+				case e := <-created:
+					assert.Equal(t, newEvent("purchase_order.created"), e)
+				case <-time.After(wait):
+					t.Fatal("the event was not delivered")
+				}
+				select {
+				case e := <-received:
+					t.Fatalf("unexpected event %v", e)
+				case <-time.After(wait):
+				}
+			})
+
+			t.Run("it should stop delivering to an unsubscribed handler", func(t *testing.T) {
+				// Arrange
+				bus := newBus(t)
+				created, sub := receive(t, bus, "purchase_order.created")
+				require.NoError(t, sub.Unsubscribe())
+
+				// Act
+				err := bus.Publish(ctx, newEvent("purchase_order.created"))
+
+				// Assert
+				require.NoError(t, err)
+				select {
+				case e := <-created:
+					t.Fatalf("unexpected event %v", e)
+				case <-time.After(wait):
+				}
+			})
+		})
+	}
+}
+
+func TestMemoryBus(t *testing.T) {
+	t.Run("it should return the errors of the handlers", func(t *testing.T) {
+		// Arrange
+		bus := NewMemoryBus()
+		expected := errors.New("connection refused")
+		_, err := bus.Subscribe("purchase_order.created", func(ctx context.Context, e Event) error { return expected })
+		require.NoError(t, err)
+
+		// Act
+		err = bus.Publish(context.Background(), newEvent("purchase_order.created"))
+
+		// Assert
+		assert.ErrorIs(t, err, expected)
+	})
+
+	t.Run("it should refuse events once closed", func(t *testing.T) {
+		// Arrange
+		bus := NewMemoryBus()
+		require.NoError(t, bus.Close())
+
+		// Act
+		err := bus.Publish(context.Background(), newEvent("purchase_order.created"))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+}
+
+func TestNATSBus(t *testing.T) {
+	t.Run("it should deliver an event once to the handlers sharing a queue", func(t *testing.T) {
+		// Arrange
+		url := natstest.Start(t).URL()
+		received := make(chan Event, 10)
+		for i := 0; i < 2; i++ {
+			bus, err := ConnectNATS(url, NATSOptions{Queue: "webhooks"})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = bus.Close() })
+			_, err = bus.Subscribe("purchase_order.created", func(ctx context.Context, e Event) error {
+				received <- e
+				return nil
+			})
+			require.NoError(t, err)
+		}
+		publisher, err := ConnectNATS(url, NATSOptions{})
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		// Act
+		err = publisher.Publish(context.Background(), newEvent("purchase_order.created"))
+
+		// Assert
+		require.NoError(t, err)
+		select {
+		case <-received:
+		case <-time.After(wait):
+			t.Fatal("the event was not delivered")
+		}
+		select {
+		case e := <-received:
+			t.Fatalf("the event was delivered twice: %v", e)
+		case <-time.After(wait):
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// Package events carries the domain events of the API, such as the creation
+// of a purchase order, from the service that committed them to the ones that
+// react to them, in process or through NATS.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event is a fact about an entity, published once the change it describes is
+// committed.
+type Event struct {
+	// ID identifies the event, so consumers can skip an event delivered
+	// twice.
+	ID string `json:"id"`
+	// Type names the event, e.g. purchase_order.created, and Version the
+	// JSON schema its Data follows.
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+	// AggregateID is the ID of the entity the event is about.
+	AggregateID int             `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Handler reacts to an event.
+type Handler func(ctx context.Context, e Event) error
+
+// Subscription is the registration of a Handler to a bus.
+type Subscription interface {
+	// Unsubscribe stops the handler from receiving events.
+	Unsubscribe() error
+}
+
+// EventBus carries events from their publishers to the handlers subscribed
+// to their type.
+type EventBus interface {
+	// Publish sends e to the handlers subscribed to its type. It returns
+	// once the bus accepted the event, which may be before the handlers
+	// received it.
+	Publish(ctx context.Context, e Event) error
+	// Subscribe registers h to receive the events of type eventType.
+	Subscribe(eventType string, h Handler) (Subscription, error)
+	// Close stops the bus. The events already accepted are still handed to
+	// their handlers.
+	Close() error
+}
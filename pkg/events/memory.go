@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when publishing to or subscribing on a closed bus.
+var ErrClosed = errors.New("events: bus closed")
+
+// memoryBus hands the events to the handlers of the same process.
+type memoryBus struct {
+	mu       sync.RWMutex
+	handlers map[string]map[*memorySubscription]Handler
+	closed   bool
+}
+
+// NewMemoryBus returns an EventBus within the process. Publish calls the
+// handlers of the event in turn and returns their errors, so a publisher
+// retrying failed events retries the ones no handler could take.
+func NewMemoryBus() EventBus {
+	return &memoryBus{handlers: make(map[string]map[*memorySubscription]Handler)}
+}
+
+func (b *memoryBus) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	handlers := make([]Handler, 0, len(b.handlers[e.Type]))
+	for _, h := range b.handlers[e.Type] {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *memoryBus) Subscribe(eventType string, h Handler) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	sub := &memorySubscription{bus: b, eventType: eventType}
+	if b.handlers[eventType] == nil {
+		b.handlers[eventType] = make(map[*memorySubscription]Handler)
+	}
+	b.handlers[eventType][sub] = h
+	return sub, nil
+}
+
+func (b *memoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return nil
+}
+
+type memorySubscription struct {
+	bus       *memoryBus
+	eventType string
+}
+
+func (s *memorySubscription) Unsubscribe() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	delete(s.bus.handlers[s.eventType], s)
+	return nil
+}
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// DefaultSubjectPrefix is the prefix of the subjects the events are published
+// to by default.
+const DefaultSubjectPrefix = "apigo.events"
+
+// flushTimeout bounds the wait for the acknowledgement of an event published
+// with a context without deadline.
+const flushTimeout = 10 * time.Second
+
+// NATSOptions configures a NATS bus.
+type NATSOptions struct {
+	// SubjectPrefix is prepended, with a dot, to the type of an event to
+	// make the subject it is published to. DefaultSubjectPrefix by default.
+	SubjectPrefix string
+	// Queue, when set, joins the handlers of every process subscribing with
+	// the same queue to a queue group, so an event is handled by one process
+	// only instead of all of them.
+	Queue string
+}
+
+// natsBus publishes the events to NATS subjects.
+type natsBus struct {
+	conn   *nats.Conn
+	prefix string
+	queue  string
+	// owned tells whether Close closes conn.
+	owned bool
+}
+
+// NewNATSBus returns an EventBus publishing to conn. Closing the bus leaves
+// conn open.
+func NewNATSBus(conn *nats.Conn, opts NATSOptions) EventBus {
+	if opts.SubjectPrefix == "" {
+		opts.SubjectPrefix = DefaultSubjectPrefix
+	}
+	return &natsBus{conn: conn, prefix: opts.SubjectPrefix, queue: opts.Queue}
+}
+
+// ConnectNATS connects to the NATS server at url and returns an EventBus
+// publishing to it. Closing the bus drains and closes the connection.
+func ConnectNATS(url string, opts NATSOptions) (EventBus, error) {
+	conn, err := nats.Connect(url, nats.Name("apigo"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	b := NewNATSBus(conn, opts).(*natsBus)
+	b.owned = true
+	return b, nil
+}
+
+// Publish sends the event and waits for the server to acknowledge it, so an
+// event that was not received fails and can be published again. NATS does not
+// redeliver an event its handlers failed on.
+func (b *natsBus) Publish(ctx context.Context, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := b.conn.Publish(b.subject(e.Type), data); err != nil {
+		return err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return b.conn.FlushTimeout(flushTimeout)
+	}
+	return b.conn.FlushWithContext(ctx)
+}
+
+// Subscribe registers h for eventType. The errors of h are logged.
+func (b *natsBus) Subscribe(eventType string, h Handler) (Subscription, error) {
+	handle := func(msg *nats.Msg) {
+		var e Event
+		if err := json.Unmarshal(msg.Data, &e); err != nil {
+			log.Printf("events: decoding %s: %v", msg.Subject, err)
+			return
+		}
+		if err := h(context.Background(), e); err != nil {
+			log.Printf("events: handling %s %s: %v", e.Type, e.ID, err)
+		}
+	}
+
+	var (
+		sub *nats.Subscription
+		err error
+	)
+	if b.queue != "" {
+		sub, err = b.conn.QueueSubscribe(b.subject(eventType), b.queue, handle)
+	} else {
+		sub, err = b.conn.Subscribe(b.subject(eventType), handle)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The subscription is only active once the server processed it.
+	if err := b.conn.Flush(); err != nil {
+		_ = sub.Unsubscribe()
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (b *natsBus) Close() error {
+	if !b.owned {
+		return nil
+	}
+	return b.conn.Drain()
+}
+
+func (b *natsBus) subject(eventType string) string {
+	return b.prefix + "." + eventType
+}
@@ -0,0 +1,154 @@
+// Package lineproto parses the line protocol of InfluxDB, the text the
+// sensors write their measurements in, one point per line:
+//
+//	temperature,sensor=north value=-18.5 1697025600000000000
+//
+// A point has a measurement, optional tags, at least one field and an
+// optional timestamp. Only numeric fields are supported.
+package lineproto
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSyntax is returned, in a *SyntaxError, for a line that is not a point.
+var ErrSyntax = errors.New("lineproto: invalid line")
+
+// SyntaxError tells the line a point could not be read from, and why.
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s %d: %s", ErrSyntax, e.Line, e.Msg)
+}
+
+func (e *SyntaxError) Unwrap() error { return ErrSyntax }
+
+// Point is a measurement read from a line. Time is zero when the line has
+// no timestamp.
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]float64
+	Time        time.Time
+}
+
+// Parse reads the points of r, skipping the empty lines and the comments,
+// which start with #. The timestamps are counted in precision units since
+// the Unix epoch, nanoseconds when precision is 0.
+func Parse(r io.Reader, precision time.Duration) ([]Point, error) {
+	if precision == 0 {
+		precision = time.Nanosecond
+	}
+	var points []Point
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p, err := parseLine(line, precision)
+		if err != nil {
+			return nil, &SyntaxError{Line: n, Msg: err.Error()}
+		}
+		points = append(points, p)
+	}
+	return points, scanner.Err()
+}
+
+func parseLine(line string, precision time.Duration) (Point, error) {
+	sections := split(line, ' ')
+	if len(sections) < 2 || len(sections) > 3 {
+		return Point{}, errors.New("a point is a measurement, its fields and a timestamp separated by spaces")
+	}
+
+	keys := split(sections[0], ',')
+	p := Point{Measurement: unescape(keys[0]), Tags: map[string]string{}, Fields: map[string]float64{}}
+	if p.Measurement == "" {
+		return Point{}, errors.New("missing measurement")
+	}
+	for _, tag := range keys[1:] {
+		k, v, ok := pair(tag)
+		if !ok {
+			return Point{}, fmt.Errorf("invalid tag %q", tag)
+		}
+		p.Tags[k] = v
+	}
+
+	for _, field := range split(sections[1], ',') {
+		k, v, ok := pair(field)
+		if !ok {
+			return Point{}, fmt.Errorf("invalid field %q", field)
+		}
+		f, err := number(v)
+		if err != nil {
+			return Point{}, fmt.Errorf("field %q is not a number", k)
+		}
+		p.Fields[k] = f
+	}
+
+	if len(sections) == 3 {
+		ts, err := strconv.ParseInt(sections[2], 10, 64)
+		if err != nil {
+			return Point{}, fmt.Errorf("invalid timestamp %q", sections[2])
+		}
+		// The time is counted in nanoseconds in an int64
+		if ts > math.MaxInt64/int64(precision) || ts < math.MinInt64/int64(precision) {
+			return Point{}, fmt.Errorf("timestamp %q out of range", sections[2])
+		}
+		p.Time = time.Unix(0, ts*int64(precision)).UTC()
+	}
+	return p, nil
+}
+
+// split splits s at the separators sep not escaped by a backslash nor in a
+// double quoted string.
+func split(s string, sep byte) []string {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case s[i] == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// pair splits a key=value pair at its first unescaped =.
+func pair(s string) (key, value string, ok bool) {
+	kv := split(s, '=')
+	if len(kv) < 2 || kv[0] == "" {
+		return "", "", false
+	}
+	return unescape(kv[0]), unescape(strings.Join(kv[1:], "=")), true
+}
+
+// number parses a float field, or an integer one suffixed with i or u.
+func number(v string) (float64, error) {
+	if n := len(v); n > 1 && (v[n-1] == 'i' || v[n-1] == 'u') {
+		i, err := strconv.ParseInt(v[:n-1], 10, 64)
+		return float64(i), err
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+var unescaper = strings.NewReplacer(`\,`, ",", `\ `, " ", `\=`, "=", `\\`, `\`)
+
+func unescape(s string) string {
+	return unescaper.Replace(s)
+}
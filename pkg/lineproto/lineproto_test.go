@@ -0,0 +1,70 @@
+package lineproto
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("it should read the points of every line", func(t *testing.T) {
+		// Arrange
+		text := `# freezer 3
+temperature,sensor=north,room=cold\ store value=-18.5 1697025600000000000
+
+temperature value=-17i,humidity=40
+`
+
+		// Act
+		points, err := Parse(strings.NewReader(text), 0)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, points, 2)
+		assert.Equal(t, Point{
+			Measurement: "temperature",
+			Tags:        map[string]string{"sensor": "north", "room": "cold store"},
+			Fields:      map[string]float64{"value": -18.5},
+			Time:        time.Date(2023, 10, 11, 12, 0, 0, 0, time.UTC),
+		}, points[0])
+		assert.Equal(t, map[string]float64{"value": -17, "humidity": 40}, points[1].Fields)
+		assert.True(t, points[1].Time.IsZero())
+	})
+
+	t.Run("it should count the timestamps in the precision given", func(t *testing.T) {
+		points, err := Parse(strings.NewReader("temperature value=2 1697025600"), time.Second)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 10, 11, 12, 0, 0, 0, time.UTC), points[0].Time)
+	})
+
+	t.Run("it should reject a timestamp beyond the nanoseconds an int64 counts", func(t *testing.T) {
+		for _, line := range []string{"temperature value=2 9223372037", "temperature value=2 -9223372037"} {
+			_, err := Parse(strings.NewReader(line), time.Second)
+
+			assert.ErrorIs(t, err, ErrSyntax, line)
+			assert.ErrorContains(t, err, "out of range", line)
+		}
+	})
+
+	t.Run("it should tell the line that is not a point", func(t *testing.T) {
+		for _, line := range []string{
+			"temperature",
+			"temperature value=cold",
+			`temperature value="cold"`,
+			"temperature value=1 yesterday",
+			",sensor=north value=1",
+			"temperature,sensor value=1",
+		} {
+			_, err := Parse(strings.NewReader("temperature value=1\n"+line), 0)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr, line)
+			assert.ErrorIs(t, err, ErrSyntax)
+			assert.Equal(t, 2, syntaxErr.Line, line)
+		}
+	})
+}
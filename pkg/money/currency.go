@@ -0,0 +1,56 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCurrency is returned for a code that is not an ISO 4217
+// currency.
+var ErrInvalidCurrency = errors.New("money: invalid currency")
+
+// Currency is the ISO 4217 code of a currency, e.g. "USD".
+type Currency string
+
+// DefaultCurrency is the currency of the prices recorded without one, and
+// the one the currency rates are expressed in.
+const DefaultCurrency Currency = "USD"
+
+// ParseCurrency parses an ISO 4217 code, in any case.
+func ParseCurrency(s string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	if _, ok := minorUnits[c]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+	}
+	return c, nil
+}
+
+// MinorUnits returns the number of fraction digits of the prices in c, 2 for
+// unknown currencies.
+func (c Currency) MinorUnits() int {
+	if digits, ok := minorUnits[c]; ok {
+		return digits
+	}
+	return 2
+}
+
+// minorUnits are the fraction digits of the active ISO 4217 currencies.
+var minorUnits = func() map[Currency]int {
+	units := make(map[Currency]int)
+	for digits, codes := range map[int]string{
+		0: "BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX VND VUV XAF XOF XPF",
+		2: "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BRL BSD BTN BWP BYN BZD " +
+			"CAD CDF CHF CNY COP CRC CUP CVE CZK DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD " +
+			"GTQ GYD HKD HNL HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD LSL MAD " +
+			"MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR NZD PAB PEN PGK PHP " +
+			"PKR PLN QAR RON RSD RUB SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS " +
+			"TMT TOP TRY TTD TWD TZS UAH USD UYU UZS VES WST XCD YER ZAR ZMW ZWL",
+		3: "BHD IQD JOD KWD LYD OMR TND",
+	} {
+		for _, code := range strings.Fields(codes) {
+			units[Currency(code)] = digits
+		}
+	}
+	return units
+}()
@@ -0,0 +1,223 @@
+// Package money holds amounts of money as fixed-point decimals, their
+// ISO 4217 currencies and the rates converting them, so that prices add up,
+// compare and round exactly.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of fraction digits of an Amount.
+const Scale = 4
+
+// unit is the Amount of 1.
+const unit = 10000
+
+// maxIntegerDigits bounds the integer part of the amounts parsed, the one of
+// the DECIMAL(19,4) columns storing them.
+const maxIntegerDigits = 15
+
+// ErrInvalidAmount is returned when a text is not a decimal amount of at most
+// Scale fraction digits.
+var ErrInvalidAmount = errors.New("money: invalid amount")
+
+// ErrOutOfRange is returned when an amount is not finite or does not fit an
+// Amount, whose range is within the one of a DECIMAL(19,4) column.
+var ErrOutOfRange = errors.New("money: amount out of range")
+
+// Amount is a decimal amount of money with Scale fraction digits, held as an
+// integer number of ten-thousandths. Its JSON form is a string, e.g. "16.20".
+type Amount int64
+
+// FromInt returns the Amount of n units.
+func FromInt(n int64) Amount {
+	return Amount(n * unit)
+}
+
+// FromFloat returns the Amount closest to f, or ErrOutOfRange if f is not
+// finite or too large for an Amount.
+func FromFloat(f float64) (Amount, error) {
+	v := math.Round(f * unit)
+	// float64(math.MaxInt64) rounds up to 2^63, the first value out of range.
+	if math.IsNaN(v) || v >= math.MaxInt64 || v < math.MinInt64 {
+		return 0, fmt.Errorf("%w: %g", ErrOutOfRange, f)
+	}
+	return Amount(v), nil
+}
+
+// Parse parses a decimal amount such as "16.2" or "-3".
+func Parse(s string) (Amount, error) {
+	v, err := parseDecimal(s, Scale, maxIntegerDigits)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	return Amount(v), nil
+}
+
+// Float64 returns a as a float, for the APIs typed with them.
+func (a Amount) Float64() float64 {
+	return float64(a) / unit
+}
+
+// String formats a with at least two fraction digits, e.g. "16.20" or
+// "0.125".
+func (a Amount) String() string {
+	return formatDecimal(int64(a), Scale, 2)
+}
+
+// Round rounds a to digits fraction digits, half away from zero, or returns
+// ErrOutOfRange if the rounded amount does not fit an Amount.
+func (a Amount) Round(digits int) (Amount, error) {
+	if digits >= Scale {
+		return a, nil
+	}
+	step := big.NewInt(int64(math.Pow10(Scale - digits)))
+	return toAmount(new(big.Int).Mul(divRound(big.NewInt(int64(a)), step), step))
+}
+
+// MulDiv returns a * num / den, rounded half away from zero to Scale
+// digits, or ErrOutOfRange if it does not fit an Amount. It panics if den
+// is 0.
+func (a Amount) MulDiv(num, den Amount) (Amount, error) {
+	n := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(num)))
+	return toAmount(divRound(n, big.NewInt(int64(den))))
+}
+
+// Ratio returns a / b rounded to four decimals, or 0 when b is 0.
+func Ratio(a, b Amount) float64 {
+	if b == 0 {
+		return 0
+	}
+	return math.Round(float64(a)/float64(b)*10000) / 10000
+}
+
+// MarshalJSON writes a as a JSON string.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(a.String())), nil
+}
+
+// UnmarshalJSON reads a JSON string or number, without going through a
+// float.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// Scan reads a DECIMAL column.
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case []byte:
+		return a.scanText(string(v))
+	case string:
+		return a.scanText(v)
+	case int64:
+		*a = FromInt(v)
+		return nil
+	case float64:
+		f, err := FromFloat(v)
+		if err != nil {
+			return err
+		}
+		*a = f
+		return nil
+	}
+	return fmt.Errorf("money: cannot scan %T into an Amount", src)
+}
+
+func (a *Amount) scanText(s string) error {
+	v, err := parseDecimal(s, Scale, -1)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	*a = Amount(v)
+	return nil
+}
+
+// Value writes a to a DECIMAL column.
+func (a Amount) Value() (driver.Value, error) {
+	return formatDecimal(int64(a), Scale, Scale), nil
+}
+
+// parseDecimal parses s as an integer number of 10^-scale, rejecting more
+// than scale fraction digits and, unless it is negative, more than
+// maxInteger integer digits.
+func parseDecimal(s string, scale, maxInteger int) (int64, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	integer, fraction, _ := strings.Cut(s, ".")
+	if integer == "" && fraction == "" || len(fraction) > scale ||
+		(maxInteger >= 0 && len(strings.TrimLeft(integer, "0")) > maxInteger) {
+		return 0, ErrInvalidAmount
+	}
+	digits := integer + fraction + strings.Repeat("0", scale-len(fraction))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, ErrInvalidAmount
+		}
+	}
+	v, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
+
+// formatDecimal formats v, a number of 10^-scale, trimming the trailing
+// zeros of its fraction beyond minFraction digits.
+func formatDecimal(v int64, scale, minFraction int) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	s := fmt.Sprintf("%0*d", scale+1, v)
+	integer, fraction := s[:len(s)-scale], s[len(s)-scale:]
+	for len(fraction) > minFraction && strings.HasSuffix(fraction, "0") {
+		fraction = fraction[:len(fraction)-1]
+	}
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
+}
+
+// toAmount returns v as an Amount, or ErrOutOfRange if it does not fit one.
+func toAmount(v *big.Int) (Amount, error) {
+	if !v.IsInt64() {
+		return 0, ErrOutOfRange
+	}
+	return Amount(v.Int64()), nil
+}
+
+// divRound returns n / d rounded half away from zero.
+func divRound(n, d *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(new(big.Int).Abs(d)) >= 0 {
+		if (n.Sign() < 0) != (d.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
@@ -0,0 +1,130 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmount(t *testing.T) {
+	t.Run("it should parse and format decimal amounts exactly", func(t *testing.T) {
+		for in, want := range map[string]string{"16.2": "16.20", "-3": "-3.00", "0.125": "0.125", ".0001": "0.0001", "+7.50": "7.50"} {
+			a, err := Parse(in)
+			require.NoError(t, err, in)
+			assert.Equal(t, want, a.String(), in)
+		}
+		for _, in := range []string{"", "-", "1.23456", "1,5", "1e3", "1234567890123456"} {
+			_, err := Parse(in)
+			assert.ErrorIs(t, err, ErrInvalidAmount, in)
+		}
+	})
+
+	t.Run("it should add amounts without rounding errors", func(t *testing.T) {
+		tenth, err := FromFloat(0.1)
+		require.NoError(t, err)
+		sum := Amount(0)
+		for i := 0; i < 10; i++ {
+			sum += tenth
+		}
+		assert.Equal(t, FromInt(1), sum)
+	})
+
+	t.Run("it should reject floats that are not finite or do not fit an amount", func(t *testing.T) {
+		for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e15, -1e15, math.MaxFloat64} {
+			_, err := FromFloat(f)
+			assert.ErrorIs(t, err, ErrOutOfRange, f)
+		}
+		a, err := FromFloat(-9e14)
+		require.NoError(t, err)
+		assert.Equal(t, "-900000000000000.00", a.String())
+	})
+
+	t.Run("it should round half away from zero", func(t *testing.T) {
+		for _, tc := range []struct {
+			amount Amount
+			digits int
+			want   string
+		}{
+			{Amount(23450), 2, "2.35"},
+			{Amount(-23450), 2, "-2.35"},
+			{Amount(25000), 0, "3.00"},
+		} {
+			a, err := tc.amount.Round(tc.digits)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, a.String())
+		}
+		a, err := FromInt(15).MulDiv(FromInt(108), FromInt(100))
+		require.NoError(t, err)
+		assert.Equal(t, "16.20", a.String())
+		assert.Equal(t, 0.375, Ratio(FromInt(6), FromInt(16)))
+		assert.Equal(t, 0.0, Ratio(FromInt(6), 0))
+	})
+
+	t.Run("it should reject results that do not fit an amount", func(t *testing.T) {
+		_, err := Amount(math.MaxInt64).Round(0)
+		assert.ErrorIs(t, err, ErrOutOfRange)
+		_, err = FromInt(1e14).MulDiv(FromInt(1000), FromInt(1))
+		assert.ErrorIs(t, err, ErrOutOfRange)
+	})
+
+	t.Run("it should write JSON strings and read strings or numbers", func(t *testing.T) {
+		var v struct {
+			A Amount `json:"a"`
+			B Amount `json:"b"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"a":"16.20","b":0.1}`), &v))
+		out, err := json.Marshal(v)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"a":"16.20","b":"0.10"}`, string(out))
+		assert.Error(t, json.Unmarshal([]byte(`{"a":"ten"}`), &v))
+	})
+
+	t.Run("it should scan and write DECIMAL columns", func(t *testing.T) {
+		var a Amount
+		require.NoError(t, a.Scan([]byte("110.5000")))
+		assert.Equal(t, Amount(1105000), a)
+		assert.ErrorIs(t, a.Scan(math.Inf(1)), ErrOutOfRange)
+		require.NoError(t, a.Scan(int64(3)))
+		assert.Equal(t, FromInt(3), a)
+		value, err := a.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "3.0000", value)
+	})
+}
+
+func TestCurrency(t *testing.T) {
+	c, err := ParseCurrency("eur")
+	require.NoError(t, err)
+	assert.Equal(t, Currency("EUR"), c)
+	assert.Equal(t, 2, c.MinorUnits())
+	assert.Equal(t, 0, Currency("JPY").MinorUnits())
+	assert.Equal(t, 3, Currency("KWD").MinorUnits())
+	_, err = ParseCurrency("EURO")
+	assert.ErrorIs(t, err, ErrInvalidCurrency)
+}
+
+func TestRate(t *testing.T) {
+	eur, err := ParseRate("1.08")
+	require.NoError(t, err)
+	ars, err := ParseRate("0.001")
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.08", eur.String())
+	assert.Equal(t, "1", OneRate.String())
+	converted, err := Convert(FromInt(100), eur, OneRate)
+	require.NoError(t, err)
+	assert.Equal(t, "108.00", converted.String())
+	converted, err = Convert(FromInt(1), eur, ars)
+	require.NoError(t, err)
+	assert.Equal(t, "1080.00", converted.String())
+	_, err = Convert(FromInt(1e14), eur, ars)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	out, err := json.Marshal(eur)
+	require.NoError(t, err)
+	assert.Equal(t, `"1.08"`, string(out))
+	_, err = ParseRate("1.123456789")
+	assert.ErrorIs(t, err, ErrInvalidRate)
+}
@@ -0,0 +1,89 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// RateScale is the number of fraction digits of a Rate.
+const RateScale = 8
+
+// ErrInvalidRate is returned when a text is not a decimal rate of at most
+// RateScale fraction digits.
+var ErrInvalidRate = errors.New("money: invalid rate")
+
+// Rate is the value of one unit of a currency in DefaultCurrency, a decimal
+// with RateScale fraction digits. Its JSON form is a string, e.g. "1.08".
+type Rate int64
+
+// OneRate is the Rate of DefaultCurrency.
+const OneRate Rate = 100000000
+
+// ParseRate parses a decimal rate such as "1.08".
+func ParseRate(s string) (Rate, error) {
+	v, err := parseDecimal(s, RateScale, 10)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	return Rate(v), nil
+}
+
+// String formats r without trailing zeros, e.g. "1.08" or "1".
+func (r Rate) String() string {
+	return formatDecimal(int64(r), RateScale, 0)
+}
+
+// Convert converts a from a currency whose rate is from to one whose rate is
+// to, rounded half away from zero, or returns ErrOutOfRange if the converted
+// amount does not fit an Amount. It panics if to is 0.
+func Convert(a Amount, from, to Rate) (Amount, error) {
+	n := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(from)))
+	return toAmount(divRound(n, big.NewInt(int64(to))))
+}
+
+// MarshalJSON writes r as a JSON string.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+// UnmarshalJSON reads a JSON string or number, without going through a
+// float.
+func (r *Rate) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// Scan reads a DECIMAL column.
+func (r *Rate) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("money: cannot scan %T into a Rate", src)
+	}
+	v, err := parseDecimal(s, RateScale, -1)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	*r = Rate(v)
+	return nil
+}
+
+// Value writes r to a DECIMAL column.
+func (r Rate) Value() (driver.Value, error) {
+	return formatDecimal(int64(r), RateScale, RateScale), nil
+}
@@ -0,0 +1,32 @@
+// Package natstest runs an embedded nats-server, so the code publishing to
+// NATS can be tested with the real client against the real server.
+package natstest
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats-server/v2/server"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+)
+
+// Server is an embedded NATS server.
+type Server struct {
+	*server.Server
+}
+
+// Start starts a server listening on a random local port. It is shut down
+// when the test finishes.
+func Start(t testing.TB) *Server {
+	t.Helper()
+
+	opts := natsserver.DefaultTestOptions
+	opts.Port = server.RANDOM_PORT
+	s := natsserver.RunServer(&opts)
+	t.Cleanup(s.Shutdown)
+	return &Server{Server: s}
+}
+
+// URL is the address clients connect to.
+func (s *Server) URL() string {
+	return s.ClientURL()
+}
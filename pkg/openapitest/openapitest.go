@@ -0,0 +1,179 @@
+// Package openapitest checks HTTP exchanges made in tests against an OpenAPI 3
+// document, so the published documents cannot drift from the handlers.
+package openapitest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// NDJSON files uploaded to the import endpoints are read as raw bytes, like
+// the other file types the validator knows.
+func init() {
+	openapi3filter.RegisterBodyDecoder("application/x-ndjson", openapi3filter.FileBodyDecoder)
+}
+
+// Spec is an OpenAPI 3 document the exchanges of a test are validated
+// against. It is parsed on first use and safe for concurrent tests.
+type Spec struct {
+	raw []byte
+
+	once     sync.Once
+	router   routers.Router
+	basePath string
+	err      error
+}
+
+// New returns a Spec over the raw JSON or YAML document.
+func New(raw []byte) *Spec {
+	return &Spec{raw: raw}
+}
+
+// load parses and validates the document once and builds a router over its
+// operations. Servers are replaced by the bare base path of the first server
+// so requests built without a host still match.
+func (s *Spec) load() (routers.Router, error) {
+	s.once.Do(func() {
+		doc, err := openapi3.NewLoader().LoadFromData(s.raw)
+		if err != nil {
+			s.err = err
+			return
+		}
+		if err = doc.Validate(context.Background()); err != nil {
+			s.err = err
+			return
+		}
+		if len(doc.Servers) == 0 {
+			s.err = errors.New("document declares no server")
+			return
+		}
+		u, err := url.Parse(doc.Servers[0].URL)
+		if err != nil {
+			s.err = err
+			return
+		}
+		s.basePath = strings.TrimSuffix(u.Path, "/")
+		doc.Servers = openapi3.Servers{{URL: s.basePath}}
+		s.router, s.err = gorillamux.NewRouter(doc)
+	})
+	return s.router, s.err
+}
+
+// ServeHTTP serves req through h and checks the exchange against the
+// document. The response is always validated against the operation matching
+// the request; the request itself is only validated when the handler accepted
+// it, since many tests send invalid input on purpose. Requests for routes the
+// document does not describe must be answered by the router itself (404/405).
+func (s *Spec) ServeHTTP(t *testing.T, h http.Handler, w *httptest.ResponseRecorder, req *http.Request) {
+	t.Helper()
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			t.Fatalf("openapi: reading request body: %v", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	h.ServeHTTP(w, req)
+
+	router, err := s.load()
+	if err != nil {
+		t.Fatalf("openapi: loading document: %v", err)
+	}
+
+	specReq := s.request(req, body)
+	route, pathParams, err := router.FindRoute(specReq)
+	if err != nil {
+		if w.Code != http.StatusNotFound && w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("openapi: %s %s answered %d but is not documented: %v", req.Method, req.URL.Path, w.Code, err)
+		}
+		return
+	}
+
+	options := &openapi3filter.Options{IncludeResponseStatus: true}
+	reqInput := &openapi3filter.RequestValidationInput{
+		Request:    specReq,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options,
+	}
+
+	if w.Code >= 200 && w.Code < 300 {
+		// Swagger 2.0 gives the body a single schema, for the JSON bodies, so
+		// the text bodies are only checked for a documented media type.
+		checked := *reqInput
+		if mediaType, _, _ := mime.ParseMediaType(specReq.Header.Get("Content-Type")); strings.HasPrefix(mediaType, "text/") {
+			textOptions := *options
+			textOptions.ExcludeRequestBody = true
+			checked.Options = &textOptions
+			if body := route.Operation.RequestBody; body == nil || body.Value == nil || body.Value.Content.Get(mediaType) == nil {
+				t.Errorf("openapi: request %s %s has undocumented content type %q", req.Method, req.URL.Path, mediaType)
+			}
+		}
+		if err := openapi3filter.ValidateRequest(context.Background(), &checked); err != nil {
+			t.Errorf("openapi: request %s %s does not match the document: %v", req.Method, req.URL.Path, err)
+		}
+	}
+
+	// Exported tables and streams are only checked for a documented status and media
+	// type; their rows are not described by the document.
+	resOptions := *options
+	if ct := w.Header().Get("Content-Type"); ct != "" && !isJSON(ct) {
+		resOptions.ExcludeResponseBody = true
+		if res := route.Operation.Responses.Status(w.Code); res != nil && res.Value != nil && res.Value.Content.Get(ct) == nil {
+			t.Errorf("openapi: %d response of %s %s has undocumented content type %q", w.Code, req.Method, req.URL.Path, ct)
+		}
+	}
+	resInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: reqInput,
+		Status:                 w.Code,
+		Header:                 w.Header(),
+		Options:                &resOptions,
+	}
+	resInput.SetBodyBytes(w.Body.Bytes())
+	if err := openapi3filter.ValidateResponse(context.Background(), resInput); err != nil {
+		t.Errorf("openapi: %d response of %s %s does not match the document: %v", w.Code, req.Method, req.URL.Path, err)
+	}
+}
+
+// request copies req into the shape the document describes: the path is
+// rooted at the base path without a trailing slash, and a JSON content type
+// is assumed when a body is sent without one, as the handlers never look at
+// it.
+func (s *Spec) request(req *http.Request, body []byte) *http.Request {
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	if !strings.HasPrefix(path, s.basePath+"/") {
+		path = s.basePath + path
+	}
+
+	r := req.Clone(context.Background())
+	r.URL.Path = path
+	r.URL.RawPath = ""
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) > 0 && r.Header.Get("Content-Type") == "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	return r
+}
+
+// isJSON reports whether the media type ct is a single JSON document.
+func isJSON(ct string) bool {
+	mediaType, _, err := mime.ParseMediaType(ct)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
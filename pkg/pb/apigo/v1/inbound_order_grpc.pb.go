@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: apigo/v1/inbound_order.proto
+
+package apigov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	InboundOrderService_CreateInboundOrder_FullMethodName      = "/apigo.v1.InboundOrderService/CreateInboundOrder"
+	InboundOrderService_ListInboundOrderReports_FullMethodName = "/apigo.v1.InboundOrderService/ListInboundOrderReports"
+)
+
+// InboundOrderServiceClient is the client API for InboundOrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InboundOrderServiceClient interface {
+	CreateInboundOrder(ctx context.Context, in *CreateInboundOrderRequest, opts ...grpc.CallOption) (*InboundOrder, error)
+	// ListInboundOrderReports streams the number of inbound orders of an
+	// employee, or of every employee when employee_id is 0.
+	ListInboundOrderReports(ctx context.Context, in *ListInboundOrderReportsRequest, opts ...grpc.CallOption) (InboundOrderService_ListInboundOrderReportsClient, error)
+}
+
+type inboundOrderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInboundOrderServiceClient(cc grpc.ClientConnInterface) InboundOrderServiceClient {
+	return &inboundOrderServiceClient{cc}
+}
+
+func (c *inboundOrderServiceClient) CreateInboundOrder(ctx context.Context, in *CreateInboundOrderRequest, opts ...grpc.CallOption) (*InboundOrder, error) {
+	out := new(InboundOrder)
+	err := c.cc.Invoke(ctx, InboundOrderService_CreateInboundOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inboundOrderServiceClient) ListInboundOrderReports(ctx context.Context, in *ListInboundOrderReportsRequest, opts ...grpc.CallOption) (InboundOrderService_ListInboundOrderReportsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &InboundOrderService_ServiceDesc.Streams[0], InboundOrderService_ListInboundOrderReports_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &inboundOrderServiceListInboundOrderReportsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type InboundOrderService_ListInboundOrderReportsClient interface {
+	Recv() (*InboundOrderReport, error)
+	grpc.ClientStream
+}
+
+type inboundOrderServiceListInboundOrderReportsClient struct {
+	grpc.ClientStream
+}
+
+func (x *inboundOrderServiceListInboundOrderReportsClient) Recv() (*InboundOrderReport, error) {
+	m := new(InboundOrderReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// InboundOrderServiceServer is the server API for InboundOrderService service.
+// All implementations must embed UnimplementedInboundOrderServiceServer
+// for forward compatibility
+type InboundOrderServiceServer interface {
+	CreateInboundOrder(context.Context, *CreateInboundOrderRequest) (*InboundOrder, error)
+	// ListInboundOrderReports streams the number of inbound orders of an
+	// employee, or of every employee when employee_id is 0.
+	ListInboundOrderReports(*ListInboundOrderReportsRequest, InboundOrderService_ListInboundOrderReportsServer) error
+	mustEmbedUnimplementedInboundOrderServiceServer()
+}
+
+// UnimplementedInboundOrderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedInboundOrderServiceServer struct {
+}
+
+func (UnimplementedInboundOrderServiceServer) CreateInboundOrder(context.Context, *CreateInboundOrderRequest) (*InboundOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInboundOrder not implemented")
+}
+func (UnimplementedInboundOrderServiceServer) ListInboundOrderReports(*ListInboundOrderReportsRequest, InboundOrderService_ListInboundOrderReportsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListInboundOrderReports not implemented")
+}
+func (UnimplementedInboundOrderServiceServer) mustEmbedUnimplementedInboundOrderServiceServer() {}
+
+// UnsafeInboundOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InboundOrderServiceServer will
+// result in compilation errors.
+type UnsafeInboundOrderServiceServer interface {
+	mustEmbedUnimplementedInboundOrderServiceServer()
+}
+
+func RegisterInboundOrderServiceServer(s grpc.ServiceRegistrar, srv InboundOrderServiceServer) {
+	s.RegisterService(&InboundOrderService_ServiceDesc, srv)
+}
+
+func _InboundOrderService_CreateInboundOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInboundOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InboundOrderServiceServer).CreateInboundOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InboundOrderService_CreateInboundOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InboundOrderServiceServer).CreateInboundOrder(ctx, req.(*CreateInboundOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InboundOrderService_ListInboundOrderReports_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListInboundOrderReportsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InboundOrderServiceServer).ListInboundOrderReports(m, &inboundOrderServiceListInboundOrderReportsServer{stream})
+}
+
+type InboundOrderService_ListInboundOrderReportsServer interface {
+	Send(*InboundOrderReport) error
+	grpc.ServerStream
+}
+
+type inboundOrderServiceListInboundOrderReportsServer struct {
+	grpc.ServerStream
+}
+
+func (x *inboundOrderServiceListInboundOrderReportsServer) Send(m *InboundOrderReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// InboundOrderService_ServiceDesc is the grpc.ServiceDesc for InboundOrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InboundOrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apigo.v1.InboundOrderService",
+	HandlerType: (*InboundOrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateInboundOrder",
+			Handler:    _InboundOrderService_CreateInboundOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListInboundOrderReports",
+			Handler:       _InboundOrderService_ListInboundOrderReports_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "apigo/v1/inbound_order.proto",
+}
@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: apigo/v1/product_batch.proto
+
+package apigov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ProductBatchService_ListProductBatches_FullMethodName = "/apigo.v1.ProductBatchService/ListProductBatches"
+	ProductBatchService_CreateProductBatch_FullMethodName = "/apigo.v1.ProductBatchService/CreateProductBatch"
+)
+
+// ProductBatchServiceClient is the client API for ProductBatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProductBatchServiceClient interface {
+	// ListProductBatches streams the batches of the products in product_ids, or
+	// every batch when product_ids is empty.
+	ListProductBatches(ctx context.Context, in *ListProductBatchesRequest, opts ...grpc.CallOption) (ProductBatchService_ListProductBatchesClient, error)
+	CreateProductBatch(ctx context.Context, in *CreateProductBatchRequest, opts ...grpc.CallOption) (*ProductBatch, error)
+}
+
+type productBatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProductBatchServiceClient(cc grpc.ClientConnInterface) ProductBatchServiceClient {
+	return &productBatchServiceClient{cc}
+}
+
+func (c *productBatchServiceClient) ListProductBatches(ctx context.Context, in *ListProductBatchesRequest, opts ...grpc.CallOption) (ProductBatchService_ListProductBatchesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductBatchService_ServiceDesc.Streams[0], ProductBatchService_ListProductBatches_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productBatchServiceListProductBatchesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProductBatchService_ListProductBatchesClient interface {
+	Recv() (*ProductBatch, error)
+	grpc.ClientStream
+}
+
+type productBatchServiceListProductBatchesClient struct {
+	grpc.ClientStream
+}
+
+func (x *productBatchServiceListProductBatchesClient) Recv() (*ProductBatch, error) {
+	m := new(ProductBatch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productBatchServiceClient) CreateProductBatch(ctx context.Context, in *CreateProductBatchRequest, opts ...grpc.CallOption) (*ProductBatch, error) {
+	out := new(ProductBatch)
+	err := c.cc.Invoke(ctx, ProductBatchService_CreateProductBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProductBatchServiceServer is the server API for ProductBatchService service.
+// All implementations must embed UnimplementedProductBatchServiceServer
+// for forward compatibility
+type ProductBatchServiceServer interface {
+	// ListProductBatches streams the batches of the products in product_ids, or
+	// every batch when product_ids is empty.
+	ListProductBatches(*ListProductBatchesRequest, ProductBatchService_ListProductBatchesServer) error
+	CreateProductBatch(context.Context, *CreateProductBatchRequest) (*ProductBatch, error)
+	mustEmbedUnimplementedProductBatchServiceServer()
+}
+
+// UnimplementedProductBatchServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedProductBatchServiceServer struct {
+}
+
+func (UnimplementedProductBatchServiceServer) ListProductBatches(*ListProductBatchesRequest, ProductBatchService_ListProductBatchesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListProductBatches not implemented")
+}
+func (UnimplementedProductBatchServiceServer) CreateProductBatch(context.Context, *CreateProductBatchRequest) (*ProductBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProductBatch not implemented")
+}
+func (UnimplementedProductBatchServiceServer) mustEmbedUnimplementedProductBatchServiceServer() {}
+
+// UnsafeProductBatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProductBatchServiceServer will
+// result in compilation errors.
+type UnsafeProductBatchServiceServer interface {
+	mustEmbedUnimplementedProductBatchServiceServer()
+}
+
+func RegisterProductBatchServiceServer(s grpc.ServiceRegistrar, srv ProductBatchServiceServer) {
+	s.RegisterService(&ProductBatchService_ServiceDesc, srv)
+}
+
+func _ProductBatchService_ListProductBatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListProductBatchesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductBatchServiceServer).ListProductBatches(m, &productBatchServiceListProductBatchesServer{stream})
+}
+
+type ProductBatchService_ListProductBatchesServer interface {
+	Send(*ProductBatch) error
+	grpc.ServerStream
+}
+
+type productBatchServiceListProductBatchesServer struct {
+	grpc.ServerStream
+}
+
+func (x *productBatchServiceListProductBatchesServer) Send(m *ProductBatch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProductBatchService_CreateProductBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductBatchServiceServer).CreateProductBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductBatchService_CreateProductBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductBatchServiceServer).CreateProductBatch(ctx, req.(*CreateProductBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProductBatchService_ServiceDesc is the grpc.ServiceDesc for ProductBatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProductBatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apigo.v1.ProductBatchService",
+	HandlerType: (*ProductBatchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateProductBatch",
+			Handler:    _ProductBatchService_CreateProductBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListProductBatches",
+			Handler:       _ProductBatchService_ListProductBatches_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "apigo/v1/product_batch.proto",
+}
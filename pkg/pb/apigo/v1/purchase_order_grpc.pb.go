@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: apigo/v1/purchase_order.proto
+
+package apigov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PurchaseOrderService_CreatePurchaseOrder_FullMethodName      = "/apigo.v1.PurchaseOrderService/CreatePurchaseOrder"
+	PurchaseOrderService_ListPurchaseOrderReports_FullMethodName = "/apigo.v1.PurchaseOrderService/ListPurchaseOrderReports"
+)
+
+// PurchaseOrderServiceClient is the client API for PurchaseOrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PurchaseOrderServiceClient interface {
+	CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error)
+	// ListPurchaseOrderReports streams the number of purchase orders of a
+	// buyer, or of every buyer when buyer_id is 0.
+	ListPurchaseOrderReports(ctx context.Context, in *ListPurchaseOrderReportsRequest, opts ...grpc.CallOption) (PurchaseOrderService_ListPurchaseOrderReportsClient, error)
+}
+
+type purchaseOrderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPurchaseOrderServiceClient(cc grpc.ClientConnInterface) PurchaseOrderServiceClient {
+	return &purchaseOrderServiceClient{cc}
+}
+
+func (c *purchaseOrderServiceClient) CreatePurchaseOrder(ctx context.Context, in *CreatePurchaseOrderRequest, opts ...grpc.CallOption) (*PurchaseOrder, error) {
+	out := new(PurchaseOrder)
+	err := c.cc.Invoke(ctx, PurchaseOrderService_CreatePurchaseOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purchaseOrderServiceClient) ListPurchaseOrderReports(ctx context.Context, in *ListPurchaseOrderReportsRequest, opts ...grpc.CallOption) (PurchaseOrderService_ListPurchaseOrderReportsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PurchaseOrderService_ServiceDesc.Streams[0], PurchaseOrderService_ListPurchaseOrderReports_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &purchaseOrderServiceListPurchaseOrderReportsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PurchaseOrderService_ListPurchaseOrderReportsClient interface {
+	Recv() (*PurchaseOrderReport, error)
+	grpc.ClientStream
+}
+
+type purchaseOrderServiceListPurchaseOrderReportsClient struct {
+	grpc.ClientStream
+}
+
+func (x *purchaseOrderServiceListPurchaseOrderReportsClient) Recv() (*PurchaseOrderReport, error) {
+	m := new(PurchaseOrderReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PurchaseOrderServiceServer is the server API for PurchaseOrderService service.
+// All implementations must embed UnimplementedPurchaseOrderServiceServer
+// for forward compatibility
+type PurchaseOrderServiceServer interface {
+	CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*PurchaseOrder, error)
+	// ListPurchaseOrderReports streams the number of purchase orders of a
+	// buyer, or of every buyer when buyer_id is 0.
+	ListPurchaseOrderReports(*ListPurchaseOrderReportsRequest, PurchaseOrderService_ListPurchaseOrderReportsServer) error
+	mustEmbedUnimplementedPurchaseOrderServiceServer()
+}
+
+// UnimplementedPurchaseOrderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPurchaseOrderServiceServer struct {
+}
+
+func (UnimplementedPurchaseOrderServiceServer) CreatePurchaseOrder(context.Context, *CreatePurchaseOrderRequest) (*PurchaseOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePurchaseOrder not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) ListPurchaseOrderReports(*ListPurchaseOrderReportsRequest, PurchaseOrderService_ListPurchaseOrderReportsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListPurchaseOrderReports not implemented")
+}
+func (UnimplementedPurchaseOrderServiceServer) mustEmbedUnimplementedPurchaseOrderServiceServer() {}
+
+// UnsafePurchaseOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PurchaseOrderServiceServer will
+// result in compilation errors.
+type UnsafePurchaseOrderServiceServer interface {
+	mustEmbedUnimplementedPurchaseOrderServiceServer()
+}
+
+func RegisterPurchaseOrderServiceServer(s grpc.ServiceRegistrar, srv PurchaseOrderServiceServer) {
+	s.RegisterService(&PurchaseOrderService_ServiceDesc, srv)
+}
+
+func _PurchaseOrderService_CreatePurchaseOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePurchaseOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurchaseOrderServiceServer).CreatePurchaseOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurchaseOrderService_CreatePurchaseOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurchaseOrderServiceServer).CreatePurchaseOrder(ctx, req.(*CreatePurchaseOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurchaseOrderService_ListPurchaseOrderReports_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPurchaseOrderReportsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PurchaseOrderServiceServer).ListPurchaseOrderReports(m, &purchaseOrderServiceListPurchaseOrderReportsServer{stream})
+}
+
+type PurchaseOrderService_ListPurchaseOrderReportsServer interface {
+	Send(*PurchaseOrderReport) error
+	grpc.ServerStream
+}
+
+type purchaseOrderServiceListPurchaseOrderReportsServer struct {
+	grpc.ServerStream
+}
+
+func (x *purchaseOrderServiceListPurchaseOrderReportsServer) Send(m *PurchaseOrderReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PurchaseOrderService_ServiceDesc is the grpc.ServiceDesc for PurchaseOrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PurchaseOrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apigo.v1.PurchaseOrderService",
+	HandlerType: (*PurchaseOrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePurchaseOrder",
+			Handler:    _PurchaseOrderService_CreatePurchaseOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListPurchaseOrderReports",
+			Handler:       _PurchaseOrderService_ListPurchaseOrderReports_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "apigo/v1/purchase_order.proto",
+}
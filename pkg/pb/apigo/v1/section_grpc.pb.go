@@ -0,0 +1,360 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: apigo/v1/section.proto
+
+package apigov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SectionService_GetSection_FullMethodName                = "/apigo.v1.SectionService/GetSection"
+	SectionService_ListSections_FullMethodName              = "/apigo.v1.SectionService/ListSections"
+	SectionService_CreateSection_FullMethodName             = "/apigo.v1.SectionService/CreateSection"
+	SectionService_UpdateSection_FullMethodName             = "/apigo.v1.SectionService/UpdateSection"
+	SectionService_DeleteSection_FullMethodName             = "/apigo.v1.SectionService/DeleteSection"
+	SectionService_ListSectionProductReports_FullMethodName = "/apigo.v1.SectionService/ListSectionProductReports"
+)
+
+// SectionServiceClient is the client API for SectionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SectionServiceClient interface {
+	GetSection(ctx context.Context, in *GetSectionRequest, opts ...grpc.CallOption) (*Section, error)
+	// ListSections streams every section.
+	ListSections(ctx context.Context, in *ListSectionsRequest, opts ...grpc.CallOption) (SectionService_ListSectionsClient, error)
+	CreateSection(ctx context.Context, in *CreateSectionRequest, opts ...grpc.CallOption) (*Section, error)
+	// UpdateSection changes the fields of update_mask, or every field set in
+	// section when the mask is empty.
+	UpdateSection(ctx context.Context, in *UpdateSectionRequest, opts ...grpc.CallOption) (*Section, error)
+	DeleteSection(ctx context.Context, in *DeleteSectionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListSectionProductReports streams the number of products stored in a
+	// section, or in every section when section_id is 0.
+	ListSectionProductReports(ctx context.Context, in *ListSectionProductReportsRequest, opts ...grpc.CallOption) (SectionService_ListSectionProductReportsClient, error)
+}
+
+type sectionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSectionServiceClient(cc grpc.ClientConnInterface) SectionServiceClient {
+	return &sectionServiceClient{cc}
+}
+
+func (c *sectionServiceClient) GetSection(ctx context.Context, in *GetSectionRequest, opts ...grpc.CallOption) (*Section, error) {
+	out := new(Section)
+	err := c.cc.Invoke(ctx, SectionService_GetSection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) ListSections(ctx context.Context, in *ListSectionsRequest, opts ...grpc.CallOption) (SectionService_ListSectionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SectionService_ServiceDesc.Streams[0], SectionService_ListSections_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sectionServiceListSectionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SectionService_ListSectionsClient interface {
+	Recv() (*Section, error)
+	grpc.ClientStream
+}
+
+type sectionServiceListSectionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sectionServiceListSectionsClient) Recv() (*Section, error) {
+	m := new(Section)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sectionServiceClient) CreateSection(ctx context.Context, in *CreateSectionRequest, opts ...grpc.CallOption) (*Section, error) {
+	out := new(Section)
+	err := c.cc.Invoke(ctx, SectionService_CreateSection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) UpdateSection(ctx context.Context, in *UpdateSectionRequest, opts ...grpc.CallOption) (*Section, error) {
+	out := new(Section)
+	err := c.cc.Invoke(ctx, SectionService_UpdateSection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) DeleteSection(ctx context.Context, in *DeleteSectionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SectionService_DeleteSection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sectionServiceClient) ListSectionProductReports(ctx context.Context, in *ListSectionProductReportsRequest, opts ...grpc.CallOption) (SectionService_ListSectionProductReportsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SectionService_ServiceDesc.Streams[1], SectionService_ListSectionProductReports_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sectionServiceListSectionProductReportsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SectionService_ListSectionProductReportsClient interface {
+	Recv() (*SectionProductReport, error)
+	grpc.ClientStream
+}
+
+type sectionServiceListSectionProductReportsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sectionServiceListSectionProductReportsClient) Recv() (*SectionProductReport, error) {
+	m := new(SectionProductReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SectionServiceServer is the server API for SectionService service.
+// All implementations must embed UnimplementedSectionServiceServer
+// for forward compatibility
+type SectionServiceServer interface {
+	GetSection(context.Context, *GetSectionRequest) (*Section, error)
+	// ListSections streams every section.
+	ListSections(*ListSectionsRequest, SectionService_ListSectionsServer) error
+	CreateSection(context.Context, *CreateSectionRequest) (*Section, error)
+	// UpdateSection changes the fields of update_mask, or every field set in
+	// section when the mask is empty.
+	UpdateSection(context.Context, *UpdateSectionRequest) (*Section, error)
+	DeleteSection(context.Context, *DeleteSectionRequest) (*emptypb.Empty, error)
+	// ListSectionProductReports streams the number of products stored in a
+	// section, or in every section when section_id is 0.
+	ListSectionProductReports(*ListSectionProductReportsRequest, SectionService_ListSectionProductReportsServer) error
+	mustEmbedUnimplementedSectionServiceServer()
+}
+
+// UnimplementedSectionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSectionServiceServer struct {
+}
+
+func (UnimplementedSectionServiceServer) GetSection(context.Context, *GetSectionRequest) (*Section, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSection not implemented")
+}
+func (UnimplementedSectionServiceServer) ListSections(*ListSectionsRequest, SectionService_ListSectionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListSections not implemented")
+}
+func (UnimplementedSectionServiceServer) CreateSection(context.Context, *CreateSectionRequest) (*Section, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSection not implemented")
+}
+func (UnimplementedSectionServiceServer) UpdateSection(context.Context, *UpdateSectionRequest) (*Section, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSection not implemented")
+}
+func (UnimplementedSectionServiceServer) DeleteSection(context.Context, *DeleteSectionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSection not implemented")
+}
+func (UnimplementedSectionServiceServer) ListSectionProductReports(*ListSectionProductReportsRequest, SectionService_ListSectionProductReportsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListSectionProductReports not implemented")
+}
+func (UnimplementedSectionServiceServer) mustEmbedUnimplementedSectionServiceServer() {}
+
+// UnsafeSectionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SectionServiceServer will
+// result in compilation errors.
+type UnsafeSectionServiceServer interface {
+	mustEmbedUnimplementedSectionServiceServer()
+}
+
+func RegisterSectionServiceServer(s grpc.ServiceRegistrar, srv SectionServiceServer) {
+	s.RegisterService(&SectionService_ServiceDesc, srv)
+}
+
+func _SectionService_GetSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).GetSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_GetSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).GetSection(ctx, req.(*GetSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_ListSections_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSectionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SectionServiceServer).ListSections(m, &sectionServiceListSectionsServer{stream})
+}
+
+type SectionService_ListSectionsServer interface {
+	Send(*Section) error
+	grpc.ServerStream
+}
+
+type sectionServiceListSectionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sectionServiceListSectionsServer) Send(m *Section) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SectionService_CreateSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).CreateSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_CreateSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).CreateSection(ctx, req.(*CreateSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_UpdateSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).UpdateSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_UpdateSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).UpdateSection(ctx, req.(*UpdateSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_DeleteSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SectionServiceServer).DeleteSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SectionService_DeleteSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SectionServiceServer).DeleteSection(ctx, req.(*DeleteSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SectionService_ListSectionProductReports_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSectionProductReportsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SectionServiceServer).ListSectionProductReports(m, &sectionServiceListSectionProductReportsServer{stream})
+}
+
+type SectionService_ListSectionProductReportsServer interface {
+	Send(*SectionProductReport) error
+	grpc.ServerStream
+}
+
+type sectionServiceListSectionProductReportsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sectionServiceListSectionProductReportsServer) Send(m *SectionProductReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// SectionService_ServiceDesc is the grpc.ServiceDesc for SectionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SectionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apigo.v1.SectionService",
+	HandlerType: (*SectionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSection",
+			Handler:    _SectionService_GetSection_Handler,
+		},
+		{
+			MethodName: "CreateSection",
+			Handler:    _SectionService_CreateSection_Handler,
+		},
+		{
+			MethodName: "UpdateSection",
+			Handler:    _SectionService_UpdateSection_Handler,
+		},
+		{
+			MethodName: "DeleteSection",
+			Handler:    _SectionService_DeleteSection_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSections",
+			Handler:       _SectionService_ListSections_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListSectionProductReports",
+			Handler:       _SectionService_ListSectionProductReports_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "apigo/v1/section.proto",
+}
@@ -0,0 +1,220 @@
+// Package redistest starts an embedded server speaking the Redis protocol
+// for the string commands a cache needs (GET, SET with an expiry, DEL and
+// PING), so the code using Redis can be tested with the real client and
+// without an external server. Keys live in memory, in a single database.
+package redistest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Server is an embedded Redis server.
+type Server struct {
+	ln net.Listener
+
+	mu    sync.Mutex
+	data  map[string]value
+	conns map[net.Conn]struct{}
+}
+
+type value struct {
+	data []byte
+	// expiresAt is zero for a key without expiry.
+	expiresAt time.Time
+}
+
+// Start starts a server listening on a random local port. It is shut down
+// when the test finishes.
+func Start(t testing.TB) *Server {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("redistest: listening: %v", err)
+	}
+	s := &Server{ln: ln, data: make(map[string]value), conns: make(map[net.Conn]struct{})}
+	go s.accept()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Addr is the host:port clients connect to.
+func (s *Server) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// URL is the redis:// URL clients connect to.
+func (s *Server) URL() string {
+	return "redis://" + s.Addr()
+}
+
+// Keys returns the keys stored and not expired.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for k, v := range s.data {
+		if v.live() {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Close stops the server and closes the connections of its clients.
+func (s *Server) Close() {
+	_ = s.ln.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		_ = c.Close()
+	}
+}
+
+func (s *Server) accept() {
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+		go s.serve(c)
+	}
+}
+
+func (s *Server) serve(c net.Conn) {
+	defer func() {
+		_ = c.Close()
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+	}()
+
+	r := bufio.NewReader(c)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(c, s.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+// exec runs a command and returns its encoded reply.
+func (s *Server) exec(args []string) string {
+	if len(args) == 0 {
+		return "-ERR empty command\r\n"
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		if len(args) != 2 {
+			return "-ERR wrong number of arguments for 'get' command\r\n"
+		}
+		v, ok := s.data[args[1]]
+		if !ok || !v.live() {
+			return "$-1\r\n"
+		}
+		return "$" + strconv.Itoa(len(v.data)) + "\r\n" + string(v.data) + "\r\n"
+	case "SET":
+		return s.set(args[1:])
+	case "DEL":
+		deleted := 0
+		for _, k := range args[1:] {
+			if v, ok := s.data[k]; ok && v.live() {
+				deleted++
+			}
+			delete(s.data, k)
+		}
+		return ":" + strconv.Itoa(deleted) + "\r\n"
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+// set handles SET key value [EX seconds | PX milliseconds].
+func (s *Server) set(args []string) string {
+	if len(args) != 2 && len(args) != 4 {
+		return "-ERR syntax error\r\n"
+	}
+	v := value{data: []byte(args[1])}
+	if len(args) == 4 {
+		n, err := strconv.Atoi(args[3])
+		if err != nil || n <= 0 {
+			return "-ERR invalid expire time in 'set' command\r\n"
+		}
+		switch strings.ToUpper(args[2]) {
+		case "EX":
+			v.expiresAt = time.Now().Add(time.Duration(n) * time.Second)
+		case "PX":
+			v.expiresAt = time.Now().Add(time.Duration(n) * time.Millisecond)
+		default:
+			return "-ERR syntax error\r\n"
+		}
+	}
+	s.data[args[0]] = v
+	return "+OK\r\n"
+}
+
+func (v value) live() bool {
+	return v.expiresAt.IsZero() || time.Now().Before(v.expiresAt)
+}
+
+// readCommand reads a command sent as an array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("redistest: unexpected %q", line)
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "$") {
+			return nil, fmt.Errorf("redistest: unexpected %q", line)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
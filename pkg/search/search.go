@@ -0,0 +1,207 @@
+// Package search is an in-memory full-text index of small collections, such
+// as the products of the catalog. Its documents are made of weighted text
+// fields, its queries match the words of the documents exactly, by prefix or
+// with typos, and the documents found are ranked by relevance (tf-idf).
+//
+// An Index is immutable and safe for concurrent searches: a changed
+// collection is indexed again with Build.
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// How much a word of a document found by each kind of match counts, relative
+// to an exact match.
+const (
+	exactBoost  = 1.0
+	prefixBoost = 0.8
+	typoBoost   = 0.5
+)
+
+// Document is a document of an index.
+type Document struct {
+	ID int
+	// Fields are the texts of the document, weighted by the weights given to
+	// Build in the same order.
+	Fields []string
+}
+
+// Hit is a document found by a search.
+type Hit struct {
+	ID    int
+	Score float64
+}
+
+// Index is the inverted index of a collection of documents.
+type Index struct {
+	// postings maps every word to the documents where it occurs, with its
+	// weighted frequency in each.
+	postings map[string]map[int]float64
+	// words are the words of postings, sorted for the prefix matches.
+	words []string
+	size  int
+}
+
+// Build indexes docs, whose fields weigh weights; a field without a weight
+// weighs 1.
+func Build(docs []Document, weights ...float64) *Index {
+	ix := &Index{postings: make(map[string]map[int]float64), size: len(docs)}
+	for _, d := range docs {
+		for i, field := range d.Fields {
+			weight := 1.0
+			if i < len(weights) {
+				weight = weights[i]
+			}
+			for _, w := range Tokenize(field) {
+				if ix.postings[w] == nil {
+					ix.postings[w] = make(map[int]float64)
+				}
+				ix.postings[w][d.ID] += weight
+			}
+		}
+	}
+	for w := range ix.postings {
+		ix.words = append(ix.words, w)
+	}
+	sort.Strings(ix.words)
+	return ix
+}
+
+// Tokenize splits s into its lower case words: the runs of letters and
+// digits.
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Search returns the documents matching every word of query that accept
+// accepts, the most relevant first and by id when equally relevant. accept
+// may be nil to accept every document.
+//
+// A word of the query matches the words of a document equal to it, starting
+// with it, or differing from it by at most one typo (an edit) for words of
+// four to seven letters and two for longer ones.
+func (ix *Index) Search(query string, accept func(id int) bool) []Hit {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var scores map[int]float64
+	for _, term := range terms {
+		termScores := ix.match(term)
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for id, s := range scores {
+			if ts, ok := termScores[id]; ok {
+				scores[id] = s + ts
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, s := range scores {
+		if accept == nil || accept(id) {
+			hits = append(hits, Hit{ID: id, Score: math.Round(s*1000) / 1000})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	return hits
+}
+
+// match returns the score of every document matching term: the best of its
+// words matching it, weighted by how rare the word is.
+func (ix *Index) match(term string) map[int]float64 {
+	scores := make(map[int]float64)
+	add := func(word string, boost float64) {
+		docs := ix.postings[word]
+		idf := math.Log(1 + float64(ix.size)/float64(len(docs)))
+		for id, freq := range docs {
+			if s := boost * idf * (1 + math.Log(freq)); s > scores[id] {
+				scores[id] = s
+			}
+		}
+	}
+
+	// The words starting with term, term itself first if present.
+	for i := sort.SearchStrings(ix.words, term); i < len(ix.words) && strings.HasPrefix(ix.words[i], term); i++ {
+		if ix.words[i] == term {
+			add(term, exactBoost)
+		} else {
+			add(ix.words[i], prefixBoost)
+		}
+	}
+
+	if edits := maxEdits(term); edits > 0 {
+		for _, w := range ix.words {
+			if strings.HasPrefix(w, term) || abs(len(w)-len(term)) > edits {
+				continue
+			}
+			if d := distance(term, w, edits); d <= edits {
+				add(w, typoBoost/float64(d))
+			}
+		}
+	}
+	return scores
+}
+
+// maxEdits is how many typos a query word may have.
+func maxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// distance returns the Levenshtein distance between a and b, or max+1 once
+// it is known to exceed max.
+func distance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			best = min(best, cur[j])
+		}
+		if best > max {
+			return max + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,90 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ids(hits []Hit) []int {
+	ids := []int{}
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	return ids
+}
+
+func TestIndex_Search(t *testing.T) {
+	ix := Build([]Document{
+		{ID: 1, Fields: []string{"Greek yogurt", "YOG-001"}},
+		{ID: 2, Fields: []string{"Strawberry yogurt drink", "YOG-002"}},
+		{ID: 3, Fields: []string{"Frozen strawberries", "FRZ-010"}},
+		{ID: 4, Fields: []string{"Yogurt yogurt yogurt", "MIX-004"}},
+	}, 1, 2)
+
+	t.Run("it should find the documents with every word", func(t *testing.T) {
+		// Act
+		hits := ix.Search("strawberry yogurt", nil)
+
+		// Assert
+		assert.Equal(t, []int{2}, ids(hits))
+	})
+
+	t.Run("it should find the words starting with a prefix", func(t *testing.T) {
+		// Act
+		hits := ix.Search("straw", nil)
+
+		// Assert
+		assert.ElementsMatch(t, []int{2, 3}, ids(hits))
+	})
+
+	t.Run("it should tolerate a typo", func(t *testing.T) {
+		// Act
+		hits := ix.Search("yoghurt", nil)
+
+		// Assert
+		assert.ElementsMatch(t, []int{1, 2, 4}, ids(hits))
+	})
+
+	t.Run("it should not tolerate typos in short words", func(t *testing.T) {
+		// Act
+		hits := ix.Search("frx", nil)
+
+		// Assert
+		assert.Empty(t, hits)
+	})
+
+	t.Run("it should rank exact matches, weighted fields and frequent words first", func(t *testing.T) {
+		// Act
+		byCode := ix.Search("yog", nil)
+		byWord := ix.Search("yogurt", nil)
+
+		// Assert
+		assert.Equal(t, []int{1, 2, 4}, ids(byCode))
+		assert.Equal(t, 4, byWord[0].ID)
+		assert.Greater(t, byWord[0].Score, byWord[1].Score)
+	})
+
+	t.Run("it should only return the accepted documents", func(t *testing.T) {
+		// Act
+		hits := ix.Search("yogurt", func(id int) bool { return id == 2 })
+
+		// Assert
+		assert.Equal(t, []int{2}, ids(hits))
+	})
+
+	t.Run("it should find nothing for a query without words", func(t *testing.T) {
+		// Act
+		hits := ix.Search(" - ", nil)
+
+		// Assert
+		assert.Empty(t, hits)
+	})
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("yogurt", "yogurt", 2))
+	assert.Equal(t, 1, distance("yoghurt", "yogurt", 2))
+	assert.Equal(t, 2, distance("yogurt", "yoguurtt", 2))
+	assert.Equal(t, 3, distance("frozen", "yogurt", 2))
+}
@@ -0,0 +1,83 @@
+// Package softdelete lets repositories hide deleted rows instead of removing
+// them. A row is deleted by setting its deleted_at column: reads skip it
+// unless their context includes deleted rows, it can be restored by clearing
+// the column, and it is purged for good once it has been deleted for longer
+// than the retention period.
+package softdelete
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DefaultRetention is how long a deleted row is kept before it can be purged.
+const DefaultRetention = 30 * 24 * time.Hour
+
+// datetimeLayout is how deleted_at is written to, and read from, its
+// DATETIME(6) column.
+const datetimeLayout = "2006-01-02 15:04:05.999999"
+
+type contextKey struct{}
+
+// WithDeleted returns a copy of ctx whose reads include the deleted rows.
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, true)
+}
+
+// Included reports whether the reads of ctx include the deleted rows.
+func Included(ctx context.Context) bool {
+	included, _ := ctx.Value(contextKey{}).(bool)
+	return included
+}
+
+// Visible returns the SQL condition on the deleted_at column that selects the
+// rows the reads of ctx see. column may be qualified, e.g. "s.deleted_at".
+func Visible(ctx context.Context, column string) string {
+	if Included(ctx) {
+		return "TRUE"
+	}
+	return column + " IS NULL"
+}
+
+// Format returns t as a value of a deleted_at column.
+func Format(t time.Time) string {
+	return t.UTC().Format(datetimeLayout)
+}
+
+// Scan returns a scanner storing a deleted_at column in dst: nil for a row
+// that is not deleted, the time it was deleted otherwise.
+func Scan(dst **time.Time) sql.Scanner {
+	return scanner{dst: dst}
+}
+
+type scanner struct {
+	dst **time.Time
+}
+
+func (s scanner) Scan(src interface{}) error {
+	var raw string
+	switch v := src.(type) {
+	case nil:
+		*s.dst = nil
+		return nil
+	case time.Time:
+		t := v.UTC()
+		*s.dst = &t
+		return nil
+	case []byte:
+		raw = string(v)
+	case string:
+		raw = v
+	default:
+		return fmt.Errorf("softdelete: cannot scan %T into deleted_at", src)
+	}
+
+	t, err := time.Parse(datetimeLayout, raw)
+	if err != nil {
+		return fmt.Errorf("softdelete: %w", err)
+	}
+	*s.dst = &t
+	return nil
+}
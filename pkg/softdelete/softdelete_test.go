@@ -0,0 +1,49 @@
+package softdelete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisible(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, "s.deleted_at IS NULL", Visible(ctx, "s.deleted_at"))
+	assert.Equal(t, "TRUE", Visible(WithDeleted(ctx), "s.deleted_at"))
+}
+
+func TestScan(t *testing.T) {
+	deletedAt := time.Date(2026, time.October, 18, 15, 4, 5, 123456000, time.UTC)
+
+	t.Run("it should read a deleted row as the time it was deleted", func(t *testing.T) {
+		for _, src := range []interface{}{[]byte(Format(deletedAt)), Format(deletedAt), deletedAt} {
+			var obtained *time.Time
+
+			err := Scan(&obtained).Scan(src)
+
+			require.NoError(t, err)
+			require.NotNil(t, obtained)
+			assert.True(t, deletedAt.Equal(*obtained), "%v", src)
+		}
+	})
+
+	t.Run("it should read a row that is not deleted as nil", func(t *testing.T) {
+		obtained := &deletedAt
+
+		err := Scan(&obtained).Scan(nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, obtained)
+	})
+
+	t.Run("it should return an error for a value that is not a time", func(t *testing.T) {
+		var obtained *time.Time
+
+		assert.Error(t, Scan(&obtained).Scan("yesterday"))
+		assert.Error(t, Scan(&obtained).Scan(42))
+	})
+}
@@ -0,0 +1,15 @@
+// Package sqlin builds the placeholder list of an SQL IN clause.
+package sqlin
+
+import "strings"
+
+// Ints returns "(?, ?, ...)" with one placeholder per id and the ids as query
+// arguments. Callers must not run the query when ids is empty, since "IN ()"
+// is not valid SQL.
+func Ints(ids []int) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + ")", args
+}
@@ -0,0 +1,14 @@
+package sqlin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInts(t *testing.T) {
+	placeholders, args := Ints([]int{4, 8, 15})
+
+	assert.Equal(t, "(?, ?, ?)", placeholders)
+	assert.Equal(t, []interface{}{4, 8, 15}, args)
+}
@@ -0,0 +1,135 @@
+// Package table lays out rows of structs as tables, the way the lists and
+// reports are exported as CSV or XLSX by the API and printed by apigoctl.
+//
+// Every exported field is a column named by its json tag, in the order the
+// fields are declared, and the fields of embedded structs are inlined.
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Table is a table of rows.
+type Table struct {
+	rows    reflect.Value
+	columns []column
+}
+
+// New returns the table of rows, a slice of structs or a single one. Rows
+// that are not structs are a single value column.
+func New(rows interface{}) *Table {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		v = reflect.Append(reflect.MakeSlice(reflect.SliceOf(v.Type()), 0, 1), v)
+	}
+	return &Table{rows: v, columns: columnsOf(v.Type().Elem())}
+}
+
+// Header returns the names of the columns.
+func (t *Table) Header() []string {
+	header := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		header[i] = col.name
+	}
+	return header
+}
+
+// Len returns the number of rows.
+func (t *Table) Len() int {
+	return t.rows.Len()
+}
+
+// Row returns the values of the i-th row, one per column. Missing values,
+// behind nil pointers, are nil and times are RFC 3339 strings.
+func (t *Table) Row(i int) []interface{} {
+	return cells(indirect(t.rows.Index(i)), t.columns)
+}
+
+// Cell formats a value of a row as text. Floats never use exponents and nil
+// is empty.
+func Cell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// column is a field of the rows of a table.
+type column struct {
+	name  string
+	index []int
+}
+
+// columnsOf returns the columns of the rows of type t.
+func columnsOf(t reflect.Type) []column {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return []column{{name: "value"}}
+	}
+
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-" || !f.IsExported() && !f.Anonymous:
+			continue
+		case f.Anonymous && name == "":
+			for _, embedded := range columnsOf(f.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				columns = append(columns, embedded)
+			}
+			continue
+		case name == "":
+			name = f.Name
+		}
+		columns = append(columns, column{name: name, index: []int{i}})
+	}
+	return columns
+}
+
+// cells returns the values of the columns of row.
+func cells(row reflect.Value, columns []column) []interface{} {
+	values := make([]interface{}, len(columns))
+	for i, col := range columns {
+		if col.index == nil {
+			values[i] = row.Interface()
+			continue
+		}
+		v, err := row.FieldByIndexErr(col.index)
+		for err == nil && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				break
+			}
+			v = v.Elem()
+		}
+		if err != nil || v.Kind() == reflect.Pointer {
+			continue
+		}
+		if t, ok := v.Interface().(time.Time); ok {
+			values[i] = t.Format(time.RFC3339)
+			continue
+		}
+		values[i] = v.Interface()
+	}
+	return values
+}
+
+// indirect follows the pointers to a row.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
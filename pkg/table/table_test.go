@@ -0,0 +1,58 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type audited struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type row struct {
+	ID      int        `json:"id"`
+	Name    string     `json:"name,omitempty"`
+	Weight  float32    `json:"weight"`
+	Deleted *time.Time `json:"deleted_at"`
+	Secret  string     `json:"-"`
+	audited
+}
+
+func TestTable(t *testing.T) {
+	deleted := time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+
+	t.Run("it should lay out the fields of the rows as columns", func(t *testing.T) {
+		// Act
+		tb := New([]row{
+			{ID: 1, Name: "Yogurt", Weight: 0.25, audited: audited{CreatedBy: "ops"}},
+			{ID: 2, Deleted: &deleted},
+		})
+
+		// Assert
+		assert.Equal(t, []string{"id", "name", "weight", "deleted_at", "created_by"}, tb.Header())
+		assert.Equal(t, 2, tb.Len())
+		assert.Equal(t, []interface{}{1, "Yogurt", float32(0.25), nil, "ops"}, tb.Row(0))
+		assert.Equal(t, []interface{}{2, "", float32(0), "2026-10-18T15:04:05Z", ""}, tb.Row(1))
+	})
+
+	t.Run("it should lay out a single row or values", func(t *testing.T) {
+		// Act
+		single := New(&row{ID: 3})
+		values := New([]int{4, 5})
+
+		// Assert
+		assert.Equal(t, 1, single.Len())
+		assert.Equal(t, 3, single.Row(0)[0])
+		assert.Equal(t, []string{"value"}, values.Header())
+		assert.Equal(t, []interface{}{5}, values.Row(1))
+	})
+}
+
+func TestCell(t *testing.T) {
+	assert.Equal(t, "", Cell(nil))
+	assert.Equal(t, "0.1", Cell(float32(0.1)))
+	assert.Equal(t, "1000000", Cell(1e6))
+	assert.Equal(t, "YOG-001", Cell("YOG-001"))
+}
@@ -0,0 +1,156 @@
+// Package units converts the dimensions, weights and volumes of the products
+// between the units of measure the API accepts. The products are stored in
+// centimetres, kilograms and cubic metres.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUnknownUnit is returned for a unit or a system of units that is not
+// supported.
+var ErrUnknownUnit = errors.New("units: unknown unit")
+
+// LengthUnit is a unit of length.
+type LengthUnit string
+
+const (
+	Centimetre LengthUnit = "cm"
+	Metre      LengthUnit = "m"
+	Inch       LengthUnit = "in"
+)
+
+// MassUnit is a unit of mass.
+type MassUnit string
+
+const (
+	Gram     MassUnit = "g"
+	Kilogram MassUnit = "kg"
+	Pound    MassUnit = "lb"
+)
+
+// VolumeUnit is a unit of volume.
+type VolumeUnit string
+
+const (
+	CubicMetre VolumeUnit = "m3"
+	CubicFoot  VolumeUnit = "ft3"
+)
+
+// The exact size of every unit in the stored one.
+var (
+	centimetres = map[LengthUnit]float64{Centimetre: 1, Metre: 100, Inch: 2.54}
+	kilograms   = map[MassUnit]float64{Gram: 0.001, Kilogram: 1, Pound: 0.45359237}
+	cubicMetres = map[VolumeUnit]float64{CubicMetre: 1, CubicFoot: 0.028316846592}
+)
+
+// ParseLengthUnit parses cm, m or in.
+func ParseLengthUnit(s string) (LengthUnit, error) {
+	if _, ok := centimetres[LengthUnit(s)]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownUnit, s)
+	}
+	return LengthUnit(s), nil
+}
+
+// ParseMassUnit parses g, kg or lb.
+func ParseMassUnit(s string) (MassUnit, error) {
+	if _, ok := kilograms[MassUnit(s)]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownUnit, s)
+	}
+	return MassUnit(s), nil
+}
+
+// ToCentimetres converts v from u to centimetres. It panics if u is unknown.
+func (u LengthUnit) ToCentimetres(v float64) float64 {
+	return v * factor(centimetres, u)
+}
+
+// FromCentimetres converts v from centimetres to u. It panics if u is
+// unknown.
+func (u LengthUnit) FromCentimetres(v float64) float64 {
+	return v / factor(centimetres, u)
+}
+
+// ToKilograms converts v from u to kilograms. It panics if u is unknown.
+func (u MassUnit) ToKilograms(v float64) float64 {
+	return v * factor(kilograms, u)
+}
+
+// FromKilograms converts v from kilograms to u. It panics if u is unknown.
+func (u MassUnit) FromKilograms(v float64) float64 {
+	return v / factor(kilograms, u)
+}
+
+// FromCubicMetres converts v from cubic metres to u. It panics if u is
+// unknown.
+func (u VolumeUnit) FromCubicMetres(v float64) float64 {
+	return v / factor(cubicMetres, u)
+}
+
+// Volume returns the volume in cubic metres of a box whose sides are in
+// centimetres.
+func Volume(height, length, width float64) float64 {
+	return height * length * width / 1e6
+}
+
+// Round rounds v to the given number of decimal digits, so that conversions
+// do not leak float noise such as 24.999999 into the responses.
+func Round(v float64, digits int) float64 {
+	p := math.Pow10(digits)
+	return math.Round(v*p) / p
+}
+
+func factor[U comparable](sizes map[U]float64, u U) float64 {
+	f, ok := sizes[u]
+	if !ok {
+		panic(fmt.Sprintf("units: unknown unit %v", u))
+	}
+	return f
+}
+
+// System is a system of units the products are written in.
+type System string
+
+const (
+	// Metric writes the products in centimetres, kilograms and cubic metres.
+	Metric System = "metric"
+	// Imperial writes them in inches, pounds and cubic feet.
+	Imperial System = "imperial"
+)
+
+// ParseSystem parses metric or imperial. The empty string is Metric.
+func ParseSystem(s string) (System, error) {
+	switch System(s) {
+	case "", Metric:
+		return Metric, nil
+	case Imperial:
+		return Imperial, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownUnit, s)
+}
+
+// Length returns the unit of the dimensions in s.
+func (s System) Length() LengthUnit {
+	if s == Imperial {
+		return Inch
+	}
+	return Centimetre
+}
+
+// Mass returns the unit of the weights in s.
+func (s System) Mass() MassUnit {
+	if s == Imperial {
+		return Pound
+	}
+	return Kilogram
+}
+
+// Volume returns the unit of the volumes in s.
+func (s System) Volume() VolumeUnit {
+	if s == Imperial {
+		return CubicFoot
+	}
+	return CubicMetre
+}
@@ -0,0 +1,48 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversions(t *testing.T) {
+	t.Run("it should convert lengths and masses both ways", func(t *testing.T) {
+		assert.Equal(t, 25.4, Inch.ToCentimetres(10))
+		assert.Equal(t, 150.0, Metre.ToCentimetres(1.5))
+		assert.Equal(t, 10.0, Round(Inch.FromCentimetres(25.4), 4))
+		assert.Equal(t, 0.25, Gram.ToKilograms(250))
+		assert.Equal(t, 2.2046, Round(Pound.FromKilograms(1), 4))
+		assert.Equal(t, 1.0, Round(Pound.ToKilograms(Pound.FromKilograms(1)), 6))
+	})
+
+	t.Run("it should compute volumes in cubic metres", func(t *testing.T) {
+		assert.Equal(t, 0.0025, Volume(25, 10, 10))
+		assert.Equal(t, 35.3147, Round(CubicFoot.FromCubicMetres(1), 4))
+	})
+
+	t.Run("it should parse the units and the systems", func(t *testing.T) {
+		l, err := ParseLengthUnit("in")
+		require.NoError(t, err)
+		assert.Equal(t, Inch, l)
+		m, err := ParseMassUnit("lb")
+		require.NoError(t, err)
+		assert.Equal(t, Pound, m)
+		s, err := ParseSystem("")
+		require.NoError(t, err)
+		assert.Equal(t, Metric, s)
+
+		_, err = ParseLengthUnit("ft")
+		assert.ErrorIs(t, err, ErrUnknownUnit)
+		_, err = ParseMassUnit("oz")
+		assert.ErrorIs(t, err, ErrUnknownUnit)
+		_, err = ParseSystem("us")
+		assert.ErrorIs(t, err, ErrUnknownUnit)
+	})
+
+	t.Run("it should pick the units of a system", func(t *testing.T) {
+		assert.Equal(t, []interface{}{Centimetre, Kilogram, CubicMetre}, []interface{}{Metric.Length(), Metric.Mass(), Metric.Volume()})
+		assert.Equal(t, []interface{}{Inch, Pound, CubicFoot}, []interface{}{Imperial.Length(), Imperial.Mass(), Imperial.Volume()})
+	})
+}
@@ -0,0 +1,32 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated(t *testing.T) {
+	t.Run("it should announce the deprecation, the sunset and the successor", func(t *testing.T) {
+		// Arrange
+		since := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+		sunset := time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+		r := gin.New()
+		r.GET("/old", Deprecated(since, sunset, "/new"), func(c *gin.Context) { c.Status(http.StatusOK) })
+		request := httptest.NewRequest(http.MethodGet, "/old", nil)
+		response := httptest.NewRecorder()
+
+		// Act
+		r.ServeHTTP(response, request)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "@1792281600", response.Header().Get("Deprecation"))
+		assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", response.Header().Get("Sunset"))
+		assert.Equal(t, `</new>; rel="successor-version"`, response.Header().Get("Link"))
+	})
+}
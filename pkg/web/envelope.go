@@ -0,0 +1,60 @@
+package web
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Envelope is the body of every successful /api/v2 response.
+type Envelope struct {
+	Data  interface{} `json:"data" validate:"required"`
+	Meta  Meta        `json:"meta"`
+	Links Links       `json:"links"`
+}
+
+// Meta carries information about the data of an Envelope.
+type Meta struct {
+	// Count is the number of items in data. It is only set for collections.
+	Count *int `json:"count,omitempty"`
+}
+
+// Links carries the hypermedia links of an Envelope.
+type Links struct {
+	Self string `json:"self" validate:"required"`
+	// Next is the next page of a paginated collection, when there is one.
+	Next string `json:"next,omitempty"`
+}
+
+// Resource writes a single resource identified by self.
+func Resource(c *gin.Context, status int, data interface{}, self string) {
+	Response(c, status, Envelope{Data: data, Links: Links{Self: self}})
+}
+
+// Collection writes the items of a collection with a 200 status code. A nil
+// slice is written as an empty list. The request may ask for the items as a
+// CSV or XLSX table instead, see Table.
+func Collection(c *gin.Context, items interface{}) {
+	Page(c, items, "")
+}
+
+// Page writes a page of a collection like Collection, linking to the next
+// page when next is not empty.
+func Page(c *gin.Context, items interface{}, next string) {
+	if Table(c, items) {
+		return
+	}
+
+	v := reflect.ValueOf(items)
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		items = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+
+	count := v.Len()
+	Response(c, http.StatusOK, Envelope{
+		Data:  items,
+		Meta:  Meta{Count: &count},
+		Links: Links{Self: c.Request.URL.RequestURI(), Next: next},
+	})
+}
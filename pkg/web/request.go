@@ -0,0 +1,83 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers identifying a request and who made it. There is no authentication
+// yet, so the actor is whoever the client says it is.
+const (
+	HeaderRequestID = "X-Request-ID"
+	HeaderActor     = "X-Actor"
+)
+
+// AnonymousActor is the actor of the requests without an X-Actor header.
+const AnonymousActor = "anonymous"
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	actorKey
+)
+
+// RequestMeta returns a middleware that stores the request ID and the actor
+// of every request in its context, where RequestIDOf and ActorOf find them.
+// The request ID is taken from the X-Request-ID header, or generated when it
+// is missing, and echoed in the response.
+//
+// Services given the *gin.Context itself only see these values when the
+// engine has ContextWithFallback set.
+func RequestMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if id == "" {
+			id = newRequestID()
+		}
+		actor := c.GetHeader(HeaderActor)
+		if actor == "" {
+			actor = AnonymousActor
+		}
+
+		ctx := WithActor(WithRequestID(c.Request.Context(), id), actor)
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(HeaderRequestID, id)
+		c.Next()
+	}
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDOf returns the request ID carried by ctx, or "" if there is none.
+func RequestIDOf(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithActor returns a copy of ctx carrying the actor of the request.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorOf returns the actor carried by ctx, or AnonymousActor if there is
+// none.
+func ActorOf(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// newRequestID returns 16 random bytes in hex.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,49 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestMeta(t *testing.T) {
+	serve := func(request *http.Request) (*httptest.ResponseRecorder, context.Context) {
+		var ctx context.Context
+		r := gin.New()
+		r.ContextWithFallback = true
+		r.Use(RequestMeta())
+		r.GET("/", func(c *gin.Context) { ctx = c })
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, request)
+		return response, ctx
+	}
+
+	t.Run("it should take the request ID and the actor from the headers", func(t *testing.T) {
+		// Arrange
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(HeaderRequestID, "4bf92f3577b34da6")
+		request.Header.Set(HeaderActor, "jdoe")
+
+		// Act
+		response, ctx := serve(request)
+
+		// Assert
+		assert.Equal(t, "4bf92f3577b34da6", RequestIDOf(ctx))
+		assert.Equal(t, "jdoe", ActorOf(ctx))
+		assert.Equal(t, "4bf92f3577b34da6", response.Header().Get(HeaderRequestID))
+	})
+
+	t.Run("it should generate a request ID and default to the anonymous actor", func(t *testing.T) {
+		// Act
+		response, ctx := serve(httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Assert
+		assert.Len(t, RequestIDOf(ctx), 32)
+		assert.Equal(t, AnonymousActor, ActorOf(ctx))
+		assert.Equal(t, RequestIDOf(ctx), response.Header().Get(HeaderRequestID))
+	})
+}
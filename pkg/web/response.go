@@ -8,14 +8,28 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-type response struct {
-	Data interface{} `json:"data"`
+// DataResponse is the envelope written by Success.
+type DataResponse struct {
+	Data interface{} `json:"data" validate:"required"`
 }
 
-type errorResponse struct {
+// ErrorResponse is the body written by Error.
+type ErrorResponse struct {
 	Status  int    `json:"-"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string `json:"code" validate:"required"`
+	Message string `json:"message" validate:"required"`
+}
+
+// MessageResponse documents the {"message": ...} bodies some handlers write
+// directly with gin.
+type MessageResponse struct {
+	Message string `json:"message" validate:"required"`
+}
+
+// ErrorMessageResponse documents the {"error": ...} bodies some handlers
+// write directly with gin.
+type ErrorMessageResponse struct {
+	Error string `json:"error" validate:"required"`
 }
 
 func Response(c *gin.Context, status int, data interface{}) {
@@ -23,13 +37,13 @@ func Response(c *gin.Context, status int, data interface{}) {
 }
 
 func Success(c *gin.Context, status int, data interface{}) {
-	Response(c, status, response{Data: data})
+	Response(c, status, DataResponse{Data: data})
 }
 
 // NewErrorf creates a new error with the given status code and the message
 // formatted according to args and format.
 func Error(c *gin.Context, status int, format string, args ...interface{}) {
-	err := errorResponse{
+	err := ErrorResponse{
 		Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
 		Message: fmt.Sprintf(format, args...),
 		Status:  status,
@@ -0,0 +1,72 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIMENDJSON is the media type of newline delimited JSON, a document per line.
+const MIMENDJSON = "application/x-ndjson"
+
+// Stream writes the rows each yields as NDJSON when the request asks for it
+// with format=ndjson or, without the format query parameter, with its Accept
+// header. It reports whether the request asked for a stream.
+//
+// Every row is written and flushed as soon as each yields it, so no more than
+// one row is held in memory: a slow client blocks each until it reads, and a
+// client that goes away cancels the context given to each, which stops it.
+// When each fails before the first row nothing is written and its error is
+// returned for the caller to answer; once rows were sent the status cannot
+// change, so the stream ends with an ErrorResponse line instead.
+func Stream[T any](c *gin.Context, each func(ctx context.Context, fn func(T) error) error) (bool, error) {
+	if !wantsStream(c) {
+		return false, nil
+	}
+
+	ctx := c.Request.Context()
+	enc := json.NewEncoder(c.Writer)
+	started := false
+	err := each(ctx, func(row T) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !started {
+			c.Header("Content-Type", MIMENDJSON)
+			c.Status(http.StatusOK)
+			started = true
+		}
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	switch {
+	case ctx.Err() != nil:
+		// The client is gone, there is no one to answer.
+		_ = c.Error(ctx.Err())
+		c.Abort()
+	case err != nil && !started:
+		return true, err
+	case err != nil:
+		_ = c.Error(err)
+		_ = enc.Encode(ErrorResponse{Code: "internal_server_error", Message: "internal server error"})
+	case !started:
+		c.Header("Content-Type", MIMENDJSON)
+		c.Status(http.StatusOK)
+		c.Writer.WriteHeaderNow()
+	}
+	return true, nil
+}
+
+// wantsStream reports whether the request asks for NDJSON.
+func wantsStream(c *gin.Context) bool {
+	if format := c.Query("format"); format != "" {
+		return format == "ndjson"
+	}
+	return c.NegotiateFormat(gin.MIMEJSON, MIMENDJSON) == MIMENDJSON
+}
@@ -0,0 +1,136 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamRow struct {
+	ID int `json:"id"`
+}
+
+// eachRow returns an iterator over n rows that fails with err after them.
+func eachRow(n int, err error) func(ctx context.Context, fn func(streamRow) error) error {
+	return func(ctx context.Context, fn func(streamRow) error) error {
+		for i := 1; i <= n; i++ {
+			if err := fn(streamRow{ID: i}); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+}
+
+func serveStream(t *testing.T, request *http.Request, each func(ctx context.Context, fn func(streamRow) error) error) (*httptest.ResponseRecorder, bool, error) {
+	t.Helper()
+	var streamed bool
+	var err error
+	r := gin.New()
+	r.GET("/rows", func(c *gin.Context) {
+		streamed, err = Stream(c, each)
+	})
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	return response, streamed, err
+}
+
+func TestStream(t *testing.T) {
+	t.Run("it should write a line per row when the Accept header asks for NDJSON", func(t *testing.T) {
+		// Arrange
+		request := httptest.NewRequest(http.MethodGet, "/rows", nil)
+		request.Header.Set("Accept", MIMENDJSON)
+
+		// Act
+		response, streamed, err := serveStream(t, request, eachRow(3, nil))
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, streamed)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, MIMENDJSON, response.Header().Get("Content-Type"))
+		assert.True(t, response.Flushed)
+		assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n", response.Body.String())
+	})
+
+	t.Run("it should write an empty stream when there are no rows", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/rows?format=ndjson", nil)
+
+		response, streamed, err := serveStream(t, request, eachRow(0, nil))
+
+		require.NoError(t, err)
+		assert.True(t, streamed)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, MIMENDJSON, response.Header().Get("Content-Type"))
+		assert.Empty(t, response.Body.String())
+	})
+
+	t.Run("it should not stream when the request does not ask for it", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/rows?format=json", nil)
+		request.Header.Set("Accept", MIMENDJSON)
+
+		_, streamed, err := serveStream(t, request, eachRow(3, nil))
+
+		require.NoError(t, err)
+		assert.False(t, streamed)
+	})
+
+	t.Run("it should return the error of an iterator that fails before the first row", func(t *testing.T) {
+		// Arrange
+		failure := errors.New("connection refused")
+		request := httptest.NewRequest(http.MethodGet, "/rows?format=ndjson", nil)
+
+		// Act
+		response, streamed, err := serveStream(t, request, eachRow(0, failure))
+
+		// Assert
+		assert.True(t, streamed)
+		assert.ErrorIs(t, err, failure)
+		assert.Empty(t, response.Body.String())
+	})
+
+	t.Run("it should end the stream with an error line when the iterator fails after a row", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/rows?format=ndjson", nil)
+
+		response, streamed, err := serveStream(t, request, eachRow(1, errors.New("connection reset")))
+
+		require.NoError(t, err)
+		assert.True(t, streamed)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "{\"id\":1}\n{\"code\":\"internal_server_error\",\"message\":\"internal server error\"}\n", response.Body.String())
+	})
+
+	t.Run("it should stop the iterator when the client goes away", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		request := httptest.NewRequest(http.MethodGet, "/rows?format=ndjson", nil).WithContext(ctx)
+		read := 0
+		each := func(ctx context.Context, fn func(streamRow) error) error {
+			for i := 1; i <= 100; i++ {
+				read++
+				if i == 2 {
+					cancel()
+				}
+				if err := fn(streamRow{ID: i}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		// Act
+		response, streamed, err := serveStream(t, request, each)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, streamed)
+		assert.Equal(t, 2, read)
+		assert.Equal(t, "{\"id\":1}\n", response.Body.String())
+	})
+}
@@ -0,0 +1,164 @@
+package web
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/davidop97/apiGo/pkg/table"
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+// Media types of the formats lists and reports can be exported in.
+const (
+	MIMECSV  = "text/csv"
+	MIMEXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ErrInvalidFormat is the message of the 400 response to an unknown format
+// query parameter.
+const ErrInvalidFormat = "format must be json, csv or xlsx"
+
+// now is the clock of the export filenames, replaced in tests.
+var now = time.Now
+
+// Table writes rows, a slice of structs or a single one, as a CSV or XLSX attachment when the
+// request asks for one with the format query parameter (json, csv or xlsx) or,
+// without it, with its Accept header. It reports whether it wrote a response,
+// which is also the case for an unknown format; the caller writes the JSON
+// response when it returns false.
+//
+// The columns are laid out by package table. The attachment is named after
+// the path of the request and the time of the export, such as
+// sections-product-reports-20240102T150405Z.csv.
+func Table(c *gin.Context, rows interface{}) bool {
+	format, ok := tableFormat(c)
+	if !ok {
+		Error(c, http.StatusBadRequest, ErrInvalidFormat)
+		return true
+	}
+	if format == "json" {
+		return false
+	}
+
+	t := table.New(rows)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, exportName(c.Request.URL.Path), format))
+	if format == "csv" {
+		writeCSV(c, t)
+	} else {
+		writeXLSX(c, t)
+	}
+	return true
+}
+
+// Report writes a single report like Resource, or as a table of one row when
+// the request asks for CSV or XLSX.
+func Report(c *gin.Context, data interface{}, self string) {
+	if Table(c, data) {
+		return
+	}
+	Resource(c, http.StatusOK, data, self)
+}
+
+// tableFormat returns the format the request asks for, and false when the
+// format query parameter is unknown.
+func tableFormat(c *gin.Context) (string, bool) {
+	switch format := c.Query("format"); format {
+	case "json", "csv", "xlsx":
+		return format, true
+	case "":
+	default:
+		return "", false
+	}
+
+	switch c.NegotiateFormat(gin.MIMEJSON, MIMECSV, MIMEXLSX) {
+	case MIMECSV:
+		return "csv", true
+	case MIMEXLSX:
+		return "xlsx", true
+	}
+	return "json", true
+}
+
+// exportName names the export of the resource at path after its segments
+// below the API version and the current time.
+func exportName(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 2 && segments[0] == "api" {
+		segments = segments[2:]
+	}
+	return strings.Join(segments, "-") + "-" + now().UTC().Format("20060102T150405Z")
+}
+
+// writeCSV streams the rows of t as CSV, a line per row after the header.
+func writeCSV(c *gin.Context, t *table.Table) {
+	c.Header("Content-Type", MIMECSV+"; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	header := t.Header()
+	_ = w.Write(header)
+	record := make([]string, len(header))
+	for i := 0; i < t.Len(); i++ {
+		for j, value := range t.Row(i) {
+			record[j] = table.Cell(value)
+		}
+		if err := w.Write(record); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// writeXLSX writes the rows of t to the first sheet of an XLSX workbook. The
+// rows go through the StreamWriter of excelize, which keeps at most
+// excelize.StreamChunkSize bytes of the sheet in memory and the rest in a
+// temporary file, removed by Close. The workbook is then zipped straight
+// into the response, never into a buffer; the sheet must be complete first
+// because it is a single entry of the zip.
+func writeXLSX(c *gin.Context, t *table.Table) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	err := func() error {
+		sw, err := f.NewStreamWriter("Sheet1")
+		if err != nil {
+			return err
+		}
+		header := make([]interface{}, 0, len(t.Header()))
+		for _, name := range t.Header() {
+			header = append(header, name)
+		}
+		if err := sw.SetRow("A1", header); err != nil {
+			return err
+		}
+		for i := 0; i < t.Len(); i++ {
+			cell, err := excelize.CoordinatesToCellName(1, i+2)
+			if err != nil {
+				return err
+			}
+			if err := sw.SetRow(cell, t.Row(i)); err != nil {
+				return err
+			}
+		}
+		return sw.Flush()
+	}()
+	if err != nil {
+		c.Header("Content-Disposition", "")
+		Error(c, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	c.Header("Content-Type", MIMEXLSX)
+	c.Status(http.StatusOK)
+	if err := f.Write(c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,120 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type tableAudit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type tableRow struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Secret string  `json:"-"`
+	Price  float64 `json:"price"`
+	Stock  *int    `json:"stock,omitempty"`
+	hidden string
+	tableAudit
+}
+
+func serveTable(t *testing.T, target, accept string, rows interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	r := gin.New()
+	r.GET("/api/v2/sections/product-reports", func(c *gin.Context) {
+		if !Table(c, rows) {
+			Resource(c, http.StatusOK, rows, "/sections/product-reports")
+		}
+	})
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	return response
+}
+
+func TestTable(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2026, time.October, 18, 15, 4, 5, 0, time.FixedZone("ART", -3*60*60))
+	}
+	t.Cleanup(func() { now = time.Now })
+
+	stock := 7
+	created := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+	rows := []tableRow{
+		{ID: 1, Name: "Meli, Inc", Secret: "x", Price: 10.5, Stock: &stock, tableAudit: tableAudit{CreatedAt: created}},
+		{ID: 2, Name: "Mercado", Price: 3},
+	}
+
+	t.Run("it should stream CSV in the declared column order when the Accept header asks for it", func(t *testing.T) {
+		// Act
+		response := serveTable(t, "/api/v2/sections/product-reports", "text/csv", rows)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", response.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="sections-product-reports-20261018T180405Z.csv"`, response.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,name,price,stock,created_at\n"+
+			"1,\"Meli, Inc\",10.5,7,2026-01-02T03:04:05Z\n"+
+			"2,Mercado,3,,0001-01-01T00:00:00Z\n", response.Body.String())
+	})
+
+	t.Run("it should prefer the format query parameter over the Accept header", func(t *testing.T) {
+		response := serveTable(t, "/api/v2/sections/product-reports?format=json", "text/csv", rows)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Header().Get("Content-Type"), "application/json")
+		assert.Empty(t, response.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("it should write a single row for a single report", func(t *testing.T) {
+		response := serveTable(t, "/api/v2/sections/product-reports?format=csv", "", rows[1])
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "id,name,price,stock,created_at\n2,Mercado,3,,0001-01-01T00:00:00Z\n", response.Body.String())
+	})
+
+	t.Run("it should write a workbook with a header row when the format is xlsx", func(t *testing.T) {
+		// Act
+		response := serveTable(t, "/api/v2/sections/product-reports?format=xlsx", "", rows)
+
+		// Assert
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, MIMEXLSX, response.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="sections-product-reports-20261018T180405Z.xlsx"`, response.Header().Get("Content-Disposition"))
+		f, err := excelize.OpenReader(response.Body)
+		require.NoError(t, err)
+		defer f.Close()
+		obtained, err := f.GetRows("Sheet1")
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"id", "name", "price", "stock", "created_at"},
+			{"1", "Meli, Inc", "10.5", "7", "2026-01-02T03:04:05Z"},
+			{"2", "Mercado", "3", "", "0001-01-01T00:00:00Z"},
+		}, obtained)
+	})
+
+	t.Run("it should return 400 when the format is unknown", func(t *testing.T) {
+		response := serveTable(t, "/api/v2/sections/product-reports?format=pdf", "", rows)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"format must be json, csv or xlsx"}`, response.Body.String())
+	})
+
+	t.Run("it should write JSON when the Accept header does not ask for a table", func(t *testing.T) {
+		response := serveTable(t, "/api/v2/sections/product-reports", "application/json", rows)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Empty(t, response.Header().Get("Content-Disposition"))
+	})
+}